@@ -15,6 +15,7 @@ package accountcreate
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	"github.com/aaron-alderman/ethdo/util"
@@ -25,6 +26,7 @@ import (
 )
 
 type dataIn struct {
+	format  string
 	timeout time.Duration
 	// For all accounts.
 	wallet           e2wtypes.Wallet
@@ -34,6 +36,10 @@ type dataIn struct {
 	// For distributed accounts.
 	participants     uint32
 	signingThreshold uint32
+	// For locally-generated distributed accounts.
+	distributedLocal       bool
+	outputDir              string
+	participantPassphrases []string
 	// For pathed accounts.
 	path string
 }
@@ -46,26 +52,40 @@ func input(ctx context.Context) (*dataIn, error) {
 		return nil, errors.New("timeout is required")
 	}
 	data.timeout = viper.GetDuration("timeout")
+	data.format = util.OutputFormat()
 
 	// Account name.
 	if viper.GetString("account") == "" {
 		return nil, errors.New("account is required")
 	}
-	_, data.accountName, err = e2wallet.WalletAndAccountNames(viper.GetString("account"))
+	walletName, accountName, err := e2wallet.WalletAndAccountNames(viper.GetString("account"))
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to obtain account name")
 	}
+	data.accountName = accountName
+	data.distributedLocal = viper.GetBool("distributed-local")
+	if data.distributedLocal && data.accountName == "" {
+		// Locally-generated accounts are not stored in a wallet, so a plain name is acceptable.
+		data.accountName = walletName
+	}
 	if data.accountName == "" {
 		return nil, errors.New("account name is required")
 	}
 
-	// Wallet.
-	ctx, cancel := context.WithTimeout(ctx, data.timeout)
-	defer cancel()
-	data.wallet, err = util.WalletFromInput(ctx)
-	cancel()
-	if err != nil {
-		return nil, errors.Wrap(err, "failed to obtain wallet")
+	if data.distributedLocal {
+		data.outputDir = viper.GetString("output-dir")
+		if data.outputDir == "" {
+			return nil, errors.New("output directory is required for locally-generated distributed accounts")
+		}
+	} else {
+		// Wallet.
+		ctx, cancel := context.WithTimeout(ctx, data.timeout)
+		defer cancel()
+		data.wallet, err = util.WalletFromInput(ctx)
+		cancel()
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to obtain wallet")
+		}
 	}
 
 	// Passphrase.
@@ -88,6 +108,21 @@ func input(ctx context.Context) (*dataIn, error) {
 		return nil, errors.New("signing threshold must be at least one")
 	}
 	data.signingThreshold = viper.GetUint32("signing-threshold")
+	if data.distributedLocal && data.participants < 2 {
+		return nil, errors.New("locally-generated distributed accounts require at least two participants")
+	}
+
+	// Participant passphrases.
+	if data.distributedLocal {
+		// Each share has its own passphrase, so that no participant can decrypt the others' shares.
+		if data.passphrase != "" {
+			return nil, errors.New("passphrase cannot be used for locally-generated distributed accounts; supply participant-passphrase once for each participant")
+		}
+		data.participantPassphrases = viper.GetStringSlice("participant-passphrase")
+		if uint32(len(data.participantPassphrases)) != data.participants {
+			return nil, fmt.Errorf("participant-passphrase must be supplied once for each of the %d participants", data.participants)
+		}
+	}
 
 	// Path.
 	data.path = viper.GetString("path")
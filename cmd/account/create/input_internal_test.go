@@ -119,6 +119,78 @@ func TestInput(t *testing.T) {
 			},
 			err: "signing threshold must be at least one",
 		},
+		{
+			name: "DistributedLocalOutputDirMissing",
+			vars: map[string]interface{}{
+				"timeout":           "5s",
+				"account":           "Test account",
+				"passphrase":        "ce%NohGhah4ye5ra",
+				"participants":      3,
+				"signing-threshold": 2,
+				"distributed-local": true,
+			},
+			err: "output directory is required for locally-generated distributed accounts",
+		},
+		{
+			name: "DistributedLocalParticipantsTooFew",
+			vars: map[string]interface{}{
+				"timeout":           "5s",
+				"account":           "Test account",
+				"passphrase":        "ce%NohGhah4ye5ra",
+				"participants":      1,
+				"signing-threshold": 1,
+				"distributed-local": true,
+				"output-dir":        "shares",
+			},
+			err: "locally-generated distributed accounts require at least two participants",
+		},
+		{
+			name: "DistributedLocalPassphrase",
+			vars: map[string]interface{}{
+				"timeout":           "5s",
+				"account":           "Test account",
+				"passphrase":        "ce%NohGhah4ye5ra",
+				"participants":      3,
+				"signing-threshold": 2,
+				"distributed-local": true,
+				"output-dir":        "shares",
+			},
+			err: "passphrase cannot be used for locally-generated distributed accounts; supply participant-passphrase once for each participant",
+		},
+		{
+			name: "DistributedLocalParticipantPassphrasesMissing",
+			vars: map[string]interface{}{
+				"timeout":                "5s",
+				"account":                "Test account",
+				"participants":           3,
+				"signing-threshold":      2,
+				"distributed-local":      true,
+				"output-dir":             "shares",
+				"participant-passphrase": []string{"ce%NohGhah4ye5ra", "pa%NohGhah4ye5rb"},
+			},
+			err: "participant-passphrase must be supplied once for each of the 3 participants",
+		},
+		{
+			name: "DistributedLocalGood",
+			vars: map[string]interface{}{
+				"timeout":                "5s",
+				"account":                "Test account",
+				"participants":           3,
+				"signing-threshold":      2,
+				"distributed-local":      true,
+				"output-dir":             "shares",
+				"participant-passphrase": []string{"ce%NohGhah4ye5ra", "pa%NohGhah4ye5rb", "ra%NohGhah4ye5rc"},
+			},
+			res: &dataIn{
+				timeout:                5 * time.Second,
+				accountName:            "Test account",
+				participants:           3,
+				signingThreshold:       2,
+				distributedLocal:       true,
+				outputDir:              "shares",
+				participantPassphrases: []string{"ce%NohGhah4ye5ra", "pa%NohGhah4ye5rb", "ra%NohGhah4ye5rc"},
+			},
+		},
 		{
 			name: "Good",
 			vars: map[string]interface{}{
@@ -155,6 +227,9 @@ func TestInput(t *testing.T) {
 				require.Equal(t, test.res.passphrase, res.passphrase)
 				require.Equal(t, test.res.participants, res.participants)
 				require.Equal(t, test.res.signingThreshold, res.signingThreshold)
+				require.Equal(t, test.res.distributedLocal, res.distributedLocal)
+				require.Equal(t, test.res.outputDir, res.outputDir)
+				require.Equal(t, test.res.participantPassphrases, res.participantPassphrases)
 			}
 		})
 	}
@@ -16,30 +16,78 @@ package accountcreate
 import (
 	"context"
 	"fmt"
+	"strings"
 
+	"github.com/aaron-alderman/ethdo/util"
 	"github.com/pkg/errors"
 	e2wtypes "github.com/wealdtech/go-eth2-wallet-types/v2"
 )
 
 type dataOut struct {
+	format  string
 	account e2wtypes.Account
+	// For locally-generated distributed accounts.
+	compositePubKey []byte
+	files           []string
+}
+
+type accountJSON struct {
+	PublicKey string   `json:"public_key"`
+	Files     []string `json:"files,omitempty"`
+}
+
+// distributedAccountJSON is the information about a locally-generated distributed account
+// that allows its shares to be verified.
+type distributedAccountJSON struct {
+	Name               string             `json:"name"`
+	CompositePubKey    string             `json:"composite_pubkey"`
+	SigningThreshold   uint32             `json:"signing_threshold"`
+	Participants       []*participantJSON `json:"participants"`
+	VerificationVector []string           `json:"verification_vector"`
+}
+
+type participantJSON struct {
+	ID     uint64 `json:"id"`
+	PubKey string `json:"pubkey"`
 }
 
 func output(ctx context.Context, data *dataOut) (string, error) {
 	if data == nil {
 		return "", errors.New("no data")
 	}
+	if data.compositePubKey != nil {
+		return outputDistributedLocal(data)
+	}
 	if data.account == nil {
 		return "", errors.New("no account")
 	}
 
+	var pubKey string
 	if pubKeyProvider, ok := data.account.(e2wtypes.AccountCompositePublicKeyProvider); ok {
-		return fmt.Sprintf("%#x", pubKeyProvider.CompositePublicKey().Marshal()), nil
+		pubKey = fmt.Sprintf("%#x", pubKeyProvider.CompositePublicKey().Marshal())
+	} else if pubKeyProvider, ok := data.account.(e2wtypes.AccountPublicKeyProvider); ok {
+		pubKey = fmt.Sprintf("%#x", pubKeyProvider.PublicKey().Marshal())
+	} else {
+		return "", errors.New("no public key available")
+	}
+
+	if util.StructuredFormat(data.format) {
+		return util.FormatOutput(data.format, &accountJSON{
+			PublicKey: pubKey,
+		})
 	}
 
-	if pubKeyProvider, ok := data.account.(e2wtypes.AccountPublicKeyProvider); ok {
-		return fmt.Sprintf("%#x", pubKeyProvider.PublicKey().Marshal()), nil
+	return pubKey, nil
+}
+
+func outputDistributedLocal(data *dataOut) (string, error) {
+	pubKey := fmt.Sprintf("%#x", data.compositePubKey)
+	if util.StructuredFormat(data.format) {
+		return util.FormatOutput(data.format, &accountJSON{
+			PublicKey: pubKey,
+			Files:     data.files,
+		})
 	}
 
-	return "", errors.New("no public key available")
+	return strings.Join(append([]string{pubKey}, data.files...), "\n"), nil
 }
@@ -97,6 +97,23 @@ func TestOutput(t *testing.T) {
 			},
 			res: "0x876dd4705157eb66dc71bc2e07fb151ea53e1a62a0bb980a7ce72d15f58944a8a3752d754f52f4a60dbfc7b18169f268",
 		},
+		{
+			name: "DistributedLocal",
+			dataOut: &dataOut{
+				compositePubKey: []byte{0x01, 0x02},
+				files:           []string{"shares/Test-1.json", "shares/Test-distributed.json"},
+			},
+			res: "0x0102\nshares/Test-1.json\nshares/Test-distributed.json",
+		},
+		{
+			name: "DistributedLocalJSON",
+			dataOut: &dataOut{
+				format:          "json",
+				compositePubKey: []byte{0x01, 0x02},
+				files:           []string{"shares/Test-1.json", "shares/Test-distributed.json"},
+			},
+			res: `{"public_key":"0x0102","files":["shares/Test-1.json","shares/Test-distributed.json"]}`,
+		},
 	}
 
 	for _, test := range tests {
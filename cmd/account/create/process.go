@@ -15,6 +15,11 @@ package accountcreate
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"regexp"
 
 	"github.com/aaron-alderman/ethdo/util"
@@ -29,6 +34,9 @@ func process(ctx context.Context, data *dataIn) (*dataOut, error) {
 	if data.passphrase != "" && !util.AcceptablePassphrase(data.passphrase) {
 		return nil, errors.New("supplied passphrase is weak; use a stronger one or run with the --allow-weak-passphrases flag")
 	}
+	if data.distributedLocal {
+		return processDistributedLocal(ctx, data)
+	}
 	locker, isLocker := data.wallet.(e2wtypes.WalletLocker)
 	if isLocker {
 		if err := locker.Unlock(ctx, []byte(data.walletPassphrase)); err != nil {
@@ -63,7 +71,9 @@ func processStandard(ctx context.Context, data *dataIn) (*dataOut, error) {
 		return nil, errors.New("passphrase is required")
 	}
 
-	results := &dataOut{}
+	results := &dataOut{
+		format: data.format,
+	}
 
 	creator, isCreator := data.wallet.(e2wtypes.WalletAccountCreator)
 	if !isCreator {
@@ -94,7 +104,9 @@ func processPathed(ctx context.Context, data *dataIn) (*dataOut, error) {
 		return nil, errors.New("path does not match expected format m/…")
 	}
 
-	results := &dataOut{}
+	results := &dataOut{
+		format: data.format,
+	}
 
 	creator, isCreator := data.wallet.(e2wtypes.WalletPathedAccountCreator)
 	if !isCreator {
@@ -115,17 +127,13 @@ func processDistributed(ctx context.Context, data *dataIn) (*dataOut, error) {
 	if data == nil {
 		return nil, errors.New("no data")
 	}
-	if data.signingThreshold == 0 {
-		return nil, errors.New("signing threshold required")
-	}
-	if data.signingThreshold <= data.participants/2 {
-		return nil, errors.New("signing threshold must be more than half the number of participants")
-	}
-	if data.signingThreshold > data.participants {
-		return nil, errors.New("signing threshold cannot be higher than the number of participants")
+	if err := checkSigningThreshold(data); err != nil {
+		return nil, err
 	}
 
-	results := &dataOut{}
+	results := &dataOut{
+		format: data.format,
+	}
 
 	creator, isCreator := data.wallet.(e2wtypes.WalletDistributedAccountCreator)
 	if !isCreator {
@@ -145,3 +153,128 @@ func processDistributed(ctx context.Context, data *dataIn) (*dataOut, error) {
 	results.account = account
 	return results, nil
 }
+
+// processDistributedLocal generates a distributed account locally, writing a keystore for each
+// participant's share along with the details required to verify the shares.
+func processDistributedLocal(_ context.Context, data *dataIn) (*dataOut, error) {
+	if data == nil {
+		return nil, errors.New("no data")
+	}
+	if uint32(len(data.participantPassphrases)) != data.participants {
+		return nil, errors.New("a passphrase is required for each participant")
+	}
+	seen := make(map[string]bool, len(data.participantPassphrases))
+	for i, passphrase := range data.participantPassphrases {
+		if !util.AcceptablePassphrase(passphrase) {
+			return nil, fmt.Errorf("passphrase for participant %d is weak; use a stronger one or run with the --allow-weak-passphrases flag", i+1)
+		}
+		if seen[passphrase] {
+			return nil, fmt.Errorf("passphrase for participant %d is the same as that of another participant", i+1)
+		}
+		seen[passphrase] = true
+	}
+	if err := checkSigningThreshold(data); err != nil {
+		return nil, err
+	}
+
+	keys, err := util.GenerateThresholdKeys(data.participants, data.signingThreshold)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to generate keys")
+	}
+
+	if err := os.MkdirAll(data.outputDir, 0700); err != nil {
+		return nil, errors.Wrap(err, "failed to create output directory")
+	}
+
+	// The account name is used in filenames, so must not be able to escape the output directory.
+	baseName := filenameSafe(data.accountName)
+	results := &dataOut{
+		format:          data.format,
+		compositePubKey: keys.CompositePublicKey.Serialize(),
+		files:           make([]string, 0, data.participants+1),
+	}
+
+	account := &distributedAccountJSON{
+		Name:               data.accountName,
+		CompositePubKey:    fmt.Sprintf("%#x", keys.CompositePublicKey.Serialize()),
+		SigningThreshold:   data.signingThreshold,
+		Participants:       make([]*participantJSON, 0, data.participants),
+		VerificationVector: make([]string, len(keys.VerificationVector)),
+	}
+	for i := range keys.VerificationVector {
+		account.VerificationVector[i] = fmt.Sprintf("%#x", keys.VerificationVector[i].Serialize())
+	}
+
+	for id := uint64(1); id <= uint64(data.participants); id++ {
+		keystore, err := util.NewThresholdKeystore(keys, id, data.accountName, data.participantPassphrases[id-1])
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to create keystore")
+		}
+		keystoreData, err := json.Marshal(keystore)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to generate keystore")
+		}
+		filename := filepath.Join(data.outputDir, fmt.Sprintf("%s-%d.json", baseName, id))
+		if err := ioutil.WriteFile(filename, keystoreData, 0600); err != nil {
+			return nil, errors.Wrap(err, "failed to write keystore")
+		}
+		results.files = append(results.files, filename)
+		account.Participants = append(account.Participants, &participantJSON{
+			ID:     id,
+			PubKey: fmt.Sprintf("%#x", keys.Shares[id].GetPublicKey().Serialize()),
+		})
+	}
+
+	accountData, err := json.Marshal(account)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to generate account information")
+	}
+	filename := filepath.Join(data.outputDir, fmt.Sprintf("%s-distributed.json", baseName))
+	if err := ioutil.WriteFile(filename, accountData, 0600); err != nil {
+		return nil, errors.Wrap(err, "failed to write account information")
+	}
+	results.files = append(results.files, filename)
+
+	pubKeys := make([][]byte, 0, data.participants+1)
+	pubKeys = append(pubKeys, keys.CompositePublicKey.Serialize())
+	for id := uint64(1); id <= uint64(data.participants); id++ {
+		pubKeys = append(pubKeys, keys.Shares[id].GetPublicKey().Serialize())
+	}
+	if err := util.AuditExport(fmt.Sprintf("%d-of-%d distributed account %s generated locally", data.signingThreshold, data.participants, data.accountName), pubKeys...); err != nil {
+		return nil, errors.Wrap(err, "failed to record export in audit log")
+	}
+
+	return results, nil
+}
+
+// filenameSafe returns a version of the name that can be used as a filename, replacing
+// path separators and other unusual characters.
+func filenameSafe(name string) string {
+	res := []rune(name)
+	for i, r := range res {
+		if !(r >= 'a' && r <= 'z') && !(r >= 'A' && r <= 'Z') && !(r >= '0' && r <= '9') && r != '-' && r != '_' && r != '.' && r != ' ' {
+			res[i] = '_'
+		}
+	}
+	// Leading periods would allow names such as "..".
+	for i := 0; i < len(res) && res[i] == '.'; i++ {
+		res[i] = '_'
+	}
+
+	return string(res)
+}
+
+// checkSigningThreshold checks that the signing threshold is suitable for the number of participants.
+func checkSigningThreshold(data *dataIn) error {
+	if data.signingThreshold == 0 {
+		return errors.New("signing threshold required")
+	}
+	if data.signingThreshold <= data.participants/2 {
+		return errors.New("signing threshold must be more than half the number of participants")
+	}
+	if data.signingThreshold > data.participants {
+		return errors.New("signing threshold cannot be higher than the number of participants")
+	}
+
+	return nil
+}
@@ -17,12 +17,15 @@ import (
 	"context"
 	"crypto/tls"
 	"crypto/x509"
+	"encoding/json"
 	"fmt"
 	"math/rand"
 	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
+	"github.com/aaron-alderman/ethdo/util"
 	"github.com/attestantio/dirk/testing/daemon"
 	"github.com/attestantio/dirk/testing/resources"
 	"github.com/pkg/errors"
@@ -283,6 +286,133 @@ func TestProcess(t *testing.T) {
 	}
 }
 
+func TestProcessDistributedLocal(t *testing.T) {
+	require.NoError(t, e2types.InitBLS())
+
+	passphrases := []string{"ce%NohGhah4ye5ra", "pa%NohGhah4ye5rb", "ra%NohGhah4ye5rc"}
+
+	tests := []struct {
+		name     string
+		dataIn   *dataIn
+		filename string
+		err      string
+	}{
+		{
+			name: "PassphrasesMissing",
+			dataIn: &dataIn{
+				accountName:      "Test",
+				participants:     3,
+				signingThreshold: 2,
+				distributedLocal: true,
+			},
+			err: "a passphrase is required for each participant",
+		},
+		{
+			name: "PassphraseWeak",
+			dataIn: &dataIn{
+				accountName:            "Test",
+				participants:           3,
+				signingThreshold:       2,
+				distributedLocal:       true,
+				participantPassphrases: []string{"ce%NohGhah4ye5ra", "poor", "ra%NohGhah4ye5rc"},
+			},
+			err: "passphrase for participant 2 is weak; use a stronger one or run with the --allow-weak-passphrases flag",
+		},
+		{
+			name: "PassphraseDuplicate",
+			dataIn: &dataIn{
+				accountName:            "Test",
+				participants:           3,
+				signingThreshold:       2,
+				distributedLocal:       true,
+				participantPassphrases: []string{"ce%NohGhah4ye5ra", "pa%NohGhah4ye5rb", "ce%NohGhah4ye5ra"},
+			},
+			err: "passphrase for participant 3 is the same as that of another participant",
+		},
+		{
+			name: "SigningThresholdNotHalf",
+			dataIn: &dataIn{
+				accountName:            "Test",
+				participants:           4,
+				signingThreshold:       2,
+				distributedLocal:       true,
+				participantPassphrases: append([]string{"wa%NohGhah4ye5rd"}, passphrases...),
+			},
+			err: "signing threshold must be more than half the number of participants",
+		},
+		{
+			name: "Good",
+			dataIn: &dataIn{
+				accountName:            "Test",
+				participants:           3,
+				signingThreshold:       2,
+				distributedLocal:       true,
+				participantPassphrases: passphrases,
+			},
+			filename: "Test",
+		},
+		{
+			name: "NameWithPath",
+			dataIn: &dataIn{
+				accountName:            "../../Test/x",
+				participants:           3,
+				signingThreshold:       2,
+				distributedLocal:       true,
+				participantPassphrases: passphrases,
+			},
+			filename: "___.._Test_x",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			test.dataIn.outputDir = t.TempDir()
+			res, err := process(context.Background(), test.dataIn)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, res.files, int(test.dataIn.participants)+1)
+
+			for id := uint64(1); id <= uint64(test.dataIn.participants); id++ {
+				data, err := os.ReadFile(filepath.Join(test.dataIn.outputDir, fmt.Sprintf("%s-%d.json", test.filename, id)))
+				require.NoError(t, err)
+				keystore := &util.ThresholdKeystore{}
+				require.NoError(t, json.Unmarshal(data, keystore))
+				require.Equal(t, id, keystore.ShareID)
+				require.Equal(t, fmt.Sprintf("%x", res.compositePubKey), keystore.CompositePubKey)
+				require.NoError(t, keystore.Verify())
+				// Each share can only be decrypted with its own participant's passphrase.
+				for i, passphrase := range test.dataIn.participantPassphrases {
+					_, err = keystore.SecretKey(passphrase)
+					if uint64(i+1) == id {
+						require.NoError(t, err)
+					} else {
+						require.Error(t, err)
+					}
+				}
+			}
+
+			data, err := os.ReadFile(filepath.Join(test.dataIn.outputDir, test.filename+"-distributed.json"))
+			require.NoError(t, err)
+			account := &distributedAccountJSON{}
+			require.NoError(t, json.Unmarshal(data, account))
+			require.Equal(t, test.dataIn.accountName, account.Name)
+			require.Equal(t, fmt.Sprintf("%#x", res.compositePubKey), account.CompositePubKey)
+			require.Len(t, account.Participants, int(test.dataIn.participants))
+			require.Len(t, account.VerificationVector, int(test.dataIn.signingThreshold))
+		})
+	}
+}
+
+func TestFilenameSafe(t *testing.T) {
+	require.Equal(t, "validator 1", filenameSafe("validator 1"))
+	require.Equal(t, "__", filenameSafe(".."))
+	require.Equal(t, "_etc_passwd", filenameSafe("/etc/passwd"))
+	require.Equal(t, "a_b_c", filenameSafe("a\\b:c"))
+}
+
 func TestNilData(t *testing.T) {
 	_, err := processStandard(context.Background(), nil)
 	require.EqualError(t, err, "no data")
@@ -290,6 +420,8 @@ func TestNilData(t *testing.T) {
 	require.EqualError(t, err, "no data")
 	_, err = processDistributed(context.Background(), nil)
 	require.EqualError(t, err, "no data")
+	_, err = processDistributedLocal(context.Background(), nil)
+	require.EqualError(t, err, "no data")
 }
 
 func credentialsFromCerts(ctx context.Context, clientCert []byte, clientKey []byte, caCert []byte) (credentials.TransportCredentials, error) {
@@ -16,6 +16,7 @@ package accountcreate
 import (
 	"context"
 
+	"github.com/aaron-alderman/ethdo/util"
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
@@ -37,7 +38,8 @@ func Run(cmd *cobra.Command) (string, error) {
 		return "", errors.Wrap(err, "failed to process")
 	}
 
-	if !viper.GetBool("verbose") {
+	// Text output is only provided when verbose, but structured output is always provided.
+	if !viper.GetBool("verbose") && !util.StructuredFormat(util.OutputFormat()) {
 		return "", nil
 	}
 
@@ -16,12 +16,14 @@ package accountderive
 import (
 	"context"
 
+	"github.com/aaron-alderman/ethdo/util"
 	"github.com/pkg/errors"
 	"github.com/spf13/viper"
 )
 
 type dataIn struct {
-	quiet bool
+	format string
+	quiet  bool
 	// Derivation information.
 	mnemonic string
 	path     string
@@ -35,6 +37,7 @@ func input(ctx context.Context) (*dataIn, error) {
 
 	// Quiet.
 	data.quiet = viper.GetBool("quiet")
+	data.format = util.OutputFormat()
 
 	// Mnemonic.
 	if viper.GetString("mnemonic") == "" {
@@ -18,17 +18,25 @@ import (
 	"fmt"
 	"strings"
 
+	ethdoutil "github.com/aaron-alderman/ethdo/util"
 	"github.com/pkg/errors"
 	e2types "github.com/wealdtech/go-eth2-types/v2"
 	util "github.com/wealdtech/go-eth2-util"
 )
 
 type dataOut struct {
+	format                    string
 	showPrivateKey            bool
 	showWithdrawalCredentials bool
 	key                       *e2types.BLSPrivateKey
 }
 
+type keyJSON struct {
+	PrivateKey            string `json:"private_key,omitempty"`
+	PublicKey             string `json:"public_key"`
+	WithdrawalCredentials string `json:"withdrawal_credentials,omitempty"`
+}
+
 func output(ctx context.Context, data *dataOut) (string, error) {
 	if data == nil {
 		return "", errors.New("no data")
@@ -37,6 +45,10 @@ func output(ctx context.Context, data *dataOut) (string, error) {
 		return "", errors.New("no key")
 	}
 
+	if ethdoutil.StructuredFormat(data.format) {
+		return outputStructured(data)
+	}
+
 	builder := strings.Builder{}
 
 	if data.showPrivateKey {
@@ -44,10 +56,29 @@ func output(ctx context.Context, data *dataOut) (string, error) {
 	}
 	builder.WriteString(fmt.Sprintf("Public key: %#x", data.key.PublicKey().Marshal()))
 	if data.showWithdrawalCredentials {
-		withdrawalCredentials := util.SHA256(data.key.PublicKey().Marshal())
-		withdrawalCredentials[0] = byte(0) // BLS_WITHDRAWAL_PREFIX
-		builder.WriteString(fmt.Sprintf("\nWithdrawal credentials: %#x", withdrawalCredentials))
+		builder.WriteString(fmt.Sprintf("\nWithdrawal credentials: %#x", withdrawalCredentials(data.key)))
 	}
 
 	return builder.String(), nil
 }
+
+func outputStructured(data *dataOut) (string, error) {
+	res := &keyJSON{
+		PublicKey: fmt.Sprintf("%#x", data.key.PublicKey().Marshal()),
+	}
+	if data.showPrivateKey {
+		res.PrivateKey = fmt.Sprintf("%#x", data.key.Marshal())
+	}
+	if data.showWithdrawalCredentials {
+		res.WithdrawalCredentials = fmt.Sprintf("%#x", withdrawalCredentials(data.key))
+	}
+
+	return ethdoutil.FormatOutput(data.format, res)
+}
+
+// withdrawalCredentials returns the BLS withdrawal credentials for a key.
+func withdrawalCredentials(key *e2types.BLSPrivateKey) []byte {
+	withdrawalCredentials := util.SHA256(key.PublicKey().Marshal())
+	withdrawalCredentials[0] = byte(0) // BLS_WITHDRAWAL_PREFIX
+	return withdrawalCredentials
+}
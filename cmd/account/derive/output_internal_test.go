@@ -83,6 +83,24 @@ func TestOutput(t *testing.T) {
 			},
 			needs: []string{"Public key", "Private key", "Withdrawal credentials"},
 		},
+		{
+			name: "JSON",
+			dataOut: &dataOut{
+				format:                    "json",
+				key:                       blsPrivateKey("0x068dce0c90cb428ab37a74af0191eac49648035f1aaef077734b91e05985ec55"),
+				showWithdrawalCredentials: true,
+			},
+			needs: []string{`{"public_key":"0x`, `"withdrawal_credentials":"0x00`},
+		},
+		{
+			name: "YAML",
+			dataOut: &dataOut{
+				format:         "yaml",
+				key:            blsPrivateKey("0x068dce0c90cb428ab37a74af0191eac49648035f1aaef077734b91e05985ec55"),
+				showPrivateKey: true,
+			},
+			needs: []string{"private_key: \"0x068dce0c90cb428ab37a74af0191eac49648035f1aaef077734b91e05985ec55\"", "public_key: "},
+		},
 	}
 
 	for _, test := range tests {
@@ -15,13 +15,12 @@ package accountderive
 
 import (
 	"context"
+	"fmt"
 	"regexp"
-	"strings"
 
+	ethdoutil "github.com/aaron-alderman/ethdo/util"
 	"github.com/pkg/errors"
-	"github.com/tyler-smith/go-bip39"
 	util "github.com/wealdtech/go-eth2-util"
-	"golang.org/x/text/unicode/norm"
 )
 
 // pathRegex is the regular expression that matches an HD path.
@@ -32,23 +31,11 @@ func process(ctx context.Context, data *dataIn) (*dataOut, error) {
 		return nil, errors.New("no data")
 	}
 
-	// If there are more than 24 words we treat the additional characters as the passphrase.
-	mnemonicParts := strings.Split(data.mnemonic, " ")
-	mnemonicPassphrase := ""
-	if len(mnemonicParts) > 24 {
-		data.mnemonic = strings.Join(mnemonicParts[:24], " ")
-		mnemonicPassphrase = strings.Join(mnemonicParts[24:], " ")
-	}
-	// Normalise the input.
-	data.mnemonic = string(norm.NFKD.Bytes([]byte(data.mnemonic)))
-	mnemonicPassphrase = string(norm.NFKD.Bytes([]byte(mnemonicPassphrase)))
-
-	if !bip39.IsMnemonicValid(data.mnemonic) {
-		return nil, errors.New("mnemonic is invalid")
-	}
-
 	// Create seed from mnemonic and passphrase.
-	seed := bip39.NewSeed(data.mnemonic, mnemonicPassphrase)
+	seed, err := ethdoutil.SeedFromMnemonic(data.mnemonic)
+	if err != nil {
+		return nil, err
+	}
 
 	// Ensure the path is valid.
 	match := pathRegex.Match([]byte(data.path))
@@ -63,10 +50,17 @@ func process(ctx context.Context, data *dataIn) (*dataOut, error) {
 	}
 
 	results := &dataOut{
-		showPrivateKey:                   data.showPrivateKey,
+		format:                    data.format,
+		showPrivateKey:            data.showPrivateKey,
 		showWithdrawalCredentials: data.showWithdrawalCredentials,
 		key:                       key,
 	}
 
+	if data.showPrivateKey {
+		if err := ethdoutil.AuditExport(fmt.Sprintf("private key for path %s", data.path), key.PublicKey().Marshal()); err != nil {
+			return nil, errors.Wrap(err, "failed to record export in audit log")
+		}
+	}
+
 	return results, nil
 }
@@ -15,9 +15,13 @@ package accountderive
 
 import (
 	"context"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/aaron-alderman/ethdo/testutil"
+	"github.com/aaron-alderman/ethdo/util"
+	"github.com/spf13/viper"
 	"github.com/stretchr/testify/require"
 	e2types "github.com/wealdtech/go-eth2-types/v2"
 )
@@ -95,3 +99,33 @@ func TestProcess(t *testing.T) {
 		})
 	}
 }
+
+func TestProcessAudit(t *testing.T) {
+	require.NoError(t, e2types.InitBLS())
+
+	logFile := filepath.Join(t.TempDir(), "audit.jsonl")
+	viper.Set("log", logFile)
+	defer viper.Reset()
+
+	dataIn := &dataIn{
+		mnemonic: "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon art",
+		path:     "m/12381/3600/0/0",
+	}
+	_, err := process(context.Background(), dataIn)
+	require.NoError(t, err)
+	// Nothing is recorded if the private key is not shown.
+	_, err = os.Stat(logFile)
+	require.True(t, os.IsNotExist(err))
+
+	dataIn.showPrivateKey = true
+	_, err = process(context.Background(), dataIn)
+	require.NoError(t, err)
+	f, err := os.Open(logFile)
+	require.NoError(t, err)
+	defer f.Close()
+	summary, err := util.VerifyAuditLog(f, "")
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), summary.Entries)
+	require.Equal(t, util.AuditActionExport, summary.Log[0].Action)
+	require.Equal(t, "private key for path m/12381/3600/0/0", summary.Log[0].Operation)
+}
@@ -28,6 +28,7 @@ import (
 )
 
 type dataIn struct {
+	format             string
 	timeout            time.Duration
 	wallet             e2wtypes.Wallet
 	key                []byte
@@ -46,6 +47,7 @@ func input(ctx context.Context) (*dataIn, error) {
 		return nil, errors.New("timeout is required")
 	}
 	data.timeout = viper.GetDuration("timeout")
+	data.format = util.OutputFormat()
 
 	// Account name.
 	if viper.GetString("account") == "" {
@@ -17,14 +17,20 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/aaron-alderman/ethdo/util"
 	"github.com/pkg/errors"
 	e2wtypes "github.com/wealdtech/go-eth2-wallet-types/v2"
 )
 
 type dataOut struct {
+	format  string
 	account e2wtypes.Account
 }
 
+type accountJSON struct {
+	PublicKey string `json:"public_key"`
+}
+
 func output(ctx context.Context, data *dataOut) (string, error) {
 	if data == nil {
 		return "", errors.New("no data")
@@ -33,13 +39,20 @@ func output(ctx context.Context, data *dataOut) (string, error) {
 		return "", errors.New("no account")
 	}
 
+	var pubKey string
 	if pubKeyProvider, ok := data.account.(e2wtypes.AccountCompositePublicKeyProvider); ok {
-		return fmt.Sprintf("%#x", pubKeyProvider.CompositePublicKey().Marshal()), nil
+		pubKey = fmt.Sprintf("%#x", pubKeyProvider.CompositePublicKey().Marshal())
+	} else if pubKeyProvider, ok := data.account.(e2wtypes.AccountPublicKeyProvider); ok {
+		pubKey = fmt.Sprintf("%#x", pubKeyProvider.PublicKey().Marshal())
+	} else {
+		return "", errors.New("no public key available")
 	}
 
-	if pubKeyProvider, ok := data.account.(e2wtypes.AccountPublicKeyProvider); ok {
-		return fmt.Sprintf("%#x", pubKeyProvider.PublicKey().Marshal()), nil
+	if util.StructuredFormat(data.format) {
+		return util.FormatOutput(data.format, &accountJSON{
+			PublicKey: pubKey,
+		})
 	}
 
-	return "", errors.New("no public key available")
+	return pubKey, nil
 }
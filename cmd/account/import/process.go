@@ -58,7 +58,9 @@ func process(ctx context.Context, data *dataIn) (*dataOut, error) {
 }
 
 func processFromKey(ctx context.Context, data *dataIn) (*dataOut, error) {
-	results := &dataOut{}
+	results := &dataOut{
+		format: data.format,
+	}
 
 	importer, isImporter := data.wallet.(e2wtypes.WalletAccountImporter)
 	if !isImporter {
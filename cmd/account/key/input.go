@@ -24,6 +24,7 @@ import (
 )
 
 type dataIn struct {
+	format      string
 	timeout     time.Duration
 	account     e2wtypes.Account
 	passphrases []string
@@ -37,6 +38,7 @@ func input(ctx context.Context) (*dataIn, error) {
 		return nil, errors.New("timeout is required")
 	}
 	data.timeout = viper.GetDuration("timeout")
+	data.format = util.OutputFormat()
 
 	// Account.
 	_, data.account, err = util.WalletAndAccountFromInput(ctx)
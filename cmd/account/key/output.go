@@ -17,11 +17,17 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/aaron-alderman/ethdo/util"
 	"github.com/pkg/errors"
 )
 
 type dataOut struct {
-	key []byte
+	format string
+	key    []byte
+}
+
+type keyJSON struct {
+	PrivateKey string `json:"private_key"`
 }
 
 func output(ctx context.Context, data *dataOut) (string, error) {
@@ -32,5 +38,11 @@ func output(ctx context.Context, data *dataOut) (string, error) {
 		return "", errors.New("no account")
 	}
 
+	if util.StructuredFormat(data.format) {
+		return util.FormatOutput(data.format, &keyJSON{
+			PrivateKey: fmt.Sprintf("%#x", data.key),
+		})
+	}
+
 	return fmt.Sprintf("%#x", data.key), nil
 }
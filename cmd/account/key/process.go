@@ -15,6 +15,7 @@ package accountkey
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/aaron-alderman/ethdo/util"
 	"github.com/pkg/errors"
@@ -29,7 +30,9 @@ func process(ctx context.Context, data *dataIn) (*dataOut, error) {
 		return nil, errors.New("passphrase is required")
 	}
 
-	results := &dataOut{}
+	results := &dataOut{
+		format: data.format,
+	}
 
 	privateKeyProvider, isPrivateKeyProvider := data.account.(e2wtypes.AccountPrivateKeyProvider)
 	if !isPrivateKeyProvider {
@@ -65,6 +68,9 @@ func process(ctx context.Context, data *dataIn) (*dataOut, error) {
 		return nil, errors.Wrap(err, "failed to obtain private key")
 	}
 	results.key = key.Marshal()
+	if err := util.AuditExport(fmt.Sprintf("private key of account %s", data.account.Name()), util.AccountPublicKey(data.account)); err != nil {
+		return nil, errors.Wrap(err, "failed to record export in audit log")
+	}
 
 	return results, nil
 }
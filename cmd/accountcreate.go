@@ -28,6 +28,10 @@ var accountCreateCmd = &cobra.Command{
 
     ethdo account create --account="primary/operations" --passphrase="my secret"
 
+A distributed account can be generated locally without a remote signer, writing a keystore for each participant's share to a directory.  Each share is encrypted with its own passphrase, supplied in order of participant with --participant-passphrase.  For example:
+
+    ethdo account create --account="validator1" --distributed-local --participants=3 --signing-threshold=2 --output-dir=shares --participant-passphrase="secret 1" --participant-passphrase="secret 2" --participant-passphrase="secret 3"
+
 In quiet mode this will return 0 if the account is created successfully, otherwise 1.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		res, err := accountcreate.Run(cmd)
@@ -50,6 +54,9 @@ func init() {
 	accountCreateCmd.Flags().Uint32("participants", 1, "Number of participants (1 for non-distributed accounts, >1 for distributed accounts)")
 	accountCreateCmd.Flags().Uint32("signing-threshold", 1, "Signing threshold (1 for non-distributed accounts)")
 	accountCreateCmd.Flags().String("path", "", "path of account (for hierarchical deterministic accounts)")
+	accountCreateCmd.Flags().Bool("distributed-local", false, "generate a distributed account locally rather than with a remote signer")
+	accountCreateCmd.Flags().String("output-dir", "", "directory to which to write keystores for locally-generated distributed accounts")
+	accountCreateCmd.Flags().StringArray("participant-passphrase", nil, "passphrase for a participant's share of a locally-generated distributed account (supply once for each participant, in order)")
 }
 
 func accountCreateBindings() {
@@ -62,4 +69,13 @@ func accountCreateBindings() {
 	if err := viper.BindPFlag("path", accountCreateCmd.Flags().Lookup("path")); err != nil {
 		panic(err)
 	}
+	if err := viper.BindPFlag("distributed-local", accountCreateCmd.Flags().Lookup("distributed-local")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("output-dir", accountCreateCmd.Flags().Lookup("output-dir")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("participant-passphrase", accountCreateCmd.Flags().Lookup("participant-passphrase")); err != nil {
+		panic(err)
+	}
 }
@@ -48,6 +48,10 @@ In quiet mode this will return 0 if the account exists, otherwise 1.`,
 			os.Exit(_exitSuccess)
 		}
 
+		if outputStructured(accountInfoStructured(account)) {
+			os.Exit(_exitSuccess)
+		}
+
 		outputIf(verbose, fmt.Sprintf("UUID: %v", account.ID()))
 		var withdrawalPubKey e2types.PublicKey
 		if pubKeyProvider, ok := account.(e2wtypes.AccountPublicKeyProvider); ok {
@@ -82,6 +86,46 @@ In quiet mode this will return 0 if the account exists, otherwise 1.`,
 	},
 }
 
+type accountInfoJSON struct {
+	UUID                  string            `json:"uuid"`
+	Name                  string            `json:"name"`
+	PublicKey             string            `json:"public_key,omitempty"`
+	CompositePublicKey    string            `json:"composite_public_key,omitempty"`
+	SigningThreshold      uint32            `json:"signing_threshold,omitempty"`
+	Participants          map[uint64]string `json:"participants,omitempty"`
+	WithdrawalCredentials string            `json:"withdrawal_credentials,omitempty"`
+	Path                  string            `json:"path,omitempty"`
+}
+
+// accountInfoStructured returns account information for structured output.
+func accountInfoStructured(account e2wtypes.Account) *accountInfoJSON {
+	res := &accountInfoJSON{
+		UUID: account.ID().String(),
+		Name: account.Name(),
+	}
+	var withdrawalPubKey e2types.PublicKey
+	if pubKeyProvider, ok := account.(e2wtypes.AccountPublicKeyProvider); ok {
+		res.PublicKey = fmt.Sprintf("%#x", pubKeyProvider.PublicKey().Marshal())
+		withdrawalPubKey = pubKeyProvider.PublicKey()
+	}
+	if distributedAccount, ok := account.(e2wtypes.DistributedAccount); ok {
+		res.CompositePublicKey = fmt.Sprintf("%#x", distributedAccount.CompositePublicKey().Marshal())
+		res.SigningThreshold = distributedAccount.SigningThreshold()
+		res.Participants = distributedAccount.Participants()
+		withdrawalPubKey = distributedAccount.CompositePublicKey()
+	}
+	if withdrawalPubKey != nil {
+		withdrawalCredentials := util.SHA256(withdrawalPubKey.Marshal())
+		withdrawalCredentials[0] = byte(0) // BLS_WITHDRAWAL_PREFIX
+		res.WithdrawalCredentials = fmt.Sprintf("%#x", withdrawalCredentials)
+	}
+	if pathProvider, ok := account.(e2wtypes.AccountPathProvider); ok {
+		res.Path = pathProvider.Path()
+	}
+
+	return res
+}
+
 func init() {
 	accountCmd.AddCommand(accountInfoCmd)
 	accountFlags(accountInfoCmd)
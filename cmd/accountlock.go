@@ -43,9 +43,18 @@ In quiet mode this will return 0 if the account is locked, otherwise 1.`,
 
 		err = locker.Lock(ctx)
 		errCheck(err, "Failed to lock account")
+
+		if !quiet {
+			outputStructured(&lockedJSON{Locked: true})
+		}
 	},
 }
 
+// lockedJSON is the structured output of a successful lock.
+type lockedJSON struct {
+	Locked bool `json:"locked"`
+}
+
 func init() {
 	accountCmd.AddCommand(accountLockCmd)
 	accountFlags(accountLockCmd)
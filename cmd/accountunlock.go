@@ -55,10 +55,18 @@ In quiet mode this will return 0 if the account is unlocked, otherwise 1.`,
 		}
 
 		assert(unlocked, "Failed to unlock account")
+		if !quiet {
+			outputStructured(&unlockedJSON{Unlocked: true})
+		}
 		os.Exit(_exitSuccess)
 	},
 }
 
+// unlockedJSON is the structured output of a successful unlock.
+type unlockedJSON struct {
+	Unlocked bool `json:"unlocked"`
+}
+
 func init() {
 	accountCmd.AddCommand(accountUnlockCmd)
 	accountFlags(accountUnlockCmd)
@@ -30,7 +30,7 @@ type dataIn struct {
 	quiet   bool
 	verbose bool
 	debug   bool
-	json    bool
+	format  string
 	// Chain information.
 	slotsPerEpoch uint64
 	// Operation.
@@ -50,7 +50,7 @@ func input(ctx context.Context) (*dataIn, error) {
 	data.quiet = viper.GetBool("quiet")
 	data.verbose = viper.GetBool("verbose")
 	data.debug = viper.GetBool("debug")
-	data.json = viper.GetBool("json")
+	data.format = util.OutputFormat()
 
 	// Account or pubkey.
 	if viper.GetString("account") == "" && viper.GetString("pubkey") == "" {
@@ -15,9 +15,9 @@ package attesterduties
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 
+	"github.com/aaron-alderman/ethdo/util"
 	api "github.com/attestantio/go-eth2-client/api/v1"
 	"github.com/pkg/errors"
 )
@@ -26,7 +26,7 @@ type dataOut struct {
 	debug   bool
 	quiet   bool
 	verbose bool
-	json    bool
+	format  string
 	duty    *api.AttesterDuty
 }
 
@@ -39,16 +39,12 @@ func output(ctx context.Context, data *dataOut) (string, error) {
 		return "", nil
 	}
 
-	if data.duty == nil {
-		return "No duties found", nil
+	if util.StructuredFormat(data.format) {
+		return util.FormatOutput(data.format, data.duty)
 	}
 
-	if data.json {
-		bytes, err := json.Marshal(data.duty)
-		if err != nil {
-			return "", errors.Wrap(err, "failed to marshalJSON")
-		}
-		return string(bytes), nil
+	if data.duty == nil {
+		return "No duties found", nil
 	}
 
 	return fmt.Sprintf("Validator attesting in slot %d committee %d", data.duty.Slot, data.duty.CommitteeIndex), nil
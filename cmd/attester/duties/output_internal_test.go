@@ -56,7 +56,7 @@ func TestOutput(t *testing.T) {
 		{
 			name: "JSON",
 			dataOut: &dataOut{
-				json: true,
+				format: "json",
 				duty: &api.AttesterDuty{
 					PubKey:                  testutil.HexToPubKey("0x933ad9491b62059dd065b560d256d8957a8c402cc6e8d8ee7290ae11e8f7329267a8811c397529dac52ae1342ba58c95"),
 					Slot:                    1,
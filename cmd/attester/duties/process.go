@@ -75,6 +75,7 @@ func process(ctx context.Context, data *dataIn) (*dataOut, error) {
 		debug:   data.debug,
 		quiet:   data.quiet,
 		verbose: data.verbose,
+		format:  data.format,
 	}
 
 	duty, err := duty(ctx, data.eth2Client, validator, data.epoch, data.slotsPerEpoch)
@@ -32,6 +32,7 @@ type dataIn struct {
 	quiet   bool
 	verbose bool
 	debug   bool
+	format  string
 	// Chain information.
 	slotsPerEpoch uint64
 	// Operation.
@@ -51,6 +52,7 @@ func input(ctx context.Context) (*dataIn, error) {
 	}
 	data.timeout = viper.GetDuration("timeout")
 	data.quiet = viper.GetBool("quiet")
+	data.format = util.OutputFormat()
 	data.verbose = viper.GetBool("verbose")
 	data.debug = viper.GetBool("debug")
 
@@ -18,6 +18,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/aaron-alderman/ethdo/util"
 	"github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/pkg/errors"
 )
@@ -26,6 +27,7 @@ type dataOut struct {
 	debug            bool
 	quiet            bool
 	verbose          bool
+	format           string
 	attestation      *phase0.Attestation
 	slot             phase0.Slot
 	attestationIndex uint64
@@ -38,12 +40,28 @@ type dataOut struct {
 	targetTimely     bool
 }
 
+type inclusionJSON struct {
+	Found          bool         `json:"found"`
+	Slot           *phase0.Slot `json:"slot,omitempty"`
+	Index          *uint64      `json:"index,omitempty"`
+	InclusionDelay *phase0.Slot `json:"inclusion_delay,omitempty"`
+	HeadCorrect    *bool        `json:"head_correct,omitempty"`
+	HeadTimely     *bool        `json:"head_timely,omitempty"`
+	SourceTimely   *bool        `json:"source_timely,omitempty"`
+	TargetCorrect  *bool        `json:"target_correct,omitempty"`
+	TargetTimely   *bool        `json:"target_timely,omitempty"`
+}
+
 func output(ctx context.Context, data *dataOut) (string, error) {
 	buf := strings.Builder{}
 	if data == nil {
 		return buf.String(), errors.New("no data")
 	}
 
+	if util.StructuredFormat(data.format) {
+		return outputStructured(data)
+	}
+
 	if !data.quiet {
 		if data.found {
 			buf.WriteString("Attestation included in block ")
@@ -90,3 +108,25 @@ func output(ctx context.Context, data *dataOut) (string, error) {
 	}
 	return buf.String(), nil
 }
+
+func outputStructured(data *dataOut) (string, error) {
+	if data.quiet {
+		return "", nil
+	}
+
+	res := &inclusionJSON{
+		Found: data.found,
+	}
+	if data.found {
+		res.Slot = &data.slot
+		res.Index = &data.attestationIndex
+		res.InclusionDelay = &data.inclusionDelay
+		res.HeadCorrect = &data.headCorrect
+		res.HeadTimely = &data.headTimely
+		res.SourceTimely = &data.sourceTimely
+		res.TargetCorrect = &data.targetCorrect
+		res.TargetTimely = &data.targetTimely
+	}
+
+	return util.FormatOutput(data.format, res)
+}
@@ -14,7 +14,6 @@
 package attesterinclusion
 
 import (
-	"bytes"
 	"context"
 	"fmt"
 
@@ -63,6 +62,7 @@ func process(ctx context.Context, data *dataIn) (*dataOut, error) {
 		debug:   data.debug,
 		quiet:   data.quiet,
 		verbose: data.verbose,
+		format:  data.format,
 	}
 
 	duty, err := duty(ctx, data.eth2Client, validator, data.epoch, data.slotsPerEpoch)
@@ -105,11 +105,11 @@ func process(ctx context.Context, data *dataIn) (*dataOut, error) {
 				headCorrect := false
 				targetCorrect := false
 				if data.verbose {
-					headCorrect, err = calcHeadCorrect(ctx, data, attestation)
+					headCorrect, err = util.AttestationHeadCorrect(ctx, data.eth2Client.(eth2client.BeaconBlockHeadersProvider), attestation)
 					if err != nil {
 						return nil, errors.Wrap(err, "failed to obtain head correct result")
 					}
-					targetCorrect, err = calcTargetCorrect(ctx, data, attestation)
+					targetCorrect, err = util.AttestationTargetCorrect(ctx, data.eth2Client.(eth2client.BeaconBlockHeadersProvider), data.chainTime, attestation)
 					if err != nil {
 						return nil, errors.Wrap(err, "failed to obtain target correct result")
 					}
@@ -134,49 +134,6 @@ func process(ctx context.Context, data *dataIn) (*dataOut, error) {
 	return results, nil
 }
 
-func calcHeadCorrect(ctx context.Context, data *dataIn, attestation *phase0.Attestation) (bool, error) {
-	slot := attestation.Data.Slot
-	for {
-		header, err := data.eth2Client.(eth2client.BeaconBlockHeadersProvider).BeaconBlockHeader(ctx, fmt.Sprintf("%d", slot))
-		if err != nil {
-			return false, nil
-		}
-		if header == nil {
-			// No block.
-			slot--
-			continue
-		}
-		if !header.Canonical {
-			// Not canonical.
-			slot--
-			continue
-		}
-		return bytes.Equal(header.Root[:], attestation.Data.BeaconBlockRoot[:]), nil
-	}
-}
-
-func calcTargetCorrect(ctx context.Context, data *dataIn, attestation *phase0.Attestation) (bool, error) {
-	// Start with first slot of the target epoch.
-	slot := data.chainTime.FirstSlotOfEpoch(attestation.Data.Target.Epoch)
-	for {
-		header, err := data.eth2Client.(eth2client.BeaconBlockHeadersProvider).BeaconBlockHeader(ctx, fmt.Sprintf("%d", slot))
-		if err != nil {
-			return false, nil
-		}
-		if header == nil {
-			// No block.
-			slot--
-			continue
-		}
-		if !header.Canonical {
-			// Not canonical.
-			slot--
-			continue
-		}
-		return bytes.Equal(header.Root[:], attestation.Data.Target.Root[:]), nil
-	}
-}
-
 func duty(ctx context.Context, eth2Client eth2client.Service, validator *api.Validator, epoch phase0.Epoch, slotsPerEpoch uint64) (*api.AttesterDuty, error) {
 	// Find the attesting slot for the given epoch.
 	duties, err := eth2Client.(eth2client.AttesterDutiesProvider).AttesterDuties(ctx, epoch, []phase0.ValidatorIndex{validator.Index})
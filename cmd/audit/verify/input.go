@@ -0,0 +1,59 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auditverify
+
+import (
+	"context"
+	"time"
+
+	"github.com/aaron-alderman/ethdo/util"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+)
+
+type dataIn struct {
+	// System.
+	timeout time.Duration
+	quiet   bool
+	verbose bool
+	format  string
+	debug   bool
+	file    string
+	key     string
+}
+
+func input(ctx context.Context) (*dataIn, error) {
+	data := &dataIn{}
+
+	if viper.GetDuration("timeout") == 0 {
+		return nil, errors.New("timeout is required")
+	}
+	data.timeout = viper.GetDuration("timeout")
+	data.quiet = viper.GetBool("quiet")
+	data.verbose = viper.GetBool("verbose")
+	data.format = util.OutputFormat()
+	data.debug = viper.GetBool("debug")
+
+	// File.
+	data.file = viper.GetString("file")
+	if data.file == "" {
+		data.file = viper.GetString("log")
+	}
+	if data.file == "" {
+		return nil, errors.New("file is required")
+	}
+	data.key = viper.GetString("log-key")
+
+	return data, nil
+}
@@ -0,0 +1,98 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auditverify
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInput(t *testing.T) {
+	tests := []struct {
+		name string
+		vars map[string]interface{}
+		res  *dataIn
+		err  string
+	}{
+		{
+			name: "TimeoutMissing",
+			vars: map[string]interface{}{
+				"file": "audit.jsonl",
+			},
+			err: "timeout is required",
+		},
+		{
+			name: "FileMissing",
+			vars: map[string]interface{}{
+				"timeout": "5s",
+			},
+			err: "file is required",
+		},
+		{
+			name: "Good",
+			vars: map[string]interface{}{
+				"timeout": "5s",
+				"file":    "audit.jsonl",
+			},
+			res: &dataIn{
+				timeout: 5 * time.Second,
+				file:    "audit.jsonl",
+			},
+		},
+		{
+			name: "GoodLog",
+			vars: map[string]interface{}{
+				"timeout": "5s",
+				"log":     "ethdo.jsonl",
+			},
+			res: &dataIn{
+				timeout: 5 * time.Second,
+				file:    "ethdo.jsonl",
+			},
+		},
+		{
+			name: "GoodFileOverridesLog",
+			vars: map[string]interface{}{
+				"timeout": "5s",
+				"file":    "audit.jsonl",
+				"log":     "ethdo.jsonl",
+			},
+			res: &dataIn{
+				timeout: 5 * time.Second,
+				file:    "audit.jsonl",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			viper.Reset()
+			for k, v := range test.vars {
+				viper.Set(k, v)
+			}
+			res, err := input(context.Background())
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, test.res.timeout, res.timeout)
+				require.Equal(t, test.res.file, res.file)
+			}
+		})
+	}
+}
@@ -0,0 +1,84 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auditverify
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/aaron-alderman/ethdo/util"
+	"github.com/pkg/errors"
+)
+
+type dataOut struct {
+	format   string
+	verbose  bool
+	entries  uint64
+	headHash string
+	log      []*util.AuditEntry
+}
+
+type verifyJSON struct {
+	Verified bool               `json:"verified"`
+	Entries  uint64             `json:"entries"`
+	HeadHash string             `json:"head_hash"`
+	Log      []*util.AuditEntry `json:"log,omitempty"`
+}
+
+func output(ctx context.Context, data *dataOut) (string, error) {
+	if data == nil {
+		return "", errors.New("no data")
+	}
+
+	if util.StructuredFormat(data.format) {
+		res := &verifyJSON{
+			Verified: true,
+			Entries:  data.entries,
+			HeadHash: data.headHash,
+		}
+		if data.verbose {
+			res.Log = data.log
+		}
+		return util.FormatOutput(data.format, res)
+	}
+
+	builder := strings.Builder{}
+	if data.verbose {
+		for _, entry := range data.log {
+			builder.WriteString(outputEntryText(entry))
+			builder.WriteString("\n")
+		}
+	}
+	builder.WriteString(fmt.Sprintf("Audit log verified: %d entries, head hash %s", data.entries, data.headHash))
+
+	return builder.String(), nil
+}
+
+func outputEntryText(entry *util.AuditEntry) string {
+	builder := strings.Builder{}
+	builder.WriteString(fmt.Sprintf("%d %s %s@%s %q %s", entry.Sequence, entry.Timestamp.Format(time.RFC3339), entry.User, entry.Host, entry.Command, entry.Action))
+	if entry.Operation != "" {
+		builder.WriteString(fmt.Sprintf(" %q", entry.Operation))
+	}
+	if entry.Root != "" {
+		builder.WriteString(fmt.Sprintf(" root %s domain %s", entry.Root, entry.Domain))
+	}
+	if len(entry.PublicKeys) > 0 {
+		builder.WriteString(fmt.Sprintf(" by %s", strings.Join(entry.PublicKeys, ", ")))
+	}
+
+	return builder.String()
+}
@@ -0,0 +1,99 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auditverify
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aaron-alderman/ethdo/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOutput(t *testing.T) {
+	tests := []struct {
+		name    string
+		dataOut *dataOut
+		res     string
+		err     string
+	}{
+		{
+			name: "Nil",
+			err:  "no data",
+		},
+		{
+			name: "Good",
+			dataOut: &dataOut{
+				entries:  3,
+				headHash: "0x0102",
+			},
+			res: "Audit log verified: 3 entries, head hash 0x0102",
+		},
+		{
+			name: "JSON",
+			dataOut: &dataOut{
+				format:   "json",
+				entries:  3,
+				headHash: "0x0102",
+			},
+			res: `{"verified":true,"entries":3,"head_hash":"0x0102"}`,
+		},
+		{
+			name: "Verbose",
+			dataOut: &dataOut{
+				verbose:  true,
+				entries:  2,
+				headHash: "0x0102",
+				log: []*util.AuditEntry{
+					{
+						Sequence:   1,
+						Timestamp:  time.Unix(1600000000, 0).UTC(),
+						User:       "operator",
+						Host:       "signer",
+						Command:    "ethdo signature sign",
+						Action:     util.AuditActionSign,
+						PublicKeys: []string{"0x01"},
+						Root:       "0x03",
+						Domain:     "0x04",
+					},
+					{
+						Sequence:  2,
+						Timestamp: time.Unix(1600000012, 0).UTC(),
+						User:      "operator",
+						Host:      "signer",
+						Command:   "ethdo wallet export",
+						Action:    util.AuditActionExport,
+						Operation: "wallet test",
+					},
+				},
+			},
+			res: `1 2020-09-13T12:26:40Z operator@signer "ethdo signature sign" sign root 0x03 domain 0x04 by 0x01
+2 2020-09-13T12:26:52Z operator@signer "ethdo wallet export" export "wallet test"
+Audit log verified: 2 entries, head hash 0x0102`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			res, err := output(context.Background(), test.dataOut)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, test.res, res)
+			}
+		})
+	}
+}
@@ -0,0 +1,50 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auditverify
+
+import (
+	"context"
+	"os"
+
+	"github.com/aaron-alderman/ethdo/util"
+	"github.com/pkg/errors"
+)
+
+func process(ctx context.Context, data *dataIn) (*dataOut, error) {
+	if data == nil {
+		return nil, errors.New("no data")
+	}
+
+	f, err := os.Open(data.file)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to open audit log")
+	}
+	defer f.Close()
+
+	summary, err := util.VerifyAuditLog(f, data.key)
+	if err != nil {
+		return nil, util.NewCodedError(util.ErrorCodeVerificationFailed, errors.Wrap(err, "audit log failed verification"))
+	}
+	if summary.Entries == 0 {
+		return nil, util.NewCodedError(util.ErrorCodeVerificationFailed, errors.New("audit log contains no entries"))
+	}
+
+	return &dataOut{
+		format:   data.format,
+		verbose:  data.verbose,
+		entries:  summary.Entries,
+		headHash: summary.HeadHash,
+		log:      summary.Log,
+	}, nil
+}
@@ -0,0 +1,130 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auditverify
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/aaron-alderman/ethdo/util"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProcess(t *testing.T) {
+	dir := t.TempDir()
+	logFile := filepath.Join(dir, "audit.jsonl")
+	viper.Set("log", logFile)
+	require.NoError(t, util.AuditBroadcast("voluntary exit for validator 1", []byte{0x01}))
+	require.NoError(t, util.AuditBroadcast("voluntary exit for validator 2", []byte{0x02}))
+	viper.Reset()
+
+	data, err := os.ReadFile(logFile)
+	require.NoError(t, err)
+	alteredFile := filepath.Join(dir, "altered.jsonl")
+	require.NoError(t, os.WriteFile(alteredFile, []byte(strings.Replace(string(data), "validator 2", "validator 3", 1)), 0600))
+	emptyFile := filepath.Join(dir, "empty.jsonl")
+	require.NoError(t, os.WriteFile(emptyFile, nil, 0600))
+
+	keyedFile := filepath.Join(dir, "keyed.jsonl")
+	viper.Set("log", keyedFile)
+	viper.Set("log-key", "log secret")
+	require.NoError(t, util.AuditBroadcast("voluntary exit for validator 1", []byte{0x01}))
+	require.NoError(t, util.AuditBroadcast("voluntary exit for validator 2", []byte{0x02}))
+	viper.Reset()
+
+	tests := []struct {
+		name   string
+		dataIn *dataIn
+		err    string
+		code   string
+	}{
+		{
+			name: "Nil",
+			err:  "no data",
+		},
+		{
+			name: "FileBad",
+			dataIn: &dataIn{
+				file: filepath.Join(dir, "bad.jsonl"),
+			},
+			err: "failed to open audit log: open " + filepath.Join(dir, "bad.jsonl") + ": no such file or directory",
+		},
+		{
+			name: "Empty",
+			dataIn: &dataIn{
+				file: emptyFile,
+			},
+			err:  "audit log contains no entries",
+			code: util.ErrorCodeVerificationFailed,
+		},
+		{
+			name: "Altered",
+			dataIn: &dataIn{
+				file: alteredFile,
+			},
+			err:  "audit log failed verification: line 3: hash does not match entry contents",
+			code: util.ErrorCodeVerificationFailed,
+		},
+		{
+			name: "Good",
+			dataIn: &dataIn{
+				file: logFile,
+			},
+		},
+		{
+			name: "KeyedKeyMissing",
+			dataIn: &dataIn{
+				file: keyedFile,
+			},
+			err:  "audit log failed verification: log is authenticated with a log key, which is required",
+			code: util.ErrorCodeVerificationFailed,
+		},
+		{
+			name: "KeyedKeyWrong",
+			dataIn: &dataIn{
+				file: keyedFile,
+				key:  "wrong secret",
+			},
+			err:  "audit log failed verification: line 2: hash does not match entry contents",
+			code: util.ErrorCodeVerificationFailed,
+		},
+		{
+			name: "Keyed",
+			dataIn: &dataIn{
+				file: keyedFile,
+				key:  "log secret",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			res, err := process(context.Background(), test.dataIn)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+				if test.code != "" {
+					require.Equal(t, test.code, util.ErrorCode(err))
+				}
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, uint64(2), res.entries)
+				require.True(t, strings.HasPrefix(res.headHash, "0x"))
+			}
+		})
+	}
+}
@@ -0,0 +1,57 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	auditverify "github.com/aaron-alderman/ethdo/cmd/audit/verify"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var auditVerifyCmd = &cobra.Command{
+	Use:   "verify",
+	Short: "Verify the hash chain of an audit log",
+	Long: `Verify the hash chain of an audit log.  For example:
+
+    ethdo audit verify --file=ethdo-audit.jsonl --log-key="audit secret"
+
+If no file is supplied the log configured with --log is verified.  If the log has a key it must be supplied with --log-key, in which case entries cannot have been altered, removed or reordered without knowledge of the key.  Without a key anyone able to write to the log can recalculate its hash chain.
+
+With the --verbose flag the entries of the log are listed.
+
+In quiet mode this will return 0 if the audit log is verified, otherwise 1.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		res, err := auditverify.Run(cmd)
+		if err != nil {
+			return err
+		}
+		if res != "" {
+			fmt.Println(res)
+		}
+		return nil
+	},
+}
+
+func init() {
+	auditCmd.AddCommand(auditVerifyCmd)
+	auditVerifyCmd.Flags().String("file", "", "Name of the audit log file (defaults to the value of --log)")
+}
+
+func auditVerifyBindings() {
+	if err := viper.BindPFlag("file", auditVerifyCmd.Flags().Lookup("file")); err != nil {
+		panic(err)
+	}
+}
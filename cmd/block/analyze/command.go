@@ -18,6 +18,7 @@ import (
 	"time"
 
 	"github.com/aaron-alderman/ethdo/services/chaintime"
+	"github.com/aaron-alderman/ethdo/util"
 	eth2client "github.com/attestantio/go-eth2-client"
 	"github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/pkg/errors"
@@ -36,9 +37,9 @@ type command struct {
 	allowInsecureConnections bool
 
 	// Operation.
-	blockID    string
-	stream     bool
-	jsonOutput bool
+	blockID string
+	stream  bool
+	format  string
 
 	// Data access.
 	eth2Client           eth2client.Service
@@ -130,7 +131,7 @@ func newCommand(ctx context.Context) (*command, error) {
 
 	c.blockID = viper.GetString("blockid")
 	c.stream = viper.GetBool("stream")
-	c.jsonOutput = viper.GetBool("json")
+	c.format = util.OutputFormat()
 
 	return c, nil
 }
@@ -0,0 +1,99 @@
+// Copyright © 2022 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package blockanalyze
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aaron-alderman/ethdo/testing/beaconnode"
+	"github.com/rs/zerolog"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRun(t *testing.T) {
+	zerolog.SetGlobalLevel(zerolog.Disabled)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	beaconNode, err := beaconnode.New(ctx,
+		beaconnode.WithFixturesDir("../../../testing/beaconnode/testdata"),
+	)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name string
+		vars map[string]interface{}
+		res  string
+		err  string
+	}{
+		{
+			name: "BlockMissing",
+			vars: map[string]interface{}{
+				"timeout":    "5s",
+				"blockid":    "50",
+				"connection": beaconNode.Address(),
+			},
+			err: "failed to process: empty beacon block",
+		},
+		{
+			name: "Good",
+			vars: map[string]interface{}{
+				"timeout":    "5s",
+				"blockid":    "101",
+				"connection": beaconNode.Address(),
+			},
+			res: "Value for block 101: 0.844\n",
+		},
+		{
+			name: "Verbose",
+			vars: map[string]interface{}{
+				"timeout":    "5s",
+				"blockid":    "101",
+				"connection": beaconNode.Address(),
+				"verbose":    true,
+			},
+			res: "Attestation 0: distance 1, 1/1/1 new/total/possible votes, score 0.844, value 0.844\nAttestation 1: distance 2, duplicate of attestation 0 in block 100\nValue for block 101: 0.844\n",
+		},
+		{
+			name: "JSON",
+			vars: map[string]interface{}{
+				"timeout":    "5s",
+				"blockid":    "101",
+				"connection": beaconNode.Address(),
+				"json":       true,
+			},
+			res: `{"slot":101,"attestations":[{"head":"0x6464646464646464646464646464646464646464646464646464646464646464","target":"0x6060606060606060606060606060606060606060606060606060606060606060","distance":1,"new_votes":1,"votes":1,"possible_votes":1,"head_correct":true,"head_timely":true,"source_timely":true,"target_correct":true,"target_timely":true,"score":0.84375,"value":0.84375},{"head":"0x6363636363636363636363636363636363636363636363636363636363636363","target":"0x6060606060606060606060606060606060606060606060606060606060606060","distance":2,"duplicate":{"block":100,"index":0},"new_votes":0,"votes":0,"possible_votes":0,"head_correct":false,"head_timely":false,"source_timely":false,"target_correct":false,"target_timely":false,"score":0,"value":0}],"sync_committee":{"contributions":0,"possible_contributions":0,"score":0,"value":0},"value":0.84375}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			viper.Reset()
+
+			for k, v := range test.vars {
+				viper.Set(k, v)
+			}
+			res, err := Run(&cobra.Command{})
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, test.res, res)
+			}
+		})
+	}
+}
@@ -15,6 +15,8 @@ package blockinfo
 
 import (
 	"context"
+	"fmt"
+	"io/ioutil"
 	"time"
 
 	"github.com/aaron-alderman/ethdo/util"
@@ -31,11 +33,13 @@ type dataIn struct {
 	debug   bool
 	// Operation.
 	eth2Client eth2client.Service
-	jsonOutput bool
+	format     string
 	sszOutput  bool
 	// Chain information.
 	blockID string
 	stream  bool
+	// Block from file.
+	file []byte
 }
 
 func input(ctx context.Context) (*dataIn, error) {
@@ -48,15 +52,31 @@ func input(ctx context.Context) (*dataIn, error) {
 	data.quiet = viper.GetBool("quiet")
 	data.verbose = viper.GetBool("verbose")
 	data.debug = viper.GetBool("debug")
-	data.jsonOutput = viper.GetBool("json")
+	data.format = util.OutputFormat()
 	data.sszOutput = viper.GetBool("ssz")
+	if data.sszOutput && util.StructuredFormat(data.format) {
+		return nil, util.NewCodedError(util.ErrorCodeInvalidInput, fmt.Errorf("--ssz cannot be used with --format=%s", data.format))
+	}
 
 	data.stream = viper.GetBool("stream")
 
 	var err error
-	data.eth2Client, err = util.ConnectToBeaconNode(ctx, viper.GetString("connection"), viper.GetDuration("timeout"), viper.GetBool("allow-insecure-connections"))
-	if err != nil {
-		return nil, err
+	if viper.GetString("file") != "" {
+		if data.stream {
+			return nil, errors.New("cannot stream blocks from a file")
+		}
+		data.file, err = ioutil.ReadFile(viper.GetString("file"))
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to read block file")
+		}
+	}
+
+	// A block from a file only uses a beacon node to add information if one is explicitly supplied.
+	if data.file == nil || viper.GetString("connection") != "" {
+		data.eth2Client, err = util.ConnectToBeaconNode(ctx, viper.GetString("connection"), viper.GetDuration("timeout"), viper.GetBool("allow-insecure-connections"))
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	if viper.GetString("blockid") == "" {
@@ -15,7 +15,10 @@ package blockinfo
 
 import (
 	"context"
+	"fmt"
+	"io/ioutil"
 	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -124,3 +127,68 @@ func TestInput(t *testing.T) {
 		})
 	}
 }
+
+func TestInputFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "block.ssz")
+	require.NoError(t, ioutil.WriteFile(path, []byte("0x01"), 0600))
+
+	tests := []struct {
+		name string
+		vars map[string]interface{}
+		res  *dataIn
+		err  string
+	}{
+		{
+			name: "FileMissing",
+			vars: map[string]interface{}{
+				"timeout": "5s",
+				"file":    filepath.Join(dir, "missing"),
+			},
+			err: fmt.Sprintf("failed to read block file: open %s: no such file or directory", filepath.Join(dir, "missing")),
+		},
+		{
+			name: "FileStream",
+			vars: map[string]interface{}{
+				"timeout": "5s",
+				"file":    path,
+				"stream":  true,
+			},
+			err: "cannot stream blocks from a file",
+		},
+		{
+			name: "Good",
+			vars: map[string]interface{}{
+				"timeout": "5s",
+				"file":    path,
+			},
+			res: &dataIn{
+				timeout: 5 * time.Second,
+				blockID: "head",
+				file:    []byte("0x01"),
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			viper.Reset()
+
+			for k, v := range test.vars {
+				viper.Set(k, v)
+			}
+			res, err := input(context.Background())
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, test.res.timeout, res.timeout)
+				require.Equal(t, test.res.blockID, res.blockID)
+				require.Equal(t, test.res.file, res.file)
+				require.Nil(t, res.eth2Client)
+			}
+		})
+	}
+}
@@ -69,7 +69,9 @@ func outputBlockGeneral(ctx context.Context,
 
 	res.WriteString(fmt.Sprintf("Slot: %d\n", slot))
 	res.WriteString(fmt.Sprintf("Epoch: %d\n", phase0.Epoch(uint64(slot)/slotsPerEpoch)))
-	res.WriteString(fmt.Sprintf("Timestamp: %v\n", time.Unix(genesisTime.Unix()+int64(slot)*int64(slotDuration.Seconds()), 0)))
+	if !genesisTime.IsZero() {
+		res.WriteString(fmt.Sprintf("Timestamp: %v\n", time.Unix(genesisTime.Unix()+int64(slot)*int64(slotDuration.Seconds()), 0)))
+	}
 	res.WriteString(fmt.Sprintf("Block root: %#x\n", blockRoot))
 	if verbose {
 		res.WriteString(fmt.Sprintf("Body root: %#x\n", bodyRoot))
@@ -162,12 +164,19 @@ func outputBlockAttesterSlashings(ctx context.Context, eth2Client eth2client.Ser
 
 			res.WriteString(fmt.Sprintf("  %d:\n", i))
 			res.WriteString(fmt.Sprintln("    Slashed validators:"))
-			validators, err := eth2Client.(eth2client.ValidatorsProvider).Validators(ctx, "head", slashedIndices)
-			if err != nil {
-				return "", errors.Wrap(err, "failed to obtain beacon committees")
-			}
-			for k, v := range validators {
-				res.WriteString(fmt.Sprintf("      %#x (%d)\n", v.Validator.PublicKey[:], k))
+			validatorsProvider, isProvider := eth2Client.(eth2client.ValidatorsProvider)
+			if isProvider {
+				validators, err := validatorsProvider.Validators(ctx, "head", slashedIndices)
+				if err != nil {
+					return "", errors.Wrap(err, "failed to obtain beacon committees")
+				}
+				for k, v := range validators {
+					res.WriteString(fmt.Sprintf("      %#x (%d)\n", v.Validator.PublicKey[:], k))
+				}
+			} else {
+				for _, index := range slashedIndices {
+					res.WriteString(fmt.Sprintf("      %d\n", index))
+				}
 			}
 
 			// Say what caused the slashing.
@@ -219,13 +228,20 @@ func outputBlockVoluntaryExits(ctx context.Context, eth2Client eth2client.Servic
 	if verbose {
 		for i, voluntaryExit := range voluntaryExits {
 			res.WriteString(fmt.Sprintf("  %d:\n", i))
-			validators, err := eth2Client.(eth2client.ValidatorsProvider).Validators(ctx, "head", []phase0.ValidatorIndex{voluntaryExit.Message.ValidatorIndex})
-			if err != nil {
-				res.WriteString(fmt.Sprintf("  Error: failed to obtain validators: %v\n", err))
-			} else {
-				res.WriteString(fmt.Sprintf("    Validator: %#x (%d)\n", validators[0].Validator.PublicKey, voluntaryExit.Message.ValidatorIndex))
-				res.WriteString(fmt.Sprintf("    Epoch: %d\n", voluntaryExit.Message.Epoch))
+			index := voluntaryExit.Message.ValidatorIndex
+			validator := fmt.Sprintf("%d", index)
+			if validatorsProvider, isProvider := eth2Client.(eth2client.ValidatorsProvider); isProvider {
+				validators, err := validatorsProvider.Validators(ctx, "head", []phase0.ValidatorIndex{index})
+				if err != nil {
+					res.WriteString(fmt.Sprintf("  Error: failed to obtain validators: %v\n", err))
+					continue
+				}
+				if v, exists := validators[index]; exists {
+					validator = fmt.Sprintf("%#x (%d)", v.Validator.PublicKey, index)
+				}
 			}
+			res.WriteString(fmt.Sprintf("    Validator: %s\n", validator))
+			res.WriteString(fmt.Sprintf("    Epoch: %d\n", voluntaryExit.Message.Epoch))
 		}
 	}
 
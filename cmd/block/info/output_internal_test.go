@@ -15,7 +15,9 @@ package blockinfo
 
 import (
 	"context"
+	"fmt"
 	"testing"
+	"time"
 
 	"github.com/aaron-alderman/ethdo/testutil"
 	spec "github.com/attestantio/go-eth2-client/spec/phase0"
@@ -175,3 +177,70 @@ func TestOutputBlockETH1Data(t *testing.T) {
 		})
 	}
 }
+
+func TestOutputBlockGeneral(t *testing.T) {
+	tests := []struct {
+		name        string
+		genesisTime time.Time
+		res         string
+	}{
+		{
+			name: "NoGenesis",
+			res:  "Slot: 65\nEpoch: 2\nBlock root: 0x0000000000000000000000000000000000000000000000000000000000000000\n",
+		},
+		{
+			name:        "Genesis",
+			genesisTime: time.Unix(1606824023, 0),
+			res:         fmt.Sprintf("Slot: 65\nEpoch: 2\nTimestamp: %v\nBlock root: 0x0000000000000000000000000000000000000000000000000000000000000000\n", time.Unix(1606824803, 0)),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			res, err := outputBlockGeneral(context.Background(), false, 65, spec.Root{}, spec.Root{}, spec.Root{}, spec.Root{}, nil, test.genesisTime, 12*time.Second, 32)
+			require.NoError(t, err)
+			require.Equal(t, test.res, res)
+		})
+	}
+}
+
+func TestOutputBlockVoluntaryExits(t *testing.T) {
+	tests := []struct {
+		name           string
+		verbose        bool
+		voluntaryExits []*spec.SignedVoluntaryExit
+		res            string
+	}{
+		{
+			name: "Empty",
+			res:  "Voluntary exits: 0\n",
+		},
+		{
+			name: "Single",
+			voluntaryExits: []*spec.SignedVoluntaryExit{
+				{
+					Message: &spec.VoluntaryExit{Epoch: 2, ValidatorIndex: 7},
+				},
+			},
+			res: "Voluntary exits: 1\n",
+		},
+		{
+			name: "SingleVerboseNoClient",
+			voluntaryExits: []*spec.SignedVoluntaryExit{
+				{
+					Message: &spec.VoluntaryExit{Epoch: 2, ValidatorIndex: 7},
+				},
+			},
+			verbose: true,
+			res:     "Voluntary exits: 1\n  0:\n    Validator: 7\n    Epoch: 2\n",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			res, err := outputBlockVoluntaryExits(context.Background(), nil, test.verbose, test.voluntaryExits)
+			require.NoError(t, err)
+			require.Equal(t, test.res, res)
+		})
+	}
+}
@@ -15,10 +15,10 @@ package blockinfo
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"time"
 
+	"github.com/aaron-alderman/ethdo/util"
 	eth2client "github.com/attestantio/go-eth2-client"
 	api "github.com/attestantio/go-eth2-client/api/v1"
 	"github.com/attestantio/go-eth2-client/spec"
@@ -28,7 +28,7 @@ import (
 	"github.com/pkg/errors"
 )
 
-var jsonOutput bool
+var format string
 var sszOutput bool
 var results *dataOut
 
@@ -43,36 +43,51 @@ func process(ctx context.Context, data *dataIn) (*dataOut, error) {
 		eth2Client: data.eth2Client,
 	}
 
-	config, err := results.eth2Client.(eth2client.SpecProvider).Spec(ctx)
-	if err != nil {
-		return nil, errors.Wrap(err, "failed to connect to obtain configuration information")
-	}
-	genesis, err := results.eth2Client.(eth2client.GenesisProvider).Genesis(ctx)
-	if err != nil {
-		return nil, errors.Wrap(err, "failed to connect to obtain genesis information")
+	if results.eth2Client != nil {
+		config, err := results.eth2Client.(eth2client.SpecProvider).Spec(ctx)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to connect to obtain configuration information")
+		}
+		genesis, err := results.eth2Client.(eth2client.GenesisProvider).Genesis(ctx)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to connect to obtain genesis information")
+		}
+		results.genesisTime = genesis.GenesisTime
+		results.slotDuration = config["SECONDS_PER_SLOT"].(time.Duration)
+		results.slotsPerEpoch = config["SLOTS_PER_EPOCH"].(uint64)
+	} else {
+		chainInfo := util.DefaultChainInfo()
+		results.slotDuration = chainInfo.SlotDuration
+		results.slotsPerEpoch = chainInfo.SlotsPerEpoch
 	}
-	results.genesisTime = genesis.GenesisTime
-	results.slotDuration = config["SECONDS_PER_SLOT"].(time.Duration)
-	results.slotsPerEpoch = config["SLOTS_PER_EPOCH"].(uint64)
 
-	signedBlock, err := results.eth2Client.(eth2client.SignedBeaconBlockProvider).SignedBeaconBlock(ctx, data.blockID)
-	if err != nil {
-		return nil, errors.Wrap(err, "failed to obtain beacon block")
+	var signedBlock *spec.VersionedSignedBeaconBlock
+	var err error
+	if data.file != nil {
+		signedBlock, err = util.ParseSignedBeaconBlock(data.file)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to parse beacon block")
+		}
+	} else {
+		signedBlock, err = results.eth2Client.(eth2client.SignedBeaconBlockProvider).SignedBeaconBlock(ctx, data.blockID)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to obtain beacon block")
+		}
 	}
 	if signedBlock == nil {
 		return nil, errors.New("empty beacon block")
 	}
 	switch signedBlock.Version {
 	case spec.DataVersionPhase0:
-		if err := outputPhase0Block(ctx, data.jsonOutput, signedBlock.Phase0); err != nil {
+		if err := outputPhase0Block(ctx, data.format, data.sszOutput, signedBlock.Phase0); err != nil {
 			return nil, errors.Wrap(err, "failed to output block")
 		}
 	case spec.DataVersionAltair:
-		if err := outputAltairBlock(ctx, data.jsonOutput, data.sszOutput, signedBlock.Altair); err != nil {
+		if err := outputAltairBlock(ctx, data.format, data.sszOutput, signedBlock.Altair); err != nil {
 			return nil, errors.Wrap(err, "failed to output block")
 		}
 	case spec.DataVersionBellatrix:
-		if err := outputBellatrixBlock(ctx, data.jsonOutput, data.sszOutput, signedBlock.Bellatrix); err != nil {
+		if err := outputBellatrixBlock(ctx, data.format, data.sszOutput, signedBlock.Bellatrix); err != nil {
 			return nil, errors.Wrap(err, "failed to output block")
 		}
 	default:
@@ -80,9 +95,9 @@ func process(ctx context.Context, data *dataIn) (*dataOut, error) {
 	}
 
 	if data.stream {
-		jsonOutput = data.jsonOutput
+		format = data.format
 		sszOutput = data.sszOutput
-		if !jsonOutput && !sszOutput {
+		if !util.StructuredFormat(format) && !sszOutput {
 			fmt.Println("")
 		}
 		err := data.eth2Client.(eth2client.EventsProvider).Events(ctx, []string{"head"}, headEventHandler)
@@ -104,58 +119,64 @@ func headEventHandler(event *api.Event) {
 	blockID := fmt.Sprintf("%#x", event.Data.(*api.HeadEvent).Block[:])
 	signedBlock, err := results.eth2Client.(eth2client.SignedBeaconBlockProvider).SignedBeaconBlock(context.Background(), blockID)
 	if err != nil {
-		if !jsonOutput && !sszOutput {
+		if !util.StructuredFormat(format) && !sszOutput {
 			fmt.Printf("Failed to obtain block: %v\n", err)
 		}
 		return
 	}
 	if signedBlock == nil {
-		if !jsonOutput && !sszOutput {
+		if !util.StructuredFormat(format) && !sszOutput {
 			fmt.Println("Empty beacon block")
 		}
 		return
 	}
 	switch signedBlock.Version {
 	case spec.DataVersionPhase0:
-		if err := outputPhase0Block(context.Background(), jsonOutput, signedBlock.Phase0); err != nil {
-			if !jsonOutput && !sszOutput {
+		if err := outputPhase0Block(context.Background(), format, sszOutput, signedBlock.Phase0); err != nil {
+			if !util.StructuredFormat(format) && !sszOutput {
 				fmt.Printf("Failed to output block: %v\n", err)
 			}
 			return
 		}
 	case spec.DataVersionAltair:
-		if err := outputAltairBlock(context.Background(), jsonOutput, sszOutput, signedBlock.Altair); err != nil {
-			if !jsonOutput && !sszOutput {
+		if err := outputAltairBlock(context.Background(), format, sszOutput, signedBlock.Altair); err != nil {
+			if !util.StructuredFormat(format) && !sszOutput {
 				fmt.Printf("Failed to output block: %v\n", err)
 			}
 			return
 		}
 	case spec.DataVersionBellatrix:
-		if err := outputBellatrixBlock(context.Background(), jsonOutput, sszOutput, signedBlock.Bellatrix); err != nil {
-			if !jsonOutput && !sszOutput {
+		if err := outputBellatrixBlock(context.Background(), format, sszOutput, signedBlock.Bellatrix); err != nil {
+			if !util.StructuredFormat(format) && !sszOutput {
 				fmt.Printf("Failed to output block: %v\n", err)
 			}
 			return
 		}
 	default:
-		if !jsonOutput && !sszOutput {
+		if !util.StructuredFormat(format) && !sszOutput {
 			fmt.Printf("Unknown block version: %v\n", signedBlock.Version)
 		}
 		return
 	}
-	if !jsonOutput && !sszOutput {
+	if !util.StructuredFormat(format) && !sszOutput {
 		fmt.Println("")
 	}
 }
 
-func outputPhase0Block(ctx context.Context, jsonOutput bool, signedBlock *phase0.SignedBeaconBlock) error {
+func outputPhase0Block(ctx context.Context, format string, sszOutput bool, signedBlock *phase0.SignedBeaconBlock) error {
 	switch {
-	case jsonOutput:
-		data, err := json.Marshal(signedBlock)
+	case util.StructuredFormat(format):
+		data, err := util.FormatOutput(format, signedBlock)
 		if err != nil {
-			return errors.Wrap(err, "failed to generate JSON")
+			return err
 		}
-		fmt.Printf("%s\n", string(data))
+		fmt.Println(data)
+	case sszOutput:
+		data, err := signedBlock.MarshalSSZ()
+		if err != nil {
+			return errors.Wrap(err, "failed to generate SSZ")
+		}
+		fmt.Printf("%x\n", data)
 	default:
 		data, err := outputPhase0BlockText(ctx, results, signedBlock)
 		if err != nil {
@@ -166,14 +187,14 @@ func outputPhase0Block(ctx context.Context, jsonOutput bool, signedBlock *phase0
 	return nil
 }
 
-func outputAltairBlock(ctx context.Context, jsonOutput bool, sszOutput bool, signedBlock *altair.SignedBeaconBlock) error {
+func outputAltairBlock(ctx context.Context, format string, sszOutput bool, signedBlock *altair.SignedBeaconBlock) error {
 	switch {
-	case jsonOutput:
-		data, err := json.Marshal(signedBlock)
+	case util.StructuredFormat(format):
+		data, err := util.FormatOutput(format, signedBlock)
 		if err != nil {
-			return errors.Wrap(err, "failed to generate JSON")
+			return err
 		}
-		fmt.Printf("%s\n", string(data))
+		fmt.Println(data)
 	case sszOutput:
 		data, err := signedBlock.MarshalSSZ()
 		if err != nil {
@@ -190,14 +211,14 @@ func outputAltairBlock(ctx context.Context, jsonOutput bool, sszOutput bool, sig
 	return nil
 }
 
-func outputBellatrixBlock(ctx context.Context, jsonOutput bool, sszOutput bool, signedBlock *bellatrix.SignedBeaconBlock) error {
+func outputBellatrixBlock(ctx context.Context, format string, sszOutput bool, signedBlock *bellatrix.SignedBeaconBlock) error {
 	switch {
-	case jsonOutput:
-		data, err := json.Marshal(signedBlock)
+	case util.StructuredFormat(format):
+		data, err := util.FormatOutput(format, signedBlock)
 		if err != nil {
-			return errors.Wrap(err, "failed to generate JSON")
+			return err
 		}
-		fmt.Printf("%s\n", string(data))
+		fmt.Println(data)
 	case sszOutput:
 		data, err := signedBlock.MarshalSSZ()
 		if err != nil {
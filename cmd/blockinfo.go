@@ -28,6 +28,8 @@ var blockInfoCmd = &cobra.Command{
 
     ethdo block info --blockid=12345
 
+A block previously written out in JSON or SSZ format can be read back with --file, in which case a beacon node is only used if --connection is supplied.
+
 In quiet mode this will return 0 if the block information is present and not skipped, otherwise 1.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		res, err := blockinfo.Run(cmd)
@@ -51,6 +53,7 @@ func init() {
 	blockInfoCmd.Flags().Bool("stream", false, "continually stream blocks as they arrive")
 	blockInfoCmd.Flags().Bool("json", false, "output data in JSON format")
 	blockInfoCmd.Flags().Bool("ssz", false, "output data in SSZ format")
+	blockInfoCmd.Flags().String("file", "", "the name of a file containing a JSON or SSZ block to decode instead of fetching one from a beacon node")
 }
 
 func blockInfoBindings() {
@@ -66,4 +69,7 @@ func blockInfoBindings() {
 	if err := viper.BindPFlag("ssz", blockInfoCmd.Flags().Lookup("ssz")); err != nil {
 		panic(err)
 	}
+	if err := viper.BindPFlag("file", blockInfoCmd.Flags().Lookup("file")); err != nil {
+		panic(err)
+	}
 }
@@ -0,0 +1,65 @@
+// Copyright © 2022 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cacheinfo
+
+import (
+	"context"
+
+	"github.com/aaron-alderman/ethdo/services/cache"
+	"github.com/aaron-alderman/ethdo/util"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+)
+
+type command struct {
+	quiet   bool
+	verbose bool
+	debug   bool
+
+	// Input.
+	cacheDir string
+	format   string
+
+	// Data access.
+	cache cache.Service
+
+	// Results.
+	kinds []*kindInfo
+}
+
+// kindInfo contains information about a kind of data in the cache.
+type kindInfo struct {
+	Kind    string      `json:"kind"`
+	Entries int         `json:"entries"`
+	Size    int64       `json:"size"`
+	MinSlot phase0.Slot `json:"min_slot"`
+	MaxSlot phase0.Slot `json:"max_slot"`
+}
+
+func newCommand(ctx context.Context) (*command, error) {
+	c := &command{
+		quiet:   viper.GetBool("quiet"),
+		verbose: viper.GetBool("verbose"),
+		debug:   viper.GetBool("debug"),
+	}
+
+	if viper.GetString("cache-dir") == "" {
+		return nil, errors.New("cache-dir is required")
+	}
+	c.cacheDir = viper.GetString("cache-dir")
+	c.format = util.OutputFormat()
+
+	return c, nil
+}
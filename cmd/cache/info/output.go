@@ -0,0 +1,60 @@
+// Copyright © 2022 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cacheinfo
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/aaron-alderman/ethdo/util"
+)
+
+func (c *command) output(ctx context.Context) (string, error) {
+	if c.quiet {
+		return "", nil
+	}
+
+	if util.StructuredFormat(c.format) {
+		return c.outputStructured(ctx)
+	}
+	return c.outputText(ctx)
+}
+
+func (c *command) outputStructured(_ context.Context) (string, error) {
+	data, err := json.Marshal(c.kinds)
+	if err != nil {
+		return "", err
+	}
+	return util.FormatJSONData(c.format, data)
+}
+
+func (c *command) outputText(_ context.Context) (string, error) {
+	builder := strings.Builder{}
+
+	for _, info := range c.kinds {
+		if info.Entries == 0 {
+			builder.WriteString(fmt.Sprintf("%s: no entries\n", info.Kind))
+			continue
+		}
+		builder.WriteString(fmt.Sprintf("%s: %d entries for slots %d-%d", info.Kind, info.Entries, info.MinSlot, info.MaxSlot))
+		if c.verbose {
+			builder.WriteString(fmt.Sprintf(" (%d bytes)", info.Size))
+		}
+		builder.WriteString("\n")
+	}
+
+	return strings.TrimSuffix(builder.String(), "\n"), nil
+}
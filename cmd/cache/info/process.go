@@ -0,0 +1,58 @@
+// Copyright © 2022 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cacheinfo
+
+import (
+	"context"
+
+	"github.com/aaron-alderman/ethdo/services/cache"
+	"github.com/aaron-alderman/ethdo/util"
+	"github.com/pkg/errors"
+)
+
+func (c *command) process(ctx context.Context) error {
+	var err error
+	c.cache, err = util.OpenCache(ctx, c.cacheDir)
+	if err != nil {
+		return err
+	}
+
+	c.kinds = make([]*kindInfo, 0, len(cache.Kinds))
+	for _, kind := range cache.Kinds {
+		entries, err := c.cache.Entries(ctx, cache.KindPrefix(kind))
+		if err != nil {
+			return errors.Wrap(err, "failed to obtain cache entries")
+		}
+		info := &kindInfo{
+			Kind:    kind,
+			Entries: len(entries),
+		}
+		for i, entry := range entries {
+			_, slot, err := cache.ParseKey(entry.Key)
+			if err != nil {
+				return errors.Wrapf(err, "invalid key %s", string(entry.Key))
+			}
+			if i == 0 || slot < info.MinSlot {
+				info.MinSlot = slot
+			}
+			if slot > info.MaxSlot {
+				info.MaxSlot = slot
+			}
+			info.Size += entry.Size
+		}
+		c.kinds = append(c.kinds, info)
+	}
+
+	return nil
+}
@@ -0,0 +1,61 @@
+// Copyright © 2022 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cacheinfo
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/aaron-alderman/ethdo/services/cache"
+	"github.com/aaron-alderman/ethdo/util"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProcess(t *testing.T) {
+	ctx := context.Background()
+
+	cacheDir, err := ioutil.TempDir("", "")
+	require.NoError(t, err)
+	defer os.RemoveAll(cacheDir)
+	defer func() {
+		require.NoError(t, util.CloseCache(ctx))
+	}()
+
+	cacheSvc, err := util.OpenCache(ctx, cacheDir)
+	require.NoError(t, err)
+	require.NoError(t, cacheSvc.Store(ctx, cache.BlockKey(5), []byte("block 5")))
+	require.NoError(t, cacheSvc.Store(ctx, cache.BlockKey(10), []byte{}))
+	require.NoError(t, cacheSvc.Store(ctx, cache.HeaderKey(7), []byte("header 7")))
+
+	viper.Reset()
+	viper.Set("cache-dir", cacheDir)
+	cmd, err := newCommand(ctx)
+	require.NoError(t, err)
+	require.NoError(t, cmd.process(ctx))
+
+	require.Len(t, cmd.kinds, len(cache.Kinds))
+	require.Equal(t, cache.KindBlocks, cmd.kinds[0].Kind)
+	require.Equal(t, 2, cmd.kinds[0].Entries)
+	require.EqualValues(t, 5, cmd.kinds[0].MinSlot)
+	require.EqualValues(t, 10, cmd.kinds[0].MaxSlot)
+	require.Equal(t, cache.KindHeaders, cmd.kinds[1].Kind)
+	require.Equal(t, 1, cmd.kinds[1].Entries)
+
+	res, err := cmd.output(ctx)
+	require.NoError(t, err)
+	require.Equal(t, "blocks: 2 entries for slots 5-10\nheaders: 1 entries for slots 7-7\ncommittees: no entries\nsynccommittees: no entries", res)
+}
@@ -0,0 +1,91 @@
+// Copyright © 2022 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cacheprune
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/aaron-alderman/ethdo/services/cache"
+	"github.com/aaron-alderman/ethdo/util"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+)
+
+type command struct {
+	quiet   bool
+	format  string
+	verbose bool
+	debug   bool
+
+	// Input.
+	cacheDir   string
+	kinds      []string
+	beforeSlot phase0.Slot
+	all        bool
+
+	// Data access.
+	cache cache.Service
+
+	// Results.
+	pruned int
+}
+
+func newCommand(ctx context.Context) (*command, error) {
+	c := &command{
+		quiet:   viper.GetBool("quiet"),
+		format:  util.OutputFormat(),
+		verbose: viper.GetBool("verbose"),
+		debug:   viper.GetBool("debug"),
+	}
+
+	if viper.GetString("cache-dir") == "" {
+		return nil, errors.New("cache-dir is required")
+	}
+	c.cacheDir = viper.GetString("cache-dir")
+
+	c.kinds = cache.Kinds
+	if viper.GetString("kind") != "" {
+		kind := viper.GetString("kind")
+		found := false
+		for _, knownKind := range cache.Kinds {
+			if kind == knownKind {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("unknown kind %s", kind)
+		}
+		c.kinds = []string{kind}
+	}
+
+	c.all = viper.GetBool("all")
+	if viper.GetString("before-slot") != "" {
+		if c.all {
+			return nil, errors.New("only one of all and before-slot allowed")
+		}
+		beforeSlot, err := strconv.ParseUint(viper.GetString("before-slot"), 10, 64)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid before-slot")
+		}
+		c.beforeSlot = phase0.Slot(beforeSlot)
+	} else if !c.all {
+		return nil, errors.New("one of all or before-slot is required")
+	}
+
+	return c, nil
+}
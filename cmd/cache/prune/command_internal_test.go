@@ -0,0 +1,95 @@
+// Copyright © 2022 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cacheprune
+
+import (
+	"context"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInput(t *testing.T) {
+	tests := []struct {
+		name string
+		vars map[string]interface{}
+		err  string
+	}{
+		{
+			name: "CacheDirMissing",
+			vars: map[string]interface{}{
+				"all": true,
+			},
+			err: "cache-dir is required",
+		},
+		{
+			name: "KindUnknown",
+			vars: map[string]interface{}{
+				"cache-dir": "/tmp/cache",
+				"kind":      "unknown",
+				"all":       true,
+			},
+			err: "unknown kind unknown",
+		},
+		{
+			name: "FilterMissing",
+			vars: map[string]interface{}{
+				"cache-dir": "/tmp/cache",
+			},
+			err: "one of all or before-slot is required",
+		},
+		{
+			name: "AllAndBeforeSlot",
+			vars: map[string]interface{}{
+				"cache-dir":   "/tmp/cache",
+				"all":         true,
+				"before-slot": "100",
+			},
+			err: "only one of all and before-slot allowed",
+		},
+		{
+			name: "BeforeSlotInvalid",
+			vars: map[string]interface{}{
+				"cache-dir":   "/tmp/cache",
+				"before-slot": "invalid",
+			},
+			err: "invalid before-slot: strconv.ParseUint: parsing \"invalid\": invalid syntax",
+		},
+		{
+			name: "Good",
+			vars: map[string]interface{}{
+				"cache-dir":   "/tmp/cache",
+				"kind":        "blocks",
+				"before-slot": "100",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			viper.Reset()
+
+			for k, v := range test.vars {
+				viper.Set(k, v)
+			}
+			_, err := newCommand(context.Background())
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,42 @@
+// Copyright © 2022 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cacheprune
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aaron-alderman/ethdo/util"
+)
+
+type pruneJSON struct {
+	Pruned int `json:"pruned"`
+}
+
+func (c *command) output(_ context.Context) (string, error) {
+	if c.quiet {
+		return "", nil
+	}
+
+	if util.StructuredFormat(c.format) {
+		return util.FormatOutput(c.format, &pruneJSON{
+			Pruned: c.pruned,
+		})
+	}
+
+	if c.pruned == 1 {
+		return "Pruned 1 entry", nil
+	}
+	return fmt.Sprintf("Pruned %d entries", c.pruned), nil
+}
@@ -0,0 +1,64 @@
+// Copyright © 2022 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cacheprune
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/aaron-alderman/ethdo/services/cache"
+	"github.com/aaron-alderman/ethdo/util"
+	"github.com/pkg/errors"
+)
+
+func (c *command) process(ctx context.Context) error {
+	var err error
+	c.cache, err = util.OpenCache(ctx, c.cacheDir)
+	if err != nil {
+		return err
+	}
+
+	keys := make([][]byte, 0)
+	for _, kind := range c.kinds {
+		entries, err := c.cache.Entries(ctx, cache.KindPrefix(kind))
+		if err != nil {
+			return errors.Wrap(err, "failed to obtain cache entries")
+		}
+		for _, entry := range entries {
+			if !c.all {
+				_, slot, err := cache.ParseKey(entry.Key)
+				if err != nil {
+					return errors.Wrapf(err, "invalid key %s", string(entry.Key))
+				}
+				if slot >= c.beforeSlot {
+					continue
+				}
+			}
+			if c.debug {
+				fmt.Fprintf(os.Stderr, "Pruning %s\n", string(entry.Key))
+			}
+			keys = append(keys, entry.Key)
+		}
+	}
+
+	if len(keys) > 0 {
+		if err := c.cache.Delete(ctx, keys); err != nil {
+			return errors.Wrap(err, "failed to prune cache")
+		}
+	}
+	c.pruned = len(keys)
+
+	return nil
+}
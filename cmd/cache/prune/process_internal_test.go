@@ -0,0 +1,103 @@
+// Copyright © 2022 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cacheprune
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/aaron-alderman/ethdo/services/cache"
+	"github.com/aaron-alderman/ethdo/util"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProcess(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name      string
+		vars      map[string]interface{}
+		pruned    int
+		remaining [][]byte
+	}{
+		{
+			name: "BeforeSlot",
+			vars: map[string]interface{}{
+				"before-slot": "10",
+			},
+			pruned: 2,
+			remaining: [][]byte{
+				cache.BlockKey(10),
+			},
+		},
+		{
+			name: "Kind",
+			vars: map[string]interface{}{
+				"kind":        "headers",
+				"before-slot": "10",
+			},
+			pruned: 1,
+			remaining: [][]byte{
+				cache.BlockKey(5),
+				cache.BlockKey(10),
+			},
+		},
+		{
+			name: "All",
+			vars: map[string]interface{}{
+				"all": true,
+			},
+			pruned:    3,
+			remaining: [][]byte{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cacheDir, err := ioutil.TempDir("", "")
+			require.NoError(t, err)
+			defer os.RemoveAll(cacheDir)
+			defer func() {
+				require.NoError(t, util.CloseCache(ctx))
+			}()
+
+			cacheSvc, err := util.OpenCache(ctx, cacheDir)
+			require.NoError(t, err)
+			require.NoError(t, cacheSvc.Store(ctx, cache.BlockKey(5), []byte("block 5")))
+			require.NoError(t, cacheSvc.Store(ctx, cache.BlockKey(10), []byte{}))
+			require.NoError(t, cacheSvc.Store(ctx, cache.HeaderKey(7), []byte("header 7")))
+
+			viper.Reset()
+			viper.Set("cache-dir", cacheDir)
+			for k, v := range test.vars {
+				viper.Set(k, v)
+			}
+			cmd, err := newCommand(ctx)
+			require.NoError(t, err)
+			require.NoError(t, cmd.process(ctx))
+			require.Equal(t, test.pruned, cmd.pruned)
+
+			entries, err := cacheSvc.Entries(ctx, nil)
+			require.NoError(t, err)
+			keys := make([][]byte, 0, len(entries))
+			for _, entry := range entries {
+				keys = append(keys, entry.Key)
+			}
+			require.ElementsMatch(t, test.remaining, keys)
+		})
+	}
+}
@@ -0,0 +1,57 @@
+// Copyright © 2022 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	cacheinfo "github.com/aaron-alderman/ethdo/cmd/cache/info"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var cacheInfoCmd = &cobra.Command{
+	Use:   "info",
+	Short: "Obtain information about the cache",
+	Long: `Obtain information about the data held in the cache.  For example:
+
+    ethdo cache info --cache-dir=/home/me/.ethdo-cache
+
+In quiet mode this will return 0 if the cache can be opened, otherwise 1.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		res, err := cacheinfo.Run(cmd)
+		if err != nil {
+			return err
+		}
+		if viper.GetBool("quiet") {
+			return nil
+		}
+		if res != "" {
+			fmt.Println(res)
+		}
+		return nil
+	},
+}
+
+func init() {
+	cacheCmd.AddCommand(cacheInfoCmd)
+	cacheFlags(cacheInfoCmd)
+	cacheInfoCmd.Flags().Bool("json", false, "output data in JSON format")
+}
+
+func cacheInfoBindings() {
+	if err := viper.BindPFlag("json", cacheInfoCmd.Flags().Lookup("json")); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,65 @@
+// Copyright © 2022 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	cacheprune "github.com/aaron-alderman/ethdo/cmd/cache/prune"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var cachePruneCmd = &cobra.Command{
+	Use:   "prune",
+	Short: "Remove data from the cache",
+	Long: `Remove data from the cache.  For example:
+
+    ethdo cache prune --cache-dir=/home/me/.ethdo-cache --before-slot=1000000
+
+In quiet mode this will return 0 if the cache is pruned, otherwise 1.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		res, err := cacheprune.Run(cmd)
+		if err != nil {
+			return err
+		}
+		if viper.GetBool("quiet") {
+			return nil
+		}
+		if res != "" {
+			fmt.Println(res)
+		}
+		return nil
+	},
+}
+
+func init() {
+	cacheCmd.AddCommand(cachePruneCmd)
+	cacheFlags(cachePruneCmd)
+	cachePruneCmd.Flags().String("kind", "", "the kind of data to prune (blocks, headers, committees or synccommittees; default all kinds)")
+	cachePruneCmd.Flags().String("before-slot", "", "prune data for slots before this slot")
+	cachePruneCmd.Flags().Bool("all", false, "prune all data")
+}
+
+func cachePruneBindings() {
+	if err := viper.BindPFlag("kind", cachePruneCmd.Flags().Lookup("kind")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("before-slot", cachePruneCmd.Flags().Lookup("before-slot")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("all", cachePruneCmd.Flags().Lookup("all")); err != nil {
+		panic(err)
+	}
+}
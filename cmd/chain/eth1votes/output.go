@@ -20,6 +20,7 @@ import (
 	"sort"
 	"strings"
 
+	"github.com/aaron-alderman/ethdo/util"
 	"github.com/attestantio/go-eth2-client/spec/phase0"
 )
 
@@ -36,13 +37,13 @@ func (c *command) output(ctx context.Context) (string, error) {
 		return "", nil
 	}
 
-	if c.json {
-		return c.outputJSON(ctx)
+	if util.StructuredFormat(c.format) {
+		return c.outputStructured(ctx)
 	}
 	return c.outputText(ctx)
 }
 
-func (c *command) outputJSON(ctx context.Context) (string, error) {
+func (c *command) outputStructured(ctx context.Context) (string, error) {
 	votes := make([]*vote, 0, len(c.votes))
 	totalVotes := 0
 	for _, vote := range c.votes {
@@ -68,7 +69,7 @@ func (c *command) outputJSON(ctx context.Context) (string, error) {
 		return "", err
 	}
 
-	return string(data), nil
+	return util.FormatJSONData(c.format, data)
 }
 
 func (c *command) outputText(ctx context.Context) (string, error) {
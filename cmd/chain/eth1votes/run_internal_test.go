@@ -0,0 +1,90 @@
+// Copyright © 2022 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chaineth1votes
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aaron-alderman/ethdo/testing/beaconnode"
+	"github.com/rs/zerolog"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRun(t *testing.T) {
+	zerolog.SetGlobalLevel(zerolog.Disabled)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	beaconNode, err := beaconnode.New(ctx,
+		beaconnode.WithFixturesDir("../../../testing/beaconnode/testdata"),
+	)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name string
+		vars map[string]interface{}
+		res  string
+		err  string
+	}{
+		{
+			name: "Good",
+			vars: map[string]interface{}{
+				"timeout":    "5s",
+				"epoch":      "0",
+				"connection": beaconNode.Address(),
+			},
+			res: "Voting period: 0\nSlots through period: 32 (31)\nVotes this period: 3\nLeading vote is for block 0x1111111111111111111111111111111111111111111111111111111111111111 with 2 votes (6.25%)",
+		},
+		{
+			name: "Verbose",
+			vars: map[string]interface{}{
+				"timeout":    "5s",
+				"epoch":      "0",
+				"connection": beaconNode.Address(),
+				"verbose":    true,
+			},
+			res: "Voting period: 0\nIncumbent: block 0x1010101010101010101010101010101010101010101010101010101010101010, deposit count 100\nSlots through period: 32 (31)\nVotes this period: 3\n  block 0x1111111111111111111111111111111111111111111111111111111111111111, deposit count 101: 2 votes (6.25%)\n  block 0x1212121212121212121212121212121212121212121212121212121212121212, deposit count 102: 1 vote (3.12%)",
+		},
+		{
+			name: "JSON",
+			vars: map[string]interface{}{
+				"timeout":    "5s",
+				"epoch":      "0",
+				"connection": beaconNode.Address(),
+				"json":       true,
+			},
+			res: `{"period":0,"epoch":0,"slot":31,"incumbent":{"deposit_root":"0x1010101010101010101010101010101010101010101010101010101010101010","deposit_count":"100","block_hash":"0x1010101010101010101010101010101010101010101010101010101010101010"},"votes":[{"vote":{"deposit_root":"0x1111111111111111111111111111111111111111111111111111111111111111","deposit_count":"101","block_hash":"0x1111111111111111111111111111111111111111111111111111111111111111"},"count":2},{"vote":{"deposit_root":"0x1212121212121212121212121212121212121212121212121212121212121212","deposit_count":"102","block_hash":"0x1212121212121212121212121212121212121212121212121212121212121212"},"count":1}]}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			viper.Reset()
+
+			for k, v := range test.vars {
+				viper.Set(k, v)
+			}
+			res, err := Run(&cobra.Command{})
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, test.res, res)
+			}
+		})
+	}
+}
@@ -18,6 +18,7 @@ import (
 	"time"
 
 	"github.com/aaron-alderman/ethdo/services/chaintime"
+	"github.com/aaron-alderman/ethdo/util"
 	eth2client "github.com/attestantio/go-eth2-client"
 	"github.com/pkg/errors"
 	"github.com/spf13/viper"
@@ -27,7 +28,7 @@ type command struct {
 	quiet   bool
 	verbose bool
 	debug   bool
-	json    bool
+	format  string
 
 	// Beacon node connection.
 	timeout                  time.Duration
@@ -52,7 +53,7 @@ func newCommand(ctx context.Context) (*command, error) {
 		quiet:   viper.GetBool("quiet"),
 		verbose: viper.GetBool("verbose"),
 		debug:   viper.GetBool("debug"),
-		json:    viper.GetBool("json"),
+		format:  util.OutputFormat(),
 	}
 
 	// Timeout.
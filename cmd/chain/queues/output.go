@@ -18,6 +18,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"strings"
+
+	"github.com/aaron-alderman/ethdo/util"
 )
 
 type jsonOutput struct {
@@ -30,13 +32,13 @@ func (c *command) output(ctx context.Context) (string, error) {
 		return "", nil
 	}
 
-	if c.json {
-		return c.outputJSON(ctx)
+	if util.StructuredFormat(c.format) {
+		return c.outputStructured(ctx)
 	}
 	return c.outputText(ctx)
 }
 
-func (c *command) outputJSON(ctx context.Context) (string, error) {
+func (c *command) outputStructured(ctx context.Context) (string, error) {
 	output := &jsonOutput{
 		ActivationQueue: c.activationQueue,
 		ExitQueue:       c.exitQueue,
@@ -46,7 +48,7 @@ func (c *command) outputJSON(ctx context.Context) (string, error) {
 		return "", err
 	}
 
-	return string(data), nil
+	return util.FormatJSONData(c.format, data)
 }
 
 func (c *command) outputText(ctx context.Context) (string, error) {
@@ -0,0 +1,89 @@
+// Copyright © 2022 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chainqueues
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aaron-alderman/ethdo/testing/beaconnode"
+	"github.com/rs/zerolog"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRun(t *testing.T) {
+	zerolog.SetGlobalLevel(zerolog.Disabled)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	beaconNode, err := beaconnode.New(ctx,
+		beaconnode.WithFixturesDir("../../../testing/beaconnode/testdata"),
+	)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name string
+		vars map[string]interface{}
+		res  string
+		err  string
+	}{
+		{
+			name: "InvalidEpoch",
+			vars: map[string]interface{}{
+				"timeout":    "5s",
+				"epoch":      "invalid",
+				"connection": beaconNode.Address(),
+			},
+			err: "failed to process: failed to parse epoch: strconv.ParseInt: parsing \"invalid\": invalid syntax",
+		},
+		{
+			name: "Good",
+			vars: map[string]interface{}{
+				"timeout":    "5s",
+				"epoch":      "10",
+				"connection": beaconNode.Address(),
+			},
+			res: "Activation queue: 1\nExit queue: 1",
+		},
+		{
+			name: "JSON",
+			vars: map[string]interface{}{
+				"timeout":    "5s",
+				"epoch":      "10",
+				"connection": beaconNode.Address(),
+				"json":       true,
+			},
+			res: `{"activation_queue":1,"exit_queue":1}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			viper.Reset()
+
+			for k, v := range test.vars {
+				viper.Set(k, v)
+			}
+			res, err := Run(&cobra.Command{})
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, test.res, res)
+			}
+		})
+	}
+}
@@ -19,6 +19,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/aaron-alderman/ethdo/util"
 	spec "github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/pkg/errors"
 )
@@ -26,6 +27,7 @@ import (
 type dataOut struct {
 	debug   bool
 	quiet   bool
+	format  string
 	verbose bool
 
 	epoch                         spec.Epoch
@@ -41,6 +43,20 @@ type dataOut struct {
 	syncCommitteePeriodEpochEnd   spec.Epoch
 }
 
+type timeJSON struct {
+	Epoch                         spec.Epoch `json:"epoch"`
+	EpochStart                    time.Time  `json:"epoch_start"`
+	EpochEnd                      time.Time  `json:"epoch_end"`
+	Slot                          spec.Slot  `json:"slot"`
+	SlotStart                     time.Time  `json:"slot_start"`
+	SlotEnd                       time.Time  `json:"slot_end"`
+	SyncCommitteePeriod           uint64     `json:"sync_committee_period"`
+	SyncCommitteePeriodStart      time.Time  `json:"sync_committee_period_start"`
+	SyncCommitteePeriodEpochStart spec.Epoch `json:"sync_committee_period_epoch_start"`
+	SyncCommitteePeriodEnd        time.Time  `json:"sync_committee_period_end"`
+	SyncCommitteePeriodEpochEnd   spec.Epoch `json:"sync_committee_period_epoch_end"`
+}
+
 func output(ctx context.Context, data *dataOut) (string, error) {
 	if data == nil {
 		return "", errors.New("no data")
@@ -50,6 +66,22 @@ func output(ctx context.Context, data *dataOut) (string, error) {
 		return "", nil
 	}
 
+	if util.StructuredFormat(data.format) {
+		return util.FormatOutput(data.format, &timeJSON{
+			Epoch:                         data.epoch,
+			EpochStart:                    data.epochStart,
+			EpochEnd:                      data.epochEnd,
+			Slot:                          data.slot,
+			SlotStart:                     data.slotStart,
+			SlotEnd:                       data.slotEnd,
+			SyncCommitteePeriod:           data.syncCommitteePeriod,
+			SyncCommitteePeriodStart:      data.syncCommitteePeriodStart,
+			SyncCommitteePeriodEpochStart: data.syncCommitteePeriodEpochStart,
+			SyncCommitteePeriodEnd:        data.syncCommitteePeriodEnd,
+			SyncCommitteePeriodEpochEnd:   data.syncCommitteePeriodEpochEnd,
+		})
+	}
+
 	builder := strings.Builder{}
 
 	builder.WriteString("Epoch ")
@@ -0,0 +1,99 @@
+// Copyright © 2022 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chainverifysignedaggregateandproof
+
+import (
+	"context"
+	"strings"
+
+	"github.com/aaron-alderman/ethdo/util"
+)
+
+type verifyJSON struct {
+	Valid                                 bool   `json:"valid"`
+	StructureValid                        bool   `json:"structure_valid"`
+	ValidatorKnown                        bool   `json:"validator_known"`
+	ValidatorInCommittee                  bool   `json:"validator_in_committee"`
+	SelectionProofValidFormat             bool   `json:"selection_proof_valid_format"`
+	SelectionProofValid                   bool   `json:"selection_proof_valid"`
+	ValidatorIsAggregator                 bool   `json:"validator_is_aggregator"`
+	AggregateSignatureValidFormat         bool   `json:"aggregate_signature_valid_format"`
+	AggregateSignatureValid               bool   `json:"aggregate_signature_valid"`
+	AggregateAndProofSignatureValidFormat bool   `json:"aggregate_and_proof_signature_valid_format"`
+	AggregateAndProofSignatureValid       bool   `json:"aggregate_and_proof_signature_valid"`
+	AdditionalInfo                        string `json:"additional_info,omitempty"`
+}
+
+func (c *command) output(ctx context.Context) (string, error) {
+	if c.quiet {
+		return "", nil
+	}
+
+	if util.StructuredFormat(c.format) {
+		res, err := util.FormatOutput(c.format, &verifyJSON{
+			Valid:                                 c.aggregateAndProofSignatureValid,
+			StructureValid:                        c.itemStructureValid,
+			ValidatorKnown:                        c.validatorKnown,
+			ValidatorInCommittee:                  c.validatorInCommittee,
+			SelectionProofValidFormat:             c.selectionProofValidFormat,
+			SelectionProofValid:                   c.selectionProofValid,
+			ValidatorIsAggregator:                 c.validatorIsAggregator,
+			AggregateSignatureValidFormat:         c.aggregateSignatureValidFormat,
+			AggregateSignatureValid:               c.aggregateSignatureValid,
+			AggregateAndProofSignatureValidFormat: c.aggregateAndProofSignatureValidFormat,
+			AggregateAndProofSignatureValid:       c.aggregateAndProofSignatureValid,
+			AdditionalInfo:                        c.additionalInfo,
+		})
+		if err != nil {
+			return "", err
+		}
+		return res + "\n", nil
+	}
+
+	checks := []struct {
+		name   string
+		passed bool
+	}{
+		{"Valid data structure", c.itemStructureValid},
+		{"Validator known", c.validatorKnown},
+		{"Validator in committee", c.validatorInCommittee},
+		{"Selection proof has valid format", c.selectionProofValidFormat},
+		{"Selection proof is valid", c.selectionProofValid},
+		{"Validator is aggregator", c.validatorIsAggregator},
+		{"Aggregate signature has valid format", c.aggregateSignatureValidFormat},
+		{"Aggregate signature is valid", c.aggregateSignatureValid},
+		{"Aggregate and proof signature has valid format", c.aggregateAndProofSignatureValidFormat},
+		{"Aggregate and proof signature is valid", c.aggregateAndProofSignatureValid},
+	}
+
+	builder := strings.Builder{}
+	for _, check := range checks {
+		builder.WriteString(check.name)
+		builder.WriteString(": ")
+		if check.passed {
+			builder.WriteString("✓\n")
+			continue
+		}
+		builder.WriteString("✕")
+		if c.additionalInfo != "" {
+			builder.WriteString(" (")
+			builder.WriteString(c.additionalInfo)
+			builder.WriteString(")")
+		}
+		builder.WriteString("\n")
+		break
+	}
+
+	return builder.String(), nil
+}
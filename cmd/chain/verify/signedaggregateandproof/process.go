@@ -0,0 +1,286 @@
+// Copyright © 2022 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chainverifysignedaggregateandproof
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/aaron-alderman/ethdo/util"
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	e2types "github.com/wealdtech/go-eth2-types/v2"
+)
+
+func (c *command) process(ctx context.Context) error {
+	// Parse the data.
+	if c.data == "" {
+		return errors.New("no data supplied")
+	}
+	c.item = &phase0.SignedAggregateAndProof{}
+	err := json.Unmarshal([]byte(c.data), c.item)
+	if err != nil {
+		c.additionalInfo = err.Error()
+		return nil
+	}
+	c.itemStructureValid = true
+
+	// Obtain information we need to process.
+	if err := c.setup(ctx); err != nil {
+		return err
+	}
+	if !c.validatorKnown {
+		return nil
+	}
+
+	for _, validatorIndex := range c.committee {
+		if validatorIndex == c.item.Message.AggregatorIndex {
+			c.validatorInCommittee = true
+			break
+		}
+	}
+	if !c.validatorInCommittee {
+		return nil
+	}
+
+	// Confirm the selection proof.
+	if err := c.confirmSelectionProof(ctx); err != nil {
+		return errors.Wrap(err, "failed to confirm the selection proof")
+	}
+	if !c.selectionProofValid {
+		return nil
+	}
+
+	// Ensure the validator is an aggregator.
+	targetAggregatorsPerCommittee, err := util.SpecUint64(c.spec, "TARGET_AGGREGATORS_PER_COMMITTEE")
+	if err != nil {
+		return err
+	}
+	c.validatorIsAggregator = util.IsAttestationAggregator(uint64(len(c.committee)), targetAggregatorsPerCommittee, c.item.Message.SelectionProof)
+	if !c.validatorIsAggregator {
+		return nil
+	}
+
+	// Confirm the aggregate signature.
+	if err := c.confirmAggregateSignature(ctx); err != nil {
+		return errors.Wrap(err, "failed to confirm the aggregate signature")
+	}
+	if !c.aggregateSignatureValid {
+		return nil
+	}
+
+	// Confirm the aggregate and proof signature.
+	if err := c.confirmAggregateAndProofSignature(ctx); err != nil {
+		return errors.Wrap(err, "failed to confirm the aggregate and proof signature")
+	}
+
+	return nil
+}
+
+func (c *command) setup(ctx context.Context) error {
+	var err error
+
+	// Connect to the client.
+	c.eth2Client, err = util.ConnectToBeaconNode(ctx, c.connection, c.timeout, c.allowInsecureConnections)
+	if err != nil {
+		return errors.Wrap(err, "failed to connect to beacon node")
+	}
+
+	specProvider, isProvider := c.eth2Client.(eth2client.SpecProvider)
+	if !isProvider {
+		return errors.New("connection does not provide spec information")
+	}
+	c.spec, err = specProvider.Spec(ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain spec information")
+	}
+
+	// Obtain the validator.
+	var isValidatorsProvider bool
+	c.validatorsProvider, isValidatorsProvider = c.eth2Client.(eth2client.ValidatorsProvider)
+	if !isValidatorsProvider {
+		return errors.New("connection does not provide validator information")
+	}
+
+	stateID := fmt.Sprintf("%d", c.item.Message.Aggregate.Data.Slot)
+	validators, err := c.validatorsProvider.Validators(ctx,
+		stateID,
+		[]phase0.ValidatorIndex{c.item.Message.AggregatorIndex},
+	)
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain validator information")
+	}
+
+	if len(validators) == 0 || validators[c.item.Message.AggregatorIndex] == nil {
+		return nil
+	}
+	c.validatorKnown = true
+	c.validator = validators[c.item.Message.AggregatorIndex]
+
+	// Obtain the committee.
+	beaconCommitteesProvider, isProvider := c.eth2Client.(eth2client.BeaconCommitteesProvider)
+	if !isProvider {
+		return errors.New("connection does not provide beacon committee information")
+	}
+	committees, err := beaconCommitteesProvider.BeaconCommittees(ctx, stateID)
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain beacon committees")
+	}
+	for _, committee := range committees {
+		if committee.Slot == c.item.Message.Aggregate.Data.Slot && committee.Index == c.item.Message.Aggregate.Data.Index {
+			c.committee = committee.Validators
+			break
+		}
+	}
+	if c.debug {
+		fmt.Fprintf(os.Stderr, "Committee size is %d\n", len(c.committee))
+	}
+
+	return nil
+}
+
+// domain obtains the domain of the given type for the epoch of the given slot.
+func (c *command) domain(ctx context.Context, name string, slot phase0.Slot) (phase0.Domain, error) {
+	domainType, err := util.SpecDomainType(c.spec, name)
+	if err != nil {
+		return phase0.Domain{}, err
+	}
+	slotsPerEpoch, err := util.SpecUint64(c.spec, "SLOTS_PER_EPOCH")
+	if err != nil {
+		return phase0.Domain{}, err
+	}
+	domain, err := c.eth2Client.(eth2client.DomainProvider).Domain(ctx, domainType, phase0.Epoch(uint64(slot)/slotsPerEpoch))
+	if err != nil {
+		return phase0.Domain{}, errors.Wrap(err, "failed to obtain domain")
+	}
+
+	return domain, nil
+}
+
+func (c *command) confirmSelectionProof(ctx context.Context) error {
+	sigBytes := make([]byte, 96)
+	copy(sigBytes, c.item.Message.SelectionProof[:])
+	sig, err := e2types.BLSSignatureFromBytes(sigBytes)
+	if err != nil {
+		c.additionalInfo = err.Error()
+		return nil
+	}
+	c.selectionProofValidFormat = true
+
+	domain, err := c.domain(ctx, "DOMAIN_SELECTION_PROOF", c.item.Message.Aggregate.Data.Slot)
+	if err != nil {
+		return err
+	}
+
+	// The selection proof signs the hash tree root of the slot.
+	var root phase0.Root
+	binary.LittleEndian.PutUint64(root[:8], uint64(c.item.Message.Aggregate.Data.Slot))
+
+	c.selectionProofValid, err = util.VerifyAggregateSignature(sig, []phase0.BLSPubKey{c.validator.Validator.PublicKey}, root, domain)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (c *command) confirmAggregateSignature(ctx context.Context) error {
+	sigBytes := make([]byte, 96)
+	copy(sigBytes, c.item.Message.Aggregate.Signature[:])
+	sig, err := e2types.BLSSignatureFromBytes(sigBytes)
+	if err != nil {
+		c.additionalInfo = err.Error()
+		return nil
+	}
+	c.aggregateSignatureValidFormat = true
+
+	attestingIndices, err := util.AttestingIndices(c.item.Message.Aggregate.AggregationBits, c.committee)
+	if err != nil {
+		c.additionalInfo = err.Error()
+		return nil
+	}
+	if len(attestingIndices) == 0 {
+		c.additionalInfo = "no attesters included"
+		return nil
+	}
+	if c.debug {
+		fmt.Fprintf(os.Stderr, "Aggregate validator indices: %v (%d)\n", attestingIndices, len(attestingIndices))
+	}
+
+	validators, err := c.validatorsProvider.Validators(ctx, fmt.Sprintf("%d", c.item.Message.Aggregate.Data.Slot), attestingIndices)
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain attesting validators")
+	}
+	pubKeys := make([]phase0.BLSPubKey, 0, len(attestingIndices))
+	for _, index := range attestingIndices {
+		validator, exists := validators[index]
+		if !exists {
+			return fmt.Errorf("no information for attesting validator %d", index)
+		}
+		pubKeys = append(pubKeys, validator.Validator.PublicKey)
+	}
+
+	domainType, err := util.SpecDomainType(c.spec, "DOMAIN_BEACON_ATTESTER")
+	if err != nil {
+		return err
+	}
+	domain, err := c.eth2Client.(eth2client.DomainProvider).Domain(ctx, domainType, c.item.Message.Aggregate.Data.Target.Epoch)
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain domain")
+	}
+
+	root, err := c.item.Message.Aggregate.Data.HashTreeRoot()
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain attestation data root")
+	}
+
+	c.aggregateSignatureValid, err = util.VerifyAggregateSignature(sig, pubKeys, root, domain)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (c *command) confirmAggregateAndProofSignature(ctx context.Context) error {
+	sigBytes := make([]byte, 96)
+	copy(sigBytes, c.item.Signature[:])
+	sig, err := e2types.BLSSignatureFromBytes(sigBytes)
+	if err != nil {
+		c.additionalInfo = err.Error()
+		return nil
+	}
+	c.aggregateAndProofSignatureValidFormat = true
+
+	domain, err := c.domain(ctx, "DOMAIN_AGGREGATE_AND_PROOF", c.item.Message.Aggregate.Data.Slot)
+	if err != nil {
+		return err
+	}
+
+	root, err := c.item.Message.HashTreeRoot()
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain aggregate and proof root")
+	}
+
+	c.aggregateAndProofSignatureValid, err = util.VerifyAggregateSignature(sig, []phase0.BLSPubKey{c.validator.Validator.PublicKey}, root, domain)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
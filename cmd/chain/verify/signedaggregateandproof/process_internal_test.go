@@ -0,0 +1,163 @@
+// Copyright © 2022 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chainverifysignedaggregateandproof
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aaron-alderman/ethdo/testing/beaconnode"
+	"github.com/rs/zerolog"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+	e2types "github.com/wealdtech/go-eth2-types/v2"
+)
+
+func TestProcess(t *testing.T) {
+	require.NoError(t, e2types.InitBLS())
+	zerolog.SetGlobalLevel(zerolog.Disabled)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	beaconNode, err := beaconnode.New(ctx,
+		beaconnode.WithFixturesDir("../../../../testing/beaconnode/testdata"),
+	)
+	require.NoError(t, err)
+
+	// The aggregates are for committee 0 at slot 0, which contains only validator 0, so validator 0
+	// is always selected as an aggregator.
+	tests := []struct {
+		name                                  string
+		data                                  string
+		itemStructureValid                    bool
+		validatorKnown                        bool
+		validatorInCommittee                  bool
+		selectionProofValidFormat             bool
+		selectionProofValid                   bool
+		validatorIsAggregator                 bool
+		aggregateSignatureValidFormat         bool
+		aggregateSignatureValid               bool
+		aggregateAndProofSignatureValidFormat bool
+		aggregateAndProofSignatureValid       bool
+		additionalInfo                        string
+	}{
+		{
+			name:           "InvalidData",
+			data:           "[[",
+			additionalInfo: "unexpected end of JSON input",
+		},
+		{
+			name:               "UnknownValidator",
+			data:               `{"message":{"aggregator_index":"5","aggregate":{"aggregation_bits":"0x03","data":{"slot":"0","index":"0","beacon_block_root":"0x0100000000000000000000000000000000000000000000000000000000000000","source":{"epoch":"0","root":"0x0000000000000000000000000000000000000000000000000000000000000000"},"target":{"epoch":"0","root":"0x0100000000000000000000000000000000000000000000000000000000000000"}},"signature":"0x90ff8fb924572b94a6819b442585c8ac11d8ce15c47ef718385fa8c032c44d2ec7905ddbe4493da92ec0061ef6da53230ac6b94c31f78e169cd1711c7a37fe5c796f44d343ce9d522b7138951ad6e86ee5983f0a9c09f1a1c6108994ffc5fae6"},"selection_proof":"0x82709e04745722fdfd11d3b44c8d15791c8cd986037b5c9b3b89ef7aa458a032685a1527fcc6763929c0c9086a6918c5185c94f0be053e5f9cebb6003e5c435336cedb7a6e9f01bc9de63a6ccd5549781378c0a0761621e47e18b3619511744f"},"signature":"0x991433bb2a1e3df4e6453684e259a7c8ae707ea31eeac505074e42dd45f3909b9b884f66bc46a8229408bd0ab9a6e41e0807058e03737400b1a1a21871956c9edcc129a05cbebd2ec050bdb20b9e703114665c51882f972eef78b553167333dc"}`,
+			itemStructureValid: true,
+		},
+		{
+			name:               "ValidatorNotInCommittee",
+			data:               `{"message":{"aggregator_index":"1","aggregate":{"aggregation_bits":"0x03","data":{"slot":"0","index":"0","beacon_block_root":"0x0100000000000000000000000000000000000000000000000000000000000000","source":{"epoch":"0","root":"0x0000000000000000000000000000000000000000000000000000000000000000"},"target":{"epoch":"0","root":"0x0100000000000000000000000000000000000000000000000000000000000000"}},"signature":"0x90ff8fb924572b94a6819b442585c8ac11d8ce15c47ef718385fa8c032c44d2ec7905ddbe4493da92ec0061ef6da53230ac6b94c31f78e169cd1711c7a37fe5c796f44d343ce9d522b7138951ad6e86ee5983f0a9c09f1a1c6108994ffc5fae6"},"selection_proof":"0x82709e04745722fdfd11d3b44c8d15791c8cd986037b5c9b3b89ef7aa458a032685a1527fcc6763929c0c9086a6918c5185c94f0be053e5f9cebb6003e5c435336cedb7a6e9f01bc9de63a6ccd5549781378c0a0761621e47e18b3619511744f"},"signature":"0x991433bb2a1e3df4e6453684e259a7c8ae707ea31eeac505074e42dd45f3909b9b884f66bc46a8229408bd0ab9a6e41e0807058e03737400b1a1a21871956c9edcc129a05cbebd2ec050bdb20b9e703114665c51882f972eef78b553167333dc"}`,
+			itemStructureValid: true,
+			validatorKnown:     true,
+		},
+		{
+			name:                 "SelectionProofFormatInvalid",
+			data:                 `{"message":{"aggregator_index":"0","aggregate":{"aggregation_bits":"0x03","data":{"slot":"0","index":"0","beacon_block_root":"0x0100000000000000000000000000000000000000000000000000000000000000","source":{"epoch":"0","root":"0x0000000000000000000000000000000000000000000000000000000000000000"},"target":{"epoch":"0","root":"0x0100000000000000000000000000000000000000000000000000000000000000"}},"signature":"0x90ff8fb924572b94a6819b442585c8ac11d8ce15c47ef718385fa8c032c44d2ec7905ddbe4493da92ec0061ef6da53230ac6b94c31f78e169cd1711c7a37fe5c796f44d343ce9d522b7138951ad6e86ee5983f0a9c09f1a1c6108994ffc5fae6"},"selection_proof":"0x010101010101010101010101010101010101010101010101010101010101010101010101010101010101010101010101010101010101010101010101010101010101010101010101010101010101010101010101010101010101010101010101"},"signature":"0x991433bb2a1e3df4e6453684e259a7c8ae707ea31eeac505074e42dd45f3909b9b884f66bc46a8229408bd0ab9a6e41e0807058e03737400b1a1a21871956c9edcc129a05cbebd2ec050bdb20b9e703114665c51882f972eef78b553167333dc"}`,
+			itemStructureValid:   true,
+			validatorKnown:       true,
+			validatorInCommittee: true,
+			additionalInfo:       "failed to deserialize signature: err blsSignatureDeserialize 010101010101010101010101010101010101010101010101010101010101010101010101010101010101010101010101010101010101010101010101010101010101010101010101010101010101010101010101010101010101010101010101",
+		},
+		{
+			name:                      "SelectionProofWrongKey",
+			data:                      `{"message":{"aggregator_index":"0","aggregate":{"aggregation_bits":"0x03","data":{"slot":"0","index":"0","beacon_block_root":"0x0100000000000000000000000000000000000000000000000000000000000000","source":{"epoch":"0","root":"0x0000000000000000000000000000000000000000000000000000000000000000"},"target":{"epoch":"0","root":"0x0100000000000000000000000000000000000000000000000000000000000000"}},"signature":"0x90ff8fb924572b94a6819b442585c8ac11d8ce15c47ef718385fa8c032c44d2ec7905ddbe4493da92ec0061ef6da53230ac6b94c31f78e169cd1711c7a37fe5c796f44d343ce9d522b7138951ad6e86ee5983f0a9c09f1a1c6108994ffc5fae6"},"selection_proof":"0xa8095e6366bf5c2da6305bed666737dc21ff1c1c28479b0aca066f324200442f29f5ce3fb91ec50cd426bf806c0a1f9a010edb8a262174a38d0658b4a35011913fe56ee185760233f5b0a155250f01b56717386cdda7ee2a0353e1de7ea75101"},"signature":"0x858590109fa4d630fe39d6b2d3f1da03bbc8d22cde17dd8dc360259b6bec4325b5054175e6c55fbb3926d2a639e634ff098c902f6b3a63815024e9498bef1a6be7a88bae9ecfe0085e0d38359ae6dabbd9128e220a65c045096a3281876aad3e"}`,
+			itemStructureValid:        true,
+			validatorKnown:            true,
+			validatorInCommittee:      true,
+			selectionProofValidFormat: true,
+		},
+		{
+			name:                          "AggregateSignatureWrongKey",
+			data:                          `{"message":{"aggregator_index":"0","aggregate":{"aggregation_bits":"0x03","data":{"slot":"0","index":"0","beacon_block_root":"0x0100000000000000000000000000000000000000000000000000000000000000","source":{"epoch":"0","root":"0x0000000000000000000000000000000000000000000000000000000000000000"},"target":{"epoch":"0","root":"0x0100000000000000000000000000000000000000000000000000000000000000"}},"signature":"0xa3b324cc50ca91131a3eeef0abb8095113d1a9e441c7817ca9524dd21a4ee2a6b82c7179730af07a1b965bd700892738169b01e37c5b327d4d8b58fa280d0a4dd012ec41f653653759cd3564a731eb118b6063e5e79d8a8355968e4a204a632a"},"selection_proof":"0x82709e04745722fdfd11d3b44c8d15791c8cd986037b5c9b3b89ef7aa458a032685a1527fcc6763929c0c9086a6918c5185c94f0be053e5f9cebb6003e5c435336cedb7a6e9f01bc9de63a6ccd5549781378c0a0761621e47e18b3619511744f"},"signature":"0x800a333e3c78653827813f04e727966e3f1d7b2ae582fb3764837ddf640a8cd0fe31eb01f1808d82b9f145070de2be4a0c958d23eb41645c1c4cbc27dee8063ee0685025e59df8ca50a91eedca817425da20c2a74c4c3e0fffa98a0e23f7ac6b"}`,
+			itemStructureValid:            true,
+			validatorKnown:                true,
+			validatorInCommittee:          true,
+			selectionProofValidFormat:     true,
+			selectionProofValid:           true,
+			validatorIsAggregator:         true,
+			aggregateSignatureValidFormat: true,
+		},
+		{
+			name:                          "SignatureFormatInvalid",
+			data:                          `{"message":{"aggregator_index":"0","aggregate":{"aggregation_bits":"0x03","data":{"slot":"0","index":"0","beacon_block_root":"0x0100000000000000000000000000000000000000000000000000000000000000","source":{"epoch":"0","root":"0x0000000000000000000000000000000000000000000000000000000000000000"},"target":{"epoch":"0","root":"0x0100000000000000000000000000000000000000000000000000000000000000"}},"signature":"0x90ff8fb924572b94a6819b442585c8ac11d8ce15c47ef718385fa8c032c44d2ec7905ddbe4493da92ec0061ef6da53230ac6b94c31f78e169cd1711c7a37fe5c796f44d343ce9d522b7138951ad6e86ee5983f0a9c09f1a1c6108994ffc5fae6"},"selection_proof":"0x82709e04745722fdfd11d3b44c8d15791c8cd986037b5c9b3b89ef7aa458a032685a1527fcc6763929c0c9086a6918c5185c94f0be053e5f9cebb6003e5c435336cedb7a6e9f01bc9de63a6ccd5549781378c0a0761621e47e18b3619511744f"},"signature":"0x010101010101010101010101010101010101010101010101010101010101010101010101010101010101010101010101010101010101010101010101010101010101010101010101010101010101010101010101010101010101010101010101"}`,
+			itemStructureValid:            true,
+			validatorKnown:                true,
+			validatorInCommittee:          true,
+			selectionProofValidFormat:     true,
+			selectionProofValid:           true,
+			validatorIsAggregator:         true,
+			aggregateSignatureValidFormat: true,
+			aggregateSignatureValid:       true,
+			additionalInfo:                "failed to deserialize signature: err blsSignatureDeserialize 010101010101010101010101010101010101010101010101010101010101010101010101010101010101010101010101010101010101010101010101010101010101010101010101010101010101010101010101010101010101010101010101",
+		},
+		{
+			name:                                  "SignatureWrongKey",
+			data:                                  `{"message":{"aggregator_index":"0","aggregate":{"aggregation_bits":"0x03","data":{"slot":"0","index":"0","beacon_block_root":"0x0100000000000000000000000000000000000000000000000000000000000000","source":{"epoch":"0","root":"0x0000000000000000000000000000000000000000000000000000000000000000"},"target":{"epoch":"0","root":"0x0100000000000000000000000000000000000000000000000000000000000000"}},"signature":"0x90ff8fb924572b94a6819b442585c8ac11d8ce15c47ef718385fa8c032c44d2ec7905ddbe4493da92ec0061ef6da53230ac6b94c31f78e169cd1711c7a37fe5c796f44d343ce9d522b7138951ad6e86ee5983f0a9c09f1a1c6108994ffc5fae6"},"selection_proof":"0x82709e04745722fdfd11d3b44c8d15791c8cd986037b5c9b3b89ef7aa458a032685a1527fcc6763929c0c9086a6918c5185c94f0be053e5f9cebb6003e5c435336cedb7a6e9f01bc9de63a6ccd5549781378c0a0761621e47e18b3619511744f"},"signature":"0x8aa257dcff03b2c4e8a8c4fe4f126ba4195b187f4a4076bff37cbf791a23475e70bb922ac69df782fedef77c49967f420f9f3fb04e8431b645d8b1e85256bd9b65d7323f249b900ee4bae0da747a5d12f7f84cd4d1127ea92c9a5b3824b3aefd"}`,
+			itemStructureValid:                    true,
+			validatorKnown:                        true,
+			validatorInCommittee:                  true,
+			selectionProofValidFormat:             true,
+			selectionProofValid:                   true,
+			validatorIsAggregator:                 true,
+			aggregateSignatureValidFormat:         true,
+			aggregateSignatureValid:               true,
+			aggregateAndProofSignatureValidFormat: true,
+		},
+		{
+			name:                                  "Good",
+			data:                                  `{"message":{"aggregator_index":"0","aggregate":{"aggregation_bits":"0x03","data":{"slot":"0","index":"0","beacon_block_root":"0x0100000000000000000000000000000000000000000000000000000000000000","source":{"epoch":"0","root":"0x0000000000000000000000000000000000000000000000000000000000000000"},"target":{"epoch":"0","root":"0x0100000000000000000000000000000000000000000000000000000000000000"}},"signature":"0x90ff8fb924572b94a6819b442585c8ac11d8ce15c47ef718385fa8c032c44d2ec7905ddbe4493da92ec0061ef6da53230ac6b94c31f78e169cd1711c7a37fe5c796f44d343ce9d522b7138951ad6e86ee5983f0a9c09f1a1c6108994ffc5fae6"},"selection_proof":"0x82709e04745722fdfd11d3b44c8d15791c8cd986037b5c9b3b89ef7aa458a032685a1527fcc6763929c0c9086a6918c5185c94f0be053e5f9cebb6003e5c435336cedb7a6e9f01bc9de63a6ccd5549781378c0a0761621e47e18b3619511744f"},"signature":"0x991433bb2a1e3df4e6453684e259a7c8ae707ea31eeac505074e42dd45f3909b9b884f66bc46a8229408bd0ab9a6e41e0807058e03737400b1a1a21871956c9edcc129a05cbebd2ec050bdb20b9e703114665c51882f972eef78b553167333dc"}`,
+			itemStructureValid:                    true,
+			validatorKnown:                        true,
+			validatorInCommittee:                  true,
+			selectionProofValidFormat:             true,
+			selectionProofValid:                   true,
+			validatorIsAggregator:                 true,
+			aggregateSignatureValidFormat:         true,
+			aggregateSignatureValid:               true,
+			aggregateAndProofSignatureValidFormat: true,
+			aggregateAndProofSignatureValid:       true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			viper.Reset()
+			viper.Set("timeout", "5s")
+			viper.Set("connection", beaconNode.Address())
+			viper.Set("data", test.data)
+
+			cmd, err := newCommand(ctx)
+			require.NoError(t, err)
+			require.NoError(t, cmd.process(ctx))
+			require.Equal(t, test.itemStructureValid, cmd.itemStructureValid)
+			require.Equal(t, test.validatorKnown, cmd.validatorKnown)
+			require.Equal(t, test.validatorInCommittee, cmd.validatorInCommittee)
+			require.Equal(t, test.selectionProofValidFormat, cmd.selectionProofValidFormat)
+			require.Equal(t, test.selectionProofValid, cmd.selectionProofValid)
+			require.Equal(t, test.validatorIsAggregator, cmd.validatorIsAggregator)
+			require.Equal(t, test.aggregateSignatureValidFormat, cmd.aggregateSignatureValidFormat)
+			require.Equal(t, test.aggregateSignatureValid, cmd.aggregateSignatureValid)
+			require.Equal(t, test.aggregateAndProofSignatureValidFormat, cmd.aggregateAndProofSignatureValidFormat)
+			require.Equal(t, test.aggregateAndProofSignatureValid, cmd.aggregateAndProofSignatureValid)
+			require.Equal(t, test.additionalInfo, cmd.additionalInfo)
+		})
+	}
+}
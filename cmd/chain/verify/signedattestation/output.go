@@ -0,0 +1,87 @@
+// Copyright © 2022 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chainverifysignedattestation
+
+import (
+	"context"
+	"strings"
+
+	"github.com/aaron-alderman/ethdo/util"
+)
+
+type verifyJSON struct {
+	Valid                bool   `json:"valid"`
+	StructureValid       bool   `json:"structure_valid"`
+	CommitteeKnown       bool   `json:"committee_known"`
+	AggregationBitsValid bool   `json:"aggregation_bits_valid"`
+	AttestersIncluded    bool   `json:"attesters_included"`
+	SignatureValidFormat bool   `json:"signature_valid_format"`
+	SignatureValid       bool   `json:"signature_valid"`
+	AdditionalInfo       string `json:"additional_info,omitempty"`
+}
+
+func (c *command) output(ctx context.Context) (string, error) {
+	if c.quiet {
+		return "", nil
+	}
+
+	if util.StructuredFormat(c.format) {
+		res, err := util.FormatOutput(c.format, &verifyJSON{
+			Valid:                c.signatureValid,
+			StructureValid:       c.itemStructureValid,
+			CommitteeKnown:       c.committeeKnown,
+			AggregationBitsValid: c.aggregationBitsValid,
+			AttestersIncluded:    c.attestersIncluded,
+			SignatureValidFormat: c.signatureValidFormat,
+			SignatureValid:       c.signatureValid,
+			AdditionalInfo:       c.additionalInfo,
+		})
+		if err != nil {
+			return "", err
+		}
+		return res + "\n", nil
+	}
+
+	checks := []struct {
+		name   string
+		passed bool
+	}{
+		{"Valid data structure", c.itemStructureValid},
+		{"Committee known", c.committeeKnown},
+		{"Aggregation bits match committee", c.aggregationBitsValid},
+		{"Attesters included", c.attestersIncluded},
+		{"Signature has valid format", c.signatureValidFormat},
+		{"Signature is valid", c.signatureValid},
+	}
+
+	builder := strings.Builder{}
+	for _, check := range checks {
+		builder.WriteString(check.name)
+		builder.WriteString(": ")
+		if check.passed {
+			builder.WriteString("✓\n")
+			continue
+		}
+		builder.WriteString("✕")
+		if c.additionalInfo != "" {
+			builder.WriteString(" (")
+			builder.WriteString(c.additionalInfo)
+			builder.WriteString(")")
+		}
+		builder.WriteString("\n")
+		break
+	}
+
+	return builder.String(), nil
+}
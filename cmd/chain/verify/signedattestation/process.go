@@ -0,0 +1,164 @@
+// Copyright © 2022 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chainverifysignedattestation
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/aaron-alderman/ethdo/util"
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	e2types "github.com/wealdtech/go-eth2-types/v2"
+)
+
+func (c *command) process(ctx context.Context) error {
+	// Parse the data.
+	if c.data == "" {
+		return errors.New("no data supplied")
+	}
+	c.item = &phase0.Attestation{}
+	err := json.Unmarshal([]byte(c.data), c.item)
+	if err != nil {
+		c.additionalInfo = err.Error()
+		return nil
+	}
+	c.itemStructureValid = true
+
+	// Obtain information we need to process.
+	if err := c.setup(ctx); err != nil {
+		return err
+	}
+	if !c.committeeKnown {
+		return nil
+	}
+
+	c.attestingIndices, err = util.AttestingIndices(c.item.AggregationBits, c.committee)
+	if err != nil {
+		c.additionalInfo = err.Error()
+		return nil
+	}
+	c.aggregationBitsValid = true
+	if c.debug {
+		fmt.Fprintf(os.Stderr, "Attesting validator indices: %v (%d)\n", c.attestingIndices, len(c.attestingIndices))
+	}
+	if len(c.attestingIndices) == 0 {
+		return nil
+	}
+	c.attestersIncluded = true
+
+	if err := c.confirmSignature(ctx); err != nil {
+		return errors.Wrap(err, "failed to confirm the attestation signature")
+	}
+
+	return nil
+}
+
+func (c *command) setup(ctx context.Context) error {
+	var err error
+
+	// Connect to the client.
+	c.eth2Client, err = util.ConnectToBeaconNode(ctx, c.connection, c.timeout, c.allowInsecureConnections)
+	if err != nil {
+		return errors.Wrap(err, "failed to connect to beacon node")
+	}
+
+	specProvider, isProvider := c.eth2Client.(eth2client.SpecProvider)
+	if !isProvider {
+		return errors.New("connection does not provide spec information")
+	}
+	c.spec, err = specProvider.Spec(ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain spec information")
+	}
+
+	var isValidatorsProvider bool
+	c.validatorsProvider, isValidatorsProvider = c.eth2Client.(eth2client.ValidatorsProvider)
+	if !isValidatorsProvider {
+		return errors.New("connection does not provide validator information")
+	}
+
+	// Obtain the committee.
+	beaconCommitteesProvider, isProvider := c.eth2Client.(eth2client.BeaconCommitteesProvider)
+	if !isProvider {
+		return errors.New("connection does not provide beacon committee information")
+	}
+	committees, err := beaconCommitteesProvider.BeaconCommittees(ctx, fmt.Sprintf("%d", c.item.Data.Slot))
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain beacon committees")
+	}
+	for _, committee := range committees {
+		if committee.Slot == c.item.Data.Slot && committee.Index == c.item.Data.Index {
+			c.committee = committee.Validators
+			c.committeeKnown = true
+			break
+		}
+	}
+	if !c.committeeKnown {
+		c.additionalInfo = fmt.Sprintf("no committee %d at slot %d", c.item.Data.Index, c.item.Data.Slot)
+	}
+
+	return nil
+}
+
+func (c *command) confirmSignature(ctx context.Context) error {
+	sigBytes := make([]byte, 96)
+	copy(sigBytes, c.item.Signature[:])
+	sig, err := e2types.BLSSignatureFromBytes(sigBytes)
+	if err != nil {
+		c.additionalInfo = err.Error()
+		return nil
+	}
+	c.signatureValidFormat = true
+
+	validators, err := c.validatorsProvider.Validators(ctx, fmt.Sprintf("%d", c.item.Data.Slot), c.attestingIndices)
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain attesting validators")
+	}
+	pubKeys := make([]phase0.BLSPubKey, 0, len(c.attestingIndices))
+	for _, index := range c.attestingIndices {
+		validator, exists := validators[index]
+		if !exists {
+			return fmt.Errorf("no information for attesting validator %d", index)
+		}
+		pubKeys = append(pubKeys, validator.Validator.PublicKey)
+	}
+
+	domainType, err := util.SpecDomainType(c.spec, "DOMAIN_BEACON_ATTESTER")
+	if err != nil {
+		return err
+	}
+	domain, err := c.eth2Client.(eth2client.DomainProvider).Domain(ctx, domainType, c.item.Data.Target.Epoch)
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain domain")
+	}
+	if c.debug {
+		fmt.Fprintf(os.Stderr, "Attester domain is %#x\n", domain)
+	}
+
+	root, err := c.item.Data.HashTreeRoot()
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain attestation data root")
+	}
+
+	c.signatureValid, err = util.VerifyAggregateSignature(sig, pubKeys, root, domain)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,133 @@
+// Copyright © 2022 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chainverifysignedattestation
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aaron-alderman/ethdo/testing/beaconnode"
+	"github.com/rs/zerolog"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+	e2types "github.com/wealdtech/go-eth2-types/v2"
+)
+
+func TestProcess(t *testing.T) {
+	require.NoError(t, e2types.InitBLS())
+	zerolog.SetGlobalLevel(zerolog.Disabled)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	beaconNode, err := beaconnode.New(ctx,
+		beaconnode.WithFixturesDir("../../../../testing/beaconnode/testdata"),
+	)
+	require.NoError(t, err)
+
+	// The attestations are for committee 0 at slot 0, which contains only validator 0.
+	tests := []struct {
+		name                 string
+		data                 string
+		itemStructureValid   bool
+		committeeKnown       bool
+		aggregationBitsValid bool
+		attestersIncluded    bool
+		signatureValidFormat bool
+		signatureValid       bool
+		additionalInfo       string
+	}{
+		{
+			name:           "InvalidData",
+			data:           "[[",
+			additionalInfo: "unexpected end of JSON input",
+		},
+		{
+			name:               "UnknownCommittee",
+			data:               `{"aggregation_bits":"0x03","data":{"slot":"0","index":"5","beacon_block_root":"0x0100000000000000000000000000000000000000000000000000000000000000","source":{"epoch":"0","root":"0x0000000000000000000000000000000000000000000000000000000000000000"},"target":{"epoch":"0","root":"0x0100000000000000000000000000000000000000000000000000000000000000"}},"signature":"0x90ff8fb924572b94a6819b442585c8ac11d8ce15c47ef718385fa8c032c44d2ec7905ddbe4493da92ec0061ef6da53230ac6b94c31f78e169cd1711c7a37fe5c796f44d343ce9d522b7138951ad6e86ee5983f0a9c09f1a1c6108994ffc5fae6"}`,
+			itemStructureValid: true,
+			additionalInfo:     "no committee 5 at slot 0",
+		},
+		{
+			name:               "AggregationBitsLengthIncorrect",
+			data:               `{"aggregation_bits":"0x07","data":{"slot":"0","index":"0","beacon_block_root":"0x0100000000000000000000000000000000000000000000000000000000000000","source":{"epoch":"0","root":"0x0000000000000000000000000000000000000000000000000000000000000000"},"target":{"epoch":"0","root":"0x0100000000000000000000000000000000000000000000000000000000000000"}},"signature":"0x90ff8fb924572b94a6819b442585c8ac11d8ce15c47ef718385fa8c032c44d2ec7905ddbe4493da92ec0061ef6da53230ac6b94c31f78e169cd1711c7a37fe5c796f44d343ce9d522b7138951ad6e86ee5983f0a9c09f1a1c6108994ffc5fae6"}`,
+			itemStructureValid: true,
+			committeeKnown:     true,
+			additionalInfo:     "aggregation bits length 2 does not match committee size 1",
+		},
+		{
+			name:                 "NoAttesters",
+			data:                 `{"aggregation_bits":"0x02","data":{"slot":"0","index":"0","beacon_block_root":"0x0100000000000000000000000000000000000000000000000000000000000000","source":{"epoch":"0","root":"0x0000000000000000000000000000000000000000000000000000000000000000"},"target":{"epoch":"0","root":"0x0100000000000000000000000000000000000000000000000000000000000000"}},"signature":"0x90ff8fb924572b94a6819b442585c8ac11d8ce15c47ef718385fa8c032c44d2ec7905ddbe4493da92ec0061ef6da53230ac6b94c31f78e169cd1711c7a37fe5c796f44d343ce9d522b7138951ad6e86ee5983f0a9c09f1a1c6108994ffc5fae6"}`,
+			itemStructureValid:   true,
+			committeeKnown:       true,
+			aggregationBitsValid: true,
+		},
+		{
+			name:                 "SignatureFormatInvalid",
+			data:                 `{"aggregation_bits":"0x03","data":{"slot":"0","index":"0","beacon_block_root":"0x0100000000000000000000000000000000000000000000000000000000000000","source":{"epoch":"0","root":"0x0000000000000000000000000000000000000000000000000000000000000000"},"target":{"epoch":"0","root":"0x0100000000000000000000000000000000000000000000000000000000000000"}},"signature":"0x010101010101010101010101010101010101010101010101010101010101010101010101010101010101010101010101010101010101010101010101010101010101010101010101010101010101010101010101010101010101010101010101"}`,
+			itemStructureValid:   true,
+			committeeKnown:       true,
+			aggregationBitsValid: true,
+			attestersIncluded:    true,
+			additionalInfo:       "failed to deserialize signature: err blsSignatureDeserialize 010101010101010101010101010101010101010101010101010101010101010101010101010101010101010101010101010101010101010101010101010101010101010101010101010101010101010101010101010101010101010101010101",
+		},
+		{
+			name:                 "SignatureWrongKey",
+			data:                 `{"aggregation_bits":"0x03","data":{"slot":"0","index":"0","beacon_block_root":"0x0100000000000000000000000000000000000000000000000000000000000000","source":{"epoch":"0","root":"0x0000000000000000000000000000000000000000000000000000000000000000"},"target":{"epoch":"0","root":"0x0100000000000000000000000000000000000000000000000000000000000000"}},"signature":"0xa3b324cc50ca91131a3eeef0abb8095113d1a9e441c7817ca9524dd21a4ee2a6b82c7179730af07a1b965bd700892738169b01e37c5b327d4d8b58fa280d0a4dd012ec41f653653759cd3564a731eb118b6063e5e79d8a8355968e4a204a632a"}`,
+			itemStructureValid:   true,
+			committeeKnown:       true,
+			aggregationBitsValid: true,
+			attestersIncluded:    true,
+			signatureValidFormat: true,
+		},
+		{
+			name:                 "SignatureWrongData",
+			data:                 `{"aggregation_bits":"0x03","data":{"slot":"0","index":"0","beacon_block_root":"0x0202020202020202020202020202020202020202020202020202020202020202","source":{"epoch":"0","root":"0x0000000000000000000000000000000000000000000000000000000000000000"},"target":{"epoch":"0","root":"0x0100000000000000000000000000000000000000000000000000000000000000"}},"signature":"0x90ff8fb924572b94a6819b442585c8ac11d8ce15c47ef718385fa8c032c44d2ec7905ddbe4493da92ec0061ef6da53230ac6b94c31f78e169cd1711c7a37fe5c796f44d343ce9d522b7138951ad6e86ee5983f0a9c09f1a1c6108994ffc5fae6"}`,
+			itemStructureValid:   true,
+			committeeKnown:       true,
+			aggregationBitsValid: true,
+			attestersIncluded:    true,
+			signatureValidFormat: true,
+		},
+		{
+			name:                 "Good",
+			data:                 `{"aggregation_bits":"0x03","data":{"slot":"0","index":"0","beacon_block_root":"0x0100000000000000000000000000000000000000000000000000000000000000","source":{"epoch":"0","root":"0x0000000000000000000000000000000000000000000000000000000000000000"},"target":{"epoch":"0","root":"0x0100000000000000000000000000000000000000000000000000000000000000"}},"signature":"0x90ff8fb924572b94a6819b442585c8ac11d8ce15c47ef718385fa8c032c44d2ec7905ddbe4493da92ec0061ef6da53230ac6b94c31f78e169cd1711c7a37fe5c796f44d343ce9d522b7138951ad6e86ee5983f0a9c09f1a1c6108994ffc5fae6"}`,
+			itemStructureValid:   true,
+			committeeKnown:       true,
+			aggregationBitsValid: true,
+			attestersIncluded:    true,
+			signatureValidFormat: true,
+			signatureValid:       true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			viper.Reset()
+			viper.Set("timeout", "5s")
+			viper.Set("connection", beaconNode.Address())
+			viper.Set("data", test.data)
+
+			cmd, err := newCommand(ctx)
+			require.NoError(t, err)
+			require.NoError(t, cmd.process(ctx))
+			require.Equal(t, test.itemStructureValid, cmd.itemStructureValid)
+			require.Equal(t, test.committeeKnown, cmd.committeeKnown)
+			require.Equal(t, test.aggregationBitsValid, cmd.aggregationBitsValid)
+			require.Equal(t, test.attestersIncluded, cmd.attestersIncluded)
+			require.Equal(t, test.signatureValidFormat, cmd.signatureValidFormat)
+			require.Equal(t, test.signatureValid, cmd.signatureValid)
+			require.Equal(t, test.additionalInfo, cmd.additionalInfo)
+		})
+	}
+}
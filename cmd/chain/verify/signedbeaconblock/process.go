@@ -0,0 +1,146 @@
+// Copyright © 2022 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chainverifysignedbeaconblock
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/aaron-alderman/ethdo/util"
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	e2types "github.com/wealdtech/go-eth2-types/v2"
+)
+
+func (c *command) process(ctx context.Context) error {
+	// Parse the data.
+	if c.data == "" {
+		return errors.New("no data supplied")
+	}
+	var err error
+	c.item, err = util.ParseSignedBeaconBlock([]byte(c.data))
+	if err != nil {
+		c.additionalInfo = err.Error()
+		return nil
+	}
+	c.itemStructureValid = true
+	if c.debug {
+		fmt.Fprintf(os.Stderr, "Block version is %s\n", c.item.Version)
+	}
+
+	// Obtain information we need to process.
+	if err := c.setup(ctx); err != nil {
+		return err
+	}
+	if !c.proposerKnown {
+		return nil
+	}
+
+	if err := c.confirmSignature(ctx); err != nil {
+		return errors.Wrap(err, "failed to confirm the block signature")
+	}
+
+	return nil
+}
+
+func (c *command) setup(ctx context.Context) error {
+	var err error
+
+	// Connect to the client.
+	c.eth2Client, err = util.ConnectToBeaconNode(ctx, c.connection, c.timeout, c.allowInsecureConnections)
+	if err != nil {
+		return errors.Wrap(err, "failed to connect to beacon node")
+	}
+
+	specProvider, isProvider := c.eth2Client.(eth2client.SpecProvider)
+	if !isProvider {
+		return errors.New("connection does not provide spec information")
+	}
+	c.spec, err = specProvider.Spec(ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain spec information")
+	}
+
+	// Obtain the proposer.
+	validatorsProvider, isProvider := c.eth2Client.(eth2client.ValidatorsProvider)
+	if !isProvider {
+		return errors.New("connection does not provide validator information")
+	}
+	slot, err := c.item.Slot()
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain block slot")
+	}
+	proposerIndex, err := util.BlockProposerIndex(c.item)
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain block proposer")
+	}
+	validators, err := validatorsProvider.Validators(ctx, fmt.Sprintf("%d", slot), []phase0.ValidatorIndex{proposerIndex})
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain validator information")
+	}
+	if len(validators) == 0 || validators[proposerIndex] == nil {
+		return nil
+	}
+	c.proposerKnown = true
+	c.proposer = validators[proposerIndex]
+
+	return nil
+}
+
+func (c *command) confirmSignature(ctx context.Context) error {
+	signature, err := util.BlockSignature(c.item)
+	if err != nil {
+		return err
+	}
+	sig, err := e2types.BLSSignatureFromBytes(signature[:])
+	if err != nil {
+		c.additionalInfo = err.Error()
+		return nil
+	}
+	c.signatureValidFormat = true
+
+	domainType, err := util.SpecDomainType(c.spec, "DOMAIN_BEACON_PROPOSER")
+	if err != nil {
+		return err
+	}
+	slotsPerEpoch, err := util.SpecUint64(c.spec, "SLOTS_PER_EPOCH")
+	if err != nil {
+		return err
+	}
+	slot, err := c.item.Slot()
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain block slot")
+	}
+	domain, err := c.eth2Client.(eth2client.DomainProvider).Domain(ctx, domainType, phase0.Epoch(uint64(slot)/slotsPerEpoch))
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain domain")
+	}
+	if c.debug {
+		fmt.Fprintf(os.Stderr, "Proposer domain is %#x\n", domain)
+	}
+
+	root, err := c.item.Root()
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain block root")
+	}
+
+	c.signatureValid, err = util.VerifyAggregateSignature(sig, []phase0.BLSPubKey{c.proposer.Validator.PublicKey}, root, domain)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
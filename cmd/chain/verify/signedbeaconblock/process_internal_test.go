@@ -0,0 +1,111 @@
+// Copyright © 2022 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chainverifysignedbeaconblock
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aaron-alderman/ethdo/testing/beaconnode"
+	"github.com/rs/zerolog"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+	e2types "github.com/wealdtech/go-eth2-types/v2"
+)
+
+func TestProcess(t *testing.T) {
+	require.NoError(t, e2types.InitBLS())
+	zerolog.SetGlobalLevel(zerolog.Disabled)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	beaconNode, err := beaconnode.New(ctx,
+		beaconnode.WithFixturesDir("../../../../testing/beaconnode/testdata"),
+	)
+	require.NoError(t, err)
+
+	// The blocks are for slot 1, proposed by validator 0.
+	tests := []struct {
+		name                 string
+		data                 string
+		itemStructureValid   bool
+		proposerKnown        bool
+		signatureValidFormat bool
+		signatureValid       bool
+		additionalInfo       string
+	}{
+		{
+			name:           "InvalidJSON",
+			data:           `{"message":1}`,
+			additionalInfo: "invalid phase0 block: invalid JSON: invalid JSON: json: cannot unmarshal number into Go value of type phase0.beaconBlockJSON",
+		},
+		{
+			name:           "InvalidSSZ",
+			data:           "0x0102",
+			additionalInfo: "SSZ block too short",
+		},
+		{
+			name:               "UnknownProposer",
+			data:               `{"message":{"slot":"1","proposer_index":"5","parent_root":"0x0100000000000000000000000000000000000000000000000000000000000000","state_root":"0x0200000000000000000000000000000000000000000000000000000000000000","body":{"randao_reveal":"0xb0dc57c41460cbfa7b3c005f057a17828b6ed0ffba24658678f0a1a876c45c6af1cd6dab6f1b80c5379fa364efa876da039570ede35c2e0fba2d09e784d2d0add29ed339f243bc51eeb8e1f68806cb9ae6950686635d54ba2f101ff7f3f35cd5","eth1_data":{"deposit_root":"0x0000000000000000000000000000000000000000000000000000000000000000","deposit_count":"0","block_hash":"0x0000000000000000000000000000000000000000000000000000000000000000"},"graffiti":"0x0000000000000000000000000000000000000000000000000000000000000000","proposer_slashings":[],"attester_slashings":[],"attestations":[],"deposits":[],"voluntary_exits":[]}},"signature":"0x84cd244055b8a313729e91f360a8b345f82e2f7bf208f323b9ce8e6283dba1ddea7a94b760875245c0e7f10894c5370c0e3aa8751143e388def6949d3e99fe6f66e2cb6eb71f00c0fcf72f4eff35e3baebe805d72fb442bf2e63dd65ad8a2740"}`,
+			itemStructureValid: true,
+		},
+		{
+			name:               "SignatureFormatInvalid",
+			data:               `{"message":{"slot":"1","proposer_index":"0","parent_root":"0x0100000000000000000000000000000000000000000000000000000000000000","state_root":"0x0200000000000000000000000000000000000000000000000000000000000000","body":{"randao_reveal":"0xb0dc57c41460cbfa7b3c005f057a17828b6ed0ffba24658678f0a1a876c45c6af1cd6dab6f1b80c5379fa364efa876da039570ede35c2e0fba2d09e784d2d0add29ed339f243bc51eeb8e1f68806cb9ae6950686635d54ba2f101ff7f3f35cd5","eth1_data":{"deposit_root":"0x0000000000000000000000000000000000000000000000000000000000000000","deposit_count":"0","block_hash":"0x0000000000000000000000000000000000000000000000000000000000000000"},"graffiti":"0x0000000000000000000000000000000000000000000000000000000000000000","proposer_slashings":[],"attester_slashings":[],"attestations":[],"deposits":[],"voluntary_exits":[]}},"signature":"0x010101010101010101010101010101010101010101010101010101010101010101010101010101010101010101010101010101010101010101010101010101010101010101010101010101010101010101010101010101010101010101010101"}`,
+			itemStructureValid: true,
+			proposerKnown:      true,
+			additionalInfo:     "failed to deserialize signature: err blsSignatureDeserialize 010101010101010101010101010101010101010101010101010101010101010101010101010101010101010101010101010101010101010101010101010101010101010101010101010101010101010101010101010101010101010101010101",
+		},
+		{
+			name:                 "SignatureWrongKey",
+			data:                 `{"message":{"slot":"1","proposer_index":"0","parent_root":"0x0100000000000000000000000000000000000000000000000000000000000000","state_root":"0x0200000000000000000000000000000000000000000000000000000000000000","body":{"randao_reveal":"0xb0dc57c41460cbfa7b3c005f057a17828b6ed0ffba24658678f0a1a876c45c6af1cd6dab6f1b80c5379fa364efa876da039570ede35c2e0fba2d09e784d2d0add29ed339f243bc51eeb8e1f68806cb9ae6950686635d54ba2f101ff7f3f35cd5","eth1_data":{"deposit_root":"0x0000000000000000000000000000000000000000000000000000000000000000","deposit_count":"0","block_hash":"0x0000000000000000000000000000000000000000000000000000000000000000"},"graffiti":"0x0000000000000000000000000000000000000000000000000000000000000000","proposer_slashings":[],"attester_slashings":[],"attestations":[],"deposits":[],"voluntary_exits":[]}},"signature":"0xb6a8d486280bed42004a5960d1436f8c8eccd990bd81f456f78d832db8273cd4c481a841b050b322445653b854e999d017f62c1973f04170d13215c7821d390f82ee552f06ea43338b99005c9af13917aabb813d50e98fdbd0e04beca6ce88ee"}`,
+			itemStructureValid:   true,
+			proposerKnown:        true,
+			signatureValidFormat: true,
+		},
+		{
+			name:                 "SignatureWrongBlock",
+			data:                 `{"message":{"slot":"1","proposer_index":"0","parent_root":"0x0100000000000000000000000000000000000000000000000000000000000000","state_root":"0x0303030303030303030303030303030303030303030303030303030303030303","body":{"randao_reveal":"0xb0dc57c41460cbfa7b3c005f057a17828b6ed0ffba24658678f0a1a876c45c6af1cd6dab6f1b80c5379fa364efa876da039570ede35c2e0fba2d09e784d2d0add29ed339f243bc51eeb8e1f68806cb9ae6950686635d54ba2f101ff7f3f35cd5","eth1_data":{"deposit_root":"0x0000000000000000000000000000000000000000000000000000000000000000","deposit_count":"0","block_hash":"0x0000000000000000000000000000000000000000000000000000000000000000"},"graffiti":"0x0000000000000000000000000000000000000000000000000000000000000000","proposer_slashings":[],"attester_slashings":[],"attestations":[],"deposits":[],"voluntary_exits":[]}},"signature":"0x84cd244055b8a313729e91f360a8b345f82e2f7bf208f323b9ce8e6283dba1ddea7a94b760875245c0e7f10894c5370c0e3aa8751143e388def6949d3e99fe6f66e2cb6eb71f00c0fcf72f4eff35e3baebe805d72fb442bf2e63dd65ad8a2740"}`,
+			itemStructureValid:   true,
+			proposerKnown:        true,
+			signatureValidFormat: true,
+		},
+		{
+			name:                 "Good",
+			data:                 `{"message":{"slot":"1","proposer_index":"0","parent_root":"0x0100000000000000000000000000000000000000000000000000000000000000","state_root":"0x0200000000000000000000000000000000000000000000000000000000000000","body":{"randao_reveal":"0xb0dc57c41460cbfa7b3c005f057a17828b6ed0ffba24658678f0a1a876c45c6af1cd6dab6f1b80c5379fa364efa876da039570ede35c2e0fba2d09e784d2d0add29ed339f243bc51eeb8e1f68806cb9ae6950686635d54ba2f101ff7f3f35cd5","eth1_data":{"deposit_root":"0x0000000000000000000000000000000000000000000000000000000000000000","deposit_count":"0","block_hash":"0x0000000000000000000000000000000000000000000000000000000000000000"},"graffiti":"0x0000000000000000000000000000000000000000000000000000000000000000","proposer_slashings":[],"attester_slashings":[],"attestations":[],"deposits":[],"voluntary_exits":[]}},"signature":"0x84cd244055b8a313729e91f360a8b345f82e2f7bf208f323b9ce8e6283dba1ddea7a94b760875245c0e7f10894c5370c0e3aa8751143e388def6949d3e99fe6f66e2cb6eb71f00c0fcf72f4eff35e3baebe805d72fb442bf2e63dd65ad8a2740"}`,
+			itemStructureValid:   true,
+			proposerKnown:        true,
+			signatureValidFormat: true,
+			signatureValid:       true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			viper.Reset()
+			viper.Set("timeout", "5s")
+			viper.Set("connection", beaconNode.Address())
+			viper.Set("data", test.data)
+
+			cmd, err := newCommand(ctx)
+			require.NoError(t, err)
+			require.NoError(t, cmd.process(ctx))
+			require.Equal(t, test.itemStructureValid, cmd.itemStructureValid)
+			require.Equal(t, test.proposerKnown, cmd.proposerKnown)
+			require.Equal(t, test.signatureValidFormat, cmd.signatureValidFormat)
+			require.Equal(t, test.signatureValid, cmd.signatureValid)
+			require.Equal(t, test.additionalInfo, cmd.additionalInfo)
+		})
+	}
+}
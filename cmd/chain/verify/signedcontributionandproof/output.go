@@ -16,13 +16,45 @@ package chainverifysignedcontributionandproof
 import (
 	"context"
 	"strings"
+
+	"github.com/aaron-alderman/ethdo/util"
 )
 
+type verifyJSON struct {
+	Valid                                    bool   `json:"valid"`
+	StructureValid                           bool   `json:"structure_valid"`
+	ValidatorKnown                           bool   `json:"validator_known"`
+	ValidatorInSyncCommittee                 bool   `json:"validator_in_sync_committee"`
+	ValidatorIsAggregator                    bool   `json:"validator_is_aggregator"`
+	ContributionSignatureValidFormat         bool   `json:"contribution_signature_valid_format"`
+	ContributionAndProofSignatureValidFormat bool   `json:"contribution_and_proof_signature_valid_format"`
+	ContributionAndProofSignatureValid       bool   `json:"contribution_and_proof_signature_valid"`
+	AdditionalInfo                           string `json:"additional_info,omitempty"`
+}
+
 func (c *command) output(ctx context.Context) (string, error) {
 	if c.quiet {
 		return "", nil
 	}
 
+	if util.StructuredFormat(c.format) {
+		res, err := util.FormatOutput(c.format, &verifyJSON{
+			Valid:                                    c.contributionAndProofSignatureValid,
+			StructureValid:                           c.itemStructureValid,
+			ValidatorKnown:                           c.validatorKnown,
+			ValidatorInSyncCommittee:                 c.validatorInSyncCommittee,
+			ValidatorIsAggregator:                    c.validatorIsAggregator,
+			ContributionSignatureValidFormat:         c.contributionSignatureValidFormat,
+			ContributionAndProofSignatureValidFormat: c.contributionAndProofSignatureValidFormat,
+			ContributionAndProofSignatureValid:       c.contributionAndProofSignatureValid,
+			AdditionalInfo:                           c.additionalInfo,
+		})
+		if err != nil {
+			return "", err
+		}
+		return res + "\n", nil
+	}
+
 	builder := strings.Builder{}
 
 	builder.WriteString("Valid data structure: ")
@@ -0,0 +1,82 @@
+// Copyright © 2022 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chainverifysynccommitteemessage
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestOutput(t *testing.T) {
+	tests := []struct {
+		name    string
+		command *command
+		res     string
+	}{
+		{
+			name: "Quiet",
+			command: &command{
+				quiet: true,
+			},
+		},
+		{
+			name: "StructureInvalid",
+			command: &command{
+				additionalInfo: "unexpected end of JSON input",
+			},
+			res: "Valid data structure: ✕ (unexpected end of JSON input)\n",
+		},
+		{
+			name: "Partial",
+			command: &command{
+				itemStructureValid: true,
+				validatorKnown:     true,
+			},
+			res: "Valid data structure: ✓\nValidator known: ✓\nValidator in sync committee: ✕\n",
+		},
+		{
+			name: "Good",
+			command: &command{
+				itemStructureValid:       true,
+				validatorKnown:           true,
+				validatorInSyncCommittee: true,
+				signatureValidFormat:     true,
+				signatureValid:           true,
+			},
+			res: "Valid data structure: ✓\nValidator known: ✓\nValidator in sync committee: ✓\nSignature has valid format: ✓\nSignature is valid: ✓\n",
+		},
+		{
+			name: "JSON",
+			command: &command{
+				format:                   "json",
+				itemStructureValid:       true,
+				validatorKnown:           true,
+				validatorInSyncCommittee: true,
+				signatureValidFormat:     true,
+				signatureValid:           true,
+			},
+			res: `{"valid":true,"structure_valid":true,"validator_known":true,"validator_in_sync_committee":true,"signature_valid_format":true,"signature_valid":true}` + "\n",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			res, err := test.command.output(context.Background())
+			require.NoError(t, err)
+			require.Equal(t, test.res, res)
+		})
+	}
+}
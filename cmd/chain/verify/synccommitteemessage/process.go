@@ -0,0 +1,148 @@
+// Copyright © 2022 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chainverifysynccommitteemessage
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/aaron-alderman/ethdo/util"
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/spec/altair"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	e2types "github.com/wealdtech/go-eth2-types/v2"
+)
+
+func (c *command) process(ctx context.Context) error {
+	// Parse the data.
+	if c.data == "" {
+		return errors.New("no data supplied")
+	}
+	c.item = &altair.SyncCommitteeMessage{}
+	err := json.Unmarshal([]byte(c.data), c.item)
+	if err != nil {
+		c.additionalInfo = err.Error()
+		return nil
+	}
+	c.itemStructureValid = true
+
+	// Obtain information we need to process.
+	if err := c.setup(ctx); err != nil {
+		return err
+	}
+	if !c.validatorKnown {
+		return nil
+	}
+
+	for _, validatorIndex := range c.syncCommittee.Validators {
+		if validatorIndex == c.item.ValidatorIndex {
+			c.validatorInSyncCommittee = true
+			break
+		}
+	}
+	if !c.validatorInSyncCommittee {
+		return nil
+	}
+
+	if err := c.confirmSignature(ctx); err != nil {
+		return errors.Wrap(err, "failed to confirm the sync committee message signature")
+	}
+
+	return nil
+}
+
+func (c *command) setup(ctx context.Context) error {
+	var err error
+
+	// Connect to the client.
+	c.eth2Client, err = util.ConnectToBeaconNode(ctx, c.connection, c.timeout, c.allowInsecureConnections)
+	if err != nil {
+		return errors.Wrap(err, "failed to connect to beacon node")
+	}
+
+	specProvider, isProvider := c.eth2Client.(eth2client.SpecProvider)
+	if !isProvider {
+		return errors.New("connection does not provide spec information")
+	}
+	c.spec, err = specProvider.Spec(ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain spec information")
+	}
+
+	// Obtain the validator.
+	validatorsProvider, isProvider := c.eth2Client.(eth2client.ValidatorsProvider)
+	if !isProvider {
+		return errors.New("connection does not provide validator information")
+	}
+	stateID := fmt.Sprintf("%d", c.item.Slot)
+	validators, err := validatorsProvider.Validators(ctx, stateID, []phase0.ValidatorIndex{c.item.ValidatorIndex})
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain validator information")
+	}
+	if len(validators) == 0 || validators[c.item.ValidatorIndex] == nil {
+		return nil
+	}
+	c.validatorKnown = true
+	c.validator = validators[c.item.ValidatorIndex]
+
+	// Obtain the sync committee.
+	syncCommitteesProvider, isProvider := c.eth2Client.(eth2client.SyncCommitteesProvider)
+	if !isProvider {
+		return errors.New("connection does not provide sync committee information")
+	}
+	c.syncCommittee, err = syncCommitteesProvider.SyncCommittee(ctx, stateID)
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain sync committee information")
+	}
+
+	return nil
+}
+
+func (c *command) confirmSignature(ctx context.Context) error {
+	sigBytes := make([]byte, 96)
+	copy(sigBytes, c.item.Signature[:])
+	sig, err := e2types.BLSSignatureFromBytes(sigBytes)
+	if err != nil {
+		c.additionalInfo = err.Error()
+		return nil
+	}
+	c.signatureValidFormat = true
+
+	domainType, err := util.SpecDomainType(c.spec, "DOMAIN_SYNC_COMMITTEE")
+	if err != nil {
+		return err
+	}
+	slotsPerEpoch, err := util.SpecUint64(c.spec, "SLOTS_PER_EPOCH")
+	if err != nil {
+		return err
+	}
+	domain, err := c.eth2Client.(eth2client.DomainProvider).Domain(ctx, domainType, phase0.Epoch(uint64(c.item.Slot)/slotsPerEpoch))
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain domain")
+	}
+	if c.debug {
+		fmt.Fprintf(os.Stderr, "Sync committee domain is %#x\n", domain)
+	}
+
+	// The message signs the beacon block root directly.
+	c.signatureValid, err = util.VerifyAggregateSignature(sig, []phase0.BLSPubKey{c.validator.Validator.PublicKey}, c.item.BeaconBlockRoot, domain)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
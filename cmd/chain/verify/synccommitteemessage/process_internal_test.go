@@ -0,0 +1,118 @@
+// Copyright © 2022 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chainverifysynccommitteemessage
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aaron-alderman/ethdo/testing/beaconnode"
+	"github.com/rs/zerolog"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+	e2types "github.com/wealdtech/go-eth2-types/v2"
+)
+
+func TestProcess(t *testing.T) {
+	require.NoError(t, e2types.InitBLS())
+	zerolog.SetGlobalLevel(zerolog.Disabled)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	beaconNode, err := beaconnode.New(ctx,
+		beaconnode.WithFixturesDir("../../../../testing/beaconnode/testdata"),
+	)
+	require.NoError(t, err)
+
+	// The messages are for slot 0, at which the sync committee contains only validator 0.
+	tests := []struct {
+		name                     string
+		data                     string
+		itemStructureValid       bool
+		validatorKnown           bool
+		validatorInSyncCommittee bool
+		signatureValidFormat     bool
+		signatureValid           bool
+		additionalInfo           string
+	}{
+		{
+			name:           "InvalidData",
+			data:           "[[",
+			additionalInfo: "unexpected end of JSON input",
+		},
+		{
+			name:               "UnknownValidator",
+			data:               `{"slot":"0","beacon_block_root":"0x0100000000000000000000000000000000000000000000000000000000000000","validator_index":"5","signature":"0x92cbf5d980ca478a1b9362ddcf5f38459143c35ffb404438e971cdc7b4eb8ca4e2c71880e7071c290de68ab9d552aff3192307b7f77f80bf0f66326773437a2ee6a32054f83ebaf8d90add73e6bb2950cd1a41c772787cf1b794ca45107cf851"}`,
+			itemStructureValid: true,
+		},
+		{
+			name:               "ValidatorNotInSyncCommittee",
+			data:               `{"slot":"0","beacon_block_root":"0x0100000000000000000000000000000000000000000000000000000000000000","validator_index":"1","signature":"0x92cbf5d980ca478a1b9362ddcf5f38459143c35ffb404438e971cdc7b4eb8ca4e2c71880e7071c290de68ab9d552aff3192307b7f77f80bf0f66326773437a2ee6a32054f83ebaf8d90add73e6bb2950cd1a41c772787cf1b794ca45107cf851"}`,
+			itemStructureValid: true,
+			validatorKnown:     true,
+		},
+		{
+			name:                     "SignatureFormatInvalid",
+			data:                     `{"slot":"0","beacon_block_root":"0x0100000000000000000000000000000000000000000000000000000000000000","validator_index":"0","signature":"0x010101010101010101010101010101010101010101010101010101010101010101010101010101010101010101010101010101010101010101010101010101010101010101010101010101010101010101010101010101010101010101010101"}`,
+			itemStructureValid:       true,
+			validatorKnown:           true,
+			validatorInSyncCommittee: true,
+			additionalInfo:           "failed to deserialize signature: err blsSignatureDeserialize 010101010101010101010101010101010101010101010101010101010101010101010101010101010101010101010101010101010101010101010101010101010101010101010101010101010101010101010101010101010101010101010101",
+		},
+		{
+			name:                     "SignatureWrongKey",
+			data:                     `{"slot":"0","beacon_block_root":"0x0100000000000000000000000000000000000000000000000000000000000000","validator_index":"0","signature":"0xa29c8c789b27cbd66a30d44765a8e32a97b3f9906eb4594cb0c3cabd71e67795e7cd3ea50091c54cb87bdf289239cf4d0500b9076b410b3f8102ed7df598c2f2da86ebbcd8d0678f770a7fb4942d5bb27515523d8dcd21612d90e99905e8c377"}`,
+			itemStructureValid:       true,
+			validatorKnown:           true,
+			validatorInSyncCommittee: true,
+			signatureValidFormat:     true,
+		},
+		{
+			name:                     "SignatureWrongRoot",
+			data:                     `{"slot":"0","beacon_block_root":"0x0202020202020202020202020202020202020202020202020202020202020202","validator_index":"0","signature":"0x92cbf5d980ca478a1b9362ddcf5f38459143c35ffb404438e971cdc7b4eb8ca4e2c71880e7071c290de68ab9d552aff3192307b7f77f80bf0f66326773437a2ee6a32054f83ebaf8d90add73e6bb2950cd1a41c772787cf1b794ca45107cf851"}`,
+			itemStructureValid:       true,
+			validatorKnown:           true,
+			validatorInSyncCommittee: true,
+			signatureValidFormat:     true,
+		},
+		{
+			name:                     "Good",
+			data:                     `{"slot":"0","beacon_block_root":"0x0100000000000000000000000000000000000000000000000000000000000000","validator_index":"0","signature":"0x92cbf5d980ca478a1b9362ddcf5f38459143c35ffb404438e971cdc7b4eb8ca4e2c71880e7071c290de68ab9d552aff3192307b7f77f80bf0f66326773437a2ee6a32054f83ebaf8d90add73e6bb2950cd1a41c772787cf1b794ca45107cf851"}`,
+			itemStructureValid:       true,
+			validatorKnown:           true,
+			validatorInSyncCommittee: true,
+			signatureValidFormat:     true,
+			signatureValid:           true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			viper.Reset()
+			viper.Set("timeout", "5s")
+			viper.Set("connection", beaconNode.Address())
+			viper.Set("data", test.data)
+
+			cmd, err := newCommand(ctx)
+			require.NoError(t, err)
+			require.NoError(t, cmd.process(ctx))
+			require.Equal(t, test.itemStructureValid, cmd.itemStructureValid)
+			require.Equal(t, test.validatorKnown, cmd.validatorKnown)
+			require.Equal(t, test.validatorInSyncCommittee, cmd.validatorInSyncCommittee)
+			require.Equal(t, test.signatureValidFormat, cmd.signatureValidFormat)
+			require.Equal(t, test.signatureValid, cmd.signatureValid)
+			require.Equal(t, test.additionalInfo, cmd.additionalInfo)
+		})
+	}
+}
@@ -53,6 +53,27 @@ In quiet mode this will return 0 if the chain information can be obtained, other
 			os.Exit(_exitSuccess)
 		}
 
+		forkData := &spec.ForkData{
+			CurrentVersion:        fork.CurrentVersion,
+			GenesisValidatorsRoot: genesis.GenesisValidatorsRoot,
+		}
+		forkDataRoot, err := forkData.HashTreeRoot()
+		errCheck(err, "Failed to calculate fork digest")
+		var forkDigest spec.ForkDigest
+		copy(forkDigest[:], forkDataRoot[:])
+
+		if outputStructured(&chainInfoJSON{
+			GenesisTime:           genesis.GenesisTime.Unix(),
+			GenesisValidatorsRoot: fmt.Sprintf("%#x", genesis.GenesisValidatorsRoot),
+			GenesisForkVersion:    fmt.Sprintf("%#x", config["GENESIS_FORK_VERSION"].(spec.Version)),
+			CurrentForkVersion:    fmt.Sprintf("%#x", fork.CurrentVersion),
+			ForkDigest:            fmt.Sprintf("%#x", forkDigest),
+			SecondsPerSlot:        int(config["SECONDS_PER_SLOT"].(time.Duration).Seconds()),
+			SlotsPerEpoch:         config["SLOTS_PER_EPOCH"].(uint64),
+		}) {
+			os.Exit(_exitSuccess)
+		}
+
 		if genesis.GenesisTime.Unix() == 0 {
 			fmt.Println("Genesis time: undefined")
 		} else {
@@ -63,16 +84,7 @@ In quiet mode this will return 0 if the chain information can be obtained, other
 		fmt.Printf("Genesis fork version: %#x\n", config["GENESIS_FORK_VERSION"].(spec.Version))
 		fmt.Printf("Current fork version: %#x\n", fork.CurrentVersion)
 		if verbose {
-			forkData := &spec.ForkData{
-				CurrentVersion:        fork.CurrentVersion,
-				GenesisValidatorsRoot: genesis.GenesisValidatorsRoot,
-			}
-			forkDataRoot, err := forkData.HashTreeRoot()
-			if err == nil {
-				var forkDigest spec.ForkDigest
-				copy(forkDigest[:], forkDataRoot[:])
-				fmt.Printf("Fork digest: %#x\n", forkDigest)
-			}
+			fmt.Printf("Fork digest: %#x\n", forkDigest)
 		}
 		fmt.Printf("Seconds per slot: %d\n", int(config["SECONDS_PER_SLOT"].(time.Duration).Seconds()))
 		fmt.Printf("Slots per epoch: %d\n", config["SLOTS_PER_EPOCH"].(uint64))
@@ -81,6 +93,16 @@ In quiet mode this will return 0 if the chain information can be obtained, other
 	},
 }
 
+type chainInfoJSON struct {
+	GenesisTime           int64  `json:"genesis_time"`
+	GenesisValidatorsRoot string `json:"genesis_validators_root"`
+	GenesisForkVersion    string `json:"genesis_fork_version"`
+	CurrentForkVersion    string `json:"current_fork_version"`
+	ForkDigest            string `json:"fork_digest"`
+	SecondsPerSlot        int    `json:"seconds_per_slot"`
+	SlotsPerEpoch         uint64 `json:"slots_per_epoch"`
+}
+
 func init() {
 	chainCmd.AddCommand(chainInfoCmd)
 	chainFlags(chainInfoCmd)
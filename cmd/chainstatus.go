@@ -20,9 +20,12 @@ import (
 	"strings"
 	"time"
 
+	"github.com/aaron-alderman/ethdo/services/chaintime"
 	standardchaintime "github.com/aaron-alderman/ethdo/services/chaintime/standard"
 	"github.com/aaron-alderman/ethdo/util"
 	eth2client "github.com/attestantio/go-eth2-client"
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 )
@@ -66,6 +69,10 @@ In quiet mode this will return 0 if the chain status can be obtained, otherwise
 		nextEpochStartSlot := chainTime.FirstSlotOfEpoch(nextEpoch)
 		nextEpochTimestamp := chainTime.StartOfEpoch(nextEpoch)
 
+		if outputStructured(chainStatusStructured(chainTime, finality)) {
+			os.Exit(_exitSuccess)
+		}
+
 		res := strings.Builder{}
 
 		res.WriteString("Current slot: ")
@@ -155,6 +162,53 @@ In quiet mode this will return 0 if the chain status can be obtained, otherwise
 	},
 }
 
+type chainStatusJSON struct {
+	Slot                      phase0.Slot   `json:"slot"`
+	Epoch                     phase0.Epoch  `json:"epoch"`
+	EpochStartSlot            phase0.Slot   `json:"epoch_start_slot"`
+	EpochEndSlot              phase0.Slot   `json:"epoch_end_slot"`
+	NextSlotTime              time.Time     `json:"next_slot_time"`
+	NextEpochTime             time.Time     `json:"next_epoch_time"`
+	SlotsUntilNextEpoch       phase0.Slot   `json:"slots_until_next_epoch"`
+	JustifiedEpoch            phase0.Epoch  `json:"justified_epoch"`
+	FinalizedEpoch            phase0.Epoch  `json:"finalized_epoch"`
+	SyncCommitteePeriod       *uint64       `json:"sync_committee_period,omitempty"`
+	SyncCommitteeStartEpoch   *phase0.Epoch `json:"sync_committee_start_epoch,omitempty"`
+	SyncCommitteeEndEpoch     *phase0.Epoch `json:"sync_committee_end_epoch,omitempty"`
+	NextSyncCommitteePeriodAt *time.Time    `json:"next_sync_committee_period_time,omitempty"`
+}
+
+// chainStatusStructured returns chain status for structured output.
+func chainStatusStructured(chainTime chaintime.Service, finality *apiv1.Finality) *chainStatusJSON {
+	slot := chainTime.CurrentSlot()
+	epoch := chainTime.CurrentEpoch()
+	nextEpochStartSlot := chainTime.FirstSlotOfEpoch(epoch + 1)
+	res := &chainStatusJSON{
+		Slot:                slot,
+		Epoch:               epoch,
+		EpochStartSlot:      chainTime.FirstSlotOfEpoch(epoch),
+		EpochEndSlot:        nextEpochStartSlot - 1,
+		NextSlotTime:        chainTime.StartOfSlot(slot + 1),
+		NextEpochTime:       chainTime.StartOfEpoch(epoch + 1),
+		SlotsUntilNextEpoch: nextEpochStartSlot - slot,
+		JustifiedEpoch:      finality.Justified.Epoch,
+		FinalizedEpoch:      finality.Finalized.Epoch,
+	}
+	if epoch >= chainTime.AltairInitialEpoch() {
+		period := chainTime.SlotToSyncCommitteePeriod(slot)
+		periodStartEpoch := chainTime.FirstEpochOfSyncPeriod(period)
+		nextPeriodStartEpoch := chainTime.FirstEpochOfSyncPeriod(period + 1)
+		periodEndEpoch := nextPeriodStartEpoch - 1
+		nextPeriodTimestamp := chainTime.StartOfEpoch(nextPeriodStartEpoch)
+		res.SyncCommitteePeriod = &period
+		res.SyncCommitteeStartEpoch = &periodStartEpoch
+		res.SyncCommitteeEndEpoch = &periodEndEpoch
+		res.NextSyncCommitteePeriodAt = &nextPeriodTimestamp
+	}
+
+	return res
+}
+
 func init() {
 	chainCmd.AddCommand(chainStatusCmd)
 	chainFlags(chainStatusCmd)
@@ -0,0 +1,50 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	chainverifysignedaggregateandproof "github.com/aaron-alderman/ethdo/cmd/chain/verify/signedaggregateandproof"
+	"github.com/spf13/cobra"
+)
+
+var chainVerifySignedAggregateAndProofCmd = &cobra.Command{
+	Use:   "signedaggregateandproof",
+	Short: "Verify a signed aggregate and proof",
+	Long: `Verify a signed aggregate and proof, checking the selection proof, that the validator is an aggregator for its committee, and both the aggregate and the aggregate and proof signatures.  For example:
+
+    ethdo chain verify signedaggregateandproof --data=...
+
+data is the signed aggregate and proof as a JSON structure.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		res, err := chainverifysignedaggregateandproof.Run(cmd)
+		if err != nil {
+			return err
+		}
+		if res != "" {
+			fmt.Print(res)
+		}
+		return nil
+	},
+}
+
+func init() {
+	chainVerifyCmd.AddCommand(chainVerifySignedAggregateAndProofCmd)
+	chainVerifyFlags(chainVerifySignedAggregateAndProofCmd)
+}
+
+func chainVerifySignedAggregateAndProofBindings(cmd *cobra.Command) {
+	chainVerifyBindings(cmd)
+}
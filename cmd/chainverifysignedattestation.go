@@ -0,0 +1,50 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	chainverifysignedattestation "github.com/aaron-alderman/ethdo/cmd/chain/verify/signedattestation"
+	"github.com/spf13/cobra"
+)
+
+var chainVerifySignedAttestationCmd = &cobra.Command{
+	Use:   "signedattestation",
+	Short: "Verify a signed attestation",
+	Long: `Verify a signed attestation, checking its aggregation bits against the beacon committee and its signature against the attesting validators.  For example:
+
+    ethdo chain verify signedattestation --data=...
+
+data is the attestation as a JSON structure.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		res, err := chainverifysignedattestation.Run(cmd)
+		if err != nil {
+			return err
+		}
+		if res != "" {
+			fmt.Print(res)
+		}
+		return nil
+	},
+}
+
+func init() {
+	chainVerifyCmd.AddCommand(chainVerifySignedAttestationCmd)
+	chainVerifyFlags(chainVerifySignedAttestationCmd)
+}
+
+func chainVerifySignedAttestationBindings(cmd *cobra.Command) {
+	chainVerifyBindings(cmd)
+}
@@ -0,0 +1,50 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	chainverifysignedbeaconblock "github.com/aaron-alderman/ethdo/cmd/chain/verify/signedbeaconblock"
+	"github.com/spf13/cobra"
+)
+
+var chainVerifySignedBeaconBlockCmd = &cobra.Command{
+	Use:   "signedbeaconblock",
+	Short: "Verify a signed beacon block",
+	Long: `Verify the proposer signature of a signed beacon block of any fork.  For example:
+
+    ethdo chain verify signedbeaconblock --data=...
+
+data is the signed beacon block as a JSON structure, either alone or wrapped with its version as returned by the beacon node API.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		res, err := chainverifysignedbeaconblock.Run(cmd)
+		if err != nil {
+			return err
+		}
+		if res != "" {
+			fmt.Print(res)
+		}
+		return nil
+	},
+}
+
+func init() {
+	chainVerifyCmd.AddCommand(chainVerifySignedBeaconBlockCmd)
+	chainVerifyFlags(chainVerifySignedBeaconBlockCmd)
+}
+
+func chainVerifySignedBeaconBlockBindings(cmd *cobra.Command) {
+	chainVerifyBindings(cmd)
+}
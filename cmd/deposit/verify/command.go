@@ -0,0 +1,115 @@
+// Copyright © 2022 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package depositverify
+
+import (
+	"context"
+
+	"github.com/aaron-alderman/ethdo/util"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+)
+
+type command struct {
+	quiet   bool
+	format  string
+	verbose bool
+	debug   bool
+
+	// Input.
+	data              string
+	withdrawalPubKey  string
+	withdrawalAddress string
+	validatorPubKey   string
+	depositValue      string
+	forkVersion       string
+	network           string
+
+	// Processing.
+	withdrawalCredentials []byte
+	amount                phase0.Gwei
+	validatorPubKeys      map[phase0.BLSPubKey]bool
+	expectedForkVersion   phase0.Version
+
+	// Output.
+	deposits []*deposit
+}
+
+// deposit is the result of verifying a single deposit.
+type deposit struct {
+	info   *util.DepositInfo
+	checks []*check
+}
+
+// check is the result of a single check on a deposit.
+type check struct {
+	name    string
+	checked bool
+	passed  bool
+	detail  string
+}
+
+func newCommand(ctx context.Context) (*command, error) {
+	c := &command{
+		quiet:   viper.GetBool("quiet"),
+		format:  util.OutputFormat(),
+		verbose: viper.GetBool("verbose"),
+		debug:   viper.GetBool("debug"),
+	}
+
+	c.data = viper.GetString("data")
+	if c.data == "" {
+		return nil, errors.New("data is required")
+	}
+
+	c.withdrawalPubKey = viper.GetString("withdrawalpubkey")
+	c.withdrawalAddress = viper.GetString("withdrawaladdress")
+	if c.withdrawalPubKey != "" && c.withdrawalAddress != "" {
+		return nil, errors.New("only one of withdrawalpubkey and withdrawaladdress can be supplied")
+	}
+	c.validatorPubKey = viper.GetString("validatorpubkey")
+	c.depositValue = viper.GetString("depositvalue")
+
+	c.forkVersion = viper.GetString("forkversion")
+	c.network = viper.GetString("network")
+	if c.forkVersion != "" && c.network != "" {
+		return nil, errors.New("only one of network and forkversion can be supplied")
+	}
+
+	return c, nil
+}
+
+// failures returns the number of deposits that failed verification.
+func (c *command) failures() int {
+	failures := 0
+	for _, deposit := range c.deposits {
+		if !deposit.verified() {
+			failures++
+		}
+	}
+
+	return failures
+}
+
+// verified returns true if all checks carried out on the deposit passed.
+func (d *deposit) verified() bool {
+	for _, check := range d.checks {
+		if check.checked && !check.passed {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,77 @@
+// Copyright © 2022 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package depositverify
+
+import (
+	"context"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInput(t *testing.T) {
+	tests := []struct {
+		name string
+		vars map[string]interface{}
+		err  string
+	}{
+		{
+			name: "DataMissing",
+			vars: map[string]interface{}{},
+			err:  "data is required",
+		},
+		{
+			name: "WithdrawalMultiple",
+			vars: map[string]interface{}{
+				"data":              "deposits.json",
+				"withdrawalpubkey":  "0xad1868210a0cff7aff22633c003c503d4c199c8dcca13bba5b3232fc784d39d3855936e94ce184c3ce27bf15d4347695",
+				"withdrawaladdress": "0x8f0844Fd51E31ff6Bf5baBe21DCcf7328E19Fd9F",
+			},
+			err: "only one of withdrawalpubkey and withdrawaladdress can be supplied",
+		},
+		{
+			name: "NetworkAndForkVersion",
+			vars: map[string]interface{}{
+				"data":        "deposits.json",
+				"network":     "mainnet",
+				"forkversion": "0x00000000",
+			},
+			err: "only one of network and forkversion can be supplied",
+		},
+		{
+			name: "Good",
+			vars: map[string]interface{}{
+				"data":    "deposits.json",
+				"network": "prater",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			viper.Reset()
+
+			for k, v := range test.vars {
+				viper.Set(k, v)
+			}
+			_, err := newCommand(context.Background())
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
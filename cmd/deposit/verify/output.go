@@ -0,0 +1,117 @@
+// Copyright © 2022 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package depositverify
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/aaron-alderman/ethdo/util"
+)
+
+type depositJSON struct {
+	Name      string       `json:"name"`
+	PublicKey string       `json:"public_key"`
+	Verified  bool         `json:"verified"`
+	Checks    []*checkJSON `json:"checks"`
+}
+
+type checkJSON struct {
+	Name   string `json:"name"`
+	Status string `json:"status"`
+	Detail string `json:"detail,omitempty"`
+}
+
+func (c *command) output(ctx context.Context) (string, error) {
+	if c.quiet {
+		return "", nil
+	}
+
+	if util.StructuredFormat(c.format) {
+		return c.outputStructured(ctx)
+	}
+
+	builder := strings.Builder{}
+	for i, deposit := range c.deposits {
+		if i > 0 {
+			builder.WriteString("\n")
+		}
+		builder.WriteString(deposit.name())
+		if deposit.verified() {
+			builder.WriteString(": verified\n")
+		} else {
+			builder.WriteString(": failed verification\n")
+		}
+		for _, check := range deposit.checks {
+			builder.WriteString("  ")
+			builder.WriteString(check.String())
+			builder.WriteString("\n")
+		}
+	}
+
+	return strings.TrimSuffix(builder.String(), "\n"), nil
+}
+
+func (c *command) outputStructured(_ context.Context) (string, error) {
+	deposits := make([]*depositJSON, 0, len(c.deposits))
+	for _, deposit := range c.deposits {
+		checks := make([]*checkJSON, 0, len(deposit.checks))
+		for _, check := range deposit.checks {
+			checks = append(checks, &checkJSON{
+				Name:   check.name,
+				Status: check.status(),
+				Detail: check.detail,
+			})
+		}
+		deposits = append(deposits, &depositJSON{
+			Name:      deposit.name(),
+			PublicKey: fmt.Sprintf("%#x", deposit.info.PublicKey),
+			Verified:  deposit.verified(),
+			Checks:    checks,
+		})
+	}
+
+	return util.FormatOutput(c.format, deposits)
+}
+
+// name returns a name for the deposit.
+func (d *deposit) name() string {
+	if d.info.Name != "" {
+		return d.info.Name
+	}
+
+	return fmt.Sprintf("Deposit for %#x", d.info.PublicKey)
+}
+
+// status returns the status of the check.
+func (c *check) status() string {
+	switch {
+	case !c.checked:
+		return "not checked"
+	case c.passed:
+		return "verified"
+	default:
+		return "incorrect"
+	}
+}
+
+// String returns a human-readable description of the check.
+func (c *check) String() string {
+	if c.detail == "" {
+		return fmt.Sprintf("%s: %s", c.name, c.status())
+	}
+
+	return fmt.Sprintf("%s: %s (%s)", c.name, c.status(), c.detail)
+}
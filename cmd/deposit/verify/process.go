@@ -0,0 +1,391 @@
+// Copyright © 2022 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package depositverify
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	"github.com/aaron-alderman/ethdo/util"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	e2types "github.com/wealdtech/go-eth2-types/v2"
+	eth2util "github.com/wealdtech/go-eth2-util"
+	string2eth "github.com/wealdtech/go-string2eth"
+)
+
+// depositDomainType is the domain type for deposits.
+var depositDomainType = phase0.DomainType{0x03, 0x00, 0x00, 0x00}
+
+func (c *command) process(ctx context.Context) error {
+	// Ensure that the BLS library is initialised, as we verify signatures offline.
+	if err := e2types.InitBLS(); err != nil {
+		return errors.Wrap(err, "failed to initialise BLS library")
+	}
+
+	data, err := c.obtainData()
+	if err != nil {
+		return err
+	}
+	infos, err := util.DepositInfoFromJSON(data)
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain deposit data")
+	}
+
+	if err := c.setup(ctx); err != nil {
+		return err
+	}
+
+	c.deposits = make([]*deposit, len(infos))
+	for i, info := range infos {
+		if info.Amount == 0 {
+			// Raw transaction data does not contain the amount, so use the supplied value.
+			info.Amount = uint64(c.amount)
+		}
+		c.deposits[i], err = c.verifyDeposit(info)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// obtainData obtains the deposit data from the input, which can be a hex string,
+// JSON or a path to a file.
+func (c *command) obtainData() ([]byte, error) {
+	switch {
+	case strings.HasPrefix(c.data, "0x"), strings.HasPrefix(c.data, "{"), strings.HasPrefix(c.data, "["):
+		return []byte(c.data), nil
+	default:
+		// Assume it's a path to JSON.
+		data, err := ioutil.ReadFile(c.data)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to read deposit data file")
+		}
+		data = bytes.TrimSpace(data)
+		if len(data) == 0 {
+			return nil, errors.New("deposit data file is empty")
+		}
+		return data, nil
+	}
+}
+
+// setup parses the values against which deposits are checked.
+func (c *command) setup(_ context.Context) error {
+	switch {
+	case c.withdrawalPubKey != "":
+		withdrawalPubKeyBytes, err := hex.DecodeString(strings.TrimPrefix(c.withdrawalPubKey, "0x"))
+		if err != nil {
+			return errors.Wrap(err, "invalid withdrawal public key")
+		}
+		if len(withdrawalPubKeyBytes) != phase0.PublicKeyLength {
+			return errors.New("withdrawal public key must be 48 bytes")
+		}
+		withdrawalPubKey, err := e2types.BLSPublicKeyFromBytes(withdrawalPubKeyBytes)
+		if err != nil {
+			return errors.Wrap(err, "invalid withdrawal public key")
+		}
+		c.withdrawalCredentials = eth2util.SHA256(withdrawalPubKey.Marshal())
+		c.withdrawalCredentials[0] = 0x00 // BLS_WITHDRAWAL_PREFIX
+	case c.withdrawalAddress != "":
+		withdrawalAddressBytes, err := hex.DecodeString(strings.TrimPrefix(c.withdrawalAddress, "0x"))
+		if err != nil {
+			return errors.Wrap(err, "invalid withdrawal address")
+		}
+		if len(withdrawalAddressBytes) != 20 {
+			return errors.New("withdrawal address must be 20 bytes")
+		}
+		c.withdrawalCredentials = make([]byte, 32)
+		c.withdrawalCredentials[0] = 0x01 // ETH1_ADDRESS_WITHDRAWAL_PREFIX
+		copy(c.withdrawalCredentials[12:], withdrawalAddressBytes)
+	}
+
+	if c.depositValue != "" {
+		amount, err := string2eth.StringToGWei(c.depositValue)
+		if err != nil {
+			return errors.Wrap(err, "invalid deposit value")
+		}
+		if amount < 1000000000 { // MIN_DEPOSIT_AMOUNT
+			return errors.New("deposit value must be at least 1 Ether")
+		}
+		c.amount = phase0.Gwei(amount)
+	}
+
+	if c.validatorPubKey != "" {
+		var err error
+		c.validatorPubKeys, err = validatorPubKeysFromInput(c.validatorPubKey)
+		if err != nil {
+			return errors.Wrap(err, "failed to obtain validator public keys")
+		}
+	}
+
+	if c.forkVersion != "" {
+		forkVersion, err := hex.DecodeString(strings.TrimPrefix(c.forkVersion, "0x"))
+		if err != nil {
+			return errors.Wrap(err, "invalid fork version")
+		}
+		if len(forkVersion) != phase0.ForkVersionLength {
+			return errors.New("fork version must be 4 bytes")
+		}
+		copy(c.expectedForkVersion[:], forkVersion)
+	} else {
+		network := c.network
+		if network == "" {
+			network = "mainnet"
+		}
+		var err error
+		c.expectedForkVersion, err = util.NetworkGenesisForkVersion(network)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// verifyDeposit carries out all possible checks on a deposit.
+func (c *command) verifyDeposit(info *util.DepositInfo) (*deposit, error) {
+	res := &deposit{
+		info:   info,
+		checks: make([]*check, 0),
+	}
+
+	res.checks = append(res.checks, c.checkWithdrawalCredentials(info))
+	res.checks = append(res.checks, c.checkAmount(info))
+	res.checks = append(res.checks, c.checkValidatorPubKey(info))
+
+	var pubKey phase0.BLSPubKey
+	copy(pubKey[:], info.PublicKey)
+	var signature phase0.BLSSignature
+	copy(signature[:], info.Signature)
+
+	depositData := &phase0.DepositData{
+		PublicKey:             pubKey,
+		WithdrawalCredentials: info.WithdrawalCredentials,
+		Amount:                phase0.Gwei(info.Amount),
+		Signature:             signature,
+	}
+	depositDataRoot, err := depositData.HashTreeRoot()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to generate deposit data root")
+	}
+	res.checks = append(res.checks, rootCheck("deposit data root", info.DepositDataRoot, depositDataRoot))
+
+	res.checks = append(res.checks, c.checkForkVersion(info))
+
+	depositMessage := &phase0.DepositMessage{
+		PublicKey:             pubKey,
+		WithdrawalCredentials: info.WithdrawalCredentials,
+		Amount:                phase0.Gwei(info.Amount),
+	}
+	depositMessageRoot, err := depositMessage.HashTreeRoot()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to generate deposit message root")
+	}
+	if len(info.DepositMessageRoot) == 0 {
+		res.checks = append(res.checks, &check{
+			name:   "deposit message root",
+			detail: "not present in deposit data",
+		})
+	} else {
+		res.checks = append(res.checks, rootCheck("deposit message root", info.DepositMessageRoot, depositMessageRoot))
+	}
+
+	// The signature is verified against the fork version in the deposit if present,
+	// so that a signature failure is not reported for a deposit that is simply for
+	// a different network.
+	forkVersion := c.expectedForkVersion
+	if len(info.ForkVersion) == phase0.ForkVersionLength {
+		copy(forkVersion[:], info.ForkVersion)
+	}
+	res.checks = append(res.checks, checkSignature(pubKey, depositMessageRoot, signature, forkVersion))
+
+	return res, nil
+}
+
+func (c *command) checkWithdrawalCredentials(info *util.DepositInfo) *check {
+	res := &check{
+		name: "withdrawal credentials",
+	}
+	if c.withdrawalCredentials == nil {
+		res.detail = "withdrawal public key or address not supplied"
+		return res
+	}
+	res.checked = true
+	res.passed = bytes.Equal(info.WithdrawalCredentials, c.withdrawalCredentials)
+	if !res.passed {
+		res.detail = fmt.Sprintf("expected %#x, found %#x", c.withdrawalCredentials, info.WithdrawalCredentials)
+	}
+
+	return res
+}
+
+func (c *command) checkAmount(info *util.DepositInfo) *check {
+	res := &check{
+		name: "amount",
+	}
+	if c.amount == 0 {
+		res.detail = "deposit value not supplied"
+		return res
+	}
+	res.checked = true
+	res.passed = phase0.Gwei(info.Amount) == c.amount
+	if !res.passed {
+		res.detail = fmt.Sprintf("expected %s, found %s", string2eth.GWeiToString(uint64(c.amount), true), string2eth.GWeiToString(info.Amount, true))
+	}
+
+	return res
+}
+
+func (c *command) checkValidatorPubKey(info *util.DepositInfo) *check {
+	res := &check{
+		name: "validator public key",
+	}
+	if len(c.validatorPubKeys) == 0 {
+		res.detail = "validator public key not supplied"
+		return res
+	}
+	res.checked = true
+	var pubKey phase0.BLSPubKey
+	copy(pubKey[:], info.PublicKey)
+	res.passed = c.validatorPubKeys[pubKey]
+	if !res.passed {
+		res.detail = fmt.Sprintf("%#x not supplied", info.PublicKey)
+	}
+
+	return res
+}
+
+func (c *command) checkForkVersion(info *util.DepositInfo) *check {
+	res := &check{
+		name: "fork version",
+	}
+	if len(info.ForkVersion) == 0 {
+		res.detail = "not present in deposit data"
+		return res
+	}
+	res.checked = true
+	var forkVersion phase0.Version
+	copy(forkVersion[:], info.ForkVersion)
+	res.passed = len(info.ForkVersion) == phase0.ForkVersionLength && forkVersion == c.expectedForkVersion
+	if res.passed {
+		res.detail = util.NetworkForGenesisForkVersion(forkVersion)
+	} else {
+		res.detail = fmt.Sprintf("deposit is for %#x (%s), expected %#x (%s)",
+			info.ForkVersion,
+			util.NetworkForGenesisForkVersion(forkVersion),
+			c.expectedForkVersion,
+			util.NetworkForGenesisForkVersion(c.expectedForkVersion),
+		)
+	}
+
+	return res
+}
+
+// rootCheck checks a supplied root against a calculated root.
+func rootCheck(name string, supplied []byte, calculated phase0.Root) *check {
+	res := &check{
+		name:    name,
+		checked: true,
+		passed:  bytes.Equal(supplied, calculated[:]),
+	}
+	if !res.passed {
+		res.detail = fmt.Sprintf("expected %#x, found %#x", calculated, supplied)
+	}
+
+	return res
+}
+
+// checkSignature checks the signature of a deposit message under the deposit domain.
+func checkSignature(pubKey phase0.BLSPubKey,
+	depositMessageRoot phase0.Root,
+	signature phase0.BLSSignature,
+	forkVersion phase0.Version,
+) *check {
+	res := &check{
+		name:    "signature",
+		checked: true,
+	}
+
+	// Deposits are valid across forks, so are signed with a zero genesis validators root.
+	signingRoot, err := util.SigningRoot(depositMessageRoot, depositDomainType, forkVersion, phase0.Root{})
+	if err != nil {
+		res.detail = err.Error()
+		return res
+	}
+	validatorPubKey, err := e2types.BLSPublicKeyFromBytes(append([]byte{}, pubKey[:]...))
+	if err != nil {
+		res.detail = fmt.Sprintf("invalid public key: %v", err)
+		return res
+	}
+	sig, err := e2types.BLSSignatureFromBytes(append([]byte{}, signature[:]...))
+	if err != nil {
+		res.detail = fmt.Sprintf("invalid signature: %v", err)
+		return res
+	}
+	res.passed = sig.Verify(signingRoot[:], validatorPubKey)
+	if !res.passed {
+		res.detail = fmt.Sprintf("signature does not verify with fork version %#x", forkVersion)
+	}
+
+	return res
+}
+
+// validatorPubKeysFromInput obtains validator public keys from either a single
+// public key or a file of public keys, one per line.
+func validatorPubKeysFromInput(input string) (map[phase0.BLSPubKey]bool, error) {
+	lines := make([][]byte, 0)
+	if strings.HasPrefix(input, "0x") {
+		// Looks like a public key.
+		lines = append(lines, []byte(input))
+	} else {
+		// Assume it's a path to a file of public keys.
+		data, err := ioutil.ReadFile(input)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to find public key file")
+		}
+		lines = bytes.Split(bytes.ReplaceAll(data, []byte("\r\n"), []byte("\n")), []byte("\n"))
+	}
+
+	pubKeys := make(map[phase0.BLSPubKey]bool)
+	for _, line := range lines {
+		if len(line) == 0 {
+			continue
+		}
+		pubKeyBytes, err := hex.DecodeString(strings.TrimPrefix(string(line), "0x"))
+		if err != nil {
+			return nil, errors.Wrap(err, "public key is not a hex string")
+		}
+		if len(pubKeyBytes) != phase0.PublicKeyLength {
+			return nil, errors.New("public key should be 48 bytes")
+		}
+		pubKey, err := e2types.BLSPublicKeyFromBytes(pubKeyBytes)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid public key")
+		}
+		var key phase0.BLSPubKey
+		copy(key[:], pubKey.Marshal())
+		pubKeys[key] = true
+	}
+	if len(pubKeys) == 0 {
+		return nil, errors.New("no public keys supplied")
+	}
+
+	return pubKeys, nil
+}
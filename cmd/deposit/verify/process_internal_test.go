@@ -0,0 +1,171 @@
+// Copyright © 2022 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package depositverify
+
+import (
+	"context"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProcess(t *testing.T) {
+	mainnetDeposit := `{"pubkey":"a99a76ed7796f7be22d5b7e85deeb7c5677e88e511e0b337618f8c4eb61349b4bf2d153f649f7b53359fe8b94a38e44c","withdrawal_credentials":"00fad2a6bfb0e7f1f0f45460944fbd8dfa7f37da06a4d13b3983cc90bb46963b","amount":32000000000,"signature":"a9ac65fdd32e9ea916127b5c307a4abde9bde12e751f372c5f0aa84f62f09eba673b25949673c5c5d01527ecff90205e02389d709a74715b5f3f30d3defd0fc559e9480eae522463d7c9e6b77649132ba1fa3b4b33f7b1f471d22829df9f9416","deposit_message_root":"139b510ea7f2788ab82da1f427d6cbe1db147c15a053db738ad5500cd83754a6","deposit_data_root":"97f892cc0b7e6ac39e28c650ea91c06c32ffcf6a37f9fffd30998d1faf7767d3","fork_version":"00000000"}`
+	praterDeposit := `{"pubkey":"a99a76ed7796f7be22d5b7e85deeb7c5677e88e511e0b337618f8c4eb61349b4bf2d153f649f7b53359fe8b94a38e44c","withdrawal_credentials":"00fad2a6bfb0e7f1f0f45460944fbd8dfa7f37da06a4d13b3983cc90bb46963b","amount":32000000000,"signature":"b3e70227e09d775f0e3ca121ddbc177a526835b3c291e1aa95bad43ced51235d385279f3b254e627d63d93e17d7d7ad80cb09c537fb82a036b3541d0589c64f99672db75d61f7a9a48e451367ad445b183741acd82c2711e164b9bd618084e18","deposit_message_root":"139b510ea7f2788ab82da1f427d6cbe1db147c15a053db738ad5500cd83754a6","deposit_data_root":"8ad31a9bb71843a34acf413579151af224801c4e7b01b8c6c0287aa8cf67fcb7","fork_version":"00001020"}`
+	// Prater signature with a matching deposit data root, but claiming to be for mainnet.
+	corruptDeposit := `{"pubkey":"a99a76ed7796f7be22d5b7e85deeb7c5677e88e511e0b337618f8c4eb61349b4bf2d153f649f7b53359fe8b94a38e44c","withdrawal_credentials":"00fad2a6bfb0e7f1f0f45460944fbd8dfa7f37da06a4d13b3983cc90bb46963b","amount":32000000000,"signature":"b3e70227e09d775f0e3ca121ddbc177a526835b3c291e1aa95bad43ced51235d385279f3b254e627d63d93e17d7d7ad80cb09c537fb82a036b3541d0589c64f99672db75d61f7a9a48e451367ad445b183741acd82c2711e164b9bd618084e18","deposit_message_root":"139b510ea7f2788ab82da1f427d6cbe1db147c15a053db738ad5500cd83754a6","deposit_data_root":"8ad31a9bb71843a34acf413579151af224801c4e7b01b8c6c0287aa8cf67fcb7","fork_version":"00000000"}`
+
+	tests := []struct {
+		name     string
+		vars     map[string]interface{}
+		err      string
+		res      string
+		failures int
+	}{
+		{
+			name: "DataInvalid",
+			vars: map[string]interface{}{
+				"data": `{"foo":"bar"}`,
+			},
+			err: "failed to obtain deposit data: unknown deposit data format",
+		},
+		{
+			name: "NetworkUnknown",
+			vars: map[string]interface{}{
+				"data":    mainnetDeposit,
+				"network": "unknown",
+			},
+			err: "unknown network unknown",
+		},
+		{
+			name: "ForkVersionInvalid",
+			vars: map[string]interface{}{
+				"data":        mainnetDeposit,
+				"forkversion": "0x000000",
+			},
+			err: "fork version must be 4 bytes",
+		},
+		{
+			name: "DepositValueTooLow",
+			vars: map[string]interface{}{
+				"data":         mainnetDeposit,
+				"depositvalue": "0.5 Ether",
+			},
+			err: "deposit value must be at least 1 Ether",
+		},
+		{
+			name: "Good",
+			vars: map[string]interface{}{
+				"data":            mainnetDeposit,
+				"depositvalue":    "32 Ether",
+				"validatorpubkey": "0xa99a76ed7796f7be22d5b7e85deeb7c5677e88e511e0b337618f8c4eb61349b4bf2d153f649f7b53359fe8b94a38e44c",
+			},
+			res: `Deposit for 0xa99a76ed7796f7be22d5b7e85deeb7c5677e88e511e0b337618f8c4eb61349b4bf2d153f649f7b53359fe8b94a38e44c: verified
+  withdrawal credentials: not checked (withdrawal public key or address not supplied)
+  amount: verified
+  validator public key: verified
+  deposit data root: verified
+  fork version: verified (Mainnet)
+  deposit message root: verified
+  signature: verified`,
+		},
+		{
+			name: "Prater",
+			vars: map[string]interface{}{
+				"data":    praterDeposit,
+				"network": "prater",
+			},
+			res: `Deposit for 0xa99a76ed7796f7be22d5b7e85deeb7c5677e88e511e0b337618f8c4eb61349b4bf2d153f649f7b53359fe8b94a38e44c: verified
+  withdrawal credentials: not checked (withdrawal public key or address not supplied)
+  amount: not checked (deposit value not supplied)
+  validator public key: not checked (validator public key not supplied)
+  deposit data root: verified
+  fork version: verified (Prater)
+  deposit message root: verified
+  signature: verified`,
+		},
+		{
+			name: "Multiple",
+			vars: map[string]interface{}{
+				"data":              "[" + mainnetDeposit + "," + praterDeposit + "," + corruptDeposit + "]",
+				"depositvalue":      "31 Ether",
+				"withdrawaladdress": "0x8f0844Fd51E31ff6Bf5baBe21DCcf7328E19Fd9F",
+			},
+			res: `Deposit for 0xa99a76ed7796f7be22d5b7e85deeb7c5677e88e511e0b337618f8c4eb61349b4bf2d153f649f7b53359fe8b94a38e44c: failed verification
+  withdrawal credentials: incorrect (expected 0x0100000000000000000000008f0844fd51e31ff6bf5babe21dccf7328e19fd9f, found 0x00fad2a6bfb0e7f1f0f45460944fbd8dfa7f37da06a4d13b3983cc90bb46963b)
+  amount: incorrect (expected 31 Ether, found 32 Ether)
+  validator public key: not checked (validator public key not supplied)
+  deposit data root: verified
+  fork version: verified (Mainnet)
+  deposit message root: verified
+  signature: verified
+
+Deposit for 0xa99a76ed7796f7be22d5b7e85deeb7c5677e88e511e0b337618f8c4eb61349b4bf2d153f649f7b53359fe8b94a38e44c: failed verification
+  withdrawal credentials: incorrect (expected 0x0100000000000000000000008f0844fd51e31ff6bf5babe21dccf7328e19fd9f, found 0x00fad2a6bfb0e7f1f0f45460944fbd8dfa7f37da06a4d13b3983cc90bb46963b)
+  amount: incorrect (expected 31 Ether, found 32 Ether)
+  validator public key: not checked (validator public key not supplied)
+  deposit data root: verified
+  fork version: incorrect (deposit is for 0x00001020 (Prater), expected 0x00000000 (Mainnet))
+  deposit message root: verified
+  signature: verified
+
+Deposit for 0xa99a76ed7796f7be22d5b7e85deeb7c5677e88e511e0b337618f8c4eb61349b4bf2d153f649f7b53359fe8b94a38e44c: failed verification
+  withdrawal credentials: incorrect (expected 0x0100000000000000000000008f0844fd51e31ff6bf5babe21dccf7328e19fd9f, found 0x00fad2a6bfb0e7f1f0f45460944fbd8dfa7f37da06a4d13b3983cc90bb46963b)
+  amount: incorrect (expected 31 Ether, found 32 Ether)
+  validator public key: not checked (validator public key not supplied)
+  deposit data root: verified
+  fork version: verified (Mainnet)
+  deposit message root: verified
+  signature: incorrect (signature does not verify with fork version 0x00000000)`,
+			failures: 3,
+		},
+		{
+			name: "CorruptSignature",
+			vars: map[string]interface{}{
+				"data": corruptDeposit,
+			},
+			res: `Deposit for 0xa99a76ed7796f7be22d5b7e85deeb7c5677e88e511e0b337618f8c4eb61349b4bf2d153f649f7b53359fe8b94a38e44c: failed verification
+  withdrawal credentials: not checked (withdrawal public key or address not supplied)
+  amount: not checked (deposit value not supplied)
+  validator public key: not checked (validator public key not supplied)
+  deposit data root: verified
+  fork version: verified (Mainnet)
+  deposit message root: verified
+  signature: incorrect (signature does not verify with fork version 0x00000000)`,
+			failures: 1,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			viper.Reset()
+			for k, v := range test.vars {
+				viper.Set(k, v)
+			}
+			cmd, err := newCommand(context.Background())
+			require.NoError(t, err)
+			err = cmd.process(context.Background())
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+				return
+			}
+			require.NoError(t, err)
+			res, err := cmd.output(context.Background())
+			require.NoError(t, err)
+			require.Equal(t, test.res, res)
+			require.Equal(t, test.failures, cmd.failures())
+		})
+	}
+}
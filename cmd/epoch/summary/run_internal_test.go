@@ -0,0 +1,90 @@
+// Copyright © 2022 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package epochsummary
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aaron-alderman/ethdo/testing/beaconnode"
+	"github.com/rs/zerolog"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRun(t *testing.T) {
+	zerolog.SetGlobalLevel(zerolog.Disabled)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	beaconNode, err := beaconnode.New(ctx,
+		beaconnode.WithFixturesDir("../../../testing/beaconnode/testdata"),
+	)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name string
+		vars map[string]interface{}
+		res  string
+		err  string
+	}{
+		{
+			name: "Good",
+			vars: map[string]interface{}{
+				"timeout":    "5s",
+				"epoch":      "0",
+				"connection": beaconNode.Address(),
+			},
+			res: "Epoch 0:\n  Proposals: 1/2 (50.00%)\n  Attestations: 1/2 (50.00%)",
+		},
+		{
+			name: "Verbose",
+			vars: map[string]interface{}{
+				"timeout":    "5s",
+				"epoch":      "0",
+				"connection": beaconNode.Address(),
+				"verbose":    true,
+			},
+			res: "Epoch 0:\n  Proposals: 1/2 (50.00%)\n    Slot 2 (0/2) validator 1 not proposed or not included\n  Attestations: 1/2 (50.00%)\n    Slot 1 committee 0 validator 1 failed to participate",
+		},
+		{
+			name: "JSON",
+			vars: map[string]interface{}{
+				"timeout":    "5s",
+				"epoch":      "0",
+				"connection": beaconNode.Address(),
+				"json":       true,
+			},
+			res: `{"epoch":0,"first_slot":0,"last_slot":31,"proposals":[{"slot":1,"proposer":0,"block":true},{"slot":2,"proposer":1,"block":false}],"sync_committees":null,"active_validators":2,"participating_validators":1,"nonparticipating_validators":[{"validator_index":1,"slot":1,"committee_index":0}]}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			viper.Reset()
+
+			for k, v := range test.vars {
+				viper.Set(k, v)
+			}
+			res, err := Run(&cobra.Command{})
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, test.res, res)
+			}
+		})
+	}
+}
@@ -16,17 +16,23 @@ package cmd
 import (
 	"fmt"
 	"os"
+
+	"github.com/aaron-alderman/ethdo/util"
+	"github.com/pkg/errors"
 )
 
 // errCheck checks for an error and quits if it is present
 func errCheck(err error, msg string) {
 	if err != nil {
 		if !quiet {
-			if msg == "" {
-				fmt.Fprintf(os.Stderr, "%s\n", err.Error())
-			} else {
-				fmt.Fprintf(os.Stderr, "%s: %s\n", msg, err.Error())
+			if msg != "" {
+				err = errors.Wrap(err, msg)
+			}
+			code := util.ErrorCode(err)
+			if code == "" {
+				code = util.ErrorCodeFailed
 			}
+			outputErrorMessage(code, err)
 		}
 		os.Exit(1)
 	}
@@ -51,18 +57,42 @@ func errCheck(err error, msg string) {
 // assert checks a condition and quits if it is false
 func assert(condition bool, msg string) {
 	if !condition {
-		die(msg)
+		if msg != "" && !quiet {
+			outputErrorMessage(util.ErrorCodeInvalidInput, errors.New(msg))
+		}
+		os.Exit(_exitFailure)
+	}
+}
+
+// assertVerified checks the result of a verification and quits if it failed
+func assertVerified(verified bool, msg string) {
+	if !verified {
+		if msg != "" && !quiet {
+			outputErrorMessage(util.ErrorCodeVerificationFailed, errors.New(msg))
+		}
+		os.Exit(_exitFailure)
 	}
 }
 
 // die prints an error and quits
 func die(msg string) {
 	if msg != "" && !quiet {
-		fmt.Fprintf(os.Stderr, "%s\n", msg)
+		outputErrorMessage(util.ErrorCodeFailed, errors.New(msg))
 	}
 	os.Exit(_exitFailure)
 }
 
+// outputErrorMessage prints an error in the selected output format.
+func outputErrorMessage(code string, err error) {
+	if format := util.OutputFormat(); format != util.FormatText {
+		if res, formatErr := util.FormatError(format, code, err); formatErr == nil {
+			fmt.Fprintln(os.Stderr, res)
+			return
+		}
+	}
+	fmt.Fprintf(os.Stderr, "%s\n", err.Error())
+}
+
 // warnCheck checks for an error and warns if it is present
 // func warnCheck(err error, msg string) {
 // 	if err != nil {
@@ -76,12 +76,15 @@ In quiet mode this will return 0 if the the exit is verified correctly, otherwis
 		errCheck(err, "Invalid signature")
 		verified, err := util.VerifyRoot(account, exitRoot, exitDomain, sig)
 		errCheck(err, "Failed to verify voluntary exit")
-		assert(verified, "Voluntary exit failed to verify")
+		assertVerified(verified, "Voluntary exit failed to verify")
 
 		fork, err := eth2Client.(eth2client.ForkProvider).Fork(ctx, "head")
 		errCheck(err, "Failed to obtain current fork")
-		assert(bytes.Equal(data.ForkVersion[:], fork.CurrentVersion[:]) || bytes.Equal(data.ForkVersion[:], fork.PreviousVersion[:]), "Exit is for an old fork version and is no longer valid")
+		assertVerified(bytes.Equal(data.ForkVersion[:], fork.CurrentVersion[:]) || bytes.Equal(data.ForkVersion[:], fork.PreviousVersion[:]), "Exit is for an old fork version and is no longer valid")
 
+		if !quiet && outputStructured(&verifiedJSON{Verified: true}) {
+			os.Exit(_exitSuccess)
+		}
 		outputIf(verbose, "Verified")
 		os.Exit(_exitSuccess)
 	},
@@ -0,0 +1,66 @@
+// Copyright © 2022 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/aaron-alderman/ethdo/cmd/monitor"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var monitorCmd = &cobra.Command{
+	Use:   "monitor",
+	Short: "Expose validator and chain metrics for Prometheus",
+	Long: `Follow the chain and expose metrics about validators and the chain for Prometheus.  For example:
+
+    ethdo monitor --validators=1,2,3 --listen-address=localhost:9091
+
+Validators can be supplied as indices or public keys with --validators, or as a wallet or wallet/account path with --accounts.  Metrics are served at /metrics on the listen address, and are updated at the start of each epoch.  Activation and exit queue lengths are updated every 8 epochs.
+
+This command runs until it is stopped.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		res, err := monitor.Run(cmd)
+		if err != nil {
+			return err
+		}
+		if viper.GetBool("quiet") {
+			return nil
+		}
+		if res != "" {
+			fmt.Println(res)
+		}
+		return nil
+	},
+}
+
+func init() {
+	RootCmd.AddCommand(monitorCmd)
+	monitorCmd.Flags().StringSlice("validators", nil, "indices or public keys of the validators to monitor")
+	monitorCmd.Flags().String("accounts", "", "wallet or wallet/account path of the validators to monitor")
+	monitorCmd.Flags().String("listen-address", "localhost:9091", "the address on which to serve metrics")
+}
+
+func monitorBindings() {
+	if err := viper.BindPFlag("validators", monitorCmd.Flags().Lookup("validators")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("accounts", monitorCmd.Flags().Lookup("accounts")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("listen-address", monitorCmd.Flags().Lookup("listen-address")); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,101 @@
+// Copyright © 2022 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package monitor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInput(t *testing.T) {
+	tests := []struct {
+		name string
+		vars map[string]interface{}
+		err  string
+	}{
+		{
+			name: "TimeoutMissing",
+			vars: map[string]interface{}{
+				"connection":     "http://localhost:5052/",
+				"validators":     []string{"1"},
+				"listen-address": "localhost:9091",
+			},
+			err: "timeout is required",
+		},
+		{
+			name: "ConnectionMissing",
+			vars: map[string]interface{}{
+				"timeout":        "5s",
+				"validators":     []string{"1"},
+				"listen-address": "localhost:9091",
+			},
+			err: "connection is required",
+		},
+		{
+			name: "ValidatorsMissing",
+			vars: map[string]interface{}{
+				"timeout":        "5s",
+				"connection":     "http://localhost:5052/",
+				"listen-address": "localhost:9091",
+			},
+			err: "validators or accounts are required",
+		},
+		{
+			name: "ListenAddressMissing",
+			vars: map[string]interface{}{
+				"timeout":    "5s",
+				"connection": "http://localhost:5052/",
+				"validators": []string{"1"},
+			},
+			err: "listen-address is required",
+		},
+		{
+			name: "Good",
+			vars: map[string]interface{}{
+				"timeout":        "5s",
+				"connection":     "http://localhost:5052/",
+				"validators":     []string{"1"},
+				"listen-address": "localhost:9091",
+			},
+		},
+		{
+			name: "Accounts",
+			vars: map[string]interface{}{
+				"timeout":        "5s",
+				"connection":     "http://localhost:5052/",
+				"accounts":       "Test wallet",
+				"listen-address": "localhost:9091",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			viper.Reset()
+
+			for k, v := range test.vars {
+				viper.Set(k, v)
+			}
+			_, err := newCommand(context.Background())
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,128 @@
+// Copyright © 2022 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package monitor
+
+import (
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// metrics are the Prometheus metrics exposed by the monitor.
+type metrics struct {
+	registry *prometheus.Registry
+
+	epoch               prometheus.Gauge
+	activationQueue     prometheus.Gauge
+	exitQueue           prometheus.Gauge
+	balance             *prometheus.GaugeVec
+	status              *prometheus.GaugeVec
+	attestations        *prometheus.CounterVec
+	attestationsMissed  *prometheus.CounterVec
+	proposals           *prometheus.CounterVec
+	proposalsMissed     *prometheus.CounterVec
+	syncCommittee       *prometheus.CounterVec
+	syncCommitteeMissed *prometheus.CounterVec
+}
+
+func newMetrics() (*metrics, error) {
+	m := &metrics{
+		registry: prometheus.NewRegistry(),
+		epoch: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: "ethdo",
+			Subsystem: "chain",
+			Name:      "epoch",
+			Help:      "The latest epoch processed by the monitor.",
+		}),
+		activationQueue: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: "ethdo",
+			Subsystem: "chain",
+			Name:      "activation_queue",
+			Help:      "The number of validators waiting to be activated.",
+		}),
+		exitQueue: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: "ethdo",
+			Subsystem: "chain",
+			Name:      "exit_queue",
+			Help:      "The number of validators waiting to exit.",
+		}),
+		balance: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: "ethdo",
+			Subsystem: "validator",
+			Name:      "balance_gwei",
+			Help:      "The balance of the validator, in Gwei.",
+		}, []string{"index"}),
+		status: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: "ethdo",
+			Subsystem: "validator",
+			Name:      "status",
+			Help:      "The status of the validator; 1 for the current status.",
+		}, []string{"index", "status"}),
+		attestations: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "ethdo",
+			Subsystem: "validator",
+			Name:      "attestations_total",
+			Help:      "The number of attestations the validator was expected to make.",
+		}, []string{"index"}),
+		attestationsMissed: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "ethdo",
+			Subsystem: "validator",
+			Name:      "attestations_missed_total",
+			Help:      "The number of attestations the validator made that were not included on chain.",
+		}, []string{"index"}),
+		proposals: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "ethdo",
+			Subsystem: "validator",
+			Name:      "proposals_total",
+			Help:      "The number of blocks the validator was expected to propose.",
+		}, []string{"index"}),
+		proposalsMissed: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "ethdo",
+			Subsystem: "validator",
+			Name:      "proposals_missed_total",
+			Help:      "The number of blocks the validator was expected to propose that are not on chain.",
+		}, []string{"index"}),
+		syncCommittee: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "ethdo",
+			Subsystem: "validator",
+			Name:      "sync_committee_total",
+			Help:      "The number of sync committee messages the validator was expected to make.",
+		}, []string{"index"}),
+		syncCommitteeMissed: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "ethdo",
+			Subsystem: "validator",
+			Name:      "sync_committee_missed_total",
+			Help:      "The number of sync committee messages the validator made that were not included on chain.",
+		}, []string{"index"}),
+	}
+
+	for _, collector := range []prometheus.Collector{
+		m.epoch,
+		m.activationQueue,
+		m.exitQueue,
+		m.balance,
+		m.status,
+		m.attestations,
+		m.attestationsMissed,
+		m.proposals,
+		m.proposalsMissed,
+		m.syncCommittee,
+		m.syncCommitteeMissed,
+	} {
+		if err := m.registry.Register(collector); err != nil {
+			return nil, errors.Wrap(err, "failed to register metric")
+		}
+	}
+
+	return m, nil
+}
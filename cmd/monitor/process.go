@@ -0,0 +1,463 @@
+// Copyright © 2022 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package monitor
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	standardchaintime "github.com/aaron-alderman/ethdo/services/chaintime/standard"
+	"github.com/aaron-alderman/ethdo/util"
+	eth2client "github.com/attestantio/go-eth2-client"
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/altair"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// queueEpochs is the number of epochs between updates of the activation and exit queues.
+const queueEpochs = 8
+
+func (c *command) process(ctx context.Context) error {
+	// Obtain information we need to process.
+	if err := c.setup(ctx); err != nil {
+		return err
+	}
+
+	var err error
+	c.monitored, err = util.ParseValidators(ctx, c.validatorsProvider, c.validators, c.accounts)
+	if err != nil {
+		return err
+	}
+	c.indices = make([]phase0.ValidatorIndex, 0, len(c.monitored))
+	for index := range c.monitored {
+		c.indices = append(c.indices, index)
+	}
+	sort.Slice(c.indices, func(i int, j int) bool {
+		return c.indices[i] < c.indices[j]
+	})
+
+	c.metrics, err = newMetrics()
+	if err != nil {
+		return err
+	}
+	if err := c.serveMetrics(ctx); err != nil {
+		return err
+	}
+
+	// Populate the metrics for the current epoch before waiting for new epochs.
+	c.processEpoch(ctx, c.chainTime.CurrentEpoch())
+
+	slots := make(chan phase0.Slot, 1)
+	cancelEvents, err := c.subscribe(ctx, slots)
+	if err != nil {
+		return err
+	}
+
+	// The events stream can stop without an error, so if no head events arrive for an
+	// epoch the stream is restarted.
+	streamTimeout := time.Duration(c.chainTime.SlotsPerEpoch()) * c.chainTime.SlotDuration()
+	lastEvent := time.Now()
+	ticker := time.NewTicker(c.chainTime.SlotDuration())
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			cancelEvents()
+			return nil
+		case slot := <-slots:
+			lastEvent = time.Now()
+			c.processEpochs(ctx, c.chainTime.SlotToEpoch(slot))
+		case <-ticker.C:
+			if time.Since(lastEvent) < streamTimeout {
+				continue
+			}
+			fmt.Fprintf(os.Stderr, "No head events received for %v; reconnecting to events stream\n", time.Since(lastEvent).Round(time.Second))
+			cancelEvents()
+			cancelEvents, err = c.subscribe(ctx, slots)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Failed to reconnect to events stream: %v\n", err)
+				// Ensure the failed subscription can be cancelled on the next attempt.
+				cancelEvents = func() {}
+			}
+			lastEvent = time.Now()
+		}
+	}
+}
+
+// subscribe subscribes to head events, sending the slot of each to the supplied channel.
+// The returned function cancels the subscription.
+func (c *command) subscribe(ctx context.Context, slots chan phase0.Slot) (context.CancelFunc, error) {
+	eventsCtx, cancel := context.WithCancel(ctx)
+	if err := c.eventsProvider.Events(eventsCtx, []string{"head"}, func(event *apiv1.Event) {
+		headEvent, isHeadEvent := event.Data.(*apiv1.HeadEvent)
+		if !isHeadEvent {
+			return
+		}
+		select {
+		case slots <- headEvent.Slot:
+		default:
+			// Processing is ongoing; it will pick up the next head event.
+		}
+	}); err != nil {
+		cancel()
+		return nil, errors.Wrap(err, "failed to connect for events")
+	}
+
+	return cancel, nil
+}
+
+// processEpochs processes each epoch after the last epoch processed up to and including
+// the given epoch, so that no epoch is skipped if head events are missed.
+func (c *command) processEpochs(ctx context.Context, epoch phase0.Epoch) {
+	for next := c.lastEpoch + 1; next <= epoch; next++ {
+		c.processEpoch(ctx, next)
+	}
+}
+
+// serveMetrics serves the metrics over HTTP.
+func (c *command) serveMetrics(ctx context.Context) error {
+	listener, err := net.Listen("tcp", c.listenAddress)
+	if err != nil {
+		return errors.Wrap(err, "failed to listen for metrics requests")
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.HandlerFor(c.metrics.registry, promhttp.HandlerOpts{}))
+	server := &http.Server{
+		Handler: mux,
+	}
+	go func() {
+		<-ctx.Done()
+		server.Close()
+	}()
+	go func() {
+		if err := server.Serve(listener); err != nil && err != http.ErrServerClosed {
+			fmt.Fprintf(os.Stderr, "Metrics server failed: %v\n", err)
+		}
+	}()
+
+	if c.verbose {
+		fmt.Printf("Serving metrics at http://%s/metrics\n", listener.Addr())
+	}
+
+	return nil
+}
+
+// processEpoch updates the metrics on the start of a new epoch.
+// Errors are reported but do not stop the monitor, as the beacon node may recover.
+func (c *command) processEpoch(ctx context.Context, epoch phase0.Epoch) {
+	if c.debug {
+		fmt.Fprintf(os.Stderr, "Processing epoch %d\n", epoch)
+	}
+
+	if err := c.processValidators(ctx); err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to process validators for epoch %d: %v\n", epoch, err)
+	}
+	// The queues require the full validator set, so are updated less often.
+	if !c.queuesObtained || epoch%queueEpochs == 0 {
+		if err := c.processQueues(ctx, epoch); err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to process queues for epoch %d: %v\n", epoch, err)
+		}
+	}
+	// Proposals and sync committee messages are complete for the previous epoch.
+	if epoch > 0 {
+		if err := c.processProposerDuties(ctx, epoch-1); err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to process proposer duties for epoch %d: %v\n", epoch-1, err)
+		}
+		if err := c.processSyncCommitteeDuties(ctx, epoch-1); err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to process sync committee duties for epoch %d: %v\n", epoch-1, err)
+		}
+	}
+	// Attestations can be included up to an epoch after they are made, so are complete
+	// for the epoch before the previous epoch.
+	if epoch > 1 {
+		if err := c.processAttesterDuties(ctx, epoch-2); err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to process attester duties for epoch %d: %v\n", epoch-2, err)
+		}
+	}
+
+	// Blocks before the epoch before the previous epoch are no longer required.
+	if epoch > 1 {
+		minSlot := c.chainTime.FirstSlotOfEpoch(epoch - 2)
+		for slot := range c.blocks {
+			if slot < minSlot {
+				delete(c.blocks, slot)
+			}
+		}
+	}
+
+	c.lastEpoch = epoch
+	c.metrics.epoch.Set(float64(epoch))
+}
+
+// processValidators updates the balances and statuses of the monitored validators.
+func (c *command) processValidators(ctx context.Context) error {
+	validators, err := c.validatorsProvider.Validators(ctx, "head", c.indices)
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain validators")
+	}
+
+	for _, index := range c.indices {
+		validator, exists := validators[index]
+		if !exists {
+			continue
+		}
+		label := fmt.Sprintf("%d", index)
+		c.metrics.balance.WithLabelValues(label).Set(float64(validator.Balance))
+		// Use the lower-case form of the status, as returned by the beacon API.
+		status := strings.ToLower(validator.Status.String())
+		if previous, exists := c.statuses[index]; exists && previous != status {
+			c.metrics.status.DeleteLabelValues(label, previous)
+		}
+		c.statuses[index] = status
+		c.metrics.status.WithLabelValues(label, status).Set(1)
+	}
+
+	return nil
+}
+
+// processQueues updates the lengths of the activation and exit queues.
+func (c *command) processQueues(ctx context.Context, epoch phase0.Epoch) error {
+	validators, err := c.validatorsProvider.Validators(ctx, "head", nil)
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain validators")
+	}
+
+	activationQueue := 0
+	exitQueue := 0
+	for _, validator := range validators {
+		if validator.Validator == nil {
+			continue
+		}
+		if validator.Validator.ActivationEligibilityEpoch <= epoch && validator.Validator.ActivationEpoch > epoch {
+			activationQueue++
+		}
+		if validator.Validator.ExitEpoch != 0xffffffffffffffff && validator.Validator.ExitEpoch > epoch {
+			exitQueue++
+		}
+	}
+	c.metrics.activationQueue.Set(float64(activationQueue))
+	c.metrics.exitQueue.Set(float64(exitQueue))
+	c.queuesObtained = true
+
+	return nil
+}
+
+func (c *command) processAttesterDuties(ctx context.Context, epoch phase0.Epoch) error {
+	duties, err := c.attesterDutiesProvider.AttesterDuties(ctx, epoch, c.indices)
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain attester duties")
+	}
+
+	for _, duty := range duties {
+		if _, exists := c.monitored[duty.ValidatorIndex]; !exists {
+			continue
+		}
+		label := fmt.Sprintf("%d", duty.ValidatorIndex)
+		c.metrics.attestations.WithLabelValues(label).Inc()
+		included, err := c.attestationIncluded(ctx, duty)
+		if err != nil {
+			return err
+		}
+		if !included {
+			if c.debug {
+				fmt.Fprintf(os.Stderr, "Attestation for validator %d at slot %d not included\n", duty.ValidatorIndex, duty.Slot)
+			}
+			c.metrics.attestationsMissed.WithLabelValues(label).Inc()
+		}
+	}
+
+	return nil
+}
+
+// attestationIncluded returns true if the attestation for the given duty was included on chain.
+func (c *command) attestationIncluded(ctx context.Context, duty *apiv1.AttesterDuty) (bool, error) {
+	lastSlot := duty.Slot + phase0.Slot(c.chainTime.SlotsPerEpoch())
+	if lastSlot > c.chainTime.CurrentSlot() {
+		lastSlot = c.chainTime.CurrentSlot()
+	}
+	for slot := duty.Slot + 1; slot <= lastSlot; slot++ {
+		block, err := util.BlockAtSlot(ctx, c.blocksProvider, c.blocks, slot)
+		if err != nil {
+			return false, err
+		}
+		if block == nil {
+			continue
+		}
+		attestations, err := block.Attestations()
+		if err != nil {
+			return false, errors.Wrap(err, "failed to obtain block attestations")
+		}
+		for _, attestation := range attestations {
+			if attestation.Data.Slot == duty.Slot &&
+				attestation.Data.Index == duty.CommitteeIndex &&
+				attestation.AggregationBits.BitAt(duty.ValidatorCommitteeIndex) {
+				return true, nil
+			}
+		}
+	}
+
+	return false, nil
+}
+
+func (c *command) processProposerDuties(ctx context.Context, epoch phase0.Epoch) error {
+	duties, err := c.proposerDutiesProvider.ProposerDuties(ctx, epoch, c.indices)
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain proposer duties")
+	}
+
+	for _, duty := range duties {
+		if _, exists := c.monitored[duty.ValidatorIndex]; !exists {
+			// Beacon nodes can return duties for all validators, so ignore those we do not want.
+			continue
+		}
+		label := fmt.Sprintf("%d", duty.ValidatorIndex)
+		c.metrics.proposals.WithLabelValues(label).Inc()
+		block, err := util.BlockAtSlot(ctx, c.blocksProvider, c.blocks, duty.Slot)
+		if err != nil {
+			return err
+		}
+		proposed := false
+		if block != nil {
+			proposerIndex, err := util.BlockProposerIndex(block)
+			if err != nil {
+				return err
+			}
+			proposed = proposerIndex == duty.ValidatorIndex
+		}
+		if !proposed {
+			if c.debug {
+				fmt.Fprintf(os.Stderr, "Proposal for validator %d at slot %d missed\n", duty.ValidatorIndex, duty.Slot)
+			}
+			c.metrics.proposalsMissed.WithLabelValues(label).Inc()
+		}
+	}
+
+	return nil
+}
+
+func (c *command) processSyncCommitteeDuties(ctx context.Context, epoch phase0.Epoch) error {
+	if epoch < c.chainTime.AltairInitialEpoch() {
+		// The epoch is pre-Altair.  No info but no error.
+		return nil
+	}
+
+	firstSlot := c.chainTime.FirstSlotOfEpoch(epoch)
+	committee, err := c.syncCommitteesProvider.SyncCommittee(ctx, fmt.Sprintf("%d", firstSlot))
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain sync committee")
+	}
+
+	// A validator can appear in the sync committee more than once.
+	positions := make(map[phase0.ValidatorIndex][]int)
+	for i, index := range committee.Validators {
+		if _, exists := c.monitored[index]; exists {
+			positions[index] = append(positions[index], i)
+		}
+	}
+	if len(positions) == 0 {
+		return nil
+	}
+
+	lastSlot := c.chainTime.FirstSlotOfEpoch(epoch+1) - 1
+	for slot := firstSlot; slot <= lastSlot; slot++ {
+		block, err := util.BlockAtSlot(ctx, c.blocksProvider, c.blocks, slot)
+		if err != nil {
+			return err
+		}
+		if block == nil {
+			// If the block is missed we don't count the sync aggregate miss.
+			continue
+		}
+		var aggregate *altair.SyncAggregate
+		switch block.Version {
+		case spec.DataVersionPhase0:
+			// No sync committees in this fork.
+			return nil
+		case spec.DataVersionAltair:
+			aggregate = block.Altair.Message.Body.SyncAggregate
+		case spec.DataVersionBellatrix:
+			aggregate = block.Bellatrix.Message.Body.SyncAggregate
+		default:
+			return fmt.Errorf("unhandled block version %v", block.Version)
+		}
+		for index, indexPositions := range positions {
+			label := fmt.Sprintf("%d", index)
+			for _, position := range indexPositions {
+				c.metrics.syncCommittee.WithLabelValues(label).Inc()
+				if !aggregate.SyncCommitteeBits.BitAt(uint64(position)) {
+					c.metrics.syncCommitteeMissed.WithLabelValues(label).Inc()
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+func (c *command) setup(ctx context.Context) error {
+	var err error
+
+	// Connect to the client.
+	c.eth2Client, err = util.ConnectToBeaconNode(ctx, c.connection, c.timeout, c.allowInsecureConnections)
+	if err != nil {
+		return errors.Wrap(err, "failed to connect to beacon node")
+	}
+
+	c.chainTime, err = standardchaintime.New(ctx,
+		standardchaintime.WithSpecProvider(c.eth2Client.(eth2client.SpecProvider)),
+		standardchaintime.WithForkScheduleProvider(c.eth2Client.(eth2client.ForkScheduleProvider)),
+		standardchaintime.WithGenesisTimeProvider(c.eth2Client.(eth2client.GenesisTimeProvider)),
+	)
+	if err != nil {
+		return errors.Wrap(err, "failed to set up chaintime service")
+	}
+
+	var isProvider bool
+	c.eventsProvider, isProvider = c.eth2Client.(eth2client.EventsProvider)
+	if !isProvider {
+		return errors.New("connection does not provide events")
+	}
+	c.validatorsProvider, isProvider = c.eth2Client.(eth2client.ValidatorsProvider)
+	if !isProvider {
+		return errors.New("connection does not provide validators")
+	}
+	c.attesterDutiesProvider, isProvider = c.eth2Client.(eth2client.AttesterDutiesProvider)
+	if !isProvider {
+		return errors.New("connection does not provide attester duties")
+	}
+	c.proposerDutiesProvider, isProvider = c.eth2Client.(eth2client.ProposerDutiesProvider)
+	if !isProvider {
+		return errors.New("connection does not provide proposer duties")
+	}
+	c.syncCommitteesProvider, isProvider = c.eth2Client.(eth2client.SyncCommitteesProvider)
+	if !isProvider {
+		return errors.New("connection does not provide sync committees")
+	}
+	c.blocksProvider, isProvider = c.eth2Client.(eth2client.SignedBeaconBlockProvider)
+	if !isProvider {
+		return errors.New("connection does not provide signed beacon blocks")
+	}
+
+	return nil
+}
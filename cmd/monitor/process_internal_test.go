@@ -0,0 +1,122 @@
+// Copyright © 2022 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package monitor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aaron-alderman/ethdo/testing/beaconnode"
+	"github.com/aaron-alderman/ethdo/util"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/rs/zerolog"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProcessEpoch(t *testing.T) {
+	zerolog.SetGlobalLevel(zerolog.Disabled)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	beaconNode, err := beaconnode.New(ctx,
+		beaconnode.WithFixturesDir("../../testing/beaconnode/testdata"),
+	)
+	require.NoError(t, err)
+
+	viper.Reset()
+	viper.Set("timeout", "5s")
+	viper.Set("connection", beaconNode.Address())
+	viper.Set("validators", []string{"0", "1"})
+	viper.Set("listen-address", "localhost:0")
+	c, err := newCommand(ctx)
+	require.NoError(t, err)
+	require.NoError(t, c.setup(ctx))
+	c.monitored, err = util.ParseValidators(ctx, c.validatorsProvider, c.validators, c.accounts)
+	require.NoError(t, err)
+	for index := range c.monitored {
+		c.indices = append(c.indices, index)
+	}
+	c.metrics, err = newMetrics()
+	require.NoError(t, err)
+
+	require.NoError(t, c.processQueues(ctx, 10))
+	require.Equal(t, float64(1), testutil.ToFloat64(c.metrics.activationQueue))
+	require.Equal(t, float64(1), testutil.ToFloat64(c.metrics.exitQueue))
+
+	require.NoError(t, c.processValidators(ctx))
+	require.Equal(t, float64(32000000000), testutil.ToFloat64(c.metrics.balance.WithLabelValues("0")))
+	require.Equal(t, float64(31000000000), testutil.ToFloat64(c.metrics.balance.WithLabelValues("1")))
+	require.Equal(t, float64(1), testutil.ToFloat64(c.metrics.status.WithLabelValues("0", "active_ongoing")))
+	require.Equal(t, float64(1), testutil.ToFloat64(c.metrics.status.WithLabelValues("1", "active_exiting")))
+
+	// Validator 0's attestation is included; validator 1's is not.
+	require.NoError(t, c.processAttesterDuties(ctx, 0))
+	require.Equal(t, float64(1), testutil.ToFloat64(c.metrics.attestations.WithLabelValues("0")))
+	require.Equal(t, float64(0), testutil.ToFloat64(c.metrics.attestationsMissed.WithLabelValues("0")))
+	require.Equal(t, float64(1), testutil.ToFloat64(c.metrics.attestations.WithLabelValues("1")))
+	require.Equal(t, float64(1), testutil.ToFloat64(c.metrics.attestationsMissed.WithLabelValues("1")))
+
+	// Validator 0's proposal is on chain; validator 1's is not.
+	require.NoError(t, c.processProposerDuties(ctx, 0))
+	require.Equal(t, float64(1), testutil.ToFloat64(c.metrics.proposals.WithLabelValues("0")))
+	require.Equal(t, float64(0), testutil.ToFloat64(c.metrics.proposalsMissed.WithLabelValues("0")))
+	require.Equal(t, float64(1), testutil.ToFloat64(c.metrics.proposals.WithLabelValues("1")))
+	require.Equal(t, float64(1), testutil.ToFloat64(c.metrics.proposalsMissed.WithLabelValues("1")))
+
+	// Sync committees are not active at epoch 0.
+	require.NoError(t, c.processSyncCommitteeDuties(ctx, 0))
+	require.Equal(t, 0, testutil.CollectAndCount(c.metrics.syncCommittee))
+}
+
+func TestProcessEpochs(t *testing.T) {
+	zerolog.SetGlobalLevel(zerolog.Disabled)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	beaconNode, err := beaconnode.New(ctx,
+		beaconnode.WithFixturesDir("../../testing/beaconnode/testdata"),
+	)
+	require.NoError(t, err)
+
+	viper.Reset()
+	viper.Set("timeout", "5s")
+	viper.Set("connection", beaconNode.Address())
+	viper.Set("validators", []string{"0", "1"})
+	viper.Set("listen-address", "localhost:0")
+	c, err := newCommand(ctx)
+	require.NoError(t, err)
+	require.NoError(t, c.setup(ctx))
+	c.monitored, err = util.ParseValidators(ctx, c.validatorsProvider, c.validators, c.accounts)
+	require.NoError(t, err)
+	for index := range c.monitored {
+		c.indices = append(c.indices, index)
+	}
+	c.metrics, err = newMetrics()
+	require.NoError(t, err)
+
+	// A head event for epoch 2 after epoch 0 processes epoch 1 as well, which includes the
+	// proposals for epoch 0.
+	c.processEpochs(ctx, 2)
+	require.Equal(t, phase0.Epoch(2), c.lastEpoch)
+	require.Equal(t, float64(2), testutil.ToFloat64(c.metrics.epoch))
+	require.Equal(t, float64(1), testutil.ToFloat64(c.metrics.proposals.WithLabelValues("0")))
+	require.Equal(t, float64(1), testutil.ToFloat64(c.metrics.attestations.WithLabelValues("0")))
+
+	// Epochs already processed are not processed again.
+	c.processEpochs(ctx, 2)
+	require.Equal(t, float64(1), testutil.ToFloat64(c.metrics.proposals.WithLabelValues("0")))
+	require.Equal(t, float64(1), testutil.ToFloat64(c.metrics.attestations.WithLabelValues("0")))
+}
@@ -32,7 +32,7 @@ type dataIn struct {
 	// Operation.
 	topics     []string
 	eth2Client eth2client.Service
-	jsonOutput bool
+	format     string
 }
 
 func input(ctx context.Context) (*dataIn, error) {
@@ -45,7 +45,7 @@ func input(ctx context.Context) (*dataIn, error) {
 	data.quiet = viper.GetBool("quiet")
 	data.verbose = viper.GetBool("verbose")
 	data.debug = viper.GetBool("debug")
-	data.jsonOutput = viper.GetBool("json")
+	data.format = util.OutputFormat()
 
 	data.topics = viper.GetStringSlice("topics")
 
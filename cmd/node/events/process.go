@@ -18,6 +18,7 @@ import (
 	"encoding/json"
 	"fmt"
 
+	"github.com/aaron-alderman/ethdo/util"
 	eth2client "github.com/attestantio/go-eth2-client"
 	api "github.com/attestantio/go-eth2-client/api/v1"
 	"github.com/pkg/errors"
@@ -28,7 +29,7 @@ func process(ctx context.Context, data *dataIn) error {
 		return errors.New("no data")
 	}
 
-	err := data.eth2Client.(eth2client.EventsProvider).Events(ctx, data.topics, eventHandler)
+	err := data.eth2Client.(eth2client.EventsProvider).Events(ctx, data.topics, eventHandler(data.format))
 	if err != nil {
 		return errors.Wrap(err, "failed to connect for events")
 	}
@@ -38,13 +39,31 @@ func process(ctx context.Context, data *dataIn) error {
 	return nil
 }
 
-func eventHandler(event *api.Event) {
-	if event.Data == nil {
-		return
-	}
+// eventHandler returns a handler that prints events in the given format.
+// Events are printed as JSON unless another structured format is selected, with YAML
+// events printed as separate documents.
+func eventHandler(format string) func(*api.Event) {
+	return func(event *api.Event) {
+		if event.Data == nil {
+			return
+		}
+
+		data, err := json.Marshal(event)
+		if err != nil {
+			return
+		}
+		if !util.StructuredFormat(format) || format == util.FormatJSON {
+			fmt.Println(string(data))
+			return
+		}
 
-	data, err := json.Marshal(event)
-	if err == nil {
-		fmt.Println(string(data))
+		res, err := util.FormatJSONData(format, data)
+		if err != nil {
+			return
+		}
+		if format == util.FormatYAML {
+			fmt.Println("---")
+		}
+		fmt.Println(res)
 	}
 }
@@ -20,6 +20,7 @@ import (
 
 	"github.com/aaron-alderman/ethdo/util"
 	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 )
@@ -42,6 +43,20 @@ In quiet mode this will return 0 if the node information can be obtained, otherw
 			os.Exit(_exitSuccess)
 		}
 
+		if util.StructuredFormat(util.OutputFormat()) {
+			version, err := eth2Client.(eth2client.NodeVersionProvider).NodeVersion(ctx)
+			errCheck(err, "Failed to obtain node version")
+			syncState, err := eth2Client.(eth2client.NodeSyncingProvider).NodeSyncing(ctx)
+			errCheck(err, "failed to obtain node sync state")
+			outputStructured(&nodeInfoJSON{
+				Version:      version,
+				Syncing:      syncState.SyncDistance != 0,
+				HeadSlot:     syncState.HeadSlot,
+				SyncDistance: syncState.SyncDistance,
+			})
+			os.Exit(_exitSuccess)
+		}
+
 		if verbose {
 			version, err := eth2Client.(eth2client.NodeVersionProvider).NodeVersion(ctx)
 			errCheck(err, "Failed to obtain node version")
@@ -56,6 +71,13 @@ In quiet mode this will return 0 if the node information can be obtained, otherw
 	},
 }
 
+type nodeInfoJSON struct {
+	Version      string      `json:"version"`
+	Syncing      bool        `json:"syncing"`
+	HeadSlot     phase0.Slot `json:"head_slot"`
+	SyncDistance phase0.Slot `json:"sync_distance"`
+}
+
 func init() {
 	nodeCmd.AddCommand(nodeInfoCmd)
 	nodeFlags(nodeInfoCmd)
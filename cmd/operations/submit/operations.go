@@ -0,0 +1,179 @@
+// Copyright © 2022 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package operationssubmit
+
+import (
+	"encoding/json"
+
+	"github.com/aaron-alderman/ethdo/util"
+	"github.com/attestantio/go-eth2-client/spec/altair"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+)
+
+// operationType is the type of a signed operation.
+type operationType int
+
+const (
+	operationTypeUnknown operationType = iota
+	operationTypeVoluntaryExit
+	operationTypeBLSToExecutionChange
+	operationTypeAttesterSlashing
+	operationTypeProposerSlashing
+	operationTypeSyncCommitteeMessage
+)
+
+var operationTypeStrings = [...]string{
+	"unknown",
+	"voluntary exit",
+	"credentials change",
+	"attester slashing",
+	"proposer slashing",
+	"sync committee message",
+}
+
+func (t operationType) String() string {
+	if int(t) < 0 || int(t) >= len(operationTypeStrings) {
+		return operationTypeStrings[0]
+	}
+	return operationTypeStrings[t]
+}
+
+// operation is a single signed operation obtained from the input.
+type operation struct {
+	source string
+	opType operationType
+
+	voluntaryExit *phase0.SignedVoluntaryExit
+	// forkVersion is set if the voluntary exit was supplied with the fork version used to sign it.
+	forkVersion          *phase0.Version
+	blsToExecutionChange *util.SignedBLSToExecutionChange
+	attesterSlashing     *phase0.AttesterSlashing
+	proposerSlashing     *phase0.ProposerSlashing
+	syncCommitteeMessage *altair.SyncCommitteeMessage
+
+	verified  bool
+	submitted bool
+	err       error
+}
+
+// obtainOperations obtains the operations from the supplied file, or all JSON files in
+// the supplied directory.
+// Operations that cannot be parsed are returned with an error, rather than failing
+// the entire input.
+func obtainOperations(path string) ([]*operation, error) {
+	items, err := util.ReadJSONItems(path)
+	if err != nil {
+		return nil, err
+	}
+	if len(items) == 0 {
+		return nil, errors.New("no operations found")
+	}
+
+	operations := make([]*operation, len(items))
+	for i, item := range items {
+		if item.Err != nil {
+			operations[i] = &operation{
+				source: item.Source,
+				err:    item.Err,
+			}
+			continue
+		}
+		operations[i] = parseOperation(item.Source, item.Data)
+	}
+
+	return operations, nil
+}
+
+// parseOperation parses a single operation, detecting its type from its fields.
+func parseOperation(source string, data []byte) *operation {
+	op := &operation{
+		source: source,
+	}
+
+	fields := make(map[string]json.RawMessage)
+	if err := json.Unmarshal(data, &fields); err != nil {
+		op.err = errors.Wrap(err, "invalid JSON")
+		return op
+	}
+
+	op.opType = detectOperationType(fields)
+	var err error
+	switch op.opType {
+	case operationTypeVoluntaryExit:
+		if _, exists := fields["exit"]; exists {
+			// Output of "ethdo validator exit --json", which includes the fork version.
+			exitData := &util.ValidatorExitData{}
+			err = json.Unmarshal(data, exitData)
+			if err == nil {
+				op.voluntaryExit = exitData.Exit
+				op.forkVersion = &exitData.ForkVersion
+			}
+		} else {
+			op.voluntaryExit = &phase0.SignedVoluntaryExit{}
+			err = json.Unmarshal(data, op.voluntaryExit)
+		}
+	case operationTypeBLSToExecutionChange:
+		op.blsToExecutionChange = &util.SignedBLSToExecutionChange{}
+		err = json.Unmarshal(data, op.blsToExecutionChange)
+	case operationTypeAttesterSlashing:
+		op.attesterSlashing = &phase0.AttesterSlashing{}
+		err = json.Unmarshal(data, op.attesterSlashing)
+	case operationTypeProposerSlashing:
+		op.proposerSlashing = &phase0.ProposerSlashing{}
+		err = json.Unmarshal(data, op.proposerSlashing)
+	case operationTypeSyncCommitteeMessage:
+		op.syncCommitteeMessage = &altair.SyncCommitteeMessage{}
+		err = json.Unmarshal(data, op.syncCommitteeMessage)
+	default:
+		err = errors.New("unknown operation type")
+	}
+	if err != nil {
+		op.err = errors.Wrap(err, "failed to parse")
+	}
+
+	return op
+}
+
+// detectOperationType detects the type of an operation from its top-level fields.
+func detectOperationType(fields map[string]json.RawMessage) operationType {
+	if _, exists := fields["exit"]; exists {
+		return operationTypeVoluntaryExit
+	}
+	if _, exists := fields["attestation_1"]; exists {
+		return operationTypeAttesterSlashing
+	}
+	if _, exists := fields["signed_header_1"]; exists {
+		return operationTypeProposerSlashing
+	}
+	if _, exists := fields["beacon_block_root"]; exists {
+		return operationTypeSyncCommitteeMessage
+	}
+	message, exists := fields["message"]
+	if !exists {
+		return operationTypeUnknown
+	}
+	messageFields := make(map[string]json.RawMessage)
+	if err := json.Unmarshal(message, &messageFields); err != nil {
+		return operationTypeUnknown
+	}
+	if _, exists := messageFields["from_bls_pubkey"]; exists {
+		return operationTypeBLSToExecutionChange
+	}
+	if _, exists := messageFields["epoch"]; exists {
+		return operationTypeVoluntaryExit
+	}
+
+	return operationTypeUnknown
+}
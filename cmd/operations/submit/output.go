@@ -0,0 +1,126 @@
+// Copyright © 2022 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package operationssubmit
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/aaron-alderman/ethdo/util"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+)
+
+type operationJSON struct {
+	Source    string `json:"source"`
+	Type      string `json:"type"`
+	Summary   string `json:"summary,omitempty"`
+	Submitted bool   `json:"submitted"`
+	Error     string `json:"error,omitempty"`
+}
+
+func (c *command) output(ctx context.Context) (string, error) {
+	if c.quiet {
+		return "", nil
+	}
+
+	if util.StructuredFormat(c.format) {
+		return c.outputStructured(ctx)
+	}
+
+	return c.outputText(ctx)
+}
+
+func (c *command) outputStructured(_ context.Context) (string, error) {
+	data := make([]*operationJSON, len(c.operations))
+	for i, op := range c.operations {
+		data[i] = &operationJSON{
+			Source:    op.source,
+			Type:      op.opType.String(),
+			Summary:   op.summary(),
+			Submitted: op.submitted,
+		}
+		if op.err != nil {
+			data[i].Error = op.err.Error()
+		}
+	}
+	res, err := json.Marshal(data)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to generate JSON")
+	}
+
+	return util.FormatJSONData(c.format, res)
+}
+
+func (c *command) outputText(_ context.Context) (string, error) {
+	builder := strings.Builder{}
+	for _, op := range c.operations {
+		builder.WriteString(op.source)
+		builder.WriteString(": ")
+		builder.WriteString(op.opType.String())
+		if summary := op.summary(); summary != "" {
+			builder.WriteString(" ")
+			builder.WriteString(summary)
+		}
+		switch {
+		case op.err != nil:
+			builder.WriteString(": failed: ")
+			builder.WriteString(op.err.Error())
+		case op.submitted:
+			builder.WriteString(": submitted")
+		}
+		builder.WriteString("\n")
+	}
+
+	return strings.TrimSuffix(builder.String(), "\n"), nil
+}
+
+// summary provides a short description of the validators concerned by an operation.
+func (op *operation) summary() string {
+	switch {
+	case op.voluntaryExit != nil && op.voluntaryExit.Message != nil:
+		return fmt.Sprintf("for validator %d", op.voluntaryExit.Message.ValidatorIndex)
+	case op.blsToExecutionChange != nil && op.blsToExecutionChange.Message != nil:
+		return fmt.Sprintf("for validator %d", op.blsToExecutionChange.Message.ValidatorIndex)
+	case op.attesterSlashing != nil && op.attesterSlashing.Attestation1 != nil && op.attesterSlashing.Attestation2 != nil:
+		indices := slashedIndices(op.attesterSlashing)
+		if len(indices) == 0 {
+			return ""
+		}
+		return fmt.Sprintf("of %s %s", pluralValidators(indices), joinIndices(indices))
+	case op.proposerSlashing != nil && op.proposerSlashing.SignedHeader1 != nil && op.proposerSlashing.SignedHeader1.Message != nil:
+		return fmt.Sprintf("of validator %d", op.proposerSlashing.SignedHeader1.Message.ProposerIndex)
+	case op.syncCommitteeMessage != nil:
+		return fmt.Sprintf("from validator %d for slot %d", op.syncCommitteeMessage.ValidatorIndex, op.syncCommitteeMessage.Slot)
+	default:
+		return ""
+	}
+}
+
+func pluralValidators(indices []phase0.ValidatorIndex) string {
+	if len(indices) == 1 {
+		return "validator"
+	}
+	return "validators"
+}
+
+func joinIndices(indices []phase0.ValidatorIndex) string {
+	strs := make([]string, len(indices))
+	for i := range indices {
+		strs[i] = fmt.Sprintf("%d", indices[i])
+	}
+	return strings.Join(strs, ", ")
+}
@@ -0,0 +1,89 @@
+// Copyright © 2022 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package operationssubmit
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOutput(t *testing.T) {
+	operations := []*operation{
+		{
+			source: "exit.json",
+			opType: operationTypeVoluntaryExit,
+			voluntaryExit: &phase0.SignedVoluntaryExit{
+				Message: &phase0.VoluntaryExit{
+					ValidatorIndex: 1,
+				},
+			},
+			verified:  true,
+			submitted: true,
+		},
+		{
+			source: "slashings.json[0]",
+			opType: operationTypeAttesterSlashing,
+			attesterSlashing: &phase0.AttesterSlashing{
+				Attestation1: &phase0.IndexedAttestation{AttestingIndices: []uint64{1, 2, 3}},
+				Attestation2: &phase0.IndexedAttestation{AttestingIndices: []uint64{2, 3}},
+			},
+			err: errors.New("signature does not verify"),
+		},
+		{
+			source: "unknown.json",
+			err:    errors.New("failed to parse: unknown operation type"),
+		},
+	}
+
+	tests := []struct {
+		name string
+		cmd  *command
+		res  string
+	}{
+		{
+			name: "Quiet",
+			cmd: &command{
+				quiet:      true,
+				operations: operations,
+			},
+		},
+		{
+			name: "Text",
+			cmd: &command{
+				operations: operations,
+			},
+			res: "exit.json: voluntary exit for validator 1: submitted\nslashings.json[0]: attester slashing of validators 2, 3: failed: signature does not verify\nunknown.json: unknown: failed: failed to parse: unknown operation type",
+		},
+		{
+			name: "JSON",
+			cmd: &command{
+				format:     "json",
+				operations: operations,
+			},
+			res: `[{"source":"exit.json","type":"voluntary exit","summary":"for validator 1","submitted":true},{"source":"slashings.json[0]","type":"attester slashing","summary":"of validators 2, 3","submitted":false,"error":"signature does not verify"},{"source":"unknown.json","type":"unknown","submitted":false,"error":"failed to parse: unknown operation type"}]`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			res, err := test.cmd.output(context.Background())
+			require.NoError(t, err)
+			require.Equal(t, test.res, res)
+		})
+	}
+}
@@ -0,0 +1,437 @@
+// Copyright © 2022 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package operationssubmit
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	standardchaintime "github.com/aaron-alderman/ethdo/services/chaintime/standard"
+	"github.com/aaron-alderman/ethdo/signing"
+	"github.com/aaron-alderman/ethdo/util"
+	eth2client "github.com/attestantio/go-eth2-client"
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/altair"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	e2types "github.com/wealdtech/go-eth2-types/v2"
+)
+
+// syncCommitteeDomainType is the domain type for sync committee messages.
+var syncCommitteeDomainType = phase0.DomainType{0x07, 0x00, 0x00, 0x00}
+
+func (c *command) process(ctx context.Context) error {
+	var err error
+	c.operations, err = obtainOperations(c.data)
+	if err != nil {
+		return err
+	}
+
+	// Obtain information we need to process.
+	if err := c.setup(ctx); err != nil {
+		return err
+	}
+
+	for _, op := range c.operations {
+		if op.err != nil {
+			continue
+		}
+		if err := c.verify(ctx, op); err != nil {
+			op.err = err
+			continue
+		}
+		op.verified = true
+	}
+
+	for _, op := range c.operations {
+		if !op.verified {
+			continue
+		}
+		if err := c.submit(ctx, op); err != nil {
+			op.err = errors.Wrap(err, "failed to submit")
+			continue
+		}
+		op.submitted = true
+		if err := util.AuditBroadcast(fmt.Sprintf("%s from %s", op.opType, op.source)); err != nil {
+			return errors.Wrap(err, "failed to record broadcast in audit log")
+		}
+	}
+
+	return nil
+}
+
+// verify verifies that an operation is valid for the current chain.
+func (c *command) verify(ctx context.Context, op *operation) error {
+	switch op.opType {
+	case operationTypeVoluntaryExit:
+		return c.verifyVoluntaryExit(ctx, op.voluntaryExit, op.forkVersion)
+	case operationTypeBLSToExecutionChange:
+		return c.verifyBLSToExecutionChange(ctx, op.blsToExecutionChange)
+	case operationTypeAttesterSlashing:
+		return c.verifyAttesterSlashing(ctx, op.attesterSlashing)
+	case operationTypeProposerSlashing:
+		return c.verifyProposerSlashing(ctx, op.proposerSlashing)
+	case operationTypeSyncCommitteeMessage:
+		return c.verifySyncCommitteeMessage(ctx, op.syncCommitteeMessage)
+	default:
+		return errors.New("unknown operation type")
+	}
+}
+
+func (c *command) verifyVoluntaryExit(ctx context.Context,
+	exit *phase0.SignedVoluntaryExit,
+	forkVersion *phase0.Version,
+) error {
+	if exit.Message == nil {
+		return errors.New("voluntary exit message missing")
+	}
+	validators, err := c.validators(ctx, []phase0.ValidatorIndex{exit.Message.ValidatorIndex})
+	if err != nil {
+		return err
+	}
+	validator := validators[exit.Message.ValidatorIndex]
+	if exit.Message.Epoch > c.chainTime.CurrentEpoch() {
+		return fmt.Errorf("exit epoch %d is later than current epoch %d", exit.Message.Epoch, c.chainTime.CurrentEpoch())
+	}
+	if validator.Status != apiv1.ValidatorStateActiveOngoing {
+		return fmt.Errorf("validator %d cannot exit with state %s", validator.Index, strings.ToLower(validator.Status.String()))
+	}
+
+	version := util.ForkVersionAtEpoch(c.forkSchedule, exit.Message.Epoch)
+	if forkVersion != nil {
+		if !c.knownForkVersion(*forkVersion) {
+			return fmt.Errorf("fork version %#x is not known to the chain", *forkVersion)
+		}
+		version = *forkVersion
+	}
+
+	root, err := exit.Message.HashTreeRoot()
+	if err != nil {
+		return errors.Wrap(err, "failed to generate root for voluntary exit")
+	}
+
+	return c.verifySignature(root, phase0.DomainType(e2types.DomainVoluntaryExit), version, exit.Signature, validator.Validator.PublicKey)
+}
+
+func (c *command) verifyBLSToExecutionChange(ctx context.Context,
+	change *util.SignedBLSToExecutionChange,
+) error {
+	validators, err := c.validators(ctx, []phase0.ValidatorIndex{change.Message.ValidatorIndex})
+	if err != nil {
+		return err
+	}
+	validator := validators[change.Message.ValidatorIndex]
+	if !util.IsBLSWithdrawalCredentials(validator.Validator.WithdrawalCredentials, change.Message.FromBLSPubkey[:]) {
+		return fmt.Errorf("withdrawal credentials of validator %d do not match the change", validator.Index)
+	}
+
+	root, err := change.Message.HashTreeRoot()
+	if err != nil {
+		return errors.Wrap(err, "failed to generate root for credentials change")
+	}
+
+	// Changes are signed with the genesis fork version so that they are valid across all forks.
+	return c.verifySignature(root, util.BLSToExecutionChangeDomainType, c.genesis.GenesisForkVersion, change.Signature, change.Message.FromBLSPubkey)
+}
+
+func (c *command) verifyAttesterSlashing(ctx context.Context,
+	slashing *phase0.AttesterSlashing,
+) error {
+	if slashing.Attestation1 == nil || slashing.Attestation1.Data == nil ||
+		slashing.Attestation2 == nil || slashing.Attestation2.Data == nil {
+		return errors.New("attester slashing attestation missing")
+	}
+	data1 := slashing.Attestation1.Data
+	data2 := slashing.Attestation2.Data
+
+	root1, err := data1.HashTreeRoot()
+	if err != nil {
+		return errors.Wrap(err, "failed to generate root for first attestation")
+	}
+	root2, err := data2.HashTreeRoot()
+	if err != nil {
+		return errors.Wrap(err, "failed to generate root for second attestation")
+	}
+	doubleVote := root1 != root2 && data1.Target.Epoch == data2.Target.Epoch
+	surroundVote := data1.Source.Epoch < data2.Source.Epoch && data2.Target.Epoch < data1.Target.Epoch
+	if !doubleVote && !surroundVote {
+		return errors.New("attestations are not slashable")
+	}
+
+	if len(slashedIndices(slashing)) == 0 {
+		return errors.New("attestations have no validators in common")
+	}
+
+	for i, attestation := range []*phase0.IndexedAttestation{slashing.Attestation1, slashing.Attestation2} {
+		if len(attestation.AttestingIndices) == 0 {
+			return fmt.Errorf("attestation %d has no attesting indices", i+1)
+		}
+		// Indices must be strictly increasing, which also ensures that they are unique.
+		for j := 1; j < len(attestation.AttestingIndices); j++ {
+			if attestation.AttestingIndices[j] <= attestation.AttestingIndices[j-1] {
+				return fmt.Errorf("attestation %d attesting indices are not sorted and unique", i+1)
+			}
+		}
+		indices := make([]phase0.ValidatorIndex, len(attestation.AttestingIndices))
+		for j := range attestation.AttestingIndices {
+			indices[j] = phase0.ValidatorIndex(attestation.AttestingIndices[j])
+		}
+		validators, err := c.validators(ctx, indices)
+		if err != nil {
+			return err
+		}
+		pubKeys := make([]phase0.BLSPubKey, len(indices))
+		for j := range indices {
+			pubKeys[j] = validators[indices[j]].Validator.PublicKey
+		}
+		root, err := attestation.Data.HashTreeRoot()
+		if err != nil {
+			return errors.Wrap(err, "failed to generate root for attestation")
+		}
+		if err := c.verifySignature(root, phase0.DomainType(e2types.DomainBeaconAttester), util.ForkVersionAtEpoch(c.forkSchedule, attestation.Data.Target.Epoch), attestation.Signature, pubKeys...); err != nil {
+			return errors.Wrap(err, fmt.Sprintf("attestation %d", i+1))
+		}
+	}
+
+	return nil
+}
+
+func (c *command) verifyProposerSlashing(ctx context.Context,
+	slashing *phase0.ProposerSlashing,
+) error {
+	if slashing.SignedHeader1 == nil || slashing.SignedHeader1.Message == nil ||
+		slashing.SignedHeader2 == nil || slashing.SignedHeader2.Message == nil {
+		return errors.New("proposer slashing header missing")
+	}
+	header1 := slashing.SignedHeader1.Message
+	header2 := slashing.SignedHeader2.Message
+	if header1.Slot != header2.Slot {
+		return errors.New("headers are for different slots")
+	}
+	if header1.ProposerIndex != header2.ProposerIndex {
+		return errors.New("headers are from different proposers")
+	}
+
+	validators, err := c.validators(ctx, []phase0.ValidatorIndex{header1.ProposerIndex})
+	if err != nil {
+		return err
+	}
+	validator := validators[header1.ProposerIndex]
+
+	roots := make([]phase0.Root, 2)
+	for i, header := range []*phase0.SignedBeaconBlockHeader{slashing.SignedHeader1, slashing.SignedHeader2} {
+		roots[i], err = header.Message.HashTreeRoot()
+		if err != nil {
+			return errors.Wrap(err, "failed to generate root for header")
+		}
+		if err := c.verifySignature(roots[i], phase0.DomainType(e2types.DomainBeaconProposer), util.ForkVersionAtEpoch(c.forkSchedule, c.chainTime.SlotToEpoch(header.Message.Slot)), header.Signature, validator.Validator.PublicKey); err != nil {
+			return errors.Wrap(err, fmt.Sprintf("header %d", i+1))
+		}
+	}
+	if roots[0] == roots[1] {
+		return errors.New("headers are identical")
+	}
+
+	return nil
+}
+
+func (c *command) verifySyncCommitteeMessage(ctx context.Context,
+	message *altair.SyncCommitteeMessage,
+) error {
+	validators, err := c.validators(ctx, []phase0.ValidatorIndex{message.ValidatorIndex})
+	if err != nil {
+		return err
+	}
+	validator := validators[message.ValidatorIndex]
+
+	inSyncCommittee, err := c.inSyncCommittee(ctx, message.ValidatorIndex)
+	if err != nil {
+		return err
+	}
+	if !inSyncCommittee {
+		return fmt.Errorf("validator %d is not in the current sync committee", message.ValidatorIndex)
+	}
+
+	// A sync committee message signs the block root directly rather than the root of a container.
+	return c.verifySignature(message.BeaconBlockRoot, syncCommitteeDomainType, util.ForkVersionAtEpoch(c.forkSchedule, c.chainTime.SlotToEpoch(message.Slot)), message.Signature, validator.Validator.PublicKey)
+}
+
+// verifySignature verifies a signature over a root, aggregating the public keys if more
+// than one is supplied.
+func (c *command) verifySignature(root phase0.Root,
+	domainType phase0.DomainType,
+	forkVersion phase0.Version,
+	signature phase0.BLSSignature,
+	pubKeys ...phase0.BLSPubKey,
+) error {
+	domain, err := signing.Domain(domainType, forkVersion, c.genesis.GenesisValidatorsRoot)
+	if err != nil {
+		return err
+	}
+
+	verified, err := signing.VerifyRoot(root, domain, signature, pubKeys...)
+	if err != nil {
+		return err
+	}
+	if !verified {
+		return errors.New("signature does not verify")
+	}
+
+	return nil
+}
+
+// validators obtains the validators with the given indices, returning an error if any are unknown.
+func (c *command) validators(ctx context.Context,
+	indices []phase0.ValidatorIndex,
+) (
+	map[phase0.ValidatorIndex]*apiv1.Validator,
+	error,
+) {
+	validators, err := c.validatorsProvider.Validators(ctx, "head", indices)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to obtain validators")
+	}
+	for _, index := range indices {
+		if _, exists := validators[index]; !exists {
+			return nil, fmt.Errorf("validator %d not found", index)
+		}
+	}
+
+	return validators, nil
+}
+
+// inSyncCommittee returns true if the validator is a member of the current sync committee.
+// The committee is obtained the first time it is required.
+func (c *command) inSyncCommittee(ctx context.Context, index phase0.ValidatorIndex) (bool, error) {
+	if c.syncCommittee == nil {
+		syncCommittee, err := c.syncCommitteesProvider.SyncCommittee(ctx, "head")
+		if err != nil {
+			return false, errors.Wrap(err, "failed to obtain current sync committee")
+		}
+		c.syncCommittee = syncCommittee
+	}
+	for _, member := range c.syncCommittee.Validators {
+		if member == index {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// knownForkVersion returns true if the fork version is part of the chain's fork schedule.
+func (c *command) knownForkVersion(version phase0.Version) bool {
+	if version == c.genesis.GenesisForkVersion {
+		return true
+	}
+	for _, fork := range c.forkSchedule {
+		if version == fork.CurrentVersion {
+			return true
+		}
+	}
+
+	return false
+}
+
+// slashedIndices returns the indices of the validators that would be slashed by an attester slashing.
+func slashedIndices(slashing *phase0.AttesterSlashing) []phase0.ValidatorIndex {
+	attesters := make(map[uint64]bool, len(slashing.Attestation1.AttestingIndices))
+	for _, index := range slashing.Attestation1.AttestingIndices {
+		attesters[index] = true
+	}
+	res := make([]phase0.ValidatorIndex, 0)
+	for _, index := range slashing.Attestation2.AttestingIndices {
+		if attesters[index] {
+			res = append(res, phase0.ValidatorIndex(index))
+		}
+	}
+
+	return res
+}
+
+// submit submits a verified operation to the beacon node.
+func (c *command) submit(ctx context.Context, op *operation) error {
+	switch op.opType {
+	case operationTypeVoluntaryExit:
+		return c.voluntaryExitSubmitter.SubmitVoluntaryExit(ctx, op.voluntaryExit)
+	case operationTypeBLSToExecutionChange:
+		return util.SubmitBLSToExecutionChanges(ctx, c.consensusClient, c.timeout, []*util.SignedBLSToExecutionChange{op.blsToExecutionChange})
+	case operationTypeAttesterSlashing:
+		return util.SubmitAttesterSlashing(ctx, c.consensusClient, c.timeout, op.attesterSlashing)
+	case operationTypeProposerSlashing:
+		return util.SubmitProposerSlashing(ctx, c.consensusClient, c.timeout, op.proposerSlashing)
+	case operationTypeSyncCommitteeMessage:
+		return c.syncCommitteeMessagesSubmitter.SubmitSyncCommitteeMessages(ctx, []*altair.SyncCommitteeMessage{op.syncCommitteeMessage})
+	default:
+		return errors.New("unknown operation type")
+	}
+}
+
+func (c *command) setup(ctx context.Context) error {
+	var err error
+
+	// Connect to the consensus node.
+	c.consensusClient, err = util.ConnectToBeaconNode(ctx, c.connection, c.timeout, c.allowInsecureConnections)
+	if err != nil {
+		return errors.Wrap(err, "failed to connect to consensus node")
+	}
+
+	c.chainTime, err = standardchaintime.New(ctx,
+		standardchaintime.WithSpecProvider(c.consensusClient.(eth2client.SpecProvider)),
+		standardchaintime.WithForkScheduleProvider(c.consensusClient.(eth2client.ForkScheduleProvider)),
+		standardchaintime.WithGenesisTimeProvider(c.consensusClient.(eth2client.GenesisTimeProvider)),
+	)
+	if err != nil {
+		return errors.Wrap(err, "failed to set up chaintime service")
+	}
+
+	var isProvider bool
+	c.validatorsProvider, isProvider = c.consensusClient.(eth2client.ValidatorsProvider)
+	if !isProvider {
+		return errors.New("consensus node does not provide validator information")
+	}
+	c.voluntaryExitSubmitter, isProvider = c.consensusClient.(eth2client.VoluntaryExitSubmitter)
+	if !isProvider {
+		return errors.New("consensus node does not submit voluntary exits")
+	}
+	c.syncCommitteeMessagesSubmitter, isProvider = c.consensusClient.(eth2client.SyncCommitteeMessagesSubmitter)
+	if !isProvider {
+		return errors.New("consensus node does not submit sync committee messages")
+	}
+	c.syncCommitteesProvider, isProvider = c.consensusClient.(eth2client.SyncCommitteesProvider)
+	if !isProvider {
+		return errors.New("consensus node does not provide sync committee information")
+	}
+
+	genesisProvider, isProvider := c.consensusClient.(eth2client.GenesisProvider)
+	if !isProvider {
+		return errors.New("consensus node does not provide genesis information")
+	}
+	c.genesis, err = genesisProvider.Genesis(ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain genesis")
+	}
+
+	forkScheduleProvider, isProvider := c.consensusClient.(eth2client.ForkScheduleProvider)
+	if !isProvider {
+		return errors.New("consensus node does not provide fork schedule")
+	}
+	c.forkSchedule, err = forkScheduleProvider.ForkSchedule(ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain fork schedule")
+	}
+
+	return nil
+}
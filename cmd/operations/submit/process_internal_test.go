@@ -0,0 +1,397 @@
+// Copyright © 2022 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package operationssubmit
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/aaron-alderman/ethdo/testing/beaconnode"
+	"github.com/aaron-alderman/ethdo/testutil"
+	"github.com/aaron-alderman/ethdo/util"
+	"github.com/attestantio/go-eth2-client/spec/altair"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/rs/zerolog"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+	e2types "github.com/wealdtech/go-eth2-types/v2"
+	util2 "github.com/wealdtech/go-eth2-util"
+)
+
+// signOperation signs a root for the fixture chain.
+func signOperation(t *testing.T, key *e2types.BLSPrivateKey, root phase0.Root, domainType phase0.DomainType) phase0.BLSSignature {
+	t.Helper()
+
+	genesisValidatorsRoot := testutil.HexToRoot("0x4b363db94e286120d76eb905340fdd4e54bfe9f06bf33ff6cf5ad27f511bfe95")
+	domainBytes, err := e2types.ComputeDomain(e2types.DomainType(domainType), []byte{0x00, 0x00, 0x00, 0x00}, genesisValidatorsRoot[:])
+	require.NoError(t, err)
+	container := &phase0.SigningData{
+		ObjectRoot: root,
+	}
+	copy(container.Domain[:], domainBytes)
+	signingRoot, err := container.HashTreeRoot()
+	require.NoError(t, err)
+
+	var sig phase0.BLSSignature
+	copy(sig[:], key.Sign(signingRoot[:]).Marshal())
+	return sig
+}
+
+func signedExit(t *testing.T, key *e2types.BLSPrivateKey, index phase0.ValidatorIndex, epoch phase0.Epoch) *phase0.SignedVoluntaryExit {
+	t.Helper()
+
+	exit := &phase0.VoluntaryExit{
+		Epoch:          epoch,
+		ValidatorIndex: index,
+	}
+	root, err := exit.HashTreeRoot()
+	require.NoError(t, err)
+	return &phase0.SignedVoluntaryExit{
+		Message:   exit,
+		Signature: signOperation(t, key, root, phase0.DomainType(e2types.DomainVoluntaryExit)),
+	}
+}
+
+func signedChange(t *testing.T, key *e2types.BLSPrivateKey, index phase0.ValidatorIndex) *util.SignedBLSToExecutionChange {
+	t.Helper()
+
+	change := &util.BLSToExecutionChange{
+		ValidatorIndex: index,
+	}
+	copy(change.FromBLSPubkey[:], key.PublicKey().Marshal())
+	address, err := util.ParseExecutionAddress("0x8c1Ff978036F2e9d7CC382Eff7B4c8c53C22ac15")
+	require.NoError(t, err)
+	change.ToExecutionAddress = address
+	root, err := change.HashTreeRoot()
+	require.NoError(t, err)
+	return &util.SignedBLSToExecutionChange{
+		Message:   change,
+		Signature: signOperation(t, key, root, util.BLSToExecutionChangeDomainType),
+	}
+}
+
+func signedHeader(t *testing.T, key *e2types.BLSPrivateKey, index phase0.ValidatorIndex, bodyRoot string) *phase0.SignedBeaconBlockHeader {
+	t.Helper()
+
+	header := &phase0.BeaconBlockHeader{
+		Slot:          1,
+		ProposerIndex: index,
+		BodyRoot:      testutil.HexToRoot(bodyRoot),
+	}
+	root, err := header.HashTreeRoot()
+	require.NoError(t, err)
+	return &phase0.SignedBeaconBlockHeader{
+		Message:   header,
+		Signature: signOperation(t, key, root, phase0.DomainType(e2types.DomainBeaconProposer)),
+	}
+}
+
+func indexedAttestation(t *testing.T, keys []*e2types.BLSPrivateKey, indices []uint64, blockRoot string) *phase0.IndexedAttestation {
+	t.Helper()
+
+	data := &phase0.AttestationData{
+		Slot:            1,
+		BeaconBlockRoot: testutil.HexToRoot(blockRoot),
+		Source:          &phase0.Checkpoint{},
+		Target:          &phase0.Checkpoint{Epoch: 0},
+	}
+	root, err := data.HashTreeRoot()
+	require.NoError(t, err)
+	sigs := make([]e2types.Signature, len(keys))
+	for i := range keys {
+		sig := signOperation(t, keys[i], root, phase0.DomainType(e2types.DomainBeaconAttester))
+		sigs[i], err = e2types.BLSSignatureFromBytes(sig[:])
+		require.NoError(t, err)
+	}
+	attestation := &phase0.IndexedAttestation{
+		AttestingIndices: indices,
+		Data:             data,
+	}
+	copy(attestation.Signature[:], e2types.AggregateSignatures(sigs).Marshal())
+	return attestation
+}
+
+func signedSyncCommitteeMessage(t *testing.T, key *e2types.BLSPrivateKey, index phase0.ValidatorIndex) *altair.SyncCommitteeMessage {
+	t.Helper()
+
+	root := testutil.HexToRoot("0x0101010101010101010101010101010101010101010101010101010101010101")
+	return &altair.SyncCommitteeMessage{
+		Slot:            1,
+		BeaconBlockRoot: root,
+		ValidatorIndex:  index,
+		Signature:       signOperation(t, key, root, syncCommitteeDomainType),
+	}
+}
+
+func writeOperation(t *testing.T, dir string, name string, op interface{}) string {
+	t.Helper()
+
+	data, err := json.Marshal(op)
+	require.NoError(t, err)
+	path := filepath.Join(dir, name)
+	require.NoError(t, os.WriteFile(path, data, 0600))
+	return path
+}
+
+func TestProcess(t *testing.T) {
+	zerolog.SetGlobalLevel(zerolog.Disabled)
+	require.NoError(t, e2types.InitBLS())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	key0, err := e2types.BLSPrivateKeyFromBytes(testutil.HexToBytes("0x25295f0d1d592a90b333e26e85149708208e9f8e8bc18f6c77bd62f8ad7a6866"))
+	require.NoError(t, err)
+	key1, err := e2types.BLSPrivateKeyFromBytes(testutil.HexToBytes("0x51d0b65185db6989ab0b560d6deed19c7ead0e24b9b6372cbecb1f26bdfad000"))
+	require.NoError(t, err)
+	// The withdrawal key for validator 0.
+	seed, err := util.SeedFromMnemonic("abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon art")
+	require.NoError(t, err)
+	withdrawalKey, err := util2.PrivateKeyFromSeedAndPath(seed, "m/12381/3600/10/0")
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	exitDataPath := writeOperation(t, dir, "exitdata.json", &util.ValidatorExitData{
+		Exit: signedExit(t, key0, 0, 1),
+	})
+	exitingPath := writeOperation(t, dir, "exiting.json", signedExit(t, key1, 1, 1))
+	badSignaturePath := writeOperation(t, dir, "badsignature.json", signedExit(t, key1, 0, 1))
+	futureExitPath := writeOperation(t, dir, "futureexit.json", signedExit(t, key0, 0, 100000000))
+	changePath := writeOperation(t, dir, "change.json", signedChange(t, withdrawalKey, 0))
+	badChangePath := writeOperation(t, dir, "badchange.json", signedChange(t, withdrawalKey, 1))
+	proposerSlashingPath := writeOperation(t, dir, "proposerslashing.json", &phase0.ProposerSlashing{
+		SignedHeader1: signedHeader(t, key0, 0, "0x0101010101010101010101010101010101010101010101010101010101010101"),
+		SignedHeader2: signedHeader(t, key0, 0, "0x0202020202020202020202020202020202020202020202020202020202020202"),
+	})
+	identicalHeadersPath := writeOperation(t, dir, "identicalheaders.json", &phase0.ProposerSlashing{
+		SignedHeader1: signedHeader(t, key0, 0, "0x0101010101010101010101010101010101010101010101010101010101010101"),
+		SignedHeader2: signedHeader(t, key0, 0, "0x0101010101010101010101010101010101010101010101010101010101010101"),
+	})
+	attesterSlashingPath := writeOperation(t, dir, "attesterslashing.json", &phase0.AttesterSlashing{
+		Attestation1: indexedAttestation(t, []*e2types.BLSPrivateKey{key0, key1}, []uint64{0, 1}, "0x0101010101010101010101010101010101010101010101010101010101010101"),
+		Attestation2: indexedAttestation(t, []*e2types.BLSPrivateKey{key0}, []uint64{0}, "0x0202020202020202020202020202020202020202020202020202020202020202"),
+	})
+	unsortedAttesterSlashingPath := writeOperation(t, dir, "unsortedattesterslashing.json", &phase0.AttesterSlashing{
+		Attestation1: indexedAttestation(t, []*e2types.BLSPrivateKey{key1, key0}, []uint64{1, 0}, "0x0101010101010101010101010101010101010101010101010101010101010101"),
+		Attestation2: indexedAttestation(t, []*e2types.BLSPrivateKey{key0}, []uint64{0}, "0x0202020202020202020202020202020202020202020202020202020202020202"),
+	})
+	duplicateAttesterSlashingPath := writeOperation(t, dir, "duplicateattesterslashing.json", &phase0.AttesterSlashing{
+		Attestation1: indexedAttestation(t, []*e2types.BLSPrivateKey{key0, key1}, []uint64{0, 1}, "0x0101010101010101010101010101010101010101010101010101010101010101"),
+		Attestation2: indexedAttestation(t, []*e2types.BLSPrivateKey{key0, key0}, []uint64{0, 0}, "0x0202020202020202020202020202020202020202020202020202020202020202"),
+	})
+	syncCommitteeMessagesPath := writeOperation(t, dir, "synccommitteemessages.json", []*altair.SyncCommitteeMessage{
+		signedSyncCommitteeMessage(t, key0, 0),
+		signedSyncCommitteeMessage(t, key1, 1),
+	})
+	unknownPath := writeOperation(t, dir, "unknown.json", map[string]string{"foo": "bar"})
+	// The fixture chain has 12 second slots and 32 slot epochs.
+	currentEpoch := phase0.Epoch(time.Since(time.Unix(1606824023, 0)) / (384 * time.Second))
+
+	tests := []struct {
+		name        string
+		data        string
+		err         string
+		results     map[string]string
+		submissions map[string]int
+	}{
+		{
+			name: "Missing",
+			data: filepath.Join(dir, "missing.json"),
+			err:  "failed to access data: stat " + filepath.Join(dir, "missing.json") + ": no such file or directory",
+		},
+		{
+			name: "Empty",
+			data: t.TempDir(),
+			err:  "no operations found",
+		},
+		{
+			name: "VoluntaryExit",
+			data: exitDataPath,
+			results: map[string]string{
+				exitDataPath: "",
+			},
+			submissions: map[string]int{
+				"/eth/v1/beacon/pool/voluntary_exits": 1,
+			},
+		},
+		{
+			name: "VoluntaryExitExiting",
+			data: exitingPath,
+			results: map[string]string{
+				exitingPath: "validator 1 cannot exit with state active_exiting",
+			},
+		},
+		{
+			name: "VoluntaryExitBadSignature",
+			data: badSignaturePath,
+			results: map[string]string{
+				badSignaturePath: "signature does not verify",
+			},
+		},
+		{
+			name: "VoluntaryExitFuture",
+			data: futureExitPath,
+			results: map[string]string{
+				futureExitPath: fmt.Sprintf("exit epoch 100000000 is later than current epoch %d", currentEpoch),
+			},
+		},
+		{
+			name: "CredentialsChange",
+			data: changePath,
+			results: map[string]string{
+				changePath: "",
+			},
+			submissions: map[string]int{
+				"/eth/v1/beacon/pool/bls_to_execution_changes": 1,
+			},
+		},
+		{
+			name: "CredentialsChangeMismatch",
+			data: badChangePath,
+			results: map[string]string{
+				badChangePath: "withdrawal credentials of validator 1 do not match the change",
+			},
+		},
+		{
+			name: "ProposerSlashing",
+			data: proposerSlashingPath,
+			results: map[string]string{
+				proposerSlashingPath: "",
+			},
+			submissions: map[string]int{
+				"/eth/v1/beacon/pool/proposer_slashings": 1,
+			},
+		},
+		{
+			name: "ProposerSlashingIdentical",
+			data: identicalHeadersPath,
+			results: map[string]string{
+				identicalHeadersPath: "headers are identical",
+			},
+		},
+		{
+			name: "AttesterSlashing",
+			data: attesterSlashingPath,
+			results: map[string]string{
+				attesterSlashingPath: "",
+			},
+			submissions: map[string]int{
+				"/eth/v1/beacon/pool/attester_slashings": 1,
+			},
+		},
+		{
+			name: "AttesterSlashingUnsorted",
+			data: unsortedAttesterSlashingPath,
+			results: map[string]string{
+				unsortedAttesterSlashingPath: "attestation 1 attesting indices are not sorted and unique",
+			},
+		},
+		{
+			name: "AttesterSlashingDuplicate",
+			data: duplicateAttesterSlashingPath,
+			results: map[string]string{
+				duplicateAttesterSlashingPath: "attestation 2 attesting indices are not sorted and unique",
+			},
+		},
+		{
+			name: "SyncCommitteeMessages",
+			data: syncCommitteeMessagesPath,
+			results: map[string]string{
+				syncCommitteeMessagesPath + "[0]": "",
+				syncCommitteeMessagesPath + "[1]": "validator 1 is not in the current sync committee",
+			},
+			submissions: map[string]int{
+				"/eth/v1/beacon/pool/sync_committees": 1,
+			},
+		},
+		{
+			name: "Unknown",
+			data: unknownPath,
+			results: map[string]string{
+				unknownPath: "failed to parse: unknown operation type",
+			},
+		},
+		{
+			name: "Directory",
+			data: dir,
+			results: map[string]string{
+				attesterSlashingPath:              "",
+				badChangePath:                     "withdrawal credentials of validator 1 do not match the change",
+				badSignaturePath:                  "signature does not verify",
+				changePath:                        "",
+				duplicateAttesterSlashingPath:     "attestation 2 attesting indices are not sorted and unique",
+				exitDataPath:                      "",
+				exitingPath:                       "validator 1 cannot exit with state active_exiting",
+				futureExitPath:                    fmt.Sprintf("exit epoch 100000000 is later than current epoch %d", currentEpoch),
+				identicalHeadersPath:              "headers are identical",
+				proposerSlashingPath:              "",
+				syncCommitteeMessagesPath + "[0]": "",
+				syncCommitteeMessagesPath + "[1]": "validator 1 is not in the current sync committee",
+				unknownPath:                       "failed to parse: unknown operation type",
+				unsortedAttesterSlashingPath:      "attestation 1 attesting indices are not sorted and unique",
+			},
+			submissions: map[string]int{
+				"/eth/v1/beacon/pool/voluntary_exits":          1,
+				"/eth/v1/beacon/pool/bls_to_execution_changes": 1,
+				"/eth/v1/beacon/pool/proposer_slashings":       1,
+				"/eth/v1/beacon/pool/attester_slashings":       1,
+				"/eth/v1/beacon/pool/sync_committees":          1,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Use a fresh beacon node for each test to keep track of submissions.
+			beaconNode, err := beaconnode.New(ctx,
+				beaconnode.WithFixturesDir("../../../testing/beaconnode/testdata"),
+			)
+			require.NoError(t, err)
+			defer beaconNode.Close()
+
+			viper.Reset()
+			viper.Set("timeout", "5s")
+			viper.Set("connection", beaconNode.Address())
+			viper.Set("data", test.data)
+			cmd, err := newCommand(context.Background())
+			require.NoError(t, err)
+			err = cmd.process(context.Background())
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+				return
+			}
+			require.NoError(t, err)
+
+			results := make(map[string]string)
+			for _, op := range cmd.operations {
+				results[op.source] = ""
+				if op.err != nil {
+					results[op.source] = op.err.Error()
+				} else {
+					require.True(t, op.submitted)
+				}
+			}
+			require.Equal(t, test.results, results)
+
+			for path, count := range test.submissions {
+				require.Len(t, beaconNode.Submissions(path), count, path)
+			}
+		})
+	}
+}
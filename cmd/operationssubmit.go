@@ -0,0 +1,59 @@
+// Copyright © 2022 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	operationssubmit "github.com/aaron-alderman/ethdo/cmd/operations/submit"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var operationsSubmitCmd = &cobra.Command{
+	Use:   "submit",
+	Short: "Verify and submit signed operations",
+	Long: `Verify and submit signed operations held in files.  For example:
+
+    ethdo operations submit --data=operations/
+
+--data can be a single file or a directory, in which case all files with a .json suffix are read.  Each file can contain a single operation or an array of operations.  Supported operations are voluntary exits (including the output of "ethdo validator exit --json"), credentials changes, attester slashings, proposer slashings and sync committee messages.  The type of each operation is detected automatically.
+
+Each operation has its signature verified against the current chain before it is submitted, along with checks that it could be included on the chain; for example, sync committee messages must come from a member of the current sync committee, and operations that fail verification are not submitted.  A report is output showing the result for each operation.
+
+In quiet mode this will return 0 if all operations are submitted, otherwise 1.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		res, err := operationssubmit.Run(cmd)
+		if res != "" && !viper.GetBool("quiet") {
+			fmt.Println(res)
+		}
+		return err
+	},
+}
+
+func init() {
+	operationsCmd.AddCommand(operationsSubmitCmd)
+	operationsFlags(operationsSubmitCmd)
+	operationsSubmitCmd.Flags().String("data", "", "File or directory containing signed operations")
+	operationsSubmitCmd.Flags().Bool("json", false, "Output the report as JSON")
+}
+
+func operationsSubmitBindings() {
+	if err := viper.BindPFlag("data", operationsSubmitCmd.Flags().Lookup("data")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("json", operationsSubmitCmd.Flags().Lookup("json")); err != nil {
+		panic(err)
+	}
+}
@@ -18,6 +18,7 @@ import (
 	"time"
 
 	"github.com/aaron-alderman/ethdo/services/chaintime"
+	"github.com/aaron-alderman/ethdo/util"
 	eth2client "github.com/attestantio/go-eth2-client"
 	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
 	"github.com/attestantio/go-eth2-client/spec/phase0"
@@ -36,8 +37,8 @@ type command struct {
 	allowInsecureConnections bool
 
 	// Operation.
-	epoch      string
-	jsonOutput bool
+	epoch  string
+	format string
 
 	// Data access.
 	eth2Client             eth2client.Service
@@ -74,7 +75,7 @@ func newCommand(ctx context.Context) (*command, error) {
 	c.allowInsecureConnections = viper.GetBool("allow-insecure-connections")
 
 	c.epoch = viper.GetString("epoch")
-	c.jsonOutput = viper.GetBool("json")
+	c.format = util.OutputFormat()
 
 	return c, nil
 }
@@ -18,6 +18,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"strings"
+
+	"github.com/aaron-alderman/ethdo/util"
 )
 
 func (c *command) output(ctx context.Context) (string, error) {
@@ -25,19 +27,19 @@ func (c *command) output(ctx context.Context) (string, error) {
 		return "", nil
 	}
 
-	if c.jsonOutput {
-		return c.outputJSON(ctx)
+	if util.StructuredFormat(c.format) {
+		return c.outputStructured(ctx)
 	}
 
 	return c.outputTxt(ctx)
 }
 
-func (c *command) outputJSON(_ context.Context) (string, error) {
+func (c *command) outputStructured(_ context.Context) (string, error) {
 	data, err := json.Marshal(c.results)
 	if err != nil {
 		return "", err
 	}
-	return string(data), nil
+	return util.FormatJSONData(c.format, data)
 }
 
 func (c *command) outputTxt(_ context.Context) (string, error) {
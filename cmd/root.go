@@ -53,8 +53,8 @@ func persistentPreRunE(cmd *cobra.Command, args []string) error {
 	}
 
 	if cmd.Name() == "version" {
-		// User just wants the version
-		return nil
+		// User just wants the version, but the output format must still be valid.
+		return util.CheckOutputFormat(util.OutputFormat())
 	}
 
 	// Disable service logging.
@@ -66,6 +66,11 @@ func persistentPreRunE(cmd *cobra.Command, args []string) error {
 	debug = viper.GetBool("debug")
 
 	includeCommandBindings(cmd)
+	util.SetAuditCommand(cmd.CommandPath())
+
+	if err := util.CheckOutputFormat(util.OutputFormat()); err != nil {
+		return err
+	}
 
 	if quiet && verbose {
 		fmt.Println("Cannot supply both quiet and verbose flags")
@@ -90,34 +95,76 @@ func includeCommandBindings(cmd *cobra.Command) {
 		attesterDutiesBindings()
 	case "attester/inclusion":
 		attesterInclusionBindings()
+	case "audit/verify":
+		auditVerifyBindings()
 	case "block/analyze":
 		blockAnalyzeBindings()
 	case "block/info":
 		blockInfoBindings()
+	case "cache/info":
+		cacheInfoBindings()
+	case "cache/prune":
+		cachePruneBindings()
 	case "chain/eth1votes":
 		chainEth1VotesBindings()
 	case "chain/queues":
 		chainQueuesBindings()
 	case "chain/time":
 		chainTimeBindings()
+	case "chain/verify/signedaggregateandproof":
+		chainVerifySignedAggregateAndProofBindings(cmd)
+	case "chain/verify/signedattestation":
+		chainVerifySignedAttestationBindings(cmd)
+	case "chain/verify/signedbeaconblock":
+		chainVerifySignedBeaconBlockBindings(cmd)
 	case "chain/verify/signedcontributionandproof":
 		chainVerifySignedContributionAndProofBindings(cmd)
+	case "chain/verify/synccommitteemessage":
+		chainVerifySyncCommitteeMessageBindings(cmd)
+	case "deposit/verify":
+		depositVerifyBindings()
 	case "epoch/summary":
 		epochSummaryBindings()
 	case "exit/verify":
 		exitVerifyBindings()
+	case "monitor":
+		monitorBindings()
 	case "node/events":
 		nodeEventsBindings()
+	case "operations/submit":
+		operationsSubmitBindings()
 	case "proposer/duties":
 		proposerDutiesBindings()
+	case "slashingprotection/check":
+		slashingProtectionCheckBindings()
+	case "slashingprotection/export":
+		slashingProtectionExportBindings()
+	case "slashingprotection/merge":
+		slashingProtectionMergeBindings()
+	case "slashingprotection/minify":
+		slashingProtectionMinifyBindings()
+	case "slashingprotection/prune":
+		slashingProtectionPruneBindings()
+	case "slashingprotection/validate":
+		slashingProtectionValidateBindings()
 	case "slot/time":
 		slotTimeBindings()
+	case "state/diff":
+		stateDiffBindings()
+	case "state/info":
+		stateInfoBindings()
+	case "state/summary":
+		stateSummaryBindings()
+	case "synccommittee/duties":
+		synccommitteeDutiesBindings()
 	case "synccommittee/inclusion":
 		synccommitteeInclusionBindings()
 	case "synccommittee/members":
 		synccommitteeMembersBindings()
 	case "validator/credentials/get":
 		validatorCredentialsGetBindings()
+	case "validator/credentials/set":
+		validatorCredentialsSetBindings()
 	case "validator/depositdata":
 		validatorDepositdataBindings()
 	case "validator/duties":
@@ -128,6 +175,10 @@ func includeCommandBindings(cmd *cobra.Command) {
 		validatorInfoBindings()
 	case "validator/keycheck":
 		validatorKeycheckBindings()
+	case "validator/maintenance-window":
+		validatorMaintenanceWindowBindings()
+	case "validator/performance":
+		validatorPerformanceBindings()
 	case "validator/yield":
 		validatorYieldBindings()
 	case "validator/expectation":
@@ -140,17 +191,55 @@ func includeCommandBindings(cmd *cobra.Command) {
 		walletSharedExportBindings()
 	case "wallet/sharedimport":
 		walletSharedImportBindings()
+	case "wallet/sharedverify":
+		walletSharedVerifyBindings()
 	}
 }
 
 // Execute adds all child commands to the root command and sets flags appropriately.
 // This is called by main.main(). It only needs to happen once to the rootCmd.
 func Execute() {
-	if err := RootCmd.Execute(); err != nil {
+	// Errors and usage are reported here rather than by cobra, to allow structured errors.
+	RootCmd.SilenceErrors = true
+	RootCmd.SilenceUsage = true
+	cmd, err := RootCmd.ExecuteC()
+	if cacheErr := util.CloseCache(context.Background()); cacheErr != nil && viper.GetBool("debug") {
+		fmt.Fprintf(os.Stderr, "Failed to close cache: %v\n", cacheErr)
+	}
+	if err != nil {
+		outputError(cmd, err)
 		os.Exit(_exitFailure)
 	}
 }
 
+// outputError outputs an error returned by a command.
+// Commands set SilenceUsage once their input has been validated, so errors
+// returned before then are considered to be errors in the input.
+func outputError(cmd *cobra.Command, err error) {
+	code := util.ErrorCode(err)
+	if code == "" {
+		if cmd.SilenceUsage {
+			code = util.ErrorCodeFailed
+		} else {
+			code = util.ErrorCodeInvalidInput
+		}
+	}
+
+	format := util.OutputFormat()
+	if format != util.FormatText && util.CheckOutputFormat(format) == nil {
+		res, formatErr := util.FormatError(format, code, err)
+		if formatErr == nil {
+			fmt.Fprintln(os.Stderr, res)
+			return
+		}
+	}
+
+	fmt.Fprintf(os.Stderr, "Error: %s\n", err.Error())
+	if !cmd.SilenceUsage {
+		fmt.Fprint(os.Stderr, cmd.UsageString())
+	}
+}
+
 func init() {
 	// Initialise our BLS library.
 	if err := e2types.InitBLS(); err != nil {
@@ -161,10 +250,14 @@ func init() {
 	cobra.OnInitialize(initConfig)
 
 	RootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file (default is $HOME/.ethdo.yaml)")
-	RootCmd.PersistentFlags().String("log", "", "log activity to the named file (default $HOME/ethdo.log).  Logs are written for every action that generates a transaction")
+	RootCmd.PersistentFlags().String("log", "", "append a hash-chained audit log to the named file.  Entries are written for every action that signs, broadcasts or exports key material.  See --log-key to make the log tamper-evident")
 	if err := viper.BindPFlag("log", RootCmd.PersistentFlags().Lookup("log")); err != nil {
 		panic(err)
 	}
+	RootCmd.PersistentFlags().String("log-key", "", "key held by the operator with which to authenticate and encrypt entries in the audit log.  Without a key anyone able to write to the log can recalculate its hash chain")
+	if err := viper.BindPFlag("log-key", RootCmd.PersistentFlags().Lookup("log-key")); err != nil {
+		panic(err)
+	}
 	RootCmd.PersistentFlags().String("store", "filesystem", "Store for accounts")
 	if err := viper.BindPFlag("store", RootCmd.PersistentFlags().Lookup("store")); err != nil {
 		panic(err)
@@ -218,14 +311,30 @@ func init() {
 	if err := viper.BindPFlag("verbose", RootCmd.PersistentFlags().Lookup("verbose")); err != nil {
 		panic(err)
 	}
+	RootCmd.PersistentFlags().String("format", util.FormatText, "output format: text, json, yaml or csv")
+	if err := viper.BindPFlag("format", RootCmd.PersistentFlags().Lookup("format")); err != nil {
+		panic(err)
+	}
 	RootCmd.PersistentFlags().Bool("debug", false, "generate debug output")
 	if err := viper.BindPFlag("debug", RootCmd.PersistentFlags().Lookup("debug")); err != nil {
 		panic(err)
 	}
-	RootCmd.PersistentFlags().String("connection", "", "URL to an Ethereum 2 node's RET API endpoint")
+	RootCmd.PersistentFlags().String("connection", "", "URL to an Ethereum 2 node's REST API endpoint; multiple comma-separated URLs can be supplied for failover")
 	if err := viper.BindPFlag("connection", RootCmd.PersistentFlags().Lookup("connection")); err != nil {
 		panic(err)
 	}
+	RootCmd.PersistentFlags().Bool("connection-first-response", false, "when multiple connections are supplied, send read requests to all of them and use the first successful response")
+	if err := viper.BindPFlag("connection-first-response", RootCmd.PersistentFlags().Lookup("connection-first-response")); err != nil {
+		panic(err)
+	}
+	RootCmd.PersistentFlags().String("cache-dir", "", "directory in which to cache finalized data from the beacon node (default no cache)")
+	if err := viper.BindPFlag("cache-dir", RootCmd.PersistentFlags().Lookup("cache-dir")); err != nil {
+		panic(err)
+	}
+	RootCmd.PersistentFlags().String("deposit-history", "", "source of deposit history: the URL of an execution node's JSON-RPC endpoint, the path to a deposit log file, or \"thegraph\" (default no deposit history)")
+	if err := viper.BindPFlag("deposit-history", RootCmd.PersistentFlags().Lookup("deposit-history")); err != nil {
+		panic(err)
+	}
 	RootCmd.PersistentFlags().Duration("timeout", 10*time.Second, "the time after which a network request will be considered failed.  Increase this if you are running on an error-prone, high-latency or low-bandwidth connection")
 	if err := viper.BindPFlag("timeout", RootCmd.PersistentFlags().Lookup("timeout")); err != nil {
 		panic(err)
@@ -292,6 +401,29 @@ func outputIf(condition bool, msg string) {
 	}
 }
 
+// verifiedJSON is the structured output of a successful verification.
+type verifiedJSON struct {
+	Verified bool `json:"verified"`
+}
+
+// signatureJSON is the structured output of a signature.
+type signatureJSON struct {
+	Signature string `json:"signature"`
+}
+
+// outputStructured prints data in the selected output format if it is a structured format.
+// It returns false if the output format is text, in which case nothing is printed.
+func outputStructured(data interface{}) bool {
+	format := util.OutputFormat()
+	if !util.StructuredFormat(format) {
+		return false
+	}
+	res, err := util.FormatOutput(format, data)
+	errCheck(err, "Failed to generate output")
+	fmt.Println(res)
+	return true
+}
+
 // walletFromInput obtains a wallet given the information in the viper variable
 // "account", or if not present the viper variable "wallet".
 func walletFromInput(ctx context.Context) (e2wtypes.Wallet, error) {
@@ -14,9 +14,17 @@
 package cmd
 
 import (
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/aaron-alderman/ethdo/util"
+	spec "github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
 	"github.com/spf13/viper"
+	e2types "github.com/wealdtech/go-eth2-types/v2"
 )
 
 // signatureCmd represents the signature command
@@ -33,6 +41,8 @@ func init() {
 
 var dataFlag *pflag.Flag
 var domainFlag *pflag.Flag
+var signatureNetworkFlag *pflag.Flag
+var domainTypeFlag *pflag.Flag
 
 func signatureFlags(cmd *cobra.Command) {
 	if dataFlag == nil {
@@ -46,8 +56,63 @@ func signatureFlags(cmd *cobra.Command) {
 		if err := viper.BindPFlag("signature-domain", domainFlag); err != nil {
 			panic(err)
 		}
+		cmd.Flags().String("network", "", "calculate the BLS domain for the current fork of a named network rather than supplying it")
+		signatureNetworkFlag = cmd.Flags().Lookup("network")
+		if err := viper.BindPFlag("signature-network", signatureNetworkFlag); err != nil {
+			panic(err)
+		}
+		cmd.Flags().String("domain-type", "", "the BLS domain type, as a hex string, when calculating the domain for a network")
+		domainTypeFlag = cmd.Flags().Lookup("domain-type")
+		if err := viper.BindPFlag("signature-domain-type", domainTypeFlag); err != nil {
+			panic(err)
+		}
 	} else {
 		cmd.Flags().AddFlag(dataFlag)
 		cmd.Flags().AddFlag(domainFlag)
+		cmd.Flags().AddFlag(signatureNetworkFlag)
+		cmd.Flags().AddFlag(domainTypeFlag)
+	}
+}
+
+// signatureDomain returns the domain for signature commands.  If a network is supplied the domain is
+// calculated from the domain type and the latest fork of the network, otherwise the supplied domain is used.
+func signatureDomain() ([]byte, error) {
+	if viper.GetString("signature-network") == "" {
+		if viper.GetString("signature-domain") == "" {
+			return e2types.Domain(e2types.DomainType([4]byte{0, 0, 0, 0}), e2types.ZeroForkVersion, e2types.ZeroGenesisValidatorsRoot), nil
+		}
+		domain, err := hex.DecodeString(strings.TrimPrefix(viper.GetString("signature-domain"), "0x"))
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to parse domain")
+		}
+		if len(domain) != 32 {
+			return nil, errors.New("domain must be 32 bytes")
+		}
+		return domain, nil
+	}
+
+	network, err := util.NetworkConfigByName(viper.GetString("signature-network"))
+	if err != nil {
+		return nil, err
+	}
+	if viper.GetString("signature-domain-type") == "" {
+		return nil, errors.New("domain type is required to calculate the domain for a network")
 	}
+	data, err := hex.DecodeString(strings.TrimPrefix(viper.GetString("signature-domain-type"), "0x"))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse domain type")
+	}
+	if len(data) != 4 {
+		return nil, errors.New("domain type must be 4 bytes")
+	}
+	var domainType spec.DomainType
+	copy(domainType[:], data)
+	latestFork := network.Forks[len(network.Forks)-1]
+	domain, err := network.Domain(domainType, latestFork.Epoch)
+	if err != nil {
+		return nil, err
+	}
+	outputIf(debug, fmt.Sprintf("Domain calculated for fork version %#x of %s", latestFork.CurrentVersion, network.Name))
+
+	return domain[:], nil
 }
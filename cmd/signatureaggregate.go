@@ -50,6 +50,9 @@ In quiet mode this will return 0 if the signatures can be aggregated, otherwise
 		}
 		errCheck(err, "Failed to aggregate signature")
 
+		if !quiet && outputStructured(&signatureJSON{Signature: fmt.Sprintf("%#x", signature.Serialize())}) {
+			os.Exit(_exitSuccess)
+		}
 		outputIf(!quiet, fmt.Sprintf("%#x", signature.Serialize()))
 		os.Exit(_exitSuccess)
 	},
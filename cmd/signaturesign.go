@@ -23,7 +23,6 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 	"github.com/wealdtech/go-bytesutil"
-	e2types "github.com/wealdtech/go-eth2-types/v2"
 )
 
 // signatureSignCmd represents the signature sign command
@@ -44,12 +43,8 @@ In quiet mode this will return 0 if the data can be signed, otherwise 1.`,
 		errCheck(err, "Failed to parse data")
 		assert(len(data) == 32, "data to sign must be 32 bytes")
 
-		domain := e2types.Domain(e2types.DomainType([4]byte{0, 0, 0, 0}), e2types.ZeroForkVersion, e2types.ZeroGenesisValidatorsRoot)
-		if viper.GetString("signature-domain") != "" {
-			domain, err = bytesutil.FromHexString(viper.GetString("signature-domain"))
-			errCheck(err, "Failed to parse domain")
-			assert(len(domain) == 32, "Domain data invalid")
-		}
+		domain, err := signatureDomain()
+		errCheck(err, "Failed to obtain domain")
 		outputIf(debug, fmt.Sprintf("Domain is %#x", domain))
 
 		assert(viper.GetString("account") != "", "--account is required")
@@ -63,6 +58,9 @@ In quiet mode this will return 0 if the data can be signed, otherwise 1.`,
 		signature, err := util.SignRoot(account, fixedSizeData, specDomain)
 		errCheck(err, "Failed to sign")
 
+		if !quiet && outputStructured(&signatureJSON{Signature: fmt.Sprintf("%#x", signature.Marshal())}) {
+			os.Exit(_exitSuccess)
+		}
 		outputIf(!quiet, fmt.Sprintf("%#x", signature.Marshal()))
 		os.Exit(_exitSuccess)
 	},
@@ -0,0 +1,264 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"github.com/aaron-alderman/ethdo/util"
+	spec "github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/herumi/bls-eth-go-binary/bls"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/wealdtech/go-bytesutil"
+	e2wtypes "github.com/wealdtech/go-eth2-wallet-types/v2"
+)
+
+var signatureThresholdSignKeystores []string
+var signatureThresholdSignAccounts []string
+
+// signatureThresholdSignCmd represents the signature threshold-sign command
+var signatureThresholdSignCmd = &cobra.Command{
+	Use:   "threshold-sign",
+	Short: "Sign a 32-byte piece of data with shares of a distributed account",
+	Long: `Sign presented data with local shares of a distributed account, and combine the results in to a signature for the distributed account.  For example:
+
+    ethdo signature threshold-sign --data=0x5f24e819400c6a8ee2bfc014343cd971b7eb707320025a7bcd83e621e26c35b7 --keystore=shares/validator1-1.json --keystore=shares/validator1-3.json --passphrase="my share passphrase"
+
+Shares can be supplied as keystores generated by "account create --distributed-local" or as accounts in local distributed wallets.  Each partial signature is checked against the public key of its share, and the combined signature is checked against the composite public key of the distributed account.
+
+In quiet mode this will return 0 if the data can be signed and the signature verified, otherwise 1.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		assert(viper.GetString("signature-data") != "", "--data is required")
+		data, err := bytesutil.FromHexString(viper.GetString("signature-data"))
+		errCheck(err, "Failed to parse data")
+		assert(len(data) == 32, "data to sign must be 32 bytes")
+		assert(len(signatureThresholdSignKeystores)+len(signatureThresholdSignAccounts) > 0, "--keystore or --account-path is required")
+
+		domain, err := signatureDomain()
+		errCheck(err, "Failed to obtain domain")
+		outputIf(debug, fmt.Sprintf("Domain is %#x", domain))
+
+		container := &spec.SigningData{}
+		copy(container.ObjectRoot[:], data)
+		copy(container.Domain[:], domain)
+		signingRoot, err := container.HashTreeRoot()
+		errCheck(err, "Failed to generate signing root")
+		outputIf(debug, fmt.Sprintf("Signing root is %#x", signingRoot))
+
+		shares := make([]*signatureThresholdShare, 0, len(signatureThresholdSignKeystores)+len(signatureThresholdSignAccounts))
+		for _, path := range signatureThresholdSignKeystores {
+			share, err := signatureThresholdSignKeystore(path, container.ObjectRoot, container.Domain, signingRoot[:])
+			errCheck(err, fmt.Sprintf("Failed to sign with keystore %s", path))
+			shares = append(shares, share)
+		}
+		for _, path := range signatureThresholdSignAccounts {
+			share, err := signatureThresholdSignAccount(path, container.ObjectRoot, container.Domain)
+			errCheck(err, fmt.Sprintf("Failed to sign with account %s", path))
+			shares = append(shares, share)
+		}
+
+		partials := make([]*util.ThresholdPartialSignature, len(shares))
+		for i := range shares {
+			if !shares[i].compositePubKey.IsEqual(shares[0].compositePubKey) {
+				errCheck(util.NewCodedError(util.ErrorCodeInvalidInput, errors.New("shares are from different distributed accounts")), "")
+			}
+			if shares[i].signingThreshold != shares[0].signingThreshold {
+				errCheck(util.NewCodedError(util.ErrorCodeInvalidInput, errors.New("shares have different signing thresholds")), "")
+			}
+			partials[i] = shares[i].partial
+			outputIf(verbose, fmt.Sprintf("Share %d: %#x", shares[i].partial.ID, shares[i].partial.Signature.Serialize()))
+		}
+
+		assert(len(partials) >= int(shares[0].signingThreshold), fmt.Sprintf("%d shares supplied but %d required", len(partials), shares[0].signingThreshold))
+
+		signature, err := util.RecoverThresholdSignature(signingRoot[:], partials, shares[0].signingThreshold, shares[0].compositePubKey)
+		if err != nil {
+			errCheck(util.NewCodedError(util.ErrorCodeVerificationFailed, err), "Failed to generate threshold signature")
+		}
+
+		if !quiet && outputStructured(signatureThresholdSignStructured(signature, shares)) {
+			os.Exit(_exitSuccess)
+		}
+		outputIf(!quiet, fmt.Sprintf("%#x", signature.Serialize()))
+		os.Exit(_exitSuccess)
+	},
+}
+
+// signatureThresholdShare is the result of signing with a single share of a distributed account.
+type signatureThresholdShare struct {
+	partial          *util.ThresholdPartialSignature
+	compositePubKey  *bls.PublicKey
+	signingThreshold uint32
+}
+
+// signatureThresholdSignKeystore signs the signing root of the root and domain with the share held in a keystore.
+func signatureThresholdSignKeystore(path string, root spec.Root, domain spec.Domain, signingRoot []byte) (*signatureThresholdShare, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read keystore")
+	}
+	keystore := &util.ThresholdKeystore{}
+	if err := json.Unmarshal(data, keystore); err != nil {
+		return nil, errors.Wrap(err, "invalid keystore")
+	}
+	if err := keystore.Verify(); err != nil {
+		return nil, util.NewCodedError(util.ErrorCodeVerificationFailed, err)
+	}
+	compositePubKey, err := keystore.CompositePublicKey()
+	if err != nil {
+		return nil, err
+	}
+	pubKey, err := keystore.PublicKey()
+	if err != nil {
+		return nil, err
+	}
+
+	var key *bls.SecretKey
+	for _, passphrase := range util.GetPassphrases() {
+		key, err = keystore.SecretKey(passphrase)
+		if err == nil {
+			break
+		}
+	}
+	if key == nil {
+		return nil, errors.New("failed to decrypt keystore with supplied passphrases")
+	}
+	if !key.GetPublicKey().IsEqual(pubKey) {
+		return nil, util.NewCodedError(util.ErrorCodeVerificationFailed, errors.New("secret key does not match keystore public key"))
+	}
+
+	signature := key.SignByte(signingRoot)
+	if err := util.AuditSigning(pubKey.Serialize(), root[:], domain[:]); err != nil {
+		return nil, errors.Wrap(err, "failed to record signing in audit log")
+	}
+
+	return &signatureThresholdShare{
+		partial: &util.ThresholdPartialSignature{
+			ID:        keystore.ShareID,
+			PublicKey: pubKey,
+			Signature: signature,
+		},
+		compositePubKey:  compositePubKey,
+		signingThreshold: keystore.SigningThreshold,
+	}, nil
+}
+
+// signatureThresholdSignAccount signs the root with an account in a local distributed wallet.
+func signatureThresholdSignAccount(path string, root spec.Root, domain spec.Domain) (*signatureThresholdShare, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), viper.GetDuration("timeout"))
+	defer cancel()
+	_, account, err := util.WalletAndAccountFromPath(ctx, path)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to obtain account")
+	}
+	distributedAccount, isDistributed := account.(e2wtypes.DistributedAccount)
+	if !isDistributed {
+		return nil, errors.New("account is not a distributed account")
+	}
+	verificationVectorProvider, isProvider := account.(e2wtypes.AccountVerificationVectorProvider)
+	if !isProvider {
+		return nil, errors.New("account does not provide a verification vector")
+	}
+	pubKeyProvider, isProvider := account.(e2wtypes.AccountPublicKeyProvider)
+	if !isProvider {
+		return nil, errors.New("account does not provide a public key")
+	}
+
+	verificationVector := make([]bls.PublicKey, len(verificationVectorProvider.VerificationVector()))
+	for i, key := range verificationVectorProvider.VerificationVector() {
+		if err := verificationVector[i].Deserialize(key.Marshal()); err != nil {
+			return nil, errors.Wrap(err, "invalid verification vector")
+		}
+	}
+	if distributedAccount.SigningThreshold() == 0 || int(distributedAccount.SigningThreshold()) > len(verificationVector) {
+		return nil, util.NewCodedError(util.ErrorCodeVerificationFailed, fmt.Errorf("invalid signing threshold %d", distributedAccount.SigningThreshold()))
+	}
+	var compositePubKey bls.PublicKey
+	if err := compositePubKey.Deserialize(distributedAccount.CompositePublicKey().Marshal()); err != nil {
+		return nil, errors.Wrap(err, "invalid composite public key")
+	}
+	var pubKey bls.PublicKey
+	if err := pubKey.Deserialize(pubKeyProvider.PublicKey().Marshal()); err != nil {
+		return nil, errors.Wrap(err, "invalid public key")
+	}
+	ids := make([]uint64, 0, len(distributedAccount.Participants()))
+	for id := range distributedAccount.Participants() {
+		ids = append(ids, id)
+	}
+	id, err := util.ThresholdShareID(verificationVector, ids, &pubKey)
+	if err != nil {
+		return nil, util.NewCodedError(util.ErrorCodeVerificationFailed, err)
+	}
+
+	e2Signature, err := util.SignRoot(account, root, domain)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to sign")
+	}
+	var signature bls.Sign
+	if err := signature.Deserialize(e2Signature.Marshal()); err != nil {
+		return nil, errors.Wrap(err, "invalid signature")
+	}
+
+	return &signatureThresholdShare{
+		partial: &util.ThresholdPartialSignature{
+			ID:        id,
+			PublicKey: &pubKey,
+			Signature: &signature,
+		},
+		compositePubKey:  &compositePubKey,
+		signingThreshold: distributedAccount.SigningThreshold(),
+	}, nil
+}
+
+type signatureThresholdSignJSON struct {
+	Signature       string                         `json:"signature"`
+	CompositePubKey string                         `json:"composite_public_key"`
+	Shares          []*signatureThresholdShareJSON `json:"shares"`
+}
+
+type signatureThresholdShareJSON struct {
+	ID        uint64 `json:"id"`
+	PublicKey string `json:"public_key"`
+	Signature string `json:"signature"`
+}
+
+func signatureThresholdSignStructured(signature *bls.Sign, shares []*signatureThresholdShare) *signatureThresholdSignJSON {
+	res := &signatureThresholdSignJSON{
+		Signature:       fmt.Sprintf("%#x", signature.Serialize()),
+		CompositePubKey: fmt.Sprintf("%#x", shares[0].compositePubKey.Serialize()),
+		Shares:          make([]*signatureThresholdShareJSON, len(shares)),
+	}
+	for i := range shares {
+		res.Shares[i] = &signatureThresholdShareJSON{
+			ID:        shares[i].partial.ID,
+			PublicKey: fmt.Sprintf("%#x", shares[i].partial.PublicKey.Serialize()),
+			Signature: fmt.Sprintf("%#x", shares[i].partial.Signature.Serialize()),
+		}
+	}
+
+	return res
+}
+
+func init() {
+	signatureCmd.AddCommand(signatureThresholdSignCmd)
+	signatureFlags(signatureThresholdSignCmd)
+	signatureThresholdSignCmd.Flags().StringArrayVar(&signatureThresholdSignKeystores, "keystore", nil, "path to a keystore holding a share of the distributed account (supply once for each share)")
+	signatureThresholdSignCmd.Flags().StringArrayVar(&signatureThresholdSignAccounts, "account-path", nil, "account holding a share of the distributed account, in format \"wallet/account\" (supply once for each share)")
+}
@@ -54,12 +54,8 @@ In quiet mode this will return 0 if the data can be signed, otherwise 1.`,
 		signature, err := e2types.BLSSignatureFromBytes(signatureBytes)
 		errCheck(err, "Invalid signature")
 
-		domain := e2types.Domain(e2types.DomainType([4]byte{0, 0, 0, 0}), e2types.ZeroForkVersion, e2types.ZeroGenesisValidatorsRoot)
-		if viper.GetString("signature-domain") != "" {
-			domain, err = bytesutil.FromHexString(viper.GetString("signature-domain"))
-			errCheck(err, "Failed to parse domain")
-			assert(len(domain) == 32, "Domain data invalid")
-		}
+		domain, err := signatureDomain()
+		errCheck(err, "Failed to obtain domain")
 
 		account, err := signatureVerifyAccount()
 		errCheck(err, "Failed to obtain account")
@@ -71,8 +67,11 @@ In quiet mode this will return 0 if the data can be signed, otherwise 1.`,
 		copy(root[:], data)
 		verified, err := util.VerifyRoot(account, root, specDomain, signature)
 		errCheck(err, "Failed to verify data")
-		assert(verified, "Failed to verify")
+		assertVerified(verified, "Failed to verify")
 
+		if !quiet && outputStructured(&verifiedJSON{Verified: true}) {
+			os.Exit(_exitSuccess)
+		}
 		outputIf(verbose, "Verified")
 		os.Exit(_exitSuccess)
 	},
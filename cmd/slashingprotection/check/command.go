@@ -0,0 +1,102 @@
+// Copyright © 2022 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package slashingprotectioncheck
+
+import (
+	"context"
+	"time"
+
+	"github.com/aaron-alderman/ethdo/services/chaintime"
+	"github.com/aaron-alderman/ethdo/util"
+	eth2client "github.com/attestantio/go-eth2-client"
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+)
+
+type command struct {
+	quiet   bool
+	format  string
+	verbose bool
+	debug   bool
+
+	// Input.
+	file string
+	data string
+
+	// Beacon node connection.
+	timeout                  time.Duration
+	connection               string
+	allowInsecureConnections bool
+
+	// Data access.
+	consensusClient          eth2client.Service
+	chainTime                chaintime.Service
+	validatorsProvider       eth2client.ValidatorsProvider
+	beaconCommitteesProvider eth2client.BeaconCommitteesProvider
+	genesis                  *apiv1.Genesis
+	forkSchedule             []*phase0.Fork
+
+	// Processing.
+	committees map[phase0.Epoch][]*apiv1.BeaconCommittee
+
+	// Output.
+	items []*item
+}
+
+func newCommand(ctx context.Context) (*command, error) {
+	c := &command{
+		quiet:      viper.GetBool("quiet"),
+		format:     util.OutputFormat(),
+		verbose:    viper.GetBool("verbose"),
+		debug:      viper.GetBool("debug"),
+		committees: make(map[phase0.Epoch][]*apiv1.BeaconCommittee),
+	}
+
+	// Timeout.
+	if viper.GetDuration("timeout") == 0 {
+		return nil, errors.New("timeout is required")
+	}
+	c.timeout = viper.GetDuration("timeout")
+
+	if viper.GetString("connection") == "" {
+		return nil, errors.New("connection is required")
+	}
+	c.connection = viper.GetString("connection")
+	c.allowInsecureConnections = viper.GetBool("allow-insecure-connections")
+
+	c.file = viper.GetString("file")
+	if c.file == "" {
+		return nil, errors.New("file is required")
+	}
+
+	c.data = viper.GetString("data")
+	if c.data == "" {
+		return nil, errors.New("data is required")
+	}
+
+	return c, nil
+}
+
+// failures returns the number of items that are slashable or could not be checked.
+func (c *command) failures() int {
+	failures := 0
+	for _, item := range c.items {
+		if item.err != nil || len(item.conflicts) > 0 {
+			failures++
+		}
+	}
+	return failures
+}
@@ -0,0 +1,221 @@
+// Copyright © 2022 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package slashingprotectioncheck
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/aaron-alderman/ethdo/util"
+	"github.com/attestantio/go-eth2-client/spec/altair"
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	e2types "github.com/wealdtech/go-eth2-types/v2"
+)
+
+// item is a signed block or attestation to check against the interchange.
+type item struct {
+	source string
+
+	// block is set if the item is a signed block.
+	block *util.SlashingProtectionBlock
+	// attestation is set if the item is a signed attestation.
+	attestation *util.SlashingProtectionAttestation
+	// validators are the indices of the validators that signed the item.
+	validators []phase0.ValidatorIndex
+
+	conflicts []string
+	err       error
+}
+
+// parseItem parses a signed block or attestation, calculating its signing root.
+func (c *command) parseItem(ctx context.Context, source string, data []byte) *item {
+	res := &item{
+		source: source,
+	}
+
+	fields := make(map[string]json.RawMessage)
+	if err := json.Unmarshal(data, &fields); err != nil {
+		res.err = errors.Wrap(err, "invalid JSON")
+		return res
+	}
+
+	var err error
+	switch {
+	case fields["attesting_indices"] != nil:
+		err = c.parseIndexedAttestation(res, data)
+	case fields["aggregation_bits"] != nil:
+		err = c.parseAttestation(ctx, res, data)
+	case fields["message"] != nil:
+		err = c.parseBlock(res, fields["message"])
+	default:
+		err = errors.New("not a signed block or attestation")
+	}
+	if err != nil {
+		res.err = err
+	}
+
+	return res
+}
+
+func (c *command) parseIndexedAttestation(res *item, data []byte) error {
+	attestation := &phase0.IndexedAttestation{}
+	if err := json.Unmarshal(data, attestation); err != nil {
+		return errors.Wrap(err, "failed to parse indexed attestation")
+	}
+	res.validators = make([]phase0.ValidatorIndex, len(attestation.AttestingIndices))
+	for i := range attestation.AttestingIndices {
+		res.validators[i] = phase0.ValidatorIndex(attestation.AttestingIndices[i])
+	}
+
+	return c.setAttestation(res, attestation.Data)
+}
+
+func (c *command) parseAttestation(ctx context.Context, res *item, data []byte) error {
+	attestation := &phase0.Attestation{}
+	if err := json.Unmarshal(data, attestation); err != nil {
+		return errors.Wrap(err, "failed to parse attestation")
+	}
+
+	committee, err := c.committee(ctx, attestation.Data.Slot, attestation.Data.Index)
+	if err != nil {
+		return err
+	}
+	if attestation.AggregationBits.Len() != uint64(len(committee)) {
+		return fmt.Errorf("aggregation bits length %d does not match committee length %d", attestation.AggregationBits.Len(), len(committee))
+	}
+	res.validators = make([]phase0.ValidatorIndex, 0)
+	for i := range committee {
+		if attestation.AggregationBits.BitAt(uint64(i)) {
+			res.validators = append(res.validators, committee[i])
+		}
+	}
+
+	return c.setAttestation(res, attestation.Data)
+}
+
+func (c *command) setAttestation(res *item, data *phase0.AttestationData) error {
+	if data == nil || data.Source == nil || data.Target == nil {
+		return errors.New("attestation data missing")
+	}
+	root, err := data.HashTreeRoot()
+	if err != nil {
+		return errors.Wrap(err, "failed to generate root for attestation")
+	}
+	signingRoot, err := util.SigningRoot(root, phase0.DomainType(e2types.DomainBeaconAttester), util.ForkVersionAtEpoch(c.forkSchedule, data.Target.Epoch), c.genesis.GenesisValidatorsRoot)
+	if err != nil {
+		return err
+	}
+	res.attestation = &util.SlashingProtectionAttestation{
+		SourceEpoch: data.Source.Epoch,
+		TargetEpoch: data.Target.Epoch,
+		SigningRoot: &signingRoot,
+	}
+
+	return nil
+}
+
+// parseBlock parses a signed block or signed block header.
+func (c *command) parseBlock(res *item, message []byte) error {
+	fields := make(map[string]json.RawMessage)
+	if err := json.Unmarshal(message, &fields); err != nil {
+		return errors.Wrap(err, "invalid JSON")
+	}
+
+	var slot phase0.Slot
+	var proposerIndex phase0.ValidatorIndex
+	var root phase0.Root
+	var err error
+	if fields["body"] == nil {
+		header := &phase0.BeaconBlockHeader{}
+		if err := json.Unmarshal(message, header); err != nil {
+			return errors.Wrap(err, "failed to parse block header")
+		}
+		slot = header.Slot
+		proposerIndex = header.ProposerIndex
+		root, err = header.HashTreeRoot()
+	} else {
+		// Try each fork in turn, newest first, as later blocks contain more fields.
+		bellatrixBlock := &bellatrix.BeaconBlock{}
+		altairBlock := &altair.BeaconBlock{}
+		phase0Block := &phase0.BeaconBlock{}
+		switch {
+		case json.Unmarshal(message, bellatrixBlock) == nil:
+			slot = bellatrixBlock.Slot
+			proposerIndex = bellatrixBlock.ProposerIndex
+			root, err = bellatrixBlock.HashTreeRoot()
+		case json.Unmarshal(message, altairBlock) == nil:
+			slot = altairBlock.Slot
+			proposerIndex = altairBlock.ProposerIndex
+			root, err = altairBlock.HashTreeRoot()
+		case json.Unmarshal(message, phase0Block) == nil:
+			slot = phase0Block.Slot
+			proposerIndex = phase0Block.ProposerIndex
+			root, err = phase0Block.HashTreeRoot()
+		default:
+			return errors.New("failed to parse block")
+		}
+	}
+	if err != nil {
+		return errors.Wrap(err, "failed to generate root for block")
+	}
+
+	signingRoot, err := util.SigningRoot(root, phase0.DomainType(e2types.DomainBeaconProposer), util.ForkVersionAtEpoch(c.forkSchedule, c.chainTime.SlotToEpoch(slot)), c.genesis.GenesisValidatorsRoot)
+	if err != nil {
+		return err
+	}
+	res.block = &util.SlashingProtectionBlock{
+		Slot:        slot,
+		SigningRoot: &signingRoot,
+	}
+	res.validators = []phase0.ValidatorIndex{proposerIndex}
+
+	return nil
+}
+
+// committee returns the validators in the given beacon committee.
+func (c *command) committee(ctx context.Context, slot phase0.Slot, index phase0.CommitteeIndex) ([]phase0.ValidatorIndex, error) {
+	epoch := c.chainTime.SlotToEpoch(slot)
+	committees, exists := c.committees[epoch]
+	if !exists {
+		var err error
+		committees, err = c.beaconCommitteesProvider.BeaconCommittees(ctx, fmt.Sprintf("%d", c.chainTime.FirstSlotOfEpoch(epoch)))
+		if err != nil {
+			return nil, errors.Wrap(err, fmt.Sprintf("failed to obtain beacon committees for epoch %d", epoch))
+		}
+		c.committees[epoch] = committees
+	}
+
+	for _, committee := range committees {
+		if committee.Slot == slot && committee.Index == index {
+			return committee.Validators, nil
+		}
+	}
+
+	return nil, fmt.Errorf("no committee %d at slot %d", index, slot)
+}
+
+// summary provides a short description of the item.
+func (i *item) summary() string {
+	switch {
+	case i.block != nil:
+		return fmt.Sprintf("block at slot %d from validator %d", i.block.Slot, i.validators[0])
+	case i.attestation != nil:
+		return fmt.Sprintf("attestation %d->%d from %d validators", i.attestation.SourceEpoch, i.attestation.TargetEpoch, len(i.validators))
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,85 @@
+// Copyright © 2022 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package slashingprotectioncheck
+
+import (
+	"context"
+	"strings"
+
+	"github.com/aaron-alderman/ethdo/util"
+)
+
+type itemJSON struct {
+	Source    string   `json:"source"`
+	Summary   string   `json:"summary,omitempty"`
+	Status    string   `json:"status"`
+	Conflicts []string `json:"conflicts,omitempty"`
+	Error     string   `json:"error,omitempty"`
+}
+
+func (c *command) output(ctx context.Context) (string, error) {
+	if c.quiet {
+		return "", nil
+	}
+
+	if util.StructuredFormat(c.format) {
+		return c.outputStructured(ctx)
+	}
+
+	builder := strings.Builder{}
+	for _, item := range c.items {
+		builder.WriteString(item.source)
+		builder.WriteString(": ")
+		if summary := item.summary(); summary != "" {
+			builder.WriteString(summary)
+			builder.WriteString(": ")
+		}
+		switch {
+		case item.err != nil:
+			builder.WriteString("failed: ")
+			builder.WriteString(item.err.Error())
+		case len(item.conflicts) > 0:
+			builder.WriteString("slashable: ")
+			builder.WriteString(strings.Join(item.conflicts, "; "))
+		default:
+			builder.WriteString("ok")
+		}
+		builder.WriteString("\n")
+	}
+
+	return strings.TrimSuffix(builder.String(), "\n"), nil
+}
+
+func (c *command) outputStructured(_ context.Context) (string, error) {
+	items := make([]*itemJSON, 0, len(c.items))
+	for _, item := range c.items {
+		res := &itemJSON{
+			Source:    item.source,
+			Summary:   item.summary(),
+			Conflicts: item.conflicts,
+		}
+		switch {
+		case item.err != nil:
+			res.Status = "failed"
+			res.Error = item.err.Error()
+		case len(item.conflicts) > 0:
+			res.Status = "slashable"
+		default:
+			res.Status = "ok"
+		}
+		items = append(items, res)
+	}
+
+	return util.FormatOutput(c.format, items)
+}
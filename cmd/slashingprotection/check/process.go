@@ -0,0 +1,172 @@
+// Copyright © 2022 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package slashingprotectioncheck
+
+import (
+	"context"
+	"fmt"
+
+	standardchaintime "github.com/aaron-alderman/ethdo/services/chaintime/standard"
+	"github.com/aaron-alderman/ethdo/util"
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+)
+
+func (c *command) process(ctx context.Context) error {
+	interchange, err := util.ReadSlashingProtection(c.file)
+	if err != nil {
+		return err
+	}
+	// Merging combines any multiple entries for the same validator.
+	interchange, err = util.MergeSlashingProtection([]*util.SlashingProtection{interchange})
+	if err != nil {
+		return err
+	}
+	history := make(map[phase0.BLSPubKey]*util.SlashingProtectionValidator, len(interchange.Validators))
+	for _, validator := range interchange.Validators {
+		history[validator.PubKey] = validator
+	}
+
+	jsonItems, err := util.ReadJSONItems(c.data)
+	if err != nil {
+		return err
+	}
+	if len(jsonItems) == 0 {
+		return errors.New("no signed blocks or attestations found")
+	}
+
+	// Obtain information we need to process.
+	if err := c.setup(ctx); err != nil {
+		return err
+	}
+	if interchange.GenesisValidatorsRoot != c.genesis.GenesisValidatorsRoot {
+		return errors.New("interchange is for a different chain")
+	}
+
+	for _, jsonItem := range jsonItems {
+		if jsonItem.Err != nil {
+			c.items = append(c.items, &item{
+				source: jsonItem.Source,
+				err:    jsonItem.Err,
+			})
+			continue
+		}
+		c.items = append(c.items, c.parseItem(ctx, jsonItem.Source, jsonItem.Data))
+	}
+
+	// Only validators in the interchange have a signing history to check against.
+	pubKeys := make([]phase0.BLSPubKey, 0, len(history))
+	for pubKey := range history {
+		pubKeys = append(pubKeys, pubKey)
+	}
+	pubKeysByIndex := make(map[phase0.ValidatorIndex]phase0.BLSPubKey, len(pubKeys))
+	if len(pubKeys) > 0 {
+		validators, err := c.validatorsProvider.ValidatorsByPubKey(ctx, "head", pubKeys)
+		if err != nil {
+			return errors.Wrap(err, "failed to obtain validators")
+		}
+		for index, validator := range validators {
+			pubKeysByIndex[index] = validator.Validator.PublicKey
+		}
+	}
+
+	for _, item := range c.items {
+		if item.err != nil {
+			continue
+		}
+		for _, index := range item.validators {
+			pubKey, exists := pubKeysByIndex[index]
+			if !exists {
+				// Nothing in the interchange for this validator.
+				continue
+			}
+			for _, conflict := range conflicts(item, history[pubKey]) {
+				item.conflicts = append(item.conflicts, fmt.Sprintf("validator %d: %s", index, conflict))
+			}
+		}
+	}
+
+	return nil
+}
+
+// conflicts returns the conflicts between an item and a validator's signing history.
+func conflicts(item *item, history *util.SlashingProtectionValidator) []string {
+	res := make([]string, 0)
+	if item.block != nil {
+		for _, block := range history.SignedBlocks {
+			if err := item.block.Conflicts(block); err != nil {
+				res = append(res, err.Error())
+			}
+		}
+	}
+	if item.attestation != nil {
+		for _, attestation := range history.SignedAttestations {
+			if err := item.attestation.Conflicts(attestation); err != nil {
+				res = append(res, err.Error())
+			}
+		}
+	}
+
+	return res
+}
+
+func (c *command) setup(ctx context.Context) error {
+	var err error
+
+	// Connect to the consensus node.
+	c.consensusClient, err = util.ConnectToBeaconNode(ctx, c.connection, c.timeout, c.allowInsecureConnections)
+	if err != nil {
+		return errors.Wrap(err, "failed to connect to consensus node")
+	}
+
+	c.chainTime, err = standardchaintime.New(ctx,
+		standardchaintime.WithSpecProvider(c.consensusClient.(eth2client.SpecProvider)),
+		standardchaintime.WithForkScheduleProvider(c.consensusClient.(eth2client.ForkScheduleProvider)),
+		standardchaintime.WithGenesisTimeProvider(c.consensusClient.(eth2client.GenesisTimeProvider)),
+	)
+	if err != nil {
+		return errors.Wrap(err, "failed to set up chaintime service")
+	}
+
+	var isProvider bool
+	c.validatorsProvider, isProvider = c.consensusClient.(eth2client.ValidatorsProvider)
+	if !isProvider {
+		return errors.New("consensus node does not provide validator information")
+	}
+	c.beaconCommitteesProvider, isProvider = c.consensusClient.(eth2client.BeaconCommitteesProvider)
+	if !isProvider {
+		return errors.New("consensus node does not provide beacon committees")
+	}
+
+	genesisProvider, isProvider := c.consensusClient.(eth2client.GenesisProvider)
+	if !isProvider {
+		return errors.New("consensus node does not provide genesis information")
+	}
+	c.genesis, err = genesisProvider.Genesis(ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain genesis")
+	}
+
+	forkScheduleProvider, isProvider := c.consensusClient.(eth2client.ForkScheduleProvider)
+	if !isProvider {
+		return errors.New("consensus node does not provide fork schedule")
+	}
+	c.forkSchedule, err = forkScheduleProvider.ForkSchedule(ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain fork schedule")
+	}
+
+	return nil
+}
@@ -0,0 +1,110 @@
+// Copyright © 2022 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package slashingprotectioncheck
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/aaron-alderman/ethdo/testing/beaconnode"
+	"github.com/rs/zerolog"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProcess(t *testing.T) {
+	zerolog.SetGlobalLevel(zerolog.Disabled)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	beaconNode, err := beaconnode.New(ctx,
+		beaconnode.WithFixturesDir("../../../testing/beaconnode/testdata"),
+	)
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	interchangeFile := filepath.Join(dir, "interchange.json")
+	require.NoError(t, os.WriteFile(interchangeFile, []byte(`{"metadata":{"interchange_format_version":"5","genesis_validators_root":"0x4b363db94e286120d76eb905340fdd4e54bfe9f06bf33ff6cf5ad27f511bfe95"},"data":[{"pubkey":"0xa99a76ed7796f7be22d5b7e85deeb7c5677e88e511e0b337618f8c4eb61349b4bf2d153f649f7b53359fe8b94a38e44c","signed_blocks":[{"slot":"1","signing_root":"0x0101010101010101010101010101010101010101010101010101010101010101"}],"signed_attestations":[{"source_epoch":"0","target_epoch":"0","signing_root":"0x0101010101010101010101010101010101010101010101010101010101010101"},{"source_epoch":"0","target_epoch":"3"}]}]}`), 0600))
+	otherChainFile := filepath.Join(dir, "otherchain.json")
+	require.NoError(t, os.WriteFile(otherChainFile, []byte(`{"metadata":{"interchange_format_version":"5","genesis_validators_root":"0x0101010101010101010101010101010101010101010101010101010101010101"},"data":[]}`), 0600))
+
+	dataDir := filepath.Join(dir, "signed")
+	require.NoError(t, os.Mkdir(dataDir, 0700))
+	require.NoError(t, os.WriteFile(filepath.Join(dataDir, "blocks.json"), []byte(`[{"message":{"slot":"1","proposer_index":"0","parent_root":"0x0101010101010101010101010101010101010101010101010101010101010101","state_root":"0x0000000000000000000000000000000000000000000000000000000000000000","body_root":"0x0202020202020202020202020202020202020202020202020202020202020202"},"signature":"0xc00000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000"},{"message":{"slot":"3","proposer_index":"0","parent_root":"0x0101010101010101010101010101010101010101010101010101010101010101","state_root":"0x0000000000000000000000000000000000000000000000000000000000000000","body_root":"0x0202020202020202020202020202020202020202020202020202020202020202"},"signature":"0xc00000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000"}]`), 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(dataDir, "attestation.json"), []byte(`{"aggregation_bits":"0x03","data":{"slot":"0","index":"0","beacon_block_root":"0x0101010101010101010101010101010101010101010101010101010101010101","source":{"epoch":"0","root":"0x0000000000000000000000000000000000000000000000000000000000000000"},"target":{"epoch":"0","root":"0x0101010101010101010101010101010101010101010101010101010101010101"}},"signature":"0xc00000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000"}`), 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(dataDir, "indexed.json"), []byte(`[{"attesting_indices":["0"],"data":{"slot":"64","index":"0","beacon_block_root":"0x0101010101010101010101010101010101010101010101010101010101010101","source":{"epoch":"1","root":"0x0000000000000000000000000000000000000000000000000000000000000000"},"target":{"epoch":"2","root":"0x0101010101010101010101010101010101010101010101010101010101010101"}},"signature":"0xc00000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000"},{"attesting_indices":["1"],"data":{"slot":"64","index":"0","beacon_block_root":"0x0101010101010101010101010101010101010101010101010101010101010101","source":{"epoch":"1","root":"0x0000000000000000000000000000000000000000000000000000000000000000"},"target":{"epoch":"2","root":"0x0101010101010101010101010101010101010101010101010101010101010101"}},"signature":"0xc00000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000"}]`), 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(dataDir, "unknown.json"), []byte(`{"foo":"bar"}`), 0600))
+
+	tests := []struct {
+		name string
+		vars map[string]interface{}
+		res  string
+		err  string
+	}{
+		{
+			name: "OtherChain",
+			vars: map[string]interface{}{
+				"file": otherChainFile,
+				"data": dataDir,
+			},
+			err: "interchange is for a different chain",
+		},
+		{
+			name: "DataMissing",
+			vars: map[string]interface{}{
+				"file": interchangeFile,
+				"data": filepath.Join(dir, "missing"),
+			},
+			err: "failed to access data: stat " + filepath.Join(dir, "missing") + ": no such file or directory",
+		},
+		{
+			name: "Good",
+			vars: map[string]interface{}{
+				"file": interchangeFile,
+				"data": dataDir,
+			},
+			res: filepath.Join(dataDir, "attestation.json") + ": attestation 0->0 from 1 validators: slashable: validator 0: double vote for target epoch 0\n" +
+				filepath.Join(dataDir, "blocks.json") + "[0]: block at slot 1 from validator 0: slashable: validator 0: double proposal at slot 1\n" +
+				filepath.Join(dataDir, "blocks.json") + "[1]: block at slot 3 from validator 0: ok\n" +
+				filepath.Join(dataDir, "indexed.json") + "[0]: attestation 1->2 from 1 validators: slashable: validator 0: surround vote between 1->2 and 0->3\n" +
+				filepath.Join(dataDir, "indexed.json") + "[1]: attestation 1->2 from 1 validators: ok\n" +
+				filepath.Join(dataDir, "unknown.json") + ": failed: not a signed block or attestation",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			viper.Reset()
+			viper.Set("timeout", "5s")
+			viper.Set("connection", beaconNode.Address())
+			for k, v := range test.vars {
+				viper.Set(k, v)
+			}
+			cmd, err := newCommand(context.Background())
+			require.NoError(t, err)
+			err = cmd.process(context.Background())
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+				return
+			}
+			require.NoError(t, err)
+			res, err := cmd.output(context.Background())
+			require.NoError(t, err)
+			require.Equal(t, test.res, res)
+			require.Equal(t, 4, cmd.failures())
+		})
+	}
+}
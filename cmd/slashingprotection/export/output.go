@@ -0,0 +1,39 @@
+// Copyright © 2022 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package slashingprotectionexport
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/aaron-alderman/ethdo/util"
+	"github.com/pkg/errors"
+)
+
+func (c *command) output(ctx context.Context) (string, error) {
+	if c.quiet {
+		return "", nil
+	}
+
+	data, err := json.Marshal(c.interchange)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to generate JSON")
+	}
+
+	if util.StructuredFormat(c.format) {
+		return util.FormatJSONData(c.format, data)
+	}
+
+	return string(data), nil
+}
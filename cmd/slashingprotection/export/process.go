@@ -0,0 +1,282 @@
+// Copyright © 2022 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package slashingprotectionexport
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+
+	standardchaintime "github.com/aaron-alderman/ethdo/services/chaintime/standard"
+	"github.com/aaron-alderman/ethdo/util"
+	eth2client "github.com/attestantio/go-eth2-client"
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	e2types "github.com/wealdtech/go-eth2-types/v2"
+)
+
+func (c *command) process(ctx context.Context) error {
+	// Obtain information we need to process.
+	if err := c.setup(ctx); err != nil {
+		return err
+	}
+
+	to, err := util.ParseEpoch(ctx, c.chainTime, c.toEpoch)
+	if err != nil {
+		return errors.Wrap(err, "failed to parse to epoch")
+	}
+	from, err := util.ParseEpoch(ctx, c.chainTime, c.fromEpoch)
+	if err != nil {
+		return errors.Wrap(err, "failed to parse from epoch")
+	}
+	if from > to {
+		return errors.New("from epoch cannot be after to epoch")
+	}
+	if to > c.chainTime.CurrentEpoch() {
+		return errors.New("to epoch cannot be in the future")
+	}
+
+	validators, err := util.ParseValidators(ctx, c.validatorsProvider, c.validators, c.accounts)
+	if err != nil {
+		return err
+	}
+	history := make(map[phase0.ValidatorIndex]*util.SlashingProtectionValidator, len(validators))
+	indices := make([]phase0.ValidatorIndex, 0, len(validators))
+	for index, validator := range validators {
+		history[index] = &util.SlashingProtectionValidator{
+			PubKey:             validator.Validator.PublicKey,
+			SignedBlocks:       make([]*util.SlashingProtectionBlock, 0),
+			SignedAttestations: make([]*util.SlashingProtectionAttestation, 0),
+		}
+		indices = append(indices, index)
+	}
+	sort.Slice(indices, func(i int, j int) bool {
+		return indices[i] < indices[j]
+	})
+
+	for epoch := from; epoch <= to; epoch++ {
+		if c.debug {
+			fmt.Fprintf(os.Stderr, "Processing epoch %d\n", epoch)
+		}
+		if err := c.processProposerDuties(ctx, epoch, indices, history); err != nil {
+			return err
+		}
+		if err := c.processAttesterDuties(ctx, epoch, indices, history); err != nil {
+			return err
+		}
+	}
+
+	c.interchange = &util.SlashingProtection{
+		GenesisValidatorsRoot: c.genesisValidatorsRoot,
+		Validators:            make([]*util.SlashingProtectionValidator, 0, len(indices)),
+	}
+	for _, index := range indices {
+		c.interchange.Validators = append(c.interchange.Validators, history[index])
+	}
+
+	return nil
+}
+
+// processProposerDuties adds the blocks proposed by the validators in the given epoch.
+func (c *command) processProposerDuties(ctx context.Context,
+	epoch phase0.Epoch,
+	indices []phase0.ValidatorIndex,
+	history map[phase0.ValidatorIndex]*util.SlashingProtectionValidator,
+) error {
+	duties, err := c.proposerDutiesProvider.ProposerDuties(ctx, epoch, indices)
+	if err != nil {
+		return errors.Wrap(err, fmt.Sprintf("failed to obtain proposer duties for epoch %d", epoch))
+	}
+
+	for _, duty := range duties {
+		validatorHistory, exists := history[duty.ValidatorIndex]
+		if !exists {
+			// Beacon nodes can return duties for all validators, so ignore those we do not want.
+			continue
+		}
+		block, err := util.BlockAtSlot(ctx, c.blocksProvider, c.blocks, duty.Slot)
+		if err != nil {
+			return err
+		}
+		if block == nil {
+			continue
+		}
+		proposerIndex, err := util.BlockProposerIndex(block)
+		if err != nil {
+			return err
+		}
+		if proposerIndex != duty.ValidatorIndex {
+			continue
+		}
+		root, err := block.Root()
+		if err != nil {
+			return errors.Wrap(err, "failed to obtain block root")
+		}
+		signingRoot, err := util.SigningRoot(root, phase0.DomainType(e2types.DomainBeaconProposer), util.ForkVersionAtEpoch(c.forkSchedule, epoch), c.genesisValidatorsRoot)
+		if err != nil {
+			return err
+		}
+		validatorHistory.SignedBlocks = append(validatorHistory.SignedBlocks, &util.SlashingProtectionBlock{
+			Slot:        duty.Slot,
+			SigningRoot: &signingRoot,
+		})
+	}
+
+	return nil
+}
+
+// processAttesterDuties adds the attestations made by the validators in the given epoch.
+// Only attestations included on chain are known, so attestations that were signed but
+// not included are absent.
+func (c *command) processAttesterDuties(ctx context.Context,
+	epoch phase0.Epoch,
+	indices []phase0.ValidatorIndex,
+	history map[phase0.ValidatorIndex]*util.SlashingProtectionValidator,
+) error {
+	duties, err := c.attesterDutiesProvider.AttesterDuties(ctx, epoch, indices)
+	if err != nil {
+		return errors.Wrap(err, fmt.Sprintf("failed to obtain attester duties for epoch %d", epoch))
+	}
+
+	for _, duty := range duties {
+		validatorHistory, exists := history[duty.ValidatorIndex]
+		if !exists {
+			continue
+		}
+		data, err := c.includedAttestationData(ctx, duty)
+		if err != nil {
+			return err
+		}
+		if data == nil {
+			continue
+		}
+		root, err := data.HashTreeRoot()
+		if err != nil {
+			return errors.Wrap(err, "failed to generate root for attestation")
+		}
+		signingRoot, err := util.SigningRoot(root, phase0.DomainType(e2types.DomainBeaconAttester), util.ForkVersionAtEpoch(c.forkSchedule, data.Target.Epoch), c.genesisValidatorsRoot)
+		if err != nil {
+			return err
+		}
+		validatorHistory.SignedAttestations = append(validatorHistory.SignedAttestations, &util.SlashingProtectionAttestation{
+			SourceEpoch: data.Source.Epoch,
+			TargetEpoch: data.Target.Epoch,
+			SigningRoot: &signingRoot,
+		})
+	}
+
+	return nil
+}
+
+// includedAttestationData finds the data of the first inclusion of the attestation for the
+// given duty, or nil if it was not included.
+func (c *command) includedAttestationData(ctx context.Context,
+	duty *apiv1.AttesterDuty,
+) (
+	*phase0.AttestationData,
+	error,
+) {
+	lastSlot := duty.Slot + phase0.Slot(c.chainTime.SlotsPerEpoch())
+	if lastSlot > c.chainTime.CurrentSlot() {
+		lastSlot = c.chainTime.CurrentSlot()
+	}
+	for slot := duty.Slot + 1; slot <= lastSlot; slot++ {
+		block, err := util.BlockAtSlot(ctx, c.blocksProvider, c.blocks, slot)
+		if err != nil {
+			return nil, err
+		}
+		if block == nil {
+			continue
+		}
+		attestations, err := block.Attestations()
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to obtain block attestations")
+		}
+		for _, attestation := range attestations {
+			if attestation.Data.Slot == duty.Slot &&
+				attestation.Data.Index == duty.CommitteeIndex &&
+				attestation.AggregationBits.BitAt(duty.ValidatorCommitteeIndex) {
+				if c.debug {
+					fmt.Fprintf(os.Stderr, "Attestation for validator %d at slot %d included in slot %d\n", duty.ValidatorIndex, duty.Slot, slot)
+				}
+				return attestation.Data, nil
+			}
+		}
+	}
+
+	if c.debug {
+		fmt.Fprintf(os.Stderr, "Attestation for validator %d at slot %d not included\n", duty.ValidatorIndex, duty.Slot)
+	}
+	return nil, nil
+}
+
+func (c *command) setup(ctx context.Context) error {
+	var err error
+
+	// Connect to the consensus node.
+	c.consensusClient, err = util.ConnectToBeaconNode(ctx, c.connection, c.timeout, c.allowInsecureConnections)
+	if err != nil {
+		return errors.Wrap(err, "failed to connect to consensus node")
+	}
+
+	c.chainTime, err = standardchaintime.New(ctx,
+		standardchaintime.WithSpecProvider(c.consensusClient.(eth2client.SpecProvider)),
+		standardchaintime.WithForkScheduleProvider(c.consensusClient.(eth2client.ForkScheduleProvider)),
+		standardchaintime.WithGenesisTimeProvider(c.consensusClient.(eth2client.GenesisTimeProvider)),
+	)
+	if err != nil {
+		return errors.Wrap(err, "failed to set up chaintime service")
+	}
+
+	var isProvider bool
+	c.validatorsProvider, isProvider = c.consensusClient.(eth2client.ValidatorsProvider)
+	if !isProvider {
+		return errors.New("consensus node does not provide validator information")
+	}
+	c.attesterDutiesProvider, isProvider = c.consensusClient.(eth2client.AttesterDutiesProvider)
+	if !isProvider {
+		return errors.New("consensus node does not provide attester duties")
+	}
+	c.proposerDutiesProvider, isProvider = c.consensusClient.(eth2client.ProposerDutiesProvider)
+	if !isProvider {
+		return errors.New("consensus node does not provide proposer duties")
+	}
+	c.blocksProvider, isProvider = c.consensusClient.(eth2client.SignedBeaconBlockProvider)
+	if !isProvider {
+		return errors.New("consensus node does not provide signed beacon blocks")
+	}
+
+	genesisProvider, isProvider := c.consensusClient.(eth2client.GenesisProvider)
+	if !isProvider {
+		return errors.New("consensus node does not provide genesis information")
+	}
+	genesis, err := genesisProvider.Genesis(ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain genesis")
+	}
+	c.genesisValidatorsRoot = genesis.GenesisValidatorsRoot
+
+	forkScheduleProvider, isProvider := c.consensusClient.(eth2client.ForkScheduleProvider)
+	if !isProvider {
+		return errors.New("consensus node does not provide fork schedule")
+	}
+	c.forkSchedule, err = forkScheduleProvider.ForkSchedule(ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain fork schedule")
+	}
+
+	return nil
+}
@@ -0,0 +1,106 @@
+// Copyright © 2022 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package slashingprotectionexport
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/aaron-alderman/ethdo/testing/beaconnode"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/rs/zerolog"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProcess(t *testing.T) {
+	zerolog.SetGlobalLevel(zerolog.Disabled)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	beaconNode, err := beaconnode.New(ctx,
+		beaconnode.WithFixturesDir("../../../testing/beaconnode/testdata"),
+	)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name string
+		vars map[string]interface{}
+		err  string
+	}{
+		{
+			name: "InvalidValidator",
+			vars: map[string]interface{}{
+				"validators": []string{"invalid"},
+				"to-epoch":   "0",
+			},
+			err: "invalid validator index invalid: strconv.ParseUint: parsing \"invalid\": invalid syntax",
+		},
+		{
+			name: "EpochsReversed",
+			vars: map[string]interface{}{
+				"validators": []string{"0"},
+				"from-epoch": "1",
+				"to-epoch":   "0",
+			},
+			err: "from epoch cannot be after to epoch",
+		},
+		{
+			name: "Good",
+			vars: map[string]interface{}{
+				"validators": []string{"0", "1"},
+				"to-epoch":   "0",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			viper.Reset()
+			viper.Set("timeout", "5s")
+			viper.Set("connection", beaconNode.Address())
+			for k, v := range test.vars {
+				viper.Set(k, v)
+			}
+			cmd, err := newCommand(context.Background())
+			require.NoError(t, err)
+			err = cmd.process(context.Background())
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+				return
+			}
+			require.NoError(t, err)
+			require.NoError(t, cmd.interchange.Validate())
+			require.Len(t, cmd.interchange.Validators, 2)
+
+			// Validator 0 proposed the block at slot 1, which included its attestation for slot 0.
+			history := cmd.interchange.Validators[0]
+			require.Equal(t, "0xa99a76ed7796f7be22d5b7e85deeb7c5677e88e511e0b337618f8c4eb61349b4bf2d153f649f7b53359fe8b94a38e44c", fmt.Sprintf("%#x", history.PubKey))
+			require.Len(t, history.SignedBlocks, 1)
+			require.Equal(t, phase0.Slot(1), history.SignedBlocks[0].Slot)
+			require.NotNil(t, history.SignedBlocks[0].SigningRoot)
+			require.Len(t, history.SignedAttestations, 1)
+			require.Equal(t, phase0.Epoch(0), history.SignedAttestations[0].SourceEpoch)
+			require.Equal(t, phase0.Epoch(0), history.SignedAttestations[0].TargetEpoch)
+			require.NotNil(t, history.SignedAttestations[0].SigningRoot)
+
+			// Validator 1 has nothing on chain.
+			history = cmd.interchange.Validators[1]
+			require.Equal(t, "0xb89bebc699769726a318c8e9971bd3171297c61aea4a6578a7a4f94b547dcba5bac16a89108b6b6a1fe3695d1a874a0b", fmt.Sprintf("%#x", history.PubKey))
+			require.Empty(t, history.SignedBlocks)
+			require.Empty(t, history.SignedAttestations)
+		})
+	}
+}
@@ -0,0 +1,46 @@
+// Copyright © 2022 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package slashingprotectionmerge
+
+import (
+	"context"
+
+	"github.com/aaron-alderman/ethdo/util"
+	"github.com/pkg/errors"
+)
+
+func (c *command) process(ctx context.Context) error {
+	interchanges := make([]*util.SlashingProtection, len(c.files))
+	for i, file := range c.files {
+		var err error
+		interchanges[i], err = util.ReadSlashingProtection(file)
+		if err != nil {
+			return err
+		}
+	}
+
+	var err error
+	c.interchange, err = util.MergeSlashingProtection(interchanges)
+	if err != nil {
+		return err
+	}
+
+	// Merging cannot make a set of valid interchanges invalid, but it can combine
+	// histories for the same validator that conflict with each other.
+	if err := c.interchange.Validate(); err != nil {
+		return errors.Wrap(err, "merged interchange is not valid")
+	}
+
+	return nil
+}
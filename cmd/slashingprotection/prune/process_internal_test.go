@@ -0,0 +1,97 @@
+// Copyright © 2022 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package slashingprotectionprune
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/aaron-alderman/ethdo/testing/beaconnode"
+	"github.com/rs/zerolog"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProcess(t *testing.T) {
+	zerolog.SetGlobalLevel(zerolog.Disabled)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	beaconNode, err := beaconnode.New(ctx,
+		beaconnode.WithFixturesDir("../../../testing/beaconnode/testdata"),
+	)
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	file := filepath.Join(dir, "interchange.json")
+	require.NoError(t, os.WriteFile(file, []byte(`{"metadata":{"interchange_format_version":"5","genesis_validators_root":"0x4b363db94e286120d76eb905340fdd4e54bfe9f06bf33ff6cf5ad27f511bfe95"},"data":[{"pubkey":"0xa99a76ed7796f7be22d5b7e85deeb7c5677e88e511e0b337618f8c4eb61349b4bf2d153f649f7b53359fe8b94a38e44c","signed_blocks":[{"slot":"10"},{"slot":"40"}],"signed_attestations":[{"source_epoch":"0","target_epoch":"0"},{"source_epoch":"0","target_epoch":"1"},{"source_epoch":"1","target_epoch":"2"}]}]}`), 0600))
+	otherChainFile := filepath.Join(dir, "otherchain.json")
+	require.NoError(t, os.WriteFile(otherChainFile, []byte(`{"metadata":{"interchange_format_version":"5","genesis_validators_root":"0x0101010101010101010101010101010101010101010101010101010101010101"},"data":[]}`), 0600))
+
+	tests := []struct {
+		name string
+		vars map[string]interface{}
+		res  string
+		err  string
+	}{
+		{
+			name: "OtherChain",
+			vars: map[string]interface{}{
+				"file":  otherChainFile,
+				"epoch": "1",
+			},
+			err: "interchange is for a different chain",
+		},
+		{
+			name: "InvalidEpoch",
+			vars: map[string]interface{}{
+				"file":  file,
+				"epoch": "invalid",
+			},
+			err: "failed to parse epoch: failed to parse epoch: strconv.ParseInt: parsing \"invalid\": invalid syntax",
+		},
+		{
+			name: "Good",
+			vars: map[string]interface{}{
+				"file":  file,
+				"epoch": "1",
+			},
+			res: `{"metadata":{"interchange_format_version":"5","genesis_validators_root":"0x4b363db94e286120d76eb905340fdd4e54bfe9f06bf33ff6cf5ad27f511bfe95"},"data":[{"pubkey":"0xa99a76ed7796f7be22d5b7e85deeb7c5677e88e511e0b337618f8c4eb61349b4bf2d153f649f7b53359fe8b94a38e44c","signed_blocks":[{"slot":"40"}],"signed_attestations":[{"source_epoch":"0","target_epoch":"1"},{"source_epoch":"1","target_epoch":"2"}]}]}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			viper.Reset()
+			viper.Set("timeout", "5s")
+			viper.Set("connection", beaconNode.Address())
+			for k, v := range test.vars {
+				viper.Set(k, v)
+			}
+			cmd, err := newCommand(context.Background())
+			require.NoError(t, err)
+			err = cmd.process(context.Background())
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+				return
+			}
+			require.NoError(t, err)
+			res, err := cmd.output(context.Background())
+			require.NoError(t, err)
+			require.Equal(t, test.res, res)
+		})
+	}
+}
@@ -0,0 +1,52 @@
+// Copyright © 2022 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package slashingprotectionvalidate
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aaron-alderman/ethdo/util"
+)
+
+type validateJSON struct {
+	Valid        bool `json:"valid"`
+	Validators   int  `json:"validators"`
+	Blocks       int  `json:"blocks"`
+	Attestations int  `json:"attestations"`
+}
+
+func (c *command) output(ctx context.Context) (string, error) {
+	if c.quiet {
+		return "", nil
+	}
+
+	blocks := 0
+	attestations := 0
+	for _, validator := range c.interchange.Validators {
+		blocks += len(validator.SignedBlocks)
+		attestations += len(validator.SignedAttestations)
+	}
+
+	if util.StructuredFormat(c.format) {
+		return util.FormatOutput(c.format, &validateJSON{
+			Valid:        true,
+			Validators:   len(c.interchange.Validators),
+			Blocks:       blocks,
+			Attestations: attestations,
+		})
+	}
+
+	return fmt.Sprintf("Interchange is valid (%d validator entries, %d blocks, %d attestations)", len(c.interchange.Validators), blocks, attestations), nil
+}
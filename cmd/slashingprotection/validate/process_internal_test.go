@@ -0,0 +1,83 @@
+// Copyright © 2022 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package slashingprotectionvalidate
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestProcess(t *testing.T) {
+	dir := t.TempDir()
+	goodFile := filepath.Join(dir, "good.json")
+	require.NoError(t, os.WriteFile(goodFile, []byte(`{"metadata":{"interchange_format_version":"5","genesis_validators_root":"0x4b363db94e286120d76eb905340fdd4e54bfe9f06bf33ff6cf5ad27f511bfe95"},"data":[{"pubkey":"0xa99a76ed7796f7be22d5b7e85deeb7c5677e88e511e0b337618f8c4eb61349b4bf2d153f649f7b53359fe8b94a38e44c","signed_blocks":[{"slot":"1"}],"signed_attestations":[{"source_epoch":"0","target_epoch":"1"},{"source_epoch":"1","target_epoch":"2"}]}]}`), 0600))
+	badFile := filepath.Join(dir, "bad.json")
+	require.NoError(t, os.WriteFile(badFile, []byte(`{"metadata":{"interchange_format_version":"5","genesis_validators_root":"0x4b363db94e286120d76eb905340fdd4e54bfe9f06bf33ff6cf5ad27f511bfe95"},"data":[{"pubkey":"0xa99a76ed7796f7be22d5b7e85deeb7c5677e88e511e0b337618f8c4eb61349b4bf2d153f649f7b53359fe8b94a38e44c","signed_blocks":[],"signed_attestations":[{"source_epoch":"1","target_epoch":"2"},{"source_epoch":"0","target_epoch":"3"}]}]}`), 0600))
+	invalidFile := filepath.Join(dir, "invalid.json")
+	require.NoError(t, os.WriteFile(invalidFile, []byte(`{"metadata":{}}`), 0600))
+
+	tests := []struct {
+		name string
+		cmd  *command
+		res  string
+		err  string
+	}{
+		{
+			name: "Missing",
+			cmd: &command{
+				file: filepath.Join(dir, "missing.json"),
+			},
+			err: "failed to read interchange file: open " + filepath.Join(dir, "missing.json") + ": no such file or directory",
+		},
+		{
+			name: "Invalid",
+			cmd: &command{
+				file: invalidFile,
+			},
+			err: "failed to parse interchange file " + invalidFile + `: unsupported interchange format version ""`,
+		},
+		{
+			name: "Surround",
+			cmd: &command{
+				file: badFile,
+			},
+			err: "interchange is not valid: validator 0xa99a76ed7796f7be22d5b7e85deeb7c5677e88e511e0b337618f8c4eb61349b4bf2d153f649f7b53359fe8b94a38e44c: surround vote between 1->2 and 0->3",
+		},
+		{
+			name: "Good",
+			cmd: &command{
+				file: goodFile,
+			},
+			res: "Interchange is valid (1 validator entries, 1 blocks, 2 attestations)",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.cmd.process(context.Background())
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+				return
+			}
+			require.NoError(t, err)
+			res, err := test.cmd.output(context.Background())
+			require.NoError(t, err)
+			require.Equal(t, test.res, res)
+		})
+	}
+}
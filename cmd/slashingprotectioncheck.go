@@ -0,0 +1,57 @@
+// Copyright © 2022 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	slashingprotectioncheck "github.com/aaron-alderman/ethdo/cmd/slashingprotection/check"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var slashingProtectionCheckCmd = &cobra.Command{
+	Use:   "check",
+	Short: "Check signed blocks and attestations against a slashing protection interchange file",
+	Long: `Check signed blocks and attestations against a slashing protection interchange file for double proposals, double votes and surround votes.  For example:
+
+    ethdo slashingprotection check --file=interchange.json --data=signed/
+
+--data can be a single file or a directory, in which case all files with a .json suffix are read.  Each file can contain a single item or an array of items.  Items can be signed blocks, signed block headers, attestations or indexed attestations.
+
+In quiet mode this will return 0 if no items are slashable, otherwise 1.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		res, err := slashingprotectioncheck.Run(cmd)
+		if res != "" && !viper.GetBool("quiet") {
+			fmt.Println(res)
+		}
+		return err
+	},
+}
+
+func init() {
+	slashingProtectionCmd.AddCommand(slashingProtectionCheckCmd)
+	slashingProtectionFlags(slashingProtectionCheckCmd)
+	slashingProtectionCheckCmd.Flags().String("file", "", "Interchange file against which to check")
+	slashingProtectionCheckCmd.Flags().String("data", "", "File or directory containing signed blocks and attestations")
+}
+
+func slashingProtectionCheckBindings() {
+	if err := viper.BindPFlag("file", slashingProtectionCheckCmd.Flags().Lookup("file")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("data", slashingProtectionCheckCmd.Flags().Lookup("data")); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,73 @@
+// Copyright © 2022 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	slashingprotectionexport "github.com/aaron-alderman/ethdo/cmd/slashingprotection/export"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var slashingProtectionExportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export a slashing protection interchange from the chain",
+	Long: `Export a slashing protection interchange for validators from the blocks and attestations on chain, output as JSON.  For example:
+
+    ethdo slashingprotection export --validators=1,2,3 --from-epoch=100 --to-epoch=110
+
+Validators can be supplied as indices or public keys with --validators, or as a wallet or wallet/account path with --accounts.  The epochs default to the last complete epoch.
+
+Only blocks and attestations that were included on chain are known, so the interchange should be used alongside, rather than instead of, the slashing protection of the validator client.
+
+In quiet mode this will return 0 if the interchange is exported, otherwise 1.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		res, err := slashingprotectionexport.Run(cmd)
+		if err != nil {
+			return err
+		}
+		if viper.GetBool("quiet") {
+			return nil
+		}
+		if res != "" {
+			fmt.Println(res)
+		}
+		return nil
+	},
+}
+
+func init() {
+	slashingProtectionCmd.AddCommand(slashingProtectionExportCmd)
+	slashingProtectionFlags(slashingProtectionExportCmd)
+	slashingProtectionExportCmd.Flags().StringSlice("validators", nil, "indices or public keys of the validators")
+	slashingProtectionExportCmd.Flags().String("accounts", "", "wallet or wallet/account path of the validators")
+	slashingProtectionExportCmd.Flags().String("from-epoch", "", "the first epoch for which to export history (defaults to to-epoch)")
+	slashingProtectionExportCmd.Flags().String("to-epoch", "", "the last epoch for which to export history (defaults to the last complete epoch)")
+}
+
+func slashingProtectionExportBindings() {
+	if err := viper.BindPFlag("validators", slashingProtectionExportCmd.Flags().Lookup("validators")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("accounts", slashingProtectionExportCmd.Flags().Lookup("accounts")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("from-epoch", slashingProtectionExportCmd.Flags().Lookup("from-epoch")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("to-epoch", slashingProtectionExportCmd.Flags().Lookup("to-epoch")); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,59 @@
+// Copyright © 2022 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	slashingprotectionmerge "github.com/aaron-alderman/ethdo/cmd/slashingprotection/merge"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var slashingProtectionMergeCmd = &cobra.Command{
+	Use:   "merge",
+	Short: "Merge slashing protection interchange files",
+	Long: `Merge multiple slashing protection interchange files in to a single interchange, output as JSON.  For example:
+
+    ethdo slashingprotection merge --files=interchange1.json,interchange2.json
+
+All files must be for the same chain.  Duplicate blocks and attestations are removed, and the merged interchange is validated.
+
+In quiet mode this will return 0 if the interchanges are merged, otherwise 1.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		res, err := slashingprotectionmerge.Run(cmd)
+		if err != nil {
+			return err
+		}
+		if viper.GetBool("quiet") {
+			return nil
+		}
+		if res != "" {
+			fmt.Println(res)
+		}
+		return nil
+	},
+}
+
+func init() {
+	slashingProtectionCmd.AddCommand(slashingProtectionMergeCmd)
+	slashingProtectionFlags(slashingProtectionMergeCmd)
+	slashingProtectionMergeCmd.Flags().StringSlice("files", nil, "Interchange files to merge")
+}
+
+func slashingProtectionMergeBindings() {
+	if err := viper.BindPFlag("files", slashingProtectionMergeCmd.Flags().Lookup("files")); err != nil {
+		panic(err)
+	}
+}
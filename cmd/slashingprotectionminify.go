@@ -0,0 +1,59 @@
+// Copyright © 2022 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	slashingprotectionminify "github.com/aaron-alderman/ethdo/cmd/slashingprotection/minify"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var slashingProtectionMinifyCmd = &cobra.Command{
+	Use:   "minify",
+	Short: "Minify a slashing protection interchange file",
+	Long: `Minify a slashing protection interchange file, output as JSON.  For example:
+
+    ethdo slashingprotection minify --file=interchange.json
+
+The minified interchange contains, for each validator, only its highest block slot and highest attestation source and target epochs.  This provides the same protection when imported, at the cost of losing the signing history.
+
+In quiet mode this will return 0 if the interchange is minified, otherwise 1.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		res, err := slashingprotectionminify.Run(cmd)
+		if err != nil {
+			return err
+		}
+		if viper.GetBool("quiet") {
+			return nil
+		}
+		if res != "" {
+			fmt.Println(res)
+		}
+		return nil
+	},
+}
+
+func init() {
+	slashingProtectionCmd.AddCommand(slashingProtectionMinifyCmd)
+	slashingProtectionFlags(slashingProtectionMinifyCmd)
+	slashingProtectionMinifyCmd.Flags().String("file", "", "Interchange file to minify")
+}
+
+func slashingProtectionMinifyBindings() {
+	if err := viper.BindPFlag("file", slashingProtectionMinifyCmd.Flags().Lookup("file")); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,63 @@
+// Copyright © 2022 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	slashingprotectionprune "github.com/aaron-alderman/ethdo/cmd/slashingprotection/prune"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var slashingProtectionPruneCmd = &cobra.Command{
+	Use:   "prune",
+	Short: "Prune a slashing protection interchange file",
+	Long: `Prune a slashing protection interchange file of blocks and attestations before an epoch, output as JSON.  For example:
+
+    ethdo slashingprotection prune --file=interchange.json --epoch=1000
+
+The latest block of each validator, and its attestations with the highest source and target epochs, are always retained, so that an importer continues to refuse to sign anything earlier.
+
+In quiet mode this will return 0 if the interchange is pruned, otherwise 1.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		res, err := slashingprotectionprune.Run(cmd)
+		if err != nil {
+			return err
+		}
+		if viper.GetBool("quiet") {
+			return nil
+		}
+		if res != "" {
+			fmt.Println(res)
+		}
+		return nil
+	},
+}
+
+func init() {
+	slashingProtectionCmd.AddCommand(slashingProtectionPruneCmd)
+	slashingProtectionFlags(slashingProtectionPruneCmd)
+	slashingProtectionPruneCmd.Flags().String("file", "", "Interchange file to prune")
+	slashingProtectionPruneCmd.Flags().String("epoch", "", "Epoch before which to prune blocks and attestations")
+}
+
+func slashingProtectionPruneBindings() {
+	if err := viper.BindPFlag("file", slashingProtectionPruneCmd.Flags().Lookup("file")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("epoch", slashingProtectionPruneCmd.Flags().Lookup("epoch")); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,57 @@
+// Copyright © 2022 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	slashingprotectionvalidate "github.com/aaron-alderman/ethdo/cmd/slashingprotection/validate"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var slashingProtectionValidateCmd = &cobra.Command{
+	Use:   "validate",
+	Short: "Validate a slashing protection interchange file",
+	Long: `Validate that a slashing protection interchange file is well-formed and contains no conflicting blocks or attestations.  For example:
+
+    ethdo slashingprotection validate --file=interchange.json
+
+In quiet mode this will return 0 if the interchange is valid, otherwise 1.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		res, err := slashingprotectionvalidate.Run(cmd)
+		if err != nil {
+			return err
+		}
+		if viper.GetBool("quiet") {
+			return nil
+		}
+		if res != "" {
+			fmt.Println(res)
+		}
+		return nil
+	},
+}
+
+func init() {
+	slashingProtectionCmd.AddCommand(slashingProtectionValidateCmd)
+	slashingProtectionFlags(slashingProtectionValidateCmd)
+	slashingProtectionValidateCmd.Flags().String("file", "", "Interchange file to validate")
+}
+
+func slashingProtectionValidateBindings() {
+	if err := viper.BindPFlag("file", slashingProtectionValidateCmd.Flags().Lookup("file")); err != nil {
+		panic(err)
+	}
+}
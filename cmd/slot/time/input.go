@@ -27,6 +27,7 @@ type dataIn struct {
 	// System.
 	timeout time.Duration
 	quiet   bool
+	format  string
 	verbose bool
 	debug   bool
 	// Operation.
@@ -42,6 +43,7 @@ func input(ctx context.Context) (*dataIn, error) {
 	}
 	data.timeout = viper.GetDuration("timeout")
 	data.quiet = viper.GetBool("quiet")
+	data.format = util.OutputFormat()
 	data.verbose = viper.GetBool("verbose")
 	data.debug = viper.GetBool("debug")
 
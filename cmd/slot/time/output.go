@@ -18,17 +18,24 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/aaron-alderman/ethdo/util"
 	"github.com/pkg/errors"
 )
 
 type dataOut struct {
 	debug     bool
 	quiet     bool
+	format    string
 	verbose   bool
 	startTime time.Time
 	endTime   time.Time
 }
 
+type timeJSON struct {
+	StartTime time.Time `json:"start_time"`
+	EndTime   time.Time `json:"end_time"`
+}
+
 func output(ctx context.Context, data *dataOut) (string, error) {
 	if data == nil {
 		return "", errors.New("no data")
@@ -37,6 +44,13 @@ func output(ctx context.Context, data *dataOut) (string, error) {
 	if data.quiet {
 		return "", nil
 	}
+
+	if util.StructuredFormat(data.format) {
+		return util.FormatOutput(data.format, &timeJSON{
+			StartTime: data.startTime,
+			EndTime:   data.endTime,
+		})
+	}
 	if data.verbose {
 		return fmt.Sprintf("%s - %s", data.startTime, data.endTime), nil
 	}
@@ -0,0 +1,130 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package statediff
+
+import (
+	"context"
+	"time"
+
+	"github.com/aaron-alderman/ethdo/util"
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+)
+
+type command struct {
+	quiet   bool
+	verbose bool
+	debug   bool
+
+	// Beacon node connection.
+	timeout                  time.Duration
+	connection               string
+	allowInsecureConnections bool
+
+	// Operation.
+	fromStateID string
+	fromFile    string
+	toStateID   string
+	toFile      string
+	format      string
+	network     string
+
+	// Data access.
+	eth2Client    eth2client.Service
+	networkConfig *util.NetworkConfig
+	slotsPerEpoch uint64
+
+	// Results.
+	diff *stateDiff
+}
+
+type stateDiff struct {
+	From                   *stateTotals     `json:"from"`
+	To                     *stateTotals     `json:"to"`
+	BalanceChange          int64            `json:"balance_change"`
+	EffectiveBalanceChange int64            `json:"effective_balance_change"`
+	BalanceIncreases       int              `json:"balance_increases"`
+	BalanceDecreases       int              `json:"balance_decreases"`
+	StatusChanges          int              `json:"status_changes"`
+	Validators             []*validatorDiff `json:"validators"`
+}
+
+type stateTotals struct {
+	Slot                  phase0.Slot    `json:"slot"`
+	Epoch                 phase0.Epoch   `json:"epoch"`
+	Validators            int            `json:"validators"`
+	ValidatorStatuses     map[string]int `json:"validator_statuses"`
+	TotalBalance          phase0.Gwei    `json:"total_balance"`
+	TotalEffectiveBalance phase0.Gwei    `json:"total_effective_balance"`
+}
+
+type validatorDiff struct {
+	Index         phase0.ValidatorIndex `json:"index"`
+	FromStatus    string                `json:"from_status"`
+	ToStatus      string                `json:"to_status"`
+	FromBalance   phase0.Gwei           `json:"from_balance"`
+	ToBalance     phase0.Gwei           `json:"to_balance"`
+	BalanceChange int64                 `json:"balance_change"`
+}
+
+func newCommand(ctx context.Context) (*command, error) {
+	c := &command{
+		quiet:   viper.GetBool("quiet"),
+		verbose: viper.GetBool("verbose"),
+		debug:   viper.GetBool("debug"),
+		diff:    &stateDiff{},
+	}
+
+	// Timeout.
+	if viper.GetDuration("timeout") == 0 {
+		return nil, errors.New("timeout is required")
+	}
+	c.timeout = viper.GetDuration("timeout")
+
+	c.fromStateID = viper.GetString("from")
+	c.fromFile = viper.GetString("from-file")
+	if c.fromStateID == "" && c.fromFile == "" {
+		return nil, errors.New("one of from or from-file is required")
+	}
+	if c.fromStateID != "" && c.fromFile != "" {
+		return nil, errors.New("only one of from and from-file allowed")
+	}
+	c.toStateID = viper.GetString("to")
+	c.toFile = viper.GetString("to-file")
+	if c.toStateID != "" && c.toFile != "" {
+		return nil, errors.New("only one of to and to-file allowed")
+	}
+	if c.toStateID == "" && c.toFile == "" {
+		c.toStateID = "head"
+	}
+
+	c.connection = viper.GetString("connection")
+	if c.connection == "" {
+		// --network provides chain parameters but cannot provide states, so is not an alternative here.
+		if c.fromFile == "" {
+			return nil, errors.New("connection is required to obtain the from state by ID; use --from-file to read it from a file instead")
+		}
+		if c.toFile == "" {
+			return nil, errors.New("connection is required to obtain the to state, which defaults to head; use --to-file to read it from a file instead")
+		}
+	}
+	c.allowInsecureConnections = viper.GetBool("allow-insecure-connections")
+
+	c.format = util.OutputFormat()
+	c.network = viper.GetString("network")
+
+	return c, nil
+}
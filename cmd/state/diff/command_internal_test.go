@@ -0,0 +1,118 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package statediff
+
+import (
+	"context"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInput(t *testing.T) {
+	tests := []struct {
+		name        string
+		vars        map[string]interface{}
+		fromStateID string
+		toStateID   string
+		err         string
+	}{
+		{
+			name: "TimeoutMissing",
+			vars: map[string]interface{}{},
+			err:  "timeout is required",
+		},
+		{
+			name: "FromMissing",
+			vars: map[string]interface{}{
+				"timeout": "5s",
+			},
+			err: "one of from or from-file is required",
+		},
+		{
+			name: "FromBoth",
+			vars: map[string]interface{}{
+				"timeout":   "5s",
+				"from":      "genesis",
+				"from-file": "from.ssz",
+			},
+			err: "only one of from and from-file allowed",
+		},
+		{
+			name: "ToBoth",
+			vars: map[string]interface{}{
+				"timeout": "5s",
+				"from":    "genesis",
+				"to":      "head",
+				"to-file": "to.ssz",
+			},
+			err: "only one of to and to-file allowed",
+		},
+		{
+			name: "ConnectionMissing",
+			vars: map[string]interface{}{
+				"timeout":   "5s",
+				"from-file": "from.ssz",
+			},
+			err: "connection is required to obtain the to state, which defaults to head; use --to-file to read it from a file instead",
+		},
+		{
+			name: "ConnectionMissingFrom",
+			vars: map[string]interface{}{
+				"timeout": "5s",
+				"from":    "finalized",
+				"to-file": "to.ssz",
+				"network": "mainnet",
+			},
+			err: "connection is required to obtain the from state by ID; use --from-file to read it from a file instead",
+		},
+		{
+			name: "Files",
+			vars: map[string]interface{}{
+				"timeout":   "5s",
+				"from-file": "from.ssz",
+				"to-file":   "to.ssz",
+			},
+		},
+		{
+			name: "StateIDs",
+			vars: map[string]interface{}{
+				"timeout":    "5s",
+				"connection": "http://localhost:5051",
+				"from":       "finalized",
+			},
+			fromStateID: "finalized",
+			toStateID:   "head",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			viper.Reset()
+
+			for k, v := range test.vars {
+				viper.Set(k, v)
+			}
+			c, err := newCommand(context.Background())
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, test.fromStateID, c.fromStateID)
+				require.Equal(t, test.toStateID, c.toStateID)
+			}
+		})
+	}
+}
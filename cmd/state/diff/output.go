@@ -0,0 +1,102 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package statediff
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/aaron-alderman/ethdo/util"
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/wealdtech/go-string2eth"
+)
+
+func (c *command) output(ctx context.Context) (string, error) {
+	if c.quiet {
+		return "", nil
+	}
+
+	if util.StructuredFormat(c.format) {
+		return c.outputStructured(ctx)
+	}
+
+	return c.outputTxt(ctx)
+}
+
+func (c *command) outputStructured(_ context.Context) (string, error) {
+	data, err := json.Marshal(c.diff)
+	if err != nil {
+		return "", err
+	}
+	return util.FormatJSONData(c.format, data)
+}
+
+func (c *command) outputTxt(_ context.Context) (string, error) {
+	builder := strings.Builder{}
+
+	builder.WriteString(fmt.Sprintf("From: slot %d (epoch %d)\n", c.diff.From.Slot, c.diff.From.Epoch))
+	builder.WriteString(fmt.Sprintf("To: slot %d (epoch %d)\n", c.diff.To.Slot, c.diff.To.Epoch))
+
+	builder.WriteString(fmt.Sprintf("Validators: %d -> %d\n", c.diff.From.Validators, c.diff.To.Validators))
+	for status := apiv1.ValidatorStatePendingInitialized; status <= apiv1.ValidatorStateWithdrawalDone; status++ {
+		fromCount := c.diff.From.ValidatorStatuses[status.String()]
+		toCount := c.diff.To.ValidatorStatuses[status.String()]
+		if fromCount > 0 || toCount > 0 {
+			builder.WriteString(fmt.Sprintf("  %s: %d -> %d\n", status.String(), fromCount, toCount))
+		}
+	}
+	builder.WriteString(fmt.Sprintf("Total balance: %s -> %s (%s)\n",
+		string2eth.GWeiToString(uint64(c.diff.From.TotalBalance), true),
+		string2eth.GWeiToString(uint64(c.diff.To.TotalBalance), true),
+		balanceChange(c.diff.BalanceChange),
+	))
+	builder.WriteString(fmt.Sprintf("Total effective balance: %s -> %s (%s)\n",
+		string2eth.GWeiToString(uint64(c.diff.From.TotalEffectiveBalance), true),
+		string2eth.GWeiToString(uint64(c.diff.To.TotalEffectiveBalance), true),
+		balanceChange(c.diff.EffectiveBalanceChange),
+	))
+	builder.WriteString(fmt.Sprintf("Balance increases: %d\n", c.diff.BalanceIncreases))
+	builder.WriteString(fmt.Sprintf("Balance decreases: %d\n", c.diff.BalanceDecreases))
+	builder.WriteString(fmt.Sprintf("Status changes: %d\n", c.diff.StatusChanges))
+
+	if len(c.diff.Validators) > 0 {
+		builder.WriteString("Validator changes:\n")
+		for _, validator := range c.diff.Validators {
+			changes := make([]string, 0, 2)
+			if validator.FromStatus != validator.ToStatus {
+				changes = append(changes, fmt.Sprintf("status %s -> %s", validator.FromStatus, validator.ToStatus))
+			}
+			if validator.BalanceChange != 0 {
+				changes = append(changes, fmt.Sprintf("balance %s -> %s (%s)",
+					string2eth.GWeiToString(uint64(validator.FromBalance), true),
+					string2eth.GWeiToString(uint64(validator.ToBalance), true),
+					balanceChange(validator.BalanceChange),
+				))
+			}
+			builder.WriteString(fmt.Sprintf("  %d: %s\n", validator.Index, strings.Join(changes, "; ")))
+		}
+	}
+
+	return strings.TrimSuffix(builder.String(), "\n"), nil
+}
+
+// balanceChange formats a signed change in balance.
+func balanceChange(change int64) string {
+	if change < 0 {
+		return "-" + string2eth.GWeiToString(uint64(-change), true)
+	}
+	return "+" + string2eth.GWeiToString(uint64(change), true)
+}
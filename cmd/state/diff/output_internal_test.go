@@ -0,0 +1,68 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package statediff
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestOutput(t *testing.T) {
+	tests := []struct {
+		name    string
+		command *command
+		res     string
+	}{
+		{
+			name: "Quiet",
+			command: &command{
+				quiet: true,
+				diff:  testDiff(),
+			},
+		},
+		{
+			name: "Text",
+			command: &command{
+				diff: testDiff(),
+			},
+			res: `From: slot 320 (epoch 10)
+To: slot 640 (epoch 20)
+Validators: 3 -> 4
+  Pending_queued: 0 -> 1
+  Active_ongoing: 2 -> 2
+  Active_exiting: 1 -> 0
+  Exited_unslashed: 0 -> 1
+Total balance: 96.0000001 Ether -> 127.9999992 Ether (+31.9999991 Ether)
+Total effective balance: 96 Ether -> 128 Ether (+32 Ether)
+Balance increases: 2
+Balance decreases: 1
+Status changes: 2
+Validator changes:
+  0: balance 32.0000001 Ether -> 32.0000002 Ether (+100 GWei)
+  1: status Active_exiting -> Exited_unslashed
+  2: balance 32 Ether -> 31.999999 Ether (-0.000001 Ether)
+  3: status Unknown -> Pending_queued; balance 0 -> 32 Ether (+32 Ether)`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			res, err := test.command.output(context.Background())
+			require.NoError(t, err)
+			require.Equal(t, test.res, res)
+		})
+	}
+}
@@ -0,0 +1,217 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package statediff
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	"github.com/aaron-alderman/ethdo/util"
+	eth2client "github.com/attestantio/go-eth2-client"
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+)
+
+func (c *command) process(ctx context.Context) error {
+	// Obtain information we need to process.
+	if err := c.setup(ctx); err != nil {
+		return err
+	}
+
+	fromState, err := util.ObtainBeaconState(ctx, c.eth2Client, c.fromStateID, c.fromFile)
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain from state")
+	}
+	toState, err := util.ObtainBeaconState(ctx, c.eth2Client, c.toStateID, c.toFile)
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain to state")
+	}
+	if c.networkConfig != nil {
+		if err := c.networkConfig.VerifyBeaconState(fromState); err != nil {
+			return util.NewCodedError(util.ErrorCodeVerificationFailed, errors.Wrap(err, "from state"))
+		}
+		if err := c.networkConfig.VerifyBeaconState(toState); err != nil {
+			return util.NewCodedError(util.ErrorCodeVerificationFailed, errors.Wrap(err, "to state"))
+		}
+	}
+
+	return c.compare(fromState, toState)
+}
+
+func (c *command) setup(ctx context.Context) error {
+	chainInfo := util.DefaultChainInfo()
+	if c.network != "" {
+		var err error
+		c.networkConfig, err = util.NetworkConfigByName(c.network)
+		if err != nil {
+			return err
+		}
+		chainInfo = c.networkConfig.ChainInfo()
+	}
+	c.slotsPerEpoch = chainInfo.SlotsPerEpoch
+
+	// States from files only use a beacon node to add information if one is explicitly supplied.
+	if c.connection == "" {
+		return nil
+	}
+
+	var err error
+	c.eth2Client, err = util.ConnectToBeaconNode(ctx, c.connection, c.timeout, c.allowInsecureConnections)
+	if err != nil {
+		return errors.Wrap(err, "failed to connect to beacon node")
+	}
+
+	specProvider, isProvider := c.eth2Client.(eth2client.SpecProvider)
+	if !isProvider {
+		return errors.New("connection does not provide spec information")
+	}
+	spec, err := specProvider.Spec(ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain spec information")
+	}
+	c.slotsPerEpoch, err = util.SpecUint64(spec, "SLOTS_PER_EPOCH")
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// validatorStates holds the validators of a state with their balances and states.
+type validatorStates struct {
+	validators []*phase0.Validator
+	balances   []uint64
+	states     []apiv1.ValidatorState
+}
+
+func (c *command) compare(fromState *spec.VersionedBeaconState, toState *spec.VersionedBeaconState) error {
+	// Without a network both states have not been checked against a chain, so ensure
+	// that they are at least from the same chain as each other.
+	if c.networkConfig == nil {
+		if err := sameChain(fromState, toState); err != nil {
+			return util.NewCodedError(util.ErrorCodeVerificationFailed, err)
+		}
+	}
+
+	var from *validatorStates
+	var err error
+	c.diff.From, from, err = c.totals(fromState)
+	if err != nil {
+		return errors.Wrap(err, "invalid from state")
+	}
+	var to *validatorStates
+	c.diff.To, to, err = c.totals(toState)
+	if err != nil {
+		return errors.Wrap(err, "invalid to state")
+	}
+
+	c.diff.BalanceChange = int64(c.diff.To.TotalBalance) - int64(c.diff.From.TotalBalance)
+	c.diff.EffectiveBalanceChange = int64(c.diff.To.TotalEffectiveBalance) - int64(c.diff.From.TotalEffectiveBalance)
+
+	// Validators can only be added to the state, but the states may have been supplied in either order.
+	validators := len(from.validators)
+	if len(to.validators) > validators {
+		validators = len(to.validators)
+	}
+	c.diff.Validators = make([]*validatorDiff, 0)
+	for i := 0; i < validators; i++ {
+		diff := &validatorDiff{
+			Index:      phase0.ValidatorIndex(i),
+			FromStatus: apiv1.ValidatorStateUnknown.String(),
+			ToStatus:   apiv1.ValidatorStateUnknown.String(),
+		}
+		if i < len(from.validators) {
+			diff.FromStatus = from.states[i].String()
+			diff.FromBalance = phase0.Gwei(from.balances[i])
+		}
+		if i < len(to.validators) {
+			diff.ToStatus = to.states[i].String()
+			diff.ToBalance = phase0.Gwei(to.balances[i])
+		}
+		diff.BalanceChange = int64(diff.ToBalance) - int64(diff.FromBalance)
+
+		switch {
+		case diff.BalanceChange > 0:
+			c.diff.BalanceIncreases++
+		case diff.BalanceChange < 0:
+			c.diff.BalanceDecreases++
+		}
+		if diff.FromStatus != diff.ToStatus {
+			c.diff.StatusChanges++
+		}
+		if diff.BalanceChange != 0 || diff.FromStatus != diff.ToStatus {
+			c.diff.Validators = append(c.diff.Validators, diff)
+		}
+	}
+
+	return nil
+}
+
+// sameChain returns an error if the states have different genesis validators roots.
+func sameChain(fromState *spec.VersionedBeaconState, toState *spec.VersionedBeaconState) error {
+	fromRoot, err := util.BeaconStateGenesisValidatorsRoot(fromState)
+	if err != nil {
+		return errors.Wrap(err, "invalid from state")
+	}
+	toRoot, err := util.BeaconStateGenesisValidatorsRoot(toState)
+	if err != nil {
+		return errors.Wrap(err, "invalid to state")
+	}
+	if !bytes.Equal(fromRoot, toRoot) {
+		return fmt.Errorf("states are from different chains: from state has genesis validators root %#x but to state has %#x", fromRoot, toRoot)
+	}
+
+	return nil
+}
+
+func (c *command) totals(state *spec.VersionedBeaconState) (*stateTotals, *validatorStates, error) {
+	slot, err := util.BeaconStateSlot(state)
+	if err != nil {
+		return nil, nil, err
+	}
+	validators, err := util.BeaconStateValidators(state)
+	if err != nil {
+		return nil, nil, err
+	}
+	balances, err := util.BeaconStateBalances(state)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(balances) != len(validators) {
+		return nil, nil, fmt.Errorf("state has %d validators but %d balances", len(validators), len(balances))
+	}
+
+	totals := &stateTotals{
+		Slot:              slot,
+		Epoch:             phase0.Epoch(uint64(slot) / c.slotsPerEpoch),
+		Validators:        len(validators),
+		ValidatorStatuses: make(map[string]int),
+	}
+	states := &validatorStates{
+		validators: validators,
+		balances:   balances,
+		states:     make([]apiv1.ValidatorState, len(validators)),
+	}
+	for i, validator := range validators {
+		states.states[i] = util.ValidatorStateAt(validator, balances[i], totals.Epoch)
+		totals.ValidatorStatuses[states.states[i].String()]++
+		totals.TotalBalance += phase0.Gwei(balances[i])
+		totals.TotalEffectiveBalance += validator.EffectiveBalance
+	}
+
+	return totals, states, nil
+}
@@ -0,0 +1,198 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package statediff
+
+import (
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/altair"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+)
+
+const farFutureEpoch = phase0.Epoch(0xffffffffffffffff)
+
+func testValidator(activationEpoch phase0.Epoch, exitEpoch phase0.Epoch) *phase0.Validator {
+	return &phase0.Validator{
+		WithdrawalCredentials:      make([]byte, 32),
+		EffectiveBalance:           32000000000,
+		ActivationEligibilityEpoch: 0,
+		ActivationEpoch:            activationEpoch,
+		ExitEpoch:                  exitEpoch,
+		WithdrawableEpoch:          farFutureEpoch,
+	}
+}
+
+func testState(slot uint64, validators []*phase0.Validator, balances []uint64) *spec.VersionedBeaconState {
+	return &spec.VersionedBeaconState{
+		Version: spec.DataVersionAltair,
+		Altair: &altair.BeaconState{
+			GenesisValidatorsRoot: make([]byte, 32),
+			Slot:                  slot,
+			Validators:            validators,
+			Balances:              balances,
+		},
+	}
+}
+
+func testFromState() *spec.VersionedBeaconState {
+	return testState(320,
+		[]*phase0.Validator{
+			testValidator(0, farFutureEpoch),
+			testValidator(0, 15),
+			testValidator(0, farFutureEpoch),
+		},
+		[]uint64{32000000100, 32000000000, 32000000000},
+	)
+}
+
+func testToState() *spec.VersionedBeaconState {
+	return testState(640,
+		[]*phase0.Validator{
+			testValidator(0, farFutureEpoch),
+			testValidator(0, 15),
+			testValidator(0, farFutureEpoch),
+			testValidator(farFutureEpoch, farFutureEpoch),
+		},
+		[]uint64{32000000200, 32000000000, 31999999000, 32000000000},
+	)
+}
+
+func testDiff() *stateDiff {
+	return &stateDiff{
+		From: &stateTotals{
+			Slot:                  320,
+			Epoch:                 10,
+			Validators:            3,
+			ValidatorStatuses:     map[string]int{"Active_ongoing": 2, "Active_exiting": 1},
+			TotalBalance:          96000000100,
+			TotalEffectiveBalance: 96000000000,
+		},
+		To: &stateTotals{
+			Slot:                  640,
+			Epoch:                 20,
+			Validators:            4,
+			ValidatorStatuses:     map[string]int{"Pending_queued": 1, "Active_ongoing": 2, "Exited_unslashed": 1},
+			TotalBalance:          127999999200,
+			TotalEffectiveBalance: 128000000000,
+		},
+		BalanceChange:          31999999100,
+		EffectiveBalanceChange: 32000000000,
+		BalanceIncreases:       2,
+		BalanceDecreases:       1,
+		StatusChanges:          2,
+		Validators: []*validatorDiff{
+			{
+				Index:         0,
+				FromStatus:    "Active_ongoing",
+				ToStatus:      "Active_ongoing",
+				FromBalance:   32000000100,
+				ToBalance:     32000000200,
+				BalanceChange: 100,
+			},
+			{
+				Index:         1,
+				FromStatus:    "Active_exiting",
+				ToStatus:      "Exited_unslashed",
+				FromBalance:   32000000000,
+				ToBalance:     32000000000,
+				BalanceChange: 0,
+			},
+			{
+				Index:         2,
+				FromStatus:    "Active_ongoing",
+				ToStatus:      "Active_ongoing",
+				FromBalance:   32000000000,
+				ToBalance:     31999999000,
+				BalanceChange: -1000,
+			},
+			{
+				Index:         3,
+				FromStatus:    "Unknown",
+				ToStatus:      "Pending_queued",
+				FromBalance:   0,
+				ToBalance:     32000000000,
+				BalanceChange: 32000000000,
+			},
+		},
+	}
+}
+
+func TestCompare(t *testing.T) {
+	mismatchState := testFromState()
+	mismatchState.Altair.Balances = mismatchState.Altair.Balances[1:]
+	otherChainState := testToState()
+	otherChainState.Altair.GenesisValidatorsRoot = make([]byte, 32)
+	otherChainState.Altair.GenesisValidatorsRoot[0] = 0x01
+
+	tests := []struct {
+		name      string
+		fromState *spec.VersionedBeaconState
+		toState   *spec.VersionedBeaconState
+		diff      *stateDiff
+		err       string
+	}{
+		{
+			name:      "DifferentChains",
+			fromState: testFromState(),
+			toState:   otherChainState,
+			err:       "states are from different chains: from state has genesis validators root 0x0000000000000000000000000000000000000000000000000000000000000000 but to state has 0x0100000000000000000000000000000000000000000000000000000000000000",
+		},
+		{
+			name:      "FromMismatch",
+			fromState: mismatchState,
+			toState:   testToState(),
+			err:       "invalid from state: state has 3 validators but 2 balances",
+		},
+		{
+			name:      "ToMismatch",
+			fromState: testFromState(),
+			toState:   mismatchState,
+			err:       "invalid to state: state has 3 validators but 2 balances",
+		},
+		{
+			name:      "Unchanged",
+			fromState: testFromState(),
+			toState:   testFromState(),
+			diff: &stateDiff{
+				From:       testDiff().From,
+				To:         testDiff().From,
+				Validators: []*validatorDiff{},
+			},
+		},
+		{
+			name:      "Good",
+			fromState: testFromState(),
+			toState:   testToState(),
+			diff:      testDiff(),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := &command{
+				slotsPerEpoch: 32,
+				diff:          &stateDiff{},
+			}
+			err := c.compare(test.fromState, test.toState)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, test.diff, c.diff)
+			}
+		})
+	}
+}
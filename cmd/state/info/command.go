@@ -0,0 +1,80 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stateinfo
+
+import (
+	"context"
+	"time"
+
+	"github.com/aaron-alderman/ethdo/util"
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+)
+
+type command struct {
+	quiet   bool
+	verbose bool
+	debug   bool
+
+	// Beacon node connection.
+	timeout                  time.Duration
+	connection               string
+	allowInsecureConnections bool
+
+	// Operation.
+	stateID string
+	file    string
+	format  string
+	network string
+
+	// Data access.
+	eth2Client    eth2client.Service
+	networkConfig *util.NetworkConfig
+
+	// Results.
+	slotsPerEpoch uint64
+	state         *spec.VersionedBeaconState
+}
+
+func newCommand(ctx context.Context) (*command, error) {
+	c := &command{
+		quiet:   viper.GetBool("quiet"),
+		verbose: viper.GetBool("verbose"),
+		debug:   viper.GetBool("debug"),
+	}
+
+	// Timeout.
+	if viper.GetDuration("timeout") == 0 {
+		return nil, errors.New("timeout is required")
+	}
+	c.timeout = viper.GetDuration("timeout")
+
+	c.file = viper.GetString("file")
+	c.connection = viper.GetString("connection")
+	if c.file == "" && c.connection == "" {
+		return nil, errors.New("connection is required")
+	}
+	c.allowInsecureConnections = viper.GetBool("allow-insecure-connections")
+
+	c.stateID = viper.GetString("stateid")
+	if c.stateID == "" {
+		c.stateID = "head"
+	}
+	c.format = util.OutputFormat()
+	c.network = viper.GetString("network")
+
+	return c, nil
+}
@@ -0,0 +1,202 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stateinfo
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/aaron-alderman/ethdo/util"
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/prysmaticlabs/go-bitfield"
+	"github.com/wealdtech/go-string2eth"
+)
+
+type stateJSON struct {
+	Version *spec.DataVersion `json:"version"`
+	Data    interface{}       `json:"data"`
+}
+
+// stateFields are the fields of the state common to all forks.
+type stateFields struct {
+	genesisTime                 uint64
+	slot                        uint64
+	fork                        *phase0.Fork
+	latestBlockHeader           *phase0.BeaconBlockHeader
+	eth1Data                    *phase0.ETH1Data
+	eth1DataVotes               int
+	eth1DepositIndex            *uint64
+	historicalRoots             int
+	validators                  []*phase0.Validator
+	balances                    []uint64
+	justificationBits           bitfield.Bitvector4
+	previousJustifiedCheckpoint *phase0.Checkpoint
+	currentJustifiedCheckpoint  *phase0.Checkpoint
+	finalizedCheckpoint         *phase0.Checkpoint
+}
+
+func (c *command) output(ctx context.Context) (string, error) {
+	if c.quiet {
+		return "", nil
+	}
+
+	if util.StructuredFormat(c.format) {
+		return c.outputStructured(ctx)
+	}
+
+	return c.outputTxt(ctx)
+}
+
+func (c *command) outputStructured(_ context.Context) (string, error) {
+	res := &stateJSON{
+		Version: &c.state.Version,
+	}
+	switch c.state.Version {
+	case spec.DataVersionPhase0:
+		res.Data = c.state.Phase0
+	case spec.DataVersionAltair:
+		res.Data = c.state.Altair
+	case spec.DataVersionBellatrix:
+		res.Data = c.state.Bellatrix
+	default:
+		return "", fmt.Errorf("unhandled state version %v", c.state.Version)
+	}
+
+	data, err := json.Marshal(res)
+	if err != nil {
+		return "", err
+	}
+	return util.FormatJSONData(c.format, data)
+}
+
+func (c *command) outputTxt(_ context.Context) (string, error) {
+	fields, err := commonFields(c.state)
+	if err != nil {
+		return "", err
+	}
+
+	builder := strings.Builder{}
+
+	builder.WriteString(fmt.Sprintf("Version: %s\n", strings.ToLower(c.state.Version.String())))
+	builder.WriteString(fmt.Sprintf("Slot: %d\n", fields.slot))
+	builder.WriteString(fmt.Sprintf("Epoch: %d\n", fields.slot/c.slotsPerEpoch))
+	builder.WriteString(fmt.Sprintf("Genesis time: %v\n", time.Unix(int64(fields.genesisTime), 0)))
+	builder.WriteString(fmt.Sprintf("Fork: %#x (previous %#x, epoch %d)\n", fields.fork.CurrentVersion, fields.fork.PreviousVersion, fields.fork.Epoch))
+
+	builder.WriteString("Latest block header:\n")
+	builder.WriteString(fmt.Sprintf("  Slot: %d\n", fields.latestBlockHeader.Slot))
+	builder.WriteString(fmt.Sprintf("  Proposer index: %d\n", fields.latestBlockHeader.ProposerIndex))
+	if c.verbose {
+		builder.WriteString(fmt.Sprintf("  Parent root: %#x\n", fields.latestBlockHeader.ParentRoot))
+		builder.WriteString(fmt.Sprintf("  State root: %#x\n", fields.latestBlockHeader.StateRoot))
+		builder.WriteString(fmt.Sprintf("  Body root: %#x\n", fields.latestBlockHeader.BodyRoot))
+	}
+
+	builder.WriteString(fmt.Sprintf("Ethereum 1 deposit count: %d\n", fields.eth1Data.DepositCount))
+	if fields.eth1DepositIndex != nil {
+		builder.WriteString(fmt.Sprintf("Ethereum 1 deposit index: %d\n", *fields.eth1DepositIndex))
+	}
+	if c.verbose {
+		builder.WriteString(fmt.Sprintf("Ethereum 1 deposit root: %#x\n", fields.eth1Data.DepositRoot))
+		builder.WriteString(fmt.Sprintf("Ethereum 1 block hash: %#x\n", fields.eth1Data.BlockHash))
+		builder.WriteString(fmt.Sprintf("Ethereum 1 data votes: %d\n", fields.eth1DataVotes))
+		builder.WriteString(fmt.Sprintf("Historical roots: %d\n", fields.historicalRoots))
+	}
+
+	builder.WriteString(fmt.Sprintf("Validators: %d\n", len(fields.validators)))
+	totalBalance := uint64(0)
+	for _, balance := range fields.balances {
+		totalBalance += balance
+	}
+	builder.WriteString(fmt.Sprintf("Total balance: %s\n", string2eth.GWeiToString(totalBalance, true)))
+
+	justificationBits := ""
+	for i := uint64(0); i < fields.justificationBits.Len(); i++ {
+		if fields.justificationBits.BitAt(i) {
+			justificationBits += "1"
+		} else {
+			justificationBits += "0"
+		}
+	}
+	builder.WriteString(fmt.Sprintf("Justification bits: %s\n", justificationBits))
+	builder.WriteString(fmt.Sprintf("Previous justified checkpoint: %d (%#x)\n", fields.previousJustifiedCheckpoint.Epoch, fields.previousJustifiedCheckpoint.Root))
+	builder.WriteString(fmt.Sprintf("Current justified checkpoint: %d (%#x)\n", fields.currentJustifiedCheckpoint.Epoch, fields.currentJustifiedCheckpoint.Root))
+	builder.WriteString(fmt.Sprintf("Finalized checkpoint: %d (%#x)\n", fields.finalizedCheckpoint.Epoch, fields.finalizedCheckpoint.Root))
+
+	return strings.TrimSuffix(builder.String(), "\n"), nil
+}
+
+func commonFields(state *spec.VersionedBeaconState) (*stateFields, error) {
+	switch state.Version {
+	case spec.DataVersionPhase0:
+		s := state.Phase0
+		return &stateFields{
+			genesisTime:                 s.GenesisTime,
+			slot:                        s.Slot,
+			fork:                        s.Fork,
+			latestBlockHeader:           s.LatestBlockHeader,
+			eth1Data:                    s.ETH1Data,
+			eth1DataVotes:               len(s.ETH1DataVotes),
+			historicalRoots:             len(s.HistoricalRoots),
+			validators:                  s.Validators,
+			balances:                    s.Balances,
+			justificationBits:           s.JustificationBits,
+			previousJustifiedCheckpoint: s.PreviousJustifiedCheckpoint,
+			currentJustifiedCheckpoint:  s.CurrentJustifiedCheckpoint,
+			finalizedCheckpoint:         s.FinalizedCheckpoint,
+		}, nil
+	case spec.DataVersionAltair:
+		s := state.Altair
+		return &stateFields{
+			genesisTime:                 s.GenesisTime,
+			slot:                        s.Slot,
+			fork:                        s.Fork,
+			latestBlockHeader:           s.LatestBlockHeader,
+			eth1Data:                    s.ETH1Data,
+			eth1DataVotes:               len(s.ETH1DataVotes),
+			eth1DepositIndex:            &s.ETH1DepositIndex,
+			historicalRoots:             len(s.HistoricalRoots),
+			validators:                  s.Validators,
+			balances:                    s.Balances,
+			justificationBits:           s.JustificationBits,
+			previousJustifiedCheckpoint: s.PreviousJustifiedCheckpoint,
+			currentJustifiedCheckpoint:  s.CurrentJustifiedCheckpoint,
+			finalizedCheckpoint:         s.FinalizedCheckpoint,
+		}, nil
+	case spec.DataVersionBellatrix:
+		s := state.Bellatrix
+		return &stateFields{
+			genesisTime:                 s.GenesisTime,
+			slot:                        s.Slot,
+			fork:                        s.Fork,
+			latestBlockHeader:           s.LatestBlockHeader,
+			eth1Data:                    s.ETH1Data,
+			eth1DataVotes:               len(s.ETH1DataVotes),
+			eth1DepositIndex:            &s.ETH1DepositIndex,
+			historicalRoots:             len(s.HistoricalRoots),
+			validators:                  s.Validators,
+			balances:                    s.Balances,
+			justificationBits:           s.JustificationBits,
+			previousJustifiedCheckpoint: s.PreviousJustifiedCheckpoint,
+			currentJustifiedCheckpoint:  s.CurrentJustifiedCheckpoint,
+			finalizedCheckpoint:         s.FinalizedCheckpoint,
+		}, nil
+	default:
+		return nil, fmt.Errorf("unhandled state version %v", state.Version)
+	}
+}
@@ -0,0 +1,101 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stateinfo
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/aaron-alderman/ethdo/util"
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOutput(t *testing.T) {
+	state := &spec.VersionedBeaconState{
+		Version: spec.DataVersionAltair,
+		Altair:  testAltairState(),
+	}
+
+	tests := []struct {
+		name    string
+		command *command
+		res     string
+		err     string
+	}{
+		{
+			name: "Quiet",
+			command: &command{
+				quiet: true,
+				state: state,
+			},
+		},
+		{
+			name: "Text",
+			command: &command{
+				slotsPerEpoch: 32,
+				state:         state,
+			},
+			res: fmt.Sprintf(`Version: altair
+Slot: 1000
+Epoch: 31
+Genesis time: %v
+Fork: 0x01000000 (previous 0x00000000, epoch 10)
+Latest block header:
+  Slot: 999
+  Proposer index: 5
+Ethereum 1 deposit count: 4
+Ethereum 1 deposit index: 4
+Validators: 2
+Total balance: 63.5 Ether
+Justification bits: 1110
+Previous justified checkpoint: 29 (0x0000000000000000000000000000000000000000000000000000000000000000)
+Current justified checkpoint: 30 (0x0000000000000000000000000000000000000000000000000000000000000000)
+Finalized checkpoint: 29 (0x0000000000000000000000000000000000000000000000000000000000000000)`, time.Unix(1606824023, 0)),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			res, err := test.command.output(context.Background())
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, test.res, res)
+			}
+		})
+	}
+}
+
+func TestOutputJSON(t *testing.T) {
+	c := &command{
+		format: "json",
+		state: &spec.VersionedBeaconState{
+			Version: spec.DataVersionAltair,
+			Altair:  testAltairState(),
+		},
+	}
+
+	res, err := c.output(context.Background())
+	require.NoError(t, err)
+
+	// The output can be read back in.
+	state, err := util.ParseBeaconState([]byte(res))
+	require.NoError(t, err)
+	require.Equal(t, spec.DataVersionAltair, state.Version)
+	require.Equal(t, uint64(1000), state.Altair.Slot)
+}
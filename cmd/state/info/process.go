@@ -0,0 +1,86 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stateinfo
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/aaron-alderman/ethdo/util"
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/pkg/errors"
+)
+
+func (c *command) process(ctx context.Context) error {
+	// Obtain information we need to process.
+	if err := c.setup(ctx); err != nil {
+		return err
+	}
+
+	var err error
+	c.state, err = util.ObtainBeaconState(ctx, c.eth2Client, c.stateID, c.file)
+	if err != nil {
+		return err
+	}
+	if c.debug {
+		fmt.Fprintf(os.Stderr, "State version is %s\n", c.state.Version)
+	}
+	if c.networkConfig != nil {
+		if err := c.networkConfig.VerifyBeaconState(c.state); err != nil {
+			return util.NewCodedError(util.ErrorCodeVerificationFailed, err)
+		}
+	}
+
+	return nil
+}
+
+func (c *command) setup(ctx context.Context) error {
+	chainInfo := util.DefaultChainInfo()
+	if c.network != "" {
+		var err error
+		c.networkConfig, err = util.NetworkConfigByName(c.network)
+		if err != nil {
+			return err
+		}
+		chainInfo = c.networkConfig.ChainInfo()
+	}
+	c.slotsPerEpoch = chainInfo.SlotsPerEpoch
+
+	// A state from a file only uses a beacon node to add information if one is explicitly supplied.
+	if c.connection == "" {
+		return nil
+	}
+
+	var err error
+	c.eth2Client, err = util.ConnectToBeaconNode(ctx, c.connection, c.timeout, c.allowInsecureConnections)
+	if err != nil {
+		return errors.Wrap(err, "failed to connect to beacon node")
+	}
+
+	specProvider, isProvider := c.eth2Client.(eth2client.SpecProvider)
+	if !isProvider {
+		return errors.New("connection does not provide spec information")
+	}
+	spec, err := specProvider.Spec(ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain spec information")
+	}
+	c.slotsPerEpoch, err = util.SpecUint64(spec, "SLOTS_PER_EPOCH")
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,179 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stateinfo
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/altair"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+)
+
+func testAltairState() *altair.BeaconState {
+	return &altair.BeaconState{
+		GenesisTime:           1606824023,
+		GenesisValidatorsRoot: make([]byte, 32),
+		Slot:                  1000,
+		Fork: &phase0.Fork{
+			PreviousVersion: phase0.Version{0x00, 0x00, 0x00, 0x00},
+			CurrentVersion:  phase0.Version{0x01, 0x00, 0x00, 0x00},
+			Epoch:           10,
+		},
+		LatestBlockHeader:          &phase0.BeaconBlockHeader{Slot: 999, ProposerIndex: 5},
+		BlockRoots:                 [][]byte{make([]byte, 32)},
+		StateRoots:                 [][]byte{make([]byte, 32)},
+		HistoricalRoots:            [][]byte{make([]byte, 32)},
+		ETH1Data:                   &phase0.ETH1Data{DepositCount: 4, BlockHash: make([]byte, 32)},
+		ETH1DataVotes:              []*phase0.ETH1Data{},
+		ETH1DepositIndex:           4,
+		Validators:                 []*phase0.Validator{{WithdrawalCredentials: make([]byte, 32)}, {WithdrawalCredentials: make([]byte, 32)}},
+		Balances:                   []uint64{32000000000, 31500000000},
+		RANDAOMixes:                [][]byte{},
+		Slashings:                  []uint64{0},
+		PreviousEpochParticipation: []altair.ParticipationFlags{0, 7},
+		CurrentEpochParticipation:  []altair.ParticipationFlags{0, 3},
+		JustificationBits:          []byte{0x07},
+		PreviousJustifiedCheckpoint: &phase0.Checkpoint{
+			Epoch: 29,
+		},
+		CurrentJustifiedCheckpoint: &phase0.Checkpoint{
+			Epoch: 30,
+		},
+		FinalizedCheckpoint: &phase0.Checkpoint{
+			Epoch: 29,
+		},
+		InactivityScores:     []uint64{0, 0},
+		CurrentSyncCommittee: &altair.SyncCommittee{Pubkeys: make([]phase0.BLSPubKey, 512)},
+		NextSyncCommittee:    &altair.SyncCommittee{Pubkeys: make([]phase0.BLSPubKey, 512)},
+	}
+}
+
+func TestProcess(t *testing.T) {
+	dir, err := ioutil.TempDir("", "")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	data, err := json.Marshal(testAltairState())
+	require.NoError(t, err)
+	goodPath := filepath.Join(dir, "state.json")
+	require.NoError(t, ioutil.WriteFile(goodPath, data, 0600))
+	badPath := filepath.Join(dir, "bad.json")
+	require.NoError(t, ioutil.WriteFile(badPath, []byte(`{}`), 0600))
+
+	viper.Reset()
+	defer viper.Reset()
+	viper.SetConfigType("yaml")
+	require.NoError(t, viper.ReadConfig(bytes.NewBufferString(`networks:
+  testnet:
+    genesis-fork-version: '0x00000000'
+    slots-per-epoch: 8
+    forks:
+      - version: '0x01000000'
+        epoch: 10
+`)))
+
+	tests := []struct {
+		name          string
+		command       *command
+		version       spec.DataVersion
+		slotsPerEpoch uint64
+		err           string
+	}{
+		{
+			name: "FileMissing",
+			command: &command{
+				timeout: 5 * time.Second,
+				file:    filepath.Join(dir, "missing"),
+			},
+			err: "failed to read state file: open " + filepath.Join(dir, "missing") + ": no such file or directory",
+		},
+		{
+			name: "FileBad",
+			command: &command{
+				timeout: 5 * time.Second,
+				file:    badPath,
+			},
+			err: "failed to parse beacon state: invalid phase0 state: genesis time missing",
+		},
+		{
+			name: "Good",
+			command: &command{
+				timeout: 5 * time.Second,
+				file:    goodPath,
+			},
+			version:       spec.DataVersionAltair,
+			slotsPerEpoch: 32,
+		},
+		{
+			name: "NetworkUnknown",
+			command: &command{
+				timeout: 5 * time.Second,
+				file:    goodPath,
+				network: "unknown",
+			},
+			err: "unknown network unknown",
+		},
+		{
+			name: "NetworkGenesisValidatorsRootMismatch",
+			command: &command{
+				timeout: 5 * time.Second,
+				file:    goodPath,
+				network: "mainnet",
+			},
+			err: "state has genesis validators root 0x0000000000000000000000000000000000000000000000000000000000000000 but Mainnet has 0x4b363db94e286120d76eb905340fdd4e54bfe9f06bf33ff6cf5ad27f511bfe95",
+		},
+		{
+			name: "NetworkForkMismatch",
+			command: &command{
+				timeout: 5 * time.Second,
+				file:    goodPath,
+				network: "pyrmont",
+			},
+			err: "state at epoch 31 has fork version 0x01000000 but Pyrmont has 0x00002009",
+		},
+		{
+			name: "Network",
+			command: &command{
+				timeout: 5 * time.Second,
+				file:    goodPath,
+				network: "testnet",
+			},
+			version:       spec.DataVersionAltair,
+			slotsPerEpoch: 8,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.command.process(context.Background())
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, test.version, test.command.state.Version)
+				require.Equal(t, test.slotsPerEpoch, test.command.slotsPerEpoch)
+			}
+		})
+	}
+}
@@ -0,0 +1,111 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package statesummary
+
+import (
+	"context"
+	"time"
+
+	"github.com/aaron-alderman/ethdo/util"
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+)
+
+type command struct {
+	quiet   bool
+	verbose bool
+	debug   bool
+
+	// Beacon node connection.
+	timeout                  time.Duration
+	connection               string
+	allowInsecureConnections bool
+
+	// Operation.
+	stateID string
+	file    string
+	format  string
+	network string
+
+	// Data access.
+	eth2Client    eth2client.Service
+	networkConfig *util.NetworkConfig
+	slotsPerEpoch uint64
+
+	// Results.
+	summary *stateSummary
+}
+
+type stateSummary struct {
+	Version                    string              `json:"version"`
+	Slot                       phase0.Slot         `json:"slot"`
+	Epoch                      phase0.Epoch        `json:"epoch"`
+	Validators                 int                 `json:"validators"`
+	ValidatorStatuses          map[string]int      `json:"validator_statuses"`
+	TotalBalance               phase0.Gwei         `json:"total_balance"`
+	TotalEffectiveBalance      phase0.Gwei         `json:"total_effective_balance"`
+	ActiveEffectiveBalance     phase0.Gwei         `json:"active_effective_balance"`
+	PreviousEpochParticipation *epochParticipation `json:"previous_epoch_participation,omitempty"`
+	CurrentEpochParticipation  *epochParticipation `json:"current_epoch_participation,omitempty"`
+	JustificationBits          string              `json:"justification_bits"`
+	SlashedValidators          int                 `json:"slashed_validators"`
+	Slashings                  phase0.Gwei         `json:"slashings"`
+	RANDAOMix                  string              `json:"randao_mix"`
+}
+
+type epochParticipation struct {
+	Epoch                  phase0.Epoch       `json:"epoch"`
+	ActiveEffectiveBalance phase0.Gwei        `json:"active_effective_balance"`
+	TimelySource           *flagParticipation `json:"timely_source"`
+	TimelyTarget           *flagParticipation `json:"timely_target"`
+	TimelyHead             *flagParticipation `json:"timely_head"`
+}
+
+type flagParticipation struct {
+	Validators       int         `json:"validators"`
+	EffectiveBalance phase0.Gwei `json:"effective_balance"`
+}
+
+func newCommand(ctx context.Context) (*command, error) {
+	c := &command{
+		quiet:   viper.GetBool("quiet"),
+		verbose: viper.GetBool("verbose"),
+		debug:   viper.GetBool("debug"),
+		summary: &stateSummary{},
+	}
+
+	// Timeout.
+	if viper.GetDuration("timeout") == 0 {
+		return nil, errors.New("timeout is required")
+	}
+	c.timeout = viper.GetDuration("timeout")
+
+	c.file = viper.GetString("file")
+	c.connection = viper.GetString("connection")
+	if c.file == "" && c.connection == "" {
+		return nil, errors.New("connection is required")
+	}
+	c.allowInsecureConnections = viper.GetBool("allow-insecure-connections")
+
+	c.stateID = viper.GetString("stateid")
+	if c.stateID == "" {
+		c.stateID = "head"
+	}
+	c.format = util.OutputFormat()
+	c.network = viper.GetString("network")
+
+	return c, nil
+}
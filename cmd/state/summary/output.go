@@ -0,0 +1,108 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package statesummary
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/aaron-alderman/ethdo/util"
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/wealdtech/go-string2eth"
+)
+
+func (c *command) output(ctx context.Context) (string, error) {
+	if c.quiet {
+		return "", nil
+	}
+
+	if util.StructuredFormat(c.format) {
+		return c.outputStructured(ctx)
+	}
+
+	return c.outputTxt(ctx)
+}
+
+func (c *command) outputStructured(_ context.Context) (string, error) {
+	data, err := json.Marshal(c.summary)
+	if err != nil {
+		return "", err
+	}
+	return util.FormatJSONData(c.format, data)
+}
+
+func (c *command) outputTxt(_ context.Context) (string, error) {
+	builder := strings.Builder{}
+
+	builder.WriteString(fmt.Sprintf("Version: %s\n", c.summary.Version))
+	builder.WriteString(fmt.Sprintf("Slot: %d\n", c.summary.Slot))
+	builder.WriteString(fmt.Sprintf("Epoch: %d\n", c.summary.Epoch))
+
+	builder.WriteString(fmt.Sprintf("Validators: %d\n", c.summary.Validators))
+	for status := apiv1.ValidatorStatePendingInitialized; status <= apiv1.ValidatorStateWithdrawalDone; status++ {
+		if count := c.summary.ValidatorStatuses[status.String()]; count > 0 {
+			builder.WriteString(fmt.Sprintf("  %s: %d\n", status.String(), count))
+		}
+	}
+	builder.WriteString(fmt.Sprintf("Total balance: %s\n", string2eth.GWeiToString(uint64(c.summary.TotalBalance), true)))
+	builder.WriteString(fmt.Sprintf("Total effective balance: %s\n", string2eth.GWeiToString(uint64(c.summary.TotalEffectiveBalance), true)))
+	builder.WriteString(fmt.Sprintf("Active effective balance: %s\n", string2eth.GWeiToString(uint64(c.summary.ActiveEffectiveBalance), true)))
+
+	if c.summary.PreviousEpochParticipation != nil {
+		builder.WriteString(outputParticipation("Previous", c.summary.PreviousEpochParticipation))
+	}
+	if c.summary.CurrentEpochParticipation != nil {
+		builder.WriteString(outputParticipation("Current", c.summary.CurrentEpochParticipation))
+	}
+
+	builder.WriteString(fmt.Sprintf("Justification bits: %s\n", c.summary.JustificationBits))
+	builder.WriteString(fmt.Sprintf("Slashed validators: %d\n", c.summary.SlashedValidators))
+	builder.WriteString(fmt.Sprintf("Slashings: %s\n", string2eth.GWeiToString(uint64(c.summary.Slashings), true)))
+	builder.WriteString(fmt.Sprintf("RANDAO mix: %s\n", c.summary.RANDAOMix))
+
+	return strings.TrimSuffix(builder.String(), "\n"), nil
+}
+
+func outputParticipation(name string, participation *epochParticipation) string {
+	builder := strings.Builder{}
+
+	builder.WriteString(fmt.Sprintf("%s epoch (%d) participation:\n", name, participation.Epoch))
+	for _, flag := range []struct {
+		name              string
+		flagParticipation *flagParticipation
+	}{
+		{name: "Timely source", flagParticipation: participation.TimelySource},
+		{name: "Timely target", flagParticipation: participation.TimelyTarget},
+		{name: "Timely head", flagParticipation: participation.TimelyHead},
+	} {
+		builder.WriteString(fmt.Sprintf("  %s: %d validators, %s (%s)\n",
+			flag.name,
+			flag.flagParticipation.Validators,
+			string2eth.GWeiToString(uint64(flag.flagParticipation.EffectiveBalance), true),
+			percentage(flag.flagParticipation.EffectiveBalance, participation.ActiveEffectiveBalance),
+		))
+	}
+
+	return builder.String()
+}
+
+func percentage(value phase0.Gwei, total phase0.Gwei) string {
+	if total == 0 {
+		return "0.00%"
+	}
+	return fmt.Sprintf("%0.2f%%", 100.0*float64(value)/float64(total))
+}
@@ -0,0 +1,87 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package statesummary
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestOutput(t *testing.T) {
+	c := &command{
+		slotsPerEpoch: 32,
+		summary:       &stateSummary{},
+	}
+	require.NoError(t, c.summarise(testAltairState()))
+
+	tests := []struct {
+		name    string
+		command *command
+		res     string
+	}{
+		{
+			name: "Quiet",
+			command: &command{
+				quiet:   true,
+				summary: c.summary,
+			},
+		},
+		{
+			name: "Text",
+			command: &command{
+				summary: c.summary,
+			},
+			res: `Version: altair
+Slot: 321
+Epoch: 10
+Validators: 4
+  Pending_queued: 1
+  Active_ongoing: 2
+  Active_slashed: 1
+Total balance: 127 Ether
+Total effective balance: 128 Ether
+Active effective balance: 96 Ether
+Previous epoch (9) participation:
+  Timely source: 2 validators, 64 Ether (66.67%)
+  Timely target: 2 validators, 64 Ether (66.67%)
+  Timely head: 1 validators, 32 Ether (33.33%)
+Current epoch (10) participation:
+  Timely source: 1 validators, 32 Ether (33.33%)
+  Timely target: 0 validators, 0 (0.00%)
+  Timely head: 0 validators, 0 (0.00%)
+Justification bits: 1100
+Slashed validators: 1
+Slashings: 1 Ether
+RANDAO mix: 0x02`,
+		},
+		{
+			name: "JSON",
+			command: &command{
+				format:  "json",
+				summary: c.summary,
+			},
+			res: `{"version":"altair","slot":321,"epoch":10,"validators":4,"validator_statuses":{"Active_ongoing":2,"Active_slashed":1,"Pending_queued":1},"total_balance":127000000000,"total_effective_balance":128000000000,"active_effective_balance":96000000000,"previous_epoch_participation":{"epoch":9,"active_effective_balance":96000000000,"timely_source":{"validators":2,"effective_balance":64000000000},"timely_target":{"validators":2,"effective_balance":64000000000},"timely_head":{"validators":1,"effective_balance":32000000000}},"current_epoch_participation":{"epoch":10,"active_effective_balance":96000000000,"timely_source":{"validators":1,"effective_balance":32000000000},"timely_target":{"validators":0,"effective_balance":0},"timely_head":{"validators":0,"effective_balance":0}},"justification_bits":"1100","slashed_validators":1,"slashings":1000000000,"randao_mix":"0x02"}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			res, err := test.command.output(context.Background())
+			require.NoError(t, err)
+			require.Equal(t, test.res, res)
+		})
+	}
+}
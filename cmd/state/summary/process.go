@@ -0,0 +1,210 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package statesummary
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/aaron-alderman/ethdo/util"
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/altair"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+)
+
+func (c *command) process(ctx context.Context) error {
+	// Obtain information we need to process.
+	if err := c.setup(ctx); err != nil {
+		return err
+	}
+
+	state, err := util.ObtainBeaconState(ctx, c.eth2Client, c.stateID, c.file)
+	if err != nil {
+		return err
+	}
+	if c.debug {
+		fmt.Fprintf(os.Stderr, "State version is %s\n", state.Version)
+	}
+	if c.networkConfig != nil {
+		if err := c.networkConfig.VerifyBeaconState(state); err != nil {
+			return util.NewCodedError(util.ErrorCodeVerificationFailed, err)
+		}
+	}
+
+	return c.summarise(state)
+}
+
+func (c *command) setup(ctx context.Context) error {
+	chainInfo := util.DefaultChainInfo()
+	if c.network != "" {
+		var err error
+		c.networkConfig, err = util.NetworkConfigByName(c.network)
+		if err != nil {
+			return err
+		}
+		chainInfo = c.networkConfig.ChainInfo()
+	}
+	c.slotsPerEpoch = chainInfo.SlotsPerEpoch
+
+	// A state from a file only uses a beacon node to add information if one is explicitly supplied.
+	if c.connection == "" {
+		return nil
+	}
+
+	var err error
+	c.eth2Client, err = util.ConnectToBeaconNode(ctx, c.connection, c.timeout, c.allowInsecureConnections)
+	if err != nil {
+		return errors.Wrap(err, "failed to connect to beacon node")
+	}
+
+	specProvider, isProvider := c.eth2Client.(eth2client.SpecProvider)
+	if !isProvider {
+		return errors.New("connection does not provide spec information")
+	}
+	spec, err := specProvider.Spec(ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain spec information")
+	}
+	c.slotsPerEpoch, err = util.SpecUint64(spec, "SLOTS_PER_EPOCH")
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (c *command) summarise(state *spec.VersionedBeaconState) error {
+	slot, err := util.BeaconStateSlot(state)
+	if err != nil {
+		return err
+	}
+	validators, err := util.BeaconStateValidators(state)
+	if err != nil {
+		return err
+	}
+	balances, err := util.BeaconStateBalances(state)
+	if err != nil {
+		return err
+	}
+	if len(balances) != len(validators) {
+		return fmt.Errorf("state has %d validators but %d balances", len(validators), len(balances))
+	}
+
+	c.summary.Version = strings.ToLower(state.Version.String())
+	c.summary.Slot = slot
+	c.summary.Epoch = phase0.Epoch(uint64(slot) / c.slotsPerEpoch)
+	c.summary.Validators = len(validators)
+	c.summary.ValidatorStatuses = make(map[string]int)
+	for i, validator := range validators {
+		status := util.ValidatorStateAt(validator, balances[i], c.summary.Epoch)
+		c.summary.ValidatorStatuses[status.String()]++
+		c.summary.TotalBalance += phase0.Gwei(balances[i])
+		c.summary.TotalEffectiveBalance += validator.EffectiveBalance
+		if status.IsActive() {
+			c.summary.ActiveEffectiveBalance += validator.EffectiveBalance
+		}
+		if validator.Slashed {
+			c.summary.SlashedValidators++
+		}
+	}
+
+	previousEpochParticipation, currentEpochParticipation, err := util.BeaconStateParticipation(state)
+	if err != nil {
+		return err
+	}
+	if previousEpochParticipation != nil {
+		previousEpoch := c.summary.Epoch
+		if previousEpoch > 0 {
+			previousEpoch--
+		}
+		c.summary.PreviousEpochParticipation, err = participation(validators, previousEpochParticipation, previousEpoch)
+		if err != nil {
+			return errors.Wrap(err, "invalid previous epoch participation")
+		}
+		c.summary.CurrentEpochParticipation, err = participation(validators, currentEpochParticipation, c.summary.Epoch)
+		if err != nil {
+			return errors.Wrap(err, "invalid current epoch participation")
+		}
+	}
+
+	justificationBits, err := util.BeaconStateJustificationBits(state)
+	if err != nil {
+		return err
+	}
+	for i := uint64(0); i < justificationBits.Len(); i++ {
+		if justificationBits.BitAt(i) {
+			c.summary.JustificationBits += "1"
+		} else {
+			c.summary.JustificationBits += "0"
+		}
+	}
+
+	slashings, err := util.BeaconStateSlashings(state)
+	if err != nil {
+		return err
+	}
+	for _, slashing := range slashings {
+		c.summary.Slashings += phase0.Gwei(slashing)
+	}
+
+	randaoMixes, err := util.BeaconStateRANDAOMixes(state)
+	if err != nil {
+		return err
+	}
+	if len(randaoMixes) > 0 {
+		c.summary.RANDAOMix = fmt.Sprintf("%#x", randaoMixes[uint64(c.summary.Epoch)%uint64(len(randaoMixes))])
+	}
+
+	return nil
+}
+
+// participation calculates the participation of unslashed active validators in the given epoch.
+func participation(validators []*phase0.Validator, flags []altair.ParticipationFlags, epoch phase0.Epoch) (*epochParticipation, error) {
+	if len(flags) != len(validators) {
+		return nil, fmt.Errorf("%d participation flags for %d validators", len(flags), len(validators))
+	}
+
+	res := &epochParticipation{
+		Epoch:        epoch,
+		TimelySource: &flagParticipation{},
+		TimelyTarget: &flagParticipation{},
+		TimelyHead:   &flagParticipation{},
+	}
+	flagParticipations := map[altair.ParticipationFlag]*flagParticipation{
+		altair.TimelySourceFlagIndex: res.TimelySource,
+		altair.TimelyTargetFlagIndex: res.TimelyTarget,
+		altair.TimelyHeadFlagIndex:   res.TimelyHead,
+	}
+	for i, validator := range validators {
+		if validator.ActivationEpoch > epoch || validator.ExitEpoch <= epoch {
+			continue
+		}
+		res.ActiveEffectiveBalance += validator.EffectiveBalance
+		if validator.Slashed {
+			continue
+		}
+		for flag, flagParticipation := range flagParticipations {
+			if flags[i]&(1<<flag) != 0 {
+				flagParticipation.Validators++
+				flagParticipation.EffectiveBalance += validator.EffectiveBalance
+			}
+		}
+	}
+
+	return res, nil
+}
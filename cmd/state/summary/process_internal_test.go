@@ -0,0 +1,165 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package statesummary
+
+import (
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/altair"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+)
+
+const farFutureEpoch = phase0.Epoch(0xffffffffffffffff)
+
+func testValidator(activationEpoch phase0.Epoch, exitEpoch phase0.Epoch, slashed bool) *phase0.Validator {
+	return &phase0.Validator{
+		WithdrawalCredentials:      make([]byte, 32),
+		EffectiveBalance:           32000000000,
+		Slashed:                    slashed,
+		ActivationEligibilityEpoch: 0,
+		ActivationEpoch:            activationEpoch,
+		ExitEpoch:                  exitEpoch,
+		WithdrawableEpoch:          farFutureEpoch,
+	}
+}
+
+func testAltairState() *spec.VersionedBeaconState {
+	randaoMixes := make([][]byte, 4)
+	for i := range randaoMixes {
+		randaoMixes[i] = []byte{byte(i)}
+	}
+	return &spec.VersionedBeaconState{
+		Version: spec.DataVersionAltair,
+		Altair: &altair.BeaconState{
+			Slot: 321,
+			Validators: []*phase0.Validator{
+				testValidator(0, farFutureEpoch, false),
+				testValidator(0, farFutureEpoch, false),
+				testValidator(0, 12, true),
+				testValidator(farFutureEpoch, farFutureEpoch, false),
+			},
+			Balances:                   []uint64{32000000100, 31999999900, 31000000000, 32000000000},
+			PreviousEpochParticipation: []altair.ParticipationFlags{0x07, 0x03, 0x07, 0x00},
+			CurrentEpochParticipation:  []altair.ParticipationFlags{0x01, 0x00, 0x07, 0x00},
+			JustificationBits:          []byte{0x03},
+			Slashings:                  []uint64{0, 1000000000},
+			RANDAOMixes:                randaoMixes,
+		},
+	}
+}
+
+func TestSummarise(t *testing.T) {
+	phase0State := &spec.VersionedBeaconState{
+		Version: spec.DataVersionPhase0,
+		Phase0: &phase0.BeaconState{
+			Slot:              64,
+			Validators:        []*phase0.Validator{testValidator(0, farFutureEpoch, false)},
+			Balances:          []uint64{32000000000},
+			JustificationBits: []byte{0x00},
+			Slashings:         []uint64{},
+			RANDAOMixes:       [][]byte{},
+		},
+	}
+	mismatchState := testAltairState()
+	mismatchState.Altair.Balances = mismatchState.Altair.Balances[1:]
+	badParticipationState := testAltairState()
+	badParticipationState.Altair.CurrentEpochParticipation = badParticipationState.Altair.CurrentEpochParticipation[1:]
+
+	tests := []struct {
+		name    string
+		state   *spec.VersionedBeaconState
+		summary *stateSummary
+		err     string
+	}{
+		{
+			name:  "Mismatch",
+			state: mismatchState,
+			err:   "state has 4 validators but 3 balances",
+		},
+		{
+			name:  "BadParticipation",
+			state: badParticipationState,
+			err:   "invalid current epoch participation: 3 participation flags for 4 validators",
+		},
+		{
+			name:  "Phase0",
+			state: phase0State,
+			summary: &stateSummary{
+				Version:                "phase0",
+				Slot:                   64,
+				Epoch:                  2,
+				Validators:             1,
+				ValidatorStatuses:      map[string]int{"Active_ongoing": 1},
+				TotalBalance:           32000000000,
+				TotalEffectiveBalance:  32000000000,
+				ActiveEffectiveBalance: 32000000000,
+				JustificationBits:      "0000",
+			},
+		},
+		{
+			name:  "Altair",
+			state: testAltairState(),
+			summary: &stateSummary{
+				Version:    "altair",
+				Slot:       321,
+				Epoch:      10,
+				Validators: 4,
+				ValidatorStatuses: map[string]int{
+					"Active_ongoing": 2,
+					"Active_slashed": 1,
+					"Pending_queued": 1,
+				},
+				TotalBalance:           127000000000,
+				TotalEffectiveBalance:  128000000000,
+				ActiveEffectiveBalance: 96000000000,
+				PreviousEpochParticipation: &epochParticipation{
+					Epoch:                  9,
+					ActiveEffectiveBalance: 96000000000,
+					TimelySource:           &flagParticipation{Validators: 2, EffectiveBalance: 64000000000},
+					TimelyTarget:           &flagParticipation{Validators: 2, EffectiveBalance: 64000000000},
+					TimelyHead:             &flagParticipation{Validators: 1, EffectiveBalance: 32000000000},
+				},
+				CurrentEpochParticipation: &epochParticipation{
+					Epoch:                  10,
+					ActiveEffectiveBalance: 96000000000,
+					TimelySource:           &flagParticipation{Validators: 1, EffectiveBalance: 32000000000},
+					TimelyTarget:           &flagParticipation{},
+					TimelyHead:             &flagParticipation{},
+				},
+				JustificationBits: "1100",
+				SlashedValidators: 1,
+				Slashings:         1000000000,
+				RANDAOMix:         "0x02",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := &command{
+				slotsPerEpoch: 32,
+				summary:       &stateSummary{},
+			}
+			err := c.summarise(test.state)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, test.summary, c.summary)
+			}
+		})
+	}
+}
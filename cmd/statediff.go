@@ -0,0 +1,79 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	statediff "github.com/aaron-alderman/ethdo/cmd/state/diff"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var stateDiffCmd = &cobra.Command{
+	Use:   "diff",
+	Short: "Compare two beacon states",
+	Long: `Compare two beacon states, showing changes in the balance and status of each validator along with aggregate totals.  For example:
+
+    ethdo state diff --from=finalized --to=head
+
+Either state can be read from a file containing a state previously written out in JSON or SSZ format with --from-file or --to-file.  The to state defaults to head, so a connection is required unless both states are read from files.  With --network both states are checked against the network; otherwise they are checked to be from the same chain.
+
+In quiet mode this will return 0 if the states can be obtained, otherwise 1.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		res, err := statediff.Run(cmd)
+		if err != nil {
+			return err
+		}
+		if viper.GetBool("quiet") {
+			return nil
+		}
+		if res != "" {
+			fmt.Println(res)
+		}
+		return nil
+	},
+}
+
+func init() {
+	stateCmd.AddCommand(stateDiffCmd)
+	stateFlags(stateDiffCmd)
+	stateDiffCmd.Flags().String("from", "", "the ID of the state from which to compare")
+	stateDiffCmd.Flags().String("from-file", "", "the name of a file containing a JSON or SSZ state from which to compare")
+	stateDiffCmd.Flags().String("to", "", "the ID of the state to which to compare (default head)")
+	stateDiffCmd.Flags().String("to-file", "", "the name of a file containing a JSON or SSZ state to which to compare")
+	stateDiffCmd.Flags().Bool("json", false, "output data in JSON format")
+	stateDiffCmd.Flags().String("network", "", "network whose chain parameters are used without a beacon node, and against which the state is checked")
+}
+
+func stateDiffBindings() {
+	if err := viper.BindPFlag("from", stateDiffCmd.Flags().Lookup("from")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("from-file", stateDiffCmd.Flags().Lookup("from-file")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("to", stateDiffCmd.Flags().Lookup("to")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("to-file", stateDiffCmd.Flags().Lookup("to-file")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("json", stateDiffCmd.Flags().Lookup("json")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("network", stateDiffCmd.Flags().Lookup("network")); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,71 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	stateinfo "github.com/aaron-alderman/ethdo/cmd/state/info"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var stateInfoCmd = &cobra.Command{
+	Use:   "info",
+	Short: "Obtain information about a beacon state",
+	Long: `Obtain information about a beacon state.  For example:
+
+    ethdo state info --stateid=finalized
+
+A state previously written out in JSON or SSZ format can be read instead with --file, in which case a beacon node is only used if --connection is supplied.  SSZ states must use the mainnet preset.
+
+In quiet mode this will return 0 if the state can be obtained, otherwise 1.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		res, err := stateinfo.Run(cmd)
+		if err != nil {
+			return err
+		}
+		if viper.GetBool("quiet") {
+			return nil
+		}
+		if res != "" {
+			fmt.Println(res)
+		}
+		return nil
+	},
+}
+
+func init() {
+	stateCmd.AddCommand(stateInfoCmd)
+	stateFlags(stateInfoCmd)
+	stateInfoCmd.Flags().String("stateid", "head", "the ID of the state to fetch")
+	stateInfoCmd.Flags().String("file", "", "the name of a file containing a JSON or SSZ state to decode instead of fetching one from a beacon node")
+	stateInfoCmd.Flags().Bool("json", false, "output data in JSON format")
+	stateInfoCmd.Flags().String("network", "", "network whose chain parameters are used without a beacon node, and against which the state is checked")
+}
+
+func stateInfoBindings() {
+	if err := viper.BindPFlag("stateid", stateInfoCmd.Flags().Lookup("stateid")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("file", stateInfoCmd.Flags().Lookup("file")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("json", stateInfoCmd.Flags().Lookup("json")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("network", stateInfoCmd.Flags().Lookup("network")); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,131 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package duties
+
+import (
+	"context"
+	"time"
+
+	"github.com/aaron-alderman/ethdo/services/chaintime"
+	"github.com/aaron-alderman/ethdo/util"
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	e2wtypes "github.com/wealdtech/go-eth2-wallet-types/v2"
+)
+
+type command struct {
+	quiet   bool
+	verbose bool
+	debug   bool
+
+	// Beacon node connection.
+	timeout                  time.Duration
+	connection               string
+	allowInsecureConnections bool
+
+	// Input.
+	validators      []string
+	accounts        string
+	aggregatorSlots uint64
+	format          string
+
+	// Data access.
+	eth2Client             eth2client.Service
+	chainTime              chaintime.Service
+	validatorsProvider     eth2client.ValidatorsProvider
+	syncCommitteesProvider eth2client.SyncCommitteesProvider
+	domainProvider         eth2client.DomainProvider
+
+	// Chain parameters.
+	syncCommitteeSize                    uint64
+	syncCommitteeSubnetCount             uint64
+	targetAggregatorsPerSyncSubcommittee uint64
+	selectionProofDomainType             phase0.DomainType
+
+	// Processing.
+	validatorPubKeys map[phase0.ValidatorIndex]phase0.BLSPubKey
+	signingAccounts  map[phase0.BLSPubKey]e2wtypes.Account
+
+	// Results.
+	periods []*periodDuties
+}
+
+// periodDuties are the sync committee duties of the validators for a single sync committee period.
+type periodDuties struct {
+	Period     uint64           `json:"period"`
+	StartEpoch phase0.Epoch     `json:"start_epoch"`
+	EndEpoch   phase0.Epoch     `json:"end_epoch"`
+	StartTime  time.Time        `json:"start_time"`
+	EndTime    time.Time        `json:"end_time"`
+	Duties     []*validatorDuty `json:"duties"`
+}
+
+// validatorDuty is the sync committee duty of a single validator.
+type validatorDuty struct {
+	Index     phase0.ValidatorIndex `json:"index"`
+	PubKey    string                `json:"pubkey"`
+	Positions []*committeePosition  `json:"positions"`
+	// AggregationChecked is true if the validator's account was available to calculate
+	// its aggregator selection proofs.
+	AggregationChecked bool           `json:"aggregation_checked"`
+	Aggregations       []*aggregation `json:"aggregations,omitempty"`
+}
+
+// committeePosition is a position of a validator in the sync committee.  A validator can
+// hold more than one position in the same sync committee.
+type committeePosition struct {
+	CommitteeIndex       uint64 `json:"committee_index"`
+	SubcommitteeIndex    uint64 `json:"subcommittee_index"`
+	SubcommitteePosition uint64 `json:"subcommittee_position"`
+}
+
+// aggregation is a slot at which a validator is selected as an aggregator for a subcommittee.
+type aggregation struct {
+	Slot              phase0.Slot `json:"slot"`
+	SubcommitteeIndex uint64      `json:"subcommittee_index"`
+}
+
+func newCommand(ctx context.Context) (*command, error) {
+	c := &command{
+		quiet:   viper.GetBool("quiet"),
+		verbose: viper.GetBool("verbose"),
+		debug:   viper.GetBool("debug"),
+	}
+
+	// Timeout.
+	if viper.GetDuration("timeout") == 0 {
+		return nil, errors.New("timeout is required")
+	}
+	c.timeout = viper.GetDuration("timeout")
+
+	if viper.GetString("connection") == "" {
+		return nil, errors.New("connection is required")
+	}
+	c.connection = viper.GetString("connection")
+	c.allowInsecureConnections = viper.GetBool("allow-insecure-connections")
+
+	c.validators = viper.GetStringSlice("validators")
+	c.accounts = viper.GetString("accounts")
+	if len(c.validators) == 0 && c.accounts == "" {
+		return nil, errors.New("validators or accounts is required")
+	}
+
+	c.aggregatorSlots = viper.GetUint64("aggregator-slots")
+
+	c.format = util.OutputFormat()
+
+	return c, nil
+}
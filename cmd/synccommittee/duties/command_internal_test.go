@@ -0,0 +1,91 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package duties
+
+import (
+	"context"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInput(t *testing.T) {
+	tests := []struct {
+		name            string
+		vars            map[string]interface{}
+		aggregatorSlots uint64
+		err             string
+	}{
+		{
+			name: "TimeoutMissing",
+			vars: map[string]interface{}{
+				"connection": "http://localhost:5051",
+				"validators": []string{"1"},
+			},
+			err: "timeout is required",
+		},
+		{
+			name: "ConnectionMissing",
+			vars: map[string]interface{}{
+				"timeout":    "5s",
+				"validators": []string{"1"},
+			},
+			err: "connection is required",
+		},
+		{
+			name: "ValidatorsMissing",
+			vars: map[string]interface{}{
+				"timeout":    "5s",
+				"connection": "http://localhost:5051",
+			},
+			err: "validators or accounts is required",
+		},
+		{
+			name: "Validators",
+			vars: map[string]interface{}{
+				"timeout":    "5s",
+				"connection": "http://localhost:5051",
+				"validators": []string{"1", "2"},
+			},
+		},
+		{
+			name: "Accounts",
+			vars: map[string]interface{}{
+				"timeout":          "5s",
+				"connection":       "http://localhost:5051",
+				"accounts":         "Test wallet",
+				"aggregator-slots": "64",
+			},
+			aggregatorSlots: 64,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			viper.Reset()
+
+			for k, v := range test.vars {
+				viper.Set(k, v)
+			}
+			c, err := newCommand(context.Background())
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, test.aggregatorSlots, c.aggregatorSlots)
+			}
+		})
+	}
+}
@@ -0,0 +1,89 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package duties
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/aaron-alderman/ethdo/util"
+)
+
+func (c *command) output(ctx context.Context) (string, error) {
+	if c.quiet {
+		return "", nil
+	}
+
+	if util.StructuredFormat(c.format) {
+		return c.outputStructured(ctx)
+	}
+
+	return c.outputTxt(ctx)
+}
+
+func (c *command) outputStructured(_ context.Context) (string, error) {
+	data, err := json.Marshal(c.periods)
+	if err != nil {
+		return "", err
+	}
+	return util.FormatJSONData(c.format, data)
+}
+
+func (c *command) outputTxt(_ context.Context) (string, error) {
+	if len(c.periods) == 0 {
+		return "Sync committees are not yet active", nil
+	}
+
+	builder := strings.Builder{}
+	for _, period := range c.periods {
+		builder.WriteString(fmt.Sprintf("Sync committee period %d: epochs %d to %d, %s to %s\n",
+			period.Period,
+			period.StartEpoch,
+			period.EndEpoch,
+			period.StartTime.Format("2006-01-02 15:04:05"),
+			period.EndTime.Format("2006-01-02 15:04:05"),
+		))
+		if len(period.Duties) == 0 {
+			builder.WriteString("  No validators in the sync committee\n")
+			continue
+		}
+		for _, duty := range period.Duties {
+			positions := make([]string, 0, len(duty.Positions))
+			for _, position := range duty.Positions {
+				if c.verbose {
+					positions = append(positions, fmt.Sprintf("subcommittee %d position %d (committee index %d)", position.SubcommitteeIndex, position.SubcommitteePosition, position.CommitteeIndex))
+				} else {
+					positions = append(positions, fmt.Sprintf("subcommittee %d position %d", position.SubcommitteeIndex, position.SubcommitteePosition))
+				}
+			}
+			builder.WriteString(fmt.Sprintf("  Validator %d: %s\n", duty.Index, strings.Join(positions, ", ")))
+			if c.verbose {
+				builder.WriteString(fmt.Sprintf("    Public key: %s\n", duty.PubKey))
+			}
+			if !duty.AggregationChecked {
+				continue
+			}
+			if len(duty.Aggregations) == 0 {
+				builder.WriteString("    Not selected as an aggregator\n")
+			}
+			for _, aggregation := range duty.Aggregations {
+				builder.WriteString(fmt.Sprintf("    Aggregator for subcommittee %d at slot %d\n", aggregation.SubcommitteeIndex, aggregation.Slot))
+			}
+		}
+	}
+
+	return strings.TrimSuffix(builder.String(), "\n"), nil
+}
@@ -0,0 +1,137 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package duties
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestOutput(t *testing.T) {
+	periods := []*periodDuties{
+		{
+			Period:     143,
+			StartEpoch: 36608,
+			EndEpoch:   36863,
+			StartTime:  time.Unix(1655973431, 0).UTC(),
+			EndTime:    time.Unix(1656071735, 0).UTC(),
+			Duties: []*validatorDuty{
+				{
+					Index:  1,
+					PubKey: "0x01",
+					Positions: []*committeePosition{
+						{CommitteeIndex: 17, SubcommitteeIndex: 0, SubcommitteePosition: 17},
+					},
+				},
+				{
+					Index:  2,
+					PubKey: "0x02",
+					Positions: []*committeePosition{
+						{CommitteeIndex: 131, SubcommitteeIndex: 1, SubcommitteePosition: 3},
+						{CommitteeIndex: 500, SubcommitteeIndex: 3, SubcommitteePosition: 116},
+					},
+					AggregationChecked: true,
+					Aggregations: []*aggregation{
+						{Slot: 1171460, SubcommitteeIndex: 3},
+					},
+				},
+				{
+					Index:  3,
+					PubKey: "0x03",
+					Positions: []*committeePosition{
+						{CommitteeIndex: 200, SubcommitteeIndex: 1, SubcommitteePosition: 72},
+					},
+					AggregationChecked: true,
+					Aggregations:       []*aggregation{},
+				},
+			},
+		},
+		{
+			Period:     144,
+			StartEpoch: 36864,
+			EndEpoch:   37119,
+			StartTime:  time.Unix(1656071735, 0).UTC(),
+			EndTime:    time.Unix(1656170039, 0).UTC(),
+			Duties:     []*validatorDuty{},
+		},
+	}
+
+	tests := []struct {
+		name    string
+		command *command
+		res     string
+	}{
+		{
+			name: "Quiet",
+			command: &command{
+				quiet:   true,
+				periods: periods,
+			},
+		},
+		{
+			name:    "PreAltair",
+			command: &command{},
+			res:     "Sync committees are not yet active",
+		},
+		{
+			name: "Text",
+			command: &command{
+				periods: periods,
+			},
+			res: `Sync committee period 143: epochs 36608 to 36863, 2022-06-23 08:37:11 to 2022-06-24 11:55:35
+  Validator 1: subcommittee 0 position 17
+  Validator 2: subcommittee 1 position 3, subcommittee 3 position 116
+    Aggregator for subcommittee 3 at slot 1171460
+  Validator 3: subcommittee 1 position 72
+    Not selected as an aggregator
+Sync committee period 144: epochs 36864 to 37119, 2022-06-24 11:55:35 to 2022-06-25 15:13:59
+  No validators in the sync committee`,
+		},
+		{
+			name: "Verbose",
+			command: &command{
+				verbose: true,
+				periods: periods[:1],
+			},
+			res: `Sync committee period 143: epochs 36608 to 36863, 2022-06-23 08:37:11 to 2022-06-24 11:55:35
+  Validator 1: subcommittee 0 position 17 (committee index 17)
+    Public key: 0x01
+  Validator 2: subcommittee 1 position 3 (committee index 131), subcommittee 3 position 116 (committee index 500)
+    Public key: 0x02
+    Aggregator for subcommittee 3 at slot 1171460
+  Validator 3: subcommittee 1 position 72 (committee index 200)
+    Public key: 0x03
+    Not selected as an aggregator`,
+		},
+		{
+			name: "JSON",
+			command: &command{
+				format:  "json",
+				periods: periods[1:],
+			},
+			res: `[{"period":144,"start_epoch":36864,"end_epoch":37119,"start_time":"2022-06-24T11:55:35Z","end_time":"2022-06-25T15:13:59Z","duties":[]}]`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			res, err := test.command.output(context.Background())
+			require.NoError(t, err)
+			require.Equal(t, test.res, res)
+		})
+	}
+}
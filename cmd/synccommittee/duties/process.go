@@ -0,0 +1,329 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package duties
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	standardchaintime "github.com/aaron-alderman/ethdo/services/chaintime/standard"
+	"github.com/aaron-alderman/ethdo/util"
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/spec/altair"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	e2wtypes "github.com/wealdtech/go-eth2-wallet-types/v2"
+)
+
+func (c *command) process(ctx context.Context) error {
+	// Obtain information we need to process.
+	if err := c.setup(ctx); err != nil {
+		return err
+	}
+
+	if err := c.obtainValidators(ctx); err != nil {
+		return err
+	}
+
+	// The beacon node can provide sync committees for the current and next periods.
+	currentPeriod := c.chainTime.CurrentSyncCommitteePeriod()
+	c.periods = make([]*periodDuties, 0, 2)
+	for period := currentPeriod; period <= currentPeriod+1; period++ {
+		if period < c.chainTime.AltairInitialSyncCommitteePeriod() {
+			// The period is pre-Altair.  No info but no error.
+			continue
+		}
+		committee, err := c.syncCommitteesProvider.SyncCommitteeAtEpoch(ctx, "head", c.chainTime.FirstEpochOfSyncPeriod(period))
+		if err != nil {
+			return errors.Wrap(err, fmt.Sprintf("failed to obtain sync committee for period %d", period))
+		}
+		if committee == nil {
+			return fmt.Errorf("no sync committee returned for period %d", period)
+		}
+		c.periods = append(c.periods, c.periodDuties(period, committee.Validators))
+	}
+
+	return c.checkAggregations(ctx)
+}
+
+// obtainValidators obtains the validators for which to report duties, along with any
+// accounts that can sign on their behalf.
+func (c *command) obtainValidators(ctx context.Context) error {
+	validators, err := util.ParseValidators(ctx, c.validatorsProvider, c.validators, c.accounts)
+	if err != nil {
+		return err
+	}
+	c.validatorPubKeys = make(map[phase0.ValidatorIndex]phase0.BLSPubKey, len(validators))
+	for index, validator := range validators {
+		c.validatorPubKeys[index] = validator.Validator.PublicKey
+	}
+
+	c.signingAccounts = make(map[phase0.BLSPubKey]e2wtypes.Account)
+	if c.accounts == "" || c.aggregatorSlots == 0 {
+		return nil
+	}
+	_, accounts, err := util.WalletAndAccountsFromPath(ctx, c.accounts)
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain accounts")
+	}
+	for _, account := range accounts {
+		if !canSign(ctx, account) {
+			continue
+		}
+		pubKey, err := util.BestPublicKey(account)
+		if err != nil {
+			return errors.Wrap(err, fmt.Sprintf("failed to obtain public key for account %s", account.Name()))
+		}
+		var blsPubKey phase0.BLSPubKey
+		copy(blsPubKey[:], pubKey.Marshal())
+		c.signingAccounts[blsPubKey] = account
+	}
+
+	return nil
+}
+
+// canSign returns true if the account can be used to sign without further user input.
+func canSign(ctx context.Context, account e2wtypes.Account) bool {
+	if _, isSigner := account.(e2wtypes.AccountSigner); !isSigner {
+		if _, isProtectingSigner := account.(e2wtypes.AccountProtectingSigner); !isProtectingSigner {
+			return false
+		}
+	}
+	locker, isLocker := account.(e2wtypes.AccountLocker)
+	if !isLocker || len(util.GetPassphrases()) > 0 {
+		return true
+	}
+	unlocked, err := locker.IsUnlocked(ctx)
+	return err == nil && unlocked
+}
+
+// periodDuties calculates the duties of our validators in the given sync committee.
+func (c *command) periodDuties(period uint64, committee []phase0.ValidatorIndex) *periodDuties {
+	startEpoch := c.chainTime.FirstEpochOfSyncPeriod(period)
+	nextStartEpoch := c.chainTime.FirstEpochOfSyncPeriod(period + 1)
+	// Sync committee messages at a slot are for the committee of the following slot, so
+	// the duty runs from the slot before the period starts to the slot before it ends.
+	startSlot := c.chainTime.FirstSlotOfEpoch(startEpoch)
+	if startSlot > 0 {
+		startSlot--
+	}
+	res := &periodDuties{
+		Period:     period,
+		StartEpoch: startEpoch,
+		EndEpoch:   nextStartEpoch - 1,
+		StartTime:  c.chainTime.StartOfSlot(startSlot),
+		EndTime:    c.chainTime.StartOfSlot(c.chainTime.FirstSlotOfEpoch(nextStartEpoch) - 1),
+		Duties:     make([]*validatorDuty, 0),
+	}
+
+	subcommitteeSize := uint64(len(committee))
+	if c.syncCommitteeSubnetCount > 0 && uint64(len(committee)) >= c.syncCommitteeSubnetCount {
+		subcommitteeSize = uint64(len(committee)) / c.syncCommitteeSubnetCount
+	}
+
+	// A validator can appear in the sync committee more than once.
+	duties := make(map[phase0.ValidatorIndex]*validatorDuty)
+	for i, index := range committee {
+		pubKey, exists := c.validatorPubKeys[index]
+		if !exists {
+			continue
+		}
+		duty, exists := duties[index]
+		if !exists {
+			duty = &validatorDuty{
+				Index:     index,
+				PubKey:    fmt.Sprintf("%#x", pubKey),
+				Positions: make([]*committeePosition, 0, 1),
+			}
+			duties[index] = duty
+			res.Duties = append(res.Duties, duty)
+		}
+		duty.Positions = append(duty.Positions, &committeePosition{
+			CommitteeIndex:       uint64(i),
+			SubcommitteeIndex:    uint64(i) / subcommitteeSize,
+			SubcommitteePosition: uint64(i) % subcommitteeSize,
+		})
+	}
+	sort.Slice(res.Duties, func(i int, j int) bool {
+		return res.Duties[i].Index < res.Duties[j].Index
+	})
+
+	return res
+}
+
+// checkAggregations calculates the aggregator selection of validators for which we hold
+// accounts, from the current slot for the requested number of slots.
+func (c *command) checkAggregations(ctx context.Context) error {
+	if c.aggregatorSlots == 0 || len(c.signingAccounts) == 0 {
+		return nil
+	}
+
+	firstSlot := c.chainTime.CurrentSlot()
+	lastSlot := firstSlot + phase0.Slot(c.aggregatorSlots) - 1
+	// Selection proofs are recorded in the audit log as a single summary.
+	selectionProofs := 0
+	signers := make([][]byte, 0)
+	seenSigners := make(map[phase0.ValidatorIndex]bool)
+	for _, period := range c.periods {
+		for _, duty := range period.Duties {
+			account, exists := c.signingAccounts[c.validatorPubKeys[duty.Index]]
+			if !exists {
+				continue
+			}
+			duty.AggregationChecked = true
+			if !seenSigners[duty.Index] {
+				seenSigners[duty.Index] = true
+				signers = append(signers, util.AccountPublicKey(account))
+			}
+			duty.Aggregations = make([]*aggregation, 0)
+			subcommittees := subcommitteeIndices(duty.Positions)
+			for slot := firstSlot; slot <= lastSlot; slot++ {
+				// The committee that signs at a slot is that of the following slot.
+				if c.chainTime.SlotToSyncCommitteePeriod(slot+1) != period.Period {
+					continue
+				}
+				for _, subcommitteeIndex := range subcommittees {
+					isAggregator, err := c.isAggregator(ctx, account, slot, subcommitteeIndex)
+					if err != nil {
+						return errors.Wrap(err, fmt.Sprintf("failed to calculate aggregator selection for validator %d", duty.Index))
+					}
+					selectionProofs++
+					if isAggregator {
+						duty.Aggregations = append(duty.Aggregations, &aggregation{
+							Slot:              slot,
+							SubcommitteeIndex: subcommitteeIndex,
+						})
+					}
+				}
+			}
+		}
+	}
+
+	if selectionProofs > 0 {
+		if err := util.AuditSigningSummary(fmt.Sprintf("%d sync committee selection proofs for slots %d to %d", selectionProofs, firstSlot, lastSlot), signers...); err != nil {
+			return errors.Wrap(err, "failed to record signing in audit log")
+		}
+	}
+
+	return nil
+}
+
+// subcommitteeIndices returns the distinct subcommittees of the given positions.
+func subcommitteeIndices(positions []*committeePosition) []uint64 {
+	res := make([]uint64, 0, len(positions))
+	seen := make(map[uint64]bool)
+	for _, position := range positions {
+		if !seen[position.SubcommitteeIndex] {
+			seen[position.SubcommitteeIndex] = true
+			res = append(res, position.SubcommitteeIndex)
+		}
+	}
+	return res
+}
+
+// isAggregator returns true if the account is selected as an aggregator for the subcommittee at the slot.
+func (c *command) isAggregator(ctx context.Context,
+	account e2wtypes.Account,
+	slot phase0.Slot,
+	subcommitteeIndex uint64,
+) (
+	bool,
+	error,
+) {
+	selectionData := &altair.SyncAggregatorSelectionData{
+		Slot:              slot,
+		SubcommitteeIndex: subcommitteeIndex,
+	}
+	root, err := selectionData.HashTreeRoot()
+	if err != nil {
+		return false, errors.Wrap(err, "failed to obtain selection data root")
+	}
+	domain, err := c.domainProvider.Domain(ctx, c.selectionProofDomainType, c.chainTime.SlotToEpoch(slot))
+	if err != nil {
+		return false, errors.Wrap(err, "failed to obtain domain")
+	}
+	signature, err := util.SignRootUnaudited(account, root, domain)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to sign selection data")
+	}
+	var selectionProof phase0.BLSSignature
+	copy(selectionProof[:], signature.Marshal())
+
+	return util.IsSyncCommitteeAggregator(c.syncCommitteeSize,
+		c.syncCommitteeSubnetCount,
+		c.targetAggregatorsPerSyncSubcommittee,
+		selectionProof,
+	), nil
+}
+
+func (c *command) setup(ctx context.Context) error {
+	var err error
+
+	// Connect to the client.
+	c.eth2Client, err = util.ConnectToBeaconNode(ctx, c.connection, c.timeout, c.allowInsecureConnections)
+	if err != nil {
+		return errors.Wrap(err, "failed to connect to beacon node")
+	}
+
+	c.chainTime, err = standardchaintime.New(ctx,
+		standardchaintime.WithSpecProvider(c.eth2Client.(eth2client.SpecProvider)),
+		standardchaintime.WithForkScheduleProvider(c.eth2Client.(eth2client.ForkScheduleProvider)),
+		standardchaintime.WithGenesisTimeProvider(c.eth2Client.(eth2client.GenesisTimeProvider)),
+	)
+	if err != nil {
+		return errors.Wrap(err, "failed to set up chaintime service")
+	}
+
+	var isProvider bool
+	c.validatorsProvider, isProvider = c.eth2Client.(eth2client.ValidatorsProvider)
+	if !isProvider {
+		return errors.New("connection does not provide validators")
+	}
+	c.syncCommitteesProvider, isProvider = c.eth2Client.(eth2client.SyncCommitteesProvider)
+	if !isProvider {
+		return errors.New("connection does not provide sync committees")
+	}
+	c.domainProvider, isProvider = c.eth2Client.(eth2client.DomainProvider)
+	if !isProvider {
+		return errors.New("connection does not provide domain information")
+	}
+
+	spec, err := c.eth2Client.(eth2client.SpecProvider).Spec(ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain spec information")
+	}
+	if c.chainTime.AltairInitialSyncCommitteePeriod() > c.chainTime.CurrentSyncCommitteePeriod()+1 {
+		// Sync committee parameters are not required before Altair.
+		return nil
+	}
+	c.syncCommitteeSize, err = util.SpecUint64(spec, "SYNC_COMMITTEE_SIZE")
+	if err != nil {
+		return err
+	}
+	c.syncCommitteeSubnetCount, err = util.SpecUint64(spec, "SYNC_COMMITTEE_SUBNET_COUNT")
+	if err != nil {
+		return err
+	}
+	c.targetAggregatorsPerSyncSubcommittee, err = util.SpecUint64(spec, "TARGET_AGGREGATORS_PER_SYNC_SUBCOMMITTEE")
+	if err != nil {
+		return err
+	}
+	c.selectionProofDomainType, err = util.SpecDomainType(spec, "DOMAIN_SYNC_COMMITTEE_SELECTION_PROOF")
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,191 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package duties
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/aaron-alderman/ethdo/services/chaintime"
+	standardchaintime "github.com/aaron-alderman/ethdo/services/chaintime/standard"
+	"github.com/aaron-alderman/ethdo/testing/mock"
+	"github.com/aaron-alderman/ethdo/testutil"
+	"github.com/aaron-alderman/ethdo/util"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+	e2types "github.com/wealdtech/go-eth2-types/v2"
+	e2wtypes "github.com/wealdtech/go-eth2-wallet-types/v2"
+)
+
+// domainProvider is a mock domain provider.
+type domainProvider struct{}
+
+func (d *domainProvider) Domain(ctx context.Context, domainType phase0.DomainType, epoch phase0.Epoch) (phase0.Domain, error) {
+	return phase0.Domain{domainType[0], domainType[1], domainType[2], domainType[3]}, nil
+}
+
+// testChainTime creates a chaintime service with 12 second slots, 32 slots per epoch,
+// 4 epochs per sync committee period and Altair from genesis.
+func testChainTime(t *testing.T, genesisTime time.Time) chaintime.Service {
+	chainTime, err := standardchaintime.New(context.Background(),
+		standardchaintime.WithGenesisTimeProvider(mock.NewGenesisTimeProvider(genesisTime)),
+		standardchaintime.WithSpecProvider(mock.NewSpecProvider(12*time.Second, 32, 4)),
+		standardchaintime.WithForkScheduleProvider(mock.NewForkScheduleProvider([]*phase0.Fork{
+			{
+				PreviousVersion: phase0.Version{0x00, 0x00, 0x00, 0x00},
+				CurrentVersion:  phase0.Version{0x00, 0x00, 0x00, 0x00},
+				Epoch:           0,
+			},
+			{
+				PreviousVersion: phase0.Version{0x00, 0x00, 0x00, 0x00},
+				CurrentVersion:  phase0.Version{0x01, 0x00, 0x00, 0x00},
+				Epoch:           0,
+			},
+		})),
+	)
+	require.NoError(t, err)
+	return chainTime
+}
+
+func TestPeriodDuties(t *testing.T) {
+	genesisTime := time.Unix(1606824023, 0).UTC()
+	c := &command{
+		chainTime:                testChainTime(t, genesisTime),
+		syncCommitteeSubnetCount: 4,
+		validatorPubKeys: map[phase0.ValidatorIndex]phase0.BLSPubKey{
+			3:  {0x03},
+			10: {0x0a},
+		},
+	}
+
+	tests := []struct {
+		name      string
+		period    uint64
+		committee []phase0.ValidatorIndex
+		duties    []*validatorDuty
+	}{
+		{
+			name:      "None",
+			period:    2,
+			committee: []phase0.ValidatorIndex{1, 2, 4, 5, 6, 7, 8, 9},
+			duties:    []*validatorDuty{},
+		},
+		{
+			name:      "Good",
+			period:    2,
+			committee: []phase0.ValidatorIndex{1, 10, 2, 4, 5, 3, 10, 9},
+			duties: []*validatorDuty{
+				{
+					Index:  3,
+					PubKey: "0x030000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000",
+					Positions: []*committeePosition{
+						{CommitteeIndex: 5, SubcommitteeIndex: 2, SubcommitteePosition: 1},
+					},
+				},
+				{
+					Index:  10,
+					PubKey: "0x0a0000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000",
+					Positions: []*committeePosition{
+						{CommitteeIndex: 1, SubcommitteeIndex: 0, SubcommitteePosition: 1},
+						{CommitteeIndex: 6, SubcommitteeIndex: 3, SubcommitteePosition: 0},
+					},
+				},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			res := c.periodDuties(test.period, test.committee)
+			require.Equal(t, test.period, res.Period)
+			require.Equal(t, phase0.Epoch(8), res.StartEpoch)
+			require.Equal(t, phase0.Epoch(11), res.EndEpoch)
+			// The duty starts and ends one slot before the period.
+			require.Equal(t, genesisTime.Add((8*32-1)*12*time.Second), res.StartTime)
+			require.Equal(t, genesisTime.Add((12*32-1)*12*time.Second), res.EndTime)
+			require.Equal(t, test.duties, res.Duties)
+		})
+	}
+}
+
+func TestCheckAggregations(t *testing.T) {
+	require.NoError(t, e2types.InitBLS())
+	ctx := context.Background()
+
+	account, err := util.NewScratchAccount(testutil.HexToBytes("0x25295f0d1d592a90b333e26e85149708208e9f8e8bc18f6c77bd62f8ad7a6866"), nil)
+	require.NoError(t, err)
+	require.NoError(t, account.Unlock(ctx, nil))
+	var pubKey phase0.BLSPubKey
+	copy(pubKey[:], account.PublicKey().Marshal())
+
+	// Start halfway through slot 100, which is in the first sync committee period.
+	genesisTime := time.Now().Add(-100*12*time.Second - 6*time.Second)
+	c := &command{
+		chainTime:      testChainTime(t, genesisTime),
+		domainProvider: &domainProvider{},
+		// These parameters select every validator as an aggregator.
+		syncCommitteeSize:                    4,
+		syncCommitteeSubnetCount:             4,
+		targetAggregatorsPerSyncSubcommittee: 1,
+		aggregatorSlots:                      30,
+		validatorPubKeys: map[phase0.ValidatorIndex]phase0.BLSPubKey{
+			1: pubKey,
+			2: {0x02},
+		},
+		signingAccounts: map[phase0.BLSPubKey]e2wtypes.Account{
+			pubKey: account,
+		},
+	}
+	c.periods = []*periodDuties{
+		c.periodDuties(0, []phase0.ValidatorIndex{2, 1, 2, 3}),
+		c.periodDuties(1, []phase0.ValidatorIndex{1, 0, 0, 0}),
+	}
+	logFile := filepath.Join(t.TempDir(), "audit.jsonl")
+	viper.Set("log", logFile)
+	defer viper.Reset()
+	require.NoError(t, c.checkAggregations(ctx))
+
+	// Selection proofs are recorded as a single summary entry.
+	f, err := os.Open(logFile)
+	require.NoError(t, err)
+	defer f.Close()
+	summary, err := util.VerifyAuditLog(f, "")
+	require.NoError(t, err)
+	require.Len(t, summary.Log, 1)
+	require.Equal(t, util.AuditActionSign, summary.Log[0].Action)
+	require.Equal(t, "30 sync committee selection proofs for slots 100 to 129", summary.Log[0].Operation)
+	require.Equal(t, []string{fmt.Sprintf("%#x", pubKey)}, summary.Log[0].PublicKeys)
+
+	// Validator 2 has no account so is not checked.
+	require.False(t, c.periods[0].Duties[1].AggregationChecked)
+	require.Nil(t, c.periods[0].Duties[1].Aggregations)
+
+	// Validator 1 is an aggregator for each slot from the current slot at which it signs for
+	// each period, which is the slot before the slot in the period.
+	require.True(t, c.periods[0].Duties[0].AggregationChecked)
+	require.Len(t, c.periods[0].Duties[0].Aggregations, 27)
+	require.Equal(t, &aggregation{Slot: 100, SubcommitteeIndex: 1}, c.periods[0].Duties[0].Aggregations[0])
+	require.Equal(t, &aggregation{Slot: 126, SubcommitteeIndex: 1}, c.periods[0].Duties[0].Aggregations[26])
+	require.True(t, c.periods[1].Duties[0].AggregationChecked)
+	require.Equal(t, []*aggregation{
+		{Slot: 127, SubcommitteeIndex: 0},
+		{Slot: 128, SubcommitteeIndex: 0},
+		{Slot: 129, SubcommitteeIndex: 0},
+	}, c.periods[1].Duties[0].Aggregations)
+}
@@ -18,6 +18,7 @@ import (
 	"time"
 
 	"github.com/aaron-alderman/ethdo/services/chaintime"
+	"github.com/aaron-alderman/ethdo/util"
 	eth2client "github.com/attestantio/go-eth2-client"
 	"github.com/pkg/errors"
 	"github.com/spf13/viper"
@@ -25,6 +26,7 @@ import (
 
 type command struct {
 	quiet   bool
+	format  string
 	verbose bool
 	debug   bool
 
@@ -52,6 +54,7 @@ type command struct {
 func newCommand(ctx context.Context) (*command, error) {
 	c := &command{
 		quiet:   viper.GetBool("quiet"),
+		format:  util.OutputFormat(),
 		verbose: viper.GetBool("verbose"),
 		debug:   viper.GetBool("debug"),
 	}
@@ -17,13 +17,31 @@ import (
 	"context"
 	"fmt"
 	"strings"
+
+	"github.com/aaron-alderman/ethdo/util"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
 )
 
+type inclusionJSON struct {
+	Epoch          phase0.Epoch `json:"epoch"`
+	InCommittee    bool         `json:"in_committee"`
+	CommitteeIndex *uint64      `json:"committee_index,omitempty"`
+	Expected       int          `json:"expected"`
+	Included       int          `json:"included"`
+	Missed         int          `json:"missed"`
+	NoBlock        int          `json:"no_block"`
+	Slots          []string     `json:"slots,omitempty"`
+}
+
 func (c *command) output(ctx context.Context) (string, error) {
 	if c.quiet {
 		return "", nil
 	}
 
+	if util.StructuredFormat(c.format) {
+		return c.outputStructured(ctx)
+	}
+
 	builder := strings.Builder{}
 
 	if c.verbose {
@@ -79,3 +97,31 @@ func (c *command) output(ctx context.Context) (string, error) {
 
 	return builder.String(), nil
 }
+
+func (c *command) outputStructured(_ context.Context) (string, error) {
+	res := &inclusionJSON{
+		Epoch:       phase0.Epoch(c.epoch),
+		InCommittee: c.inCommittee,
+	}
+	if c.inCommittee {
+		committeeIndex := c.committeeIndex
+		res.CommitteeIndex = &committeeIndex
+		res.Expected = len(c.inclusions)
+		res.Slots = make([]string, 0, len(c.inclusions))
+		for _, inclusion := range c.inclusions {
+			switch inclusion {
+			case 0:
+				res.NoBlock++
+				res.Slots = append(res.Slots, "no_block")
+			case 1:
+				res.Included++
+				res.Slots = append(res.Slots, "included")
+			case 2:
+				res.Missed++
+				res.Slots = append(res.Slots, "missed")
+			}
+		}
+	}
+
+	return util.FormatOutput(c.format, res)
+}
@@ -15,10 +15,10 @@ package members
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"strings"
 
+	"github.com/aaron-alderman/ethdo/util"
 	"github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/pkg/errors"
 )
@@ -26,8 +26,8 @@ import (
 type dataOut struct {
 	debug      bool
 	quiet      bool
+	format     string
 	verbose    bool
-	json       bool
 	validators []phase0.ValidatorIndex
 }
 
@@ -40,16 +40,12 @@ func output(ctx context.Context, data *dataOut) (string, error) {
 		return "", nil
 	}
 
-	if data.validators == nil {
-		return "No sync committee validators found", nil
+	if util.StructuredFormat(data.format) {
+		return util.FormatOutput(data.format, data.validators)
 	}
 
-	if data.json {
-		bytes, err := json.Marshal(data.validators)
-		if err != nil {
-			return "", errors.Wrap(err, "failed to marshal JSON")
-		}
-		return string(bytes), nil
+	if data.validators == nil {
+		return "No sync committee validators found", nil
 	}
 
 	validators := make([]string, len(data.validators))
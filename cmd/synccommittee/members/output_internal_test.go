@@ -47,7 +47,7 @@ func TestOutput(t *testing.T) {
 		{
 			name: "JSON",
 			dataOut: &dataOut{
-				json:       true,
+				format:     "json",
 				validators: []phase0.ValidatorIndex{1, 2, 3},
 			},
 			res: "[1,2,3]",
@@ -0,0 +1,71 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	synccommitteeduties "github.com/aaron-alderman/ethdo/cmd/synccommittee/duties"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var synccommitteeDutiesCmd = &cobra.Command{
+	Use:   "duties",
+	Short: "Obtain sync committee duties for validators",
+	Long: `Obtain sync committee duties for validators in the current and next sync committee periods.  For example:
+
+    ethdo synccommittee duties --validators=1,2,3
+
+validators can be indices or public keys, and accounts can be a wallet or wallet/account path.  If accounts are supplied and can be unlocked with the passphrase then aggregator selection is also calculated for the number of slots given by aggregator-slots, starting at the current slot.
+
+In quiet mode this will return 0 if the duties can be obtained, otherwise 1.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		res, err := synccommitteeduties.Run(cmd)
+		if err != nil {
+			return err
+		}
+		if viper.GetBool("quiet") {
+			return nil
+		}
+		if res != "" {
+			fmt.Println(res)
+		}
+		return nil
+	},
+}
+
+func init() {
+	synccommitteeCmd.AddCommand(synccommitteeDutiesCmd)
+	synccommitteeFlags(synccommitteeDutiesCmd)
+	synccommitteeDutiesCmd.Flags().StringSlice("validators", nil, "indices or public keys of the validators")
+	synccommitteeDutiesCmd.Flags().String("accounts", "", "wallet or wallet/account path of the validators")
+	synccommitteeDutiesCmd.Flags().Uint64("aggregator-slots", 32, "the number of slots, starting at the current slot, for which to calculate aggregator selection")
+	synccommitteeDutiesCmd.Flags().Bool("json", false, "output data in JSON format")
+}
+
+func synccommitteeDutiesBindings() {
+	if err := viper.BindPFlag("validators", synccommitteeDutiesCmd.Flags().Lookup("validators")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("accounts", synccommitteeDutiesCmd.Flags().Lookup("accounts")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("aggregator-slots", synccommitteeDutiesCmd.Flags().Lookup("aggregator-slots")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("json", synccommitteeDutiesCmd.Flags().Lookup("json")); err != nil {
+		panic(err)
+	}
+}
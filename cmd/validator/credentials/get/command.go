@@ -17,6 +17,7 @@ import (
 	"context"
 	"time"
 
+	"github.com/aaron-alderman/ethdo/util"
 	eth2client "github.com/attestantio/go-eth2-client"
 	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
 	"github.com/pkg/errors"
@@ -25,6 +26,7 @@ import (
 
 type command struct {
 	quiet   bool
+	format  string
 	verbose bool
 	debug   bool
 
@@ -49,6 +51,7 @@ type command struct {
 func newCommand(ctx context.Context) (*command, error) {
 	c := &command{
 		quiet:   viper.GetBool("quiet"),
+		format:  util.OutputFormat(),
 		verbose: viper.GetBool("verbose"),
 		debug:   viper.GetBool("debug"),
 	}
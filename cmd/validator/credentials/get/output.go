@@ -17,13 +17,25 @@ import (
 	"context"
 	"fmt"
 	"strings"
+
+	"github.com/aaron-alderman/ethdo/util"
 )
 
+type credentialsJSON struct {
+	Type                  string `json:"type"`
+	WithdrawalCredentials string `json:"withdrawal_credentials"`
+	ExecutionAddress      string `json:"execution_address,omitempty"`
+}
+
 func (c *command) output(ctx context.Context) (string, error) {
 	if c.quiet {
 		return "", nil
 	}
 
+	if util.StructuredFormat(c.format) {
+		return c.outputStructured(ctx)
+	}
+
 	builder := strings.Builder{}
 
 	switch c.validator.Validator.WithdrawalCredentials[0] {
@@ -42,3 +54,21 @@ func (c *command) output(ctx context.Context) (string, error) {
 
 	return builder.String(), nil
 }
+
+func (c *command) outputStructured(_ context.Context) (string, error) {
+	credentials := c.validator.Validator.WithdrawalCredentials
+	res := &credentialsJSON{
+		WithdrawalCredentials: fmt.Sprintf("%#x", credentials),
+	}
+	switch credentials[0] {
+	case 0:
+		res.Type = "bls"
+	case 1:
+		res.Type = "execution"
+		res.ExecutionAddress = fmt.Sprintf("%#x", credentials[12:])
+	default:
+		res.Type = "unknown"
+	}
+
+	return util.FormatOutput(c.format, res)
+}
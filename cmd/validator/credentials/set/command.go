@@ -0,0 +1,138 @@
+// Copyright © 2022 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatorcredentialsset
+
+import (
+	"context"
+	"encoding/hex"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aaron-alderman/ethdo/util"
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+)
+
+type command struct {
+	quiet   bool
+	format  string
+	verbose bool
+	debug   bool
+
+	// Input.
+	index             string
+	pubKey            string
+	mnemonic          string
+	withdrawalAccount string
+	withdrawalAddress [util.ExecutionAddressLength]byte
+	jsonOutput        bool
+	network           string
+	// withdrawalCredentials are the current withdrawal credentials of the validator, if supplied.
+	withdrawalCredentials []byte
+
+	// offline is set if everything required is supplied, so no beacon node is used.
+	offline bool
+
+	// Beacon node connection.
+	timeout                  time.Duration
+	connection               string
+	allowInsecureConnections bool
+
+	// Data access.
+	consensusClient    eth2client.Service
+	validatorsProvider eth2client.ValidatorsProvider
+	genesisProvider    eth2client.GenesisProvider
+
+	// Processing.
+	seed                  []byte
+	genesisForkVersion    phase0.Version
+	genesisValidatorsRoot phase0.Root
+	// withdrawalKeys are withdrawal keys already found in the seed, keyed by withdrawal credentials.
+	withdrawalKeys map[[32]byte]*util.WithdrawalKey
+
+	// Output.
+	changes []*util.SignedBLSToExecutionChange
+}
+
+func newCommand(ctx context.Context) (*command, error) {
+	c := &command{
+		quiet:   viper.GetBool("quiet"),
+		format:  util.OutputFormat(),
+		verbose: viper.GetBool("verbose"),
+		debug:   viper.GetBool("debug"),
+	}
+
+	// Timeout.
+	if viper.GetDuration("timeout") == 0 {
+		return nil, errors.New("timeout is required")
+	}
+	c.timeout = viper.GetDuration("timeout")
+
+	c.index = viper.GetString("index")
+	c.pubKey = viper.GetString("pubkey")
+	if c.index != "" && c.pubKey != "" {
+		return nil, errors.New("only one of index and pubkey allowed")
+	}
+
+	if viper.GetString("withdrawal-credentials") != "" {
+		// Without a beacon node the validator is identified by its index alone.
+		if _, err := strconv.ParseUint(c.index, 10, 64); err != nil {
+			return nil, errors.New("withdrawal-credentials requires a numeric index")
+		}
+		var err error
+		c.withdrawalCredentials, err = hex.DecodeString(strings.TrimPrefix(viper.GetString("withdrawal-credentials"), "0x"))
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid withdrawal credentials")
+		}
+		if len(c.withdrawalCredentials) != 32 {
+			return nil, errors.New("withdrawal credentials must be 32 bytes")
+		}
+	}
+
+	c.mnemonic = viper.GetString("mnemonic")
+	c.withdrawalAccount = viper.GetString("withdrawal-account")
+	if c.mnemonic == "" && c.withdrawalAccount == "" {
+		return nil, errors.New("one of mnemonic or withdrawal-account required")
+	}
+	if c.mnemonic != "" && c.withdrawalAccount != "" {
+		return nil, errors.New("only one of mnemonic and withdrawal-account allowed")
+	}
+
+	if viper.GetString("withdrawal-address") == "" {
+		return nil, errors.New("withdrawal-address is required")
+	}
+	var err error
+	c.withdrawalAddress, err = util.ParseExecutionAddress(viper.GetString("withdrawal-address"))
+	if err != nil {
+		return nil, err
+	}
+
+	c.jsonOutput = viper.GetBool("json")
+	c.network = viper.GetString("network")
+
+	// A beacon node is needed to obtain the validators and genesis, and to broadcast the changes.
+	c.offline = c.jsonOutput && c.network != "" && c.withdrawalCredentials != nil
+	if !c.offline {
+		if viper.GetString("connection") == "" {
+			return nil, errors.New("connection is required unless json, network and withdrawal-credentials are supplied")
+		}
+		c.connection = viper.GetString("connection")
+		c.allowInsecureConnections = viper.GetBool("allow-insecure-connections")
+	}
+
+	return c, nil
+}
@@ -0,0 +1,186 @@
+// Copyright © 2022 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatorcredentialsset
+
+import (
+	"context"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInput(t *testing.T) {
+	tests := []struct {
+		name string
+		vars map[string]interface{}
+		err  string
+	}{
+		{
+			name: "TimeoutMissing",
+			vars: map[string]interface{}{
+				"connection":         "http://localhost:5052/",
+				"mnemonic":           "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon art",
+				"withdrawal-address": "0x8c1Ff978036F2e9d7CC382Eff7B4c8c53C22ac15",
+			},
+			err: "timeout is required",
+		},
+		{
+			name: "ConnectionMissing",
+			vars: map[string]interface{}{
+				"timeout":            "5s",
+				"mnemonic":           "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon art",
+				"withdrawal-address": "0x8c1Ff978036F2e9d7CC382Eff7B4c8c53C22ac15",
+			},
+			err: "connection is required unless json, network and withdrawal-credentials are supplied",
+		},
+		{
+			name: "ConnectionMissingNetworkMissing",
+			vars: map[string]interface{}{
+				"timeout":                "5s",
+				"index":                  "0",
+				"withdrawal-credentials": "0x007e28dcf9029e8d92ca4b5d01c66c934e7f3110606f34ae3052cbf67bd3fc02",
+				"mnemonic":               "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon art",
+				"withdrawal-address":     "0x8c1Ff978036F2e9d7CC382Eff7B4c8c53C22ac15",
+				"json":                   true,
+			},
+			err: "connection is required unless json, network and withdrawal-credentials are supplied",
+		},
+		{
+			name: "WithdrawalCredentialsIndexMissing",
+			vars: map[string]interface{}{
+				"timeout":                "5s",
+				"pubkey":                 "0xa99a76ed7796f7be22d5b7e85deeb7c5677e88e511e0b337618f8c4eb61349b4bf2d153f649f7b53359fe8b94a38e44c",
+				"withdrawal-credentials": "0x007e28dcf9029e8d92ca4b5d01c66c934e7f3110606f34ae3052cbf67bd3fc02",
+				"network":                "mainnet",
+				"mnemonic":               "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon art",
+				"withdrawal-address":     "0x8c1Ff978036F2e9d7CC382Eff7B4c8c53C22ac15",
+				"json":                   true,
+			},
+			err: "withdrawal-credentials requires a numeric index",
+		},
+		{
+			name: "WithdrawalCredentialsShort",
+			vars: map[string]interface{}{
+				"timeout":                "5s",
+				"index":                  "0",
+				"withdrawal-credentials": "0x007e28dcf9029e8d92ca4b5d01c66c934e7f3110606f34ae3052cbf67bd3fc",
+				"network":                "mainnet",
+				"mnemonic":               "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon art",
+				"withdrawal-address":     "0x8c1Ff978036F2e9d7CC382Eff7B4c8c53C22ac15",
+				"json":                   true,
+			},
+			err: "withdrawal credentials must be 32 bytes",
+		},
+		{
+			name: "IndexAndPubKey",
+			vars: map[string]interface{}{
+				"timeout":            "5s",
+				"connection":         "http://localhost:5052/",
+				"index":              "1",
+				"pubkey":             "0xa99a76ed7796f7be22d5b7e85deeb7c5677e88e511e0b337618f8c4eb61349b4bf2d153f649f7b53359fe8b94a38e44c",
+				"mnemonic":           "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon art",
+				"withdrawal-address": "0x8c1Ff978036F2e9d7CC382Eff7B4c8c53C22ac15",
+			},
+			err: "only one of index and pubkey allowed",
+		},
+		{
+			name: "WithdrawalKeyMissing",
+			vars: map[string]interface{}{
+				"timeout":            "5s",
+				"connection":         "http://localhost:5052/",
+				"withdrawal-address": "0x8c1Ff978036F2e9d7CC382Eff7B4c8c53C22ac15",
+			},
+			err: "one of mnemonic or withdrawal-account required",
+		},
+		{
+			name: "MnemonicAndWithdrawalAccount",
+			vars: map[string]interface{}{
+				"timeout":            "5s",
+				"connection":         "http://localhost:5052/",
+				"mnemonic":           "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon art",
+				"withdrawal-account": "Test wallet/Test account",
+				"withdrawal-address": "0x8c1Ff978036F2e9d7CC382Eff7B4c8c53C22ac15",
+			},
+			err: "only one of mnemonic and withdrawal-account allowed",
+		},
+		{
+			name: "WithdrawalAddressMissing",
+			vars: map[string]interface{}{
+				"timeout":    "5s",
+				"connection": "http://localhost:5052/",
+				"mnemonic":   "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon art",
+			},
+			err: "withdrawal-address is required",
+		},
+		{
+			name: "WithdrawalAddressShort",
+			vars: map[string]interface{}{
+				"timeout":            "5s",
+				"connection":         "http://localhost:5052/",
+				"mnemonic":           "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon art",
+				"withdrawal-address": "0x8c1Ff978036F2e9d7CC382Eff7B4c8c53C22ac",
+			},
+			err: "execution address must be 20 bytes",
+		},
+		{
+			name: "WithdrawalAddressChecksumIncorrect",
+			vars: map[string]interface{}{
+				"timeout":            "5s",
+				"connection":         "http://localhost:5052/",
+				"mnemonic":           "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon art",
+				"withdrawal-address": "0x8C1Ff978036F2e9d7CC382Eff7B4c8c53C22ac15",
+			},
+			err: "execution address checksum incorrect",
+		},
+		{
+			name: "Good",
+			vars: map[string]interface{}{
+				"timeout":            "5s",
+				"connection":         "http://localhost:5052/",
+				"index":              "1",
+				"mnemonic":           "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon art",
+				"withdrawal-address": "0x8c1Ff978036F2e9d7CC382Eff7B4c8c53C22ac15",
+			},
+		},
+		{
+			name: "GoodOffline",
+			vars: map[string]interface{}{
+				"timeout":                "5s",
+				"index":                  "0",
+				"withdrawal-credentials": "0x007e28dcf9029e8d92ca4b5d01c66c934e7f3110606f34ae3052cbf67bd3fc02",
+				"network":                "mainnet",
+				"mnemonic":               "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon art",
+				"withdrawal-address":     "0x8c1Ff978036F2e9d7CC382Eff7B4c8c53C22ac15",
+				"json":                   true,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			viper.Reset()
+
+			for k, v := range test.vars {
+				viper.Set(k, v)
+			}
+			_, err := newCommand(context.Background())
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,79 @@
+// Copyright © 2022 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatorcredentialsset
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/aaron-alderman/ethdo/util"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+)
+
+type changeJSON struct {
+	ValidatorIndex     phase0.ValidatorIndex `json:"validator_index"`
+	ToExecutionAddress string                `json:"to_execution_address"`
+	Submitted          bool                  `json:"submitted"`
+}
+
+func (c *command) output(ctx context.Context) (string, error) {
+	if c.quiet {
+		return "", nil
+	}
+
+	if c.jsonOutput {
+		data, err := json.Marshal(c.changes)
+		if err != nil {
+			return "", errors.Wrap(err, "failed to marshal JSON")
+		}
+		return string(data), nil
+	}
+
+	if util.StructuredFormat(c.format) {
+		return c.outputStructured(ctx)
+	}
+
+	if !c.verbose {
+		return "", nil
+	}
+
+	builder := strings.Builder{}
+	for i, change := range c.changes {
+		if i > 0 {
+			builder.WriteString("\n")
+		}
+		builder.WriteString(fmt.Sprintf("Validator %d: withdrawal credentials change to %s submitted",
+			change.Message.ValidatorIndex,
+			util.ExecutionAddressChecksum(change.Message.ToExecutionAddress),
+		))
+	}
+
+	return builder.String(), nil
+}
+
+func (c *command) outputStructured(_ context.Context) (string, error) {
+	changes := make([]*changeJSON, 0, len(c.changes))
+	for _, change := range c.changes {
+		changes = append(changes, &changeJSON{
+			ValidatorIndex:     change.Message.ValidatorIndex,
+			ToExecutionAddress: util.ExecutionAddressChecksum(change.Message.ToExecutionAddress),
+			Submitted:          true,
+		})
+	}
+
+	return util.FormatOutput(c.format, changes)
+}
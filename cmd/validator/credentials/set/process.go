@@ -0,0 +1,333 @@
+// Copyright © 2022 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatorcredentialsset
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+
+	"github.com/aaron-alderman/ethdo/util"
+	eth2client "github.com/attestantio/go-eth2-client"
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	e2types "github.com/wealdtech/go-eth2-types/v2"
+	ethutil "github.com/wealdtech/go-eth2-util"
+	e2wtypes "github.com/wealdtech/go-eth2-wallet-types/v2"
+)
+
+func (c *command) process(ctx context.Context) error {
+	// Obtain information we need to process.
+	if err := c.setup(ctx); err != nil {
+		return err
+	}
+
+	validators, err := c.obtainValidators(ctx)
+	if err != nil {
+		return err
+	}
+
+	// Group the validators by their withdrawal credentials, as a withdrawal key can be
+	// used by more than one validator.
+	credentialsValidators := make(map[[32]byte][]*apiv1.Validator)
+	for _, validator := range validators {
+		var credentials [32]byte
+		copy(credentials[:], validator.Validator.WithdrawalCredentials)
+		credentialsValidators[credentials] = append(credentialsValidators[credentials], validator)
+	}
+
+	accounts, err := c.withdrawalAccounts(ctx, credentialsValidators)
+	if err != nil {
+		return err
+	}
+	if len(accounts) == 0 {
+		return errors.New("no validators found with withdrawal credentials for the withdrawal key")
+	}
+
+	// Changes are signed with the genesis fork version so that they are valid across all forks.
+	domainBytes, err := e2types.ComputeDomain(e2types.DomainType(util.BLSToExecutionChangeDomainType), c.genesisForkVersion[:], c.genesisValidatorsRoot[:])
+	if err != nil {
+		return errors.Wrap(err, "failed to calculate domain")
+	}
+	var domain phase0.Domain
+	copy(domain[:], domainBytes)
+
+	for credentials, account := range accounts {
+		for _, validator := range credentialsValidators[credentials] {
+			change, err := c.signChange(validator, account, domain)
+			if err != nil {
+				return err
+			}
+			c.changes = append(c.changes, change)
+		}
+	}
+	sort.Slice(c.changes, func(i int, j int) bool {
+		return c.changes[i].Message.ValidatorIndex < c.changes[j].Message.ValidatorIndex
+	})
+
+	if c.jsonOutput {
+		// JSON output is for later submission.
+		return nil
+	}
+
+	if err := util.SubmitBLSToExecutionChanges(ctx, c.consensusClient, c.timeout, c.changes); err != nil {
+		return errors.Wrap(err, "failed to submit credentials changes")
+	}
+	for _, change := range c.changes {
+		if err := util.AuditBroadcast(fmt.Sprintf("credentials change for validator %d to %#x", change.Message.ValidatorIndex, change.Message.ToExecutionAddress), change.Message.FromBLSPubkey[:]); err != nil {
+			return errors.Wrap(err, "failed to record broadcast in audit log")
+		}
+	}
+
+	return nil
+}
+
+// obtainValidators obtains the validators with BLS withdrawal credentials that could be changed.
+func (c *command) obtainValidators(ctx context.Context) ([]*apiv1.Validator, error) {
+	var validators map[phase0.ValidatorIndex]*apiv1.Validator
+	var err error
+	explicit := c.index != "" || c.pubKey != ""
+	switch {
+	case c.withdrawalCredentials != nil:
+		// The index was checked to be numeric when the command was created.
+		index, _ := strconv.ParseUint(c.index, 10, 64)
+		validators = map[phase0.ValidatorIndex]*apiv1.Validator{
+			phase0.ValidatorIndex(index): {
+				Index: phase0.ValidatorIndex(index),
+				Validator: &phase0.Validator{
+					WithdrawalCredentials: c.withdrawalCredentials,
+				},
+			},
+		}
+	case explicit:
+		validators, err = util.ParseValidators(ctx, c.validatorsProvider, []string{c.index, c.pubKey}, "")
+	case c.mnemonic != "":
+		validators, err = c.mnemonicValidators(ctx)
+	default:
+		validators, err = c.validatorsProvider.Validators(ctx, "head", nil)
+		if err != nil {
+			err = errors.Wrap(err, "failed to obtain validators")
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]*apiv1.Validator, 0, len(validators))
+	for _, validator := range validators {
+		if validator.Validator.WithdrawalCredentials[0] != 0x00 {
+			if explicit {
+				return nil, fmt.Errorf("validator %d does not have BLS withdrawal credentials", validator.Index)
+			}
+			continue
+		}
+		res = append(res, validator)
+	}
+
+	return res, nil
+}
+
+// mnemonicValidators obtains the validators whose signing keys are derived from the mnemonic, and
+// whose withdrawal credentials are for the withdrawal key at the path above the signing key.
+func (c *command) mnemonicValidators(ctx context.Context) (map[phase0.ValidatorIndex]*apiv1.Validator, error) {
+	paths, err := util.WithdrawalPathsForValidatorKeys(c.seed)
+	if err != nil {
+		return nil, err
+	}
+	pubKeys := make([]phase0.BLSPubKey, 0, len(paths))
+	for pubKey := range paths {
+		pubKeys = append(pubKeys, pubKey)
+	}
+	validators, err := c.validatorsProvider.ValidatorsByPubKey(ctx, "head", pubKeys)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to obtain validators")
+	}
+
+	res := make(map[phase0.ValidatorIndex]*apiv1.Validator, len(validators))
+	for index, validator := range validators {
+		path := paths[validator.Validator.PublicKey]
+		key, err := ethutil.PrivateKeyFromSeedAndPath(c.seed, path)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to generate withdrawal key")
+		}
+		var credentials [32]byte
+		copy(credentials[:], util.BLSWithdrawalCredentials(key.PublicKey().Marshal()))
+		if !bytes.Equal(credentials[:], validator.Validator.WithdrawalCredentials) {
+			if c.debug {
+				fmt.Fprintf(os.Stderr, "Validator %d does not have withdrawal credentials for the key at path %s\n", index, path)
+			}
+			continue
+		}
+		c.withdrawalKeys[credentials] = &util.WithdrawalKey{
+			Path: path,
+			Key:  key,
+		}
+		res[index] = validator
+	}
+
+	return res, nil
+}
+
+// withdrawalAccounts obtains accounts for the withdrawal keys of the validators, keyed by withdrawal credentials.
+func (c *command) withdrawalAccounts(ctx context.Context,
+	credentialsValidators map[[32]byte][]*apiv1.Validator,
+) (
+	map[[32]byte]e2wtypes.Account,
+	error,
+) {
+	res := make(map[[32]byte]e2wtypes.Account)
+
+	if c.withdrawalAccount != "" {
+		_, account, err := util.WalletAndAccountFromPath(ctx, c.withdrawalAccount)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to obtain withdrawal account")
+		}
+		pubKey, err := util.BestPublicKey(account)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to obtain public key for withdrawal account")
+		}
+		var credentials [32]byte
+		copy(credentials[:], util.BLSWithdrawalCredentials(pubKey.Marshal()))
+		if _, exists := credentialsValidators[credentials]; exists {
+			res[credentials] = account
+		}
+		return res, nil
+	}
+
+	// Search the seed only for credentials whose keys have not already been found.
+	withdrawalCredentials := make([][]byte, 0, len(credentialsValidators))
+	for credentials := range credentialsValidators {
+		if _, exists := c.withdrawalKeys[credentials]; !exists {
+			withdrawalCredentials = append(withdrawalCredentials, append([]byte{}, credentials[:]...))
+		}
+	}
+	if len(withdrawalCredentials) > 0 {
+		keys, err := util.WithdrawalKeysForCredentials(c.seed, withdrawalCredentials)
+		if err != nil {
+			return nil, err
+		}
+		for credentials, key := range keys {
+			c.withdrawalKeys[credentials] = key
+		}
+	}
+	for credentials, key := range c.withdrawalKeys {
+		if c.debug {
+			fmt.Fprintf(os.Stderr, "Found withdrawal key for credentials %#x at path %s\n", credentials, key.Path)
+		}
+		account, err := util.NewScratchAccount(key.Key.Marshal(), nil)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to create withdrawal account")
+		}
+		if err := account.Unlock(ctx, nil); err != nil {
+			return nil, errors.Wrap(err, "failed to unlock withdrawal account")
+		}
+		res[credentials] = account
+	}
+
+	return res, nil
+}
+
+// signChange creates a signed credentials change for a validator.
+func (c *command) signChange(validator *apiv1.Validator,
+	account e2wtypes.Account,
+	domain phase0.Domain,
+) (
+	*util.SignedBLSToExecutionChange,
+	error,
+) {
+	pubKey, err := util.BestPublicKey(account)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to obtain public key for withdrawal account")
+	}
+	change := &util.BLSToExecutionChange{
+		ValidatorIndex:     validator.Index,
+		ToExecutionAddress: c.withdrawalAddress,
+	}
+	copy(change.FromBLSPubkey[:], pubKey.Marshal())
+
+	root, err := change.HashTreeRoot()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to generate root for credentials change")
+	}
+	sig, err := util.SignRoot(account, root, domain)
+	if err != nil {
+		return nil, errors.Wrap(err, fmt.Sprintf("failed to sign credentials change for validator %d", validator.Index))
+	}
+
+	signedChange := &util.SignedBLSToExecutionChange{
+		Message: change,
+	}
+	copy(signedChange.Signature[:], sig.Marshal())
+
+	return signedChange, nil
+}
+
+func (c *command) setup(ctx context.Context) error {
+	var err error
+
+	c.withdrawalKeys = make(map[[32]byte]*util.WithdrawalKey)
+	if c.mnemonic != "" {
+		c.seed, err = util.SeedFromMnemonic(c.mnemonic)
+		if err != nil {
+			return err
+		}
+	}
+
+	if c.network != "" {
+		networkConfig, err := util.NetworkConfigByName(c.network)
+		if err != nil {
+			return err
+		}
+		if networkConfig.GenesisValidatorsRoot == nil {
+			return fmt.Errorf("genesis validators root of network %s is not known", networkConfig.Name)
+		}
+		c.genesisForkVersion = networkConfig.GenesisForkVersion
+		c.genesisValidatorsRoot = *networkConfig.GenesisValidatorsRoot
+	}
+
+	if c.offline {
+		return nil
+	}
+
+	// Connect to the consensus node.
+	c.consensusClient, err = util.ConnectToBeaconNode(ctx, c.connection, c.timeout, c.allowInsecureConnections)
+	if err != nil {
+		return errors.Wrap(err, "failed to connect to consensus node")
+	}
+
+	var isProvider bool
+	c.validatorsProvider, isProvider = c.consensusClient.(eth2client.ValidatorsProvider)
+	if !isProvider {
+		return errors.New("consensus node does not provide validator information")
+	}
+	c.genesisProvider, isProvider = c.consensusClient.(eth2client.GenesisProvider)
+	if !isProvider {
+		return errors.New("consensus node does not provide genesis information")
+	}
+
+	if c.network == "" {
+		genesis, err := c.genesisProvider.Genesis(ctx)
+		if err != nil {
+			return errors.Wrap(err, "failed to obtain genesis")
+		}
+		c.genesisForkVersion = genesis.GenesisForkVersion
+		c.genesisValidatorsRoot = genesis.GenesisValidatorsRoot
+	}
+
+	return nil
+}
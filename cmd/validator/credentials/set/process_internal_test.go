@@ -0,0 +1,208 @@
+// Copyright © 2022 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatorcredentialsset
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/aaron-alderman/ethdo/testing/beaconnode"
+	"github.com/aaron-alderman/ethdo/util"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/rs/zerolog"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+	e2types "github.com/wealdtech/go-eth2-types/v2"
+)
+
+func TestProcess(t *testing.T) {
+	require.NoError(t, e2types.InitBLS())
+	zerolog.SetGlobalLevel(zerolog.Disabled)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	beaconNode, err := beaconnode.New(ctx,
+		beaconnode.WithFixturesDir(mnemonicFixturesDir(t)),
+	)
+	require.NoError(t, err)
+
+	// The withdrawal key for validators 0 and 3 is at m/12381/3600/10/0 of this mnemonic.
+	mnemonic := "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon art"
+
+	tests := []struct {
+		name      string
+		vars      map[string]interface{}
+		offline   bool
+		err       string
+		indices   []phase0.ValidatorIndex
+		submitted int
+	}{
+		{
+			name: "KeyMismatch",
+			vars: map[string]interface{}{
+				"index":    "1",
+				"mnemonic": mnemonic,
+				"json":     true,
+			},
+			err: "no validators found with withdrawal credentials for the withdrawal key",
+		},
+		{
+			name: "Index",
+			vars: map[string]interface{}{
+				"index":    "0",
+				"mnemonic": mnemonic,
+				"json":     true,
+			},
+			indices: []phase0.ValidatorIndex{0},
+		},
+		{
+			name: "PubKey",
+			vars: map[string]interface{}{
+				"pubkey":   "0xa99a76ed7796f7be22d5b7e85deeb7c5677e88e511e0b337618f8c4eb61349b4bf2d153f649f7b53359fe8b94a38e44c",
+				"mnemonic": mnemonic,
+				"json":     true,
+			},
+			indices: []phase0.ValidatorIndex{0},
+		},
+		{
+			name: "Bulk",
+			vars: map[string]interface{}{
+				"mnemonic": mnemonic,
+				"json":     true,
+			},
+			indices: []phase0.ValidatorIndex{3},
+		},
+		{
+			name: "Submit",
+			vars: map[string]interface{}{
+				"mnemonic": mnemonic,
+			},
+			indices:   []phase0.ValidatorIndex{3},
+			submitted: 1,
+		},
+		{
+			name: "Offline",
+			vars: map[string]interface{}{
+				"index":                  "0",
+				"withdrawal-credentials": "0x007e28dcf9029e8d92ca4b5d01c66c934e7f3110606f34ae3052cbf67bd3fc02",
+				"network":                "mainnet",
+				"mnemonic":               mnemonic,
+				"json":                   true,
+			},
+			offline: true,
+			indices: []phase0.ValidatorIndex{0},
+		},
+		{
+			name: "OfflineKeyMismatch",
+			vars: map[string]interface{}{
+				"index":                  "0",
+				"withdrawal-credentials": "0x00ec7ef7780c9d151597924036262dd28dc60e1228f4da6fecf9d402cb3f3594",
+				"network":                "mainnet",
+				"mnemonic":               mnemonic,
+				"json":                   true,
+			},
+			offline: true,
+			err:     "no validators found with withdrawal credentials for the withdrawal key",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			viper.Reset()
+			viper.Set("timeout", "5s")
+			if !test.offline {
+				viper.Set("connection", beaconNode.Address())
+			}
+			viper.Set("withdrawal-address", "0x8c1Ff978036F2e9d7CC382Eff7B4c8c53C22ac15")
+			for k, v := range test.vars {
+				viper.Set(k, v)
+			}
+
+			submissionsBefore := len(beaconNode.Submissions("/eth/v1/beacon/pool/bls_to_execution_changes"))
+			c, err := newCommand(ctx)
+			require.NoError(t, err)
+			err = c.process(ctx)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+				return
+			}
+			require.NoError(t, err)
+
+			require.Len(t, c.changes, len(test.indices))
+			for i, change := range c.changes {
+				require.Equal(t, test.indices[i], change.Message.ValidatorIndex)
+				verifyChange(t, change)
+			}
+
+			submissions := beaconNode.Submissions("/eth/v1/beacon/pool/bls_to_execution_changes")
+			require.Len(t, submissions, submissionsBefore+test.submitted)
+			if test.submitted > 0 {
+				changes := make([]*util.SignedBLSToExecutionChange, 0)
+				require.NoError(t, json.Unmarshal(submissions[len(submissions)-1], &changes))
+				require.Equal(t, c.changes, changes)
+			}
+		})
+	}
+}
+
+// mnemonicFixturesDir creates fixtures from the shared fixtures with validators whose keys are derived
+// from the test mnemonic: validator 3 has its signing key at m/12381/3600/10/0/0 and withdrawal key at
+// m/12381/3600/10/0, and validator 4 has its signing key at m/12381/3600/3/0/0 but a different withdrawal key.
+// Validator 0 has its withdrawal key at m/12381/3600/10/0 but its signing key is not derived from the mnemonic.
+func mnemonicFixturesDir(t *testing.T) string {
+	dir := t.TempDir()
+	for _, name := range []string{"genesis.json", "spec.json", "fork_schedule.json"} {
+		data, err := os.ReadFile(filepath.Join("../../../../testing/beaconnode/testdata", name))
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), data, 0600))
+	}
+	validators := `{"data":[
+{"index":"0","balance":"32000000000","status":"active_ongoing","validator":{"pubkey":"0xa99a76ed7796f7be22d5b7e85deeb7c5677e88e511e0b337618f8c4eb61349b4bf2d153f649f7b53359fe8b94a38e44c","withdrawal_credentials":"0x007e28dcf9029e8d92ca4b5d01c66c934e7f3110606f34ae3052cbf67bd3fc02","effective_balance":"32000000000","slashed":false,"activation_eligibility_epoch":"0","activation_epoch":"0","exit_epoch":"18446744073709551615","withdrawable_epoch":"18446744073709551615"}},
+{"index":"1","balance":"31000000000","status":"active_exiting","validator":{"pubkey":"0xb89bebc699769726a318c8e9971bd3171297c61aea4a6578a7a4f94b547dcba5bac16a89108b6b6a1fe3695d1a874a0b","withdrawal_credentials":"0x00ec7ef7780c9d151597924036262dd28dc60e1228f4da6fecf9d402cb3f3594","effective_balance":"31000000000","slashed":false,"activation_eligibility_epoch":"0","activation_epoch":"0","exit_epoch":"20","withdrawable_epoch":"276"}},
+{"index":"3","balance":"32000000000","status":"active_ongoing","validator":{"pubkey":"0xb557c7af324c68f7c160ba3845b99efed189424b0c74a71050f1ecc11a9ff4617e2df70a9dcdbf69be47e341d53e2c98","withdrawal_credentials":"0x007e28dcf9029e8d92ca4b5d01c66c934e7f3110606f34ae3052cbf67bd3fc02","effective_balance":"32000000000","slashed":false,"activation_eligibility_epoch":"0","activation_epoch":"0","exit_epoch":"18446744073709551615","withdrawable_epoch":"18446744073709551615"}},
+{"index":"4","balance":"32000000000","status":"active_ongoing","validator":{"pubkey":"0x86d330af51fa593fa9f93edb9d16640186be2e93ea94d259781e1eb34deb844c3968d75ea91d19f159dbd0523c6c5ba5","withdrawal_credentials":"0x00ec7ef7780c9d151597924036262dd28dc60e1228f4da6fecf9d402cb3f3594","effective_balance":"32000000000","slashed":false,"activation_eligibility_epoch":"0","activation_epoch":"0","exit_epoch":"18446744073709551615","withdrawable_epoch":"18446744073709551615"}}
+]}`
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "validators.json"), []byte(validators), 0600))
+
+	return dir
+}
+
+// verifyChange verifies the signature of a change against the fixture genesis.
+func verifyChange(t *testing.T, change *util.SignedBLSToExecutionChange) {
+	genesisForkVersion := []byte{0x00, 0x00, 0x00, 0x00}
+	genesisValidatorsRoot := []byte{
+		0x4b, 0x36, 0x3d, 0xb9, 0x4e, 0x28, 0x61, 0x20, 0xd7, 0x6e, 0xb9, 0x05, 0x34, 0x0f, 0xdd, 0x4e,
+		0x54, 0xbf, 0xe9, 0xf0, 0x6b, 0xf3, 0x3f, 0xf6, 0xcf, 0x5a, 0xd2, 0x7f, 0x51, 0x1b, 0xfe, 0x95,
+	}
+	domain, err := e2types.ComputeDomain(e2types.DomainType(util.BLSToExecutionChangeDomainType), genesisForkVersion, genesisValidatorsRoot)
+	require.NoError(t, err)
+
+	root, err := change.Message.HashTreeRoot()
+	require.NoError(t, err)
+	container := &phase0.SigningData{
+		ObjectRoot: root,
+	}
+	copy(container.Domain[:], domain)
+	signingRoot, err := container.HashTreeRoot()
+	require.NoError(t, err)
+
+	pubKey, err := e2types.BLSPublicKeyFromBytes(change.Message.FromBLSPubkey[:])
+	require.NoError(t, err)
+	sig, err := e2types.BLSSignatureFromBytes(append([]byte{}, change.Signature[:]...))
+	require.NoError(t, err)
+	require.True(t, sig.Verify(signingRoot[:], pubKey))
+}
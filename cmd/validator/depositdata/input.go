@@ -16,6 +16,7 @@ package depositdata
 import (
 	"context"
 	"encoding/hex"
+	"fmt"
 	"strings"
 	"time"
 
@@ -39,6 +40,12 @@ type dataIn struct {
 	forkVersion       *spec.Version
 	domain            *spec.Domain
 	passphrases       []string
+	// Generation from a mnemonic.
+	seed               []byte
+	index              uint64
+	count              uint64
+	outputDir          string
+	keystorePassphrase string
 }
 
 func input() (*dataIn, error) {
@@ -48,8 +55,11 @@ func input() (*dataIn, error) {
 		domain:      &spec.Domain{},
 	}
 
-	if viper.GetString("validatoraccount") == "" {
-		return nil, errors.New("validator account is required")
+	if viper.GetString("validatoraccount") == "" && viper.GetString("mnemonic") == "" {
+		return nil, errors.New("validator account or mnemonic is required")
+	}
+	if viper.GetString("validatoraccount") != "" && viper.GetString("mnemonic") != "" {
+		return nil, errors.New("only one of validator account and mnemonic is allowed")
 	}
 
 	if viper.GetDuration("timeout") == 0 {
@@ -59,18 +69,30 @@ func input() (*dataIn, error) {
 
 	ctx, cancel := context.WithTimeout(context.Background(), viper.GetDuration("timeout"))
 	defer cancel()
-	_, data.validatorAccounts, err = ethdoutil.WalletAndAccountsFromPath(ctx, viper.GetString("validatoraccount"))
-	if err != nil {
-		return nil, errors.New("failed to obtain validator account")
-	}
-	if len(data.validatorAccounts) == 0 {
-		return nil, errors.New("unknown validator account")
+	if viper.GetString("mnemonic") != "" {
+		if err := inputMnemonic(data); err != nil {
+			return nil, err
+		}
+	} else {
+		_, data.validatorAccounts, err = ethdoutil.WalletAndAccountsFromPath(ctx, viper.GetString("validatoraccount"))
+		if err != nil {
+			return nil, errors.New("failed to obtain validator account")
+		}
+		if len(data.validatorAccounts) == 0 {
+			return nil, errors.New("unknown validator account")
+		}
 	}
 
 	switch {
+	case data.seed != nil:
+		// Deposits generated from a mnemonic are always for the launchpad.
+		data.format = "launchpad"
 	case viper.GetBool("launchpad"):
 		data.format = "launchpad"
 	case viper.GetBool("raw"):
+		if format := ethdoutil.OutputFormat(); ethdoutil.StructuredFormat(format) {
+			return nil, ethdoutil.NewCodedError(ethdoutil.ErrorCodeInvalidInput, fmt.Errorf("--raw cannot be used with --format=%s", format))
+		}
 		data.format = "raw"
 	default:
 		data.format = "json"
@@ -91,7 +113,8 @@ func input() (*dataIn, error) {
 	if data.withdrawalAddress != "" {
 		withdrawalDetailsPresent++
 	}
-	if withdrawalDetailsPresent == 0 {
+	// Deposits generated from a mnemonic default to the withdrawal key of the same index.
+	if withdrawalDetailsPresent == 0 && data.seed == nil {
 		return nil, errors.New("withdrawal account, public key or address is required")
 	}
 	if withdrawalDetailsPresent > 1 {
@@ -121,11 +144,49 @@ func input() (*dataIn, error) {
 	return data, nil
 }
 
+// inputMnemonic obtains the information required to generate deposits from a mnemonic.
+func inputMnemonic(data *dataIn) error {
+	var err error
+	data.seed, err = ethdoutil.SeedFromMnemonic(viper.GetString("mnemonic"))
+	if err != nil {
+		return err
+	}
+
+	data.index = viper.GetUint64("index")
+	data.count = viper.GetUint64("count")
+	if data.count == 0 {
+		return errors.New("count must be at least 1")
+	}
+
+	data.outputDir = viper.GetString("output-dir")
+	if data.outputDir == "" {
+		return errors.New("output directory is required to generate deposits from a mnemonic")
+	}
+
+	data.keystorePassphrase, err = ethdoutil.GetPassphrase()
+	if err != nil {
+		return errors.Wrap(err, "keystore passphrase is required to generate deposits from a mnemonic")
+	}
+
+	return nil
+}
+
 func inputForkVersion(ctx context.Context) (*spec.Version, error) {
+	if viper.GetString("network") != "" && viper.GetString("forkversion") != "" {
+		return nil, errors.New("only one of network and forkversion can be supplied")
+	}
+
 	// Default to mainnet.
 	forkVersion := &spec.Version{0x00, 0x00, 0x00, 0x00}
 
 	// Override if supplied.
+	if viper.GetString("network") != "" {
+		networkForkVersion, err := ethdoutil.NetworkGenesisForkVersion(viper.GetString("network"))
+		if err != nil {
+			return nil, err
+		}
+		copy(forkVersion[:], networkForkVersion[:])
+	}
 	if viper.GetString("forkversion") != "" {
 		data, err := hex.DecodeString(strings.TrimPrefix(viper.GetString("forkversion"), "0x"))
 		if err != nil {
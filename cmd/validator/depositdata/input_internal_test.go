@@ -82,7 +82,7 @@ func TestInput(t *testing.T) {
 	}{
 		{
 			name: "Nil",
-			err:  "validator account is required",
+			err:  "validator account or mnemonic is required",
 		},
 		{
 			name: "TimeoutMissing",
@@ -102,7 +102,7 @@ func TestInput(t *testing.T) {
 				"depositvalue":      "32 Ether",
 				"forkversion":       "0x01020304",
 			},
-			err: "validator account is required",
+			err: "validator account or mnemonic is required",
 		},
 		{
 			name: "ValidatorAccountUnknown",
@@ -234,6 +234,52 @@ func TestInput(t *testing.T) {
 			},
 			err: "failed to obtain fork version: fork version must be exactly 4 bytes in length",
 		},
+		{
+			name: "NetworkAndForkVersion",
+			vars: map[string]interface{}{
+				"timeout":           "10s",
+				"validatoraccount":  "Test/Interop 0",
+				"withdrawalaccount": "Test/Interop 0",
+				"depositvalue":      "32 Ether",
+				"forkversion":       "0x01020304",
+				"network":           "prater",
+			},
+			err: "failed to obtain fork version: only one of network and forkversion can be supplied",
+		},
+		{
+			name: "NetworkUnknown",
+			vars: map[string]interface{}{
+				"timeout":           "10s",
+				"validatoraccount":  "Test/Interop 0",
+				"withdrawalaccount": "Test/Interop 0",
+				"depositvalue":      "32 Ether",
+				"network":           "unknown",
+			},
+			err: "failed to obtain fork version: unknown network unknown",
+		},
+		{
+			name: "GoodCustomNetwork",
+			vars: map[string]interface{}{
+				"timeout":           "10s",
+				"validatoraccount":  "Test/Interop 0",
+				"withdrawalaccount": "Test/Interop 0",
+				"depositvalue":      "32 Ether",
+				"network":           "mydevnet",
+				"networks": map[string]interface{}{
+					"mydevnet": map[string]interface{}{
+						"genesis-fork-version": "0x01020304",
+					},
+				},
+			},
+			res: &dataIn{
+				format:            "json",
+				withdrawalAccount: "Test/Interop 0",
+				amount:            32000000000,
+				validatorAccounts: []e2wtypes.Account{interop0},
+				forkVersion:       forkVersion,
+				domain:            domain,
+			},
+		},
 		{
 			name: "Good",
 			vars: map[string]interface{}{
@@ -287,6 +333,81 @@ func TestInput(t *testing.T) {
 				domain:            domain,
 			},
 		},
+		{
+			name: "ValidatorAccountAndMnemonic",
+			vars: map[string]interface{}{
+				"timeout":           "10s",
+				"validatoraccount":  "Test/Interop 0",
+				"mnemonic":          "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon art",
+				"withdrawalaccount": "Test/Interop 0",
+				"depositvalue":      "32 Ether",
+			},
+			err: "only one of validator account and mnemonic is allowed",
+		},
+		{
+			name: "MnemonicInvalid",
+			vars: map[string]interface{}{
+				"timeout":      "10s",
+				"mnemonic":     "invalid",
+				"count":        1,
+				"output-dir":   "validator_keys",
+				"depositvalue": "32 Ether",
+			},
+			err: "mnemonic is invalid",
+		},
+		{
+			name: "MnemonicCountZero",
+			vars: map[string]interface{}{
+				"timeout":      "10s",
+				"mnemonic":     "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon art",
+				"output-dir":   "validator_keys",
+				"depositvalue": "32 Ether",
+			},
+			err: "count must be at least 1",
+		},
+		{
+			name: "MnemonicOutputDirMissing",
+			vars: map[string]interface{}{
+				"timeout":      "10s",
+				"mnemonic":     "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon art",
+				"count":        1,
+				"depositvalue": "32 Ether",
+			},
+			err: "output directory is required to generate deposits from a mnemonic",
+		},
+		{
+			name: "MnemonicPassphraseMissing",
+			vars: map[string]interface{}{
+				"timeout":      "10s",
+				"mnemonic":     "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon art",
+				"count":        1,
+				"output-dir":   "validator_keys",
+				"depositvalue": "32 Ether",
+			},
+			err: "keystore passphrase is required to generate deposits from a mnemonic: passphrase is required",
+		},
+		{
+			name: "GoodMnemonic",
+			vars: map[string]interface{}{
+				"timeout":      "10s",
+				"mnemonic":     "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon art",
+				"count":        2,
+				"index":        5,
+				"output-dir":   "validator_keys",
+				"passphrase":   []string{"keystore secret"},
+				"depositvalue": "32 Ether",
+			},
+			res: &dataIn{
+				format:             "launchpad",
+				amount:             32000000000,
+				forkVersion:        mainnetForkVersion,
+				domain:             mainnetDomain,
+				index:              5,
+				count:              2,
+				outputDir:          "validator_keys",
+				keystorePassphrase: "keystore secret",
+			},
+		},
 	}
 
 	for _, test := range tests {
@@ -308,6 +429,10 @@ func TestInput(t *testing.T) {
 				require.Equal(t, test.res.amount, res.amount)
 				require.Equal(t, test.res.forkVersion, res.forkVersion)
 				require.Equal(t, test.res.domain, res.domain)
+				require.Equal(t, test.res.index, res.index)
+				require.Equal(t, test.res.count, res.count)
+				require.Equal(t, test.res.outputDir, res.outputDir)
+				require.Equal(t, test.res.keystorePassphrase, res.keystorePassphrase)
 				require.Equal(t, len(test.res.validatorAccounts), len(res.validatorAccounts))
 				for i := range test.res.validatorAccounts {
 					require.Equal(t, test.res.validatorAccounts[i].ID(), res.validatorAccounts[i].ID())
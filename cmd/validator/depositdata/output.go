@@ -15,8 +15,13 @@ package depositdata
 
 import (
 	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"strings"
+	"time"
 
+	ethdoutil "github.com/aaron-alderman/ethdo/util"
 	spec "github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/pkg/errors"
 )
@@ -31,6 +36,14 @@ type dataOut struct {
 	forkVersion           *spec.Version
 	depositDataRoot       *spec.Root
 	depositMessageRoot    *spec.Root
+	// Keystore, if the validator key was generated.
+	path     string
+	keystore []byte
+}
+
+// filesJSON is the structured output of deposit data written to a directory.
+type filesJSON struct {
+	Files []string `json:"files"`
 }
 
 func output(data []*dataOut) (string, error) {
@@ -57,6 +70,39 @@ func output(data []*dataOut) (string, error) {
 	return fmt.Sprintf("[%s]", strings.Join(outputs, ",")), nil
 }
 
+// outputFiles writes deposit data and keystores to a directory, with the same names as
+// those generated by the deposit CLI.  It returns the names of the files written.
+func outputFiles(dir string, data []*dataOut) (string, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return "", errors.Wrap(err, "failed to create output directory")
+	}
+	timestamp := time.Now().Unix()
+
+	files := make([]string, 0, len(data)+1)
+	for _, datum := range data {
+		if datum == nil || datum.keystore == nil {
+			continue
+		}
+		filename := filepath.Join(dir, fmt.Sprintf("keystore-%s-%d.json", strings.ReplaceAll(datum.path, "/", "_"), timestamp))
+		if err := ioutil.WriteFile(filename, datum.keystore, 0600); err != nil {
+			return "", errors.Wrap(err, "failed to write keystore")
+		}
+		files = append(files, filename)
+	}
+
+	depositData, err := output(data)
+	if err != nil {
+		return "", err
+	}
+	filename := filepath.Join(dir, fmt.Sprintf("deposit_data-%d.json", timestamp))
+	if err := ioutil.WriteFile(filename, []byte(depositData), 0600); err != nil {
+		return "", errors.Wrap(err, "failed to write deposit data")
+	}
+	files = append(files, filename)
+
+	return strings.Join(files, "\n"), nil
+}
+
 func validatorDepositDataOutputRaw(datum *dataOut) (string, error) {
 	if datum.validatorPubKey == nil {
 		return "", errors.New("validator public key required")
@@ -105,15 +151,6 @@ func validatorDepositDataOutputRaw(datum *dataOut) (string, error) {
 }
 
 func validatorDepositDataOutputLaunchpad(datum *dataOut) (string, error) {
-	// Map of fork version to network name.
-	forkVersionMap := map[spec.Version]string{
-		[4]byte{0x00, 0x00, 0x00, 0x00}: "mainnet",
-		[4]byte{0x00, 0x00, 0x20, 0x09}: "pyrmont",
-		[4]byte{0x00, 0x00, 0x10, 0x20}: "prater",
-		[4]byte{0x80, 0x00, 0x00, 0x69}: "ropsten",
-		[4]byte{0x90, 0x00, 0x00, 0x69}: "sepolia",
-	}
-
 	if datum.validatorPubKey == nil {
 		return "", errors.New("validator public key required")
 	}
@@ -133,10 +170,7 @@ func validatorDepositDataOutputLaunchpad(datum *dataOut) (string, error) {
 		return "", errors.New("deposit data root required")
 	}
 
-	networkName := "unknown"
-	if network, exists := forkVersionMap[*datum.forkVersion]; exists {
-		networkName = network
-	}
+	networkName := strings.ToLower(ethdoutil.NetworkForGenesisForkVersion(*datum.forkVersion))
 	output := fmt.Sprintf(`{"pubkey":"%x","withdrawal_credentials":"%x","amount":%d,"signature":"%x","deposit_message_root":"%x","deposit_data_root":"%x","fork_version":"%x","eth2_network_name":"%s","deposit_cli_version":"1.1.0"}`,
 		*datum.validatorPubKey,
 		datum.withdrawalCredentials,
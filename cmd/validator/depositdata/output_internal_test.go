@@ -14,6 +14,9 @@
 package depositdata
 
 import (
+	"io/ioutil"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/aaron-alderman/ethdo/testutil"
@@ -689,3 +692,40 @@ func TestOutputRaw(t *testing.T) {
 		})
 	}
 }
+
+func TestOutputFiles(t *testing.T) {
+	validatorPubKey := testutil.HexToPubKey("0xa99a76ed7796f7be22d5b7e85deeb7c5677e88e511e0b337618f8c4eb61349b4bf2d153f649f7b53359fe8b94a38e44c")
+	signature := testutil.HexToSignature("0xb7a757a4c506ac6ac5f2d23e065de7d00dc9f5a6a3f9610a8b60b65f166379139ae382c91ecbbf5c9fabc34b1cd2cf8f0211488d50d8754716d8e72e17c1a00b5d9b37cc73767946790ebe66cf9669abfc5c25c67e1e2d1c2e11429d149c25a2")
+	forkVersion := testutil.HexToVersion("0x00000000")
+	depositDataRoot := testutil.HexToRoot("0x9e51b386f4271c18149dd0f73297a26a4a8c15c3622c44af79c92446f44a3554")
+	depositMessageRoot := testutil.HexToRoot("0x139b510ea7f2788ab82da1f427d6cbe1db147c15a053db738ad5500cd83754a6")
+
+	dir := filepath.Join(t.TempDir(), "validator_keys")
+	res, err := outputFiles(dir, []*dataOut{
+		{
+			format:                "launchpad",
+			validatorPubKey:       &validatorPubKey,
+			withdrawalCredentials: testutil.HexToBytes("0x00fad2a6bfb0e7f1f0f45460944fbd8dfa7f37da06a4d13b3983cc90bb46963b"),
+			amount:                32000000000,
+			signature:             &signature,
+			forkVersion:           &forkVersion,
+			depositDataRoot:       &depositDataRoot,
+			depositMessageRoot:    &depositMessageRoot,
+			path:                  "m/12381/3600/0/0/0",
+			keystore:              []byte(`{"path":"m/12381/3600/0/0/0"}`),
+		},
+	})
+	require.NoError(t, err)
+
+	files := strings.Split(res, "\n")
+	require.Len(t, files, 2)
+	require.Regexp(t, `/keystore-m_12381_3600_0_0_0-[0-9]+\.json$`, files[0])
+	keystore, err := ioutil.ReadFile(files[0])
+	require.NoError(t, err)
+	require.Equal(t, `{"path":"m/12381/3600/0/0/0"}`, string(keystore))
+
+	require.Regexp(t, `/deposit_data-[0-9]+\.json$`, files[1])
+	depositData, err := ioutil.ReadFile(files[1])
+	require.NoError(t, err)
+	require.Equal(t, `[{"pubkey":"a99a76ed7796f7be22d5b7e85deeb7c5677e88e511e0b337618f8c4eb61349b4bf2d153f649f7b53359fe8b94a38e44c","withdrawal_credentials":"00fad2a6bfb0e7f1f0f45460944fbd8dfa7f37da06a4d13b3983cc90bb46963b","amount":32000000000,"signature":"b7a757a4c506ac6ac5f2d23e065de7d00dc9f5a6a3f9610a8b60b65f166379139ae382c91ecbbf5c9fabc34b1cd2cf8f0211488d50d8754716d8e72e17c1a00b5d9b37cc73767946790ebe66cf9669abfc5c25c67e1e2d1c2e11429d149c25a2","deposit_message_root":"139b510ea7f2788ab82da1f427d6cbe1db147c15a053db738ad5500cd83754a6","deposit_data_root":"9e51b386f4271c18149dd0f73297a26a4a8c15c3622c44af79c92446f44a3554","fork_version":"00000000","eth2_network_name":"mainnet","deposit_cli_version":"1.1.0"}]`, string(depositData))
+}
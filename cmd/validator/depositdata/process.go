@@ -16,15 +16,18 @@ package depositdata
 import (
 	"context"
 	"encoding/hex"
+	"encoding/json"
 	"fmt"
 	"strings"
 
 	"github.com/aaron-alderman/ethdo/signing"
 	ethdoutil "github.com/aaron-alderman/ethdo/util"
 	spec "github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/google/uuid"
 	"github.com/pkg/errors"
 	e2types "github.com/wealdtech/go-eth2-types/v2"
 	util "github.com/wealdtech/go-eth2-util"
+	keystorev4 "github.com/wealdtech/go-eth2-wallet-encryptor-keystorev4"
 	e2wtypes "github.com/wealdtech/go-eth2-wallet-types/v2"
 )
 
@@ -33,6 +36,10 @@ func process(data *dataIn) ([]*dataOut, error) {
 		return nil, errors.New("no data")
 	}
 
+	if data.seed != nil {
+		return processMnemonic(data)
+	}
+
 	results := make([]*dataOut, 0)
 
 	withdrawalCredentials, err := createWithdrawalCredentials(data)
@@ -48,53 +55,167 @@ func process(data *dataIn) ([]*dataOut, error) {
 
 		var pubKey spec.BLSPubKey
 		copy(pubKey[:], validatorPubKey.Marshal())
-		depositMessage := &spec.DepositMessage{
-			PublicKey:             pubKey,
-			WithdrawalCredentials: withdrawalCredentials,
-			Amount:                data.amount,
+		result, err := createDeposit(data, pubKey, withdrawalCredentials, func(root spec.Root) (spec.BLSSignature, error) {
+			return signing.SignRoot(context.Background(), validatorAccount, data.passphrases, root, *data.domain)
+		})
+		if err != nil {
+			return nil, err
 		}
-		root, err := depositMessage.HashTreeRoot()
+
+		validatorWallet := validatorAccount.(e2wtypes.AccountWalletProvider).Wallet()
+		result.account = fmt.Sprintf("%s/%s", validatorWallet.Name(), validatorAccount.Name())
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+// processMnemonic generates deposits and keystores for validator keys derived from a seed
+// at EIP-2334 paths.
+func processMnemonic(data *dataIn) ([]*dataOut, error) {
+	if !ethdoutil.AcceptablePassphrase(data.keystorePassphrase) {
+		return nil, errors.New("supplied passphrase is weak; use a stronger one or run with the --allow-weak-passphrases flag")
+	}
+
+	// If no withdrawal details are supplied each validator uses the withdrawal key of
+	// the same index from the seed.
+	var withdrawalCredentials []byte
+	if data.withdrawalAccount != "" || data.withdrawalPubKey != "" || data.withdrawalAddress != "" {
+		var err error
+		withdrawalCredentials, err = createWithdrawalCredentials(data)
 		if err != nil {
-			return nil, errors.Wrap(err, "failed to generate deposit message root")
+			return nil, err
 		}
-		var depositMessageRoot spec.Root
-		copy(depositMessageRoot[:], root[:])
+	}
 
-		sig, err := signing.SignRoot(context.Background(), validatorAccount, data.passphrases, depositMessageRoot, *data.domain)
+	encryptor := keystorev4.New()
+	results := make([]*dataOut, 0, data.count)
+	for index := data.index; index < data.index+data.count; index++ {
+		path := fmt.Sprintf("m/12381/3600/%d/0/0", index)
+		validatorKey, err := util.PrivateKeyFromSeedAndPath(data.seed, path)
 		if err != nil {
-			return nil, errors.Wrap(err, "failed to sign deposit message")
+			return nil, errors.Wrap(err, fmt.Sprintf("failed to generate validator key for path %s", path))
 		}
 
-		depositData := &spec.DepositData{
-			PublicKey:             pubKey,
-			WithdrawalCredentials: withdrawalCredentials,
-			Amount:                data.amount,
-			Signature:             sig,
+		credentials := withdrawalCredentials
+		if credentials == nil {
+			withdrawalPath := fmt.Sprintf("m/12381/3600/%d/0", index)
+			withdrawalKey, err := util.PrivateKeyFromSeedAndPath(data.seed, withdrawalPath)
+			if err != nil {
+				return nil, errors.Wrap(err, fmt.Sprintf("failed to generate withdrawal key for path %s", withdrawalPath))
+			}
+			credentials = ethdoutil.BLSWithdrawalCredentials(withdrawalKey.PublicKey().Marshal())
 		}
 
-		root, err = depositData.HashTreeRoot()
+		var pubKey spec.BLSPubKey
+		copy(pubKey[:], validatorKey.PublicKey().Marshal())
+		result, err := createDeposit(data, pubKey, credentials, func(root spec.Root) (spec.BLSSignature, error) {
+			container := &spec.SigningData{
+				ObjectRoot: root,
+				Domain:     *data.domain,
+			}
+			signingRoot, err := container.HashTreeRoot()
+			if err != nil {
+				return spec.BLSSignature{}, errors.Wrap(err, "failed to generate signing root")
+			}
+			var sig spec.BLSSignature
+			copy(sig[:], validatorKey.Sign(signingRoot[:]).Marshal())
+			if err := ethdoutil.AuditSigning(pubKey[:], root[:], data.domain[:]); err != nil {
+				return spec.BLSSignature{}, errors.Wrap(err, "failed to record signing in audit log")
+			}
+			return sig, nil
+		})
 		if err != nil {
-			return nil, errors.Wrap(err, "failed to generate deposit data root")
+			return nil, err
 		}
-		var depositDataRoot spec.Root
-		copy(depositDataRoot[:], root[:])
 
-		validatorWallet := validatorAccount.(e2wtypes.AccountWalletProvider).Wallet()
-		results = append(results, &dataOut{
-			format:                data.format,
-			account:               fmt.Sprintf("%s/%s", validatorWallet.Name(), validatorAccount.Name()),
-			validatorPubKey:       &pubKey,
-			withdrawalCredentials: withdrawalCredentials,
-			amount:                data.amount,
-			signature:             &sig,
-			forkVersion:           data.forkVersion,
-			depositMessageRoot:    &depositMessageRoot,
-			depositDataRoot:       &depositDataRoot,
-		})
+		result.path = path
+		result.keystore, err = createKeystore(encryptor, validatorKey, path, data.keystorePassphrase)
+		if err != nil {
+			return nil, err
+		}
+		if err := ethdoutil.AuditExport(fmt.Sprintf("keystore for path %s", path), pubKey[:]); err != nil {
+			return nil, errors.Wrap(err, "failed to record export in audit log")
+		}
+		results = append(results, result)
 	}
+
 	return results, nil
 }
 
+// createDeposit creates a signed deposit for the given validator.
+func createDeposit(data *dataIn,
+	pubKey spec.BLSPubKey,
+	withdrawalCredentials []byte,
+	sign func(spec.Root) (spec.BLSSignature, error),
+) (
+	*dataOut,
+	error,
+) {
+	depositMessage := &spec.DepositMessage{
+		PublicKey:             pubKey,
+		WithdrawalCredentials: withdrawalCredentials,
+		Amount:                data.amount,
+	}
+	root, err := depositMessage.HashTreeRoot()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to generate deposit message root")
+	}
+	var depositMessageRoot spec.Root
+	copy(depositMessageRoot[:], root[:])
+
+	sig, err := sign(depositMessageRoot)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to sign deposit message")
+	}
+
+	depositData := &spec.DepositData{
+		PublicKey:             pubKey,
+		WithdrawalCredentials: withdrawalCredentials,
+		Amount:                data.amount,
+		Signature:             sig,
+	}
+
+	root, err = depositData.HashTreeRoot()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to generate deposit data root")
+	}
+	var depositDataRoot spec.Root
+	copy(depositDataRoot[:], root[:])
+
+	return &dataOut{
+		format:                data.format,
+		validatorPubKey:       &pubKey,
+		withdrawalCredentials: withdrawalCredentials,
+		amount:                data.amount,
+		signature:             &sig,
+		forkVersion:           data.forkVersion,
+		depositMessageRoot:    &depositMessageRoot,
+		depositDataRoot:       &depositDataRoot,
+	}, nil
+}
+
+// createKeystore creates an EIP-2335 keystore for a key.
+func createKeystore(encryptor *keystorev4.Encryptor, key *e2types.BLSPrivateKey, path string, passphrase string) ([]byte, error) {
+	crypto, err := encryptor.Encrypt(key.Marshal(), passphrase)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to encrypt key")
+	}
+	keystore := map[string]interface{}{
+		"crypto":      crypto,
+		"description": "",
+		"pubkey":      fmt.Sprintf("%x", key.PublicKey().Marshal()),
+		"path":        path,
+		"uuid":        uuid.New().String(),
+		"version":     4,
+	}
+	data, err := json.Marshal(keystore)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to generate keystore")
+	}
+
+	return data, nil
+}
+
 // createWithdrawalCredentials creates withdrawal credentials given an account, public key or Ethereum 1 address.
 func createWithdrawalCredentials(data *dataIn) ([]byte, error) {
 	var withdrawalCredentials []byte
@@ -171,3 +292,38 @@ func addressBytesToEIP55(address []byte) string {
 
 	return fmt.Sprintf("0x%s", string(bytes))
 }
+
+// depositHistoryWarnings returns warnings about prior deposits for the validators in the
+// generated deposit data, if a deposit history source is configured.
+func depositHistoryWarnings(ctx context.Context, data []*dataOut) ([]string, error) {
+	warnings := make([]string, 0)
+	if len(data) == 0 {
+		return warnings, nil
+	}
+
+	network := ethdoutil.NetworkForGenesisForkVersion(*data[0].forkVersion)
+	depositHistory, err := ethdoutil.DepositHistory(ctx, network, nil)
+	if err != nil {
+		return nil, err
+	}
+	if depositHistory == nil {
+		return warnings, nil
+	}
+
+	pubKeys := make([]spec.BLSPubKey, 0, len(data))
+	for _, datum := range data {
+		pubKeys = append(pubKeys, *datum.validatorPubKey)
+	}
+	history, err := depositHistory.Deposits(ctx, pubKeys)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to obtain deposit history")
+	}
+
+	for _, datum := range data {
+		for _, warning := range ethdoutil.DepositHistoryWarnings(history[*datum.validatorPubKey], datum.withdrawalCredentials, *datum.forkVersion) {
+			warnings = append(warnings, fmt.Sprintf("%#x: %s", *datum.validatorPubKey, warning))
+		}
+	}
+
+	return warnings, nil
+}
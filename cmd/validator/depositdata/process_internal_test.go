@@ -16,14 +16,21 @@ package depositdata
 import (
 	"context"
 	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
 	"github.com/aaron-alderman/ethdo/testutil"
+	ethdoutil "github.com/aaron-alderman/ethdo/util"
 	spec "github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/spf13/viper"
 	"github.com/stretchr/testify/require"
 	e2types "github.com/wealdtech/go-eth2-types/v2"
+	util "github.com/wealdtech/go-eth2-util"
 	keystorev4 "github.com/wealdtech/go-eth2-wallet-encryptor-keystorev4"
 	nd "github.com/wealdtech/go-eth2-wallet-nd/v2"
 	scratch "github.com/wealdtech/go-eth2-wallet-store-scratch"
@@ -337,6 +344,144 @@ func TestProcess(t *testing.T) {
 	}
 }
 
+func TestProcessMnemonic(t *testing.T) {
+	require.NoError(t, e2types.InitBLS())
+
+	seed, err := ethdoutil.SeedFromMnemonic("abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon art")
+	require.NoError(t, err)
+
+	mainnetForkVersion := testutil.HexToVersion("0x00000000")
+	mainnetDomain := testutil.HexToDomain("0x03000000f5a5fd42d16a20302798ef6ed309979b43003d2320d9f0e8ea9831a9")
+
+	tests := []struct {
+		name                  string
+		dataIn                *dataIn
+		withdrawalCredentials []byte
+		err                   string
+	}{
+		{
+			name: "WeakPassphrase",
+			dataIn: &dataIn{
+				format:             "launchpad",
+				amount:             32000000000,
+				forkVersion:        &mainnetForkVersion,
+				domain:             &mainnetDomain,
+				seed:               seed,
+				count:              1,
+				keystorePassphrase: "pass",
+			},
+			err: "supplied passphrase is weak; use a stronger one or run with the --allow-weak-passphrases flag",
+		},
+		{
+			name: "SeedWithdrawalKeys",
+			dataIn: &dataIn{
+				format:             "launchpad",
+				amount:             32000000000,
+				forkVersion:        &mainnetForkVersion,
+				domain:             &mainnetDomain,
+				seed:               seed,
+				index:              9,
+				count:              2,
+				keystorePassphrase: "a strong keystore secret",
+			},
+		},
+		{
+			name: "WithdrawalAddress",
+			dataIn: &dataIn{
+				format:             "launchpad",
+				withdrawalAddress:  "0x30C99930617B7b793beaB603ecEB08691005f2E5",
+				amount:             32000000000,
+				forkVersion:        &mainnetForkVersion,
+				domain:             &mainnetDomain,
+				seed:               seed,
+				count:              1,
+				keystorePassphrase: "a strong keystore secret",
+			},
+			withdrawalCredentials: testutil.HexToBytes("0x01000000000000000000000030c99930617b7b793beab603eceb08691005f2e5"),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			res, err := process(test.dataIn)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, res, int(test.dataIn.count))
+			for i, datum := range res {
+				index := test.dataIn.index + uint64(i)
+				require.Equal(t, fmt.Sprintf("m/12381/3600/%d/0/0", index), datum.path)
+				validatorKey, err := util.PrivateKeyFromSeedAndPath(seed, datum.path)
+				require.NoError(t, err)
+				require.Equal(t, validatorKey.PublicKey().Marshal(), datum.validatorPubKey[:])
+
+				// Withdrawal credentials default to the withdrawal key of the same index.
+				if test.withdrawalCredentials != nil {
+					require.Equal(t, test.withdrawalCredentials, datum.withdrawalCredentials)
+				} else {
+					withdrawalKey, err := util.PrivateKeyFromSeedAndPath(seed, fmt.Sprintf("m/12381/3600/%d/0", index))
+					require.NoError(t, err)
+					require.Equal(t, ethdoutil.BLSWithdrawalCredentials(withdrawalKey.PublicKey().Marshal()), datum.withdrawalCredentials)
+				}
+
+				// Ensure the signature verifies.
+				container := &spec.SigningData{
+					ObjectRoot: *datum.depositMessageRoot,
+					Domain:     mainnetDomain,
+				}
+				signingRoot, err := container.HashTreeRoot()
+				require.NoError(t, err)
+				sig, err := e2types.BLSSignatureFromBytes(append([]byte{}, datum.signature[:]...))
+				require.NoError(t, err)
+				require.True(t, sig.Verify(signingRoot[:], validatorKey.PublicKey()))
+
+				// Ensure the keystore decrypts to the validator key.
+				keystore := make(map[string]interface{})
+				require.NoError(t, json.Unmarshal(datum.keystore, &keystore))
+				require.Equal(t, datum.path, keystore["path"])
+				require.Equal(t, fmt.Sprintf("%x", validatorKey.PublicKey().Marshal()), keystore["pubkey"])
+				key, err := keystorev4.New().Decrypt(keystore["crypto"].(map[string]interface{}), test.dataIn.keystorePassphrase)
+				require.NoError(t, err)
+				require.Equal(t, validatorKey.Marshal(), key)
+			}
+		})
+	}
+}
+
+func TestProcessMnemonicAudit(t *testing.T) {
+	require.NoError(t, e2types.InitBLS())
+
+	seed, err := ethdoutil.SeedFromMnemonic("abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon art")
+	require.NoError(t, err)
+	mainnetForkVersion := testutil.HexToVersion("0x00000000")
+	mainnetDomain := testutil.HexToDomain("0x03000000f5a5fd42d16a20302798ef6ed309979b43003d2320d9f0e8ea9831a9")
+
+	logFile := filepath.Join(t.TempDir(), "audit.jsonl")
+	viper.Set("log", logFile)
+	defer viper.Reset()
+
+	_, err = process(&dataIn{
+		format:             "launchpad",
+		amount:             32000000000,
+		forkVersion:        &mainnetForkVersion,
+		domain:             &mainnetDomain,
+		seed:               seed,
+		count:              2,
+		keystorePassphrase: "a strong keystore secret",
+	})
+	require.NoError(t, err)
+
+	// Each validator has its deposit signing and keystore export recorded.
+	f, err := os.Open(logFile)
+	require.NoError(t, err)
+	defer f.Close()
+	summary, err := ethdoutil.VerifyAuditLog(f, "")
+	require.NoError(t, err)
+	require.Equal(t, uint64(4), summary.Entries)
+}
+
 func TestAddressBytesToEIP55(t *testing.T) {
 	tests := []string{
 		"0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed",
@@ -351,3 +496,88 @@ func TestAddressBytesToEIP55(t *testing.T) {
 		require.Equal(t, addressBytesToEIP55(bytes), test)
 	}
 }
+
+func TestDepositHistoryWarnings(t *testing.T) {
+	dir, err := ioutil.TempDir("", "")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "deposits.json")
+	require.NoError(t, ioutil.WriteFile(path, []byte(`[{"index":"0","pubkey":"0xa99a76ed7796f7be22d5b7e85deeb7c5677e88e511e0b337618f8c4eb61349b4bf2d153f649f7b53359fe8b94a38e44c","withdrawal_credentials":"0x007e28dcf9029e8d92ca4b5d01c66c934e7f3110606f34ae3052cbf67bd3fc02","amount":"32000000000","signature":"0xa1eac56d32da23cef57214f9670399615af931659b27728ff1910594ec3a7eea5bf4e75b6d0ab28f3754c1a600acf2bb1632e9ce853b927eabe839e111d4d629d89626214e8e0d52f7622cba26a4ae42d878b73046522f59a104a88a831cd8e9"}]`), 0600))
+
+	pubKey := spec.BLSPubKey{}
+	copy(pubKey[:], testutil.HexToBytes("0xa99a76ed7796f7be22d5b7e85deeb7c5677e88e511e0b337618f8c4eb61349b4bf2d153f649f7b53359fe8b94a38e44c"))
+	otherPubKey := spec.BLSPubKey{0x01}
+
+	tests := []struct {
+		name     string
+		source   string
+		data     []*dataOut
+		err      string
+		warnings []string
+	}{
+		{
+			name: "NoSource",
+			data: []*dataOut{
+				{
+					validatorPubKey:       &pubKey,
+					withdrawalCredentials: testutil.HexToBytes("0x007e28dcf9029e8d92ca4b5d01c66c934e7f3110606f34ae3052cbf67bd3fc02"),
+					forkVersion:           &spec.Version{},
+				},
+			},
+			warnings: []string{},
+		},
+		{
+			name:   "NoPriorDeposits",
+			source: path,
+			data: []*dataOut{
+				{
+					validatorPubKey:       &otherPubKey,
+					withdrawalCredentials: testutil.HexToBytes("0x007e28dcf9029e8d92ca4b5d01c66c934e7f3110606f34ae3052cbf67bd3fc02"),
+					forkVersion:           &spec.Version{},
+				},
+			},
+			warnings: []string{},
+		},
+		{
+			name:   "PriorDeposits",
+			source: path,
+			data: []*dataOut{
+				{
+					validatorPubKey:       &pubKey,
+					withdrawalCredentials: testutil.HexToBytes("0x0100000000000000000000008c1ff978036f2e9d7cc382eff7b4c8c53c22ac15"),
+					forkVersion:           &spec.Version{},
+				},
+			},
+			warnings: []string{
+				"0xa99a76ed7796f7be22d5b7e85deeb7c5677e88e511e0b337618f8c4eb61349b4bf2d153f649f7b53359fe8b94a38e44c: validator already has 1 deposit(s) totalling 32 Ether",
+				"0xa99a76ed7796f7be22d5b7e85deeb7c5677e88e511e0b337618f8c4eb61349b4bf2d153f649f7b53359fe8b94a38e44c: withdrawal credentials 0x0100000000000000000000008c1ff978036f2e9d7cc382eff7b4c8c53c22ac15 do not match 0x007e28dcf9029e8d92ca4b5d01c66c934e7f3110606f34ae3052cbf67bd3fc02 of the first valid deposit, which are those used by the validator",
+			},
+		},
+		{
+			name:   "UnknownNetworkJSONRPC",
+			source: "http://localhost:8545",
+			data: []*dataOut{
+				{
+					validatorPubKey:       &pubKey,
+					withdrawalCredentials: testutil.HexToBytes("0x007e28dcf9029e8d92ca4b5d01c66c934e7f3110606f34ae3052cbf67bd3fc02"),
+					forkVersion:           &spec.Version{0xff, 0xff, 0xff, 0xff},
+				},
+			},
+			err: "failed to obtain deposit contract: unknown network Unknown",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			viper.Reset()
+			viper.Set("deposit-history", test.source)
+			warnings, err := depositHistoryWarnings(context.Background(), test.data)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, test.warnings, warnings)
+		})
+	}
+}
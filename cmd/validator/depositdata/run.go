@@ -14,6 +14,12 @@
 package depositdata
 
 import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	ethdoutil "github.com/aaron-alderman/ethdo/util"
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
@@ -34,6 +40,31 @@ func Run(cmd *cobra.Command) (string, error) {
 		return "", errors.Wrap(err, "failed to process")
 	}
 
+	warnings, err := depositHistoryWarnings(context.Background(), dataOut)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to check deposit history")
+	}
+	if !viper.GetBool("quiet") {
+		for _, warning := range warnings {
+			fmt.Fprintf(os.Stderr, "Warning: %s\n", warning)
+		}
+	}
+
+	if dataIn.outputDir != "" {
+		// Files are written regardless of quiet mode.
+		results, err := outputFiles(dataIn.outputDir, dataOut)
+		if err != nil {
+			return "", errors.Wrap(err, "failed to write output")
+		}
+		if viper.GetBool("quiet") {
+			return "", nil
+		}
+		if format := ethdoutil.OutputFormat(); ethdoutil.StructuredFormat(format) {
+			return ethdoutil.FormatOutput(format, &filesJSON{Files: strings.Split(results, "\n")})
+		}
+		return results, nil
+	}
+
 	if viper.GetBool("quiet") {
 		return "", nil
 	}
@@ -43,5 +74,13 @@ func Run(cmd *cobra.Command) (string, error) {
 		return "", errors.Wrap(err, "failed to obtain output")
 	}
 
+	// Non-raw deposit data is JSON, so can be provided in other structured formats.
+	if format := ethdoutil.OutputFormat(); ethdoutil.StructuredFormat(format) && dataIn.format != "raw" {
+		results, err = ethdoutil.FormatJSONData(format, []byte(results))
+		if err != nil {
+			return "", errors.Wrap(err, "failed to format output")
+		}
+	}
+
 	return results, nil
 }
@@ -17,6 +17,7 @@ import (
 	"context"
 	"time"
 
+	"github.com/aaron-alderman/ethdo/util"
 	"github.com/pkg/errors"
 	"github.com/spf13/viper"
 )
@@ -25,6 +26,7 @@ type dataIn struct {
 	// System.
 	timeout time.Duration
 	quiet   bool
+	format  string
 	verbose bool
 	debug   bool
 	// Ethereum 2 connection.
@@ -44,6 +46,7 @@ func input(ctx context.Context) (*dataIn, error) {
 	}
 	data.timeout = viper.GetDuration("timeout")
 	data.quiet = viper.GetBool("quiet")
+	data.format = util.OutputFormat()
 	data.verbose = viper.GetBool("verbose")
 	data.debug = viper.GetBool("debug")
 
@@ -19,13 +19,16 @@ import (
 	"strings"
 	"time"
 
+	"github.com/aaron-alderman/ethdo/util"
 	api "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/pkg/errors"
 )
 
 type dataOut struct {
 	debug                   bool
 	quiet                   bool
+	format                  string
 	verbose                 bool
 	genesisTime             time.Time
 	slotDuration            time.Duration
@@ -35,6 +38,19 @@ type dataOut struct {
 	nextEpochAttesterDuty   *api.AttesterDuty
 }
 
+type dutiesJSON struct {
+	ThisEpochAttestation *dutyJSON   `json:"this_epoch_attestation,omitempty"`
+	ThisEpochProposals   []*dutyJSON `json:"this_epoch_proposals"`
+	NextEpochAttestation *dutyJSON   `json:"next_epoch_attestation,omitempty"`
+	NextEpochStart       *time.Time  `json:"next_epoch_start,omitempty"`
+}
+
+type dutyJSON struct {
+	Slot  phase0.Slot `json:"slot"`
+	Start time.Time   `json:"start"`
+	End   time.Time   `json:"end"`
+}
+
 func output(ctx context.Context, data *dataOut) (string, error) {
 	if data == nil {
 		return "", errors.New("no data")
@@ -44,6 +60,10 @@ func output(ctx context.Context, data *dataOut) (string, error) {
 		return "", nil
 	}
 
+	if util.StructuredFormat(data.format) {
+		return outputStructured(data)
+	}
+
 	builder := strings.Builder{}
 
 	now := time.Now()
@@ -110,3 +130,33 @@ func output(ctx context.Context, data *dataOut) (string, error) {
 
 	return builder.String(), nil
 }
+
+func outputStructured(data *dataOut) (string, error) {
+	res := &dutiesJSON{
+		ThisEpochProposals: make([]*dutyJSON, 0, len(data.thisEpochProposerDuties)),
+	}
+	if data.thisEpochAttesterDuty != nil {
+		res.ThisEpochAttestation = slotDuty(data, data.thisEpochAttesterDuty.Slot)
+	}
+	for _, proposerDuty := range data.thisEpochProposerDuties {
+		res.ThisEpochProposals = append(res.ThisEpochProposals, slotDuty(data, proposerDuty.Slot))
+	}
+	if data.nextEpochAttesterDuty != nil {
+		res.NextEpochAttestation = slotDuty(data, data.nextEpochAttesterDuty.Slot)
+		nextEpoch := uint64(data.nextEpochAttesterDuty.Slot) / data.slotsPerEpoch
+		nextEpochStart := data.genesisTime.Add(time.Duration(nextEpoch*data.slotsPerEpoch) * data.slotDuration)
+		res.NextEpochStart = &nextEpochStart
+	}
+
+	return util.FormatOutput(data.format, res)
+}
+
+// slotDuty returns the timing of a duty at the given slot.
+func slotDuty(data *dataOut, slot phase0.Slot) *dutyJSON {
+	start := data.genesisTime.Add(time.Duration(slot) * data.slotDuration)
+	return &dutyJSON{
+		Slot:  slot,
+		Start: start,
+		End:   start.Add(data.slotDuration),
+	}
+}
@@ -38,6 +38,7 @@ func process(ctx context.Context, data *dataIn) (*dataOut, error) {
 	results := &dataOut{
 		debug:   data.debug,
 		quiet:   data.quiet,
+		format:  data.format,
 		verbose: data.verbose,
 	}
 
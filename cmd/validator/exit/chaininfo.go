@@ -0,0 +1,190 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatorexit
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"strconv"
+	"strings"
+
+	api "github.com/attestantio/go-eth2-client/api/v1"
+	spec "github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+)
+
+// chainInfoVersion is the version of the chain information file format.
+const chainInfoVersion = 1
+
+// chainInfo contains the chain information required to generate exits offline.
+type chainInfo struct {
+	Version               uint64
+	Validators            []*chainInfoValidator
+	GenesisValidatorsRoot spec.Root
+	Epoch                 spec.Epoch
+	Fork                  *spec.Fork
+}
+
+// chainInfoValidator contains the information about a single validator required to generate an exit offline.
+type chainInfoValidator struct {
+	Index  spec.ValidatorIndex
+	PubKey spec.BLSPubKey
+	State  api.ValidatorState
+}
+
+type chainInfoJSON struct {
+	Version               string                    `json:"version"`
+	Validators            []*chainInfoValidatorJSON `json:"validators"`
+	GenesisValidatorsRoot string                    `json:"genesis_validators_root"`
+	Epoch                 string                    `json:"epoch"`
+	Fork                  *spec.Fork                `json:"fork"`
+}
+
+type chainInfoValidatorJSON struct {
+	Index  string             `json:"index"`
+	PubKey string             `json:"pubkey"`
+	State  api.ValidatorState `json:"state"`
+}
+
+// MarshalJSON implements json.Marshaler.
+func (c *chainInfo) MarshalJSON() ([]byte, error) {
+	validators := make([]*chainInfoValidatorJSON, 0, len(c.Validators))
+	for _, validator := range c.Validators {
+		validators = append(validators, &chainInfoValidatorJSON{
+			Index:  fmt.Sprintf("%d", validator.Index),
+			PubKey: fmt.Sprintf("%#x", validator.PubKey),
+			State:  validator.State,
+		})
+	}
+
+	return json.Marshal(&chainInfoJSON{
+		Version:               fmt.Sprintf("%d", c.Version),
+		Validators:            validators,
+		GenesisValidatorsRoot: fmt.Sprintf("%#x", c.GenesisValidatorsRoot),
+		Epoch:                 fmt.Sprintf("%d", c.Epoch),
+		Fork:                  c.Fork,
+	})
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (c *chainInfo) UnmarshalJSON(input []byte) error {
+	var data chainInfoJSON
+	if err := json.Unmarshal(input, &data); err != nil {
+		return errors.Wrap(err, "invalid JSON")
+	}
+
+	if data.Version == "" {
+		return errors.New("version missing")
+	}
+	version, err := strconv.ParseUint(data.Version, 10, 64)
+	if err != nil {
+		return errors.Wrap(err, "version invalid")
+	}
+	if version != chainInfoVersion {
+		return fmt.Errorf("unsupported version %d", version)
+	}
+	c.Version = version
+
+	if data.GenesisValidatorsRoot == "" {
+		return errors.New("genesis validators root missing")
+	}
+	genesisValidatorsRoot, err := hex.DecodeString(strings.TrimPrefix(data.GenesisValidatorsRoot, "0x"))
+	if err != nil {
+		return errors.Wrap(err, "genesis validators root invalid")
+	}
+	if len(genesisValidatorsRoot) != len(c.GenesisValidatorsRoot) {
+		return errors.New("genesis validators root incorrect length")
+	}
+	copy(c.GenesisValidatorsRoot[:], genesisValidatorsRoot)
+
+	if data.Epoch == "" {
+		return errors.New("epoch missing")
+	}
+	epoch, err := strconv.ParseUint(data.Epoch, 10, 64)
+	if err != nil {
+		return errors.Wrap(err, "epoch invalid")
+	}
+	c.Epoch = spec.Epoch(epoch)
+
+	if data.Fork == nil {
+		return errors.New("fork missing")
+	}
+	c.Fork = data.Fork
+
+	c.Validators = make([]*chainInfoValidator, 0, len(data.Validators))
+	for _, validatorJSON := range data.Validators {
+		index, err := strconv.ParseUint(validatorJSON.Index, 10, 64)
+		if err != nil {
+			return errors.Wrap(err, "validator index invalid")
+		}
+		pubKey, err := hex.DecodeString(strings.TrimPrefix(validatorJSON.PubKey, "0x"))
+		if err != nil {
+			return errors.Wrap(err, "validator public key invalid")
+		}
+		if len(pubKey) != len(spec.BLSPubKey{}) {
+			return errors.New("validator public key incorrect length")
+		}
+		validator := &chainInfoValidator{
+			Index: spec.ValidatorIndex(index),
+			State: validatorJSON.State,
+		}
+		copy(validator.PubKey[:], pubKey)
+		c.Validators = append(c.Validators, validator)
+	}
+
+	return nil
+}
+
+// loadChainInfo loads chain information from a file.
+func loadChainInfo(path string) (*chainInfo, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read chain information file")
+	}
+	info := &chainInfo{}
+	if err := json.Unmarshal(data, info); err != nil {
+		return nil, errors.Wrap(err, "failed to parse chain information file")
+	}
+	return info, nil
+}
+
+// writeChainInfo writes chain information to a file.
+func writeChainInfo(path string, info *chainInfo) error {
+	data, err := json.Marshal(info)
+	if err != nil {
+		return errors.Wrap(err, "failed to generate chain information")
+	}
+	if err := ioutil.WriteFile(path, data, 0600); err != nil {
+		return errors.Wrap(err, "failed to write chain information file")
+	}
+	return nil
+}
+
+// apiValidators returns the validators in the chain information in the same form as
+// those returned by the beacon node.
+func (c *chainInfo) apiValidators() map[spec.ValidatorIndex]*api.Validator {
+	res := make(map[spec.ValidatorIndex]*api.Validator, len(c.Validators))
+	for _, validator := range c.Validators {
+		res[validator.Index] = &api.Validator{
+			Index:  validator.Index,
+			Status: validator.State,
+			Validator: &spec.Validator{
+				PublicKey: validator.PubKey,
+			},
+		}
+	}
+	return res
+}
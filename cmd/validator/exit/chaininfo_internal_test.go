@@ -0,0 +1,117 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatorexit
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"testing"
+
+	"github.com/aaron-alderman/ethdo/testutil"
+	api "github.com/attestantio/go-eth2-client/api/v1"
+	spec "github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChainInfoJSON(t *testing.T) {
+	tests := []struct {
+		name  string
+		input []byte
+		err   string
+	}{
+		{
+			name:  "Empty",
+			input: []byte{},
+			err:   "unexpected end of JSON input",
+		},
+		{
+			name:  "VersionMissing",
+			input: []byte(`{"validators":[],"genesis_validators_root":"0x0102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f20","epoch":"100","fork":{"previous_version":"0x00000000","current_version":"0x01000000","epoch":"50"}}`),
+			err:   "version missing",
+		},
+		{
+			name:  "VersionUnsupported",
+			input: []byte(`{"version":"2","validators":[],"genesis_validators_root":"0x0102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f20","epoch":"100","fork":{"previous_version":"0x00000000","current_version":"0x01000000","epoch":"50"}}`),
+			err:   "unsupported version 2",
+		},
+		{
+			name:  "GenesisValidatorsRootShort",
+			input: []byte(`{"version":"1","validators":[],"genesis_validators_root":"0x0102","epoch":"100","fork":{"previous_version":"0x00000000","current_version":"0x01000000","epoch":"50"}}`),
+			err:   "genesis validators root incorrect length",
+		},
+		{
+			name:  "EpochMissing",
+			input: []byte(`{"version":"1","validators":[],"genesis_validators_root":"0x0102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f20","fork":{"previous_version":"0x00000000","current_version":"0x01000000","epoch":"50"}}`),
+			err:   "epoch missing",
+		},
+		{
+			name:  "ForkMissing",
+			input: []byte(`{"version":"1","validators":[],"genesis_validators_root":"0x0102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f20","epoch":"100"}`),
+			err:   "fork missing",
+		},
+		{
+			name:  "ValidatorPubKeyShort",
+			input: []byte(`{"version":"1","validators":[{"index":"1","pubkey":"0x0102","state":"active_ongoing"}],"genesis_validators_root":"0x0102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f20","epoch":"100","fork":{"previous_version":"0x00000000","current_version":"0x01000000","epoch":"50"}}`),
+			err:   "validator public key incorrect length",
+		},
+		{
+			name:  "Good",
+			input: []byte(`{"version":"1","validators":[{"index":"1","pubkey":"0xa99a76ed7796f7be22d5b7e85deeb7c5677e88e511e0b337618f8c4eb61349b4bf2d153f649f7b53359fe8b94a38e44c","state":"Active_ongoing"}],"genesis_validators_root":"0x0102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f20","epoch":"100","fork":{"previous_version":"0x00000000","current_version":"0x01000000","epoch":"50"}}`),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var res chainInfo
+			err := json.Unmarshal(test.input, &res)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				rt, err := json.Marshal(&res)
+				require.NoError(t, err)
+				require.Equal(t, string(test.input), string(rt))
+			}
+		})
+	}
+}
+
+func TestChainInfoFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "offline-preparation.json")
+	info := &chainInfo{
+		Version: chainInfoVersion,
+		Validators: []*chainInfoValidator{
+			{
+				Index:  123,
+				PubKey: testutil.HexToPubKey("0xa99a76ed7796f7be22d5b7e85deeb7c5677e88e511e0b337618f8c4eb61349b4bf2d153f649f7b53359fe8b94a38e44c"),
+				State:  api.ValidatorStateActiveOngoing,
+			},
+		},
+		GenesisValidatorsRoot: spec.Root{0x01, 0x02},
+		Epoch:                 100,
+		Fork: &spec.Fork{
+			PreviousVersion: spec.Version{0x00, 0x00, 0x00, 0x00},
+			CurrentVersion:  spec.Version{0x01, 0x00, 0x00, 0x00},
+			Epoch:           50,
+		},
+	}
+	require.NoError(t, writeChainInfo(path, info))
+
+	res, err := loadChainInfo(path)
+	require.NoError(t, err)
+	require.Equal(t, info, res)
+
+	_, err = loadChainInfo(filepath.Join(t.TempDir(), "missing.json"))
+	require.Error(t, err)
+}
@@ -14,9 +14,15 @@
 package validatorexit
 
 import (
+	"bufio"
+	"bytes"
 	"context"
 	"encoding/hex"
 	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strconv"
 	"strings"
 	"time"
 
@@ -25,6 +31,7 @@ import (
 	spec "github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/pkg/errors"
 	"github.com/spf13/viper"
+	e2types "github.com/wealdtech/go-eth2-types/v2"
 	e2wtypes "github.com/wealdtech/go-eth2-wallet-types/v2"
 )
 
@@ -37,15 +44,29 @@ type dataIn struct {
 	// Operation.
 	eth2Client eth2client.Service
 	jsonOutput bool
+	format     string
 	// Chain information.
-	fork         *spec.Fork
-	currentEpoch spec.Epoch
+	fork                  *spec.Fork
+	currentEpoch          spec.Epoch
+	genesisValidatorsRoot spec.Root
 	// Exit information.
 	account             e2wtypes.Account
 	passphrases         []string
 	epoch               spec.Epoch
+	forkVersion         spec.Version
 	domain              spec.Domain
 	signedVoluntaryExit *spec.SignedVoluntaryExit
+	// Batch exit information.
+	batch                bool
+	accounts             []e2wtypes.Account
+	indices              []spec.ValidatorIndex
+	pubKeys              []spec.BLSPubKey
+	signedVoluntaryExits []*util.ValidatorExitData
+	// Offline information.
+	offline        bool
+	prepareOffline bool
+	chainInfoFile  string
+	chainInfo      *chainInfo
 }
 
 func input(ctx context.Context) (*dataIn, error) {
@@ -60,10 +81,37 @@ func input(ctx context.Context) (*dataIn, error) {
 	data.debug = viper.GetBool("debug")
 	data.passphrases = util.GetPassphrases()
 	data.jsonOutput = viper.GetBool("json")
+	data.format = util.OutputFormat()
+	data.offline = viper.GetBool("offline")
+	data.prepareOffline = viper.GetBool("prepare-offline")
+	data.chainInfoFile = viper.GetString("chain-info")
+
+	if data.offline && data.prepareOffline {
+		return nil, errors.New("only one of offline and prepare-offline allowed")
+	}
+	if data.offline || data.prepareOffline {
+		if data.chainInfoFile == "" {
+			return nil, errors.New("chain-info is required for offline operation")
+		}
+	}
+	if data.prepareOffline {
+		return inputPrepareOffline(ctx, data)
+	}
+	if data.offline {
+		if viper.GetString("exit") != "" {
+			return nil, errors.New("cannot broadcast exits when offline")
+		}
+		// Offline exits can only be output, not broadcast.
+		data.jsonOutput = true
+	}
 
 	switch {
 	case viper.GetString("exit") != "":
 		return inputJSON(ctx, data)
+	case viper.GetString("accounts") != "":
+		return inputAccounts(ctx, data)
+	case len(viper.GetStringSlice("indices")) > 0 || viper.GetString("pubkeys-file") != "":
+		return nil, errors.New("accounts is required to sign exits for indices or public keys")
 	case viper.GetString("account") != "":
 		return inputAccount(ctx, data)
 	case viper.GetString("key") != "":
@@ -74,15 +122,170 @@ func input(ctx context.Context) (*dataIn, error) {
 }
 
 func inputJSON(ctx context.Context, data *dataIn) (*dataIn, error) {
+	input, err := obtainExitJSON(viper.GetString("exit"))
+	if err != nil {
+		return nil, err
+	}
+
+	if bytes.HasPrefix(input, []byte("[")) {
+		// Multiple exits.
+		data.batch = true
+		data.signedVoluntaryExits, err = unmarshalBatchExits(input)
+		if err != nil {
+			return nil, err
+		}
+		if len(data.signedVoluntaryExits) == 0 {
+			return nil, errors.New("no exits supplied")
+		}
+		return inputChainData(ctx, data)
+	}
+
 	validatorData := &util.ValidatorExitData{}
-	err := json.Unmarshal([]byte(viper.GetString("exit")), validatorData)
+	err = json.Unmarshal(input, validatorData)
 	if err != nil {
 		return nil, err
 	}
 	data.signedVoluntaryExit = validatorData.Exit
+	data, err = inputChainData(ctx, data)
+	if err != nil {
+		return nil, err
+	}
+	// A pre-signed exit retains the fork version with which it was signed.
+	data.forkVersion = validatorData.ForkVersion
+	return data, nil
+}
+
+// unmarshalBatchExits unmarshals batch exit JSON, skipping entries that record a failure
+// to generate the exit.
+func unmarshalBatchExits(input []byte) ([]*util.ValidatorExitData, error) {
+	entries := make([]json.RawMessage, 0)
+	if err := json.Unmarshal(input, &entries); err != nil {
+		return nil, err
+	}
+	exits := make([]*util.ValidatorExitData, 0, len(entries))
+	for _, entry := range entries {
+		failure := &batchExitFailureJSON{}
+		if err := json.Unmarshal(entry, failure); err != nil {
+			return nil, err
+		}
+		if failure.Error != "" {
+			continue
+		}
+		exit := &util.ValidatorExitData{}
+		if err := json.Unmarshal(entry, exit); err != nil {
+			return nil, err
+		}
+		exits = append(exits, exit)
+	}
+	return exits, nil
+}
+
+// obtainExitJSON obtains exit JSON from an input, which could be JSON itself or a path to JSON.
+func obtainExitJSON(input string) ([]byte, error) {
+	input = strings.TrimSpace(input)
+	if strings.HasPrefix(input, "{") || strings.HasPrefix(input, "[") {
+		return []byte(input), nil
+	}
+	if _, err := os.Stat(input); err != nil {
+		// Not a file; pass it through to be reported as invalid JSON.
+		return []byte(input), nil
+	}
+	data, err := ioutil.ReadFile(input)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read exit file")
+	}
+	return bytes.TrimSpace(data), nil
+}
+
+func inputAccounts(ctx context.Context, data *dataIn) (*dataIn, error) {
+	var err error
+	data.batch = true
+	_, data.accounts, err = util.WalletAndAccountsFromPath(ctx, viper.GetString("accounts"))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to obtain accounts")
+	}
+	if len(data.accounts) == 0 {
+		return nil, errors.New("no accounts found")
+	}
+
+	if err := inputValidatorFilters(data); err != nil {
+		return nil, err
+	}
+
 	return inputChainData(ctx, data)
 }
 
+// inputPrepareOffline obtains input for the online step that prepares chain information for offline exits.
+func inputPrepareOffline(ctx context.Context, data *dataIn) (*dataIn, error) {
+	if err := inputValidatorFilters(data); err != nil {
+		return nil, err
+	}
+
+	return inputChainData(ctx, data)
+}
+
+// inputValidatorFilters obtains the indices or public keys that select validators to exit.
+func inputValidatorFilters(data *dataIn) error {
+	for _, index := range viper.GetStringSlice("indices") {
+		val, err := strconv.ParseUint(strings.TrimSpace(index), 10, 64)
+		if err != nil {
+			return errors.Wrap(err, fmt.Sprintf("invalid validator index %s", index))
+		}
+		data.indices = append(data.indices, spec.ValidatorIndex(val))
+	}
+
+	if viper.GetString("pubkeys-file") != "" {
+		var err error
+		data.pubKeys, err = pubKeysFromFile(viper.GetString("pubkeys-file"))
+		if err != nil {
+			return err
+		}
+	}
+
+	if len(data.indices) > 0 && len(data.pubKeys) > 0 {
+		return errors.New("only one of indices and pubkeys-file allowed")
+	}
+
+	return nil
+}
+
+// pubKeysFromFile reads public keys from a file, one per line.
+// Blank lines and lines starting with '#' are ignored.
+func pubKeysFromFile(path string) ([]spec.BLSPubKey, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to open public keys file")
+	}
+	defer file.Close()
+
+	pubKeys := make([]spec.BLSPubKey, 0)
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		pubKeyBytes, err := hex.DecodeString(strings.TrimPrefix(line, "0x"))
+		if err != nil {
+			return nil, errors.Wrap(err, fmt.Sprintf("failed to decode public key %s", line))
+		}
+		if len(pubKeyBytes) != len(spec.BLSPubKey{}) {
+			return nil, fmt.Errorf("invalid public key %s", line)
+		}
+		var pubKey spec.BLSPubKey
+		copy(pubKey[:], pubKeyBytes)
+		pubKeys = append(pubKeys, pubKey)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, errors.Wrap(err, "failed to read public keys file")
+	}
+	if len(pubKeys) == 0 {
+		return nil, errors.New("no public keys in public keys file")
+	}
+
+	return pubKeys, nil
+}
+
 func inputAccount(ctx context.Context, data *dataIn) (*dataIn, error) {
 	var err error
 	_, data.account, err = util.WalletAndAccountFromInput(ctx)
@@ -105,6 +308,10 @@ func inputKey(ctx context.Context, data *dataIn) (*dataIn, error) {
 }
 
 func inputChainData(ctx context.Context, data *dataIn) (*dataIn, error) {
+	if data.offline {
+		return inputOfflineChainData(ctx, data)
+	}
+
 	var err error
 	data.eth2Client, err = util.ConnectToBeaconNode(ctx, viper.GetString("connection"), viper.GetDuration("timeout"), viper.GetBool("allow-insecure-connections"))
 	if err != nil {
@@ -127,6 +334,7 @@ func inputChainData(ctx context.Context, data *dataIn) (*dataIn, error) {
 		return nil, errors.Wrap(err, "failed to connect to obtain genesis information")
 	}
 	data.currentEpoch = spec.Epoch(uint64(time.Since(genesis.GenesisTime).Seconds()) / (uint64(config["SECONDS_PER_SLOT"].(time.Duration).Seconds()) * config["SLOTS_PER_EPOCH"].(uint64)))
+	data.genesisValidatorsRoot = genesis.GenesisValidatorsRoot
 
 	// Epoch.
 	if viper.GetInt64("epoch") == -1 {
@@ -136,11 +344,86 @@ func inputChainData(ctx context.Context, data *dataIn) (*dataIn, error) {
 	}
 
 	// Domain.
-	domain, err := data.eth2Client.(eth2client.DomainProvider).Domain(ctx, config["DOMAIN_VOLUNTARY_EXIT"].(spec.DomainType), data.epoch)
+	forkSchedule, err := data.eth2Client.(eth2client.ForkScheduleProvider).ForkSchedule(ctx)
 	if err != nil {
-		return nil, errors.New("failed to calculate domain")
+		return nil, errors.Wrap(err, "failed to obtain fork schedule")
 	}
-	data.domain = domain
+	data.forkVersion, err = forkVersionAtEpoch(forkSchedule, data.epoch)
+	if err != nil {
+		return nil, err
+	}
+	domainType, isDomainType := config["DOMAIN_VOLUNTARY_EXIT"].(spec.DomainType)
+	if !isDomainType {
+		return nil, errors.New("failed to obtain voluntary exit domain type")
+	}
+	copy(data.domain[:], e2types.Domain(e2types.DomainType(domainType), data.forkVersion[:], data.genesisValidatorsRoot[:]))
+
+	return data, nil
+}
+
+// forkVersionAtEpoch returns the fork version in force at the given epoch.
+func forkVersionAtEpoch(forkSchedule []*spec.Fork, epoch spec.Epoch) (spec.Version, error) {
+	var fork *spec.Fork
+	for _, scheduledFork := range forkSchedule {
+		if scheduledFork.Epoch > epoch {
+			break
+		}
+		fork = scheduledFork
+	}
+	if fork == nil {
+		return spec.Version{}, fmt.Errorf("no fork at epoch %d", epoch)
+	}
+	return fork.CurrentVersion, nil
+}
+
+// inputOfflineChainData obtains chain data from a chain information file rather than a beacon node.
+func inputOfflineChainData(ctx context.Context, data *dataIn) (*dataIn, error) {
+	var err error
+	data.chainInfo, err = loadChainInfo(data.chainInfoFile)
+	if err != nil {
+		return nil, err
+	}
+
+	data.fork = data.chainInfo.Fork
+	data.currentEpoch = data.chainInfo.Epoch
+	data.genesisValidatorsRoot = data.chainInfo.GenesisValidatorsRoot
+
+	// Epoch.
+	if viper.GetInt64("epoch") == -1 {
+		data.epoch = data.currentEpoch
+	} else {
+		data.epoch = spec.Epoch(viper.GetUint64("epoch"))
+	}
+
+	if viper.GetString("network") != "" {
+		return inputNetworkDomain(data, viper.GetString("network"))
+	}
+
+	// Domain.
+	data.forkVersion = data.fork.CurrentVersion
+	if data.epoch < data.fork.Epoch {
+		data.forkVersion = data.fork.PreviousVersion
+	}
+	copy(data.domain[:], e2types.Domain(e2types.DomainVoluntaryExit, data.forkVersion[:], data.chainInfo.GenesisValidatorsRoot[:]))
+
+	return data, nil
+}
+
+// inputNetworkDomain calculates the domain for offline exits from the fork schedule of a named network,
+// rather than the fork in the chain information file.
+func inputNetworkDomain(data *dataIn, name string) (*dataIn, error) {
+	network, err := util.NetworkConfigByName(name)
+	if err != nil {
+		return nil, err
+	}
+	// The genesis validators root is not known for all networks, in which case that in the chain information is used.
+	if network.GenesisValidatorsRoot != nil && *network.GenesisValidatorsRoot != data.chainInfo.GenesisValidatorsRoot {
+		return nil, fmt.Errorf("chain information is not for network %s", network.Name)
+	}
+
+	data.fork = network.ForkAtEpoch(data.epoch)
+	data.forkVersion = data.fork.CurrentVersion
+	copy(data.domain[:], e2types.Domain(e2types.DomainVoluntaryExit, data.forkVersion[:], data.chainInfo.GenesisValidatorsRoot[:]))
 
 	return data, nil
 }
@@ -16,10 +16,12 @@ package validatorexit
 import (
 	"context"
 	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
 	"github.com/aaron-alderman/ethdo/testutil"
+	spec "github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/spf13/viper"
 	"github.com/stretchr/testify/require"
 	e2types "github.com/wealdtech/go-eth2-types/v2"
@@ -191,3 +193,252 @@ func TestInput(t *testing.T) {
 		})
 	}
 }
+
+func TestPubKeysFromFile(t *testing.T) {
+	dir := t.TempDir()
+
+	tests := []struct {
+		name     string
+		contents string
+		pubKeys  int
+		err      string
+	}{
+		{
+			name:     "Empty",
+			contents: "# No keys\n\n",
+			err:      "no public keys in public keys file",
+		},
+		{
+			name:     "Invalid",
+			contents: "0xinvalid\n",
+			err:      "failed to decode public key 0xinvalid: encoding/hex: invalid byte: U+0069 'i'",
+		},
+		{
+			name:     "Short",
+			contents: "0x0102\n",
+			err:      "invalid public key 0x0102",
+		},
+		{
+			name:     "Good",
+			contents: "# Validators\n0xa99a76ed7796f7be22d5b7e85deeb7c5677e88e511e0b337618f8c4eb61349b4bf2d153f649f7b53359fe8b94a38e44c\n\nb89bebc699769726a318c8e9971bd3171297c61aea4a6578a7a4f94b547dcba5bac16a89108b6b6a1fe3695d1a874a0b\n",
+			pubKeys:  2,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			path := filepath.Join(dir, test.name)
+			require.NoError(t, os.WriteFile(path, []byte(test.contents), 0600))
+			pubKeys, err := pubKeysFromFile(path)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.Len(t, pubKeys, test.pubKeys)
+			}
+		})
+	}
+}
+
+func TestInputOfflineNetwork(t *testing.T) {
+	mainnetRoot := spec.Root{}
+	copy(mainnetRoot[:], testutil.HexToBytes("0x4b363db94e286120d76eb905340fdd4e54bfe9f06bf33ff6cf5ad27f511bfe95"))
+	chainInfoFile := filepath.Join(t.TempDir(), "offline-preparation.json")
+	require.NoError(t, writeChainInfo(chainInfoFile, &chainInfo{
+		Version:               chainInfoVersion,
+		GenesisValidatorsRoot: mainnetRoot,
+		Epoch:                 100000,
+		Fork: &spec.Fork{
+			PreviousVersion: spec.Version{0x00, 0x00, 0x00, 0x00},
+			CurrentVersion:  spec.Version{0x01, 0x00, 0x00, 0x00},
+			Epoch:           74240,
+		},
+	}))
+
+	tests := []struct {
+		name        string
+		vars        map[string]interface{}
+		err         string
+		forkVersion spec.Version
+	}{
+		{
+			name: "NetworkUnknown",
+			vars: map[string]interface{}{
+				"network": "unknown",
+			},
+			err: "unknown network unknown",
+		},
+		{
+			name: "NetworkMismatch",
+			vars: map[string]interface{}{
+				"network": "prater",
+			},
+			err: "chain information is not for network Prater",
+		},
+		{
+			name: "ChainInfoFork",
+			vars: map[string]interface{}{
+				"epoch": "150000",
+			},
+			forkVersion: spec.Version{0x01, 0x00, 0x00, 0x00},
+		},
+		{
+			name: "ChainInfoPreviousFork",
+			vars: map[string]interface{}{
+				"epoch": "50000",
+			},
+			forkVersion: spec.Version{0x00, 0x00, 0x00, 0x00},
+		},
+		{
+			name: "NetworkFork",
+			vars: map[string]interface{}{
+				"epoch":   "150000",
+				"network": "mainnet",
+			},
+			forkVersion: spec.Version{0x02, 0x00, 0x00, 0x00},
+		},
+		{
+			name: "CustomNetworkFork",
+			vars: map[string]interface{}{
+				"epoch":   "150000",
+				"network": "mydevnet",
+				"networks": map[string]interface{}{
+					"mydevnet": map[string]interface{}{
+						"genesis-fork-version": "0x00000000",
+						"forks": []interface{}{
+							map[string]interface{}{"version": "0x01000000", "epoch": 74240},
+							map[string]interface{}{"version": "0x02000000", "epoch": 140000},
+						},
+					},
+				},
+			},
+			forkVersion: spec.Version{0x02, 0x00, 0x00, 0x00},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			viper.Reset()
+			viper.Set("epoch", -1)
+			for k, v := range test.vars {
+				viper.Set(k, v)
+			}
+			data, err := inputOfflineChainData(context.Background(), &dataIn{
+				offline:       true,
+				chainInfoFile: chainInfoFile,
+			})
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+				return
+			}
+			require.NoError(t, err)
+			expected := spec.Domain{}
+			copy(expected[:], e2types.Domain(e2types.DomainVoluntaryExit, test.forkVersion[:], mainnetRoot[:]))
+			require.Equal(t, expected, data.domain)
+			require.Equal(t, test.forkVersion, data.forkVersion)
+		})
+	}
+}
+
+func TestForkVersionAtEpoch(t *testing.T) {
+	forkSchedule := []*spec.Fork{
+		{
+			PreviousVersion: spec.Version{0x00, 0x00, 0x00, 0x00},
+			CurrentVersion:  spec.Version{0x00, 0x00, 0x00, 0x00},
+			Epoch:           0,
+		},
+		{
+			PreviousVersion: spec.Version{0x00, 0x00, 0x00, 0x00},
+			CurrentVersion:  spec.Version{0x01, 0x00, 0x00, 0x00},
+			Epoch:           100,
+		},
+	}
+
+	tests := []struct {
+		name         string
+		forkSchedule []*spec.Fork
+		epoch        spec.Epoch
+		forkVersion  spec.Version
+		err          string
+	}{
+		{
+			name:  "NoSchedule",
+			epoch: 10,
+			err:   "no fork at epoch 10",
+		},
+		{
+			name:         "Genesis",
+			forkSchedule: forkSchedule,
+			epoch:        99,
+			forkVersion:  spec.Version{0x00, 0x00, 0x00, 0x00},
+		},
+		{
+			name:         "ForkEpoch",
+			forkSchedule: forkSchedule,
+			epoch:        100,
+			forkVersion:  spec.Version{0x01, 0x00, 0x00, 0x00},
+		},
+		{
+			name:         "AfterFork",
+			forkSchedule: forkSchedule,
+			epoch:        200,
+			forkVersion:  spec.Version{0x01, 0x00, 0x00, 0x00},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			forkVersion, err := forkVersionAtEpoch(test.forkSchedule, test.epoch)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, test.forkVersion, forkVersion)
+			}
+		})
+	}
+}
+
+func TestUnmarshalBatchExits(t *testing.T) {
+	exit := `{"exit":{"message":{"epoch":"123","validator_index":"456"},"signature":"0x000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f202122232425262728292a2b2c2d2e2f303132333435363738393a3b3c3d3e3f404142434445464748494a4b4c4d4e4f505152535455565758595a5b5c5d5e5f"},"fork_version":"0x01020304"}`
+
+	tests := []struct {
+		name  string
+		input string
+		exits int
+		err   string
+	}{
+		{
+			name:  "Invalid",
+			input: `[{`,
+			err:   "unexpected end of JSON input",
+		},
+		{
+			name:  "ExitMissing",
+			input: `[{"fork_version":"0x01020304"}]`,
+			err:   "exit missing",
+		},
+		{
+			name:  "Good",
+			input: `[` + exit + `]`,
+			exits: 1,
+		},
+		{
+			name:  "Failures",
+			input: `[` + exit + `,{"validator_index":457,"error":"no account available to sign exit"}]`,
+			exits: 1,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			exits, err := unmarshalBatchExits([]byte(test.input))
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.Len(t, exits, test.exits)
+			}
+		})
+	}
+}
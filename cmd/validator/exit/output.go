@@ -16,6 +16,8 @@ package validatorexit
 import (
 	"context"
 	"encoding/json"
+	"fmt"
+	"strings"
 
 	"github.com/aaron-alderman/ethdo/util"
 	spec "github.com/attestantio/go-eth2-client/spec/phase0"
@@ -24,8 +26,38 @@ import (
 
 type dataOut struct {
 	jsonOutput          bool
+	format              string
 	forkVersion         spec.Version
 	signedVoluntaryExit *spec.SignedVoluntaryExit
+	// Batch results.
+	batch bool
+	exits []*exitResult
+	// Offline preparation results.
+	prepareOffline bool
+	chainInfoFile  string
+	validators     int
+	allValidators  bool
+}
+
+// exitResult is the result of generating and broadcasting an exit for a single validator.
+type exitResult struct {
+	index               spec.ValidatorIndex
+	pubKey              spec.BLSPubKey
+	forkVersion         spec.Version
+	signedVoluntaryExit *spec.SignedVoluntaryExit
+	broadcast           bool
+	err                 error
+}
+
+// failures returns the number of exits in a batch that failed.
+func (d *dataOut) failures() int {
+	failures := 0
+	for _, exit := range d.exits {
+		if exit.err != nil {
+			failures++
+		}
+	}
+	return failures
 }
 
 func output(ctx context.Context, data *dataOut) (string, error) {
@@ -33,17 +65,61 @@ func output(ctx context.Context, data *dataOut) (string, error) {
 		return "", errors.New("no data")
 	}
 
+	if data.prepareOffline {
+		if util.StructuredFormat(data.format) {
+			return util.FormatOutput(data.format, &prepareOfflineJSON{
+				ChainInfoFile: data.chainInfoFile,
+				Validators:    data.validators,
+				AllValidators: data.allValidators,
+			})
+		}
+		if data.allValidators {
+			return fmt.Sprintf("Chain information for all %d validators on the chain written to %s", data.validators, data.chainInfoFile), nil
+		}
+		return fmt.Sprintf("Chain information for %d validators written to %s", data.validators, data.chainInfoFile), nil
+	}
+
+	if data.batch {
+		if data.jsonOutput {
+			return outputBatchJSON(ctx, data)
+		}
+		if util.StructuredFormat(data.format) {
+			return outputBatchStructured(ctx, data)
+		}
+		return outputBatchText(ctx, data)
+	}
+
 	if data.signedVoluntaryExit == nil {
 		return "", errors.New("no signed voluntary exit")
 	}
 
+	// JSON output is the exit itself, for later submission, regardless of format.
 	if data.jsonOutput {
 		return outputJSON(ctx, data)
 	}
 
+	if util.StructuredFormat(data.format) {
+		return util.FormatOutput(data.format, &exitResultJSON{
+			ValidatorIndex: data.signedVoluntaryExit.Message.ValidatorIndex,
+			Status:         "broadcast",
+		})
+	}
+
 	return "", nil
 }
 
+type prepareOfflineJSON struct {
+	ChainInfoFile string `json:"chain_info_file"`
+	Validators    int    `json:"validators"`
+	AllValidators bool   `json:"all_validators"`
+}
+
+type exitResultJSON struct {
+	ValidatorIndex spec.ValidatorIndex `json:"validator_index"`
+	Status         string              `json:"status"`
+	Error          string              `json:"error,omitempty"`
+}
+
 func outputJSON(ctx context.Context, data *dataOut) (string, error) {
 	validatorExitData := &util.ValidatorExitData{
 		Exit:        data.signedVoluntaryExit,
@@ -55,3 +131,70 @@ func outputJSON(ctx context.Context, data *dataOut) (string, error) {
 	}
 	return string(bytes), nil
 }
+
+// batchExitFailureJSON records an exit in a batch that could not be generated.
+type batchExitFailureJSON struct {
+	ValidatorIndex spec.ValidatorIndex `json:"validator_index"`
+	Error          string              `json:"error"`
+}
+
+func outputBatchJSON(_ context.Context, data *dataOut) (string, error) {
+	entries := make([]interface{}, 0, len(data.exits))
+	for _, exit := range data.exits {
+		if exit.err != nil {
+			entries = append(entries, &batchExitFailureJSON{
+				ValidatorIndex: exit.index,
+				Error:          exit.err.Error(),
+			})
+			continue
+		}
+		entries = append(entries, &util.ValidatorExitData{
+			Exit:        exit.signedVoluntaryExit,
+			ForkVersion: exit.forkVersion,
+		})
+	}
+	bytes, err := json.Marshal(entries)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to generate JSON")
+	}
+	return string(bytes), nil
+}
+
+func outputBatchText(ctx context.Context, data *dataOut) (string, error) {
+	builder := strings.Builder{}
+	for _, exit := range data.exits {
+		builder.WriteString(fmt.Sprintf("Validator %d: ", exit.index))
+		switch {
+		case exit.err != nil:
+			builder.WriteString(fmt.Sprintf("failed: %v\n", exit.err))
+		case exit.broadcast:
+			builder.WriteString("exit broadcast\n")
+		default:
+			builder.WriteString("exit generated\n")
+		}
+	}
+	builder.WriteString(fmt.Sprintf("%d of %d exits succeeded", len(data.exits)-data.failures(), len(data.exits)))
+
+	return builder.String(), nil
+}
+
+func outputBatchStructured(_ context.Context, data *dataOut) (string, error) {
+	results := make([]*exitResultJSON, 0, len(data.exits))
+	for _, exit := range data.exits {
+		res := &exitResultJSON{
+			ValidatorIndex: exit.index,
+		}
+		switch {
+		case exit.err != nil:
+			res.Status = "failed"
+			res.Error = exit.err.Error()
+		case exit.broadcast:
+			res.Status = "broadcast"
+		default:
+			res.Status = "generated"
+		}
+		results = append(results, res)
+	}
+
+	return util.FormatOutput(data.format, results)
+}
@@ -15,6 +15,7 @@ package validatorexit
 
 import (
 	"context"
+	"errors"
 	"testing"
 
 	spec "github.com/attestantio/go-eth2-client/spec/phase0"
@@ -95,3 +96,106 @@ func TestOutput(t *testing.T) {
 		})
 	}
 }
+
+func TestOutputBatch(t *testing.T) {
+	signedVoluntaryExit := &spec.SignedVoluntaryExit{
+		Message: &spec.VoluntaryExit{
+			Epoch:          spec.Epoch(123),
+			ValidatorIndex: spec.ValidatorIndex(456),
+		},
+		Signature: spec.BLSSignature{
+			0x00, 0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08, 0x09, 0x0a, 0x0b, 0x0c, 0x0d, 0x0e, 0x0f,
+			0x10, 0x11, 0x12, 0x13, 0x14, 0x15, 0x16, 0x17, 0x18, 0x19, 0x1a, 0x1b, 0x1c, 0x1d, 0x1e, 0x1f,
+			0x20, 0x21, 0x22, 0x23, 0x24, 0x25, 0x26, 0x27, 0x28, 0x29, 0x2a, 0x2b, 0x2c, 0x2d, 0x2e, 0x2f,
+			0x30, 0x31, 0x32, 0x33, 0x34, 0x35, 0x36, 0x37, 0x38, 0x39, 0x3a, 0x3b, 0x3c, 0x3d, 0x3e, 0x3f,
+			0x40, 0x41, 0x42, 0x43, 0x44, 0x45, 0x46, 0x47, 0x48, 0x49, 0x4a, 0x4b, 0x4c, 0x4d, 0x4e, 0x4f,
+			0x50, 0x51, 0x52, 0x53, 0x54, 0x55, 0x56, 0x57, 0x58, 0x59, 0x5a, 0x5b, 0x5c, 0x5d, 0x5e, 0x5f,
+		},
+	}
+
+	tests := []struct {
+		name    string
+		dataOut *dataOut
+		res     string
+		err     string
+	}{
+		{
+			name: "Broadcast",
+			dataOut: &dataOut{
+				batch: true,
+				exits: []*exitResult{
+					{
+						index:               456,
+						signedVoluntaryExit: signedVoluntaryExit,
+						broadcast:           true,
+					},
+					{
+						index: 457,
+						err:   errors.New("no account available to sign exit"),
+					},
+				},
+			},
+			res: "Validator 456: exit broadcast\nValidator 457: failed: no account available to sign exit\n1 of 2 exits succeeded",
+		},
+		{
+			name: "JSON",
+			dataOut: &dataOut{
+				batch:      true,
+				jsonOutput: true,
+				exits: []*exitResult{
+					{
+						index:               456,
+						forkVersion:         spec.Version{0x01, 0x02, 0x03, 0x04},
+						signedVoluntaryExit: signedVoluntaryExit,
+					},
+				},
+			},
+			res: `[{"exit":{"message":{"epoch":"123","validator_index":"456"},"signature":"0x000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f202122232425262728292a2b2c2d2e2f303132333435363738393a3b3c3d3e3f404142434445464748494a4b4c4d4e4f505152535455565758595a5b5c5d5e5f"},"fork_version":"0x01020304"}]`,
+		},
+		{
+			name: "JSONFailures",
+			dataOut: &dataOut{
+				batch:      true,
+				jsonOutput: true,
+				exits: []*exitResult{
+					{
+						index: 456,
+						err:   errors.New("failed to sign voluntary exit"),
+					},
+				},
+			},
+			res: `[{"validator_index":456,"error":"failed to sign voluntary exit"}]`,
+		},
+		{
+			name: "JSONPartialFailure",
+			dataOut: &dataOut{
+				batch:      true,
+				jsonOutput: true,
+				exits: []*exitResult{
+					{
+						index:               456,
+						forkVersion:         spec.Version{0x01, 0x02, 0x03, 0x04},
+						signedVoluntaryExit: signedVoluntaryExit,
+					},
+					{
+						index: 457,
+						err:   errors.New("no account available to sign exit"),
+					},
+				},
+			},
+			res: `[{"exit":{"message":{"epoch":"123","validator_index":"456"},"signature":"0x000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f202122232425262728292a2b2c2d2e2f303132333435363738393a3b3c3d3e3f404142434445464748494a4b4c4d4e4f505152535455565758595a5b5c5d5e5f"},"fork_version":"0x01020304"},{"validator_index":457,"error":"no account available to sign exit"}]`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			res, err := output(context.Background(), test.dataOut)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, test.res, res)
+			}
+		})
+	}
+}
@@ -15,6 +15,10 @@ package validatorexit
 
 import (
 	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
 
 	"github.com/aaron-alderman/ethdo/signing"
 	"github.com/aaron-alderman/ethdo/util"
@@ -22,6 +26,8 @@ import (
 	api "github.com/attestantio/go-eth2-client/api/v1"
 	spec "github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/pkg/errors"
+	e2types "github.com/wealdtech/go-eth2-types/v2"
+	e2wtypes "github.com/wealdtech/go-eth2-wallet-types/v2"
 )
 
 // maxFutureEpochs is the farthest in the future for which an exit will be created.
@@ -37,9 +43,17 @@ func process(ctx context.Context, data *dataIn) (*dataOut, error) {
 	// 		return nil, errors.New("not generating exit for an epoch in the far future")
 	// 	}
 	// }
+	if data.prepareOffline {
+		return processPrepareOffline(ctx, data)
+	}
+	if data.batch {
+		return processBatch(ctx, data)
+	}
+
 	results := &dataOut{
-		forkVersion: data.fork.CurrentVersion,
+		forkVersion: data.forkVersion,
 		jsonOutput:  data.jsonOutput,
+		format:      data.format,
 	}
 
 	validator, err := fetchValidator(ctx, data)
@@ -67,6 +81,9 @@ func process(ctx context.Context, data *dataIn) (*dataOut, error) {
 			Signature: signature,
 		}
 	} else {
+		if err := verifyPreSignedExit(ctx, data); err != nil {
+			return nil, err
+		}
 		results.signedVoluntaryExit = data.signedVoluntaryExit
 	}
 
@@ -100,7 +117,15 @@ func generateExit(ctx context.Context, data *dataIn, validator *api.Validator) (
 }
 
 func broadcastExit(ctx context.Context, data *dataIn, results *dataOut) error {
-	return data.eth2Client.(eth2client.VoluntaryExitSubmitter).SubmitVoluntaryExit(ctx, results.signedVoluntaryExit)
+	if err := data.eth2Client.(eth2client.VoluntaryExitSubmitter).SubmitVoluntaryExit(ctx, results.signedVoluntaryExit); err != nil {
+		return err
+	}
+
+	var pubKey []byte
+	if data.account != nil {
+		pubKey = util.AccountPublicKey(data.account)
+	}
+	return util.AuditBroadcast(fmt.Sprintf("voluntary exit for validator %d", results.signedVoluntaryExit.Message.ValidatorIndex), pubKey)
 }
 
 func fetchValidator(ctx context.Context, data *dataIn) (*api.Validator, error) {
@@ -116,7 +141,7 @@ func fetchValidator(ctx context.Context, data *dataIn) (*api.Validator, error) {
 		return nil, errors.Wrap(err, "failed to obtain public key for account")
 	}
 	copy(validatorPubKeys[0][:], pubKey.Marshal())
-	validators, err := data.eth2Client.(eth2client.ValidatorsProvider).ValidatorsByPubKey(ctx, "head", validatorPubKeys)
+	validators, err := validatorsByPubKey(ctx, data, validatorPubKeys)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to obtain validator from beacon node")
 	}
@@ -131,3 +156,370 @@ func fetchValidator(ctx context.Context, data *dataIn) (*api.Validator, error) {
 	// }
 	return validator, nil
 }
+
+// processBatch generates and optionally broadcasts exits for multiple validators.
+func processBatch(ctx context.Context, data *dataIn) (*dataOut, error) {
+	results := &dataOut{
+		forkVersion: data.forkVersion,
+		jsonOutput:  data.jsonOutput,
+		format:      data.format,
+		batch:       true,
+	}
+
+	if len(data.signedVoluntaryExits) > 0 {
+		// Pre-signed exits.
+		indices := make([]spec.ValidatorIndex, 0, len(data.signedVoluntaryExits))
+		for _, exit := range data.signedVoluntaryExits {
+			if exit.Exit == nil || exit.Exit.Message == nil {
+				return nil, errors.New("exit missing message")
+			}
+			indices = append(indices, exit.Exit.Message.ValidatorIndex)
+		}
+		validators, err := validatorsByIndex(ctx, data, indices)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to obtain validators from beacon node")
+		}
+		for _, exit := range data.signedVoluntaryExits {
+			result := &exitResult{
+				index:               exit.Exit.Message.ValidatorIndex,
+				forkVersion:         exit.ForkVersion,
+				signedVoluntaryExit: exit.Exit,
+			}
+			results.exits = append(results.exits, result)
+			validator, exists := validators[result.index]
+			if !exists {
+				result.err = errors.New("validator not known by beacon node")
+				continue
+			}
+			result.pubKey = validator.Validator.PublicKey
+			result.err = verifyExit(data, exit, result.pubKey)
+		}
+	} else {
+		validators, err := fetchValidators(ctx, data)
+		if err != nil {
+			return nil, err
+		}
+		accounts, err := accountsByPubKey(data.accounts)
+		if err != nil {
+			return nil, err
+		}
+		for _, validator := range validators {
+			result := &exitResult{
+				index:       validator.Index,
+				pubKey:      validator.Validator.PublicKey,
+				forkVersion: data.forkVersion,
+			}
+			results.exits = append(results.exits, result)
+			account, exists := accounts[validator.Validator.PublicKey]
+			if !exists {
+				result.err = errors.New("no account available to sign exit")
+				continue
+			}
+			result.signedVoluntaryExit, result.err = signExit(ctx, data, account, validator)
+		}
+	}
+
+	if !data.jsonOutput {
+		for _, result := range results.exits {
+			if result.err != nil {
+				continue
+			}
+			if err := data.eth2Client.(eth2client.VoluntaryExitSubmitter).SubmitVoluntaryExit(ctx, result.signedVoluntaryExit); err != nil {
+				result.err = errors.Wrap(err, "failed to broadcast voluntary exit")
+				continue
+			}
+			result.broadcast = true
+			var pubKey []byte
+			if result.pubKey != (spec.BLSPubKey{}) {
+				pubKey = result.pubKey[:]
+			}
+			if err := util.AuditBroadcast(fmt.Sprintf("voluntary exit for validator %d", result.index), pubKey); err != nil {
+				return nil, errors.Wrap(err, "failed to record broadcast in audit log")
+			}
+		}
+	}
+
+	return results, nil
+}
+
+// signExit generates and signs an exit for a validator.
+func signExit(ctx context.Context, data *dataIn, account e2wtypes.Account, validator *api.Validator) (*spec.SignedVoluntaryExit, error) {
+	exit := &spec.VoluntaryExit{
+		Epoch:          data.epoch,
+		ValidatorIndex: validator.Index,
+	}
+	root, err := exit.HashTreeRoot()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to generate root for voluntary exit")
+	}
+	signature, err := signing.SignRoot(ctx, account, data.passphrases, root, data.domain)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to sign voluntary exit")
+	}
+
+	return &spec.SignedVoluntaryExit{
+		Message:   exit,
+		Signature: signature,
+	}, nil
+}
+
+// verifyPreSignedExit verifies the signature of a single pre-signed exit before it is broadcast.
+func verifyPreSignedExit(ctx context.Context, data *dataIn) error {
+	index := data.signedVoluntaryExit.Message.ValidatorIndex
+	validators, err := validatorsByIndex(ctx, data, []spec.ValidatorIndex{index})
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain validator from beacon node")
+	}
+	validator, exists := validators[index]
+	if !exists {
+		return errors.New("validator not known by beacon node")
+	}
+	return verifyExit(data, &util.ValidatorExitData{
+		Exit:        data.signedVoluntaryExit,
+		ForkVersion: data.forkVersion,
+	}, validator.Validator.PublicKey)
+}
+
+// verifyExit verifies the signature of a pre-signed exit against the public key of its validator.
+func verifyExit(data *dataIn, exit *util.ValidatorExitData, pubKey spec.BLSPubKey) error {
+	root, err := exit.Exit.Message.HashTreeRoot()
+	if err != nil {
+		return errors.Wrap(err, "failed to generate root for voluntary exit")
+	}
+	var domain spec.Domain
+	copy(domain[:], e2types.Domain(e2types.DomainVoluntaryExit, exit.ForkVersion[:], data.genesisValidatorsRoot[:]))
+	signatureBytes := make([]byte, len(exit.Exit.Signature))
+	copy(signatureBytes, exit.Exit.Signature[:])
+	signature, err := e2types.BLSSignatureFromBytes(signatureBytes)
+	if err != nil {
+		return errors.Wrap(err, "invalid signature")
+	}
+	verified, err := util.VerifyAggregateSignature(signature, []spec.BLSPubKey{pubKey}, root, domain)
+	if err != nil {
+		return errors.Wrap(err, "failed to verify voluntary exit")
+	}
+	if !verified {
+		return errors.New("signature does not verify")
+	}
+	return nil
+}
+
+// fetchValidators fetches all validators for a batch exit in a single request,
+// returning them ordered by index.
+func fetchValidators(ctx context.Context, data *dataIn) ([]*api.Validator, error) {
+	var validators map[spec.ValidatorIndex]*api.Validator
+	var err error
+	switch {
+	case len(data.indices) > 0:
+		validators, err = validatorsByIndex(ctx, data, data.indices)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to obtain validators from beacon node")
+		}
+		if err := checkValidatorsKnown(data, validators, data.indices, nil); err != nil {
+			return nil, err
+		}
+	case len(data.pubKeys) > 0:
+		validators, err = validatorsByPubKey(ctx, data, data.pubKeys)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to obtain validators from beacon node")
+		}
+		if err := checkValidatorsKnown(data, validators, nil, data.pubKeys); err != nil {
+			return nil, err
+		}
+	default:
+		// Without a filter every account is considered, and those that are not validators
+		// are reported together rather than failing the batch.
+		pubKeys := make([]spec.BLSPubKey, 0, len(data.accounts))
+		names := make(map[spec.BLSPubKey]string, len(data.accounts))
+		for _, account := range data.accounts {
+			pubKey, err := util.BestPublicKey(account)
+			if err != nil {
+				return nil, errors.Wrap(err, fmt.Sprintf("failed to obtain public key for account %s", account.Name()))
+			}
+			var validatorPubKey spec.BLSPubKey
+			copy(validatorPubKey[:], pubKey.Marshal())
+			pubKeys = append(pubKeys, validatorPubKey)
+			names[validatorPubKey] = account.Name()
+		}
+		validators, err = validatorsByPubKey(ctx, data, pubKeys)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to obtain validators from beacon node")
+		}
+		known := make(map[spec.BLSPubKey]bool, len(validators))
+		for _, validator := range validators {
+			known[validator.Validator.PublicKey] = true
+		}
+		unknown := make([]string, 0)
+		for _, pubKey := range pubKeys {
+			if !known[pubKey] {
+				unknown = append(unknown, names[pubKey])
+			}
+		}
+		if len(unknown) > 0 && len(validators) > 0 && !data.quiet {
+			fmt.Fprintf(os.Stderr, "Warning: accounts not known as validators by %s: %s\n", validatorSource(data), strings.Join(unknown, ", "))
+		}
+	}
+	if len(validators) == 0 {
+		return nil, fmt.Errorf("no validators known by %s", validatorSource(data))
+	}
+
+	res := make([]*api.Validator, 0, len(validators))
+	for _, validator := range validators {
+		res = append(res, validator)
+	}
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].Index < res[j].Index
+	})
+
+	return res, nil
+}
+
+// checkValidatorsKnown returns a single error listing every requested validator that is not known.
+func checkValidatorsKnown(data *dataIn,
+	validators map[spec.ValidatorIndex]*api.Validator,
+	indices []spec.ValidatorIndex,
+	pubKeys []spec.BLSPubKey,
+) error {
+	unknown := make([]string, 0)
+	for _, index := range indices {
+		if _, exists := validators[index]; !exists {
+			unknown = append(unknown, fmt.Sprintf("%d", index))
+		}
+	}
+	if len(pubKeys) > 0 {
+		known := make(map[spec.BLSPubKey]bool, len(validators))
+		for _, validator := range validators {
+			known[validator.Validator.PublicKey] = true
+		}
+		for _, pubKey := range pubKeys {
+			if !known[pubKey] {
+				unknown = append(unknown, fmt.Sprintf("%#x", pubKey))
+			}
+		}
+	}
+	if len(unknown) > 0 {
+		return fmt.Errorf("validators not known by %s: %s", validatorSource(data), strings.Join(unknown, ", "))
+	}
+
+	return nil
+}
+
+// validatorSource describes where validator information is obtained.
+func validatorSource(data *dataIn) string {
+	if data.offline {
+		return "chain information"
+	}
+	return "beacon node"
+}
+
+// accountsByPubKey maps accounts by their public key.
+func accountsByPubKey(accounts []e2wtypes.Account) (map[spec.BLSPubKey]e2wtypes.Account, error) {
+	res := make(map[spec.BLSPubKey]e2wtypes.Account, len(accounts))
+	for _, account := range accounts {
+		pubKey, err := util.BestPublicKey(account)
+		if err != nil {
+			return nil, errors.Wrap(err, fmt.Sprintf("failed to obtain public key for account %s", account.Name()))
+		}
+		var validatorPubKey spec.BLSPubKey
+		copy(validatorPubKey[:], pubKey.Marshal())
+		res[validatorPubKey] = account
+	}
+	return res, nil
+}
+
+// processPrepareOffline writes the chain information required to generate exits offline.
+func processPrepareOffline(ctx context.Context, data *dataIn) (*dataOut, error) {
+	genesis, err := data.eth2Client.(eth2client.GenesisProvider).Genesis(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to obtain genesis information")
+	}
+
+	var validators map[spec.ValidatorIndex]*api.Validator
+	if len(data.pubKeys) > 0 {
+		validators, err = validatorsByPubKey(ctx, data, data.pubKeys)
+	} else {
+		// Without a filter nil indices are passed, which returns every validator on the chain.
+		validators, err = validatorsByIndex(ctx, data, data.indices)
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to obtain validators from beacon node")
+	}
+	if err := checkValidatorsKnown(data, validators, data.indices, data.pubKeys); err != nil {
+		return nil, err
+	}
+
+	info := &chainInfo{
+		Version:               chainInfoVersion,
+		Validators:            make([]*chainInfoValidator, 0, len(validators)),
+		GenesisValidatorsRoot: genesis.GenesisValidatorsRoot,
+		Epoch:                 data.currentEpoch,
+		Fork:                  data.fork,
+	}
+	for _, validator := range validators {
+		info.Validators = append(info.Validators, &chainInfoValidator{
+			Index:  validator.Index,
+			PubKey: validator.Validator.PublicKey,
+			State:  validator.Status,
+		})
+	}
+	sort.Slice(info.Validators, func(i, j int) bool {
+		return info.Validators[i].Index < info.Validators[j].Index
+	})
+
+	if err := writeChainInfo(data.chainInfoFile, info); err != nil {
+		return nil, err
+	}
+
+	return &dataOut{
+		format:         data.format,
+		prepareOffline: true,
+		chainInfoFile:  data.chainInfoFile,
+		validators:     len(info.Validators),
+		allValidators:  len(data.indices) == 0 && len(data.pubKeys) == 0,
+	}, nil
+}
+
+// validatorsByIndex obtains validators given their indices, either from the beacon node or
+// from chain information if offline.
+func validatorsByIndex(ctx context.Context, data *dataIn, indices []spec.ValidatorIndex) (map[spec.ValidatorIndex]*api.Validator, error) {
+	if data.offline {
+		all := data.chainInfo.apiValidators()
+		validators := make(map[spec.ValidatorIndex]*api.Validator)
+		for _, index := range indices {
+			if validator, exists := all[index]; exists {
+				validators[index] = validator
+			}
+		}
+		return validators, nil
+	}
+
+	validatorsProvider, isProvider := data.eth2Client.(eth2client.ValidatorsProvider)
+	if !isProvider {
+		return nil, errors.New("beacon node does not provide validator information")
+	}
+	return validatorsProvider.Validators(ctx, "head", indices)
+}
+
+// validatorsByPubKey obtains validators given their public keys, either from the beacon node or
+// from chain information if offline.
+func validatorsByPubKey(ctx context.Context, data *dataIn, pubKeys []spec.BLSPubKey) (map[spec.ValidatorIndex]*api.Validator, error) {
+	if data.offline {
+		wanted := make(map[spec.BLSPubKey]bool, len(pubKeys))
+		for _, pubKey := range pubKeys {
+			wanted[pubKey] = true
+		}
+		validators := make(map[spec.ValidatorIndex]*api.Validator)
+		for index, validator := range data.chainInfo.apiValidators() {
+			if wanted[validator.Validator.PublicKey] {
+				validators[index] = validator
+			}
+		}
+		return validators, nil
+	}
+
+	validatorsProvider, isProvider := data.eth2Client.(eth2client.ValidatorsProvider)
+	if !isProvider {
+		return nil, errors.New("beacon node does not provide validator information")
+	}
+	return validatorsProvider.ValidatorsByPubKey(ctx, "head", pubKeys)
+}
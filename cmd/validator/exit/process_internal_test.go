@@ -20,6 +20,7 @@ import (
 	"time"
 
 	"github.com/aaron-alderman/ethdo/testutil"
+	"github.com/aaron-alderman/ethdo/util"
 	api "github.com/attestantio/go-eth2-client/api/v1"
 	"github.com/attestantio/go-eth2-client/auto"
 	spec "github.com/attestantio/go-eth2-client/spec/phase0"
@@ -232,3 +233,228 @@ func TestGenerateExit(t *testing.T) {
 		})
 	}
 }
+
+func TestProcessOffline(t *testing.T) {
+	require.NoError(t, e2types.InitBLS())
+
+	account, err := util.NewScratchAccount(testutil.HexToBytes("0x25295f0d1d592a90b333e26e85149708208e9f8e8bc18f6c77bd62f8ad7a6866"), nil)
+	require.NoError(t, err)
+	// otherAccount is not a validator.
+	otherAccount, err := util.NewScratchAccount(testutil.HexToBytes("0x51d0b65185db6989ab0b560d6deed19c7ead0e24b9b6372cbecb1f26bdfad000"), nil)
+	require.NoError(t, err)
+
+	info := &chainInfo{
+		Version: chainInfoVersion,
+		Validators: []*chainInfoValidator{
+			{
+				Index:  123,
+				PubKey: testutil.HexToPubKey("0xa99a76ed7796f7be22d5b7e85deeb7c5677e88e511e0b337618f8c4eb61349b4bf2d153f649f7b53359fe8b94a38e44c"),
+				State:  api.ValidatorStateActiveOngoing,
+			},
+		},
+		GenesisValidatorsRoot: spec.Root{0x01, 0x02},
+		Epoch:                 100,
+		Fork: &spec.Fork{
+			PreviousVersion: spec.Version{0x00, 0x00, 0x00, 0x00},
+			CurrentVersion:  spec.Version{0x01, 0x00, 0x00, 0x00},
+			Epoch:           50,
+		},
+	}
+
+	tests := []struct {
+		name   string
+		dataIn *dataIn
+		index  spec.ValidatorIndex
+		err    string
+	}{
+		{
+			name: "Single",
+			dataIn: &dataIn{
+				offline:     true,
+				jsonOutput:  true,
+				chainInfo:   info,
+				fork:        info.Fork,
+				account:     account,
+				passphrases: []string{"pass"},
+				epoch:       100,
+			},
+			index: 123,
+		},
+		{
+			name: "SingleUnknown",
+			dataIn: &dataIn{
+				offline:    true,
+				jsonOutput: true,
+				chainInfo: &chainInfo{
+					Version: chainInfoVersion,
+					Fork:    info.Fork,
+				},
+				fork:    info.Fork,
+				account: account,
+				epoch:   100,
+			},
+			err: "validator not known by beacon node",
+		},
+		{
+			name: "Batch",
+			dataIn: &dataIn{
+				offline:     true,
+				jsonOutput:  true,
+				batch:       true,
+				chainInfo:   info,
+				fork:        info.Fork,
+				accounts:    []e2wtypes.Account{account},
+				passphrases: []string{"pass"},
+				epoch:       100,
+			},
+			index: 123,
+		},
+		{
+			name: "BatchAccountNotValidator",
+			dataIn: &dataIn{
+				offline:     true,
+				jsonOutput:  true,
+				batch:       true,
+				quiet:       true,
+				chainInfo:   info,
+				fork:        info.Fork,
+				accounts:    []e2wtypes.Account{account, otherAccount},
+				passphrases: []string{"pass"},
+				epoch:       100,
+			},
+			index: 123,
+		},
+		{
+			name: "BatchIndicesUnknown",
+			dataIn: &dataIn{
+				offline:     true,
+				jsonOutput:  true,
+				batch:       true,
+				chainInfo:   info,
+				fork:        info.Fork,
+				accounts:    []e2wtypes.Account{account},
+				indices:     []spec.ValidatorIndex{123, 5, 7},
+				passphrases: []string{"pass"},
+				epoch:       100,
+			},
+			err: "validators not known by chain information: 5, 7",
+		},
+		{
+			name: "BatchPubKeysUnknown",
+			dataIn: &dataIn{
+				offline:    true,
+				jsonOutput: true,
+				batch:      true,
+				chainInfo:  info,
+				fork:       info.Fork,
+				accounts:   []e2wtypes.Account{account},
+				pubKeys: []spec.BLSPubKey{
+					testutil.HexToPubKey("0xa99a76ed7796f7be22d5b7e85deeb7c5677e88e511e0b337618f8c4eb61349b4bf2d153f649f7b53359fe8b94a38e44c"),
+					testutil.HexToPubKey("0xb89bebc699769726a318c8e9971bd3171297c61aea4a6578a7a4f94b547dcba5bac16a89108b6b6a1fe3695d1a874a0b"),
+				},
+				passphrases: []string{"pass"},
+				epoch:       100,
+			},
+			err: "validators not known by chain information: 0xb89bebc699769726a318c8e9971bd3171297c61aea4a6578a7a4f94b547dcba5bac16a89108b6b6a1fe3695d1a874a0b",
+		},
+		{
+			name: "BatchNoValidators",
+			dataIn: &dataIn{
+				offline:     true,
+				jsonOutput:  true,
+				batch:       true,
+				chainInfo:   info,
+				fork:        info.Fork,
+				accounts:    []e2wtypes.Account{otherAccount},
+				passphrases: []string{"pass"},
+				epoch:       100,
+			},
+			err: "no validators known by chain information",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			res, err := process(context.Background(), test.dataIn)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				if test.dataIn.batch {
+					require.Len(t, res.exits, 1)
+					require.NoError(t, res.exits[0].err)
+					require.False(t, res.exits[0].broadcast)
+					require.Equal(t, test.index, res.exits[0].signedVoluntaryExit.Message.ValidatorIndex)
+				} else {
+					require.Equal(t, test.index, res.signedVoluntaryExit.Message.ValidatorIndex)
+				}
+			}
+		})
+	}
+}
+
+func TestVerifyExit(t *testing.T) {
+	require.NoError(t, e2types.InitBLS())
+
+	genesisValidatorsRoot := spec.Root{0x01, 0x02}
+	forkVersion := spec.Version{0x01, 0x00, 0x00, 0x00}
+	pubKey := testutil.HexToPubKey("0xa99a76ed7796f7be22d5b7e85deeb7c5677e88e511e0b337618f8c4eb61349b4bf2d153f649f7b53359fe8b94a38e44c")
+
+	privKey, err := e2types.BLSPrivateKeyFromBytes(testutil.HexToBytes("0x25295f0d1d592a90b333e26e85149708208e9f8e8bc18f6c77bd62f8ad7a6866"))
+	require.NoError(t, err)
+	exit := &spec.VoluntaryExit{
+		Epoch:          100,
+		ValidatorIndex: 123,
+	}
+	root, err := exit.HashTreeRoot()
+	require.NoError(t, err)
+	signingRoot, err := util.SigningRoot(root, spec.DomainType(e2types.DomainVoluntaryExit), forkVersion, genesisValidatorsRoot)
+	require.NoError(t, err)
+	var signature spec.BLSSignature
+	copy(signature[:], privKey.Sign(signingRoot[:]).Marshal())
+
+	tests := []struct {
+		name        string
+		forkVersion spec.Version
+		pubKey      spec.BLSPubKey
+		err         string
+	}{
+		{
+			name:        "WrongForkVersion",
+			forkVersion: spec.Version{0x00, 0x00, 0x00, 0x00},
+			pubKey:      pubKey,
+			err:         "signature does not verify",
+		},
+		{
+			name:        "WrongPubKey",
+			forkVersion: forkVersion,
+			pubKey:      testutil.HexToPubKey("0xb89bebc699769726a318c8e9971bd3171297c61aea4a6578a7a4f94b547dcba5bac16a89108b6b6a1fe3695d1a874a0b"),
+			err:         "signature does not verify",
+		},
+		{
+			name:        "Good",
+			forkVersion: forkVersion,
+			pubKey:      pubKey,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			data := &dataIn{
+				genesisValidatorsRoot: genesisValidatorsRoot,
+			}
+			err := verifyExit(data, &util.ValidatorExitData{
+				Exit: &spec.SignedVoluntaryExit{
+					Message:   exit,
+					Signature: signature,
+				},
+				ForkVersion: test.forkVersion,
+			}, test.pubKey)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
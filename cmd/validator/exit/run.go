@@ -15,13 +15,15 @@ package validatorexit
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 )
 
-// Run runs the wallet create data command.
+// Run runs the validator exit command.
+// For batch exits any results are returned alongside an error if one or more exits failed.
 func Run(cmd *cobra.Command) (string, error) {
 	ctx := context.Background()
 	dataIn, err := input(ctx)
@@ -37,8 +39,13 @@ func Run(cmd *cobra.Command) (string, error) {
 		return "", errors.Wrap(err, "failed to process")
 	}
 
+	var failed error
+	if dataOut.batch && dataOut.failures() > 0 {
+		failed = fmt.Errorf("%d of %d exits failed", dataOut.failures(), len(dataOut.exits))
+	}
+
 	if viper.GetBool("quiet") {
-		return "", nil
+		return "", failed
 	}
 
 	results, err := output(ctx, dataOut)
@@ -46,5 +53,5 @@ func Run(cmd *cobra.Command) (string, error) {
 		return "", errors.Wrap(err, "failed to obtain output")
 	}
 
-	return results, nil
+	return results, failed
 }
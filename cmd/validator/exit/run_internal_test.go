@@ -0,0 +1,147 @@
+// Copyright © 2022 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatorexit
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/aaron-alderman/ethdo/testing/beaconnode"
+	spec "github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/rs/zerolog"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRun(t *testing.T) {
+	zerolog.SetGlobalLevel(zerolog.Disabled)
+
+	tests := []struct {
+		name  string
+		vars  map[string]interface{}
+		err   string
+		exits []*spec.VoluntaryExit
+	}{
+		{
+			name: "UnknownValidator",
+			vars: map[string]interface{}{
+				"timeout":    "5s",
+				"key":        "0x01e748d098d3bcb477d636f19d510399ae18205fadf9814ee67052f88c1f88c0",
+				"passphrase": "pass",
+				"epoch":      "10",
+			},
+			err: "failed to process: validator not known by beacon node",
+		},
+		{
+			name: "Key",
+			vars: map[string]interface{}{
+				"timeout":    "5s",
+				"key":        "0x25295f0d1d592a90b333e26e85149708208e9f8e8bc18f6c77bd62f8ad7a6866",
+				"passphrase": "pass",
+				"epoch":      "10",
+			},
+			exits: []*spec.VoluntaryExit{
+				{
+					Epoch:          10,
+					ValidatorIndex: 0,
+				},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			beaconNode, err := beaconnode.New(ctx,
+				beaconnode.WithFixturesDir("../../../testing/beaconnode/testdata"),
+			)
+			require.NoError(t, err)
+
+			viper.Reset()
+			viper.Set("connection", beaconNode.Address())
+			for k, v := range test.vars {
+				viper.Set(k, v)
+			}
+			_, err = Run(&cobra.Command{})
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+				return
+			}
+			require.NoError(t, err)
+
+			exits, err := beaconNode.VoluntaryExits()
+			require.NoError(t, err)
+			require.Len(t, exits, len(test.exits))
+			for i := range test.exits {
+				require.Equal(t, test.exits[i], exits[i].Message)
+			}
+		})
+	}
+}
+
+func TestRunPrepareOffline(t *testing.T) {
+	zerolog.SetGlobalLevel(zerolog.Disabled)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	beaconNode, err := beaconnode.New(ctx,
+		beaconnode.WithFixturesDir("../../../testing/beaconnode/testdata"),
+	)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		indices []string
+		res     string
+		err     string
+	}{
+		{
+			name: "All",
+			res:  "Chain information for all 3 validators on the chain written to %s",
+		},
+		{
+			name:    "Indices",
+			indices: []string{"0", "1"},
+			res:     "Chain information for 2 validators written to %s",
+		},
+		{
+			name:    "IndicesUnknown",
+			indices: []string{"0", "5", "7"},
+			err:     "failed to process: validators not known by beacon node: 5, 7",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			chainInfoFile := filepath.Join(t.TempDir(), "offline-preparation.json")
+			viper.Reset()
+			viper.Set("connection", beaconNode.Address())
+			viper.Set("timeout", "5s")
+			viper.Set("prepare-offline", true)
+			viper.Set("chain-info", chainInfoFile)
+			viper.Set("indices", test.indices)
+			res, err := Run(&cobra.Command{})
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, fmt.Sprintf(test.res, chainInfoFile), res)
+		})
+	}
+}
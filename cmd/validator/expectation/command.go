@@ -17,6 +17,7 @@ import (
 	"context"
 	"time"
 
+	"github.com/aaron-alderman/ethdo/util"
 	eth2client "github.com/attestantio/go-eth2-client"
 	"github.com/pkg/errors"
 	"github.com/spf13/viper"
@@ -24,6 +25,7 @@ import (
 
 type command struct {
 	quiet   bool
+	format  string
 	verbose bool
 	debug   bool
 
@@ -48,6 +50,7 @@ type command struct {
 func newCommand(ctx context.Context) (*command, error) {
 	c := &command{
 		quiet:   viper.GetBool("quiet"),
+		format:  util.OutputFormat(),
 		verbose: viper.GetBool("verbose"),
 		debug:   viper.GetBool("debug"),
 	}
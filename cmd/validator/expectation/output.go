@@ -17,14 +17,27 @@ import (
 	"context"
 	"strings"
 
+	"github.com/aaron-alderman/ethdo/util"
 	"github.com/hako/durafmt"
 )
 
+type expectationJSON struct {
+	TimeBetweenProposals      string `json:"time_between_proposals"`
+	TimeBetweenSyncCommittees string `json:"time_between_sync_committees"`
+}
+
 func (c *command) output(ctx context.Context) (string, error) {
 	if c.quiet {
 		return "", nil
 	}
 
+	if util.StructuredFormat(c.format) {
+		return util.FormatOutput(c.format, &expectationJSON{
+			TimeBetweenProposals:      c.timeBetweenProposals.String(),
+			TimeBetweenSyncCommittees: c.timeBetweenSyncCommittees.String(),
+		})
+	}
+
 	builder := strings.Builder{}
 
 	builder.WriteString("Expected time between block proposals: ")
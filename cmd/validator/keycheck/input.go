@@ -16,6 +16,7 @@ package validatorkeycheck
 import (
 	"context"
 
+	"github.com/aaron-alderman/ethdo/util"
 	"github.com/pkg/errors"
 	"github.com/spf13/viper"
 )
@@ -23,6 +24,7 @@ import (
 type dataIn struct {
 	// System.
 	quiet   bool
+	format  string
 	verbose bool
 	debug   bool
 	// Withdrawal credentials.
@@ -36,6 +38,7 @@ func input(ctx context.Context) (*dataIn, error) {
 	data := &dataIn{}
 
 	data.quiet = viper.GetBool("quiet")
+	data.format = util.OutputFormat()
 	data.verbose = viper.GetBool("verbose")
 	data.debug = viper.GetBool("debug")
 
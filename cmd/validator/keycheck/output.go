@@ -18,17 +18,24 @@ import (
 	"fmt"
 	"os"
 
+	"github.com/aaron-alderman/ethdo/util"
 	"github.com/pkg/errors"
 )
 
 type dataOut struct {
 	debug   bool
 	quiet   bool
+	format  string
 	verbose bool
 	match   bool
 	path    string
 }
 
+type keycheckJSON struct {
+	Match bool   `json:"match"`
+	Path  string `json:"path,omitempty"`
+}
+
 func output(ctx context.Context, data *dataOut) (string, int, error) {
 	if data == nil {
 		return "", 1, errors.New("no data")
@@ -41,6 +48,20 @@ func output(ctx context.Context, data *dataOut) (string, int, error) {
 		return "", 1, nil
 	}
 
+	if util.StructuredFormat(data.format) {
+		res, err := util.FormatOutput(data.format, &keycheckJSON{
+			Match: data.match,
+			Path:  data.path,
+		})
+		if err != nil {
+			return "", 1, err
+		}
+		if !data.match {
+			return res, 1, nil
+		}
+		return res, 0, nil
+	}
+
 	if data.match {
 		if data.path == "" {
 			return "Withdrawal credentials confirmed", 0, nil
@@ -14,17 +14,13 @@
 package validatorkeycheck
 
 import (
-	"bytes"
 	"context"
 	"encoding/hex"
-	"fmt"
 	"strings"
 
+	"github.com/aaron-alderman/ethdo/util"
 	"github.com/pkg/errors"
-	"github.com/tyler-smith/go-bip39"
 	e2types "github.com/wealdtech/go-eth2-types/v2"
-	util "github.com/wealdtech/go-eth2-util"
-	"golang.org/x/text/unicode/norm"
 )
 
 func process(ctx context.Context, data *dataIn) (*dataOut, error) {
@@ -65,6 +61,7 @@ func process(ctx context.Context, data *dataIn) (*dataOut, error) {
 	results := &dataOut{
 		debug:   data.debug,
 		quiet:   data.quiet,
+		format:  data.format,
 		verbose: data.verbose,
 		match:   match,
 		path:    path,
@@ -74,49 +71,21 @@ func process(ctx context.Context, data *dataIn) (*dataOut, error) {
 }
 
 func checkPrivKey(ctx context.Context, debug bool, validatorWithdrawalCredentials []byte, key *e2types.BLSPrivateKey) (bool, error) {
-	pubKey := key.PublicKey()
-
-	withdrawalCredentials := util.SHA256(pubKey.Marshal())
-	withdrawalCredentials[0] = byte(0) // BLS_WITHDRAWAL_PREFIX
-
-	return bytes.Equal(withdrawalCredentials, validatorWithdrawalCredentials), nil
+	return util.IsBLSWithdrawalCredentials(validatorWithdrawalCredentials, key.PublicKey().Marshal()), nil
 }
 
 func checkMnemonic(ctx context.Context, debug bool, validatorWithdrawalCredentials []byte, mnemonic string) (bool, string, error) {
-	// If there are more than 24 words we treat the additional characters as the passphrase.
-	mnemonicParts := strings.Split(mnemonic, " ")
-	mnemonicPassphrase := ""
-	if len(mnemonicParts) > 24 {
-		mnemonic = strings.Join(mnemonicParts[:24], " ")
-		mnemonicPassphrase = strings.Join(mnemonicParts[24:], " ")
+	seed, err := util.SeedFromMnemonic(mnemonic)
+	if err != nil {
+		return false, "", err
 	}
-	// Normalise the input.
-	mnemonic = string(norm.NFKD.Bytes([]byte(mnemonic)))
-	mnemonicPassphrase = string(norm.NFKD.Bytes([]byte(mnemonicPassphrase)))
 
-	if !bip39.IsMnemonicValid(mnemonic) {
-		return false, "", errors.New("mnemonic is invalid")
+	keys, err := util.WithdrawalKeysForCredentials(seed, [][]byte{validatorWithdrawalCredentials})
+	if err != nil {
+		return false, "", err
 	}
-
-	// Create seed from mnemonic and passphrase.
-	seed := bip39.NewSeed(mnemonic, mnemonicPassphrase)
-	// Check first 1024 indices.
-	for i := 0; i < 1024; i++ {
-		path := fmt.Sprintf("m/12381/3600/%d/0", i)
-		if debug {
-			fmt.Printf("Checking path %s\n", path)
-		}
-		key, err := util.PrivateKeyFromSeedAndPath(seed, path)
-		if err != nil {
-			return false, "", errors.Wrap(err, "failed to generate key")
-		}
-		match, err := checkPrivKey(ctx, debug, validatorWithdrawalCredentials, key)
-		if err != nil {
-			return false, "", errors.Wrap(err, "failed to match key")
-		}
-		if match {
-			return true, path, nil
-		}
+	for _, key := range keys {
+		return true, key.Path, nil
 	}
 
 	return false, "", nil
@@ -0,0 +1,152 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatormaintenancewindow
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/aaron-alderman/ethdo/services/chaintime"
+	"github.com/aaron-alderman/ethdo/util"
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+)
+
+// maxEpochs is the number of epochs, starting with the current epoch, for which
+// beacon nodes provide duties.
+const maxEpochs = 2
+
+type command struct {
+	quiet   bool
+	verbose bool
+	debug   bool
+
+	// Beacon node connection.
+	timeout                  time.Duration
+	connection               string
+	allowInsecureConnections bool
+
+	// Input.
+	validators []string
+	accounts   string
+	duration   time.Duration
+	epochs     uint64
+	windows    int
+	format     string
+
+	// Data access.
+	eth2Client             eth2client.Service
+	chainTime              chaintime.Service
+	validatorsProvider     eth2client.ValidatorsProvider
+	attesterDutiesProvider eth2client.AttesterDutiesProvider
+	proposerDutiesProvider eth2client.ProposerDutiesProvider
+	syncCommitteesProvider eth2client.SyncCommitteesProvider
+
+	// Processing.
+	indices []phase0.ValidatorIndex
+
+	// Results.
+	plan *plan
+}
+
+// plan contains the duties of the validators over the slots considered, and the
+// maintenance windows that avoid them.
+type plan struct {
+	FirstSlot      phase0.Slot          `json:"first_slot"`
+	LastSlot       phase0.Slot          `json:"last_slot"`
+	WindowSlots    uint64               `json:"window_slots"`
+	Proposals      []*duty              `json:"proposals"`
+	SyncCommittees []*syncCommitteeDuty `json:"sync_committees"`
+	Attestations   []*duty              `json:"attestations"`
+	Windows        []*window            `json:"windows"`
+	// ProvisionalFrom is the first slot of the next epoch, if considered.  Proposer
+	// duties from this slot can change if the chain reorganises.
+	ProvisionalFrom phase0.Slot `json:"provisional_from,omitempty"`
+}
+
+// duty is a duty of a validator at a slot.
+type duty struct {
+	Slot           phase0.Slot           `json:"slot"`
+	ValidatorIndex phase0.ValidatorIndex `json:"validator_index"`
+}
+
+// syncCommitteeDuty is the membership of the validators in the sync committee for the
+// slots considered.
+type syncCommitteeDuty struct {
+	Period     uint64                  `json:"period"`
+	FirstSlot  phase0.Slot             `json:"first_slot"`
+	LastSlot   phase0.Slot             `json:"last_slot"`
+	Validators []phase0.ValidatorIndex `json:"validators"`
+}
+
+// window is a range of slots during which the validators can be offline.
+type window struct {
+	StartSlot phase0.Slot `json:"start_slot"`
+	EndSlot   phase0.Slot `json:"end_slot"`
+	StartTime time.Time   `json:"start_time"`
+	EndTime   time.Time   `json:"end_time"`
+	// MissedAttestations are the attestation duties that fall within the window.
+	MissedAttestations []*duty `json:"missed_attestations"`
+}
+
+func newCommand(ctx context.Context) (*command, error) {
+	c := &command{
+		quiet:   viper.GetBool("quiet"),
+		verbose: viper.GetBool("verbose"),
+		debug:   viper.GetBool("debug"),
+	}
+
+	// Timeout.
+	if viper.GetDuration("timeout") == 0 {
+		return nil, errors.New("timeout is required")
+	}
+	c.timeout = viper.GetDuration("timeout")
+
+	if viper.GetString("connection") == "" {
+		return nil, errors.New("connection is required")
+	}
+	c.connection = viper.GetString("connection")
+	c.allowInsecureConnections = viper.GetBool("allow-insecure-connections")
+
+	c.validators = viper.GetStringSlice("validators")
+	c.accounts = viper.GetString("accounts")
+	if len(c.validators) == 0 && c.accounts == "" {
+		return nil, errors.New("validators or accounts is required")
+	}
+
+	c.duration = viper.GetDuration("duration")
+	if c.duration <= 0 {
+		return nil, errors.New("duration is required")
+	}
+
+	c.epochs = viper.GetUint64("epochs")
+	if c.epochs == 0 {
+		return nil, errors.New("epochs must be at least 1")
+	}
+	if c.epochs > maxEpochs {
+		return nil, fmt.Errorf("epochs must be at most %d, as beacon nodes only provide duties for the current and next epochs", maxEpochs)
+	}
+
+	c.windows = viper.GetInt("windows")
+	if c.windows <= 0 {
+		return nil, errors.New("windows must be at least 1")
+	}
+
+	c.format = util.OutputFormat()
+
+	return c, nil
+}
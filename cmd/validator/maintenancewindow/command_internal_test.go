@@ -0,0 +1,144 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatormaintenancewindow
+
+import (
+	"context"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInput(t *testing.T) {
+	tests := []struct {
+		name    string
+		vars    map[string]interface{}
+		epochs  uint64
+		windows int
+		err     string
+	}{
+		{
+			name: "TimeoutMissing",
+			vars: map[string]interface{}{
+				"connection": "http://localhost:5051",
+				"validators": []string{"1"},
+				"duration":   "10m",
+				"epochs":     "2",
+				"windows":    "3",
+			},
+			err: "timeout is required",
+		},
+		{
+			name: "ConnectionMissing",
+			vars: map[string]interface{}{
+				"timeout":    "5s",
+				"validators": []string{"1"},
+				"duration":   "10m",
+				"epochs":     "2",
+				"windows":    "3",
+			},
+			err: "connection is required",
+		},
+		{
+			name: "ValidatorsMissing",
+			vars: map[string]interface{}{
+				"timeout":    "5s",
+				"connection": "http://localhost:5051",
+				"duration":   "10m",
+				"epochs":     "2",
+				"windows":    "3",
+			},
+			err: "validators or accounts is required",
+		},
+		{
+			name: "DurationMissing",
+			vars: map[string]interface{}{
+				"timeout":    "5s",
+				"connection": "http://localhost:5051",
+				"validators": []string{"1"},
+				"epochs":     "2",
+				"windows":    "3",
+			},
+			err: "duration is required",
+		},
+		{
+			name: "EpochsZero",
+			vars: map[string]interface{}{
+				"timeout":    "5s",
+				"connection": "http://localhost:5051",
+				"validators": []string{"1"},
+				"duration":   "10m",
+				"epochs":     "0",
+				"windows":    "3",
+			},
+			err: "epochs must be at least 1",
+		},
+		{
+			name: "EpochsTooLarge",
+			vars: map[string]interface{}{
+				"timeout":    "5s",
+				"connection": "http://localhost:5051",
+				"validators": []string{"1"},
+				"duration":   "10m",
+				"epochs":     "3",
+				"windows":    "3",
+			},
+			err: "epochs must be at most 2, as beacon nodes only provide duties for the current and next epochs",
+		},
+		{
+			name: "WindowsZero",
+			vars: map[string]interface{}{
+				"timeout":    "5s",
+				"connection": "http://localhost:5051",
+				"validators": []string{"1"},
+				"duration":   "10m",
+				"epochs":     "2",
+				"windows":    "0",
+			},
+			err: "windows must be at least 1",
+		},
+		{
+			name: "Good",
+			vars: map[string]interface{}{
+				"timeout":    "5s",
+				"connection": "http://localhost:5051",
+				"accounts":   "Test wallet",
+				"duration":   "10m",
+				"epochs":     "1",
+				"windows":    "5",
+			},
+			epochs:  1,
+			windows: 5,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			viper.Reset()
+
+			for k, v := range test.vars {
+				viper.Set(k, v)
+			}
+			c, err := newCommand(context.Background())
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, test.epochs, c.epochs)
+				require.Equal(t, test.windows, c.windows)
+			}
+		})
+	}
+}
@@ -0,0 +1,90 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatormaintenancewindow
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/aaron-alderman/ethdo/util"
+)
+
+func (c *command) output(ctx context.Context) (string, error) {
+	if c.quiet {
+		return "", nil
+	}
+
+	if util.StructuredFormat(c.format) {
+		return c.outputStructured(ctx)
+	}
+
+	return c.outputTxt(ctx)
+}
+
+func (c *command) outputStructured(_ context.Context) (string, error) {
+	data, err := json.Marshal(c.plan)
+	if err != nil {
+		return "", err
+	}
+	return util.FormatJSONData(c.format, data)
+}
+
+func (c *command) outputTxt(_ context.Context) (string, error) {
+	builder := strings.Builder{}
+
+	builder.WriteString(fmt.Sprintf("Slots considered: %d to %d\n", c.plan.FirstSlot, c.plan.LastSlot))
+	builder.WriteString(fmt.Sprintf("Window length: %d slots\n", c.plan.WindowSlots))
+	for _, proposal := range c.plan.Proposals {
+		builder.WriteString(fmt.Sprintf("Proposal: validator %d at slot %d\n", proposal.ValidatorIndex, proposal.Slot))
+	}
+	for _, syncCommittee := range c.plan.SyncCommittees {
+		validators := make([]string, 0, len(syncCommittee.Validators))
+		for _, index := range syncCommittee.Validators {
+			validators = append(validators, fmt.Sprintf("%d", index))
+		}
+		builder.WriteString(fmt.Sprintf("Sync committee period %d: validators %s for slots %d to %d\n",
+			syncCommittee.Period,
+			strings.Join(validators, ", "),
+			syncCommittee.FirstSlot,
+			syncCommittee.LastSlot,
+		))
+	}
+
+	if len(c.plan.Windows) == 0 {
+		builder.WriteString("No window avoids proposals and sync committee duties\n")
+	}
+	for i, window := range c.plan.Windows {
+		builder.WriteString(fmt.Sprintf("Window %d: slots %d to %d (%s to %s), %d missed attestations\n",
+			i+1,
+			window.StartSlot,
+			window.EndSlot,
+			window.StartTime.Format("2006-01-02 15:04:05"),
+			window.EndTime.Format("2006-01-02 15:04:05"),
+			len(window.MissedAttestations),
+		))
+		if c.verbose {
+			for _, attestation := range window.MissedAttestations {
+				builder.WriteString(fmt.Sprintf("  Attestation: validator %d at slot %d\n", attestation.ValidatorIndex, attestation.Slot))
+			}
+		}
+	}
+
+	if c.plan.ProvisionalFrom != 0 {
+		builder.WriteString(fmt.Sprintf("Note: proposer duties from slot %d are for the next epoch, and can change if the chain reorganises\n", c.plan.ProvisionalFrom))
+	}
+
+	return strings.TrimSuffix(builder.String(), "\n"), nil
+}
@@ -0,0 +1,144 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatormaintenancewindow
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOutput(t *testing.T) {
+	genesisTime := time.Unix(1606824023, 0).UTC()
+	testPlan := &plan{
+		FirstSlot:       10,
+		LastSlot:        31,
+		WindowSlots:     4,
+		ProvisionalFrom: 16,
+		Proposals:       []*duty{{Slot: 15, ValidatorIndex: 1}},
+		SyncCommittees: []*syncCommitteeDuty{
+			{Period: 0, FirstSlot: 28, LastSlot: 31, Validators: []phase0.ValidatorIndex{2, 3}},
+		},
+		Attestations: []*duty{
+			{Slot: 12, ValidatorIndex: 2},
+		},
+		Windows: []*window{
+			{
+				StartSlot:          16,
+				EndSlot:            19,
+				StartTime:          genesisTime.Add(16 * 12 * time.Second),
+				EndTime:            genesisTime.Add(20 * 12 * time.Second),
+				MissedAttestations: []*duty{},
+			},
+			{
+				StartSlot:          11,
+				EndSlot:            14,
+				StartTime:          genesisTime.Add(11 * 12 * time.Second),
+				EndTime:            genesisTime.Add(15 * 12 * time.Second),
+				MissedAttestations: []*duty{{Slot: 12, ValidatorIndex: 2}},
+			},
+		},
+	}
+
+	tests := []struct {
+		name    string
+		command *command
+		res     string
+	}{
+		{
+			name: "Quiet",
+			command: &command{
+				quiet: true,
+				plan:  testPlan,
+			},
+		},
+		{
+			name: "NoWindows",
+			command: &command{
+				plan: &plan{
+					FirstSlot:   10,
+					LastSlot:    31,
+					WindowSlots: 4,
+					Windows:     []*window{},
+				},
+			},
+			res: `Slots considered: 10 to 31
+Window length: 4 slots
+No window avoids proposals and sync committee duties`,
+		},
+		{
+			name: "Text",
+			command: &command{
+				plan: testPlan,
+			},
+			res: `Slots considered: 10 to 31
+Window length: 4 slots
+Proposal: validator 1 at slot 15
+Sync committee period 0: validators 2, 3 for slots 28 to 31
+Window 1: slots 16 to 19 (2020-12-01 12:03:35 to 2020-12-01 12:04:23), 0 missed attestations
+Window 2: slots 11 to 14 (2020-12-01 12:02:35 to 2020-12-01 12:03:23), 1 missed attestations
+Note: proposer duties from slot 16 are for the next epoch, and can change if the chain reorganises`,
+		},
+		{
+			name: "Verbose",
+			command: &command{
+				verbose: true,
+				plan:    testPlan,
+			},
+			res: `Slots considered: 10 to 31
+Window length: 4 slots
+Proposal: validator 1 at slot 15
+Sync committee period 0: validators 2, 3 for slots 28 to 31
+Window 1: slots 16 to 19 (2020-12-01 12:03:35 to 2020-12-01 12:04:23), 0 missed attestations
+Window 2: slots 11 to 14 (2020-12-01 12:02:35 to 2020-12-01 12:03:23), 1 missed attestations
+  Attestation: validator 2 at slot 12
+Note: proposer duties from slot 16 are for the next epoch, and can change if the chain reorganises`,
+		},
+		{
+			name: "JSON",
+			command: &command{
+				format: "json",
+				plan: &plan{
+					FirstSlot:      10,
+					LastSlot:       31,
+					WindowSlots:    22,
+					Proposals:      []*duty{},
+					SyncCommittees: []*syncCommitteeDuty{},
+					Attestations:   []*duty{{Slot: 12, ValidatorIndex: 2}},
+					Windows: []*window{
+						{
+							StartSlot:          10,
+							EndSlot:            31,
+							StartTime:          genesisTime.Add(10 * 12 * time.Second),
+							EndTime:            genesisTime.Add(32 * 12 * time.Second),
+							MissedAttestations: []*duty{{Slot: 12, ValidatorIndex: 2}},
+						},
+					},
+				},
+			},
+			res: `{"first_slot":10,"last_slot":31,"window_slots":22,"proposals":[],"sync_committees":[],"attestations":[{"slot":12,"validator_index":2}],"windows":[{"start_slot":10,"end_slot":31,"start_time":"2020-12-01T12:02:23Z","end_time":"2020-12-01T12:06:47Z","missed_attestations":[{"slot":12,"validator_index":2}]}]}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			res, err := test.command.output(context.Background())
+			require.NoError(t, err)
+			require.Equal(t, test.res, res)
+		})
+	}
+}
@@ -0,0 +1,328 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatormaintenancewindow
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	standardchaintime "github.com/aaron-alderman/ethdo/services/chaintime/standard"
+	"github.com/aaron-alderman/ethdo/util"
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+)
+
+func (c *command) process(ctx context.Context) error {
+	// Obtain information we need to process.
+	if err := c.setup(ctx); err != nil {
+		return err
+	}
+
+	if err := c.obtainValidators(ctx); err != nil {
+		return err
+	}
+
+	// Windows start no earlier than the next slot, and end before the duties run out.
+	firstSlot := c.chainTime.CurrentSlot() + 1
+	lastSlot := c.chainTime.FirstSlotOfEpoch(c.chainTime.CurrentEpoch()+phase0.Epoch(c.epochs)) - 1
+	windowSlots := uint64((c.duration + c.chainTime.SlotDuration() - 1) / c.chainTime.SlotDuration())
+	if firstSlot+phase0.Slot(windowSlots) > lastSlot+1 {
+		return fmt.Errorf("duration of %d slots is longer than the %d remaining slots of the epochs for which duties are obtained", windowSlots, lastSlot+1-firstSlot)
+	}
+	c.plan = &plan{
+		FirstSlot:      firstSlot,
+		LastSlot:       lastSlot,
+		WindowSlots:    windowSlots,
+		Proposals:      make([]*duty, 0),
+		SyncCommittees: make([]*syncCommitteeDuty, 0),
+		Attestations:   make([]*duty, 0),
+	}
+
+	if c.chainTime.SlotToEpoch(lastSlot) > c.chainTime.CurrentEpoch() {
+		c.plan.ProvisionalFrom = c.chainTime.FirstSlotOfEpoch(c.chainTime.CurrentEpoch() + 1)
+	}
+
+	for epoch := c.chainTime.SlotToEpoch(firstSlot); epoch <= c.chainTime.SlotToEpoch(lastSlot); epoch++ {
+		if err := c.obtainProposerDuties(ctx, epoch); err != nil {
+			return err
+		}
+		if err := c.obtainAttesterDuties(ctx, epoch); err != nil {
+			return err
+		}
+	}
+	sortDuties(c.plan.Proposals)
+	sortDuties(c.plan.Attestations)
+
+	// Sync committee messages at a slot are for the committee of the following slot.
+	for period := c.chainTime.SlotToSyncCommitteePeriod(firstSlot + 1); period <= c.chainTime.SlotToSyncCommitteePeriod(lastSlot+1); period++ {
+		if err := c.obtainSyncCommitteeDuty(ctx, period); err != nil {
+			return err
+		}
+	}
+
+	c.plan.Windows = c.findWindows()
+
+	return nil
+}
+
+// obtainValidators obtains the indices of the validators for which to plan.
+func (c *command) obtainValidators(ctx context.Context) error {
+	validators, err := util.ParseValidators(ctx, c.validatorsProvider, c.validators, c.accounts)
+	if err != nil {
+		return err
+	}
+
+	c.indices = make([]phase0.ValidatorIndex, 0, len(validators))
+	for index := range validators {
+		c.indices = append(c.indices, index)
+	}
+	sort.Slice(c.indices, func(i int, j int) bool {
+		return c.indices[i] < c.indices[j]
+	})
+
+	return nil
+}
+
+func (c *command) obtainProposerDuties(ctx context.Context, epoch phase0.Epoch) error {
+	duties, err := c.proposerDutiesProvider.ProposerDuties(ctx, epoch, c.indices)
+	if err != nil {
+		return errors.Wrap(err, fmt.Sprintf("failed to obtain proposer duties for epoch %d", epoch))
+	}
+
+	for _, proposerDuty := range duties {
+		if c.isOurs(proposerDuty.ValidatorIndex) && c.inPlan(proposerDuty.Slot) {
+			c.plan.Proposals = append(c.plan.Proposals, &duty{
+				Slot:           proposerDuty.Slot,
+				ValidatorIndex: proposerDuty.ValidatorIndex,
+			})
+		}
+	}
+
+	return nil
+}
+
+func (c *command) obtainAttesterDuties(ctx context.Context, epoch phase0.Epoch) error {
+	duties, err := c.attesterDutiesProvider.AttesterDuties(ctx, epoch, c.indices)
+	if err != nil {
+		return errors.Wrap(err, fmt.Sprintf("failed to obtain attester duties for epoch %d", epoch))
+	}
+
+	for _, attesterDuty := range duties {
+		if c.isOurs(attesterDuty.ValidatorIndex) && c.inPlan(attesterDuty.Slot) {
+			c.plan.Attestations = append(c.plan.Attestations, &duty{
+				Slot:           attesterDuty.Slot,
+				ValidatorIndex: attesterDuty.ValidatorIndex,
+			})
+		}
+	}
+
+	return nil
+}
+
+func (c *command) obtainSyncCommitteeDuty(ctx context.Context, period uint64) error {
+	if period < c.chainTime.AltairInitialSyncCommitteePeriod() {
+		// The period is pre-Altair.  No info but no error.
+		return nil
+	}
+	firstSlot, lastSlot, inPlan := c.syncCommitteeDutySlots(period)
+	if !inPlan {
+		return nil
+	}
+
+	committee, err := c.syncCommitteesProvider.SyncCommitteeAtEpoch(ctx, "head", c.chainTime.FirstEpochOfSyncPeriod(period))
+	if err != nil {
+		return errors.Wrap(err, fmt.Sprintf("failed to obtain sync committee for period %d", period))
+	}
+	if committee == nil {
+		return fmt.Errorf("no sync committee returned for period %d", period)
+	}
+
+	// A validator can appear in the sync committee more than once.
+	members := make(map[phase0.ValidatorIndex]bool)
+	for _, index := range committee.Validators {
+		if c.isOurs(index) {
+			members[index] = true
+		}
+	}
+	if len(members) == 0 {
+		return nil
+	}
+
+	syncCommitteeDuty := &syncCommitteeDuty{
+		Period:     period,
+		FirstSlot:  firstSlot,
+		LastSlot:   lastSlot,
+		Validators: make([]phase0.ValidatorIndex, 0, len(members)),
+	}
+	for _, index := range c.indices {
+		if members[index] {
+			syncCommitteeDuty.Validators = append(syncCommitteeDuty.Validators, index)
+		}
+	}
+	c.plan.SyncCommittees = append(c.plan.SyncCommittees, syncCommitteeDuty)
+
+	return nil
+}
+
+// syncCommitteeDutySlots returns the first and last slots within the plan at which members
+// of the sync committee for the given period sign, or false if there are no such slots.
+// Sync committee messages at a slot are for the committee of the following slot, so the
+// duty runs from the slot before the period starts to the slot before it ends.
+func (c *command) syncCommitteeDutySlots(period uint64) (phase0.Slot, phase0.Slot, bool) {
+	firstSlot := c.chainTime.FirstSlotOfEpoch(c.chainTime.FirstEpochOfSyncPeriod(period))
+	if firstSlot > 0 {
+		firstSlot--
+	}
+	lastSlot := c.chainTime.FirstSlotOfEpoch(c.chainTime.FirstEpochOfSyncPeriod(period+1)) - 2
+
+	if firstSlot < c.plan.FirstSlot {
+		firstSlot = c.plan.FirstSlot
+	}
+	if lastSlot > c.plan.LastSlot {
+		lastSlot = c.plan.LastSlot
+	}
+	if firstSlot > lastSlot {
+		return 0, 0, false
+	}
+
+	return firstSlot, lastSlot, true
+}
+
+// isOurs returns true if the validator is one for which we are planning.
+func (c *command) isOurs(index phase0.ValidatorIndex) bool {
+	i := sort.Search(len(c.indices), func(i int) bool { return c.indices[i] >= index })
+	return i < len(c.indices) && c.indices[i] == index
+}
+
+// inPlan returns true if the slot is within the slots considered by the plan.
+func (c *command) inPlan(slot phase0.Slot) bool {
+	return slot >= c.plan.FirstSlot && slot <= c.plan.LastSlot
+}
+
+// sortDuties sorts duties by slot and then validator index.
+func sortDuties(duties []*duty) {
+	sort.Slice(duties, func(i int, j int) bool {
+		if duties[i].Slot != duties[j].Slot {
+			return duties[i].Slot < duties[j].Slot
+		}
+		return duties[i].ValidatorIndex < duties[j].ValidatorIndex
+	})
+}
+
+// findWindows finds the windows that avoid proposals and sync committee duties, ranked
+// by the number of attestations they miss and then by how soon they start.  Windows do
+// not overlap, so that each one offers a distinct choice.
+func (c *command) findWindows() []*window {
+	blocked := make(map[phase0.Slot]bool)
+	for _, proposal := range c.plan.Proposals {
+		blocked[proposal.Slot] = true
+	}
+	for _, syncCommittee := range c.plan.SyncCommittees {
+		for slot := syncCommittee.FirstSlot; slot <= syncCommittee.LastSlot; slot++ {
+			blocked[slot] = true
+		}
+	}
+
+	candidates := make([]*window, 0)
+	windowSlots := phase0.Slot(c.plan.WindowSlots)
+	for start := c.plan.FirstSlot; start+windowSlots-1 <= c.plan.LastSlot; start++ {
+		end := start + windowSlots - 1
+		available := true
+		for slot := start; slot <= end; slot++ {
+			if blocked[slot] {
+				available = false
+				break
+			}
+		}
+		if !available {
+			continue
+		}
+		candidate := &window{
+			StartSlot:          start,
+			EndSlot:            end,
+			StartTime:          c.chainTime.StartOfSlot(start),
+			EndTime:            c.chainTime.StartOfSlot(end + 1),
+			MissedAttestations: make([]*duty, 0),
+		}
+		for _, attestation := range c.plan.Attestations {
+			if attestation.Slot >= start && attestation.Slot <= end {
+				candidate.MissedAttestations = append(candidate.MissedAttestations, attestation)
+			}
+		}
+		candidates = append(candidates, candidate)
+	}
+	sort.SliceStable(candidates, func(i int, j int) bool {
+		return len(candidates[i].MissedAttestations) < len(candidates[j].MissedAttestations)
+	})
+
+	windows := make([]*window, 0, c.windows)
+	for _, candidate := range candidates {
+		if len(windows) == c.windows {
+			break
+		}
+		overlaps := false
+		for _, chosen := range windows {
+			if candidate.StartSlot <= chosen.EndSlot && candidate.EndSlot >= chosen.StartSlot {
+				overlaps = true
+				break
+			}
+		}
+		if !overlaps {
+			windows = append(windows, candidate)
+		}
+	}
+
+	return windows
+}
+
+func (c *command) setup(ctx context.Context) error {
+	var err error
+
+	// Connect to the client.
+	c.eth2Client, err = util.ConnectToBeaconNode(ctx, c.connection, c.timeout, c.allowInsecureConnections)
+	if err != nil {
+		return errors.Wrap(err, "failed to connect to beacon node")
+	}
+
+	c.chainTime, err = standardchaintime.New(ctx,
+		standardchaintime.WithSpecProvider(c.eth2Client.(eth2client.SpecProvider)),
+		standardchaintime.WithForkScheduleProvider(c.eth2Client.(eth2client.ForkScheduleProvider)),
+		standardchaintime.WithGenesisTimeProvider(c.eth2Client.(eth2client.GenesisTimeProvider)),
+	)
+	if err != nil {
+		return errors.Wrap(err, "failed to set up chaintime service")
+	}
+
+	var isProvider bool
+	c.validatorsProvider, isProvider = c.eth2Client.(eth2client.ValidatorsProvider)
+	if !isProvider {
+		return errors.New("connection does not provide validators")
+	}
+	c.attesterDutiesProvider, isProvider = c.eth2Client.(eth2client.AttesterDutiesProvider)
+	if !isProvider {
+		return errors.New("connection does not provide attester duties")
+	}
+	c.proposerDutiesProvider, isProvider = c.eth2Client.(eth2client.ProposerDutiesProvider)
+	if !isProvider {
+		return errors.New("connection does not provide proposer duties")
+	}
+	c.syncCommitteesProvider, isProvider = c.eth2Client.(eth2client.SyncCommitteesProvider)
+	if !isProvider {
+		return errors.New("connection does not provide sync committees")
+	}
+
+	return nil
+}
@@ -0,0 +1,262 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatormaintenancewindow
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aaron-alderman/ethdo/services/chaintime"
+	standardchaintime "github.com/aaron-alderman/ethdo/services/chaintime/standard"
+	"github.com/aaron-alderman/ethdo/testing/mock"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsOurs(t *testing.T) {
+	c := &command{
+		indices: []phase0.ValidatorIndex{2, 5, 9},
+	}
+	require.False(t, c.isOurs(1))
+	require.True(t, c.isOurs(2))
+	require.False(t, c.isOurs(3))
+	require.True(t, c.isOurs(9))
+	require.False(t, c.isOurs(10))
+}
+
+// testChainTime creates a chaintime service with 12 second slots, 32 slots per epoch
+// 256 epochs per sync committee period and Altair from genesis.
+func testChainTime(t *testing.T, genesisTime time.Time) chaintime.Service {
+	chainTime, err := standardchaintime.New(context.Background(),
+		standardchaintime.WithGenesisTimeProvider(mock.NewGenesisTimeProvider(genesisTime)),
+		standardchaintime.WithSpecProvider(mock.NewSpecProvider(12*time.Second, 32, 256)),
+		standardchaintime.WithForkScheduleProvider(mock.NewForkScheduleProvider([]*phase0.Fork{
+			{
+				PreviousVersion: phase0.Version{0x00, 0x00, 0x00, 0x00},
+				CurrentVersion:  phase0.Version{0x00, 0x00, 0x00, 0x00},
+				Epoch:           0,
+			},
+			{
+				PreviousVersion: phase0.Version{0x00, 0x00, 0x00, 0x00},
+				CurrentVersion:  phase0.Version{0x01, 0x00, 0x00, 0x00},
+				Epoch:           0,
+			},
+		})),
+	)
+	require.NoError(t, err)
+
+	return chainTime
+}
+
+func TestFindWindows(t *testing.T) {
+	genesisTime := time.Unix(1606824023, 0).UTC()
+	chainTime := testChainTime(t, genesisTime)
+
+	attestations := []*duty{
+		{Slot: 10, ValidatorIndex: 1},
+		{Slot: 12, ValidatorIndex: 2},
+		{Slot: 20, ValidatorIndex: 1},
+		{Slot: 21, ValidatorIndex: 2},
+		{Slot: 22, ValidatorIndex: 3},
+	}
+
+	tests := []struct {
+		name    string
+		plan    *plan
+		windows int
+		res     []*window
+	}{
+		{
+			name: "Blocked",
+			plan: &plan{
+				FirstSlot:   10,
+				LastSlot:    31,
+				WindowSlots: 4,
+				Proposals:   []*duty{{Slot: 15, ValidatorIndex: 1}},
+				SyncCommittees: []*syncCommitteeDuty{
+					{Period: 0, FirstSlot: 10, LastSlot: 31, Validators: []phase0.ValidatorIndex{2}},
+				},
+				Attestations: attestations,
+			},
+			windows: 3,
+			res:     []*window{},
+		},
+		{
+			name: "Good",
+			plan: &plan{
+				FirstSlot:   10,
+				LastSlot:    31,
+				WindowSlots: 4,
+				Proposals:   []*duty{{Slot: 15, ValidatorIndex: 1}},
+				SyncCommittees: []*syncCommitteeDuty{
+					{Period: 0, FirstSlot: 28, LastSlot: 31, Validators: []phase0.ValidatorIndex{2}},
+				},
+				Attestations: attestations,
+			},
+			windows: 3,
+			res: []*window{
+				{
+					StartSlot:          16,
+					EndSlot:            19,
+					StartTime:          genesisTime.Add(16 * 12 * time.Second),
+					EndTime:            genesisTime.Add(20 * 12 * time.Second),
+					MissedAttestations: []*duty{},
+				},
+				{
+					StartSlot:          23,
+					EndSlot:            26,
+					StartTime:          genesisTime.Add(23 * 12 * time.Second),
+					EndTime:            genesisTime.Add(27 * 12 * time.Second),
+					MissedAttestations: []*duty{},
+				},
+				{
+					StartSlot:          11,
+					EndSlot:            14,
+					StartTime:          genesisTime.Add(11 * 12 * time.Second),
+					EndTime:            genesisTime.Add(15 * 12 * time.Second),
+					MissedAttestations: []*duty{{Slot: 12, ValidatorIndex: 2}},
+				},
+			},
+		},
+		{
+			name: "SingleWindow",
+			plan: &plan{
+				FirstSlot:    10,
+				LastSlot:     31,
+				WindowSlots:  22,
+				Attestations: attestations,
+			},
+			windows: 3,
+			res: []*window{
+				{
+					StartSlot:          10,
+					EndSlot:            31,
+					StartTime:          genesisTime.Add(10 * 12 * time.Second),
+					EndTime:            genesisTime.Add(32 * 12 * time.Second),
+					MissedAttestations: attestations,
+				},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := &command{
+				chainTime: chainTime,
+				windows:   test.windows,
+				plan:      test.plan,
+			}
+			require.Equal(t, test.res, c.findWindows())
+		})
+	}
+}
+
+func TestSyncCommitteeDutySlots(t *testing.T) {
+	c := &command{
+		chainTime: testChainTime(t, time.Unix(1606824023, 0).UTC()),
+	}
+
+	tests := []struct {
+		name      string
+		plan      *plan
+		period    uint64
+		firstSlot phase0.Slot
+		lastSlot  phase0.Slot
+		inPlan    bool
+	}{
+		{
+			name:      "Genesis",
+			plan:      &plan{FirstSlot: 0, LastSlot: 20000},
+			period:    0,
+			firstSlot: 0,
+			lastSlot:  8190,
+			inPlan:    true,
+		},
+		{
+			name:      "Full",
+			plan:      &plan{FirstSlot: 0, LastSlot: 20000},
+			period:    1,
+			firstSlot: 8191,
+			lastSlot:  16382,
+			inPlan:    true,
+		},
+		{
+			name:      "Clamped",
+			plan:      &plan{FirstSlot: 8185, LastSlot: 8200},
+			period:    1,
+			firstSlot: 8191,
+			lastSlot:  8200,
+			inPlan:    true,
+		},
+		{
+			name:      "PreviousPeriodLastSlot",
+			plan:      &plan{FirstSlot: 8191, LastSlot: 8200},
+			period:    0,
+			firstSlot: 0,
+			lastSlot:  0,
+			inPlan:    false,
+		},
+		{
+			name:      "PreviousPeriodEnds",
+			plan:      &plan{FirstSlot: 8190, LastSlot: 8200},
+			period:    0,
+			firstSlot: 8190,
+			lastSlot:  8190,
+			inPlan:    true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c.plan = test.plan
+			firstSlot, lastSlot, inPlan := c.syncCommitteeDutySlots(test.period)
+			require.Equal(t, test.inPlan, inPlan)
+			require.Equal(t, test.firstSlot, firstSlot)
+			require.Equal(t, test.lastSlot, lastSlot)
+		})
+	}
+}
+
+func TestFindWindowsSyncCommitteePeriodBoundary(t *testing.T) {
+	genesisTime := time.Unix(1606824023, 0).UTC()
+	c := &command{
+		chainTime: testChainTime(t, genesisTime),
+		windows:   3,
+		plan: &plan{
+			FirstSlot:    8185,
+			LastSlot:     8200,
+			WindowSlots:  6,
+			Attestations: []*duty{{Slot: 8185, ValidatorIndex: 1}},
+		},
+	}
+
+	// The validator is a member of the sync committee for period 1, which starts at slot
+	// 8192, so its duty starts at slot 8191 and the only window must end at slot 8190
+	// even though that misses an attestation.
+	firstSlot, lastSlot, inPlan := c.syncCommitteeDutySlots(1)
+	require.True(t, inPlan)
+	c.plan.SyncCommittees = []*syncCommitteeDuty{
+		{Period: 1, FirstSlot: firstSlot, LastSlot: lastSlot, Validators: []phase0.ValidatorIndex{1}},
+	}
+	require.Equal(t, []*window{
+		{
+			StartSlot:          8185,
+			EndSlot:            8190,
+			StartTime:          genesisTime.Add(8185 * 12 * time.Second),
+			EndTime:            genesisTime.Add(8191 * 12 * time.Second),
+			MissedAttestations: []*duty{{Slot: 8185, ValidatorIndex: 1}},
+		},
+	}, c.findWindows())
+}
@@ -0,0 +1,163 @@
+// Copyright © 2022 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatorperformance
+
+import (
+	"context"
+	"time"
+
+	"fmt"
+	"github.com/aaron-alderman/ethdo/services/chaintime"
+	"github.com/aaron-alderman/ethdo/util"
+	eth2client "github.com/attestantio/go-eth2-client"
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+)
+
+type command struct {
+	quiet   bool
+	verbose bool
+	debug   bool
+
+	// Beacon node connection.
+	timeout                  time.Duration
+	connection               string
+	allowInsecureConnections bool
+
+	// Input.
+	validators []string
+	accounts   string
+	fromEpoch  string
+	toEpoch    string
+	format     string
+
+	// Data access.
+	eth2Client                eth2client.Service
+	chainTime                 chaintime.Service
+	validatorsProvider        eth2client.ValidatorsProvider
+	validatorBalancesProvider eth2client.ValidatorBalancesProvider
+	attesterDutiesProvider    eth2client.AttesterDutiesProvider
+	proposerDutiesProvider    eth2client.ProposerDutiesProvider
+	syncCommitteesProvider    eth2client.SyncCommitteesProvider
+	blocksProvider            eth2client.SignedBeaconBlockProvider
+	headersProvider           eth2client.BeaconBlockHeadersProvider
+
+	// Processing.
+	blocks map[phase0.Slot]*spec.VersionedSignedBeaconBlock
+
+	// Results.
+	from         phase0.Epoch
+	to           phase0.Epoch
+	performances []*validatorPerformance
+}
+
+// validatorPerformance is the performance of a single validator over the epoch range.
+type validatorPerformance struct {
+	Index  phase0.ValidatorIndex `json:"index"`
+	PubKey string                `json:"pubkey"`
+	// Attestations is the number of attestations the validator was expected to make.
+	Attestations int `json:"attestations"`
+	// AttestationsIncluded is the number of attestations included on chain.
+	AttestationsIncluded int `json:"attestations_included"`
+	// InclusionDistance is the total inclusion distance of included attestations.
+	InclusionDistance uint64 `json:"inclusion_distance"`
+	// HeadCorrect is the number of included attestations that voted for the correct head.
+	HeadCorrect int `json:"head_correct"`
+	// TargetCorrect is the number of included attestations that voted for the correct target.
+	TargetCorrect int `json:"target_correct"`
+	// SourceTimely is the number of included attestations that were included quickly enough
+	// for their source vote to count.  The source vote of any included attestation is correct,
+	// otherwise it could not have been included.
+	SourceTimely int `json:"source_timely"`
+	// Proposals is the number of blocks the validator was expected to propose.
+	Proposals int `json:"proposals"`
+	// ProposalsIncluded is the number of proposed blocks that are on chain.
+	ProposalsIncluded int `json:"proposals_included"`
+	// SyncCommitteeDuties is the number of blocks for which the validator was expected to
+	// contribute to the sync aggregate.
+	SyncCommitteeDuties int `json:"sync_committee_duties"`
+	// SyncCommitteeMissed is the number of blocks for which the validator's contribution to
+	// the sync aggregate was missing.
+	SyncCommitteeMissed int `json:"sync_committee_missed"`
+	// StartBalance is the balance of the validator at the start of the epoch range.
+	StartBalance phase0.Gwei `json:"start_balance"`
+	// EndBalance is the balance of the validator at the end of the epoch range.
+	EndBalance phase0.Gwei `json:"end_balance"`
+
+	// validator is the validator as known by the beacon node.
+	validator *apiv1.Validator
+}
+
+// BalanceDelta returns the change in balance over the epoch range.
+func (v *validatorPerformance) BalanceDelta() int64 {
+	return int64(v.EndBalance) - int64(v.StartBalance)
+}
+
+// AverageInclusionDistance returns the average inclusion distance of included attestations.
+func (v *validatorPerformance) AverageInclusionDistance() float64 {
+	if v.AttestationsIncluded == 0 {
+		return 0
+	}
+	return float64(v.InclusionDistance) / float64(v.AttestationsIncluded)
+}
+
+func newCommand(ctx context.Context) (*command, error) {
+	c := &command{
+		quiet:   viper.GetBool("quiet"),
+		verbose: viper.GetBool("verbose"),
+		debug:   viper.GetBool("debug"),
+		blocks:  make(map[phase0.Slot]*spec.VersionedSignedBeaconBlock),
+	}
+
+	// Timeout.
+	if viper.GetDuration("timeout") == 0 {
+		return nil, errors.New("timeout is required")
+	}
+	c.timeout = viper.GetDuration("timeout")
+
+	if viper.GetString("connection") == "" {
+		return nil, errors.New("connection is required")
+	}
+	c.connection = viper.GetString("connection")
+	c.allowInsecureConnections = viper.GetBool("allow-insecure-connections")
+
+	c.validators = viper.GetStringSlice("validators")
+	c.accounts = viper.GetString("accounts")
+	if len(c.validators) == 0 && c.accounts == "" {
+		return nil, errors.New("validators or accounts is required")
+	}
+
+	// Default to the last complete epoch.
+	c.toEpoch = viper.GetString("to-epoch")
+	if c.toEpoch == "" {
+		c.toEpoch = "last"
+	}
+	c.fromEpoch = viper.GetString("from-epoch")
+	if c.fromEpoch == "" {
+		c.fromEpoch = c.toEpoch
+	}
+
+	c.format = util.OutputFormat()
+	if viper.GetBool("csv") {
+		if c.format != util.FormatText && c.format != util.FormatCSV {
+			return nil, fmt.Errorf("only one of %s and csv output allowed", c.format)
+		}
+		c.format = util.FormatCSV
+	}
+
+	return c, nil
+}
@@ -0,0 +1,48 @@
+// Copyright © 2022 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatorperformance
+
+import (
+	"context"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+)
+
+// headersCache caches beacon block headers, as the same headers are checked
+// when calculating the correctness of many attestations.
+type headersCache struct {
+	provider eth2client.BeaconBlockHeadersProvider
+	headers  map[string]*apiv1.BeaconBlockHeader
+}
+
+func newHeadersCache(provider eth2client.BeaconBlockHeadersProvider) *headersCache {
+	return &headersCache{
+		provider: provider,
+		headers:  make(map[string]*apiv1.BeaconBlockHeader),
+	}
+}
+
+// BeaconBlockHeader provides the block header of a given block ID.
+func (h *headersCache) BeaconBlockHeader(ctx context.Context, blockID string) (*apiv1.BeaconBlockHeader, error) {
+	if header, exists := h.headers[blockID]; exists {
+		return header, nil
+	}
+	header, err := h.provider.BeaconBlockHeader(ctx, blockID)
+	if err != nil {
+		return nil, err
+	}
+	h.headers[blockID] = header
+	return header, nil
+}
@@ -0,0 +1,139 @@
+// Copyright © 2022 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatorperformance
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/aaron-alderman/ethdo/util"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	string2eth "github.com/wealdtech/go-string2eth"
+)
+
+type jsonOutput struct {
+	FromEpoch  phase0.Epoch            `json:"from_epoch"`
+	ToEpoch    phase0.Epoch            `json:"to_epoch"`
+	Validators []*validatorPerformance `json:"validators"`
+}
+
+// csvHeader is the header line for CSV output.
+const csvHeader = "index,pubkey,attestations,attestations_included,average_inclusion_distance,head_correct,target_correct,source_timely,proposals,proposals_included,sync_committee_duties,sync_committee_missed,start_balance,end_balance,balance_delta"
+
+func (c *command) output(ctx context.Context) (string, error) {
+	if c.quiet {
+		return "", nil
+	}
+
+	switch {
+	case c.format == util.FormatCSV:
+		return c.outputCSV(ctx)
+	case util.StructuredFormat(c.format):
+		return c.outputStructured(ctx)
+	default:
+		return c.outputText(ctx)
+	}
+}
+
+func (c *command) outputStructured(_ context.Context) (string, error) {
+	data, err := json.Marshal(&jsonOutput{
+		FromEpoch:  c.from,
+		ToEpoch:    c.to,
+		Validators: c.performances,
+	})
+	if err != nil {
+		return "", err
+	}
+	return util.FormatJSONData(c.format, data)
+}
+
+func (c *command) outputCSV(_ context.Context) (string, error) {
+	builder := strings.Builder{}
+
+	builder.WriteString(csvHeader)
+	for _, performance := range c.performances {
+		builder.WriteString(fmt.Sprintf("\n%d,%s,%d,%d,%0.2f,%d,%d,%d,%d,%d,%d,%d,%d,%d,%d",
+			performance.Index,
+			performance.PubKey,
+			performance.Attestations,
+			performance.AttestationsIncluded,
+			performance.AverageInclusionDistance(),
+			performance.HeadCorrect,
+			performance.TargetCorrect,
+			performance.SourceTimely,
+			performance.Proposals,
+			performance.ProposalsIncluded,
+			performance.SyncCommitteeDuties,
+			performance.SyncCommitteeMissed,
+			performance.StartBalance,
+			performance.EndBalance,
+			performance.BalanceDelta(),
+		))
+	}
+
+	return builder.String(), nil
+}
+
+func (c *command) outputText(_ context.Context) (string, error) {
+	builder := strings.Builder{}
+
+	if c.from == c.to {
+		builder.WriteString(fmt.Sprintf("Epoch %d:", c.from))
+	} else {
+		builder.WriteString(fmt.Sprintf("Epochs %d-%d:", c.from, c.to))
+	}
+
+	for _, performance := range c.performances {
+		builder.WriteString(fmt.Sprintf("\n  Validator %d", performance.Index))
+		if c.verbose {
+			builder.WriteString(fmt.Sprintf(" (%s)", performance.PubKey))
+		}
+		builder.WriteString(":")
+		if performance.Attestations > 0 {
+			builder.WriteString(fmt.Sprintf("\n    Attestations: %d/%d (%0.2f%%)",
+				performance.AttestationsIncluded,
+				performance.Attestations,
+				100.0*float64(performance.AttestationsIncluded)/float64(performance.Attestations)))
+			if performance.AttestationsIncluded > 0 {
+				builder.WriteString(fmt.Sprintf("\n    Average inclusion distance: %0.2f", performance.AverageInclusionDistance()))
+				builder.WriteString(fmt.Sprintf("\n    Correct head votes: %d/%d", performance.HeadCorrect, performance.AttestationsIncluded))
+				builder.WriteString(fmt.Sprintf("\n    Correct target votes: %d/%d", performance.TargetCorrect, performance.AttestationsIncluded))
+				builder.WriteString(fmt.Sprintf("\n    Timely source votes: %d/%d", performance.SourceTimely, performance.AttestationsIncluded))
+			}
+		} else {
+			builder.WriteString("\n    No attestation duties")
+		}
+		if performance.Proposals > 0 {
+			builder.WriteString(fmt.Sprintf("\n    Proposals: %d/%d", performance.ProposalsIncluded, performance.Proposals))
+		}
+		if performance.SyncCommitteeDuties > 0 {
+			builder.WriteString(fmt.Sprintf("\n    Sync committee contributions: %d/%d",
+				performance.SyncCommitteeDuties-performance.SyncCommitteeMissed,
+				performance.SyncCommitteeDuties))
+		}
+		builder.WriteString(fmt.Sprintf("\n    Balance change: %s", balanceDeltaString(performance.BalanceDelta())))
+	}
+
+	return builder.String(), nil
+}
+
+// balanceDeltaString returns a signed, human-readable representation of a balance change.
+func balanceDeltaString(delta int64) string {
+	if delta < 0 {
+		return fmt.Sprintf("-%s", string2eth.GWeiToString(uint64(-delta), true))
+	}
+	return fmt.Sprintf("+%s", string2eth.GWeiToString(uint64(delta), true))
+}
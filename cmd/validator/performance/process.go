@@ -0,0 +1,372 @@
+// Copyright © 2022 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatorperformance
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+
+	standardchaintime "github.com/aaron-alderman/ethdo/services/chaintime/standard"
+	"github.com/aaron-alderman/ethdo/util"
+	eth2client "github.com/attestantio/go-eth2-client"
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/altair"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+)
+
+// maxSourceInclusionDelay is the maximum inclusion delay for an attestation's source vote to be rewarded.
+const maxSourceInclusionDelay = 5 // integer_squareroot(SLOTS_PER_EPOCH)
+
+func (c *command) process(ctx context.Context) error {
+	// Obtain information we need to process.
+	err := c.setup(ctx)
+	if err != nil {
+		return err
+	}
+
+	c.to, err = util.ParseEpoch(ctx, c.chainTime, c.toEpoch)
+	if err != nil {
+		return errors.Wrap(err, "failed to parse to epoch")
+	}
+	c.from, err = util.ParseEpoch(ctx, c.chainTime, c.fromEpoch)
+	if err != nil {
+		return errors.Wrap(err, "failed to parse from epoch")
+	}
+	if c.from > c.to {
+		return errors.New("from epoch cannot be after to epoch")
+	}
+	if c.to > c.chainTime.CurrentEpoch() {
+		return errors.New("to epoch cannot be in the future")
+	}
+
+	if err := c.obtainValidators(ctx); err != nil {
+		return err
+	}
+
+	performances := make(map[phase0.ValidatorIndex]*validatorPerformance, len(c.performances))
+	indices := make([]phase0.ValidatorIndex, 0, len(c.performances))
+	for _, performance := range c.performances {
+		performances[performance.Index] = performance
+		indices = append(indices, performance.Index)
+	}
+
+	for epoch := c.from; epoch <= c.to; epoch++ {
+		if c.debug {
+			fmt.Fprintf(os.Stderr, "Processing epoch %d\n", epoch)
+		}
+		if err := c.processAttesterDuties(ctx, epoch, indices, performances); err != nil {
+			return err
+		}
+		if err := c.processProposerDuties(ctx, epoch, indices, performances); err != nil {
+			return err
+		}
+		if err := c.processSyncCommitteeDuties(ctx, epoch, performances); err != nil {
+			return err
+		}
+	}
+
+	return c.processBalances(ctx, indices, performances)
+}
+
+// obtainValidators obtains the validators for which to report performance.
+func (c *command) obtainValidators(ctx context.Context) error {
+	validators, err := util.ParseValidators(ctx, c.validatorsProvider, c.validators, c.accounts)
+	if err != nil {
+		return err
+	}
+
+	c.performances = make([]*validatorPerformance, 0, len(validators))
+	for index, validator := range validators {
+		c.performances = append(c.performances, &validatorPerformance{
+			Index:     index,
+			PubKey:    fmt.Sprintf("%#x", validator.Validator.PublicKey),
+			validator: validator,
+		})
+	}
+	sort.Slice(c.performances, func(i int, j int) bool {
+		return c.performances[i].Index < c.performances[j].Index
+	})
+
+	return nil
+}
+
+func (c *command) processAttesterDuties(ctx context.Context,
+	epoch phase0.Epoch,
+	indices []phase0.ValidatorIndex,
+	performances map[phase0.ValidatorIndex]*validatorPerformance,
+) error {
+	duties, err := c.attesterDutiesProvider.AttesterDuties(ctx, epoch, indices)
+	if err != nil {
+		return errors.Wrap(err, fmt.Sprintf("failed to obtain attester duties for epoch %d", epoch))
+	}
+
+	for _, duty := range duties {
+		performance, exists := performances[duty.ValidatorIndex]
+		if !exists {
+			continue
+		}
+		performance.Attestations++
+		if err := c.processAttestation(ctx, duty, performance); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// processAttestation finds the first inclusion of the attestation for the given duty, if any.
+func (c *command) processAttestation(ctx context.Context,
+	duty *apiv1.AttesterDuty,
+	performance *validatorPerformance,
+) error {
+	lastSlot := duty.Slot + phase0.Slot(c.chainTime.SlotsPerEpoch())
+	if lastSlot > c.chainTime.CurrentSlot() {
+		lastSlot = c.chainTime.CurrentSlot()
+	}
+	for slot := duty.Slot + 1; slot <= lastSlot; slot++ {
+		block, err := util.BlockAtSlot(ctx, c.blocksProvider, c.blocks, slot)
+		if err != nil {
+			return err
+		}
+		if block == nil {
+			continue
+		}
+		attestations, err := block.Attestations()
+		if err != nil {
+			return errors.Wrap(err, "failed to obtain block attestations")
+		}
+		for _, attestation := range attestations {
+			if attestation.Data.Slot != duty.Slot ||
+				attestation.Data.Index != duty.CommitteeIndex ||
+				!attestation.AggregationBits.BitAt(duty.ValidatorCommitteeIndex) {
+				continue
+			}
+
+			inclusionDistance := slot - duty.Slot
+			performance.AttestationsIncluded++
+			performance.InclusionDistance += uint64(inclusionDistance)
+			if inclusionDistance <= maxSourceInclusionDelay {
+				performance.SourceTimely++
+			}
+			headCorrect, err := util.AttestationHeadCorrect(ctx, c.headersProvider, attestation)
+			if err != nil {
+				return errors.Wrap(err, "failed to obtain head correct result")
+			}
+			if headCorrect {
+				performance.HeadCorrect++
+			}
+			targetCorrect, err := util.AttestationTargetCorrect(ctx, c.headersProvider, c.chainTime, attestation)
+			if err != nil {
+				return errors.Wrap(err, "failed to obtain target correct result")
+			}
+			if targetCorrect {
+				performance.TargetCorrect++
+			}
+			if c.debug {
+				fmt.Fprintf(os.Stderr, "Attestation for validator %d at slot %d included in slot %d\n", duty.ValidatorIndex, duty.Slot, slot)
+			}
+			return nil
+		}
+	}
+
+	if c.debug {
+		fmt.Fprintf(os.Stderr, "Attestation for validator %d at slot %d not included\n", duty.ValidatorIndex, duty.Slot)
+	}
+	return nil
+}
+
+func (c *command) processProposerDuties(ctx context.Context,
+	epoch phase0.Epoch,
+	indices []phase0.ValidatorIndex,
+	performances map[phase0.ValidatorIndex]*validatorPerformance,
+) error {
+	duties, err := c.proposerDutiesProvider.ProposerDuties(ctx, epoch, indices)
+	if err != nil {
+		return errors.Wrap(err, fmt.Sprintf("failed to obtain proposer duties for epoch %d", epoch))
+	}
+
+	for _, duty := range duties {
+		performance, exists := performances[duty.ValidatorIndex]
+		if !exists {
+			// Beacon nodes can return duties for all validators, so ignore those we do not want.
+			continue
+		}
+		performance.Proposals++
+		block, err := util.BlockAtSlot(ctx, c.blocksProvider, c.blocks, duty.Slot)
+		if err != nil {
+			return err
+		}
+		if block == nil {
+			continue
+		}
+		proposerIndex, err := util.BlockProposerIndex(block)
+		if err != nil {
+			return err
+		}
+		if proposerIndex == duty.ValidatorIndex {
+			performance.ProposalsIncluded++
+		}
+	}
+
+	return nil
+}
+
+func (c *command) processSyncCommitteeDuties(ctx context.Context,
+	epoch phase0.Epoch,
+	performances map[phase0.ValidatorIndex]*validatorPerformance,
+) error {
+	if epoch < c.chainTime.AltairInitialEpoch() {
+		// The epoch is pre-Altair.  No info but no error.
+		return nil
+	}
+
+	firstSlot := c.chainTime.FirstSlotOfEpoch(epoch)
+	committee, err := c.syncCommitteesProvider.SyncCommittee(ctx, fmt.Sprintf("%d", firstSlot))
+	if err != nil {
+		return errors.Wrap(err, fmt.Sprintf("failed to obtain sync committee for epoch %d", epoch))
+	}
+
+	// A validator can appear in the sync committee more than once.
+	positions := make(map[phase0.ValidatorIndex][]int)
+	for i, index := range committee.Validators {
+		if _, exists := performances[index]; exists {
+			positions[index] = append(positions[index], i)
+		}
+	}
+	if len(positions) == 0 {
+		return nil
+	}
+
+	lastSlot := c.chainTime.FirstSlotOfEpoch(epoch+1) - 1
+	if lastSlot > c.chainTime.CurrentSlot() {
+		lastSlot = c.chainTime.CurrentSlot()
+	}
+	for slot := firstSlot; slot <= lastSlot; slot++ {
+		block, err := util.BlockAtSlot(ctx, c.blocksProvider, c.blocks, slot)
+		if err != nil {
+			return err
+		}
+		if block == nil {
+			// If the block is missed we don't count the sync aggregate miss.
+			continue
+		}
+		var aggregate *altair.SyncAggregate
+		switch block.Version {
+		case spec.DataVersionPhase0:
+			// No sync committees in this fork.
+			return nil
+		case spec.DataVersionAltair:
+			aggregate = block.Altair.Message.Body.SyncAggregate
+		case spec.DataVersionBellatrix:
+			aggregate = block.Bellatrix.Message.Body.SyncAggregate
+		default:
+			return fmt.Errorf("unhandled block version %v", block.Version)
+		}
+		for index, indexPositions := range positions {
+			for _, position := range indexPositions {
+				performances[index].SyncCommitteeDuties++
+				if !aggregate.SyncCommitteeBits.BitAt(uint64(position)) {
+					performances[index].SyncCommitteeMissed++
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// processBalances obtains the balances at the start and end of the epoch range.
+func (c *command) processBalances(ctx context.Context,
+	indices []phase0.ValidatorIndex,
+	performances map[phase0.ValidatorIndex]*validatorPerformance,
+) error {
+	startBalances, err := c.validatorBalancesProvider.ValidatorBalances(ctx, fmt.Sprintf("%d", c.chainTime.FirstSlotOfEpoch(c.from)), indices)
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain start balances")
+	}
+
+	endStateID := "head"
+	endSlot := c.chainTime.FirstSlotOfEpoch(c.to + 1)
+	if endSlot <= c.chainTime.CurrentSlot() {
+		endStateID = fmt.Sprintf("%d", endSlot)
+	}
+	endBalances, err := c.validatorBalancesProvider.ValidatorBalances(ctx, endStateID, indices)
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain end balances")
+	}
+
+	for index, performance := range performances {
+		// Validators that did not exist at the start of the range have a starting balance of 0.
+		performance.StartBalance = startBalances[index]
+		performance.EndBalance = endBalances[index]
+	}
+
+	return nil
+}
+
+func (c *command) setup(ctx context.Context) error {
+	var err error
+
+	// Connect to the client.
+	c.eth2Client, err = util.ConnectToBeaconNode(ctx, c.connection, c.timeout, c.allowInsecureConnections)
+	if err != nil {
+		return errors.Wrap(err, "failed to connect to beacon node")
+	}
+
+	c.chainTime, err = standardchaintime.New(ctx,
+		standardchaintime.WithSpecProvider(c.eth2Client.(eth2client.SpecProvider)),
+		standardchaintime.WithForkScheduleProvider(c.eth2Client.(eth2client.ForkScheduleProvider)),
+		standardchaintime.WithGenesisTimeProvider(c.eth2Client.(eth2client.GenesisTimeProvider)),
+	)
+	if err != nil {
+		return errors.Wrap(err, "failed to set up chaintime service")
+	}
+
+	var isProvider bool
+	c.validatorsProvider, isProvider = c.eth2Client.(eth2client.ValidatorsProvider)
+	if !isProvider {
+		return errors.New("connection does not provide validators")
+	}
+	c.validatorBalancesProvider, isProvider = c.eth2Client.(eth2client.ValidatorBalancesProvider)
+	if !isProvider {
+		return errors.New("connection does not provide validator balances")
+	}
+	c.attesterDutiesProvider, isProvider = c.eth2Client.(eth2client.AttesterDutiesProvider)
+	if !isProvider {
+		return errors.New("connection does not provide attester duties")
+	}
+	c.proposerDutiesProvider, isProvider = c.eth2Client.(eth2client.ProposerDutiesProvider)
+	if !isProvider {
+		return errors.New("connection does not provide proposer duties")
+	}
+	c.syncCommitteesProvider, isProvider = c.eth2Client.(eth2client.SyncCommitteesProvider)
+	if !isProvider {
+		return errors.New("connection does not provide sync committees")
+	}
+	c.blocksProvider, isProvider = c.eth2Client.(eth2client.SignedBeaconBlockProvider)
+	if !isProvider {
+		return errors.New("connection does not provide signed beacon blocks")
+	}
+	headersProvider, isProvider := c.eth2Client.(eth2client.BeaconBlockHeadersProvider)
+	if !isProvider {
+		return errors.New("connection does not provide beacon block headers")
+	}
+	c.headersProvider = newHeadersCache(headersProvider)
+
+	return nil
+}
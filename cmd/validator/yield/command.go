@@ -17,6 +17,7 @@ import (
 	"context"
 	"time"
 
+	"github.com/aaron-alderman/ethdo/util"
 	eth2client "github.com/attestantio/go-eth2-client"
 	"github.com/pkg/errors"
 	"github.com/shopspring/decimal"
@@ -27,7 +28,7 @@ type command struct {
 	quiet   bool
 	verbose bool
 	debug   bool
-	json    bool
+	format  string
 
 	// Beacon node connection.
 	timeout                  time.Duration
@@ -62,7 +63,7 @@ func newCommand(ctx context.Context) (*command, error) {
 		quiet:   viper.GetBool("quiet"),
 		verbose: viper.GetBool("verbose"),
 		debug:   viper.GetBool("debug"),
-		json:    viper.GetBool("json"),
+		format:  util.OutputFormat(),
 		results: &output{},
 	}
 
@@ -15,9 +15,9 @@ package validatoryield
 
 import (
 	"context"
-	"encoding/json"
 	"strings"
 
+	"github.com/aaron-alderman/ethdo/util"
 	"github.com/shopspring/decimal"
 	"github.com/wealdtech/go-string2eth"
 )
@@ -27,12 +27,8 @@ func (c *command) output(ctx context.Context) (string, error) {
 		return "", nil
 	}
 
-	if c.json {
-		data, err := json.Marshal(c.results)
-		if err != nil {
-			return "", err
-		}
-		return string(data), nil
+	if util.StructuredFormat(c.format) {
+		return util.FormatOutput(c.format, c.results)
 	}
 
 	builder := strings.Builder{}
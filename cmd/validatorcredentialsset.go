@@ -0,0 +1,89 @@
+// Copyright © 2022 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	validatorcredentialsset "github.com/aaron-alderman/ethdo/cmd/validator/credentials/set"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var validatorCredentialsSetCmd = &cobra.Command{
+	Use:   "set",
+	Short: "Change withdrawal credentials of Ethereum consensus validators to an execution address",
+	Long: `Change the withdrawal credentials of Ethereum consensus validators from BLS credentials to an execution address.  For example:
+
+    ethdo validator credentials set --index=123 --mnemonic="..." --withdrawal-address=0x...
+
+The withdrawal key is derived from a mnemonic with --mnemonic, or supplied as a wallet account with --withdrawal-account.  If a validator is not supplied with --index or --pubkey then changes are created for every validator whose withdrawal credentials match the withdrawal key; with a mnemonic these are the validators whose signing keys are derived from the mnemonic.
+
+Changes are broadcast to the network unless --json is supplied, in which case they are output as JSON for later submission.  Changes can be created without a beacon node by supplying --json along with the validator's current withdrawal credentials with --withdrawal-credentials, its index with --index and the network with --network.
+
+In quiet mode this will return 0 if the changes are created, otherwise 1.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		res, err := validatorcredentialsset.Run(cmd)
+		if err != nil {
+			return err
+		}
+		if viper.GetBool("quiet") {
+			return nil
+		}
+		if res != "" {
+			fmt.Println(res)
+		}
+		return nil
+	},
+}
+
+func init() {
+	validatorCredentialsCmd.AddCommand(validatorCredentialsSetCmd)
+	validatorCredentialsFlags(validatorCredentialsSetCmd)
+	validatorCredentialsSetCmd.Flags().String("index", "", "Validator index for which to change validator credentials")
+	validatorCredentialsSetCmd.Flags().String("pubkey", "", "Validator public key for which to change validator credentials")
+	validatorCredentialsSetCmd.Flags().String("mnemonic", "", "Mnemonic from which to derive the withdrawal key")
+	validatorCredentialsSetCmd.Flags().String("withdrawal-account", "", "Account holding the withdrawal key (in format \"wallet/account\")")
+	validatorCredentialsSetCmd.Flags().String("withdrawal-address", "", "Execution address to which to change the withdrawal credentials")
+	validatorCredentialsSetCmd.Flags().Bool("json", false, "Generate JSON data for the changes; do not broadcast to network")
+	validatorCredentialsSetCmd.Flags().String("withdrawal-credentials", "", "Current withdrawal credentials of the validator, to create the change without a beacon node")
+	validatorCredentialsSetCmd.Flags().String("network", "", "network whose chain parameters are used without a beacon node")
+}
+
+func validatorCredentialsSetBindings() {
+	if err := viper.BindPFlag("index", validatorCredentialsSetCmd.Flags().Lookup("index")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("pubkey", validatorCredentialsSetCmd.Flags().Lookup("pubkey")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("mnemonic", validatorCredentialsSetCmd.Flags().Lookup("mnemonic")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("withdrawal-account", validatorCredentialsSetCmd.Flags().Lookup("withdrawal-account")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("withdrawal-address", validatorCredentialsSetCmd.Flags().Lookup("withdrawal-address")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("json", validatorCredentialsSetCmd.Flags().Lookup("json")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("withdrawal-credentials", validatorCredentialsSetCmd.Flags().Lookup("withdrawal-credentials")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("network", validatorCredentialsSetCmd.Flags().Lookup("network")); err != nil {
+		panic(err)
+	}
+}
@@ -30,6 +30,12 @@ var validatorDepositDataCmd = &cobra.Command{
 
 If validatoraccount is provided with an account path it will generate deposit data for all matching accounts.
 
+Alternatively, deposit data can be generated for validator keys derived directly from a mnemonic.  For example:
+
+    ethdo validator depositdata --mnemonic="..." --count=10 --index=0 --output-dir=validator_keys --passphrase="keystore secret"
+
+This generates keys at the EIP-2334 paths m/12381/3600/i/0/0 for the supplied number of indices, and writes launchpad-compatible deposit data and an EIP-2335 keystore for each key to the output directory.  If no withdrawal account, public key or address is supplied then the withdrawal credentials use the withdrawal key at m/12381/3600/i/0 for each index.
+
 The information generated can be passed to ethereal to create a deposit from the Ethereum 1 chain.
 
 In quiet mode this will return 0 if the the data can be generated correctly, otherwise 1.`,
@@ -56,7 +62,12 @@ func init() {
 	validatorDepositDataCmd.Flags().String("depositvalue", "", "Value of the amount to be deposited")
 	validatorDepositDataCmd.Flags().Bool("raw", false, "Print raw deposit data transaction data")
 	validatorDepositDataCmd.Flags().String("forkversion", "", "Use a hard-coded fork version (default is to use mainnet value)")
+	validatorDepositDataCmd.Flags().String("network", "", "Use the genesis fork version of a named network (default mainnet)")
 	validatorDepositDataCmd.Flags().Bool("launchpad", false, "Print launchpad-compatible JSON")
+	validatorDepositDataCmd.Flags().String("mnemonic", "", "Mnemonic from which to derive validator keys")
+	validatorDepositDataCmd.Flags().Uint64("index", 0, "Index of the first validator key to derive from the mnemonic")
+	validatorDepositDataCmd.Flags().Uint64("count", 1, "Number of validator keys to derive from the mnemonic")
+	validatorDepositDataCmd.Flags().String("output-dir", "", "Directory to which to write deposit data and keystores for keys derived from the mnemonic")
 }
 
 func validatorDepositdataBindings() {
@@ -81,7 +92,22 @@ func validatorDepositdataBindings() {
 	if err := viper.BindPFlag("forkversion", validatorDepositDataCmd.Flags().Lookup("forkversion")); err != nil {
 		panic(err)
 	}
+	if err := viper.BindPFlag("network", validatorDepositDataCmd.Flags().Lookup("network")); err != nil {
+		panic(err)
+	}
 	if err := viper.BindPFlag("launchpad", validatorDepositDataCmd.Flags().Lookup("launchpad")); err != nil {
 		panic(err)
 	}
+	if err := viper.BindPFlag("mnemonic", validatorDepositDataCmd.Flags().Lookup("mnemonic")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("index", validatorDepositDataCmd.Flags().Lookup("index")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("count", validatorDepositDataCmd.Flags().Lookup("count")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("output-dir", validatorDepositDataCmd.Flags().Lookup("output-dir")); err != nil {
+		panic(err)
+	}
 }
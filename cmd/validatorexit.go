@@ -28,19 +28,29 @@ var validatorExitCmd = &cobra.Command{
 
     ethdo validator exit --account=primary/validator --passphrase=secret
 
+Multiple validators can be exited at once by supplying a wallet path pattern, optionally restricted to a list of indices or a file of public keys.  For example:
+
+    ethdo validator exit --accounts=primary/validator.* --indices=123,124,125 --passphrase=secret
+
+Exits can be generated on a machine without network access.  First prepare the chain information on a machine with access to a beacon node:
+
+    ethdo validator exit --prepare-offline --chain-info=offline-preparation.json
+
+Without --indices or --pubkeys-file this downloads information for every validator on the chain; supply one of them to include only the validators to be exited.  Then generate the exits on the offline machine:
+
+    ethdo validator exit --offline --chain-info=offline-preparation.json --accounts=primary/validator.* --passphrase=secret > exits.json
+
+and finally broadcast them from a machine with access to a beacon node:
+
+    ethdo validator exit --exit=exits.json
+
 In quiet mode this will return 0 if the transaction has been generated, otherwise 1.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		res, err := validatorexit.Run(cmd)
-		if err != nil {
-			return err
-		}
-		if viper.GetBool("quiet") {
-			return nil
-		}
-		if res != "" {
+		if res != "" && !viper.GetBool("quiet") {
 			fmt.Println(res)
 		}
-		return nil
+		return err
 	},
 }
 
@@ -49,8 +59,15 @@ func init() {
 	validatorFlags(validatorExitCmd)
 	validatorExitCmd.Flags().Int64("epoch", -1, "Epoch at which to exit (defaults to current epoch)")
 	validatorExitCmd.Flags().String("key", "", "Private key if validator not known by ethdo")
-	validatorExitCmd.Flags().String("exit", "", "Use pre-defined JSON data as created by --json to exit")
+	validatorExitCmd.Flags().String("exit", "", "Use pre-defined JSON data as created by --json to exit, or path to JSON data")
 	validatorExitCmd.Flags().Bool("json", false, "Generate JSON data for an exit; do not broadcast to network")
+	validatorExitCmd.Flags().String("accounts", "", "Wallet path pattern for accounts to exit (in format \"wallet/account regex\")")
+	validatorExitCmd.Flags().StringSlice("indices", nil, "Indices of validators to exit")
+	validatorExitCmd.Flags().String("pubkeys-file", "", "File containing public keys of validators to exit, one per line")
+	validatorExitCmd.Flags().Bool("prepare-offline", false, "Write chain information required to generate exits offline to the chain information file")
+	validatorExitCmd.Flags().Bool("offline", false, "Generate exits using the chain information file rather than a beacon node")
+	validatorExitCmd.Flags().String("chain-info", "offline-preparation.json", "Chain information file for offline exits")
+	validatorExitCmd.Flags().String("network", "", "Network whose fork schedule is used to sign offline exits (default the fork in the chain information file)")
 }
 
 func validatorExitBindings() {
@@ -66,4 +83,25 @@ func validatorExitBindings() {
 	if err := viper.BindPFlag("json", validatorExitCmd.Flags().Lookup("json")); err != nil {
 		panic(err)
 	}
+	if err := viper.BindPFlag("accounts", validatorExitCmd.Flags().Lookup("accounts")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("indices", validatorExitCmd.Flags().Lookup("indices")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("pubkeys-file", validatorExitCmd.Flags().Lookup("pubkeys-file")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("prepare-offline", validatorExitCmd.Flags().Lookup("prepare-offline")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("offline", validatorExitCmd.Flags().Lookup("offline")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("chain-info", validatorExitCmd.Flags().Lookup("chain-info")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("network", validatorExitCmd.Flags().Lookup("network")); err != nil {
+		panic(err)
+	}
 }
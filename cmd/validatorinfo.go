@@ -14,17 +14,13 @@
 package cmd
 
 import (
-	"bytes"
 	"context"
 	"encoding/hex"
-	"encoding/json"
 	"fmt"
-	"io/ioutil"
-	"net/http"
 	"os"
-	"strconv"
 	"strings"
 
+	"github.com/aaron-alderman/ethdo/services/deposithistory"
 	"github.com/aaron-alderman/ethdo/util"
 	eth2client "github.com/attestantio/go-eth2-client"
 	api "github.com/attestantio/go-eth2-client/api/v1"
@@ -63,9 +59,9 @@ In quiet mode this will return 0 if the validator information can be obtained, o
 		copy(pubKeys[0][:], pubKey.Marshal())
 		validators, err := eth2Client.(eth2client.ValidatorsProvider).ValidatorsByPubKey(ctx, "head", pubKeys)
 		errCheck(err, "Failed to obtain validator information")
-		if len(validators) == 0 {
-			fmt.Println("Validator not known by beacon node")
-			os.Exit(_exitSuccess)
+		deposits, err := validatorInfoDepositHistory(ctx, eth2Client, pubKeys[0])
+		if err != nil && !quiet {
+			fmt.Fprintf(os.Stderr, "Warning: failed to obtain deposit history: %v\n", err)
 		}
 
 		var validator *api.Validator
@@ -73,18 +69,22 @@ In quiet mode this will return 0 if the validator information can be obtained, o
 			validator = v
 		}
 
-		if verbose {
-			network, err := util.Network(ctx, eth2Client)
-			errCheck(err, "Failed to obtain network")
-			outputIf(debug, fmt.Sprintf("Network is %s", network))
-			pubKey, err := bestPublicKey(account)
-			if err == nil {
-				deposits, totalDeposited, err := graphData(network, pubKey.Marshal())
-				if err == nil && deposits > 0 {
-					fmt.Printf("Number of deposits: %d\n", deposits)
-					fmt.Printf("Total deposited: %s\n", string2eth.GWeiToString(uint64(totalDeposited), true))
-				}
+		if !quiet && outputStructured(validatorInfoStructured(pubKeys[0], validator, deposits)) {
+			os.Exit(_exitSuccess)
+		}
+
+		if verbose && len(deposits) > 0 {
+			totalDeposited := spec.Gwei(0)
+			for _, deposit := range deposits {
+				totalDeposited += deposit.Amount
 			}
+			fmt.Printf("Number of deposits: %d\n", len(deposits))
+			fmt.Printf("Total deposited: %s\n", string2eth.GWeiToString(uint64(totalDeposited), true))
+		}
+
+		if validator == nil {
+			fmt.Println("Validator not known by beacon node")
+			os.Exit(_exitSuccess)
 		}
 
 		if quiet {
@@ -122,6 +122,93 @@ In quiet mode this will return 0 if the validator information can be obtained, o
 	},
 }
 
+// validatorInfoDepositHistory obtains the deposit history for the validator, if a source is configured.
+// Any warnings about the deposits are output.
+func validatorInfoDepositHistory(ctx context.Context, eth2Client eth2client.Service, pubKey spec.BLSPubKey) ([]*deposithistory.Deposit, error) {
+	network, err := util.Network(ctx, eth2Client)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to obtain network")
+	}
+	outputIf(debug, fmt.Sprintf("Network is %s", network))
+	depositContract, err := util.DepositContractAddress(ctx, eth2Client)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to obtain deposit contract address")
+	}
+	depositHistory, err := util.DepositHistory(ctx, network, depositContract)
+	if err != nil {
+		return nil, err
+	}
+	if depositHistory == nil {
+		return nil, nil
+	}
+
+	history, err := depositHistory.Deposits(ctx, []spec.BLSPubKey{pubKey})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to obtain deposits")
+	}
+	deposits := history[pubKey]
+	if !quiet {
+		genesis, err := eth2Client.(eth2client.GenesisProvider).Genesis(ctx)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to obtain genesis")
+		}
+		for _, warning := range util.DepositHistoryWarnings(deposits, nil, genesis.GenesisForkVersion) {
+			fmt.Fprintf(os.Stderr, "Warning: %s\n", warning)
+		}
+	}
+
+	return deposits, nil
+}
+
+type validatorInfoJSON struct {
+	PublicKey                  string               `json:"public_key"`
+	Known                      bool                 `json:"known"`
+	Index                      *spec.ValidatorIndex `json:"index,omitempty"`
+	Status                     string               `json:"status,omitempty"`
+	ActivationEligibilityEpoch *spec.Epoch          `json:"activation_eligibility_epoch,omitempty"`
+	ActivationEpoch            *spec.Epoch          `json:"activation_epoch,omitempty"`
+	ExitEpoch                  *spec.Epoch          `json:"exit_epoch,omitempty"`
+	WithdrawableEpoch          *spec.Epoch          `json:"withdrawable_epoch,omitempty"`
+	Balance                    *spec.Gwei           `json:"balance,omitempty"`
+	EffectiveBalance           *spec.Gwei           `json:"effective_balance,omitempty"`
+	WithdrawalCredentials      string               `json:"withdrawal_credentials,omitempty"`
+	Deposits                   *int                 `json:"deposits,omitempty"`
+	TotalDeposited             *spec.Gwei           `json:"total_deposited,omitempty"`
+}
+
+// validatorInfoStructured returns validator information for structured output.
+// Deposit information is included if a deposit history source is configured.
+func validatorInfoStructured(pubKey spec.BLSPubKey, validator *api.Validator, deposits []*deposithistory.Deposit) *validatorInfoJSON {
+	res := &validatorInfoJSON{
+		PublicKey: fmt.Sprintf("%#x", pubKey),
+	}
+	if deposits != nil {
+		numDeposits := len(deposits)
+		totalDeposited := spec.Gwei(0)
+		for _, deposit := range deposits {
+			totalDeposited += deposit.Amount
+		}
+		res.Deposits = &numDeposits
+		res.TotalDeposited = &totalDeposited
+	}
+	if validator == nil {
+		return res
+	}
+
+	res.Known = true
+	res.Index = &validator.Index
+	res.Status = validator.Status.String()
+	res.ActivationEligibilityEpoch = &validator.Validator.ActivationEligibilityEpoch
+	res.ActivationEpoch = &validator.Validator.ActivationEpoch
+	res.ExitEpoch = &validator.Validator.ExitEpoch
+	res.WithdrawableEpoch = &validator.Validator.WithdrawableEpoch
+	res.Balance = &validator.Balance
+	res.EffectiveBalance = &validator.Validator.EffectiveBalance
+	res.WithdrawalCredentials = fmt.Sprintf("%#x", validator.Validator.WithdrawalCredentials)
+
+	return res
+}
+
 // validatorInfoAccount obtains the account for the validator info command.
 func validatorInfoAccount(ctx context.Context, eth2Client eth2client.Service) (e2wtypes.Account, error) {
 	var account e2wtypes.Account
@@ -170,58 +257,6 @@ func validatorInfoAccount(ctx context.Context, eth2Client eth2client.Service) (e
 	return account, nil
 }
 
-// graphData returns data from the graph about number and amount of deposits
-func graphData(network string, validatorPubKey []byte) (uint64, spec.Gwei, error) {
-	subgraph := ""
-	if network == "Mainnet" {
-		subgraph = "attestantio/eth2deposits"
-	} else {
-		subgraph = fmt.Sprintf("attestantio/eth2deposits-%s", strings.ToLower(network))
-	}
-	query := fmt.Sprintf(`{"query": "{deposits(where: {validatorPubKey:\"%#x\"}) { id amount withdrawalCredentials }}"}`, validatorPubKey)
-	url := fmt.Sprintf("https://api.thegraph.com/subgraphs/name/%s", subgraph)
-	// #nosec G107
-	graphResp, err := http.Post(url, "application/json", bytes.NewBufferString(query))
-	if err != nil {
-		return 0, 0, errors.Wrap(err, "failed to check if there is already a deposit for this validator")
-	}
-	defer graphResp.Body.Close()
-	body, err := ioutil.ReadAll(graphResp.Body)
-	if err != nil {
-		return 0, 0, errors.Wrap(err, "bad information returned from existing deposit check")
-	}
-
-	type graphDeposit struct {
-		Index                 string `json:"index"`
-		Amount                string `json:"amount"`
-		WithdrawalCredentials string `json:"withdrawalCredentials"`
-	}
-	type graphData struct {
-		Deposits []*graphDeposit `json:"deposits,omitempty"`
-	}
-	type graphResponse struct {
-		Data *graphData `json:"data,omitempty"`
-	}
-
-	var response graphResponse
-	if err := json.Unmarshal(body, &response); err != nil {
-		return 0, 0, errors.Wrap(err, "invalid data returned from existing deposit check")
-	}
-	deposits := uint64(0)
-	totalDeposited := spec.Gwei(0)
-	if response.Data != nil && len(response.Data.Deposits) > 0 {
-		for _, deposit := range response.Data.Deposits {
-			deposits++
-			depositAmount, err := strconv.ParseUint(deposit.Amount, 10, 64)
-			if err != nil {
-				return 0, 0, errors.Wrap(err, fmt.Sprintf("invalid deposit amount from pre-existing deposit %s", deposit.Amount))
-			}
-			totalDeposited += spec.Gwei(depositAmount)
-		}
-	}
-	return deposits, totalDeposited, nil
-}
-
 func init() {
 	validatorCmd.AddCommand(validatorInfoCmd)
 	validatorInfoCmd.Flags().String("pubkey", "", "Public key for which to obtain status")
@@ -0,0 +1,79 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	validatormaintenancewindow "github.com/aaron-alderman/ethdo/cmd/validator/maintenancewindow"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var validatorMaintenanceWindowCmd = &cobra.Command{
+	Use:   "maintenance-window",
+	Short: "Find upcoming windows in which validators can be offline",
+	Long: `Find upcoming windows in which validators can be offline without missing a block proposal or sync committee duty, ranked by the number of attestations missed.  For example:
+
+    ethdo validator maintenance-window --validators=1,2,3 --duration=10m
+
+Validators can be supplied as indices or public keys with --validators, or as a wallet or wallet/account path with --accounts.  Windows are found within the current epoch and the following epochs, up to the number of epochs given by --epochs.  Beacon nodes only provide duties for the current and next epochs, so --epochs can be at most 2, and proposer duties for the next epoch can change if the chain reorganises.
+
+In quiet mode this will return 0 if the duties of the validators are obtained, otherwise 1.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		res, err := validatormaintenancewindow.Run(cmd)
+		if err != nil {
+			return err
+		}
+		if viper.GetBool("quiet") {
+			return nil
+		}
+		if res != "" {
+			fmt.Println(res)
+		}
+		return nil
+	},
+}
+
+func init() {
+	validatorCmd.AddCommand(validatorMaintenanceWindowCmd)
+	validatorFlags(validatorMaintenanceWindowCmd)
+	validatorMaintenanceWindowCmd.Flags().StringSlice("validators", nil, "indices or public keys of the validators")
+	validatorMaintenanceWindowCmd.Flags().String("accounts", "", "wallet or wallet/account path of the validators")
+	validatorMaintenanceWindowCmd.Flags().Duration("duration", 0, "the length of time for which the validators will be offline")
+	validatorMaintenanceWindowCmd.Flags().Uint64("epochs", 2, "the number of epochs, starting with the current epoch, in which to find windows (at most 2)")
+	validatorMaintenanceWindowCmd.Flags().Int("windows", 3, "the maximum number of windows to show")
+	validatorMaintenanceWindowCmd.Flags().Bool("json", false, "output data in JSON format")
+}
+
+func validatorMaintenanceWindowBindings() {
+	if err := viper.BindPFlag("validators", validatorMaintenanceWindowCmd.Flags().Lookup("validators")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("accounts", validatorMaintenanceWindowCmd.Flags().Lookup("accounts")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("duration", validatorMaintenanceWindowCmd.Flags().Lookup("duration")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("epochs", validatorMaintenanceWindowCmd.Flags().Lookup("epochs")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("windows", validatorMaintenanceWindowCmd.Flags().Lookup("windows")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("json", validatorMaintenanceWindowCmd.Flags().Lookup("json")); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,79 @@
+// Copyright © 2022 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	validatorperformance "github.com/aaron-alderman/ethdo/cmd/validator/performance"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var validatorPerformanceCmd = &cobra.Command{
+	Use:   "performance",
+	Short: "Report the performance of validators over a range of epochs",
+	Long: `Report the performance of validators over a range of epochs.  For example:
+
+    ethdo validator performance --validators=1,2,3 --from-epoch=100 --to-epoch=110
+
+Validators can be supplied as indices or public keys with --validators, or as a wallet or wallet/account path with --accounts.  The epochs default to the last complete epoch.
+
+In quiet mode this will return 0 if the performance of the validators is obtained, otherwise 1.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		res, err := validatorperformance.Run(cmd)
+		if err != nil {
+			return err
+		}
+		if viper.GetBool("quiet") {
+			return nil
+		}
+		if res != "" {
+			fmt.Println(res)
+		}
+		return nil
+	},
+}
+
+func init() {
+	validatorCmd.AddCommand(validatorPerformanceCmd)
+	validatorFlags(validatorPerformanceCmd)
+	validatorPerformanceCmd.Flags().StringSlice("validators", nil, "indices or public keys of the validators")
+	validatorPerformanceCmd.Flags().String("accounts", "", "wallet or wallet/account path of the validators")
+	validatorPerformanceCmd.Flags().String("from-epoch", "", "the first epoch for which to report performance (defaults to to-epoch)")
+	validatorPerformanceCmd.Flags().String("to-epoch", "", "the last epoch for which to report performance (defaults to the last complete epoch)")
+	validatorPerformanceCmd.Flags().Bool("json", false, "output data in JSON format")
+	validatorPerformanceCmd.Flags().Bool("csv", false, "output data in CSV format")
+}
+
+func validatorPerformanceBindings() {
+	if err := viper.BindPFlag("validators", validatorPerformanceCmd.Flags().Lookup("validators")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("accounts", validatorPerformanceCmd.Flags().Lookup("accounts")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("from-epoch", validatorPerformanceCmd.Flags().Lookup("from-epoch")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("to-epoch", validatorPerformanceCmd.Flags().Lookup("to-epoch")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("json", validatorPerformanceCmd.Flags().Lookup("json")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("csv", validatorPerformanceCmd.Flags().Lookup("csv")); err != nil {
+		panic(err)
+	}
+}
@@ -34,6 +34,10 @@ var versionCmd = &cobra.Command{
 
     ethdo version`,
 	Run: func(cmd *cobra.Command, args []string) {
+		if outputStructured(versionStructured()) {
+			os.Exit(_exitSuccess)
+		}
+
 		fmt.Println(ReleaseVersion)
 		if viper.GetBool("verbose") {
 			buildInfo, ok := dbg.ReadBuildInfo()
@@ -52,6 +56,40 @@ var versionCmd = &cobra.Command{
 	},
 }
 
+type versionJSON struct {
+	Version      string            `json:"version"`
+	Package      string            `json:"package,omitempty"`
+	Dependencies []*dependencyJSON `json:"dependencies,omitempty"`
+}
+
+type dependencyJSON struct {
+	Path    string `json:"path"`
+	Version string `json:"version"`
+}
+
+// versionStructured returns version information for structured output.
+func versionStructured() *versionJSON {
+	res := &versionJSON{
+		Version: ReleaseVersion,
+	}
+	if viper.GetBool("verbose") {
+		if buildInfo, ok := dbg.ReadBuildInfo(); ok {
+			res.Package = buildInfo.Path
+			for _, dep := range buildInfo.Deps {
+				for dep.Replace != nil {
+					dep = dep.Replace
+				}
+				res.Dependencies = append(res.Dependencies, &dependencyJSON{
+					Path:    dep.Path,
+					Version: dep.Version,
+				})
+			}
+		}
+	}
+
+	return res
+}
+
 func init() {
 	RootCmd.AddCommand(versionCmd)
 }
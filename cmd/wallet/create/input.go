@@ -30,6 +30,7 @@ type dataIn struct {
 	timeout time.Duration
 	quiet   bool
 	verbose bool
+	format  string
 	debug   bool
 	// For all wallets.
 	store      e2wtypes.Store
@@ -54,6 +55,7 @@ func input(ctx context.Context) (*dataIn, error) {
 	data.timeout = viper.GetDuration("timeout")
 	data.quiet = viper.GetBool("quiet")
 	data.verbose = viper.GetBool("verbose")
+	data.format = util.OutputFormat()
 	data.debug = viper.GetBool("debug")
 
 	store, isStore := viper.Get("store").(e2wtypes.Store)
@@ -17,17 +17,32 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/aaron-alderman/ethdo/util"
 	"github.com/pkg/errors"
 )
 
 type dataOut struct {
+	format   string
+	name     string
 	mnemonic string
 }
 
+type walletJSON struct {
+	Name     string `json:"name"`
+	Mnemonic string `json:"mnemonic,omitempty"`
+}
+
 func output(ctx context.Context, data *dataOut) (string, error) {
 	if data == nil {
 		return "", errors.New("no data")
 	}
+
+	if util.StructuredFormat(data.format) {
+		return util.FormatOutput(data.format, &walletJSON{
+			Name:     data.name,
+			Mnemonic: data.mnemonic,
+		})
+	}
 	if data.mnemonic != "" {
 		return fmt.Sprintf(`The following phrase is your mnemonic for this wallet:
 
@@ -50,7 +50,10 @@ func processND(ctx context.Context, data *dataIn) (*dataOut, error) {
 		return nil, errors.New("no data")
 	}
 
-	results := &dataOut{}
+	results := &dataOut{
+		format: data.format,
+		name:   data.walletName,
+	}
 
 	if _, err := nd.CreateWallet(ctx, data.walletName, data.store, keystorev4.New()); err != nil {
 		return nil, err
@@ -72,7 +75,10 @@ func processHD(ctx context.Context, data *dataIn) (*dataOut, error) {
 		return nil, errors.New("creation of hierarchical deterministic wallets prints its mnemonic, so cannot be run with the --quiet flag")
 	}
 
-	results := &dataOut{}
+	results := &dataOut{
+		format: data.format,
+		name:   data.walletName,
+	}
 
 	// Only show the mnemonic on output if we generate it.
 	printMnemonic := data.mnemonic == ""
@@ -124,7 +130,10 @@ func processDistributed(ctx context.Context, data *dataIn) (*dataOut, error) {
 		return nil, errors.New("no data")
 	}
 
-	results := &dataOut{}
+	results := &dataOut{
+		format: data.format,
+		name:   data.walletName,
+	}
 
 	if _, err := distributed.CreateWallet(ctx, data.walletName, data.store, keystorev4.New()); err != nil {
 		return nil, err
@@ -29,6 +29,7 @@ type dataIn struct {
 	quiet   bool
 	verbose bool
 	debug   bool
+	format  string
 	wallet  e2wtypes.Wallet
 }
 
@@ -47,6 +48,7 @@ func input(ctx context.Context) (*dataIn, error) {
 	data.quiet = viper.GetBool("quiet")
 	data.verbose = viper.GetBool("verbose")
 	data.debug = viper.GetBool("debug")
+	data.format = util.OutputFormat()
 
 	// Wallet.
 	wallet, err := util.WalletFromInput(ctx)
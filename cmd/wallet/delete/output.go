@@ -16,14 +16,31 @@ package walletdelete
 import (
 	"context"
 
+	"github.com/aaron-alderman/ethdo/util"
 	"github.com/pkg/errors"
 )
 
-type dataOut struct{}
+type dataOut struct {
+	format string
+	wallet string
+}
+
+type deletedJSON struct {
+	Wallet  string `json:"wallet"`
+	Deleted bool   `json:"deleted"`
+}
 
 func output(ctx context.Context, data *dataOut) (string, error) {
 	if data == nil {
 		return "", errors.New("no data")
 	}
+
+	if util.StructuredFormat(data.format) {
+		return util.FormatOutput(data.format, &deletedJSON{
+			Wallet:  data.wallet,
+			Deleted: true,
+		})
+	}
+
 	return "", nil
 }
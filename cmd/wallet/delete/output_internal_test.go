@@ -24,6 +24,7 @@ func TestOutput(t *testing.T) {
 	tests := []struct {
 		name    string
 		dataOut *dataOut
+		res     string
 		err     string
 	}{
 		{
@@ -34,6 +35,14 @@ func TestOutput(t *testing.T) {
 			name:    "Good",
 			dataOut: &dataOut{},
 		},
+		{
+			name: "JSON",
+			dataOut: &dataOut{
+				format: "json",
+				wallet: "Test wallet",
+			},
+			res: `{"wallet":"Test wallet","deleted":true}`,
+		},
 	}
 
 	for _, test := range tests {
@@ -43,7 +52,7 @@ func TestOutput(t *testing.T) {
 				require.EqualError(t, err, test.err)
 			} else {
 				require.NoError(t, err)
-				require.Equal(t, "", res)
+				require.Equal(t, test.res, res)
 			}
 		})
 	}
@@ -44,5 +44,8 @@ func process(ctx context.Context, data *dataIn) (*dataOut, error) {
 		return nil, errors.Wrap(err, "failed to delete wallet")
 	}
 
-	return &dataOut{}, nil
+	return &dataOut{
+		format: data.format,
+		wallet: data.wallet.Name(),
+	}, nil
 }
@@ -28,6 +28,7 @@ type dataIn struct {
 	timeout    time.Duration
 	quiet      bool
 	verbose    bool
+	format     string
 	debug      bool
 	wallet     e2wtypes.Wallet
 	passphrase string
@@ -47,6 +48,7 @@ func input(ctx context.Context) (*dataIn, error) {
 	data.timeout = viper.GetDuration("timeout")
 	data.quiet = viper.GetBool("quiet")
 	data.verbose = viper.GetBool("verbose")
+	data.format = util.OutputFormat()
 	data.debug = viper.GetBool("debug")
 
 	// Wallet.
@@ -17,17 +17,29 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/aaron-alderman/ethdo/util"
 	"github.com/pkg/errors"
 )
 
 type dataOut struct {
+	format string
 	export []byte
 }
 
+type exportJSON struct {
+	Export string `json:"export"`
+}
+
 func output(ctx context.Context, data *dataOut) (string, error) {
 	if data == nil {
 		return "", errors.New("no data")
 	}
 
+	if util.StructuredFormat(data.format) {
+		return util.FormatOutput(data.format, &exportJSON{
+			Export: fmt.Sprintf("%#x", data.export),
+		})
+	}
+
 	return fmt.Sprintf("%#x", data.export), nil
 }
@@ -15,6 +15,7 @@ package walletexport
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/aaron-alderman/ethdo/util"
 	"github.com/pkg/errors"
@@ -41,8 +42,12 @@ func process(ctx context.Context, data *dataIn) (*dataOut, error) {
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to export wallet")
 	}
+	if err := util.AuditExport(fmt.Sprintf("wallet %s", data.wallet.Name())); err != nil {
+		return nil, errors.Wrap(err, "failed to record export in audit log")
+	}
 
 	results := &dataOut{
+		format: data.format,
 		export: export,
 	}
 
@@ -30,6 +30,7 @@ type dataIn struct {
 	timeout    time.Duration
 	quiet      bool
 	verbose    bool
+	format     string
 	debug      bool
 	data       []byte
 	passphrase string
@@ -50,6 +51,7 @@ func input(ctx context.Context) (*dataIn, error) {
 	data.timeout = viper.GetDuration("timeout")
 	data.quiet = viper.GetBool("quiet")
 	data.verbose = viper.GetBool("verbose")
+	data.format = util.OutputFormat()
 	data.debug = viper.GetBool("debug")
 
 	// Data.
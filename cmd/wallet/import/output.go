@@ -17,6 +17,7 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/aaron-alderman/ethdo/util"
 	"github.com/gofrs/uuid"
 	"github.com/pkg/errors"
 )
@@ -25,6 +26,7 @@ type dataOut struct {
 	verify  bool
 	quiet   bool
 	verbose bool
+	format  string
 	export  *export
 }
 
@@ -46,6 +48,10 @@ func output(ctx context.Context, data *dataOut) (string, error) {
 		return "", errors.New("no data")
 	}
 
+	if data.verify && !data.quiet && util.StructuredFormat(data.format) {
+		return util.FormatOutput(data.format, data.export)
+	}
+
 	res := ""
 	if data.verify {
 		if !data.quiet {
@@ -15,6 +15,8 @@ package walletsharedexport
 
 import (
 	"context"
+	"fmt"
+	"strings"
 	"time"
 
 	"github.com/aaron-alderman/ethdo/util"
@@ -23,15 +25,24 @@ import (
 	e2wtypes "github.com/wealdtech/go-eth2-wallet-types/v2"
 )
 
+const (
+	// shareFormatHex outputs shares as hex strings.
+	shareFormatHex = "hex"
+	// shareFormatWords outputs shares as lists of words with checksums.
+	shareFormatWords = "words"
+)
+
 type dataIn struct {
 	// System.
 	timeout      time.Duration
 	verbose      bool
+	format       string
 	debug        bool
 	wallet       e2wtypes.Wallet
 	file         string
 	participants uint32
 	threshold    uint32
+	shareFormat  string
 }
 
 func input(ctx context.Context) (*dataIn, error) {
@@ -51,6 +62,7 @@ func input(ctx context.Context) (*dataIn, error) {
 		return nil, errors.New("quiet not allowed")
 	}
 	data.verbose = viper.GetBool("verbose")
+	data.format = util.OutputFormat()
 	data.debug = viper.GetBool("debug")
 
 	// Wallet.
@@ -79,5 +91,15 @@ func input(ctx context.Context) (*dataIn, error) {
 		return nil, errors.New("threshold cannot be more than participants")
 	}
 
+	// Share format.
+	data.shareFormat = strings.ToLower(viper.GetString("share-format"))
+	switch data.shareFormat {
+	case "":
+		data.shareFormat = shareFormatHex
+	case shareFormatHex, shareFormatWords:
+	default:
+		return nil, fmt.Errorf("unknown share format %q; must be %s or %s", data.shareFormat, shareFormatHex, shareFormatWords)
+	}
+
 	return data, nil
 }
@@ -118,6 +118,34 @@ func TestInput(t *testing.T) {
 			},
 			err: "threshold cannot be more than participants",
 		},
+		{
+			name: "ShareFormatInvalid",
+			vars: map[string]interface{}{
+				"timeout":      "5s",
+				"wallet":       "Test wallet",
+				"file":         "test.dat",
+				"participants": "5",
+				"threshold":    "3",
+				"share-format": "base64",
+			},
+			err: `unknown share format "base64"; must be hex or words`,
+		},
+		{
+			name: "GoodWords",
+			vars: map[string]interface{}{
+				"timeout":      "5s",
+				"wallet":       "Test wallet",
+				"file":         "test.dat",
+				"participants": "5",
+				"threshold":    "3",
+				"share-format": "words",
+			},
+			res: &dataIn{
+				timeout:     5 * time.Second,
+				wallet:      wallet,
+				shareFormat: "words",
+			},
+		},
 		{
 			name: "Good",
 			vars: map[string]interface{}{
@@ -128,8 +156,9 @@ func TestInput(t *testing.T) {
 				"threshold":    "3",
 			},
 			res: &dataIn{
-				timeout: 5 * time.Second,
-				wallet:  wallet,
+				timeout:     5 * time.Second,
+				wallet:      wallet,
+				shareFormat: "hex",
 			},
 		},
 	}
@@ -147,6 +176,7 @@ func TestInput(t *testing.T) {
 				require.NoError(t, err)
 				require.Equal(t, test.res.timeout, res.timeout)
 				require.Equal(t, test.vars["wallet"], res.wallet.Name())
+				require.Equal(t, test.res.shareFormat, res.shareFormat)
 			}
 		})
 	}
@@ -18,11 +18,20 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/aaron-alderman/ethdo/shamir"
+	"github.com/aaron-alderman/ethdo/util"
 	"github.com/pkg/errors"
 )
 
 type dataOut struct {
-	shares [][]byte
+	format      string
+	shareFormat string
+	threshold   uint32
+	shares      [][]byte
+}
+
+type sharesJSON struct {
+	Shares []string `json:"shares"`
 }
 
 func output(ctx context.Context, data *dataOut) (string, error) {
@@ -30,13 +39,24 @@ func output(ctx context.Context, data *dataOut) (string, error) {
 		return "", errors.New("no data")
 	}
 
-	builder := strings.Builder{}
+	shares := make([]string, 0, len(data.shares))
 	for i := range data.shares {
-		builder.WriteString(fmt.Sprintf("%x", data.shares[i]))
-		if i != len(data.shares)-1 {
-			builder.WriteString("\n")
+		if data.shareFormat == shareFormatWords {
+			share, err := shamir.ShareToMnemonic(data.shares[i], int(data.threshold))
+			if err != nil {
+				return "", errors.Wrap(err, "failed to encode share")
+			}
+			shares = append(shares, share)
+		} else {
+			shares = append(shares, fmt.Sprintf("%x", data.shares[i]))
 		}
 	}
 
-	return builder.String(), nil
+	if util.StructuredFormat(data.format) {
+		return util.FormatOutput(data.format, &sharesJSON{
+			Shares: shares,
+		})
+	}
+
+	return strings.Join(shares, "\n"), nil
 }
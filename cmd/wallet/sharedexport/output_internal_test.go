@@ -42,6 +42,28 @@ func TestOutput(t *testing.T) {
 			},
 			expected: "0102\n0203\n0304",
 		},
+		{
+			name: "Words",
+			dataOut: &dataOut{
+				shareFormat: "words",
+				threshold:   2,
+				shares: [][]byte{
+					{0x01, 0x02},
+					{0x02, 0x03},
+				},
+			},
+			expected: "absurd avoid leopard next arm dentist\nabsurd blossom letter exist raven possible",
+		},
+		{
+			name: "WordsThresholdInvalid",
+			dataOut: &dataOut{
+				shareFormat: "words",
+				shares: [][]byte{
+					{0x01, 0x02},
+				},
+			},
+			err: "failed to encode share: threshold must be between 2 and 255",
+		},
 	}
 
 	for _, test := range tests {
@@ -21,15 +21,18 @@ import (
 	"os"
 
 	"github.com/aaron-alderman/ethdo/shamir"
+	"github.com/aaron-alderman/ethdo/util"
 	"github.com/pkg/errors"
 	e2wtypes "github.com/wealdtech/go-eth2-wallet-types/v2"
 )
 
 type sharedExport struct {
-	Version      uint32 `json:"version"`
-	Participants uint32 `json:"participants"`
-	Threshold    uint32 `json:"threshold"`
-	Data         string `json:"data"`
+	Version          uint32           `json:"version"`
+	Participants     uint32           `json:"participants"`
+	Threshold        uint32           `json:"threshold"`
+	Data             string           `json:"data"`
+	Commitments      map[uint8]string `json:"commitments"`
+	SecretCommitment string           `json:"secret_commitment"`
 }
 
 func process(ctx context.Context, data *dataIn) (*dataOut, error) {
@@ -63,11 +66,19 @@ func process(ctx context.Context, data *dataIn) (*dataOut, error) {
 		return nil, errors.Wrap(err, "failed to create shamir shares")
 	}
 
+	// Commitments allow each share, and the reconstructed passphrase, to be verified.
+	commitments := make(map[uint8]string, len(shares))
+	for index, commitment := range shamir.Commitments(shares) {
+		commitments[index] = fmt.Sprintf("%#x", commitment)
+	}
+
 	sharedExport := &sharedExport{
-		Version:      1,
-		Participants: data.participants,
-		Threshold:    data.threshold,
-		Data:         fmt.Sprintf("%#x", export),
+		Version:          2,
+		Participants:     data.participants,
+		Threshold:        data.threshold,
+		Data:             fmt.Sprintf("%#x", export),
+		Commitments:      commitments,
+		SecretCommitment: fmt.Sprintf("%#x", shamir.Commitment(passphrase)),
 	}
 	sharedFile, err := json.Marshal(sharedExport)
 	if err != nil {
@@ -77,9 +88,15 @@ func process(ctx context.Context, data *dataIn) (*dataOut, error) {
 	if err := os.WriteFile(data.file, sharedFile, 0600); err != nil {
 		return nil, errors.Wrap(err, "failed to write export file")
 	}
+	if err := util.AuditExport(fmt.Sprintf("wallet %s as %d-of-%d shared export", data.wallet.Name(), data.threshold, data.participants)); err != nil {
+		return nil, errors.Wrap(err, "failed to record export in audit log")
+	}
 
 	results := &dataOut{
-		shares: shares,
+		format:      data.format,
+		shareFormat: data.shareFormat,
+		threshold:   data.threshold,
+		shares:      shares,
 	}
 
 	return results, nil
@@ -15,11 +15,14 @@ package walletsharedexport
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
 	"io/ioutil"
 	"os"
 	"testing"
 	"time"
 
+	"github.com/aaron-alderman/ethdo/shamir"
 	"github.com/stretchr/testify/require"
 	e2types "github.com/wealdtech/go-eth2-types/v2"
 	e2wallet "github.com/wealdtech/go-eth2-wallet"
@@ -84,9 +87,23 @@ func TestProcess(t *testing.T) {
 			if test.err != "" {
 				require.EqualError(t, err, test.err)
 			} else {
+				require.NoError(t, err)
+				exportData, err := os.ReadFile(test.dataIn.file)
 				require.NoError(t, err)
 				os.Remove(test.dataIn.file)
 				require.Len(t, res.shares, int(test.dataIn.participants))
+
+				// Each share should verify against the commitments in the export.
+				export := &sharedExport{}
+				require.NoError(t, json.Unmarshal(exportData, export))
+				require.Equal(t, uint32(2), export.Version)
+				require.Len(t, export.Commitments, int(test.dataIn.participants))
+				for _, share := range res.shares {
+					require.Equal(t, fmt.Sprintf("%#x", shamir.Commitment(share)), export.Commitments[share[len(share)-1]])
+				}
+				passphrase, err := shamir.Combine(res.shares)
+				require.NoError(t, err)
+				require.Equal(t, fmt.Sprintf("%#x", shamir.Commitment(passphrase)), export.SecretCommitment)
 			}
 		})
 	}
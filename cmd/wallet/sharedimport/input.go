@@ -18,6 +18,7 @@ import (
 	"io/ioutil"
 	"time"
 
+	"github.com/aaron-alderman/ethdo/util"
 	"github.com/pkg/errors"
 	"github.com/spf13/viper"
 )
@@ -27,6 +28,7 @@ type dataIn struct {
 	timeout time.Duration
 	quiet   bool
 	verbose bool
+	format  string
 	debug   bool
 	file    []byte
 	shares  []string
@@ -46,6 +48,7 @@ func input(ctx context.Context) (*dataIn, error) {
 	data.timeout = viper.GetDuration("timeout")
 	data.quiet = viper.GetBool("quiet")
 	data.verbose = viper.GetBool("verbose")
+	data.format = util.OutputFormat()
 	data.debug = viper.GetBool("debug")
 
 	// Data.
@@ -57,8 +60,9 @@ func input(ctx context.Context) (*dataIn, error) {
 		return nil, errors.Wrap(err, "failed to read wallet import file")
 	}
 
-	// Shares.
-	data.shares = viper.GetStringSlice("shares")
+	// Shares.  Hex shares can be supplied together, separated by spaces; word shares
+	// contain spaces so must be supplied individually.
+	data.shares = append(viper.GetStringSlice("shares"), viper.GetStringSlice("share")...)
 	if len(data.shares) == 0 {
 		return nil, errors.New("failed to obtain shares")
 	}
@@ -112,6 +112,18 @@ func TestInput(t *testing.T) {
 				timeout: 5 * time.Second,
 			},
 		},
+		{
+			name: "GoodShare",
+			vars: map[string]interface{}{
+				"timeout": "5s",
+				"file":    datFile,
+				"share":   []string{"absurd avoid leopard next arm dentist", "absurd blossom letter exist raven possible"},
+			},
+			res: &dataIn{
+				timeout: 5 * time.Second,
+				shares:  []string{"absurd avoid leopard next arm dentist", "absurd blossom letter exist raven possible"},
+			},
+		},
 	}
 
 	for _, test := range tests {
@@ -126,6 +138,9 @@ func TestInput(t *testing.T) {
 			} else {
 				require.NoError(t, err)
 				require.NotNil(t, res)
+				if test.res.shares != nil {
+					require.Equal(t, test.res.shares, res.shares)
+				}
 			}
 		})
 	}
@@ -15,10 +15,62 @@ package walletsharedimport
 
 import (
 	"context"
+	"fmt"
+	"strings"
+
+	"github.com/aaron-alderman/ethdo/util"
 )
 
-type dataOut struct{}
+type dataOut struct {
+	format        string
+	corruptShares []*corruptShare
+}
+
+// corruptShare is a share that could not be used in the import.
+type corruptShare struct {
+	// position is the position of the share as supplied, starting at 1.
+	position int
+	err      error
+}
+
+// String provides a description of the corrupt share.
+func (c *corruptShare) String() string {
+	return fmt.Sprintf("share %d is corrupt: %v", c.position, c.err)
+}
+
+type importJSON struct {
+	Imported      bool                `json:"imported"`
+	CorruptShares []*corruptShareJSON `json:"corrupt_shares,omitempty"`
+}
+
+type corruptShareJSON struct {
+	Share int    `json:"share"`
+	Error string `json:"error"`
+}
 
 func output(ctx context.Context, data *dataOut) (string, error) {
-	return "Wallet imported", nil
+	if data == nil {
+		return "Wallet imported", nil
+	}
+
+	if util.StructuredFormat(data.format) {
+		res := &importJSON{
+			Imported: true,
+		}
+		for _, corruptShare := range data.corruptShares {
+			res.CorruptShares = append(res.CorruptShares, &corruptShareJSON{
+				Share: corruptShare.position,
+				Error: corruptShare.err.Error(),
+			})
+		}
+		return util.FormatOutput(data.format, res)
+	}
+
+	builder := strings.Builder{}
+	for _, corruptShare := range data.corruptShares {
+		builder.WriteString(fmt.Sprintf("Warning: %s\n", corruptShare))
+	}
+	builder.WriteString("Wallet imported")
+
+	return builder.String(), nil
 }
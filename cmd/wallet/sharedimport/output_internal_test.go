@@ -17,6 +17,7 @@ import (
 	"context"
 	"testing"
 
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/require"
 )
 
@@ -31,6 +32,31 @@ func TestOutput(t *testing.T) {
 			name: "Good",
 			res:  "Wallet imported",
 		},
+		{
+			name: "CorruptShares",
+			dataOut: &dataOut{
+				corruptShares: []*corruptShare{
+					{
+						position: 2,
+						err:      errors.New("checksum mismatch"),
+					},
+				},
+			},
+			res: "Warning: share 2 is corrupt: checksum mismatch\nWallet imported",
+		},
+		{
+			name: "CorruptSharesJSON",
+			dataOut: &dataOut{
+				format: "json",
+				corruptShares: []*corruptShare{
+					{
+						position: 2,
+						err:      errors.New("checksum mismatch"),
+					},
+				},
+			},
+			res: `{"imported":true,"corrupt_shares":[{"share":2,"error":"checksum mismatch"}]}`,
+		},
 	}
 
 	for _, test := range tests {
@@ -14,6 +14,7 @@
 package walletsharedimport
 
 import (
+	"bytes"
 	"context"
 	"encoding/hex"
 	"encoding/json"
@@ -26,10 +27,12 @@ import (
 )
 
 type sharedExport struct {
-	Version      uint32 `json:"version"`
-	Participants uint32 `json:"participants"`
-	Threshold    uint32 `json:"threshold"`
-	Data         string `json:"data"`
+	Version          uint32           `json:"version"`
+	Participants     uint32           `json:"participants"`
+	Threshold        uint32           `json:"threshold"`
+	Data             string           `json:"data"`
+	Commitments      map[uint8]string `json:"commitments"`
+	SecretCommitment string           `json:"secret_commitment"`
 }
 
 func process(ctx context.Context, data *dataIn) (*dataOut, error) {
@@ -46,21 +49,57 @@ func process(ctx context.Context, data *dataIn) (*dataOut, error) {
 		return nil, errors.Wrap(err, "failed to unmarshal export")
 	}
 
-	if len(data.shares) != int(sharedExport.Threshold) {
-		return nil, fmt.Errorf("import requires %d shares, %d were provided", sharedExport.Threshold, len(data.shares))
+	// Commitments are present from version 2 of the export.
+	var commitments map[uint8][]byte
+	if sharedExport.Version >= 2 {
+		commitments, err = decodeCommitments(sharedExport.Commitments)
+		if err != nil {
+			return nil, err
+		}
 	}
 
-	shares := make([][]byte, len(data.shares))
+	// Decode each share, noting any that are corrupt rather than failing immediately.
+	shares := make([][]byte, 0, len(data.shares))
+	corruptShares := make([]*corruptShare, 0)
+	indices := make(map[byte]int, len(data.shares))
 	for i := range data.shares {
-		shares[i], err = hex.DecodeString(data.shares[i])
+		share, err := decodeShare(data.shares[i], sharedExport.Threshold, commitments)
+		if err == nil {
+			if position, exists := indices[share[len(share)-1]]; exists {
+				err = fmt.Errorf("duplicate of share %d", position)
+			}
+		}
 		if err != nil {
-			return nil, errors.Wrap(err, "invalid share")
+			corruptShares = append(corruptShares, &corruptShare{
+				position: i + 1,
+				err:      err,
+			})
+			continue
+		}
+		indices[share[len(share)-1]] = i + 1
+		shares = append(shares, share)
+	}
+
+	if len(shares) < int(sharedExport.Threshold) {
+		if len(corruptShares) == 0 {
+			return nil, fmt.Errorf("import requires %d shares, %d were provided", sharedExport.Threshold, len(shares))
 		}
+		return nil, fmt.Errorf("import requires %d shares, %d valid shares were provided: %s", sharedExport.Threshold, len(shares), describeCorruptShares(corruptShares))
 	}
+
 	passphrase, err := shamir.Combine(shares)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to recreate passphrase from shares")
 	}
+	if sharedExport.SecretCommitment != "" {
+		secretCommitment, err := hex.DecodeString(strings.TrimPrefix(sharedExport.SecretCommitment, "0x"))
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid secret commitment")
+		}
+		if !bytes.Equal(shamir.Commitment(passphrase), secretCommitment) {
+			return nil, errors.New("recreated passphrase does not match commitment")
+		}
+	}
 	wallet, err := hex.DecodeString(strings.TrimPrefix(sharedExport.Data, "0x"))
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to obtain data from export")
@@ -69,5 +108,54 @@ func process(ctx context.Context, data *dataIn) (*dataOut, error) {
 		return nil, errors.Wrap(err, "failed to import wallet")
 	}
 
-	return nil, nil
+	return &dataOut{
+		format:        data.format,
+		corruptShares: corruptShares,
+	}, nil
+}
+
+// decodeShare decodes a share supplied either as a hex string or as a list of words,
+// verifying it against the commitments if present.
+func decodeShare(input string, threshold uint32, commitments map[uint8][]byte) ([]byte, error) {
+	share, shareThreshold, err := shamir.DecodeShare(input)
+	if err != nil {
+		return nil, err
+	}
+	if shareThreshold != 0 && shareThreshold != int(threshold) {
+		return nil, fmt.Errorf("share threshold %d does not match export threshold %d", shareThreshold, threshold)
+	}
+	if commitments != nil {
+		if err := shamir.VerifyShare(share, commitments); err != nil {
+			return nil, err
+		}
+	}
+
+	return share, nil
+}
+
+// decodeCommitments decodes the commitments held in the export.
+func decodeCommitments(input map[uint8]string) (map[uint8][]byte, error) {
+	if len(input) == 0 {
+		return nil, errors.New("export does not contain commitments")
+	}
+	commitments := make(map[uint8][]byte, len(input))
+	for index, commitment := range input {
+		data, err := hex.DecodeString(strings.TrimPrefix(commitment, "0x"))
+		if err != nil {
+			return nil, errors.Wrap(err, fmt.Sprintf("invalid commitment for share index %d", index))
+		}
+		commitments[index] = data
+	}
+
+	return commitments, nil
+}
+
+// describeCorruptShares provides a description of corrupt shares suitable for an error message.
+func describeCorruptShares(corruptShares []*corruptShare) string {
+	descriptions := make([]string, len(corruptShares))
+	for i := range corruptShares {
+		descriptions[i] = corruptShares[i].String()
+	}
+
+	return strings.Join(descriptions, "; ")
 }
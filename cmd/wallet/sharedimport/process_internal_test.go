@@ -15,14 +15,21 @@ package walletsharedimport
 
 import (
 	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/aaron-alderman/ethdo/shamir"
 	"github.com/stretchr/testify/require"
 	e2types "github.com/wealdtech/go-eth2-types/v2"
+	e2wallet "github.com/wealdtech/go-eth2-wallet"
+	scratch "github.com/wealdtech/go-eth2-wallet-store-scratch"
 )
 
 func TestProcess(t *testing.T) {
@@ -43,10 +50,54 @@ func TestProcess(t *testing.T) {
 	require.NoError(t, ioutil.WriteFile(datFile, export, 0600))
 	defer os.RemoveAll(dir)
 
+	wordShares := make([]string, len(shares))
+	for i := range shares {
+		share, err := hex.DecodeString(shares[i])
+		require.NoError(t, err)
+		wordShares[i], err = shamir.ShareToMnemonic(share, 3)
+		require.NoError(t, err)
+	}
+	words := strings.Fields(wordShares[0])
+	words[0], words[1] = words[1], words[0]
+	corruptWordShare := strings.Join(words, " ")
+	share, err := hex.DecodeString(shares[0])
+	require.NoError(t, err)
+	wrongThresholdWordShare, err := shamir.ShareToMnemonic(share, 2)
+	require.NoError(t, err)
+
+	// Create a version of the export with commitments.
+	v2 := &sharedExport{}
+	require.NoError(t, json.Unmarshal(export, v2))
+	v2.Version = 2
+	v2.Commitments = make(map[uint8]string)
+	shareBytes := make([][]byte, len(shares))
+	for i := range shares {
+		shareBytes[i], err = hex.DecodeString(shares[i])
+		require.NoError(t, err)
+		v2.Commitments[shareBytes[i][len(shareBytes[i])-1]] = fmt.Sprintf("%#x", shamir.Commitment(shareBytes[i]))
+	}
+	passphrase, err := shamir.Combine(shareBytes[:3])
+	require.NoError(t, err)
+	v2.SecretCommitment = fmt.Sprintf("%#x", shamir.Commitment(passphrase))
+	exportV2, err := json.Marshal(v2)
+	require.NoError(t, err)
+	v2.SecretCommitment = "0x0000000000000000000000000000000000000000000000000000000000000000"
+	exportV2BadSecret, err := json.Marshal(v2)
+	require.NoError(t, err)
+	v2.SecretCommitment = ""
+	v2.Commitments = nil
+	exportV2NoCommitments, err := json.Marshal(v2)
+	require.NoError(t, err)
+
+	// A share that is valid hex but not genuine.
+	forgedShare := append([]byte{}, shareBytes[0]...)
+	forgedShare[0] ^= 0x01
+
 	tests := []struct {
-		name   string
-		dataIn *dataIn
-		err    string
+		name          string
+		dataIn        *dataIn
+		corruptShares int
+		err           string
 	}{
 		{
 			name: "Nil",
@@ -85,7 +136,7 @@ func TestProcess(t *testing.T) {
 			err: "import requires 3 shares, 2 were provided",
 		},
 		{
-			name: "SharesTooHigh",
+			name: "SharesExtra",
 			dataIn: &dataIn{
 				timeout: 5 * time.Second,
 				file:    export,
@@ -96,7 +147,6 @@ func TestProcess(t *testing.T) {
 					shares[3],
 				},
 			},
-			err: "import requires 3 shares, 4 were provided",
 		},
 		{
 			name: "ShareBad",
@@ -109,7 +159,163 @@ func TestProcess(t *testing.T) {
 					shares[2],
 				},
 			},
-			err: "invalid share: encoding/hex: invalid byte: U+0078 'x'",
+			err: "import requires 3 shares, 2 valid shares were provided: share 1 is corrupt: invalid hex: encoding/hex: invalid byte: U+0078 'x'",
+		},
+		{
+			name: "ShareDuplicate",
+			dataIn: &dataIn{
+				timeout: 5 * time.Second,
+				file:    export,
+				shares: []string{
+					shares[0],
+					shares[1],
+					shares[1],
+				},
+			},
+			err: "import requires 3 shares, 2 valid shares were provided: share 3 is corrupt: duplicate of share 2",
+		},
+		{
+			name: "ShareBadWithSpare",
+			dataIn: &dataIn{
+				timeout: 5 * time.Second,
+				file:    export,
+				shares: []string{
+					"xxx",
+					shares[1],
+					shares[2],
+					shares[3],
+				},
+			},
+			corruptShares: 1,
+		},
+		{
+			name: "Words",
+			dataIn: &dataIn{
+				timeout: 5 * time.Second,
+				file:    export,
+				shares: []string{
+					wordShares[0],
+					wordShares[1],
+					wordShares[2],
+				},
+			},
+		},
+		{
+			name: "WordsAndHex",
+			dataIn: &dataIn{
+				timeout: 5 * time.Second,
+				file:    export,
+				shares: []string{
+					wordShares[0],
+					shares[1],
+					wordShares[4],
+				},
+			},
+		},
+		{
+			name: "WordsCorrupt",
+			dataIn: &dataIn{
+				timeout: 5 * time.Second,
+				file:    export,
+				shares: []string{
+					corruptWordShare,
+					wordShares[1],
+					wordShares[2],
+				},
+			},
+			err: "import requires 3 shares, 2 valid shares were provided: share 1 is corrupt: checksum mismatch",
+		},
+		{
+			name: "WordsCorruptWithSpare",
+			dataIn: &dataIn{
+				timeout: 5 * time.Second,
+				file:    export,
+				shares: []string{
+					wordShares[0],
+					corruptWordShare,
+					wordShares[2],
+					wordShares[3],
+				},
+			},
+			corruptShares: 1,
+		},
+		{
+			name: "WordsThresholdMismatch",
+			dataIn: &dataIn{
+				timeout: 5 * time.Second,
+				file:    export,
+				shares: []string{
+					wrongThresholdWordShare,
+					wordShares[1],
+					wordShares[2],
+				},
+			},
+			err: "import requires 3 shares, 2 valid shares were provided: share 1 is corrupt: share threshold 2 does not match export threshold 3",
+		},
+		{
+			name: "CommitmentsMissing",
+			dataIn: &dataIn{
+				timeout: 5 * time.Second,
+				file:    exportV2NoCommitments,
+				shares: []string{
+					shares[0],
+					shares[1],
+					shares[2],
+				},
+			},
+			err: "export does not contain commitments",
+		},
+		{
+			name: "CommitmentsForgedShare",
+			dataIn: &dataIn{
+				timeout: 5 * time.Second,
+				file:    exportV2,
+				shares: []string{
+					fmt.Sprintf("%x", forgedShare),
+					shares[1],
+					shares[2],
+				},
+			},
+			err: fmt.Sprintf("import requires 3 shares, 2 valid shares were provided: share 1 is corrupt: share does not match commitment for share index %d", forgedShare[len(forgedShare)-1]),
+		},
+		{
+			name: "CommitmentsForgedShareWithSpare",
+			dataIn: &dataIn{
+				timeout: 5 * time.Second,
+				file:    exportV2,
+				shares: []string{
+					fmt.Sprintf("%x", forgedShare),
+					shares[1],
+					shares[2],
+					wordShares[3],
+				},
+			},
+			corruptShares: 1,
+		},
+		{
+			name: "CommitmentsSecretMismatch",
+			dataIn: &dataIn{
+				timeout: 5 * time.Second,
+				file:    exportV2BadSecret,
+				shares: []string{
+					shares[0],
+					shares[1],
+					shares[2],
+				},
+			},
+			err: "recreated passphrase does not match commitment",
+		},
+		{
+			name: "CommitmentsGood",
+			dataIn: &dataIn{
+				timeout: 5 * time.Second,
+				file:    exportV2,
+				shares: []string{
+					shares[0],
+					shares[1],
+					shares[2],
+				},
+			},
 		},
 		{
 			name: "Good",
@@ -127,11 +333,14 @@ func TestProcess(t *testing.T) {
 
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
-			_, err := process(context.Background(), test.dataIn)
+			// Each import requires a fresh store.
+			require.NoError(t, e2wallet.UseStore(scratch.New()))
+			res, err := process(context.Background(), test.dataIn)
 			if test.err != "" {
 				require.EqualError(t, err, test.err)
 			} else {
 				require.NoError(t, err)
+				require.Len(t, res.corruptShares, test.corruptShares)
 			}
 		})
 	}
@@ -0,0 +1,66 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package walletsharedverify
+
+import (
+	"context"
+	"io/ioutil"
+	"time"
+
+	"github.com/aaron-alderman/ethdo/util"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+)
+
+type dataIn struct {
+	// System.
+	timeout time.Duration
+	quiet   bool
+	verbose bool
+	format  string
+	debug   bool
+	file    []byte
+	share   string
+}
+
+func input(ctx context.Context) (*dataIn, error) {
+	var err error
+	data := &dataIn{}
+
+	if viper.GetDuration("timeout") == 0 {
+		return nil, errors.New("timeout is required")
+	}
+	data.timeout = viper.GetDuration("timeout")
+	data.quiet = viper.GetBool("quiet")
+	data.verbose = viper.GetBool("verbose")
+	data.format = util.OutputFormat()
+	data.debug = viper.GetBool("debug")
+
+	// Data.
+	if viper.GetString("file") == "" {
+		return nil, errors.New("file is required")
+	}
+	data.file, err = ioutil.ReadFile(viper.GetString("file"))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read wallet export file")
+	}
+
+	// Share.
+	data.share = viper.GetString("share")
+	if data.share == "" {
+		return nil, errors.New("share is required")
+	}
+
+	return data, nil
+}
@@ -0,0 +1,103 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package walletsharedverify
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInput(t *testing.T) {
+	dir := t.TempDir()
+	datFile := filepath.Join(dir, "backup.dat")
+	require.NoError(t, os.WriteFile(datFile, []byte("dummy"), 0600))
+
+	tests := []struct {
+		name string
+		vars map[string]interface{}
+		res  *dataIn
+		err  string
+	}{
+		{
+			name: "TimeoutMissing",
+			vars: map[string]interface{}{
+				"file":  datFile,
+				"share": "0102",
+			},
+			err: "timeout is required",
+		},
+		{
+			name: "FileMissing",
+			vars: map[string]interface{}{
+				"timeout": "5s",
+				"share":   "0102",
+			},
+			err: "file is required",
+		},
+		{
+			name: "FileBad",
+			vars: map[string]interface{}{
+				"timeout": "5s",
+				"file":    filepath.Join(dir, "bad.dat"),
+				"share":   "0102",
+			},
+			err: "failed to read wallet export file: open " + filepath.Join(dir, "bad.dat") + ": no such file or directory",
+		},
+		{
+			name: "ShareMissing",
+			vars: map[string]interface{}{
+				"timeout": "5s",
+				"file":    datFile,
+			},
+			err: "share is required",
+		},
+		{
+			name: "Good",
+			vars: map[string]interface{}{
+				"timeout": "5s",
+				"file":    datFile,
+				"share":   "0102",
+			},
+			res: &dataIn{
+				timeout: 5 * time.Second,
+				file:    []byte("dummy"),
+				share:   "0102",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			viper.Reset()
+			for k, v := range test.vars {
+				viper.Set(k, v)
+			}
+			res, err := input(context.Background())
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, test.res.timeout, res.timeout)
+				require.Equal(t, test.res.file, res.file)
+				require.Equal(t, test.res.share, res.share)
+			}
+		})
+	}
+}
@@ -0,0 +1,50 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package walletsharedverify
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aaron-alderman/ethdo/util"
+	"github.com/pkg/errors"
+)
+
+type dataOut struct {
+	format    string
+	index     uint8
+	threshold uint32
+}
+
+type verifyJSON struct {
+	Verified  bool   `json:"verified"`
+	Index     uint8  `json:"index"`
+	Threshold uint32 `json:"threshold"`
+}
+
+func output(ctx context.Context, data *dataOut) (string, error) {
+	if data == nil {
+		return "", errors.New("no data")
+	}
+
+	if util.StructuredFormat(data.format) {
+		return util.FormatOutput(data.format, &verifyJSON{
+			Verified:  true,
+			Index:     data.index,
+			Threshold: data.threshold,
+		})
+	}
+
+	return fmt.Sprintf("Share with index %d verified; %d shares are required to import the wallet", data.index, data.threshold), nil
+}
@@ -0,0 +1,77 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package walletsharedverify
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/aaron-alderman/ethdo/shamir"
+	"github.com/aaron-alderman/ethdo/util"
+	"github.com/pkg/errors"
+)
+
+type sharedExport struct {
+	Version          uint32           `json:"version"`
+	Participants     uint32           `json:"participants"`
+	Threshold        uint32           `json:"threshold"`
+	Data             string           `json:"data"`
+	Commitments      map[uint8]string `json:"commitments"`
+	SecretCommitment string           `json:"secret_commitment"`
+}
+
+func process(ctx context.Context, data *dataIn) (*dataOut, error) {
+	if data == nil {
+		return nil, errors.New("no data")
+	}
+	if len(data.file) == 0 {
+		return nil, errors.New("export file is required")
+	}
+
+	sharedExport := &sharedExport{}
+	if err := json.Unmarshal(data.file, sharedExport); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal export")
+	}
+	if sharedExport.Version < 2 || len(sharedExport.Commitments) == 0 {
+		return nil, errors.New("export does not contain commitments so shares cannot be verified")
+	}
+	commitments := make(map[uint8][]byte, len(sharedExport.Commitments))
+	for index, commitment := range sharedExport.Commitments {
+		data, err := hex.DecodeString(strings.TrimPrefix(commitment, "0x"))
+		if err != nil {
+			return nil, errors.Wrap(err, fmt.Sprintf("invalid commitment for share index %d", index))
+		}
+		commitments[index] = data
+	}
+
+	share, threshold, err := shamir.DecodeShare(data.share)
+	if err != nil {
+		return nil, util.NewCodedError(util.ErrorCodeVerificationFailed, errors.Wrap(err, "invalid share"))
+	}
+	if threshold != 0 && threshold != int(sharedExport.Threshold) {
+		return nil, util.NewCodedError(util.ErrorCodeVerificationFailed, fmt.Errorf("share threshold %d does not match export threshold %d", threshold, sharedExport.Threshold))
+	}
+	if err := shamir.VerifyShare(share, commitments); err != nil {
+		return nil, util.NewCodedError(util.ErrorCodeVerificationFailed, err)
+	}
+
+	return &dataOut{
+		format:    data.format,
+		index:     share[len(share)-1],
+		threshold: sharedExport.Threshold,
+	}, nil
+}
@@ -0,0 +1,152 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package walletsharedverify
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/aaron-alderman/ethdo/shamir"
+	"github.com/aaron-alderman/ethdo/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProcess(t *testing.T) {
+	shares, err := shamir.Split([]byte("test passphrase"), 3, 2)
+	require.NoError(t, err)
+	export := &sharedExport{
+		Version:      2,
+		Participants: 3,
+		Threshold:    2,
+		Data:         "0x00",
+		Commitments:  make(map[uint8]string),
+	}
+	for index, commitment := range shamir.Commitments(shares) {
+		export.Commitments[index] = fmt.Sprintf("%#x", commitment)
+	}
+	exportData, err := json.Marshal(export)
+	require.NoError(t, err)
+	export.Version = 1
+	export.Commitments = nil
+	exportV1Data, err := json.Marshal(export)
+	require.NoError(t, err)
+
+	wordShare, err := shamir.ShareToMnemonic(shares[1], 2)
+	require.NoError(t, err)
+	wrongThresholdWordShare, err := shamir.ShareToMnemonic(shares[1], 3)
+	require.NoError(t, err)
+	forgedShare := append([]byte{}, shares[2]...)
+	forgedShare[0] ^= 0x01
+
+	tests := []struct {
+		name   string
+		dataIn *dataIn
+		res    *dataOut
+		err    string
+		code   string
+	}{
+		{
+			name: "Nil",
+			err:  "no data",
+		},
+		{
+			name:   "FileMissing",
+			dataIn: &dataIn{},
+			err:    "export file is required",
+		},
+		{
+			name: "FileBad",
+			dataIn: &dataIn{
+				file:  []byte("bad"),
+				share: hex.EncodeToString(shares[0]),
+			},
+			err: "failed to unmarshal export: invalid character 'b' looking for beginning of value",
+		},
+		{
+			name: "CommitmentsMissing",
+			dataIn: &dataIn{
+				file:  exportV1Data,
+				share: hex.EncodeToString(shares[0]),
+			},
+			err: "export does not contain commitments so shares cannot be verified",
+		},
+		{
+			name: "ShareInvalid",
+			dataIn: &dataIn{
+				file:  exportData,
+				share: "xx",
+			},
+			err:  "invalid share: invalid hex: encoding/hex: invalid byte: U+0078 'x'",
+			code: util.ErrorCodeVerificationFailed,
+		},
+		{
+			name: "ShareForged",
+			dataIn: &dataIn{
+				file:  exportData,
+				share: hex.EncodeToString(forgedShare),
+			},
+			err:  fmt.Sprintf("share does not match commitment for share index %d", forgedShare[len(forgedShare)-1]),
+			code: util.ErrorCodeVerificationFailed,
+		},
+		{
+			name: "ShareThresholdMismatch",
+			dataIn: &dataIn{
+				file:  exportData,
+				share: wrongThresholdWordShare,
+			},
+			err:  "share threshold 3 does not match export threshold 2",
+			code: util.ErrorCodeVerificationFailed,
+		},
+		{
+			name: "GoodHex",
+			dataIn: &dataIn{
+				file:  exportData,
+				share: hex.EncodeToString(shares[0]),
+			},
+			res: &dataOut{
+				index:     shares[0][len(shares[0])-1],
+				threshold: 2,
+			},
+		},
+		{
+			name: "GoodWords",
+			dataIn: &dataIn{
+				file:  exportData,
+				share: wordShare,
+			},
+			res: &dataOut{
+				index:     shares[1][len(shares[1])-1],
+				threshold: 2,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			res, err := process(context.Background(), test.dataIn)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+				if test.code != "" {
+					require.Equal(t, test.code, util.ErrorCode(err))
+				}
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, test.res, res)
+			}
+		})
+	}
+}
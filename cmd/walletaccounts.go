@@ -85,6 +85,10 @@ In quiet mode this will return 0 if the wallet holds any addresses, otherwise 1.
 			})
 		}
 
+		if !quiet && outputStructured(walletAccountsStructured(accounts)) {
+			os.Exit(_exitSuccess)
+		}
+
 		for _, account := range accounts {
 			outputIf(!quiet, account.Name())
 			if verbose {
@@ -106,6 +110,37 @@ In quiet mode this will return 0 if the wallet holds any addresses, otherwise 1.
 	},
 }
 
+type walletAccountJSON struct {
+	Name               string `json:"name"`
+	UUID               string `json:"uuid"`
+	Path               string `json:"path,omitempty"`
+	PublicKey          string `json:"public_key,omitempty"`
+	CompositePublicKey string `json:"composite_public_key,omitempty"`
+}
+
+// walletAccountsStructured returns wallet accounts for structured output.
+func walletAccountsStructured(accounts []e2wtypes.Account) []*walletAccountJSON {
+	res := make([]*walletAccountJSON, 0, len(accounts))
+	for _, account := range accounts {
+		info := &walletAccountJSON{
+			Name: account.Name(),
+			UUID: account.ID().String(),
+		}
+		if pathProvider, isProvider := account.(e2wtypes.AccountPathProvider); isProvider {
+			info.Path = pathProvider.Path()
+		}
+		if pubKeyProvider, isProvider := account.(e2wtypes.AccountPublicKeyProvider); isProvider {
+			info.PublicKey = fmt.Sprintf("%#x", pubKeyProvider.PublicKey().Marshal())
+		}
+		if compositePubKeyProvider, isProvider := account.(e2wtypes.AccountCompositePublicKeyProvider); isProvider {
+			info.CompositePublicKey = fmt.Sprintf("%#x", compositePubKeyProvider.CompositePublicKey().Marshal())
+		}
+		res = append(res, info)
+	}
+
+	return res
+}
+
 func init() {
 	walletCmd.AddCommand(walletAccountsCmd)
 	walletFlags(walletAccountsCmd)
@@ -46,6 +46,10 @@ In quiet mode this will return 0 if the wallet exists, otherwise 1.`,
 			os.Exit(0)
 		}
 
+		if outputStructured(walletInfoStructured(ctx, wallet)) {
+			os.Exit(_exitSuccess)
+		}
+
 		outputIf(verbose, fmt.Sprintf("UUID: %v", wallet.ID()))
 		fmt.Printf("Type: %s\n", wallet.Type())
 		if verbose {
@@ -67,6 +71,36 @@ In quiet mode this will return 0 if the wallet exists, otherwise 1.`,
 	},
 }
 
+type walletInfoJSON struct {
+	Name     string `json:"name"`
+	UUID     string `json:"uuid"`
+	Type     string `json:"type"`
+	Store    string `json:"store,omitempty"`
+	Location string `json:"location,omitempty"`
+	Accounts int    `json:"accounts"`
+}
+
+// walletInfoStructured returns wallet information for structured output.
+func walletInfoStructured(ctx context.Context, wallet wtypes.Wallet) *walletInfoJSON {
+	res := &walletInfoJSON{
+		Name: wallet.Name(),
+		UUID: wallet.ID().String(),
+		Type: wallet.Type(),
+	}
+	if storeProvider, ok := wallet.(wtypes.StoreProvider); ok {
+		store := storeProvider.Store()
+		res.Store = store.Name()
+		if storeLocationProvider, ok := store.(wtypes.StoreLocationProvider); ok {
+			res.Location = filepath.Join(storeLocationProvider.Location(), wallet.ID().String())
+		}
+	}
+	for range wallet.Accounts(ctx) {
+		res.Accounts++
+	}
+
+	return res
+}
+
 func init() {
 	walletCmd.AddCommand(walletInfoCmd)
 	walletFlags(walletInfoCmd)
@@ -34,20 +34,33 @@ In quiet mode this will return 0 if any wallets are found, otherwise 1.`,
 		assert(viper.GetString("remote") == "", "wallet list not available with remote wallets")
 		assert(viper.GetString("wallet") == "", "wallet list does not take a --wallet parameter")
 
-		walletsFound := false
+		wallets := make([]*walletListJSON, 0)
 		for w := range e2wallet.Wallets() {
-			walletsFound = true
-			outputIf(!quiet && !verbose, w.Name())
-			outputIf(verbose, fmt.Sprintf("%s\n UUID: %s", w.Name(), w.ID().String()))
+			wallets = append(wallets, &walletListJSON{
+				Name: w.Name(),
+				UUID: w.ID().String(),
+			})
 		}
 
-		if !walletsFound {
+		if len(wallets) == 0 {
 			os.Exit(_exitFailure)
 		}
+		if !quiet && outputStructured(wallets) {
+			os.Exit(_exitSuccess)
+		}
+		for _, w := range wallets {
+			outputIf(!quiet && !verbose, w.Name)
+			outputIf(verbose, fmt.Sprintf("%s\n UUID: %s", w.Name, w.UUID))
+		}
 		os.Exit(_exitSuccess)
 	},
 }
 
+type walletListJSON struct {
+	Name string `json:"name"`
+	UUID string `json:"uuid"`
+}
+
 func init() {
 	walletCmd.AddCommand(walletListCmd)
 	walletFlags(walletListCmd)
@@ -26,7 +26,9 @@ var walletSharedExportCmd = &cobra.Command{
 	Short: "Export a wallet using Shamir secret sharing",
 	Long: `Export a wallet for backup of transfer using Shamir secret sharing.  For example:
 
-    ethdo wallet sharedexport --wallet=primary --participants=5 --threshold=3 --file=backup.dat`,
+    ethdo wallet sharedexport --wallet=primary --participants=5 --threshold=3 --file=backup.dat
+
+Shares are output as hex strings by default; supplying --share-format=words outputs each share as a list of words containing the share index, the threshold and a checksum, which is easier to transcribe accurately.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		res, err := walletsharedexport.Run(cmd)
 		if err != nil {
@@ -45,6 +47,7 @@ func init() {
 	walletSharedExportCmd.Flags().Uint32("participants", 0, "Number of participants in sharing scheme")
 	walletSharedExportCmd.Flags().Uint32("threshold", 0, "Number of participants required to recover the export")
 	walletSharedExportCmd.Flags().String("file", "", "Name of the file that stores the export")
+	walletSharedExportCmd.Flags().String("share-format", "hex", "Format of the shares: hex or words")
 }
 
 func walletSharedExportBindings() {
@@ -57,4 +60,7 @@ func walletSharedExportBindings() {
 	if err := viper.BindPFlag("file", walletSharedExportCmd.Flags().Lookup("file")); err != nil {
 		panic(err)
 	}
+	if err := viper.BindPFlag("share-format", walletSharedExportCmd.Flags().Lookup("share-format")); err != nil {
+		panic(err)
+	}
 }
@@ -28,6 +28,12 @@ var walletSharedImportCmd = &cobra.Command{
 
 	ethdo wallet sharedimport --file=backup.dat --shares="1234 2345 3456"
 
+Shares output as words are supplied individually with --share, for example:
+
+	ethdo wallet sharedimport --file=backup.dat --share="absurd avoid ..." --share="absurd blossom ..." --share="absurd cabin ..."
+
+More shares than the threshold can be supplied; any that are corrupt are reported and the remainder used to import the wallet.
+
 In quiet mode this will return 0 if the wallet is imported successfully, otherwise 1.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		res, err := walletsharedimport.Run(cmd)
@@ -46,6 +52,7 @@ func init() {
 	walletFlags(walletSharedImportCmd)
 	walletSharedImportCmd.Flags().String("file", "", "Name of the file that stores the export")
 	walletSharedImportCmd.Flags().String("shares", "", "Shares required to decrypt the export, separated with spaces")
+	walletSharedImportCmd.Flags().StringArray("share", nil, "A share required to decrypt the export, as hex or words (supply once for each share)")
 }
 
 func walletSharedImportBindings() {
@@ -55,4 +62,7 @@ func walletSharedImportBindings() {
 	if err := viper.BindPFlag("shares", walletSharedImportCmd.Flags().Lookup("shares")); err != nil {
 		panic(err)
 	}
+	if err := viper.BindPFlag("share", walletSharedImportCmd.Flags().Lookup("share")); err != nil {
+		panic(err)
+	}
 }
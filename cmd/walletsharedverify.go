@@ -0,0 +1,58 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	walletsharedverify "github.com/aaron-alderman/ethdo/cmd/wallet/sharedverify"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var walletSharedVerifyCmd = &cobra.Command{
+	Use:   "sharedverify",
+	Short: "Verify a share of a wallet exported using Shamir secret sharing",
+	Long: `Verify a single share of a wallet exported with Shamir secret sharing against the commitments in the export file, without requiring any other shares.  For example:
+
+	ethdo wallet sharedverify --file=backup.dat --share="1234"
+
+In quiet mode this will return 0 if the share is verified, otherwise 1.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		res, err := walletsharedverify.Run(cmd)
+		if err != nil {
+			return err
+		}
+		if res != "" {
+			fmt.Println(res)
+		}
+		return nil
+	},
+}
+
+func init() {
+	walletCmd.AddCommand(walletSharedVerifyCmd)
+	walletFlags(walletSharedVerifyCmd)
+	walletSharedVerifyCmd.Flags().String("file", "", "Name of the file that stores the export")
+	walletSharedVerifyCmd.Flags().String("share", "", "The share to verify, as hex or words")
+}
+
+func walletSharedVerifyBindings() {
+	if err := viper.BindPFlag("file", walletSharedVerifyCmd.Flags().Lookup("file")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("share", walletSharedVerifyCmd.Flags().Lookup("share")); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,144 @@
+// Copyright © 2022 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package badger
+
+import (
+	"context"
+
+	"github.com/aaron-alderman/ethdo/services/cache"
+	"github.com/dgraph-io/badger/v2"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
+	zerologger "github.com/rs/zerolog/log"
+)
+
+// Service is a cache backed by a badger database.
+type Service struct {
+	db *badger.DB
+}
+
+// module-wide log.
+var log zerolog.Logger
+
+// New creates a new badger cache.
+func New(ctx context.Context, params ...Parameter) (*Service, error) {
+	parameters, err := parseAndCheckParameters(params...)
+	if err != nil {
+		return nil, errors.Wrap(err, "problem with parameters")
+	}
+
+	// Set logging.
+	log = zerologger.With().Str("service", "cache").Str("impl", "badger").Logger().Level(parameters.logLevel)
+
+	opts := badger.DefaultOptions(parameters.baseDir).WithLogger(nil)
+	db, err := badger.Open(opts)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to open cache")
+	}
+	log.Trace().Str("base_dir", parameters.baseDir).Msg("Opened cache")
+
+	return &Service{
+		db: db,
+	}, nil
+}
+
+// Fetch fetches the value for the given key.
+// It returns false if the key is not present.
+func (s *Service) Fetch(_ context.Context, key []byte) ([]byte, bool, error) {
+	var value []byte
+	err := s.db.View(func(txn *badger.Txn) error {
+		item, err := txn.Get(key)
+		if err != nil {
+			return err
+		}
+		value, err = item.ValueCopy(nil)
+		return err
+	})
+	if errors.Is(err, badger.ErrKeyNotFound) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, errors.Wrap(err, "failed to fetch from cache")
+	}
+
+	return value, true, nil
+}
+
+// Store stores the value for the given key.
+func (s *Service) Store(_ context.Context, key []byte, value []byte) error {
+	if err := s.db.Update(func(txn *badger.Txn) error {
+		return txn.Set(key, value)
+	}); err != nil {
+		return errors.Wrap(err, "failed to store in cache")
+	}
+
+	return nil
+}
+
+// Entries returns the entries whose keys start with the given prefix.
+func (s *Service) Entries(_ context.Context, prefix []byte) ([]*cache.Entry, error) {
+	entries := make([]*cache.Entry, 0)
+	err := s.db.View(func(txn *badger.Txn) error {
+		opts := badger.DefaultIteratorOptions
+		opts.PrefetchValues = false
+		opts.Prefix = prefix
+		it := txn.NewIterator(opts)
+		defer it.Close()
+		for it.Rewind(); it.Valid(); it.Next() {
+			item := it.Item()
+			entries = append(entries, &cache.Entry{
+				Key:  item.KeyCopy(nil),
+				Size: item.EstimatedSize(),
+			})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to obtain cache entries")
+	}
+
+	return entries, nil
+}
+
+// Delete deletes the entries with the given keys.
+func (s *Service) Delete(_ context.Context, keys [][]byte) error {
+	batch := s.db.NewWriteBatch()
+	defer batch.Cancel()
+	for _, key := range keys {
+		if err := batch.Delete(key); err != nil {
+			return errors.Wrap(err, "failed to delete from cache")
+		}
+	}
+	if err := batch.Flush(); err != nil {
+		return errors.Wrap(err, "failed to delete from cache")
+	}
+
+	// Reclaim space from the value log where possible.
+	for {
+		if err := s.db.RunValueLogGC(0.5); err != nil {
+			break
+		}
+	}
+
+	return nil
+}
+
+// Close closes the cache.
+func (s *Service) Close(_ context.Context) error {
+	if err := s.db.Close(); err != nil {
+		return errors.Wrap(err, "failed to close cache")
+	}
+
+	return nil
+}
@@ -0,0 +1,101 @@
+// Copyright © 2022 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package badger_test
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/aaron-alderman/ethdo/services/cache"
+	"github.com/aaron-alderman/ethdo/services/cache/badger"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNew(t *testing.T) {
+	ctx := context.Background()
+
+	_, err := badger.New(ctx, badger.WithLogLevel(zerolog.Disabled))
+	require.EqualError(t, err, "problem with parameters: no base directory specified")
+
+	baseDir, err := ioutil.TempDir("", "")
+	require.NoError(t, err)
+	defer os.RemoveAll(baseDir)
+
+	s, err := badger.New(ctx,
+		badger.WithLogLevel(zerolog.Disabled),
+		badger.WithBaseDir(baseDir),
+	)
+	require.NoError(t, err)
+	require.NoError(t, s.Close(ctx))
+}
+
+func TestService(t *testing.T) {
+	ctx := context.Background()
+
+	baseDir, err := ioutil.TempDir("", "")
+	require.NoError(t, err)
+	defer os.RemoveAll(baseDir)
+
+	s, err := badger.New(ctx,
+		badger.WithLogLevel(zerolog.Disabled),
+		badger.WithBaseDir(baseDir),
+	)
+	require.NoError(t, err)
+
+	_, found, err := s.Fetch(ctx, cache.BlockKey(1))
+	require.NoError(t, err)
+	require.False(t, found)
+
+	require.NoError(t, s.Store(ctx, cache.BlockKey(1), []byte("block 1")))
+	require.NoError(t, s.Store(ctx, cache.BlockKey(2), []byte{}))
+	require.NoError(t, s.Store(ctx, cache.HeaderKey(1), []byte("header 1")))
+
+	value, found, err := s.Fetch(ctx, cache.BlockKey(1))
+	require.NoError(t, err)
+	require.True(t, found)
+	require.Equal(t, []byte("block 1"), value)
+
+	// Empty values are present.
+	value, found, err = s.Fetch(ctx, cache.BlockKey(2))
+	require.NoError(t, err)
+	require.True(t, found)
+	require.Empty(t, value)
+
+	entries, err := s.Entries(ctx, cache.KindPrefix(cache.KindBlocks))
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	require.Equal(t, cache.BlockKey(1), entries[0].Key)
+	require.Equal(t, cache.BlockKey(2), entries[1].Key)
+
+	require.NoError(t, s.Delete(ctx, [][]byte{cache.BlockKey(1)}))
+	entries, err = s.Entries(ctx, nil)
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+
+	// Ensure data persists.
+	require.NoError(t, s.Close(ctx))
+	s, err = badger.New(ctx,
+		badger.WithLogLevel(zerolog.Disabled),
+		badger.WithBaseDir(baseDir),
+	)
+	require.NoError(t, err)
+	value, found, err = s.Fetch(ctx, cache.HeaderKey(1))
+	require.NoError(t, err)
+	require.True(t, found)
+	require.Equal(t, []byte("header 1"), value)
+	require.NoError(t, s.Close(ctx))
+}
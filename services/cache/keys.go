@@ -0,0 +1,79 @@
+// Copyright © 2022 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cache
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+)
+
+// Kinds of data held in the cache.
+const (
+	KindBlocks         = "blocks"
+	KindHeaders        = "headers"
+	KindCommittees     = "committees"
+	KindSyncCommittees = "synccommittees"
+)
+
+// Kinds are all kinds of data held in the cache.
+var Kinds = []string{
+	KindBlocks,
+	KindHeaders,
+	KindCommittees,
+	KindSyncCommittees,
+}
+
+// BlockKey returns the cache key for the block at the given slot.
+func BlockKey(slot phase0.Slot) []byte {
+	return []byte(fmt.Sprintf("%s/%020d", KindBlocks, slot))
+}
+
+// HeaderKey returns the cache key for the block header at the given slot.
+func HeaderKey(slot phase0.Slot) []byte {
+	return []byte(fmt.Sprintf("%s/%020d", KindHeaders, slot))
+}
+
+// CommitteesKey returns the cache key for the beacon committees for the given epoch
+// obtained from the state at the given slot.
+func CommitteesKey(slot phase0.Slot, epoch phase0.Epoch) []byte {
+	return []byte(fmt.Sprintf("%s/%020d/%020d", KindCommittees, slot, epoch))
+}
+
+// SyncCommitteeKey returns the cache key for the sync committee for the given epoch
+// obtained from the state at the given slot.
+func SyncCommitteeKey(slot phase0.Slot, epoch phase0.Epoch) []byte {
+	return []byte(fmt.Sprintf("%s/%020d/%020d", KindSyncCommittees, slot, epoch))
+}
+
+// KindPrefix returns the prefix of cache keys for the given kind.
+func KindPrefix(kind string) []byte {
+	return []byte(fmt.Sprintf("%s/", kind))
+}
+
+// ParseKey parses a cache key, returning its kind and slot.
+func ParseKey(key []byte) (string, phase0.Slot, error) {
+	parts := strings.Split(string(key), "/")
+	if len(parts) < 2 {
+		return "", 0, errors.New("invalid key")
+	}
+	slot, err := strconv.ParseUint(parts[1], 10, 64)
+	if err != nil {
+		return "", 0, errors.Wrap(err, "invalid slot in key")
+	}
+	return parts[0], phase0.Slot(slot), nil
+}
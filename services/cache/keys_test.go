@@ -0,0 +1,80 @@
+// Copyright © 2022 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cache_test
+
+import (
+	"testing"
+
+	"github.com/aaron-alderman/ethdo/services/cache"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseKey(t *testing.T) {
+	tests := []struct {
+		name string
+		key  []byte
+		kind string
+		slot phase0.Slot
+		err  string
+	}{
+		{
+			name: "Empty",
+			key:  []byte{},
+			err:  "invalid key",
+		},
+		{
+			name: "SlotInvalid",
+			key:  []byte("blocks/invalid"),
+			err:  "invalid slot in key: strconv.ParseUint: parsing \"invalid\": invalid syntax",
+		},
+		{
+			name: "Block",
+			key:  cache.BlockKey(12345),
+			kind: cache.KindBlocks,
+			slot: 12345,
+		},
+		{
+			name: "Header",
+			key:  cache.HeaderKey(12345),
+			kind: cache.KindHeaders,
+			slot: 12345,
+		},
+		{
+			name: "Committees",
+			key:  cache.CommitteesKey(12345, 385),
+			kind: cache.KindCommittees,
+			slot: 12345,
+		},
+		{
+			name: "SyncCommittee",
+			key:  cache.SyncCommitteeKey(12345, 385),
+			kind: cache.KindSyncCommittees,
+			slot: 12345,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			kind, slot, err := cache.ParseKey(test.key)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, test.kind, kind)
+				require.Equal(t, test.slot, slot)
+			}
+		})
+	}
+}
@@ -0,0 +1,39 @@
+// Copyright © 2022 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cache
+
+import (
+	"context"
+)
+
+// Entry is an entry in the cache.
+type Entry struct {
+	Key  []byte
+	Size int64
+}
+
+// Service provides a persistent key/value cache.
+type Service interface {
+	// Fetch fetches the value for the given key.
+	// It returns false if the key is not present.
+	Fetch(ctx context.Context, key []byte) ([]byte, bool, error)
+	// Store stores the value for the given key.
+	Store(ctx context.Context, key []byte, value []byte) error
+	// Entries returns the entries whose keys start with the given prefix.
+	Entries(ctx context.Context, prefix []byte) ([]*Entry, error)
+	// Delete deletes the entries with the given keys.
+	Delete(ctx context.Context, keys [][]byte) error
+	// Close closes the cache.
+	Close(ctx context.Context) error
+}
@@ -0,0 +1,132 @@
+// Copyright © 2022 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deposithistory
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+)
+
+// Deposit is a deposit made to the deposit contract.
+type Deposit struct {
+	// Index is the index of the deposit in the deposit contract.
+	Index                 uint64
+	PublicKey             phase0.BLSPubKey
+	WithdrawalCredentials []byte
+	Amount                phase0.Gwei
+	Signature             phase0.BLSSignature
+	// BlockNumber is the number of the execution block containing the deposit, if known.
+	BlockNumber uint64
+}
+
+// depositJSON is the spec representation of the struct.
+type depositJSON struct {
+	Index                 string `json:"index"`
+	PublicKey             string `json:"pubkey"`
+	WithdrawalCredentials string `json:"withdrawal_credentials"`
+	Amount                string `json:"amount"`
+	Signature             string `json:"signature"`
+	BlockNumber           string `json:"block_number,omitempty"`
+}
+
+// MarshalJSON implements json.Marshaler.
+func (d *Deposit) MarshalJSON() ([]byte, error) {
+	data := &depositJSON{
+		Index:                 fmt.Sprintf("%d", d.Index),
+		PublicKey:             fmt.Sprintf("%#x", d.PublicKey),
+		WithdrawalCredentials: fmt.Sprintf("%#x", d.WithdrawalCredentials),
+		Amount:                fmt.Sprintf("%d", d.Amount),
+		Signature:             fmt.Sprintf("%#x", d.Signature),
+	}
+	if d.BlockNumber != 0 {
+		data.BlockNumber = fmt.Sprintf("%d", d.BlockNumber)
+	}
+
+	return json.Marshal(data)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (d *Deposit) UnmarshalJSON(input []byte) error {
+	var data depositJSON
+	if err := json.Unmarshal(input, &data); err != nil {
+		return errors.Wrap(err, "invalid JSON")
+	}
+
+	var err error
+	if data.Index == "" {
+		return errors.New("index missing")
+	}
+	d.Index, err = strconv.ParseUint(data.Index, 10, 64)
+	if err != nil {
+		return errors.Wrap(err, "invalid value for index")
+	}
+
+	if data.PublicKey == "" {
+		return errors.New("public key missing")
+	}
+	pubKey, err := hex.DecodeString(strings.TrimPrefix(data.PublicKey, "0x"))
+	if err != nil {
+		return errors.Wrap(err, "invalid value for public key")
+	}
+	if len(pubKey) != phase0.PublicKeyLength {
+		return errors.New("incorrect length for public key")
+	}
+	copy(d.PublicKey[:], pubKey)
+
+	if data.WithdrawalCredentials == "" {
+		return errors.New("withdrawal credentials missing")
+	}
+	d.WithdrawalCredentials, err = hex.DecodeString(strings.TrimPrefix(data.WithdrawalCredentials, "0x"))
+	if err != nil {
+		return errors.Wrap(err, "invalid value for withdrawal credentials")
+	}
+	if len(d.WithdrawalCredentials) != 32 {
+		return errors.New("incorrect length for withdrawal credentials")
+	}
+
+	if data.Amount == "" {
+		return errors.New("amount missing")
+	}
+	amount, err := strconv.ParseUint(data.Amount, 10, 64)
+	if err != nil {
+		return errors.Wrap(err, "invalid value for amount")
+	}
+	d.Amount = phase0.Gwei(amount)
+
+	if data.Signature != "" {
+		signature, err := hex.DecodeString(strings.TrimPrefix(data.Signature, "0x"))
+		if err != nil {
+			return errors.Wrap(err, "invalid value for signature")
+		}
+		if len(signature) != phase0.SignatureLength {
+			return errors.New("incorrect length for signature")
+		}
+		copy(d.Signature[:], signature)
+	}
+
+	if data.BlockNumber != "" {
+		d.BlockNumber, err = strconv.ParseUint(data.BlockNumber, 10, 64)
+		if err != nil {
+			return errors.Wrap(err, "invalid value for block number")
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,83 @@
+// Copyright © 2022 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package file
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"sort"
+
+	"github.com/aaron-alderman/ethdo/services/deposithistory"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
+	zerologger "github.com/rs/zerolog/log"
+)
+
+// Service provides deposit history from a local deposit log file.
+// The file contains a JSON array of deposits.
+type Service struct {
+	deposits map[phase0.BLSPubKey][]*deposithistory.Deposit
+}
+
+// module-wide log.
+var log zerolog.Logger
+
+// New creates a new deposit history service backed by a file.
+func New(_ context.Context, params ...Parameter) (*Service, error) {
+	parameters, err := parseAndCheckParameters(params...)
+	if err != nil {
+		return nil, errors.Wrap(err, "problem with parameters")
+	}
+
+	// Set logging.
+	log = zerologger.With().Str("service", "deposithistory").Str("impl", "file").Logger().Level(parameters.logLevel)
+
+	data, err := ioutil.ReadFile(parameters.path)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read deposit log file")
+	}
+	deposits := make([]*deposithistory.Deposit, 0)
+	if err := json.Unmarshal(data, &deposits); err != nil {
+		return nil, errors.Wrap(err, "failed to parse deposit log file")
+	}
+	log.Trace().Str("path", parameters.path).Int("deposits", len(deposits)).Msg("Read deposit log file")
+
+	s := &Service{
+		deposits: make(map[phase0.BLSPubKey][]*deposithistory.Deposit),
+	}
+	for _, deposit := range deposits {
+		s.deposits[deposit.PublicKey] = append(s.deposits[deposit.PublicKey], deposit)
+	}
+	for _, validatorDeposits := range s.deposits {
+		sort.Slice(validatorDeposits, func(i int, j int) bool {
+			return validatorDeposits[i].Index < validatorDeposits[j].Index
+		})
+	}
+
+	return s, nil
+}
+
+// Deposits returns the deposits made for the given validator public keys.
+func (s *Service) Deposits(_ context.Context, pubKeys []phase0.BLSPubKey) (map[phase0.BLSPubKey][]*deposithistory.Deposit, error) {
+	res := make(map[phase0.BLSPubKey][]*deposithistory.Deposit)
+	for _, pubKey := range pubKeys {
+		if deposits, exists := s.deposits[pubKey]; exists {
+			res[pubKey] = deposits
+		}
+	}
+
+	return res, nil
+}
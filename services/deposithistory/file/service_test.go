@@ -0,0 +1,86 @@
+// Copyright © 2022 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package file_test
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/aaron-alderman/ethdo/services/deposithistory/file"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNew(t *testing.T) {
+	ctx := context.Background()
+
+	baseDir, err := ioutil.TempDir("", "")
+	require.NoError(t, err)
+	defer os.RemoveAll(baseDir)
+
+	_, err = file.New(ctx, file.WithLogLevel(zerolog.Disabled))
+	require.EqualError(t, err, "problem with parameters: no path specified")
+
+	_, err = file.New(ctx,
+		file.WithLogLevel(zerolog.Disabled),
+		file.WithPath(filepath.Join(baseDir, "missing.json")),
+	)
+	require.Contains(t, err.Error(), "failed to read deposit log file")
+
+	path := filepath.Join(baseDir, "bad.json")
+	require.NoError(t, ioutil.WriteFile(path, []byte(`[{"index":"0"}]`), 0600))
+	_, err = file.New(ctx,
+		file.WithLogLevel(zerolog.Disabled),
+		file.WithPath(path),
+	)
+	require.EqualError(t, err, "failed to parse deposit log file: public key missing")
+}
+
+func TestDeposits(t *testing.T) {
+	ctx := context.Background()
+
+	baseDir, err := ioutil.TempDir("", "")
+	require.NoError(t, err)
+	defer os.RemoveAll(baseDir)
+
+	path := filepath.Join(baseDir, "deposits.json")
+	require.NoError(t, ioutil.WriteFile(path, []byte(`[
+{"index":"5","pubkey":"0xa99a76ed7796f7be22d5b7e85deeb7c5677e88e511e0b337618f8c4eb61349b4bf2d153f649f7b53359fe8b94a38e44c","withdrawal_credentials":"0x0100000000000000000000000000000000000000000000000000000000000001","amount":"1000000000"},
+{"index":"2","pubkey":"0xa99a76ed7796f7be22d5b7e85deeb7c5677e88e511e0b337618f8c4eb61349b4bf2d153f649f7b53359fe8b94a38e44c","withdrawal_credentials":"0x007e28dcf9029e8d92ca4b5d01c66c934e7f3110606f34ae3052cbf67bd3fc02","amount":"32000000000"},
+{"index":"3","pubkey":"0xb89bebc699769726a318c8e9971bd3171297c61aea4a6578a7a4f94b547dcba5bac16a89108b6b6a1fe3695d1a874a0b","withdrawal_credentials":"0x00ec7ef7780c9d151597924036262dd28dc60e1228f4da6fecf9d402cb3f3594","amount":"32000000000","block_number":"100"}
+]`), 0600))
+
+	s, err := file.New(ctx,
+		file.WithLogLevel(zerolog.Disabled),
+		file.WithPath(path),
+	)
+	require.NoError(t, err)
+
+	pubKey0 := phase0.BLSPubKey{0xa9, 0x9a, 0x76, 0xed, 0x77, 0x96, 0xf7, 0xbe, 0x22, 0xd5, 0xb7, 0xe8, 0x5d, 0xee, 0xb7, 0xc5, 0x67, 0x7e, 0x88, 0xe5, 0x11, 0xe0, 0xb3, 0x37, 0x61, 0x8f, 0x8c, 0x4e, 0xb6, 0x13, 0x49, 0xb4, 0xbf, 0x2d, 0x15, 0x3f, 0x64, 0x9f, 0x7b, 0x53, 0x35, 0x9f, 0xe8, 0xb9, 0x4a, 0x38, 0xe4, 0x4c}
+	unknown := phase0.BLSPubKey{0x01}
+
+	deposits, err := s.Deposits(ctx, []phase0.BLSPubKey{pubKey0, unknown})
+	require.NoError(t, err)
+	require.Len(t, deposits, 1)
+	require.Len(t, deposits[pubKey0], 2)
+	// Deposits are ordered by index.
+	require.Equal(t, uint64(2), deposits[pubKey0][0].Index)
+	require.Equal(t, phase0.Gwei(32000000000), deposits[pubKey0][0].Amount)
+	require.Equal(t, uint64(5), deposits[pubKey0][1].Index)
+	require.Equal(t, phase0.Gwei(1000000000), deposits[pubKey0][1].Amount)
+}
@@ -0,0 +1,113 @@
+// Copyright © 2022 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jsonrpc
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
+)
+
+type parameters struct {
+	logLevel         zerolog.Level
+	address          string
+	timeout          time.Duration
+	depositContract  []byte
+	startBlock       uint64
+	blocksPerRequest uint64
+}
+
+// Parameter is the interface for service parameters.
+type Parameter interface {
+	apply(*parameters)
+}
+
+type parameterFunc func(*parameters)
+
+func (f parameterFunc) apply(p *parameters) {
+	f(p)
+}
+
+// WithLogLevel sets the log level for the module.
+func WithLogLevel(logLevel zerolog.Level) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.logLevel = logLevel
+	})
+}
+
+// WithAddress sets the address of the execution node JSON-RPC endpoint.
+func WithAddress(address string) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.address = address
+	})
+}
+
+// WithTimeout sets the timeout for requests to the execution node.
+func WithTimeout(timeout time.Duration) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.timeout = timeout
+	})
+}
+
+// WithDepositContract sets the address of the deposit contract.
+func WithDepositContract(address []byte) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.depositContract = address
+	})
+}
+
+// WithStartBlock sets the block from which to search for deposits, usually the block
+// in which the deposit contract was deployed.
+func WithStartBlock(block uint64) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.startBlock = block
+	})
+}
+
+// WithBlocksPerRequest sets the number of blocks searched for deposits in each request.
+func WithBlocksPerRequest(blocks uint64) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.blocksPerRequest = blocks
+	})
+}
+
+// parseAndCheckParameters parses and checks parameters to ensure that mandatory parameters are present and correct.
+func parseAndCheckParameters(params ...Parameter) (*parameters, error) {
+	parameters := parameters{
+		logLevel:         zerolog.GlobalLevel(),
+		timeout:          30 * time.Second,
+		blocksPerRequest: 10000,
+	}
+	for _, p := range params {
+		if params != nil {
+			p.apply(&parameters)
+		}
+	}
+
+	if parameters.address == "" {
+		return nil, errors.New("no address specified")
+	}
+	if parameters.timeout == 0 {
+		return nil, errors.New("no timeout specified")
+	}
+	if len(parameters.depositContract) != 20 {
+		return nil, errors.New("deposit contract address must be 20 bytes")
+	}
+	if parameters.blocksPerRequest == 0 {
+		return nil, errors.New("blocks per request must be at least 1")
+	}
+
+	return &parameters, nil
+}
@@ -0,0 +1,292 @@
+// Copyright © 2022 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jsonrpc
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/aaron-alderman/ethdo/services/deposithistory"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
+	zerologger "github.com/rs/zerolog/log"
+	util "github.com/wealdtech/go-eth2-util"
+)
+
+// depositEventTopic is the topic of the DepositEvent log emitted by the deposit contract.
+var depositEventTopic = fmt.Sprintf("%#x", util.Keccak256([]byte("DepositEvent(bytes,bytes,bytes,bytes,bytes)")))
+
+// Service provides deposit history from the DepositEvent logs of an execution node.
+type Service struct {
+	address          string
+	client           *http.Client
+	depositContract  []byte
+	startBlock       uint64
+	blocksPerRequest uint64
+}
+
+// module-wide log.
+var log zerolog.Logger
+
+// New creates a new deposit history service backed by an execution node.
+func New(_ context.Context, params ...Parameter) (*Service, error) {
+	parameters, err := parseAndCheckParameters(params...)
+	if err != nil {
+		return nil, errors.Wrap(err, "problem with parameters")
+	}
+
+	// Set logging.
+	log = zerologger.With().Str("service", "deposithistory").Str("impl", "jsonrpc").Logger().Level(parameters.logLevel)
+
+	address := parameters.address
+	if !strings.HasPrefix(address, "http") {
+		address = fmt.Sprintf("http://%s", address)
+	}
+
+	return &Service{
+		address: address,
+		client: &http.Client{
+			Timeout: parameters.timeout,
+		},
+		depositContract:  parameters.depositContract,
+		startBlock:       parameters.startBlock,
+		blocksPerRequest: parameters.blocksPerRequest,
+	}, nil
+}
+
+// Deposits returns the deposits made for the given validator public keys.
+// Deposit logs are not indexed by public key, so this searches all logs of the deposit contract.
+func (s *Service) Deposits(ctx context.Context, pubKeys []phase0.BLSPubKey) (map[phase0.BLSPubKey][]*deposithistory.Deposit, error) {
+	required := make(map[phase0.BLSPubKey]bool, len(pubKeys))
+	for _, pubKey := range pubKeys {
+		required[pubKey] = true
+	}
+
+	latestBlock, err := s.blockNumber(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make(map[phase0.BLSPubKey][]*deposithistory.Deposit)
+	for from := s.startBlock; from <= latestBlock; from += s.blocksPerRequest {
+		to := from + s.blocksPerRequest - 1
+		if to > latestBlock {
+			to = latestBlock
+		}
+		log.Trace().Uint64("from", from).Uint64("to", to).Msg("Fetching deposit logs")
+		logs, err := s.depositLogs(ctx, from, to)
+		if err != nil {
+			return nil, err
+		}
+		for _, depositLog := range logs {
+			if depositLog.Removed {
+				continue
+			}
+			deposit, err := parseDepositEvent(depositLog.Data)
+			if err != nil {
+				return nil, errors.Wrap(err, fmt.Sprintf("invalid deposit log in block %s", depositLog.BlockNumber))
+			}
+			if !required[deposit.PublicKey] {
+				continue
+			}
+			deposit.BlockNumber, err = strconv.ParseUint(strings.TrimPrefix(depositLog.BlockNumber, "0x"), 16, 64)
+			if err != nil {
+				return nil, errors.Wrap(err, "invalid block number for deposit log")
+			}
+			res[deposit.PublicKey] = append(res[deposit.PublicKey], deposit)
+		}
+	}
+
+	return res, nil
+}
+
+type logJSON struct {
+	Data        string `json:"data"`
+	BlockNumber string `json:"blockNumber"`
+	Removed     bool   `json:"removed"`
+}
+
+// blockNumber returns the number of the latest block.
+func (s *Service) blockNumber(ctx context.Context) (uint64, error) {
+	var result string
+	if err := s.call(ctx, "eth_blockNumber", []interface{}{}, &result); err != nil {
+		return 0, errors.Wrap(err, "failed to obtain latest block number")
+	}
+	blockNumber, err := strconv.ParseUint(strings.TrimPrefix(result, "0x"), 16, 64)
+	if err != nil {
+		return 0, errors.Wrap(err, "invalid latest block number")
+	}
+
+	return blockNumber, nil
+}
+
+// depositLogs returns the deposit logs between the given blocks, inclusive.
+func (s *Service) depositLogs(ctx context.Context, from uint64, to uint64) ([]*logJSON, error) {
+	filter := map[string]interface{}{
+		"fromBlock": fmt.Sprintf("0x%x", from),
+		"toBlock":   fmt.Sprintf("0x%x", to),
+		"address":   fmt.Sprintf("%#x", s.depositContract),
+		"topics":    []string{depositEventTopic},
+	}
+	logs := make([]*logJSON, 0)
+	if err := s.call(ctx, "eth_getLogs", []interface{}{filter}, &logs); err != nil {
+		return nil, errors.Wrap(err, fmt.Sprintf("failed to obtain deposit logs for blocks %d to %d", from, to))
+	}
+
+	return logs, nil
+}
+
+type requestJSON struct {
+	JSONRPC string        `json:"jsonrpc"`
+	ID      int           `json:"id"`
+	Method  string        `json:"method"`
+	Params  []interface{} `json:"params"`
+}
+
+type responseJSON struct {
+	Result json.RawMessage `json:"result"`
+	Error  *struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+// call makes a JSON-RPC call to the execution node.
+func (s *Service) call(ctx context.Context, method string, params []interface{}, result interface{}) error {
+	body, err := json.Marshal(&requestJSON{
+		JSONRPC: "2.0",
+		ID:      1,
+		Method:  method,
+		Params:  params,
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to encode request")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.address, bytes.NewReader(body))
+	if err != nil {
+		return errors.Wrap(err, "failed to create request")
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "failed to call execution node")
+	}
+	defer resp.Body.Close()
+	respBody, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return errors.Wrap(err, "failed to read response")
+	}
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("execution node returned status %d: %s", resp.StatusCode, string(respBody))
+	}
+
+	var response responseJSON
+	if err := json.Unmarshal(respBody, &response); err != nil {
+		return errors.Wrap(err, "invalid response")
+	}
+	if response.Error != nil {
+		return fmt.Errorf("execution node returned error %d: %s", response.Error.Code, response.Error.Message)
+	}
+	if err := json.Unmarshal(response.Result, result); err != nil {
+		return errors.Wrap(err, "invalid result")
+	}
+
+	return nil
+}
+
+// parseDepositEvent parses the ABI-encoded data of a DepositEvent log, which contains
+// the public key, withdrawal credentials, amount, signature and index as dynamic byte arrays.
+func parseDepositEvent(input string) (*deposithistory.Deposit, error) {
+	data, err := hex.DecodeString(strings.TrimPrefix(input, "0x"))
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid data")
+	}
+
+	fields := make([][]byte, 5)
+	for i := range fields {
+		fields[i], err = abiBytes(data, i)
+		if err != nil {
+			return nil, errors.Wrap(err, fmt.Sprintf("invalid field %d", i))
+		}
+	}
+	if len(fields[0]) != phase0.PublicKeyLength {
+		return nil, errors.New("incorrect length for public key")
+	}
+	if len(fields[1]) != 32 {
+		return nil, errors.New("incorrect length for withdrawal credentials")
+	}
+	if len(fields[2]) != 8 {
+		return nil, errors.New("incorrect length for amount")
+	}
+	if len(fields[3]) != phase0.SignatureLength {
+		return nil, errors.New("incorrect length for signature")
+	}
+	if len(fields[4]) != 8 {
+		return nil, errors.New("incorrect length for index")
+	}
+
+	deposit := &deposithistory.Deposit{
+		WithdrawalCredentials: fields[1],
+		// The deposit contract encodes amount and index as little-endian.
+		Amount: phase0.Gwei(binary.LittleEndian.Uint64(fields[2])),
+		Index:  binary.LittleEndian.Uint64(fields[4]),
+	}
+	copy(deposit.PublicKey[:], fields[0])
+	copy(deposit.Signature[:], fields[3])
+
+	return deposit, nil
+}
+
+// abiBytes returns the dynamic byte array at the given position of ABI-encoded data.
+func abiBytes(data []byte, position int) ([]byte, error) {
+	offset, err := abiUint(data, position*32)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid offset")
+	}
+	length, err := abiUint(data, offset)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid length")
+	}
+	start := offset + 32
+	if start+length > len(data) {
+		return nil, errors.New("data too short")
+	}
+
+	return data[start : start+length], nil
+}
+
+// abiUint returns the 32-byte unsigned integer at the given offset of ABI-encoded data.
+func abiUint(data []byte, offset int) (int, error) {
+	if offset < 0 || offset+32 > len(data) {
+		return 0, errors.New("data too short")
+	}
+	value := new(big.Int).SetBytes(data[offset : offset+32])
+	if !value.IsInt64() || value.Int64() > int64(len(data)) {
+		return 0, errors.New("value out of range")
+	}
+
+	return int(value.Int64()), nil
+}
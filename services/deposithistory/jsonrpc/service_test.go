@@ -0,0 +1,183 @@
+// Copyright © 2022 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jsonrpc_test
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/aaron-alderman/ethdo/services/deposithistory/jsonrpc"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+)
+
+var depositContract = []byte{0x00, 0x00, 0x00, 0x00, 0x21, 0x9a, 0xb5, 0x40, 0x35, 0x6c, 0xbb, 0x83, 0x9c, 0xbe, 0x05, 0x30, 0x3d, 0x77, 0x05, 0xfa}
+
+// depositEventData returns the ABI encoding of a DepositEvent log.
+func depositEventData(pubKey phase0.BLSPubKey, withdrawalCredentials []byte, amount uint64, index uint64) string {
+	amountBytes := make([]byte, 8)
+	binary.LittleEndian.PutUint64(amountBytes, amount)
+	indexBytes := make([]byte, 8)
+	binary.LittleEndian.PutUint64(indexBytes, index)
+	fields := [][]byte{pubKey[:], withdrawalCredentials, amountBytes, make([]byte, phase0.SignatureLength), indexBytes}
+
+	word := func(value int) []byte {
+		res := make([]byte, 32)
+		big.NewInt(int64(value)).FillBytes(res)
+		return res
+	}
+
+	head := make([]byte, 0)
+	tail := make([]byte, 0)
+	for _, field := range fields {
+		head = append(head, word(len(fields)*32+len(tail))...)
+		tail = append(tail, word(len(field))...)
+		padded := make([]byte, (len(field)+31)/32*32)
+		copy(padded, field)
+		tail = append(tail, padded...)
+	}
+
+	return fmt.Sprintf("%#x", append(head, tail...))
+}
+
+func TestNew(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name   string
+		params []jsonrpc.Parameter
+		err    string
+	}{
+		{
+			name: "AddressMissing",
+			params: []jsonrpc.Parameter{
+				jsonrpc.WithLogLevel(zerolog.Disabled),
+				jsonrpc.WithDepositContract(depositContract),
+			},
+			err: "problem with parameters: no address specified",
+		},
+		{
+			name: "DepositContractInvalid",
+			params: []jsonrpc.Parameter{
+				jsonrpc.WithLogLevel(zerolog.Disabled),
+				jsonrpc.WithAddress("localhost:8545"),
+				jsonrpc.WithDepositContract([]byte{0x01}),
+			},
+			err: "problem with parameters: deposit contract address must be 20 bytes",
+		},
+		{
+			name: "BlocksPerRequestZero",
+			params: []jsonrpc.Parameter{
+				jsonrpc.WithLogLevel(zerolog.Disabled),
+				jsonrpc.WithAddress("localhost:8545"),
+				jsonrpc.WithDepositContract(depositContract),
+				jsonrpc.WithBlocksPerRequest(0),
+			},
+			err: "problem with parameters: blocks per request must be at least 1",
+		},
+		{
+			name: "Good",
+			params: []jsonrpc.Parameter{
+				jsonrpc.WithLogLevel(zerolog.Disabled),
+				jsonrpc.WithAddress("localhost:8545"),
+				jsonrpc.WithDepositContract(depositContract),
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := jsonrpc.New(ctx, test.params...)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestDeposits(t *testing.T) {
+	ctx := context.Background()
+
+	pubKey0 := phase0.BLSPubKey{0x01}
+	pubKey1 := phase0.BLSPubKey{0x02}
+	credentials0 := make([]byte, 32)
+	credentials1 := make([]byte, 32)
+	credentials1[0] = 0x01
+
+	// Logs by starting block of the request.
+	logs := map[string][]map[string]interface{}{
+		"0x10": {
+			{"blockNumber": "0x10", "data": depositEventData(pubKey0, credentials0, 32000000000, 0)},
+			{"blockNumber": "0x11", "data": depositEventData(pubKey1, credentials0, 32000000000, 1)},
+		},
+		"0x12": {
+			{"blockNumber": "0x12", "data": depositEventData(pubKey0, credentials1, 1000000000, 2)},
+			{"blockNumber": "0x12", "data": depositEventData(pubKey0, credentials1, 1000000000, 3), "removed": true},
+		},
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Method string                   `json:"method"`
+			Params []map[string]interface{} `json:"params"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		var result interface{}
+		switch req.Method {
+		case "eth_blockNumber":
+			result = "0x13"
+		case "eth_getLogs":
+			require.Equal(t, fmt.Sprintf("%#x", depositContract), req.Params[0]["address"])
+			result = logs[req.Params[0]["fromBlock"].(string)]
+			if result == nil {
+				result = []interface{}{}
+			}
+		}
+		require.NoError(t, json.NewEncoder(w).Encode(map[string]interface{}{"jsonrpc": "2.0", "id": 1, "result": result}))
+	}))
+	defer server.Close()
+
+	s, err := jsonrpc.New(ctx,
+		jsonrpc.WithLogLevel(zerolog.Disabled),
+		jsonrpc.WithAddress(server.URL),
+		jsonrpc.WithTimeout(5*time.Second),
+		jsonrpc.WithDepositContract(depositContract),
+		jsonrpc.WithStartBlock(0x10),
+		jsonrpc.WithBlocksPerRequest(2),
+	)
+	require.NoError(t, err)
+
+	deposits, err := s.Deposits(ctx, []phase0.BLSPubKey{pubKey0})
+	require.NoError(t, err)
+	require.Len(t, deposits, 1)
+	require.Len(t, deposits[pubKey0], 2)
+	require.Equal(t, uint64(0), deposits[pubKey0][0].Index)
+	require.Equal(t, uint64(0x10), deposits[pubKey0][0].BlockNumber)
+	require.Equal(t, phase0.Gwei(32000000000), deposits[pubKey0][0].Amount)
+	require.Equal(t, credentials0, deposits[pubKey0][0].WithdrawalCredentials)
+	require.Equal(t, uint64(2), deposits[pubKey0][1].Index)
+	require.Equal(t, uint64(0x12), deposits[pubKey0][1].BlockNumber)
+	require.Equal(t, phase0.Gwei(1000000000), deposits[pubKey0][1].Amount)
+	require.Equal(t, credentials1, deposits[pubKey0][1].WithdrawalCredentials)
+}
@@ -0,0 +1,141 @@
+// Copyright © 2022 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package thegraph
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/aaron-alderman/ethdo/services/deposithistory"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
+	zerologger "github.com/rs/zerolog/log"
+)
+
+// Service provides deposit history from the deposit subgraphs of The Graph.
+type Service struct {
+	url    string
+	client *http.Client
+}
+
+// module-wide log.
+var log zerolog.Logger
+
+// New creates a new deposit history service backed by The Graph.
+func New(_ context.Context, params ...Parameter) (*Service, error) {
+	parameters, err := parseAndCheckParameters(params...)
+	if err != nil {
+		return nil, errors.Wrap(err, "problem with parameters")
+	}
+
+	// Set logging.
+	log = zerologger.With().Str("service", "deposithistory").Str("impl", "thegraph").Logger().Level(parameters.logLevel)
+
+	subgraph := "attestantio/eth2deposits"
+	if !strings.EqualFold(parameters.network, "mainnet") {
+		subgraph = fmt.Sprintf("attestantio/eth2deposits-%s", strings.ToLower(parameters.network))
+	}
+
+	return &Service{
+		url: fmt.Sprintf("https://api.thegraph.com/subgraphs/name/%s", subgraph),
+		client: &http.Client{
+			Timeout: parameters.timeout,
+		},
+	}, nil
+}
+
+type graphDeposit struct {
+	Amount                string `json:"amount"`
+	WithdrawalCredentials string `json:"withdrawalCredentials"`
+}
+
+type graphData struct {
+	Deposits []*graphDeposit `json:"deposits,omitempty"`
+}
+
+type graphResponse struct {
+	Data *graphData `json:"data,omitempty"`
+}
+
+// Deposits returns the deposits made for the given validator public keys.
+func (s *Service) Deposits(ctx context.Context, pubKeys []phase0.BLSPubKey) (map[phase0.BLSPubKey][]*deposithistory.Deposit, error) {
+	res := make(map[phase0.BLSPubKey][]*deposithistory.Deposit)
+	for _, pubKey := range pubKeys {
+		deposits, err := s.deposits(ctx, pubKey)
+		if err != nil {
+			return nil, err
+		}
+		if len(deposits) > 0 {
+			res[pubKey] = deposits
+		}
+	}
+
+	return res, nil
+}
+
+func (s *Service) deposits(ctx context.Context, pubKey phase0.BLSPubKey) ([]*deposithistory.Deposit, error) {
+	query := fmt.Sprintf(`{"query": "{deposits(where: {validatorPubKey:\"%#x\"}) { id amount withdrawalCredentials }}"}`, pubKey)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewBufferString(query))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create request")
+	}
+	req.Header.Set("Content-Type", "application/json")
+	log.Trace().Str("url", s.url).Str("query", query).Msg("Querying graph")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to query graph")
+	}
+	defer resp.Body.Close()
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, errors.Wrap(err, "bad information returned from graph")
+	}
+
+	var response graphResponse
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, errors.Wrap(err, "invalid data returned from graph")
+	}
+	if response.Data == nil {
+		return nil, nil
+	}
+
+	deposits := make([]*deposithistory.Deposit, 0, len(response.Data.Deposits))
+	for _, graphDeposit := range response.Data.Deposits {
+		// The graph does not provide the index of the deposit.
+		deposit := &deposithistory.Deposit{
+			PublicKey: pubKey,
+		}
+		amount, err := strconv.ParseUint(graphDeposit.Amount, 10, 64)
+		if err != nil {
+			return nil, errors.Wrap(err, fmt.Sprintf("invalid deposit amount %s", graphDeposit.Amount))
+		}
+		deposit.Amount = phase0.Gwei(amount)
+		deposit.WithdrawalCredentials, err = hex.DecodeString(strings.TrimPrefix(graphDeposit.WithdrawalCredentials, "0x"))
+		if err != nil {
+			return nil, errors.Wrap(err, fmt.Sprintf("invalid deposit withdrawal credentials %s", graphDeposit.WithdrawalCredentials))
+		}
+		deposits = append(deposits, deposit)
+	}
+
+	return deposits, nil
+}
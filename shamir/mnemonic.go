@@ -0,0 +1,157 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package shamir
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/tyler-smith/go-bip39"
+)
+
+const (
+	// mnemonicVersion is the version of the mnemonic share encoding.
+	mnemonicVersion = 1
+	// mnemonicChecksumLen is the number of bytes of checksum in a mnemonic share.
+	mnemonicChecksumLen = 4
+	// mnemonicHeaderLen is the number of bytes of version, index and threshold in a mnemonic share.
+	mnemonicHeaderLen = 3
+	// bitsPerWord is the number of bits encoded by each word of a mnemonic share.
+	bitsPerWord = 11
+)
+
+// ShareToMnemonic encodes a share, as returned by Split, as a list of words from
+// the BIP-39 English word list.  The encoding contains the share index and the
+// threshold required to reconstruct the secret, along with a checksum to allow
+// errors in the share to be detected.
+func ShareToMnemonic(share []byte, threshold int) (string, error) {
+	if len(share) < 2 {
+		return "", fmt.Errorf("share must be at least two bytes")
+	}
+	if threshold < 2 || threshold > 255 {
+		return "", fmt.Errorf("threshold must be between 2 and 255")
+	}
+
+	// The index of the share is held in the final byte; move it to the header so that it is readily visible.
+	data := make([]byte, 0, mnemonicHeaderLen+len(share)-1+mnemonicChecksumLen)
+	data = append(data, mnemonicVersion, share[len(share)-1], uint8(threshold))
+	data = append(data, share[:len(share)-1]...)
+	checksum := sha256.Sum256(data)
+	data = append(data, checksum[:mnemonicChecksumLen]...)
+
+	wordList := bip39.GetWordList()
+	words := make([]string, 0, (len(data)*8+bitsPerWord-1)/bitsPerWord)
+	acc := 0
+	accBits := 0
+	for _, b := range data {
+		acc = acc<<8 | int(b)
+		accBits += 8
+		for accBits >= bitsPerWord {
+			accBits -= bitsPerWord
+			words = append(words, wordList[(acc>>accBits)&0x7ff])
+		}
+	}
+	if accBits > 0 {
+		words = append(words, wordList[(acc<<(bitsPerWord-accBits))&0x7ff])
+	}
+
+	return strings.Join(words, " "), nil
+}
+
+// MnemonicToShare decodes a share encoded by ShareToMnemonic, returning the share
+// in the form required by Combine along with the threshold required to reconstruct
+// the secret.  An error is returned if the share is malformed or its checksum does
+// not match.
+func MnemonicToShare(mnemonic string) ([]byte, int, error) {
+	words := strings.Fields(strings.ToLower(mnemonic))
+	if len(words) == 0 {
+		return nil, 0, fmt.Errorf("no words supplied")
+	}
+
+	data := make([]byte, 0, len(words)*bitsPerWord/8)
+	acc := 0
+	accBits := 0
+	for i, word := range words {
+		index, exists := bip39.GetWordIndex(word)
+		if !exists {
+			return nil, 0, fmt.Errorf("unknown word %q at position %d", word, i+1)
+		}
+		acc = acc<<bitsPerWord | index
+		accBits += bitsPerWord
+		for accBits >= 8 {
+			accBits -= 8
+			data = append(data, uint8(acc>>accBits))
+		}
+		acc &= (1 << accBits) - 1
+	}
+	if acc != 0 {
+		return nil, 0, fmt.Errorf("invalid padding")
+	}
+
+	// The final word can contain enough padding to produce a trailing zero byte,
+	// in which case the checksum decides if it is part of the data.
+	if !validMnemonicChecksum(data) {
+		if len(data) == 0 || data[len(data)-1] != 0 || ((len(data)-1)*8+bitsPerWord-1)/bitsPerWord != len(words) {
+			return nil, 0, fmt.Errorf("checksum mismatch")
+		}
+		data = data[:len(data)-1]
+		if !validMnemonicChecksum(data) {
+			return nil, 0, fmt.Errorf("checksum mismatch")
+		}
+	}
+	if data[0] != mnemonicVersion {
+		return nil, 0, fmt.Errorf("unsupported version %d", data[0])
+	}
+
+	index := data[1]
+	threshold := int(data[2])
+	share := make([]byte, 0, len(data)-mnemonicHeaderLen-mnemonicChecksumLen+1)
+	share = append(share, data[mnemonicHeaderLen:len(data)-mnemonicChecksumLen]...)
+	share = append(share, index)
+
+	return share, threshold, nil
+}
+
+// DecodeShare decodes a share supplied either as a hex string or as words generated
+// by ShareToMnemonic.  The threshold is returned for shares supplied as words, and is
+// 0 for shares supplied as hex.
+func DecodeShare(input string) ([]byte, int, error) {
+	input = strings.TrimSpace(input)
+	if strings.ContainsAny(input, " \t\n") {
+		return MnemonicToShare(input)
+	}
+
+	share, err := hex.DecodeString(strings.TrimPrefix(input, "0x"))
+	if err != nil {
+		return nil, 0, fmt.Errorf("invalid hex: %w", err)
+	}
+	if len(share) < 2 {
+		return nil, 0, fmt.Errorf("share must be at least two bytes")
+	}
+
+	return share, 0, nil
+}
+
+// validMnemonicChecksum returns true if the data is long enough to be a share and its checksum matches.
+func validMnemonicChecksum(data []byte) bool {
+	if len(data) < mnemonicHeaderLen+1+mnemonicChecksumLen {
+		return false
+	}
+	checksum := sha256.Sum256(data[:len(data)-mnemonicChecksumLen])
+
+	return bytes.Equal(checksum[:mnemonicChecksumLen], data[len(data)-mnemonicChecksumLen:])
+}
@@ -0,0 +1,137 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package shamir
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestMnemonic_roundtrip(t *testing.T) {
+	for secretLen := 1; secretLen <= 80; secretLen++ {
+		secret := bytes.Repeat([]byte{0x5a}, secretLen)
+		shares, err := Split(secret, 5, 3)
+		if err != nil {
+			t.Fatalf("err: %v", err)
+		}
+
+		decoded := make([][]byte, 0, 3)
+		for _, share := range shares[:3] {
+			mnemonic, err := ShareToMnemonic(share, 3)
+			if err != nil {
+				t.Fatalf("err: %v", err)
+			}
+			res, threshold, err := MnemonicToShare(mnemonic)
+			if err != nil {
+				t.Fatalf("secret length %d: err: %v", secretLen, err)
+			}
+			if threshold != 3 {
+				t.Fatalf("bad threshold: %d", threshold)
+			}
+			if !bytes.Equal(res, share) {
+				t.Fatalf("secret length %d: bad share: %x != %x", secretLen, res, share)
+			}
+			decoded = append(decoded, res)
+		}
+
+		recomb, err := Combine(decoded)
+		if err != nil {
+			t.Fatalf("err: %v", err)
+		}
+		if !bytes.Equal(recomb, secret) {
+			t.Fatalf("bad: %v %v", recomb, secret)
+		}
+	}
+}
+
+func TestMnemonic_zeroShare(t *testing.T) {
+	// Shares ending in zero bytes exercise the handling of padding.
+	for shareLen := 2; shareLen <= 20; shareLen++ {
+		share := make([]byte, shareLen)
+		share[shareLen-1] = 1
+		mnemonic, err := ShareToMnemonic(share, 2)
+		if err != nil {
+			t.Fatalf("err: %v", err)
+		}
+		res, _, err := MnemonicToShare(mnemonic)
+		if err != nil {
+			t.Fatalf("share length %d: err: %v", shareLen, err)
+		}
+		if !bytes.Equal(res, share) {
+			t.Fatalf("share length %d: bad share: %x != %x", shareLen, res, share)
+		}
+	}
+}
+
+func TestShareToMnemonic_invalid(t *testing.T) {
+	if _, err := ShareToMnemonic([]byte{0x01}, 3); err == nil {
+		t.Fatalf("expect error")
+	}
+	if _, err := ShareToMnemonic([]byte{0x01, 0x02}, 1); err == nil {
+		t.Fatalf("expect error")
+	}
+	if _, err := ShareToMnemonic([]byte{0x01, 0x02}, 256); err == nil {
+		t.Fatalf("expect error")
+	}
+}
+
+func TestMnemonicToShare_invalid(t *testing.T) {
+	shares, err := Split([]byte("test secret"), 3, 2)
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	mnemonic, err := ShareToMnemonic(shares[0], 2)
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	words := strings.Fields(mnemonic)
+
+	if _, _, err := MnemonicToShare(""); err == nil || err.Error() != "no words supplied" {
+		t.Fatalf("expect no words error, got %v", err)
+	}
+
+	unknown := append([]string{}, words...)
+	unknown[2] = "notaword"
+	if _, _, err := MnemonicToShare(strings.Join(unknown, " ")); err == nil || err.Error() != `unknown word "notaword" at position 3` {
+		t.Fatalf("expect unknown word error, got %v", err)
+	}
+
+	changed := append([]string{}, words...)
+	if changed[4] == "abandon" {
+		changed[4] = "ability"
+	} else {
+		changed[4] = "abandon"
+	}
+	if _, _, err := MnemonicToShare(strings.Join(changed, " ")); err == nil || err.Error() != "checksum mismatch" {
+		t.Fatalf("expect checksum error, got %v", err)
+	}
+
+	swapped := append([]string{}, words...)
+	swapped[5], swapped[6] = swapped[6], swapped[5]
+	if swapped[5] != swapped[6] {
+		if _, _, err := MnemonicToShare(strings.Join(swapped, " ")); err == nil {
+			t.Fatalf("expect error")
+		}
+	}
+
+	if _, _, err := MnemonicToShare(strings.Join(words[:len(words)-1], " ")); err == nil {
+		t.Fatalf("expect error")
+	}
+
+	// Upper case and extra whitespace are accepted.
+	if _, _, err := MnemonicToShare("  " + strings.ToUpper(strings.Join(words, "  ")) + "\n"); err != nil {
+		t.Fatalf("err: %v", err)
+	}
+}
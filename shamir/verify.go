@@ -0,0 +1,57 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package shamir
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"fmt"
+)
+
+// Commitment returns a commitment to a share or secret.  Shares and secrets
+// contain enough entropy that the commitment reveals nothing about them, but
+// allows them to be checked later without reference to any other share.
+func Commitment(data []byte) []byte {
+	commitment := sha256.Sum256(data)
+	return commitment[:]
+}
+
+// Commitments returns the commitments to a set of shares as returned by Split,
+// indexed by the share index.
+func Commitments(shares [][]byte) map[uint8][]byte {
+	commitments := make(map[uint8][]byte, len(shares))
+	for _, share := range shares {
+		if len(share) == 0 {
+			continue
+		}
+		commitments[share[len(share)-1]] = Commitment(share)
+	}
+	return commitments
+}
+
+// VerifyShare checks that a share matches the commitment for its index.
+func VerifyShare(share []byte, commitments map[uint8][]byte) error {
+	if len(share) < 2 {
+		return fmt.Errorf("share must be at least two bytes")
+	}
+	index := share[len(share)-1]
+	commitment, exists := commitments[index]
+	if !exists {
+		return fmt.Errorf("no commitment for share index %d", index)
+	}
+	if !bytes.Equal(Commitment(share), commitment) {
+		return fmt.Errorf("share does not match commitment for share index %d", index)
+	}
+	return nil
+}
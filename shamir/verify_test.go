@@ -0,0 +1,88 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package shamir
+
+import (
+	"encoding/hex"
+	"testing"
+)
+
+func TestVerifyShare(t *testing.T) {
+	shares, err := Split([]byte("test secret"), 5, 3)
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	commitments := Commitments(shares)
+	if len(commitments) != 5 {
+		t.Fatalf("bad: %v", commitments)
+	}
+
+	for _, share := range shares {
+		if err := VerifyShare(share, commitments); err != nil {
+			t.Fatalf("err: %v", err)
+		}
+	}
+
+	// Alter a share.
+	altered := make([]byte, len(shares[0]))
+	copy(altered, shares[0])
+	altered[0] ^= 0x01
+	if err := VerifyShare(altered, commitments); err == nil {
+		t.Fatalf("expect error")
+	}
+
+	// Remove the commitment for a share.
+	delete(commitments, shares[1][len(shares[1])-1])
+	if err := VerifyShare(shares[1], commitments); err == nil {
+		t.Fatalf("expect error")
+	}
+
+	if err := VerifyShare([]byte{0x01}, commitments); err == nil {
+		t.Fatalf("expect error")
+	}
+}
+
+func TestDecodeShare(t *testing.T) {
+	shares, err := Split([]byte("test secret"), 3, 2)
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	mnemonic, err := ShareToMnemonic(shares[0], 2)
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+
+	share, threshold, err := DecodeShare(mnemonic)
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	if threshold != 2 || string(share) != string(shares[0]) {
+		t.Fatalf("bad share from words")
+	}
+
+	share, threshold, err = DecodeShare(" 0x" + hex.EncodeToString(shares[0]) + "\n")
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	if threshold != 0 || string(share) != string(shares[0]) {
+		t.Fatalf("bad share from hex")
+	}
+
+	if _, _, err := DecodeShare("xx"); err == nil {
+		t.Fatalf("expect error")
+	}
+	if _, _, err := DecodeShare("01"); err == nil {
+		t.Fatalf("expect error")
+	}
+}
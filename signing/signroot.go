@@ -16,6 +16,7 @@ package signing
 import (
 	"context"
 
+	"github.com/aaron-alderman/ethdo/util"
 	spec "github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/pkg/errors"
 	e2types "github.com/wealdtech/go-eth2-types/v2"
@@ -54,6 +55,10 @@ func SignRoot(ctx context.Context, account e2wtypes.Account, passphrases []strin
 		}
 	}
 
+	if err := util.AuditSigning(util.AccountPublicKey(account), root[:], domain[:]); err != nil {
+		return spec.BLSSignature{}, errors.Wrap(err, "failed to record signing in audit log")
+	}
+
 	var sig spec.BLSSignature
 	copy(sig[:], signature.Marshal())
 	return sig, nil
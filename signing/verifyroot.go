@@ -0,0 +1,67 @@
+// Copyright © 2022 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package signing
+
+import (
+	spec "github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	e2types "github.com/wealdtech/go-eth2-types/v2"
+)
+
+// Domain computes the domain for a domain type, fork version and genesis validators root.
+func Domain(domainType spec.DomainType, forkVersion spec.Version, genesisValidatorsRoot spec.Root) (spec.Domain, error) {
+	domainBytes, err := e2types.ComputeDomain(e2types.DomainType(domainType), forkVersion[:], genesisValidatorsRoot[:])
+	if err != nil {
+		return spec.Domain{}, errors.Wrap(err, "failed to calculate domain")
+	}
+
+	var domain spec.Domain
+	copy(domain[:], domainBytes)
+	return domain, nil
+}
+
+// VerifyRoot verifies a signature of a root with a domain.  If more than one public key
+// is supplied the signature is verified as an aggregate of them all.
+func VerifyRoot(root spec.Root, domain spec.Domain, signature spec.BLSSignature, pubKeys ...spec.BLSPubKey) (bool, error) {
+	if len(pubKeys) == 0 {
+		return false, errors.New("no public keys supplied")
+	}
+
+	container := &Container{
+		Root:   root[:],
+		Domain: domain[:],
+	}
+	signingRoot, err := container.HashTreeRoot()
+	if err != nil {
+		return false, errors.Wrap(err, "failed to generate hash tree root")
+	}
+
+	// Copy the signature and keys to avoid passing references to the originals to the BLS library.
+	sig, err := e2types.BLSSignatureFromBytes(append([]byte{}, signature[:]...))
+	if err != nil {
+		return false, errors.Wrap(err, "invalid signature")
+	}
+	keys := make([]e2types.PublicKey, len(pubKeys))
+	for i := range pubKeys {
+		keys[i], err = e2types.BLSPublicKeyFromBytes(append([]byte{}, pubKeys[i][:]...))
+		if err != nil {
+			return false, errors.Wrap(err, "invalid public key")
+		}
+	}
+
+	if len(keys) == 1 {
+		return sig.Verify(signingRoot[:], keys[0]), nil
+	}
+	return sig.VerifyAggregateCommon(signingRoot[:], keys), nil
+}
@@ -0,0 +1,254 @@
+// Copyright © 2022 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package beaconnode
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+var (
+	blockRe            = regexp.MustCompile(`^/eth/v[12]/beacon/blocks/([^/]+)$`)
+	stateRe            = regexp.MustCompile(`^/eth/v[12]/debug/beacon/states/([^/]+)$`)
+	validatorsRe       = regexp.MustCompile(`^/eth/v1/beacon/states/[^/]+/validators$`)
+	validatorBalanceRe = regexp.MustCompile(`^/eth/v1/beacon/states/[^/]+/validator_balances$`)
+	dutiesRe           = regexp.MustCompile(`^/eth/v1/validator/duties/(attester|proposer|sync)/([0-9]+)$`)
+)
+
+// Responses for optional fixtures.
+var defaultResponses = map[string]string{
+	"deposit_contract.json": `{"data":{"chain_id":"1","address":"0x0000000000000000000000000000000000000000"}}`,
+	"node_version.json":     `{"data":{"version":"ethdo/fixtures"}}`,
+	"syncing.json":          `{"data":{"head_slot":"0","sync_distance":"0","is_syncing":false}}`,
+}
+
+// validatorJSON contains the fields of a validator used for filtering.
+type validatorJSON struct {
+	Index     string `json:"index"`
+	Balance   string `json:"balance"`
+	Validator struct {
+		PubKey string `json:"pubkey"`
+	} `json:"validator"`
+}
+
+// dutyJSON contains the fields of a duty used for filtering.
+type dutyJSON struct {
+	ValidatorIndex string `json:"validator_index"`
+}
+
+// handle handles all requests to the beacon node.
+func (s *Service) handle(w http.ResponseWriter, r *http.Request) {
+	log.Trace().Str("method", r.Method).Str("path", r.URL.Path).Msg("Received request")
+
+	if r.Method == http.MethodPost && strings.HasPrefix(r.URL.Path, "/eth/v1/beacon/pool/") {
+		s.handleSubmission(w, r)
+		return
+	}
+
+	path := r.URL.Path
+	switch {
+	case path == "/eth/v1/beacon/genesis":
+		s.serveFixture(w, "genesis.json")
+	case path == "/eth/v1/config/spec":
+		s.serveFixture(w, "spec.json")
+	case path == "/eth/v1/config/fork_schedule":
+		s.serveFixture(w, "fork_schedule.json")
+	case path == "/eth/v1/config/deposit_contract":
+		s.serveFixture(w, "deposit_contract.json")
+	case path == "/eth/v1/node/version":
+		s.serveFixture(w, "node_version.json")
+	case path == "/eth/v1/node/syncing":
+		s.serveFixture(w, "syncing.json")
+	case blockRe.MatchString(path):
+		s.serveFixture(w, filepath.Join("blocks", fmt.Sprintf("%s.json", blockRe.FindStringSubmatch(path)[1])))
+	case stateRe.MatchString(path):
+		s.serveFixture(w, filepath.Join("states", fmt.Sprintf("%s.json", stateRe.FindStringSubmatch(path)[1])))
+	case validatorsRe.MatchString(path):
+		s.serveValidators(w, r, false)
+	case validatorBalanceRe.MatchString(path):
+		s.serveValidators(w, r, true)
+	case dutiesRe.MatchString(path):
+		matches := dutiesRe.FindStringSubmatch(path)
+		s.serveDuties(w, r, filepath.Join("duties", matches[1], fmt.Sprintf("%s.json", matches[2])))
+	default:
+		s.serveFixture(w, fmt.Sprintf("%s.json", strings.TrimPrefix(path, "/")))
+	}
+}
+
+// handleSubmission records a submission.
+func (s *Service) handleSubmission(w http.ResponseWriter, r *http.Request) {
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "failed to read body")
+		return
+	}
+	if !json.Valid(body) {
+		writeError(w, http.StatusBadRequest, "invalid JSON")
+		return
+	}
+	s.record(r.URL.Path, body)
+	w.WriteHeader(http.StatusOK)
+}
+
+// serveFixture serves the named fixture.
+func (s *Service) serveFixture(w http.ResponseWriter, name string) {
+	data, err := s.readFixture(name)
+	if err != nil {
+		writeError(w, http.StatusNotFound, "not found")
+		return
+	}
+	writeJSON(w, data)
+}
+
+// serveValidators serves validators or their balances, filtered by the "id" query parameter.
+func (s *Service) serveValidators(w http.ResponseWriter, r *http.Request, balances bool) {
+	data, err := s.readFixture("validators.json")
+	if err != nil {
+		writeError(w, http.StatusNotFound, "not found")
+		return
+	}
+	var fixture struct {
+		Data []json.RawMessage `json:"data"`
+	}
+	if err := json.Unmarshal(data, &fixture); err != nil {
+		writeError(w, http.StatusInternalServerError, "invalid validators fixture")
+		return
+	}
+
+	ids := make(map[string]bool)
+	for _, id := range strings.Split(r.URL.Query().Get("id"), ",") {
+		if id != "" {
+			ids[strings.ToLower(id)] = true
+		}
+	}
+
+	res := make([]interface{}, 0, len(fixture.Data))
+	for _, raw := range fixture.Data {
+		validator := &validatorJSON{}
+		if err := json.Unmarshal(raw, validator); err != nil {
+			writeError(w, http.StatusInternalServerError, "invalid validator in fixture")
+			return
+		}
+		if len(ids) > 0 && !ids[validator.Index] && !ids[strings.ToLower(validator.Validator.PubKey)] {
+			continue
+		}
+		if balances {
+			res = append(res, map[string]string{
+				"index":   validator.Index,
+				"balance": validator.Balance,
+			})
+		} else {
+			res = append(res, raw)
+		}
+	}
+
+	data, err = json.Marshal(map[string]interface{}{"data": res})
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to generate response")
+		return
+	}
+	writeJSON(w, data)
+}
+
+// serveDuties serves duties, filtered by the validator indices in the body of a POST request.
+func (s *Service) serveDuties(w http.ResponseWriter, r *http.Request, name string) {
+	data, err := s.readFixture(name)
+	if err != nil {
+		writeError(w, http.StatusNotFound, "not found")
+		return
+	}
+	if r.Method != http.MethodPost {
+		writeJSON(w, data)
+		return
+	}
+
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "failed to read body")
+		return
+	}
+	indices := make([]string, 0)
+	if err := json.Unmarshal(body, &indices); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid validator indices")
+		return
+	}
+	wanted := make(map[string]bool, len(indices))
+	for _, index := range indices {
+		wanted[index] = true
+	}
+
+	fixture := make(map[string]json.RawMessage)
+	if err := json.Unmarshal(data, &fixture); err != nil {
+		writeError(w, http.StatusInternalServerError, "invalid duties fixture")
+		return
+	}
+	duties := make([]json.RawMessage, 0)
+	if err := json.Unmarshal(fixture["data"], &duties); err != nil {
+		writeError(w, http.StatusInternalServerError, "invalid duties fixture")
+		return
+	}
+	filtered := make([]json.RawMessage, 0, len(duties))
+	for _, raw := range duties {
+		duty := &dutyJSON{}
+		if err := json.Unmarshal(raw, duty); err != nil {
+			writeError(w, http.StatusInternalServerError, "invalid duty in fixture")
+			return
+		}
+		if wanted[duty.ValidatorIndex] {
+			filtered = append(filtered, raw)
+		}
+	}
+	fixture["data"], err = json.Marshal(filtered)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to generate response")
+		return
+	}
+
+	data, err = json.Marshal(fixture)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to generate response")
+		return
+	}
+	writeJSON(w, data)
+}
+
+// readFixture reads the named fixture, falling back to a default response if one is available.
+func (s *Service) readFixture(name string) ([]byte, error) {
+	data, err := ioutil.ReadFile(filepath.Join(s.fixturesDir, filepath.Clean(string(os.PathSeparator)+name)))
+	if err != nil {
+		if response, exists := defaultResponses[name]; exists {
+			return []byte(response), nil
+		}
+		return nil, err
+	}
+	return data, nil
+}
+
+func writeJSON(w http.ResponseWriter, data []byte) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(data)
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_, _ = w.Write([]byte(fmt.Sprintf(`{"code":%d,"message":%q}`, status, message)))
+}
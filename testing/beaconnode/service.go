@@ -0,0 +1,162 @@
+// Copyright © 2022 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package beaconnode provides an in-process stand-in for a beacon node, serving the
+// standard beacon API from fixtures on disk.  It is intended for end-to-end tests of
+// commands.
+//
+// Fixtures are laid out in a directory as follows:
+//
+//	genesis.json                 /eth/v1/beacon/genesis
+//	spec.json                    /eth/v1/config/spec
+//	fork_schedule.json           /eth/v1/config/fork_schedule
+//	deposit_contract.json        /eth/v1/config/deposit_contract (optional)
+//	node_version.json            /eth/v1/node/version (optional)
+//	syncing.json                 /eth/v1/node/syncing (optional)
+//	validators.json              /eth/v1/beacon/states/{state}/validators and validator_balances
+//	blocks/{block}.json          /eth/v1/beacon/blocks/{block} and /eth/v2/beacon/blocks/{block}
+//	states/{state}.json          /eth/v2/debug/beacon/states/{state}
+//	duties/attester/{epoch}.json /eth/v1/validator/duties/attester/{epoch}
+//	duties/proposer/{epoch}.json /eth/v1/validator/duties/proposer/{epoch}
+//	duties/sync/{epoch}.json     /eth/v1/validator/duties/sync/{epoch}
+//
+// Each fixture contains the full body of the response as returned by a beacon node.
+// Any other request is served from the file at the request path with a ".json" suffix,
+// for example /eth/v1/beacon/states/head/fork is served from
+// eth/v1/beacon/states/head/fork.json.  Query parameters are ignored except where noted
+// above.
+//
+// Items submitted to the beacon node are recorded, and can be obtained with Submissions().
+package beaconnode
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
+	zerologger "github.com/rs/zerolog/log"
+)
+
+// Service is a stand-in beacon node.
+type Service struct {
+	fixturesDir   string
+	server        *httptest.Server
+	submissionsMu sync.Mutex
+	submissions   map[string][][]byte
+}
+
+// module-wide log.
+var log zerolog.Logger
+
+// requiredFixtures are the fixtures required for a beacon API client to connect.
+var requiredFixtures = []string{
+	"genesis.json",
+	"spec.json",
+	"fork_schedule.json",
+}
+
+// New creates a new stand-in beacon node, and starts it listening on a local address.
+// The beacon node is closed when the context is done.
+func New(ctx context.Context, params ...Parameter) (*Service, error) {
+	parameters, err := parseAndCheckParameters(params...)
+	if err != nil {
+		return nil, errors.Wrap(err, "problem with parameters")
+	}
+
+	// Set logging.
+	log = zerologger.With().Str("service", "beaconnode").Str("impl", "fixtures").Logger().Level(parameters.logLevel)
+
+	for _, fixture := range requiredFixtures {
+		if _, err := os.Stat(filepath.Join(parameters.fixturesDir, fixture)); err != nil {
+			return nil, errors.Wrapf(err, "required fixture %s missing", fixture)
+		}
+	}
+
+	s := &Service{
+		fixturesDir: parameters.fixturesDir,
+		submissions: make(map[string][][]byte),
+	}
+	s.server = httptest.NewServer(http.HandlerFunc(s.handle))
+	log.Trace().Str("address", s.server.URL).Msg("Started")
+
+	go func(s *Service) {
+		<-ctx.Done()
+		s.Close()
+	}(s)
+
+	return s, nil
+}
+
+// Address returns the address on which the beacon node is listening.
+func (s *Service) Address() string {
+	return s.server.URL
+}
+
+// Close stops the beacon node.
+func (s *Service) Close() {
+	s.server.Close()
+}
+
+// Submissions returns the bodies of the requests submitted to the given path,
+// in the order in which they were received.
+func (s *Service) Submissions(path string) [][]byte {
+	s.submissionsMu.Lock()
+	defer s.submissionsMu.Unlock()
+
+	res := make([][]byte, len(s.submissions[path]))
+	copy(res, s.submissions[path])
+	return res
+}
+
+// VoluntaryExits returns the voluntary exits submitted to the beacon node.
+func (s *Service) VoluntaryExits() ([]*phase0.SignedVoluntaryExit, error) {
+	submissions := s.Submissions("/eth/v1/beacon/pool/voluntary_exits")
+	res := make([]*phase0.SignedVoluntaryExit, 0, len(submissions))
+	for _, submission := range submissions {
+		exit := &phase0.SignedVoluntaryExit{}
+		if err := json.Unmarshal(submission, exit); err != nil {
+			return nil, errors.Wrap(err, "invalid voluntary exit submitted")
+		}
+		res = append(res, exit)
+	}
+	return res, nil
+}
+
+// Attestations returns the attestations submitted to the beacon node.
+func (s *Service) Attestations() ([]*phase0.Attestation, error) {
+	submissions := s.Submissions("/eth/v1/beacon/pool/attestations")
+	res := make([]*phase0.Attestation, 0)
+	for _, submission := range submissions {
+		attestations := make([]*phase0.Attestation, 0)
+		if err := json.Unmarshal(submission, &attestations); err != nil {
+			return nil, errors.Wrap(err, "invalid attestations submitted")
+		}
+		res = append(res, attestations...)
+	}
+	return res, nil
+}
+
+// record records a submission.
+func (s *Service) record(path string, body []byte) {
+	s.submissionsMu.Lock()
+	defer s.submissionsMu.Unlock()
+
+	s.submissions[path] = append(s.submissions[path], body)
+}
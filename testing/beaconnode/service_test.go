@@ -0,0 +1,144 @@
+// Copyright © 2022 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package beaconnode_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aaron-alderman/ethdo/testing/beaconnode"
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/http"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		name   string
+		params []beaconnode.Parameter
+		err    string
+	}{
+		{
+			name: "FixturesDirMissing",
+			params: []beaconnode.Parameter{
+				beaconnode.WithLogLevel(zerolog.Disabled),
+			},
+			err: "problem with parameters: no fixtures directory specified",
+		},
+		{
+			name: "FixturesDirNotDir",
+			params: []beaconnode.Parameter{
+				beaconnode.WithLogLevel(zerolog.Disabled),
+				beaconnode.WithFixturesDir("testdata/genesis.json"),
+			},
+			err: "problem with parameters: fixtures directory is not a directory",
+		},
+		{
+			name: "FixturesMissing",
+			params: []beaconnode.Parameter{
+				beaconnode.WithLogLevel(zerolog.Disabled),
+				beaconnode.WithFixturesDir("testdata/blocks"),
+			},
+			err: "required fixture genesis.json missing: stat testdata/blocks/genesis.json: no such file or directory",
+		},
+		{
+			name: "Good",
+			params: []beaconnode.Parameter{
+				beaconnode.WithLogLevel(zerolog.Disabled),
+				beaconnode.WithFixturesDir("testdata"),
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			_, err := beaconnode.New(ctx, test.params...)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestService(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	s, err := beaconnode.New(ctx,
+		beaconnode.WithLogLevel(zerolog.Disabled),
+		beaconnode.WithFixturesDir("testdata"),
+	)
+	require.NoError(t, err)
+
+	client, err := http.New(ctx,
+		http.WithLogLevel(zerolog.Disabled),
+		http.WithAddress(s.Address()),
+		http.WithTimeout(5*time.Second),
+	)
+	require.NoError(t, err)
+
+	genesis, err := client.(eth2client.GenesisProvider).Genesis(ctx)
+	require.NoError(t, err)
+	require.Equal(t, int64(1606824023), genesis.GenesisTime.Unix())
+
+	validators, err := client.(eth2client.ValidatorsProvider).Validators(ctx, "head", nil)
+	require.NoError(t, err)
+	require.Len(t, validators, 3)
+
+	validators, err = client.(eth2client.ValidatorsProvider).Validators(ctx, "head", []phase0.ValidatorIndex{1})
+	require.NoError(t, err)
+	require.Len(t, validators, 1)
+	require.Contains(t, validators, phase0.ValidatorIndex(1))
+
+	balances, err := client.(eth2client.ValidatorBalancesProvider).ValidatorBalances(ctx, "head", []phase0.ValidatorIndex{0, 1})
+	require.NoError(t, err)
+	require.Equal(t, map[phase0.ValidatorIndex]phase0.Gwei{0: 32000000000, 1: 31000000000}, balances)
+
+	duties, err := client.(eth2client.ProposerDutiesProvider).ProposerDuties(ctx, 0, nil)
+	require.NoError(t, err)
+	require.Len(t, duties, 2)
+
+	block, err := client.(eth2client.SignedBeaconBlockProvider).SignedBeaconBlock(ctx, "1")
+	require.NoError(t, err)
+	require.NotNil(t, block)
+	require.Equal(t, phase0.Slot(1), block.Phase0.Message.Slot)
+
+	state, err := client.(eth2client.BeaconStateProvider).BeaconState(ctx, "31")
+	require.NoError(t, err)
+	require.NotNil(t, state)
+	require.Equal(t, uint64(31), state.Phase0.Slot)
+	require.Len(t, state.Phase0.ETH1DataVotes, 3)
+
+	fork, err := client.(eth2client.ForkProvider).Fork(ctx, "head")
+	require.NoError(t, err)
+	require.Equal(t, phase0.Version{0x00, 0x00, 0x00, 0x00}, fork.CurrentVersion)
+
+	exit := &phase0.SignedVoluntaryExit{
+		Message: &phase0.VoluntaryExit{
+			Epoch:          10,
+			ValidatorIndex: 1,
+		},
+	}
+	require.NoError(t, client.(eth2client.VoluntaryExitSubmitter).SubmitVoluntaryExit(ctx, exit))
+	exits, err := s.VoluntaryExits()
+	require.NoError(t, err)
+	require.Equal(t, []*phase0.SignedVoluntaryExit{exit}, exits)
+}
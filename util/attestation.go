@@ -0,0 +1,107 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	"github.com/aaron-alderman/ethdo/services/chaintime"
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/go-bitfield"
+)
+
+// AttestingIndices returns the indices of the validators in the committee that are
+// marked as attesting in the aggregation bits.
+func AttestingIndices(aggregationBits bitfield.Bitlist, committee []phase0.ValidatorIndex) ([]phase0.ValidatorIndex, error) {
+	if aggregationBits.Len() != uint64(len(committee)) {
+		return nil, fmt.Errorf("aggregation bits length %d does not match committee size %d", aggregationBits.Len(), len(committee))
+	}
+
+	res := make([]phase0.ValidatorIndex, 0, aggregationBits.Count())
+	for i := uint64(0); i < aggregationBits.Len(); i++ {
+		if aggregationBits.BitAt(i) {
+			res = append(res, committee[i])
+		}
+	}
+
+	return res, nil
+}
+
+// AttestationHeadCorrect returns true if the attestation voted for the canonical head at its slot.
+func AttestationHeadCorrect(ctx context.Context,
+	headersProvider eth2client.BeaconBlockHeadersProvider,
+	attestation *phase0.Attestation,
+) (
+	bool,
+	error,
+) {
+	root, found, err := canonicalRootAtOrBefore(ctx, headersProvider, attestation.Data.Slot)
+	if err != nil {
+		return false, err
+	}
+	if !found {
+		return false, nil
+	}
+	return bytes.Equal(root[:], attestation.Data.BeaconBlockRoot[:]), nil
+}
+
+// AttestationTargetCorrect returns true if the attestation voted for the canonical target of its epoch.
+func AttestationTargetCorrect(ctx context.Context,
+	headersProvider eth2client.BeaconBlockHeadersProvider,
+	chainTime chaintime.Service,
+	attestation *phase0.Attestation,
+) (
+	bool,
+	error,
+) {
+	// Start with first slot of the target epoch.
+	root, found, err := canonicalRootAtOrBefore(ctx, headersProvider, chainTime.FirstSlotOfEpoch(attestation.Data.Target.Epoch))
+	if err != nil {
+		return false, err
+	}
+	if !found {
+		return false, nil
+	}
+	return bytes.Equal(root[:], attestation.Data.Target.Root[:]), nil
+}
+
+// canonicalRootAtOrBefore returns the root of the canonical block at the given slot, or the
+// most recent canonical block before it if the slot is empty.
+func canonicalRootAtOrBefore(ctx context.Context,
+	headersProvider eth2client.BeaconBlockHeadersProvider,
+	slot phase0.Slot,
+) (
+	phase0.Root,
+	bool,
+	error,
+) {
+	for {
+		header, err := headersProvider.BeaconBlockHeader(ctx, fmt.Sprintf("%d", slot))
+		if err != nil {
+			return phase0.Root{}, false, errors.Wrap(err, fmt.Sprintf("failed to obtain block header for slot %d", slot))
+		}
+		if header != nil && header.Canonical {
+			return header.Root, true, nil
+		}
+		// No block, or not canonical.
+		if slot == 0 {
+			return phase0.Root{}, false, nil
+		}
+		slot--
+	}
+}
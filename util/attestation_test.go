@@ -0,0 +1,106 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aaron-alderman/ethdo/util"
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+// headersProvider provides canonical block headers, with empty slots where no header is present.
+type headersProvider struct {
+	headers map[string]*apiv1.BeaconBlockHeader
+	err     error
+}
+
+func (p *headersProvider) BeaconBlockHeader(_ context.Context, blockID string) (*apiv1.BeaconBlockHeader, error) {
+	if p.err != nil {
+		return nil, p.err
+	}
+	return p.headers[blockID], nil
+}
+
+func TestAttestationHeadCorrect(t *testing.T) {
+	provider := &headersProvider{
+		headers: map[string]*apiv1.BeaconBlockHeader{
+			"1": {Root: phase0.Root{0x01}, Canonical: true},
+			"2": {Root: phase0.Root{0x02}, Canonical: false},
+		},
+	}
+
+	tests := []struct {
+		name        string
+		provider    *headersProvider
+		attestation *phase0.Attestation
+		correct     bool
+		err         string
+	}{
+		{
+			name:     "Correct",
+			provider: provider,
+			attestation: &phase0.Attestation{
+				Data: &phase0.AttestationData{Slot: 1, BeaconBlockRoot: phase0.Root{0x01}},
+			},
+			correct: true,
+		},
+		{
+			name:     "CorrectEarlierBlock",
+			provider: provider,
+			attestation: &phase0.Attestation{
+				Data: &phase0.AttestationData{Slot: 3, BeaconBlockRoot: phase0.Root{0x01}},
+			},
+			correct: true,
+		},
+		{
+			name:     "Incorrect",
+			provider: provider,
+			attestation: &phase0.Attestation{
+				Data: &phase0.AttestationData{Slot: 2, BeaconBlockRoot: phase0.Root{0x02}},
+			},
+		},
+		{
+			name:     "NoBlock",
+			provider: &headersProvider{},
+			attestation: &phase0.Attestation{
+				Data: &phase0.AttestationData{Slot: 1, BeaconBlockRoot: phase0.Root{0x01}},
+			},
+		},
+		{
+			name:     "ProviderError",
+			provider: &headersProvider{err: errors.New("unavailable")},
+			attestation: &phase0.Attestation{
+				Data: &phase0.AttestationData{Slot: 1, BeaconBlockRoot: phase0.Root{0x01}},
+			},
+			err: "failed to obtain block header for slot 1: unavailable",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			correct, err := util.AttestationHeadCorrect(context.Background(), test.provider, test.attestation)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, test.correct, correct)
+			}
+		})
+	}
+}
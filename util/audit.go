@@ -0,0 +1,598 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/user"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	e2wtypes "github.com/wealdtech/go-eth2-wallet-types/v2"
+	"golang.org/x/crypto/scrypt"
+)
+
+// AuditActionSign is the audit action for signing a root with a domain.
+const AuditActionSign = "sign"
+
+// AuditActionBroadcast is the audit action for broadcasting an operation to the network.
+const AuditActionBroadcast = "broadcast"
+
+// AuditActionExport is the audit action for exporting key material.
+const AuditActionExport = "export"
+
+// auditGenesisHash is the head hash of an audit log without entries.
+var auditGenesisHash = fmt.Sprintf("%#x", make([]byte, sha256.Size))
+
+// maxAuditEntrySize is the maximum size of a single line in an audit log.
+const maxAuditEntrySize = 1024 * 1024
+
+// auditLogVersion is the version of the audit log format.
+const auditLogVersion = 1
+
+// auditHeader is the first line of an audit log.  The previous hash of the
+// first entry is the hash of the header, so the header cannot be altered
+// without breaking the chain.
+type auditHeader struct {
+	Version int  `json:"audit_log_version"`
+	Keyed   bool `json:"keyed"`
+	// Salt is the salt with which keys are derived from the log key.
+	Salt string `json:"salt,omitempty"`
+}
+
+// AuditEntry is an entry in the audit log.
+type AuditEntry struct {
+	Sequence   uint64    `json:"sequence"`
+	Timestamp  time.Time `json:"timestamp"`
+	User       string    `json:"user"`
+	Host       string    `json:"host"`
+	Command    string    `json:"command"`
+	Action     string    `json:"action"`
+	PublicKeys []string  `json:"public_keys,omitempty"`
+	Root       string    `json:"root,omitempty"`
+	Domain     string    `json:"domain,omitempty"`
+	Operation  string    `json:"operation,omitempty"`
+	Payload    string    `json:"payload,omitempty"`
+	PrevHash   string    `json:"prev_hash"`
+	Hash       string    `json:"hash"`
+}
+
+// auditPayload contains the fields of an audit entry that are encrypted if the log has a key.
+type auditPayload struct {
+	User       string   `json:"user"`
+	Host       string   `json:"host"`
+	Command    string   `json:"command"`
+	PublicKeys []string `json:"public_keys,omitempty"`
+	Root       string   `json:"root,omitempty"`
+	Domain     string   `json:"domain,omitempty"`
+	Operation  string   `json:"operation,omitempty"`
+}
+
+// auditKeys are the keys derived from the operator's log key.
+type auditKeys struct {
+	mac        []byte
+	encryption []byte
+}
+
+// AuditSummary is the result of verifying an audit log.
+type AuditSummary struct {
+	Entries  uint64
+	HeadHash string
+	// Log contains the verified entries, decrypted if the log has a key.
+	Log []*AuditEntry
+}
+
+var auditMu sync.Mutex
+var auditCommand string
+var auditKeysMu sync.Mutex
+var auditKeysCache = make(map[string]*auditKeys)
+
+// SetAuditCommand sets the command recorded against audit entries.
+// Command arguments are not recorded, as they can contain passphrases.
+func SetAuditCommand(command string) {
+	auditMu.Lock()
+	auditCommand = command
+	auditMu.Unlock()
+}
+
+// AuditEnabled returns true if an audit log has been configured.
+func AuditEnabled() bool {
+	return viper.GetString("log") != ""
+}
+
+// AuditSigning records the signing of a root with a domain by the given public key.
+func AuditSigning(pubKey []byte, root []byte, domain []byte) error {
+	return RecordAudit(&AuditEntry{
+		Action:     AuditActionSign,
+		PublicKeys: auditPublicKeys([][]byte{pubKey}),
+		Root:       fmt.Sprintf("%#x", root),
+		Domain:     fmt.Sprintf("%#x", domain),
+	})
+}
+
+// AuditSigningSummary records a summary of multiple signings by the given public keys.
+func AuditSigningSummary(operation string, pubKeys ...[]byte) error {
+	return RecordAudit(&AuditEntry{
+		Action:     AuditActionSign,
+		PublicKeys: auditPublicKeys(pubKeys),
+		Operation:  operation,
+	})
+}
+
+// AuditBroadcast records the broadcast of an operation.
+func AuditBroadcast(operation string, pubKeys ...[]byte) error {
+	return RecordAudit(&AuditEntry{
+		Action:     AuditActionBroadcast,
+		PublicKeys: auditPublicKeys(pubKeys),
+		Operation:  operation,
+	})
+}
+
+// AuditExport records the export of key material.
+func AuditExport(operation string, pubKeys ...[]byte) error {
+	return RecordAudit(&AuditEntry{
+		Action:     AuditActionExport,
+		PublicKeys: auditPublicKeys(pubKeys),
+		Operation:  operation,
+	})
+}
+
+// RecordAudit appends an entry to the audit log, if one is configured.
+// The sequence, timestamp, user, host, command and hashes of the entry
+// are filled in by this function.  If a log key is configured the hash
+// is an HMAC with the key and the details of the entry are encrypted.
+func RecordAudit(entry *AuditEntry) error {
+	if !AuditEnabled() {
+		return nil
+	}
+	if entry == nil {
+		return errors.New("no audit entry supplied")
+	}
+
+	auditMu.Lock()
+	defer auditMu.Unlock()
+
+	f, err := os.OpenFile(viper.GetString("log"), os.O_APPEND|os.O_CREATE|os.O_RDWR, 0600)
+	if err != nil {
+		return errors.Wrap(err, "failed to open audit log")
+	}
+	defer f.Close()
+
+	// Lock the log against other processes, so that they cannot append an entry
+	// with the same previous hash.
+	if err := lockAuditLog(f); err != nil {
+		return errors.Wrap(err, "failed to lock audit log")
+	}
+	defer func() {
+		if err := unlockAuditLog(f); err != nil {
+			Log.Trace().Err(err).Msg("Failed to unlock audit log")
+		}
+	}()
+
+	key := viper.GetString("log-key")
+	header, headerHash, err := auditLogHeader(f, key != "")
+	if err != nil {
+		return err
+	}
+	if header.Keyed != (key != "") {
+		if header.Keyed {
+			return errors.New("audit log is authenticated with a log key, which must be supplied")
+		}
+		return errors.New("audit log is not authenticated with a log key, so one cannot be supplied")
+	}
+	var keys *auditKeys
+	if key != "" {
+		keys, err = deriveAuditKeys(key, header.Salt)
+		if err != nil {
+			return err
+		}
+	}
+
+	last, err := lastAuditEntry(f)
+	if err != nil {
+		return err
+	}
+	if last == nil {
+		entry.Sequence = 1
+		entry.PrevHash = headerHash
+	} else {
+		if (last.Payload != "") != header.Keyed {
+			return errors.New("last audit log entry does not match the log key mode of the log")
+		}
+		entry.Sequence = last.Sequence + 1
+		entry.PrevHash = last.Hash
+	}
+	entry.Timestamp = time.Now().UTC()
+	entry.User = auditUser()
+	entry.Host, _ = os.Hostname()
+	entry.Command = auditCommand
+	if keys != nil {
+		if err := sealAuditEntry(entry, keys); err != nil {
+			return err
+		}
+	}
+	entry.Hash, err = auditHash(entry, keys)
+	if err != nil {
+		return err
+	}
+
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal audit entry")
+	}
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		return errors.Wrap(err, "failed to write audit entry")
+	}
+
+	return f.Sync()
+}
+
+// VerifyAuditLog verifies the hash chain of an audit log.  If the log has a key it must
+// be supplied, in which case the HMAC of each entry is checked and its details decrypted.
+func VerifyAuditLog(r io.Reader, key string) (*AuditSummary, error) {
+	summary := &AuditSummary{
+		HeadHash: auditGenesisHash,
+		Log:      make([]*AuditEntry, 0),
+	}
+
+	var keys *auditKeys
+	headerFound := false
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxAuditEntrySize)
+	line := 0
+	for scanner.Scan() {
+		line++
+		if len(bytes.TrimSpace(scanner.Bytes())) == 0 {
+			continue
+		}
+		if !headerFound {
+			header, err := parseAuditHeader(scanner.Bytes())
+			if err != nil {
+				return summary, errors.Wrap(err, fmt.Sprintf("line %d", line))
+			}
+			if header.Keyed && key == "" {
+				return summary, errors.New("log is authenticated with a log key, which is required")
+			}
+			if !header.Keyed && key != "" {
+				return summary, errors.New("log is not authenticated with a log key, so one cannot be supplied")
+			}
+			if header.Keyed {
+				keys, err = deriveAuditKeys(key, header.Salt)
+				if err != nil {
+					return summary, err
+				}
+			}
+			headerFound = true
+			summary.HeadHash = auditLineHash(scanner.Bytes())
+			continue
+		}
+		entry := &AuditEntry{}
+		if err := json.Unmarshal(scanner.Bytes(), entry); err != nil {
+			return summary, errors.Wrap(err, fmt.Sprintf("line %d: invalid entry", line))
+		}
+		if entry.Sequence != summary.Entries+1 {
+			return summary, fmt.Errorf("line %d: sequence %d found but %d expected", line, entry.Sequence, summary.Entries+1)
+		}
+		if entry.PrevHash != summary.HeadHash {
+			return summary, fmt.Errorf("line %d: previous hash does not match hash of preceding entry", line)
+		}
+		if entry.Payload != "" && keys == nil {
+			return summary, fmt.Errorf("line %d: entry is encrypted so the log key is required", line)
+		}
+		hash, err := auditHash(entry, keys)
+		if err != nil {
+			return summary, err
+		}
+		if entry.Hash != hash {
+			return summary, fmt.Errorf("line %d: hash does not match entry contents", line)
+		}
+		if keys != nil {
+			if err := openAuditEntry(entry, keys); err != nil {
+				return summary, errors.Wrap(err, fmt.Sprintf("line %d", line))
+			}
+		}
+		summary.Entries++
+		summary.HeadHash = entry.Hash
+		summary.Log = append(summary.Log, entry)
+	}
+	if err := scanner.Err(); err != nil {
+		return summary, errors.Wrap(err, "failed to read audit log")
+	}
+
+	return summary, nil
+}
+
+// auditLogHeader returns the header of the audit log and its hash, writing a new
+// header if the log is empty.
+func auditLogHeader(f *os.File, keyed bool) (*auditHeader, string, error) {
+	reader := bufio.NewReaderSize(io.NewSectionReader(f, 0, maxAuditEntrySize), 64*1024)
+	line, err := reader.ReadBytes('\n')
+	if err != nil && err != io.EOF {
+		return nil, "", errors.Wrap(err, "failed to read audit log header")
+	}
+	line = bytes.TrimRight(line, "\r\n")
+	if len(line) > 0 {
+		header, err := parseAuditHeader(line)
+		if err != nil {
+			return nil, "", err
+		}
+		return header, auditLineHash(line), nil
+	}
+
+	// New log.
+	header := &auditHeader{
+		Version: auditLogVersion,
+		Keyed:   keyed,
+	}
+	if keyed {
+		salt := make([]byte, 32)
+		if _, err := rand.Read(salt); err != nil {
+			return nil, "", errors.Wrap(err, "failed to generate salt")
+		}
+		header.Salt = fmt.Sprintf("%#x", salt)
+	}
+	line, err = json.Marshal(header)
+	if err != nil {
+		return nil, "", errors.Wrap(err, "failed to marshal audit log header")
+	}
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		return nil, "", errors.Wrap(err, "failed to write audit log header")
+	}
+
+	return header, auditLineHash(line), nil
+}
+
+// parseAuditHeader parses the header line of an audit log.
+func parseAuditHeader(line []byte) (*auditHeader, error) {
+	header := &auditHeader{}
+	if err := json.Unmarshal(line, header); err != nil {
+		return nil, errors.Wrap(err, "invalid audit log header")
+	}
+	if header.Version != auditLogVersion {
+		return nil, fmt.Errorf("unsupported audit log version %d", header.Version)
+	}
+	if header.Keyed && header.Salt == "" {
+		return nil, errors.New("audit log header has no salt")
+	}
+
+	return header, nil
+}
+
+// auditLineHash returns the hash of a line of the audit log.
+func auditLineHash(line []byte) string {
+	hash := sha256.Sum256(line)
+	return fmt.Sprintf("%#x", hash)
+}
+
+// lastAuditEntry returns the last entry in the audit log, or nil if the log is empty.
+// Only the tail of the log is read, so appending does not slow as the log grows.
+func lastAuditEntry(f *os.File) (*AuditEntry, error) {
+	info, err := f.Stat()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to obtain audit log information")
+	}
+	// Read enough to contain the largest entry and its preceding newline.
+	offset := info.Size() - maxAuditEntrySize - 1
+	if offset < 0 {
+		offset = 0
+	}
+	tail := make([]byte, info.Size()-offset)
+	if _, err := f.ReadAt(tail, offset); err != nil && err != io.EOF {
+		return nil, errors.Wrap(err, "failed to read audit log")
+	}
+
+	tail = bytes.TrimRight(tail, " \t\r\n")
+	if len(tail) == 0 {
+		return nil, nil
+	}
+	if index := bytes.LastIndexByte(tail, '\n'); index != -1 {
+		tail = tail[index+1:]
+	} else if offset > 0 {
+		return nil, errors.New("last audit log entry is too large")
+	} else {
+		// The only line in the log is its header.
+		return nil, nil
+	}
+
+	entry := &AuditEntry{}
+	if err := json.Unmarshal(tail, entry); err != nil {
+		return nil, errors.Wrap(err, "failed to parse last audit log entry")
+	}
+
+	return entry, nil
+}
+
+// auditHash calculates the hash of an entry, which covers all fields bar the hash itself.
+// If keys are supplied the hash is an HMAC, so cannot be recalculated without the log key.
+func auditHash(entry *AuditEntry, keys *auditKeys) (string, error) {
+	hashed := *entry
+	hashed.Hash = ""
+	data, err := json.Marshal(&hashed)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to marshal audit entry")
+	}
+	if keys == nil {
+		hash := sha256.Sum256(data)
+		return fmt.Sprintf("%#x", hash), nil
+	}
+	mac := hmac.New(sha256.New, keys.mac)
+	if _, err := mac.Write(data); err != nil {
+		return "", errors.Wrap(err, "failed to generate audit entry HMAC")
+	}
+
+	return fmt.Sprintf("%#x", mac.Sum(nil)), nil
+}
+
+// deriveAuditKeys derives the HMAC and encryption keys from the operator's log key and
+// the salt of the log.  Derivation is deliberately slow, so results are cached for
+// subsequent entries.
+func deriveAuditKeys(key string, salt string) (*auditKeys, error) {
+	auditKeysMu.Lock()
+	defer auditKeysMu.Unlock()
+
+	saltBytes, err := hex.DecodeString(strings.TrimPrefix(salt, "0x"))
+	if err != nil || len(saltBytes) == 0 {
+		return nil, errors.New("invalid audit log salt")
+	}
+	cacheKey := fmt.Sprintf("%s:%s", salt, key)
+	if keys, exists := auditKeysCache[cacheKey]; exists {
+		return keys, nil
+	}
+	derived, err := scrypt.Key([]byte(key), saltBytes, 1<<15, 8, 1, 64)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to derive audit log keys")
+	}
+	keys := &auditKeys{
+		mac:        derived[:32],
+		encryption: derived[32:],
+	}
+	auditKeysCache[cacheKey] = keys
+
+	return keys, nil
+}
+
+// sealAuditEntry moves the details of an entry in to its encrypted payload.
+func sealAuditEntry(entry *AuditEntry, keys *auditKeys) error {
+	plaintext, err := json.Marshal(&auditPayload{
+		User:       entry.User,
+		Host:       entry.Host,
+		Command:    entry.Command,
+		PublicKeys: entry.PublicKeys,
+		Root:       entry.Root,
+		Domain:     entry.Domain,
+		Operation:  entry.Operation,
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal audit payload")
+	}
+	aead, err := auditAEAD(keys)
+	if err != nil {
+		return err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return errors.Wrap(err, "failed to generate nonce")
+	}
+
+	entry.Payload = fmt.Sprintf("%#x", aead.Seal(nonce, nonce, plaintext, nil))
+	entry.User = ""
+	entry.Host = ""
+	entry.Command = ""
+	entry.PublicKeys = nil
+	entry.Root = ""
+	entry.Domain = ""
+	entry.Operation = ""
+
+	return nil
+}
+
+// openAuditEntry restores the details of an entry from its encrypted payload.
+func openAuditEntry(entry *AuditEntry, keys *auditKeys) error {
+	if entry.Payload == "" {
+		return errors.New("entry is not encrypted")
+	}
+	ciphertext, err := hex.DecodeString(strings.TrimPrefix(entry.Payload, "0x"))
+	if err != nil {
+		return errors.Wrap(err, "invalid payload")
+	}
+	aead, err := auditAEAD(keys)
+	if err != nil {
+		return err
+	}
+	if len(ciphertext) < aead.NonceSize() {
+		return errors.New("payload too short")
+	}
+	plaintext, err := aead.Open(nil, ciphertext[:aead.NonceSize()], ciphertext[aead.NonceSize():], nil)
+	if err != nil {
+		return errors.Wrap(err, "failed to decrypt payload")
+	}
+	payload := &auditPayload{}
+	if err := json.Unmarshal(plaintext, payload); err != nil {
+		return errors.Wrap(err, "invalid payload")
+	}
+
+	entry.User = payload.User
+	entry.Host = payload.Host
+	entry.Command = payload.Command
+	entry.PublicKeys = payload.PublicKeys
+	entry.Root = payload.Root
+	entry.Domain = payload.Domain
+	entry.Operation = payload.Operation
+	entry.Payload = ""
+
+	return nil
+}
+
+func auditAEAD(keys *auditKeys) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(keys.encryption)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create cipher")
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create cipher")
+	}
+
+	return aead, nil
+}
+
+// auditUser returns the name of the user running the command.
+func auditUser() string {
+	if current, err := user.Current(); err == nil && current.Username != "" {
+		return current.Username
+	}
+	for _, name := range []string{"USER", "USERNAME"} {
+		if value := strings.TrimSpace(os.Getenv(name)); value != "" {
+			return value
+		}
+	}
+
+	return "unknown"
+}
+
+func auditPublicKeys(pubKeys [][]byte) []string {
+	var res []string
+	for _, pubKey := range pubKeys {
+		if len(pubKey) > 0 {
+			res = append(res, fmt.Sprintf("%#x", pubKey))
+		}
+	}
+
+	return res
+}
+
+// AccountPublicKey returns the public key of an account for the audit log,
+// or nil if the account does not provide one.  For distributed accounts this
+// is the public key of the local share.
+func AccountPublicKey(account e2wtypes.Account) []byte {
+	if provider, isProvider := account.(e2wtypes.AccountPublicKeyProvider); isProvider && provider.PublicKey() != nil {
+		return provider.PublicKey().Marshal()
+	}
+
+	return nil
+}
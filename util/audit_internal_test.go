@@ -0,0 +1,378 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecordAudit(t *testing.T) {
+	viper.Reset()
+	dir := t.TempDir()
+
+	// Nothing is written if an audit log is not configured.
+	require.NoError(t, AuditBroadcast("test"))
+	files, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	require.Len(t, files, 0)
+
+	logFile := filepath.Join(dir, "audit.jsonl")
+	viper.Set("log", logFile)
+	defer viper.Reset()
+	SetAuditCommand("ethdo test")
+
+	require.NoError(t, AuditSigning([]byte{0x01, 0x02}, bytes.Repeat([]byte{0x03}, 32), bytes.Repeat([]byte{0x04}, 32)))
+	require.NoError(t, AuditBroadcast("voluntary exit for validator 1", []byte{0x01, 0x02}))
+	require.NoError(t, AuditExport("wallet test"))
+
+	f, err := os.Open(logFile)
+	require.NoError(t, err)
+	defer f.Close()
+	summary, err := VerifyAuditLog(f, "")
+	require.NoError(t, err)
+	require.Equal(t, uint64(3), summary.Entries)
+	require.Len(t, summary.Log, 3)
+
+	f2, err := os.Open(logFile)
+	require.NoError(t, err)
+	defer f2.Close()
+	last, err := lastAuditEntry(f2)
+	require.NoError(t, err)
+	require.Equal(t, uint64(3), last.Sequence)
+	require.Equal(t, "ethdo test", last.Command)
+	require.Equal(t, AuditActionExport, last.Action)
+	require.Equal(t, "wallet test", last.Operation)
+	require.Nil(t, last.PublicKeys)
+	require.Equal(t, summary.HeadHash, last.Hash)
+}
+
+func TestVerifyAuditLog(t *testing.T) {
+	dir := t.TempDir()
+	logFile := filepath.Join(dir, "audit.jsonl")
+	viper.Set("log", logFile)
+	SetAuditCommand("ethdo test")
+	require.NoError(t, AuditSigning([]byte{0x01}, bytes.Repeat([]byte{0x03}, 32), bytes.Repeat([]byte{0x04}, 32)))
+	require.NoError(t, AuditBroadcast("voluntary exit for validator 1", []byte{0x01}))
+	require.NoError(t, AuditBroadcast("voluntary exit for validator 2", []byte{0x02}))
+	viper.Reset()
+
+	data, err := os.ReadFile(logFile)
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	require.Len(t, lines, 4)
+	header := lines[0]
+
+	tests := []struct {
+		name    string
+		input   string
+		key     string
+		entries uint64
+		err     string
+	}{
+		{
+			name: "Empty",
+		},
+		{
+			name:  "HeaderOnly",
+			input: header,
+		},
+		{
+			name:    "Good",
+			input:   string(data),
+			entries: 3,
+		},
+		{
+			name:    "Truncated",
+			input:   strings.Join(lines[:3], "\n"),
+			entries: 2,
+		},
+		{
+			name:  "HeaderMissing",
+			input: strings.Join(lines[1:], "\n"),
+			err:   "line 1: unsupported audit log version 0",
+		},
+		{
+			name:  "HeaderAltered",
+			input: strings.Join([]string{`{"audit_log_version":1,"keyed":false }`, lines[1], lines[2], lines[3]}, "\n"),
+			err:   "line 2: previous hash does not match hash of preceding entry",
+		},
+		{
+			name:  "Invalid",
+			input: header + "\n" + lines[1] + "\n{bad\n",
+			err:   "line 3: invalid entry: invalid character 'b' looking for beginning of object key string",
+		},
+		{
+			name:  "FirstRemoved",
+			input: strings.Join([]string{header, lines[2], lines[3]}, "\n"),
+			err:   "line 2: sequence 2 found but 1 expected",
+		},
+		{
+			name:  "MiddleRemoved",
+			input: strings.Join([]string{header, lines[1], lines[3]}, "\n"),
+			err:   "line 3: sequence 3 found but 2 expected",
+		},
+		{
+			name:  "Reordered",
+			input: strings.Join([]string{header, lines[1], lines[3], lines[2]}, "\n"),
+			err:   "line 3: sequence 3 found but 2 expected",
+		},
+		{
+			name:  "Altered",
+			input: strings.Join([]string{header, lines[1], strings.Replace(lines[2], "validator 1", "validator 9", 1), lines[3]}, "\n"),
+			err:   "line 3: hash does not match entry contents",
+		},
+		{
+			name:  "Resequenced",
+			input: strings.Join([]string{header, lines[1], strings.Replace(lines[3], `"sequence":3`, `"sequence":2`, 1)}, "\n"),
+			err:   "line 3: previous hash does not match hash of preceding entry",
+		},
+		{
+			name:  "KeySupplied",
+			input: string(data),
+			key:   "log secret",
+			err:   "log is not authenticated with a log key, so one cannot be supplied",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			summary, err := VerifyAuditLog(strings.NewReader(test.input), test.key)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, test.entries, summary.Entries)
+			}
+		})
+	}
+}
+
+func TestRecordAuditKeyed(t *testing.T) {
+	dir := t.TempDir()
+	logFile := filepath.Join(dir, "audit.jsonl")
+	viper.Set("log", logFile)
+	viper.Set("log-key", "log secret")
+	SetAuditCommand("ethdo test")
+	require.NoError(t, AuditSigning([]byte{0x01}, bytes.Repeat([]byte{0x03}, 32), bytes.Repeat([]byte{0x04}, 32)))
+	require.NoError(t, AuditBroadcast("voluntary exit for validator 1", []byte{0x01}))
+	viper.Reset()
+
+	data, err := os.ReadFile(logFile)
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	require.Len(t, lines, 3)
+
+	// Details of the entries are not visible without the key.
+	require.NotContains(t, string(data), "ethdo test")
+	require.NotContains(t, string(data), "validator 1")
+
+	// A hash chain recalculated without the key does not verify.
+	forged := &AuditEntry{
+		Sequence:  1,
+		Action:    AuditActionExport,
+		Operation: "forged",
+		PrevHash:  auditLineHash([]byte(lines[0])),
+	}
+	forged.Hash, err = auditHash(forged, nil)
+	require.NoError(t, err)
+	forgedLine, err := json.Marshal(forged)
+	require.NoError(t, err)
+
+	// A second log with the same key has a different salt, so different keys.
+	otherLogFile := filepath.Join(dir, "other.jsonl")
+	viper.Set("log", otherLogFile)
+	viper.Set("log-key", "log secret")
+	require.NoError(t, AuditExport("wallet test"))
+	viper.Reset()
+	otherData, err := os.ReadFile(otherLogFile)
+	require.NoError(t, err)
+	otherLines := strings.Split(strings.TrimSpace(string(otherData)), "\n")
+	require.NotEqual(t, lines[0], otherLines[0])
+
+	tests := []struct {
+		name    string
+		input   string
+		key     string
+		entries uint64
+		err     string
+	}{
+		{
+			name:  "KeyMissing",
+			input: string(data),
+			err:   "log is authenticated with a log key, which is required",
+		},
+		{
+			name:  "KeyWrong",
+			input: string(data),
+			key:   "wrong secret",
+			err:   "line 2: hash does not match entry contents",
+		},
+		{
+			name:  "Forged",
+			input: lines[0] + "\n" + string(forgedLine),
+			key:   "log secret",
+			err:   "line 2: hash does not match entry contents",
+		},
+		{
+			name:  "HeaderSwapped",
+			input: strings.Join([]string{otherLines[0], lines[1], lines[2]}, "\n"),
+			key:   "log secret",
+			err:   "line 2: previous hash does not match hash of preceding entry",
+		},
+		{
+			name:    "Good",
+			input:   string(data),
+			key:     "log secret",
+			entries: 2,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			summary, err := VerifyAuditLog(strings.NewReader(test.input), test.key)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, test.entries, summary.Entries)
+				require.Equal(t, "ethdo test", summary.Log[0].Command)
+				require.Equal(t, []string{"0x01"}, summary.Log[0].PublicKeys)
+				require.Equal(t, "voluntary exit for validator 1", summary.Log[1].Operation)
+				require.Empty(t, summary.Log[1].Payload)
+			}
+		})
+	}
+}
+
+func TestRecordAuditKeyMismatch(t *testing.T) {
+	dir := t.TempDir()
+	defer viper.Reset()
+
+	plainLogFile := filepath.Join(dir, "plain.jsonl")
+	viper.Set("log", plainLogFile)
+	require.NoError(t, AuditExport("wallet test"))
+	viper.Set("log-key", "log secret")
+	require.EqualError(t, AuditExport("wallet test"), "audit log is not authenticated with a log key, so one cannot be supplied")
+
+	keyedLogFile := filepath.Join(dir, "keyed.jsonl")
+	viper.Set("log", keyedLogFile)
+	require.NoError(t, AuditExport("wallet test"))
+	viper.Set("log-key", "")
+	require.EqualError(t, AuditExport("wallet test"), "audit log is authenticated with a log key, which must be supplied")
+}
+
+func TestRecordAuditLocked(t *testing.T) {
+	dir := t.TempDir()
+	logFile := filepath.Join(dir, "audit.jsonl")
+	viper.Set("log", logFile)
+	defer viper.Reset()
+	require.NoError(t, AuditExport("wallet test"))
+
+	// Another process holding the lock prevents entries being appended until it is released.
+	f, err := os.OpenFile(logFile, os.O_RDWR, 0600)
+	require.NoError(t, err)
+	defer f.Close()
+	require.NoError(t, lockAuditLog(f))
+	done := make(chan error)
+	go func() {
+		done <- AuditExport("wallet test")
+	}()
+	select {
+	case <-done:
+		require.Fail(t, "entry appended while log locked")
+	case <-time.After(100 * time.Millisecond):
+	}
+	require.NoError(t, unlockAuditLog(f))
+	require.NoError(t, <-done)
+
+	data, err := os.ReadFile(logFile)
+	require.NoError(t, err)
+	summary, err := VerifyAuditLog(bytes.NewReader(data), "")
+	require.NoError(t, err)
+	require.Equal(t, uint64(2), summary.Entries)
+}
+
+func TestLastAuditEntry(t *testing.T) {
+	dir := t.TempDir()
+
+	tests := []struct {
+		name     string
+		input    string
+		sequence uint64
+		err      string
+	}{
+		{
+			name: "Empty",
+		},
+		{
+			name:  "Whitespace",
+			input: "\n\n",
+		},
+		{
+			name:  "HeaderOnly",
+			input: "{\"audit_log_version\":1,\"keyed\":false}\n",
+		},
+		{
+			name:     "Single",
+			input:    "{\"audit_log_version\":1,\"keyed\":false}\n{\"sequence\":1}",
+			sequence: 1,
+		},
+		{
+			name:     "TrailingNewlines",
+			input:    "{\"audit_log_version\":1,\"keyed\":false}\n{\"sequence\":1}\n{\"sequence\":2}\n\n",
+			sequence: 2,
+		},
+		{
+			name:  "Invalid",
+			input: "{\"audit_log_version\":1,\"keyed\":false}\n{\"sequence\":1}\n{bad\n",
+			err:   "failed to parse last audit log entry: invalid character 'b' looking for beginning of object key string",
+		},
+		{
+			name:     "LargeLog",
+			input:    strings.Repeat(fmt.Sprintf("{\"operation\":\"%s\"}\n", strings.Repeat("x", 1000)), 2000) + "{\"sequence\":2001}\n",
+			sequence: 2001,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			logFile := filepath.Join(dir, test.name)
+			require.NoError(t, os.WriteFile(logFile, []byte(test.input), 0600))
+			f, err := os.Open(logFile)
+			require.NoError(t, err)
+			defer f.Close()
+			entry, err := lastAuditEntry(f)
+			switch {
+			case test.err != "":
+				require.EqualError(t, err, test.err)
+			case test.sequence == 0:
+				require.NoError(t, err)
+				require.Nil(t, entry)
+			default:
+				require.NoError(t, err)
+				require.Equal(t, test.sequence, entry.Sequence)
+			}
+		})
+	}
+}
@@ -1,4 +1,4 @@
-// Copyright © 2020 Weald Technology Trading
+// Copyright © 2020, 2022 Weald Technology Trading
 // Licensed under the Apache License, Version 2.0 (the "License");
 // you may not use this file except in compliance with the License.
 // You may obtain a copy of the License at
@@ -17,13 +17,19 @@ import (
 	"context"
 	"fmt"
 	"net/url"
+	"os"
+	"sort"
 	"strings"
+	"sync"
 	"time"
 
 	eth2client "github.com/attestantio/go-eth2-client"
 	"github.com/attestantio/go-eth2-client/http"
+	"github.com/attestantio/go-eth2-client/multi"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/pkg/errors"
 	"github.com/rs/zerolog"
+	"github.com/spf13/viper"
 )
 
 // defaultBeaconNodeAddresses are default REST endpoint addresses for beacon nodes.
@@ -33,32 +39,295 @@ var defaultBeaconNodeAddresses = []string{
 	"localhost:3500", // Prysm
 }
 
+// maxHeadSlotLag is the number of slots a beacon node's head can be behind that of
+// the best beacon node before it is considered unhealthy.
+var maxHeadSlotLag = phase0.Slot(4)
+
 // ConnectToBeaconNode connects to a beacon node at the given address.
+// The address can be a comma-separated list of addresses, in which case the
+// beacon nodes are health checked and requests fail over between them.
 func ConnectToBeaconNode(ctx context.Context, address string, timeout time.Duration, allowInsecure bool) (eth2client.Service, error) {
 	if timeout == 0 {
 		return nil, errors.New("no timeout specified")
 	}
 
-	if address != "" {
+	client, err := connectToAddresses(ctx, address, timeout, allowInsecure)
+	if err != nil {
+		return nil, NewCodedError(ErrorCodeConnectionFailed, err)
+	}
+
+	if viper.GetString("cache-dir") != "" {
+		cache, err := OpenCache(ctx, viper.GetString("cache-dir"))
+		if err != nil {
+			return nil, err
+		}
+		debugBeaconNode("Using cache at %s", viper.GetString("cache-dir"))
+		return newCachedService(client, cache), nil
+	}
+
+	return client, nil
+}
+
+// connectToAddresses connects to the beacon node or nodes in the comma-separated address.
+func connectToAddresses(ctx context.Context, address string, timeout time.Duration, allowInsecure bool) (eth2client.Service, error) {
+	addresses := make([]string, 0)
+	for _, address := range strings.Split(address, ",") {
+		address = strings.TrimSpace(address)
+		if address != "" {
+			addresses = append(addresses, address)
+		}
+	}
+
+	switch len(addresses) {
+	case 0:
+		// Try the defaults.
+		for _, address := range defaultBeaconNodeAddresses {
+			client, err := connectToBeaconNode(ctx, address, timeout, allowInsecure)
+			if err == nil {
+				debugBeaconNode("Using beacon node at %s", client.Address())
+				return client, nil
+			}
+		}
+		return nil, errors.New("failed to connect to any beacon node")
+	case 1:
 		// We have an explicit address; use it.
-		return connectToBeaconNode(ctx, address, timeout, allowInsecure)
+		client, err := connectToBeaconNode(ctx, addresses[0], timeout, allowInsecure)
+		if err != nil {
+			return nil, err
+		}
+		debugBeaconNode("Using beacon node at %s", client.Address())
+		return client, nil
+	default:
+		return connectToBeaconNodes(ctx, addresses, timeout, allowInsecure)
 	}
+}
+
+// beaconNodeHealth is the health of a beacon node.
+type beaconNodeHealth struct {
+	client    eth2client.Service
+	syncing   bool
+	headSlot  phase0.Slot
+	available bool
+}
 
-	// Try the defaults.
-	for _, address := range defaultBeaconNodeAddresses {
+// connectToBeaconNodes connects to multiple beacon nodes, ordering them by health.
+func connectToBeaconNodes(ctx context.Context, addresses []string, timeout time.Duration, allowInsecure bool) (eth2client.Service, error) {
+	healths := make([]*beaconNodeHealth, 0, len(addresses))
+	for _, address := range addresses {
 		client, err := connectToBeaconNode(ctx, address, timeout, allowInsecure)
+		if err != nil {
+			debugBeaconNode("Beacon node at %s unavailable: %v", address, err)
+			continue
+		}
+		health := checkBeaconNodeHealth(ctx, client)
+		healths = append(healths, health)
+		if health.available {
+			debugBeaconNode("Beacon node at %s: head slot %d, syncing %t", client.Address(), health.headSlot, health.syncing)
+		} else {
+			debugBeaconNode("Beacon node at %s: sync state unavailable", client.Address())
+		}
+	}
+	if len(healths) == 0 {
+		return nil, errors.New("failed to connect to any beacon node")
+	}
+
+	clients := orderBeaconNodes(healths)
+
+	client, err := multi.New(ctx,
+		multi.WithLogLevel(zerolog.Disabled),
+		multi.WithTimeout(timeout),
+		multi.WithClients(clients),
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to connect to beacon nodes")
+	}
+	debugBeaconNode("Using beacon node at %s", client.Address())
+	failover := newFailoverService(client, clients)
+
+	if viper.GetBool("connection-first-response") {
+		debugBeaconNode("Using first successful response from %d beacon nodes for reads", len(clients))
+		return newFirstResponseService(failover, clients), nil
+	}
+
+	return failover, nil
+}
+
+// failoverService is a client that fails over between multiple beacon nodes.  Read
+// requests are made to the beacon nodes in order of health; if a request fails the
+// health of the beacon nodes is checked again and the request is retried with the
+// healthiest beacon node that has not yet been tried.  Other requests are passed to
+// the underlying service.
+type failoverService struct {
+	*dispatchService
+	clientsMu sync.RWMutex
+	clients   []eth2client.Service
+}
+
+// newFailoverService creates a new failover service.
+func newFailoverService(service eth2client.Service, clients []eth2client.Service) *failoverService {
+	s := &failoverService{
+		clients: clients,
+	}
+	s.dispatchService = &dispatchService{
+		wrappedService: &wrappedService{Service: service},
+		dispatch:       s.failover,
+	}
+	return s
+}
+
+// failover calls the beacon nodes in order of health until one succeeds, checking
+// their health again after each failure.  If all beacon nodes fail the last error
+// is returned.
+func (s *failoverService) failover(ctx context.Context, call callFunc) (interface{}, error) {
+	tried := make(map[eth2client.Service]bool)
+	var err error
+	for {
+		client := s.nextClient(tried)
+		if client == nil {
+			return nil, err
+		}
+		tried[client] = true
+
+		var res interface{}
+		res, err = call(ctx, client)
 		if err == nil {
-			return client, nil
+			return res, nil
 		}
+		if ctx.Err() != nil {
+			return nil, err
+		}
+		debugBeaconNode("Request to beacon node at %s failed: %v", client.Address(), err)
+		s.recheck(ctx)
 	}
+}
+
+// nextClient returns the healthiest beacon node that has not been tried, or nil if
+// all have been tried.
+func (s *failoverService) nextClient(tried map[eth2client.Service]bool) eth2client.Service {
+	s.clientsMu.RLock()
+	defer s.clientsMu.RUnlock()
 
-	return nil, errors.New("failed to connect to any beacon node")
+	for _, client := range s.clients {
+		if !tried[client] {
+			return client
+		}
+	}
+	return nil
 }
 
-func connectToBeaconNode(ctx context.Context, address string, timeout time.Duration, allowInsecure bool) (eth2client.Service, error) {
+// recheck checks the health of the beacon nodes and reorders them accordingly.
+func (s *failoverService) recheck(ctx context.Context) {
+	clients := s.beaconNodeClients()
+	healths := make([]*beaconNodeHealth, 0, len(clients))
+	for _, client := range clients {
+		healths = append(healths, checkBeaconNodeHealth(ctx, client))
+	}
+	clients = orderBeaconNodes(healths)
+
+	s.clientsMu.Lock()
+	s.clients = clients
+	s.clientsMu.Unlock()
+	debugBeaconNode("Using beacon node at %s", clients[0].Address())
+}
+
+// beaconNodeClients returns the individual beacon nodes, in order of health.
+func (s *failoverService) beaconNodeClients() []eth2client.Service {
+	s.clientsMu.RLock()
+	defer s.clientsMu.RUnlock()
+
+	clients := make([]eth2client.Service, len(s.clients))
+	copy(clients, s.clients)
+	return clients
+}
+
+// beaconNodeClientsProvider is implemented by clients that front one or more beacon nodes.
+type beaconNodeClientsProvider interface {
+	beaconNodeClients() []eth2client.Service
+}
+
+// beaconNodeClients returns the individual beacon nodes behind a client, in order of preference.
+func beaconNodeClients(client eth2client.Service) []eth2client.Service {
+	if provider, isProvider := client.(beaconNodeClientsProvider); isProvider {
+		return provider.beaconNodeClients()
+	}
+	return []eth2client.Service{client}
+}
+
+// checkBeaconNodeHealth checks the sync state of a beacon node.
+func checkBeaconNodeHealth(ctx context.Context, client eth2client.Service) *beaconNodeHealth {
+	health := &beaconNodeHealth{
+		client: client,
+	}
+	provider, isProvider := client.(eth2client.NodeSyncingProvider)
+	if !isProvider {
+		return health
+	}
+	syncState, err := provider.NodeSyncing(ctx)
+	if err != nil || syncState == nil {
+		return health
+	}
+	health.available = true
+	health.syncing = syncState.IsSyncing
+	health.headSlot = syncState.HeadSlot
+
+	return health
+}
+
+// orderBeaconNodes orders beacon nodes by health: synced nodes close to the best
+// head slot first, then lagging nodes, then syncing nodes, then nodes whose sync
+// state is unknown.  Within each group nodes are ordered by head slot, highest first.
+func orderBeaconNodes(healths []*beaconNodeHealth) []eth2client.Service {
+	bestHeadSlot := phase0.Slot(0)
+	for _, health := range healths {
+		if health.available && !health.syncing && health.headSlot > bestHeadSlot {
+			bestHeadSlot = health.headSlot
+		}
+	}
+
+	rank := func(health *beaconNodeHealth) int {
+		switch {
+		case !health.available:
+			return 3
+		case health.syncing:
+			return 2
+		case health.headSlot+maxHeadSlotLag < bestHeadSlot:
+			return 1
+		default:
+			return 0
+		}
+	}
+
+	sort.SliceStable(healths, func(i, j int) bool {
+		if rank(healths[i]) != rank(healths[j]) {
+			return rank(healths[i]) < rank(healths[j])
+		}
+		return healths[i].headSlot > healths[j].headSlot
+	})
+
+	clients := make([]eth2client.Service, 0, len(healths))
+	for _, health := range healths {
+		clients = append(clients, health.client)
+	}
+	return clients
+}
+
+// debugBeaconNode outputs beacon node connection information if debug is enabled.
+func debugBeaconNode(format string, args ...interface{}) {
+	if viper.GetBool("debug") {
+		fmt.Fprintf(os.Stderr, format+"\n", args...)
+	}
+}
+
+// beaconNodeURL returns the URL of a beacon node given its address, which may omit the scheme.
+func beaconNodeURL(address string) string {
 	if !strings.HasPrefix(address, "http") {
 		address = fmt.Sprintf("http://%s", address)
 	}
+	return address
+}
+
+func connectToBeaconNode(ctx context.Context, address string, timeout time.Duration, allowInsecure bool) (eth2client.Service, error) {
+	address = beaconNodeURL(address)
 	if !allowInsecure {
 		// Ensure the connection is either secure or local.
 		connectionURL, err := url.Parse(address)
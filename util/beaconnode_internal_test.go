@@ -0,0 +1,204 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+)
+
+// A mock Ethereum 2 client service that returns sync and genesis information.
+type syncETH2Client struct {
+	address   string
+	syncState *apiv1.SyncState
+	delay     time.Duration
+	err       error
+}
+
+// Name returns the name of the client implementation.
+func (c *syncETH2Client) Name() string {
+	return "sync mock"
+}
+
+// Address returns the address of the client.
+func (c *syncETH2Client) Address() string {
+	return c.address
+}
+
+// NodeSyncing provides the sync state of the node.
+func (c *syncETH2Client) NodeSyncing(ctx context.Context) (*apiv1.SyncState, error) {
+	if c.err != nil {
+		return nil, c.err
+	}
+	return c.syncState, nil
+}
+
+// Genesis provides the genesis of the chain.
+func (c *syncETH2Client) Genesis(ctx context.Context) (*apiv1.Genesis, error) {
+	select {
+	case <-time.After(c.delay):
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	if c.err != nil {
+		return nil, c.err
+	}
+	return &apiv1.Genesis{
+		GenesisForkVersion: phase0.Version{c.address[0]},
+	}, nil
+}
+
+func TestOrderBeaconNodes(t *testing.T) {
+	synced := &syncETH2Client{address: "synced", syncState: &apiv1.SyncState{HeadSlot: 100}}
+	ahead := &syncETH2Client{address: "ahead", syncState: &apiv1.SyncState{HeadSlot: 101}}
+	lagging := &syncETH2Client{address: "lagging", syncState: &apiv1.SyncState{HeadSlot: 90}}
+	syncing := &syncETH2Client{address: "syncing", syncState: &apiv1.SyncState{HeadSlot: 200, IsSyncing: true}}
+	unavailable := &syncETH2Client{address: "unavailable", err: errors.New("unavailable")}
+
+	tests := []struct {
+		name    string
+		clients []*syncETH2Client
+		order   []string
+	}{
+		{
+			name:    "Single",
+			clients: []*syncETH2Client{synced},
+			order:   []string{"synced"},
+		},
+		{
+			name:    "HeadSlot",
+			clients: []*syncETH2Client{synced, ahead},
+			order:   []string{"ahead", "synced"},
+		},
+		{
+			name:    "All",
+			clients: []*syncETH2Client{unavailable, syncing, lagging, synced, ahead},
+			order:   []string{"ahead", "synced", "lagging", "syncing", "unavailable"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			healths := make([]*beaconNodeHealth, 0, len(test.clients))
+			for _, client := range test.clients {
+				healths = append(healths, checkBeaconNodeHealth(context.Background(), client))
+			}
+			order := make([]string, 0, len(test.clients))
+			for _, client := range orderBeaconNodes(healths) {
+				order = append(order, client.Address())
+			}
+			require.Equal(t, test.order, order)
+		})
+	}
+}
+
+func TestFirstResponse(t *testing.T) {
+	fast := &syncETH2Client{address: "fast", delay: time.Millisecond}
+	slow := &syncETH2Client{address: "slow", delay: 100 * time.Millisecond}
+	failed := &syncETH2Client{address: "failed", err: errors.New("failed")}
+
+	tests := []struct {
+		name    string
+		clients []eth2client.Service
+		res     string
+		err     string
+	}{
+		{
+			name:    "Fastest",
+			clients: []eth2client.Service{slow, fast},
+			res:     "fast",
+		},
+		{
+			name:    "Failover",
+			clients: []eth2client.Service{failed, slow},
+			res:     "slow",
+		},
+		{
+			name:    "AllFailed",
+			clients: []eth2client.Service{failed},
+			err:     "failed",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			s := newFirstResponseService(test.clients[0], test.clients)
+			genesis, err := s.Genesis(context.Background())
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, phase0.Version{test.res[0]}, genesis.GenesisForkVersion)
+			}
+		})
+	}
+}
+
+func TestFailover(t *testing.T) {
+	synced := &syncETH2Client{address: "synced", syncState: &apiv1.SyncState{HeadSlot: 100}}
+	lagging := &syncETH2Client{address: "lagging", syncState: &apiv1.SyncState{HeadSlot: 90}}
+	failed := &syncETH2Client{address: "failed", err: errors.New("failed")}
+
+	tests := []struct {
+		name    string
+		clients []eth2client.Service
+		res     string
+		err     string
+		order   []string
+	}{
+		{
+			name:    "First",
+			clients: []eth2client.Service{synced, lagging},
+			res:     "synced",
+			order:   []string{"synced", "lagging"},
+		},
+		{
+			name:    "Failover",
+			clients: []eth2client.Service{failed, lagging, synced},
+			res:     "synced",
+			order:   []string{"synced", "lagging", "failed"},
+		},
+		{
+			name:    "AllFailed",
+			clients: []eth2client.Service{failed},
+			err:     "failed",
+			order:   []string{"failed"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			s := newFailoverService(test.clients[0], test.clients)
+			genesis, err := s.Genesis(context.Background())
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, phase0.Version{test.res[0]}, genesis.GenesisForkVersion)
+			}
+			order := make([]string, 0, len(test.clients))
+			for _, client := range s.beaconNodeClients() {
+				order = append(order, client.Address())
+			}
+			require.Equal(t, test.order, order)
+		})
+	}
+}
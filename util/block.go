@@ -0,0 +1,225 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/altair"
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+)
+
+// signedBeaconBlockBodyOffsetPos is the position in an SSZ signed beacon block
+// of the first offset in the block body, which is also the size of the fixed
+// part of the body.
+const signedBeaconBlockBodyOffsetPos = 384
+
+// beaconBlockBodyFixedSizes are the sizes of the fixed part of the block body
+// for each fork.
+var beaconBlockBodyFixedSizes = map[uint32]spec.DataVersion{
+	220: spec.DataVersionPhase0,
+	380: spec.DataVersionAltair,
+	384: spec.DataVersionBellatrix,
+}
+
+type versionedJSON struct {
+	Version *spec.DataVersion `json:"version"`
+	Data    json.RawMessage   `json:"data"`
+}
+
+// ParseSignedBeaconBlock parses a signed beacon block.  JSON blocks can either
+// be wrapped with their version, as returned by the beacon node API, or bare, in
+// which case the most recent fork with a matching structure is used.  SSZ blocks
+// can be raw or hex-encoded, with the fork detected from the size of the fixed
+// part of the block body.
+func ParseSignedBeaconBlock(input []byte) (*spec.VersionedSignedBeaconBlock, error) {
+	data, isJSON, err := decodeInput(input)
+	if err != nil {
+		return nil, err
+	}
+	if !isJSON {
+		return parseSignedBeaconBlockSSZ(data)
+	}
+
+	versioned := &versionedJSON{}
+	if err := json.Unmarshal(input, versioned); err == nil && versioned.Version != nil && len(versioned.Data) > 0 {
+		return parseSignedBeaconBlockVersion(*versioned.Version, versioned.Data)
+	}
+
+	for _, version := range []spec.DataVersion{spec.DataVersionBellatrix, spec.DataVersionAltair, spec.DataVersionPhase0} {
+		var block *spec.VersionedSignedBeaconBlock
+		block, err = parseSignedBeaconBlockVersion(version, data)
+		if err == nil {
+			return block, nil
+		}
+	}
+
+	return nil, err
+}
+
+func parseSignedBeaconBlockVersion(version spec.DataVersion, input []byte) (*spec.VersionedSignedBeaconBlock, error) {
+	block := &spec.VersionedSignedBeaconBlock{
+		Version: version,
+	}
+	switch version {
+	case spec.DataVersionPhase0:
+		block.Phase0 = &phase0.SignedBeaconBlock{}
+		if err := json.Unmarshal(input, block.Phase0); err != nil {
+			return nil, errors.Wrap(err, "invalid phase0 block")
+		}
+	case spec.DataVersionAltair:
+		block.Altair = &altair.SignedBeaconBlock{}
+		if err := json.Unmarshal(input, block.Altair); err != nil {
+			return nil, errors.Wrap(err, "invalid altair block")
+		}
+	case spec.DataVersionBellatrix:
+		block.Bellatrix = &bellatrix.SignedBeaconBlock{}
+		if err := json.Unmarshal(input, block.Bellatrix); err != nil {
+			return nil, errors.Wrap(err, "invalid bellatrix block")
+		}
+	default:
+		return nil, fmt.Errorf("unhandled block version %v", version)
+	}
+
+	return block, nil
+}
+
+func parseSignedBeaconBlockSSZ(input []byte) (*spec.VersionedSignedBeaconBlock, error) {
+	if len(input) < signedBeaconBlockBodyOffsetPos+4 {
+		return nil, errors.New("SSZ block too short")
+	}
+	version, exists := beaconBlockBodyFixedSizes[binary.LittleEndian.Uint32(input[signedBeaconBlockBodyOffsetPos:])]
+	if !exists {
+		return nil, errors.New("SSZ block does not match a known fork")
+	}
+
+	block := &spec.VersionedSignedBeaconBlock{
+		Version: version,
+	}
+	switch version {
+	case spec.DataVersionPhase0:
+		block.Phase0 = &phase0.SignedBeaconBlock{}
+		if err := block.Phase0.UnmarshalSSZ(input); err != nil {
+			return nil, errors.Wrap(err, "invalid phase0 block")
+		}
+	case spec.DataVersionAltair:
+		block.Altair = &altair.SignedBeaconBlock{}
+		if err := block.Altair.UnmarshalSSZ(input); err != nil {
+			return nil, errors.Wrap(err, "invalid altair block")
+		}
+	case spec.DataVersionBellatrix:
+		block.Bellatrix = &bellatrix.SignedBeaconBlock{}
+		if err := block.Bellatrix.UnmarshalSSZ(input); err != nil {
+			return nil, errors.Wrap(err, "invalid bellatrix block")
+		}
+	}
+
+	return block, nil
+}
+
+// decodeInput decodes input that may be JSON, hex-encoded SSZ or raw SSZ.  It
+// returns the decoded data, and if the data is JSON.
+func decodeInput(input []byte) ([]byte, bool, error) {
+	trimmed := bytes.TrimSpace(input)
+	if len(trimmed) == 0 {
+		return nil, false, errors.New("no data supplied")
+	}
+	if trimmed[0] == '{' {
+		return trimmed, true, nil
+	}
+
+	hexData := strings.Join(strings.Fields(strings.TrimPrefix(string(trimmed), "0x")), "")
+	if len(hexData)%2 == 0 && strings.Trim(hexData, "0123456789abcdefABCDEF") == "" {
+		data, err := hex.DecodeString(hexData)
+		if err != nil {
+			return nil, false, errors.Wrap(err, "invalid hex data")
+		}
+		return data, false, nil
+	}
+
+	return input, false, nil
+}
+
+// BlockSignature obtains the signature of the block.
+func BlockSignature(block *spec.VersionedSignedBeaconBlock) (phase0.BLSSignature, error) {
+	switch block.Version {
+	case spec.DataVersionPhase0:
+		return block.Phase0.Signature, nil
+	case spec.DataVersionAltair:
+		return block.Altair.Signature, nil
+	case spec.DataVersionBellatrix:
+		return block.Bellatrix.Signature, nil
+	default:
+		return phase0.BLSSignature{}, fmt.Errorf("unhandled block version %v", block.Version)
+	}
+}
+
+// BlockProposerIndex obtains the index of the validator that proposed the block.
+func BlockProposerIndex(block *spec.VersionedSignedBeaconBlock) (phase0.ValidatorIndex, error) {
+	switch block.Version {
+	case spec.DataVersionPhase0:
+		return block.Phase0.Message.ProposerIndex, nil
+	case spec.DataVersionAltair:
+		return block.Altair.Message.ProposerIndex, nil
+	case spec.DataVersionBellatrix:
+		return block.Bellatrix.Message.ProposerIndex, nil
+	default:
+		return 0, fmt.Errorf("unhandled block version %v", block.Version)
+	}
+}
+
+// BlockAtSlot obtains the block at the given slot, or nil if there is no block at the slot.
+// If blocks is not nil it is used as a cache of blocks by slot, including empty slots.
+func BlockAtSlot(ctx context.Context,
+	provider eth2client.SignedBeaconBlockProvider,
+	blocks map[phase0.Slot]*spec.VersionedSignedBeaconBlock,
+	slot phase0.Slot,
+) (
+	*spec.VersionedSignedBeaconBlock,
+	error,
+) {
+	if block, exists := blocks[slot]; exists {
+		return block, nil
+	}
+
+	block, err := provider.SignedBeaconBlock(ctx, fmt.Sprintf("%d", slot))
+	if err != nil {
+		return nil, errors.Wrap(err, fmt.Sprintf("failed to obtain block for slot %d", slot))
+	}
+	if block != nil {
+		blockSlot, err := block.Slot()
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to obtain block slot")
+		}
+		if blockSlot != slot {
+			// Some beacon nodes return the previous block for an empty slot.
+			block = nil
+		}
+	}
+	if blocks != nil {
+		blocks[slot] = block
+	}
+
+	return block, nil
+}
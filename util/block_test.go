@@ -0,0 +1,234 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/aaron-alderman/ethdo/util"
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/altair"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/go-bitfield"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSignedBeaconBlock(t *testing.T) {
+	phase0Block := &phase0.SignedBeaconBlock{
+		Message: &phase0.BeaconBlock{
+			Slot:          1,
+			ProposerIndex: 2,
+			Body: &phase0.BeaconBlockBody{
+				ETH1Data:          &phase0.ETH1Data{BlockHash: make([]byte, 32)},
+				Graffiti:          make([]byte, 32),
+				ProposerSlashings: []*phase0.ProposerSlashing{},
+				AttesterSlashings: []*phase0.AttesterSlashing{},
+				Attestations:      []*phase0.Attestation{},
+				Deposits:          []*phase0.Deposit{},
+				VoluntaryExits:    []*phase0.SignedVoluntaryExit{},
+			},
+		},
+		Signature: phase0.BLSSignature{0x03},
+	}
+	phase0Data, err := json.Marshal(phase0Block)
+	require.NoError(t, err)
+
+	altairBlock := &altair.SignedBeaconBlock{
+		Message: &altair.BeaconBlock{
+			Slot:          4,
+			ProposerIndex: 5,
+			Body: &altair.BeaconBlockBody{
+				ETH1Data:          &phase0.ETH1Data{BlockHash: make([]byte, 32)},
+				Graffiti:          make([]byte, 32),
+				ProposerSlashings: []*phase0.ProposerSlashing{},
+				AttesterSlashings: []*phase0.AttesterSlashing{},
+				Attestations:      []*phase0.Attestation{},
+				Deposits:          []*phase0.Deposit{},
+				VoluntaryExits:    []*phase0.SignedVoluntaryExit{},
+				SyncAggregate: &altair.SyncAggregate{
+					SyncCommitteeBits: bitfield.NewBitvector512(),
+				},
+			},
+		},
+		Signature: phase0.BLSSignature{0x06},
+	}
+	altairData, err := json.Marshal(altairBlock)
+	require.NoError(t, err)
+
+	phase0SSZ, err := phase0Block.MarshalSSZ()
+	require.NoError(t, err)
+	altairSSZ, err := altairBlock.MarshalSSZ()
+	require.NoError(t, err)
+
+	tests := []struct {
+		name          string
+		input         []byte
+		version       spec.DataVersion
+		proposerIndex phase0.ValidatorIndex
+		signature     phase0.BLSSignature
+		err           string
+	}{
+		{
+			name:  "Invalid",
+			input: []byte(`{}`),
+			err:   "invalid phase0 block: message missing",
+		},
+		{
+			name:          "Phase0",
+			input:         phase0Data,
+			version:       spec.DataVersionPhase0,
+			proposerIndex: 2,
+			signature:     phase0.BLSSignature{0x03},
+		},
+		{
+			name:          "Altair",
+			input:         altairData,
+			version:       spec.DataVersionAltair,
+			proposerIndex: 5,
+			signature:     phase0.BLSSignature{0x06},
+		},
+		{
+			name:          "Versioned",
+			input:         []byte(fmt.Sprintf(`{"version":"phase0","data":%s}`, string(phase0Data))),
+			version:       spec.DataVersionPhase0,
+			proposerIndex: 2,
+			signature:     phase0.BLSSignature{0x03},
+		},
+		{
+			name:          "Phase0SSZ",
+			input:         phase0SSZ,
+			version:       spec.DataVersionPhase0,
+			proposerIndex: 2,
+			signature:     phase0.BLSSignature{0x03},
+		},
+		{
+			name:          "AltairSSZ",
+			input:         altairSSZ,
+			version:       spec.DataVersionAltair,
+			proposerIndex: 5,
+			signature:     phase0.BLSSignature{0x06},
+		},
+		{
+			name:          "AltairSSZHex",
+			input:         []byte(fmt.Sprintf("0x%x\n", altairSSZ)),
+			version:       spec.DataVersionAltair,
+			proposerIndex: 5,
+			signature:     phase0.BLSSignature{0x06},
+		},
+		{
+			name:  "SSZShort",
+			input: []byte("0x0102"),
+			err:   "SSZ block too short",
+		},
+		{
+			name:  "SSZUnknownFork",
+			input: make([]byte, 400),
+			err:   "SSZ block does not match a known fork",
+		},
+		{
+			name:  "SSZTruncated",
+			input: phase0SSZ[:len(phase0SSZ)-1],
+			err:   "invalid phase0 block: incorrect size",
+		},
+		{
+			name:  "VersionedMismatch",
+			input: []byte(fmt.Sprintf(`{"version":"altair","data":%s}`, string(phase0Data))),
+			err:   "invalid altair block: invalid JSON: invalid JSON: sync aggregate missing",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			block, err := util.ParseSignedBeaconBlock(test.input)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, test.version, block.Version)
+				proposerIndex, err := util.BlockProposerIndex(block)
+				require.NoError(t, err)
+				require.Equal(t, test.proposerIndex, proposerIndex)
+				signature, err := util.BlockSignature(block)
+				require.NoError(t, err)
+				require.Equal(t, test.signature, signature)
+			}
+		})
+	}
+}
+
+// blocksProvider provides blocks by slot, counting the requests it receives.
+type blocksProvider struct {
+	blocks   map[string]*spec.VersionedSignedBeaconBlock
+	requests int
+}
+
+func (p *blocksProvider) SignedBeaconBlock(_ context.Context, blockID string) (*spec.VersionedSignedBeaconBlock, error) {
+	p.requests++
+	if blockID == "9" {
+		return nil, errors.New("unavailable")
+	}
+	return p.blocks[blockID], nil
+}
+
+func TestBlockAtSlot(t *testing.T) {
+	ctx := context.Background()
+	block1 := &spec.VersionedSignedBeaconBlock{
+		Version: spec.DataVersionPhase0,
+		Phase0: &phase0.SignedBeaconBlock{
+			Message: &phase0.BeaconBlock{Slot: 1},
+		},
+	}
+	provider := &blocksProvider{
+		blocks: map[string]*spec.VersionedSignedBeaconBlock{
+			"1": block1,
+			// Some beacon nodes return the previous block for an empty slot.
+			"2": block1,
+		},
+	}
+	blocks := make(map[phase0.Slot]*spec.VersionedSignedBeaconBlock)
+
+	block, err := util.BlockAtSlot(ctx, provider, blocks, 1)
+	require.NoError(t, err)
+	require.Equal(t, block1, block)
+
+	block, err = util.BlockAtSlot(ctx, provider, blocks, 2)
+	require.NoError(t, err)
+	require.Nil(t, block)
+
+	block, err = util.BlockAtSlot(ctx, provider, blocks, 3)
+	require.NoError(t, err)
+	require.Nil(t, block)
+
+	_, err = util.BlockAtSlot(ctx, provider, blocks, 9)
+	require.EqualError(t, err, "failed to obtain block for slot 9: unavailable")
+
+	// Blocks and empty slots are cached.
+	require.Equal(t, 4, provider.requests)
+	block, err = util.BlockAtSlot(ctx, provider, blocks, 1)
+	require.NoError(t, err)
+	require.Equal(t, block1, block)
+	block, err = util.BlockAtSlot(ctx, provider, blocks, 2)
+	require.NoError(t, err)
+	require.Nil(t, block)
+	require.Equal(t, 4, provider.requests)
+
+	// Without a cache every request goes to the provider.
+	_, err = util.BlockAtSlot(ctx, provider, nil, 1)
+	require.NoError(t, err)
+	require.Equal(t, 5, provider.requests)
+}
@@ -0,0 +1,175 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	ssz "github.com/ferranbt/fastssz"
+	"github.com/pkg/errors"
+	ethutil "github.com/wealdtech/go-eth2-util"
+)
+
+// BLSToExecutionChangeDomainType is the domain type for BLS to execution changes.
+var BLSToExecutionChangeDomainType = phase0.DomainType{0x0a, 0x00, 0x00, 0x00}
+
+// ExecutionAddressLength is the length of an execution address.
+const ExecutionAddressLength = 20
+
+// BLSToExecutionChange changes a validator's withdrawal credentials from a BLS key
+// to an execution address.
+type BLSToExecutionChange struct {
+	ValidatorIndex     phase0.ValidatorIndex
+	FromBLSPubkey      phase0.BLSPubKey
+	ToExecutionAddress [ExecutionAddressLength]byte
+}
+
+// SignedBLSToExecutionChange is a BLS to execution change signed by the validator's withdrawal key.
+type SignedBLSToExecutionChange struct {
+	Message   *BLSToExecutionChange
+	Signature phase0.BLSSignature
+}
+
+type blsToExecutionChangeJSON struct {
+	ValidatorIndex     string `json:"validator_index"`
+	FromBLSPubkey      string `json:"from_bls_pubkey"`
+	ToExecutionAddress string `json:"to_execution_address"`
+}
+
+type signedBLSToExecutionChangeJSON struct {
+	Message   *BLSToExecutionChange `json:"message"`
+	Signature string                `json:"signature"`
+}
+
+// MarshalJSON implements json.Marshaler.
+func (c *BLSToExecutionChange) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&blsToExecutionChangeJSON{
+		ValidatorIndex:     fmt.Sprintf("%d", c.ValidatorIndex),
+		FromBLSPubkey:      fmt.Sprintf("%#x", c.FromBLSPubkey),
+		ToExecutionAddress: fmt.Sprintf("%#x", c.ToExecutionAddress),
+	})
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (c *BLSToExecutionChange) UnmarshalJSON(input []byte) error {
+	var data blsToExecutionChangeJSON
+	if err := json.Unmarshal(input, &data); err != nil {
+		return errors.Wrap(err, "invalid JSON")
+	}
+
+	if data.ValidatorIndex == "" {
+		return errors.New("validator index missing")
+	}
+	index, err := strconv.ParseUint(data.ValidatorIndex, 10, 64)
+	if err != nil {
+		return errors.Wrap(err, "validator index invalid")
+	}
+	c.ValidatorIndex = phase0.ValidatorIndex(index)
+
+	if data.FromBLSPubkey == "" {
+		return errors.New("from BLS public key missing")
+	}
+	pubKey, err := hex.DecodeString(strings.TrimPrefix(data.FromBLSPubkey, "0x"))
+	if err != nil {
+		return errors.Wrap(err, "from BLS public key invalid")
+	}
+	if len(pubKey) != phase0.PublicKeyLength {
+		return errors.New("from BLS public key incorrect length")
+	}
+	copy(c.FromBLSPubkey[:], pubKey)
+
+	if data.ToExecutionAddress == "" {
+		return errors.New("to execution address missing")
+	}
+	address, err := hex.DecodeString(strings.TrimPrefix(data.ToExecutionAddress, "0x"))
+	if err != nil {
+		return errors.Wrap(err, "to execution address invalid")
+	}
+	if len(address) != ExecutionAddressLength {
+		return errors.New("to execution address incorrect length")
+	}
+	copy(c.ToExecutionAddress[:], address)
+
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler.
+func (c *SignedBLSToExecutionChange) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&signedBLSToExecutionChangeJSON{
+		Message:   c.Message,
+		Signature: fmt.Sprintf("%#x", c.Signature),
+	})
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (c *SignedBLSToExecutionChange) UnmarshalJSON(input []byte) error {
+	var data signedBLSToExecutionChangeJSON
+	if err := json.Unmarshal(input, &data); err != nil {
+		return errors.Wrap(err, "invalid JSON")
+	}
+
+	if data.Message == nil {
+		return errors.New("message missing")
+	}
+	c.Message = data.Message
+
+	if data.Signature == "" {
+		return errors.New("signature missing")
+	}
+	signature, err := hex.DecodeString(strings.TrimPrefix(data.Signature, "0x"))
+	if err != nil {
+		return errors.Wrap(err, "signature invalid")
+	}
+	if len(signature) != phase0.SignatureLength {
+		return errors.New("signature incorrect length")
+	}
+	copy(c.Signature[:], signature)
+
+	return nil
+}
+
+// HashTreeRoot provides the SSZ hash tree root of the change.
+func (c *BLSToExecutionChange) HashTreeRoot() ([32]byte, error) {
+	return ssz.HashWithDefaultHasher(c)
+}
+
+// HashTreeRootWith provides the SSZ hash tree root of the change with a hasher.
+func (c *BLSToExecutionChange) HashTreeRootWith(hh *ssz.Hasher) error {
+	indx := hh.Index()
+	hh.PutUint64(uint64(c.ValidatorIndex))
+	hh.PutBytes(c.FromBLSPubkey[:])
+	hh.PutBytes(c.ToExecutionAddress[:])
+	hh.Merkleize(indx)
+
+	return nil
+}
+
+// BLSWithdrawalCredentials provides the BLS withdrawal credentials for a public key.
+func BLSWithdrawalCredentials(pubKey []byte) []byte {
+	withdrawalCredentials := ethutil.SHA256(pubKey)
+	withdrawalCredentials[0] = 0x00 // BLS_WITHDRAWAL_PREFIX
+	return withdrawalCredentials
+}
+
+// IsBLSWithdrawalCredentials returns true if the withdrawal credentials are BLS withdrawal credentials
+// for the given public key.
+func IsBLSWithdrawalCredentials(withdrawalCredentials []byte, pubKey []byte) bool {
+	return bytes.Equal(withdrawalCredentials, BLSWithdrawalCredentials(pubKey))
+}
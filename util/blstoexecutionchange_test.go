@@ -0,0 +1,112 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util_test
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/json"
+	"testing"
+
+	"github.com/aaron-alderman/ethdo/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSignedBLSToExecutionChangeUnmarshal(t *testing.T) {
+	tests := []struct {
+		name string
+		in   []byte
+		err  string
+	}{
+		{
+			name: "Nil",
+			err:  "unexpected end of JSON input",
+		},
+		{
+			name: "MessageMissing",
+			in:   []byte(`{"signature":"0xb74eade64ebf1e02cc57e5d29517032c6ca99132fb8e7fb7e6d58c68713e581ef0ef88e2a6c599a007d997782abdd50b0f9763500a93a971c89cb2275583fe755d7c0e64f459ff22fcef5cab3f80848f0356e67c142b9cf3ee65613f56283d6e"}`),
+			err:  "message missing",
+		},
+		{
+			name: "ValidatorIndexInvalid",
+			in:   []byte(`{"message":{"validator_index":"invalid","from_bls_pubkey":"0xa99a76ed7796f7be22d5b7e85deeb7c5677e88e511e0b337618f8c4eb61349b4bf2d153f649f7b53359fe8b94a38e44c","to_execution_address":"0x8c1ff978036f2e9d7cc382eff7b4c8c53c22ac15"},"signature":"0xb74eade64ebf1e02cc57e5d29517032c6ca99132fb8e7fb7e6d58c68713e581ef0ef88e2a6c599a007d997782abdd50b0f9763500a93a971c89cb2275583fe755d7c0e64f459ff22fcef5cab3f80848f0356e67c142b9cf3ee65613f56283d6e"}`),
+			err:  "invalid JSON: validator index invalid: strconv.ParseUint: parsing \"invalid\": invalid syntax",
+		},
+		{
+			name: "FromBLSPubkeyShort",
+			in:   []byte(`{"message":{"validator_index":"1","from_bls_pubkey":"0xa99a76ed7796f7be22d5b7e85deeb7c5677e88e511e0b337618f8c4eb61349b4bf2d153f649f7b53359fe8b94a38e4","to_execution_address":"0x8c1ff978036f2e9d7cc382eff7b4c8c53c22ac15"},"signature":"0xb74eade64ebf1e02cc57e5d29517032c6ca99132fb8e7fb7e6d58c68713e581ef0ef88e2a6c599a007d997782abdd50b0f9763500a93a971c89cb2275583fe755d7c0e64f459ff22fcef5cab3f80848f0356e67c142b9cf3ee65613f56283d6e"}`),
+			err:  "invalid JSON: from BLS public key incorrect length",
+		},
+		{
+			name: "ToExecutionAddressShort",
+			in:   []byte(`{"message":{"validator_index":"1","from_bls_pubkey":"0xa99a76ed7796f7be22d5b7e85deeb7c5677e88e511e0b337618f8c4eb61349b4bf2d153f649f7b53359fe8b94a38e44c","to_execution_address":"0x8c1ff978036f2e9d7cc382eff7b4c8c53c22ac"},"signature":"0xb74eade64ebf1e02cc57e5d29517032c6ca99132fb8e7fb7e6d58c68713e581ef0ef88e2a6c599a007d997782abdd50b0f9763500a93a971c89cb2275583fe755d7c0e64f459ff22fcef5cab3f80848f0356e67c142b9cf3ee65613f56283d6e"}`),
+			err:  "invalid JSON: to execution address incorrect length",
+		},
+		{
+			name: "SignatureMissing",
+			in:   []byte(`{"message":{"validator_index":"1","from_bls_pubkey":"0xa99a76ed7796f7be22d5b7e85deeb7c5677e88e511e0b337618f8c4eb61349b4bf2d153f649f7b53359fe8b94a38e44c","to_execution_address":"0x8c1ff978036f2e9d7cc382eff7b4c8c53c22ac15"}}`),
+			err:  "signature missing",
+		},
+		{
+			name: "Good",
+			in:   []byte(`{"message":{"validator_index":"1","from_bls_pubkey":"0xa99a76ed7796f7be22d5b7e85deeb7c5677e88e511e0b337618f8c4eb61349b4bf2d153f649f7b53359fe8b94a38e44c","to_execution_address":"0x8c1ff978036f2e9d7cc382eff7b4c8c53c22ac15"},"signature":"0xb74eade64ebf1e02cc57e5d29517032c6ca99132fb8e7fb7e6d58c68713e581ef0ef88e2a6c599a007d997782abdd50b0f9763500a93a971c89cb2275583fe755d7c0e64f459ff22fcef5cab3f80848f0356e67c142b9cf3ee65613f56283d6e"}`),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var res util.SignedBLSToExecutionChange
+			err := json.Unmarshal(test.in, &res)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				rt, err := json.Marshal(&res)
+				require.NoError(t, err)
+				require.Equal(t, string(test.in), string(rt))
+			}
+		})
+	}
+}
+
+func TestBLSToExecutionChangeHashTreeRoot(t *testing.T) {
+	change := &util.BLSToExecutionChange{
+		ValidatorIndex: 12345,
+	}
+	for i := range change.FromBLSPubkey {
+		change.FromBLSPubkey[i] = byte(i + 1)
+	}
+	for i := range change.ToExecutionAddress {
+		change.ToExecutionAddress[i] = byte(0x80 + i)
+	}
+
+	// Build the root by hand: three fields padded to four leaves.
+	leaves := make([][]byte, 4)
+	for i := range leaves {
+		leaves[i] = make([]byte, 32)
+	}
+	binary.LittleEndian.PutUint64(leaves[0], 12345)
+	pubKeyChunks := make([]byte, 64)
+	copy(pubKeyChunks, change.FromBLSPubkey[:])
+	pubKeyRoot := sha256.Sum256(pubKeyChunks)
+	copy(leaves[1], pubKeyRoot[:])
+	copy(leaves[2], change.ToExecutionAddress[:])
+	left := sha256.Sum256(append(append([]byte{}, leaves[0]...), leaves[1]...))
+	right := sha256.Sum256(append(append([]byte{}, leaves[2]...), leaves[3]...))
+	expected := sha256.Sum256(append(left[:], right[:]...))
+
+	root, err := change.HashTreeRoot()
+	require.NoError(t, err)
+	require.Equal(t, expected, root)
+}
@@ -0,0 +1,73 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"context"
+	"sync"
+
+	"github.com/aaron-alderman/ethdo/services/cache"
+	badgercache "github.com/aaron-alderman/ethdo/services/cache/badger"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
+)
+
+var (
+	openCacheMu    sync.Mutex
+	openedCache    cache.Service
+	openedCacheDir string
+)
+
+// OpenCache opens the cache at the given directory.  The cache is opened once,
+// and the same cache returned on subsequent calls for the same directory.
+func OpenCache(ctx context.Context, dir string) (cache.Service, error) {
+	openCacheMu.Lock()
+	defer openCacheMu.Unlock()
+
+	if openedCache != nil {
+		if openedCacheDir == dir {
+			return openedCache, nil
+		}
+		if err := openedCache.Close(ctx); err != nil {
+			return nil, errors.Wrap(err, "failed to close previous cache")
+		}
+		openedCache = nil
+	}
+
+	cache, err := badgercache.New(ctx,
+		badgercache.WithLogLevel(zerolog.Disabled),
+		badgercache.WithBaseDir(dir),
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to open cache")
+	}
+	openedCache = cache
+	openedCacheDir = dir
+
+	return openedCache, nil
+}
+
+// CloseCache closes the cache, if it has been opened.
+func CloseCache(ctx context.Context) error {
+	openCacheMu.Lock()
+	defer openCacheMu.Unlock()
+
+	if openedCache == nil {
+		return nil
+	}
+	err := openedCache.Close(ctx)
+	openedCache = nil
+
+	return err
+}
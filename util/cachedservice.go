@@ -0,0 +1,368 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/aaron-alderman/ethdo/services/cache"
+	eth2client "github.com/attestantio/go-eth2-client"
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/altair"
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+)
+
+// cachedService is a client that serves finalized blocks, headers and committees
+// from a persistent cache, only requesting them from the beacon node if they are not
+// already present.  Data that is not finalized is always requested from the beacon node.
+type cachedService struct {
+	*wrappedService
+	cache         cache.Service
+	finalizedSlot *phase0.Slot
+	slotsPerEpoch uint64
+}
+
+// newCachedService creates a new cached service.
+func newCachedService(service eth2client.Service, cache cache.Service) *cachedService {
+	return &cachedService{
+		wrappedService: &wrappedService{Service: service},
+		cache:          cache,
+	}
+}
+
+type cachedBlockJSON struct {
+	Version spec.DataVersion `json:"version"`
+	Data    json.RawMessage  `json:"data"`
+}
+
+// SignedBeaconBlock implements eth2client.SignedBeaconBlockProvider.
+func (s *cachedService) SignedBeaconBlock(ctx context.Context, blockID string) (*spec.VersionedSignedBeaconBlock, error) {
+	slot, cacheable := s.cacheable(ctx, blockID, nil)
+	if !cacheable {
+		return s.wrappedService.SignedBeaconBlock(ctx, blockID)
+	}
+
+	key := cache.BlockKey(slot)
+	data, found, err := s.cache.Fetch(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	if found {
+		return decodeCachedBlock(data)
+	}
+
+	block, err := s.wrappedService.SignedBeaconBlock(ctx, blockID)
+	if err != nil {
+		return nil, err
+	}
+	data, err = encodeCachedBlock(block)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.cache.Store(ctx, key, data); err != nil {
+		return nil, err
+	}
+
+	return block, nil
+}
+
+// BeaconBlockHeader implements eth2client.BeaconBlockHeadersProvider.
+func (s *cachedService) BeaconBlockHeader(ctx context.Context, blockID string) (*apiv1.BeaconBlockHeader, error) {
+	slot, cacheable := s.cacheable(ctx, blockID, nil)
+	if !cacheable {
+		return s.wrappedService.BeaconBlockHeader(ctx, blockID)
+	}
+
+	key := cache.HeaderKey(slot)
+	data, found, err := s.cache.Fetch(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	if found {
+		if len(data) == 0 {
+			// No block at this slot.
+			return nil, nil
+		}
+		header := &apiv1.BeaconBlockHeader{}
+		if err := json.Unmarshal(data, header); err != nil {
+			return nil, errors.Wrap(err, "failed to decode cached header")
+		}
+		return header, nil
+	}
+
+	header, err := s.wrappedService.BeaconBlockHeader(ctx, blockID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.store(ctx, key, header, header == nil); err != nil {
+		return nil, err
+	}
+
+	return header, nil
+}
+
+// BeaconCommittees implements eth2client.BeaconCommitteesProvider.
+func (s *cachedService) BeaconCommittees(ctx context.Context, stateID string) ([]*apiv1.BeaconCommittee, error) {
+	slot, cacheable := s.cacheable(ctx, stateID, nil)
+	if !cacheable {
+		return s.wrappedService.BeaconCommittees(ctx, stateID)
+	}
+
+	return s.beaconCommittees(ctx, cache.CommitteesKey(slot, s.slotToEpoch(slot)), func() ([]*apiv1.BeaconCommittee, error) {
+		return s.wrappedService.BeaconCommittees(ctx, stateID)
+	})
+}
+
+// BeaconCommitteesAtEpoch implements eth2client.BeaconCommitteesProvider.
+func (s *cachedService) BeaconCommitteesAtEpoch(ctx context.Context, stateID string, epoch phase0.Epoch) ([]*apiv1.BeaconCommittee, error) {
+	slot, cacheable := s.cacheable(ctx, stateID, &epoch)
+	if !cacheable {
+		return s.wrappedService.BeaconCommitteesAtEpoch(ctx, stateID, epoch)
+	}
+
+	return s.beaconCommittees(ctx, cache.CommitteesKey(slot, epoch), func() ([]*apiv1.BeaconCommittee, error) {
+		return s.wrappedService.BeaconCommitteesAtEpoch(ctx, stateID, epoch)
+	})
+}
+
+func (s *cachedService) beaconCommittees(ctx context.Context,
+	key []byte,
+	fetch func() ([]*apiv1.BeaconCommittee, error),
+) (
+	[]*apiv1.BeaconCommittee,
+	error,
+) {
+	data, found, err := s.cache.Fetch(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	if found {
+		committees := make([]*apiv1.BeaconCommittee, 0)
+		if err := json.Unmarshal(data, &committees); err != nil {
+			return nil, errors.Wrap(err, "failed to decode cached committees")
+		}
+		return committees, nil
+	}
+
+	committees, err := fetch()
+	if err != nil {
+		return nil, err
+	}
+	if committees != nil {
+		// Do not cache a missing response, as it may not be permanent.
+		if err := s.store(ctx, key, committees, false); err != nil {
+			return nil, err
+		}
+	}
+
+	return committees, nil
+}
+
+// SyncCommittee implements eth2client.SyncCommitteesProvider.
+func (s *cachedService) SyncCommittee(ctx context.Context, stateID string) (*apiv1.SyncCommittee, error) {
+	slot, cacheable := s.cacheable(ctx, stateID, nil)
+	if !cacheable {
+		return s.wrappedService.SyncCommittee(ctx, stateID)
+	}
+
+	return s.syncCommittee(ctx, cache.SyncCommitteeKey(slot, s.slotToEpoch(slot)), func() (*apiv1.SyncCommittee, error) {
+		return s.wrappedService.SyncCommittee(ctx, stateID)
+	})
+}
+
+// SyncCommitteeAtEpoch implements eth2client.SyncCommitteesProvider.
+func (s *cachedService) SyncCommitteeAtEpoch(ctx context.Context, stateID string, epoch phase0.Epoch) (*apiv1.SyncCommittee, error) {
+	slot, cacheable := s.cacheable(ctx, stateID, &epoch)
+	if !cacheable {
+		return s.wrappedService.SyncCommitteeAtEpoch(ctx, stateID, epoch)
+	}
+
+	return s.syncCommittee(ctx, cache.SyncCommitteeKey(slot, epoch), func() (*apiv1.SyncCommittee, error) {
+		return s.wrappedService.SyncCommitteeAtEpoch(ctx, stateID, epoch)
+	})
+}
+
+func (s *cachedService) syncCommittee(ctx context.Context,
+	key []byte,
+	fetch func() (*apiv1.SyncCommittee, error),
+) (
+	*apiv1.SyncCommittee,
+	error,
+) {
+	data, found, err := s.cache.Fetch(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	if found {
+		committee := &apiv1.SyncCommittee{}
+		if err := json.Unmarshal(data, committee); err != nil {
+			return nil, errors.Wrap(err, "failed to decode cached sync committee")
+		}
+		return committee, nil
+	}
+
+	committee, err := fetch()
+	if err != nil {
+		return nil, err
+	}
+	if committee != nil {
+		// Do not cache a missing response, as it may not be permanent.
+		if err := s.store(ctx, key, committee, false); err != nil {
+			return nil, err
+		}
+	}
+
+	return committee, nil
+}
+
+// cacheable returns the slot referenced by the given block or state ID, and true if data
+// for that slot, and optionally the given epoch, is finalized and so can be cached.
+func (s *cachedService) cacheable(ctx context.Context, id string, epoch *phase0.Epoch) (phase0.Slot, bool) {
+	tmp, err := strconv.ParseUint(id, 10, 64)
+	if err != nil {
+		// Only numeric slots can be cached; roots and named IDs such as "head" can change.
+		return 0, false
+	}
+	slot := phase0.Slot(tmp)
+
+	finalizedSlot, err := s.obtainFinalizedSlot(ctx)
+	if err != nil {
+		return 0, false
+	}
+	if slot > finalizedSlot {
+		return 0, false
+	}
+	if epoch != nil && phase0.Slot(uint64(*epoch)*s.slotsPerEpoch) > finalizedSlot {
+		return 0, false
+	}
+
+	return slot, true
+}
+
+// obtainFinalizedSlot obtains the first slot of the finalized epoch, which is the
+// latest slot for which data will not change.
+func (s *cachedService) obtainFinalizedSlot(ctx context.Context) (phase0.Slot, error) {
+	if s.finalizedSlot != nil {
+		return *s.finalizedSlot, nil
+	}
+
+	slotsPerEpoch, err := s.wrappedService.SlotsPerEpoch(ctx)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to obtain slots per epoch")
+	}
+	finality, err := s.wrappedService.Finality(ctx, "head")
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to obtain finality")
+	}
+	if finality == nil || finality.Finalized == nil {
+		return 0, errors.New("finality not available")
+	}
+
+	finalizedSlot := phase0.Slot(uint64(finality.Finalized.Epoch) * slotsPerEpoch)
+	s.slotsPerEpoch = slotsPerEpoch
+	s.finalizedSlot = &finalizedSlot
+	debugBeaconNode("Caching data up to finalized slot %d", finalizedSlot)
+
+	return finalizedSlot, nil
+}
+
+func (s *cachedService) slotToEpoch(slot phase0.Slot) phase0.Epoch {
+	return phase0.Epoch(uint64(slot) / s.slotsPerEpoch)
+}
+
+// store stores the JSON encoding of the item in the cache.  An empty item is stored if
+// missing is true, to record that there is no data.
+func (s *cachedService) store(ctx context.Context, key []byte, item interface{}, missing bool) error {
+	data := []byte{}
+	if !missing {
+		var err error
+		data, err = json.Marshal(item)
+		if err != nil {
+			return errors.Wrap(err, "failed to encode item for cache")
+		}
+	}
+
+	return s.cache.Store(ctx, key, data)
+}
+
+// encodeCachedBlock encodes a block for the cache.  A nil block, signifying an empty slot,
+// is encoded as empty data.
+func encodeCachedBlock(block *spec.VersionedSignedBeaconBlock) ([]byte, error) {
+	if block == nil {
+		return []byte{}, nil
+	}
+
+	var data []byte
+	var err error
+	switch block.Version {
+	case spec.DataVersionPhase0:
+		data, err = json.Marshal(block.Phase0)
+	case spec.DataVersionAltair:
+		data, err = json.Marshal(block.Altair)
+	case spec.DataVersionBellatrix:
+		data, err = json.Marshal(block.Bellatrix)
+	default:
+		return nil, fmt.Errorf("unhandled block version %v", block.Version)
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to encode block for cache")
+	}
+
+	return json.Marshal(&cachedBlockJSON{
+		Version: block.Version,
+		Data:    data,
+	})
+}
+
+// decodeCachedBlock decodes a block from the cache.
+func decodeCachedBlock(data []byte) (*spec.VersionedSignedBeaconBlock, error) {
+	if len(data) == 0 {
+		// No block at this slot.
+		return nil, nil
+	}
+
+	var cached cachedBlockJSON
+	if err := json.Unmarshal(data, &cached); err != nil {
+		return nil, errors.Wrap(err, "failed to decode cached block")
+	}
+
+	block := &spec.VersionedSignedBeaconBlock{
+		Version: cached.Version,
+	}
+	var err error
+	switch cached.Version {
+	case spec.DataVersionPhase0:
+		block.Phase0 = &phase0.SignedBeaconBlock{}
+		err = json.Unmarshal(cached.Data, block.Phase0)
+	case spec.DataVersionAltair:
+		block.Altair = &altair.SignedBeaconBlock{}
+		err = json.Unmarshal(cached.Data, block.Altair)
+	case spec.DataVersionBellatrix:
+		block.Bellatrix = &bellatrix.SignedBeaconBlock{}
+		err = json.Unmarshal(cached.Data, block.Bellatrix)
+	default:
+		return nil, fmt.Errorf("unhandled block version %v", cached.Version)
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to decode cached block")
+	}
+
+	return block, nil
+}
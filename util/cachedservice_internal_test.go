@@ -0,0 +1,103 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/aaron-alderman/ethdo/services/cache"
+	badgercache "github.com/aaron-alderman/ethdo/services/cache/badger"
+	"github.com/aaron-alderman/ethdo/testing/beaconnode"
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/http"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCachedService(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	beaconNode, err := beaconnode.New(ctx,
+		beaconnode.WithLogLevel(zerolog.Disabled),
+		beaconnode.WithFixturesDir("../testing/beaconnode/testdata"),
+	)
+	require.NoError(t, err)
+	client, err := http.New(ctx,
+		http.WithLogLevel(zerolog.Disabled),
+		http.WithAddress(beaconNode.Address()),
+		http.WithTimeout(time.Second),
+	)
+	require.NoError(t, err)
+
+	baseDir, err := ioutil.TempDir("", "")
+	require.NoError(t, err)
+	defer os.RemoveAll(baseDir)
+	cacheSvc, err := badgercache.New(ctx,
+		badgercache.WithLogLevel(zerolog.Disabled),
+		badgercache.WithBaseDir(baseDir),
+	)
+	require.NoError(t, err)
+	defer cacheSvc.Close(ctx)
+
+	s := newCachedService(client, cacheSvc)
+
+	// Finalized block and header, and an empty finalized slot.
+	block, err := s.SignedBeaconBlock(ctx, "1")
+	require.NoError(t, err)
+	require.NotNil(t, block)
+	header, err := s.BeaconBlockHeader(ctx, "0")
+	require.NoError(t, err)
+	require.NotNil(t, header)
+	emptyBlock, err := s.SignedBeaconBlock(ctx, "2")
+	require.NoError(t, err)
+	require.Nil(t, emptyBlock)
+
+	// Non-finalized and non-numeric IDs are not cached.
+	_, err = s.SignedBeaconBlock(ctx, "100")
+	require.NoError(t, err)
+	_, err = s.SignedBeaconBlock(ctx, "head")
+	require.NoError(t, err)
+
+	entries, err := cacheSvc.Entries(ctx, nil)
+	require.NoError(t, err)
+	require.Len(t, entries, 3)
+	_, found, err := cacheSvc.Fetch(ctx, cache.BlockKey(100))
+	require.NoError(t, err)
+	require.False(t, found)
+
+	// Cached data is served without the beacon node.
+	beaconNode.Close()
+	cachedBlock, err := s.SignedBeaconBlock(ctx, "1")
+	require.NoError(t, err)
+	require.Equal(t, block, cachedBlock)
+	cachedHeader, err := s.BeaconBlockHeader(ctx, "0")
+	require.NoError(t, err)
+	require.Equal(t, header, cachedHeader)
+	cachedEmptyBlock, err := s.SignedBeaconBlock(ctx, "2")
+	require.NoError(t, err)
+	require.Nil(t, cachedEmptyBlock)
+	_, err = s.SignedBeaconBlock(ctx, "100")
+	require.Error(t, err)
+
+	// Pass-through requests are still available.
+	_, isProvider := interface{}(s).(eth2client.ValidatorsProvider)
+	require.True(t, isProvider)
+	require.Equal(t, phase0.Slot(32), *s.finalizedSlot)
+}
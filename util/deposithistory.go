@@ -0,0 +1,161 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/aaron-alderman/ethdo/services/deposithistory"
+	filedeposithistory "github.com/aaron-alderman/ethdo/services/deposithistory/file"
+	jsonrpcdeposithistory "github.com/aaron-alderman/ethdo/services/deposithistory/jsonrpc"
+	thegraphdeposithistory "github.com/aaron-alderman/ethdo/services/deposithistory/thegraph"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	e2types "github.com/wealdtech/go-eth2-types/v2"
+	string2eth "github.com/wealdtech/go-string2eth"
+)
+
+// DepositHistory returns the deposit history service selected by the deposit-history option,
+// or nil if no source is configured.  The option can be the address of an execution node
+// JSON-RPC endpoint, "thegraph", or the path to a deposit log file.
+// The network and deposit contract address are used by sources that require them; if the
+// deposit contract address is not supplied it is obtained from the network.
+func DepositHistory(ctx context.Context, network string, depositContract []byte) (deposithistory.Service, error) {
+	source := viper.GetString("deposit-history")
+	switch {
+	case source == "":
+		return nil, nil
+	case source == "thegraph":
+		service, err := thegraphdeposithistory.New(ctx,
+			thegraphdeposithistory.WithNetwork(network),
+			thegraphdeposithistory.WithTimeout(viper.GetDuration("timeout")),
+		)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to create graph deposit history")
+		}
+		return service, nil
+	case strings.HasPrefix(source, "http://"), strings.HasPrefix(source, "https://"):
+		networkDepositContract, startBlock, err := NetworkDepositContract(network)
+		if len(depositContract) == 0 {
+			if err != nil {
+				return nil, errors.Wrap(err, "failed to obtain deposit contract")
+			}
+			depositContract = networkDepositContract
+		}
+		if !bytes.Equal(depositContract, networkDepositContract) {
+			// Not a known deployment, so search from the start of the chain.
+			startBlock = 0
+		}
+		service, err := jsonrpcdeposithistory.New(ctx,
+			jsonrpcdeposithistory.WithAddress(source),
+			jsonrpcdeposithistory.WithTimeout(viper.GetDuration("timeout")),
+			jsonrpcdeposithistory.WithDepositContract(depositContract),
+			jsonrpcdeposithistory.WithStartBlock(startBlock),
+		)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to create execution node deposit history")
+		}
+		return service, nil
+	default:
+		service, err := filedeposithistory.New(ctx,
+			filedeposithistory.WithPath(source),
+		)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to create file deposit history")
+		}
+		return service, nil
+	}
+}
+
+// DepositHistoryWarnings returns warnings about the prior deposits of a validator.
+// If withdrawal credentials are supplied they are for a new deposit, and are checked
+// against those of the prior deposits.  The fork version is the genesis fork version
+// of the chain, which is used to verify the signatures of the deposits.
+func DepositHistoryWarnings(deposits []*deposithistory.Deposit, withdrawalCredentials []byte, forkVersion phase0.Version) []string {
+	warnings := make([]string, 0)
+	if len(deposits) == 0 {
+		return warnings
+	}
+
+	// The withdrawal credentials used by the validator are those of the first deposit with a
+	// valid signature; deposits before it are ignored by the chain.
+	var first *deposithistory.Deposit
+	firstIndex := 0
+	for i, deposit := range deposits {
+		if depositSignatureValid(deposit, forkVersion) {
+			first = deposit
+			firstIndex = i
+			break
+		}
+		warnings = append(warnings, fmt.Sprintf("deposit %d has an invalid signature so was ignored by the chain", deposit.Index))
+	}
+
+	if withdrawalCredentials != nil {
+		total := phase0.Gwei(0)
+		for _, deposit := range deposits {
+			total += deposit.Amount
+		}
+		warnings = append(warnings, fmt.Sprintf("validator already has %d deposit(s) totalling %s", len(deposits), string2eth.GWeiToString(uint64(total), true)))
+		if first != nil && !bytes.Equal(withdrawalCredentials, first.WithdrawalCredentials) {
+			warnings = append(warnings, fmt.Sprintf("withdrawal credentials %#x do not match %#x of the first valid deposit, which are those used by the validator", withdrawalCredentials, first.WithdrawalCredentials))
+		}
+	}
+	if first == nil {
+		return warnings
+	}
+
+	for _, deposit := range deposits[firstIndex+1:] {
+		if !bytes.Equal(deposit.WithdrawalCredentials, first.WithdrawalCredentials) {
+			warnings = append(warnings, fmt.Sprintf("prior deposits have differing withdrawal credentials; the validator uses %#x of the first valid deposit", first.WithdrawalCredentials))
+			break
+		}
+	}
+
+	return warnings
+}
+
+// depositSignatureValid returns true if the deposit is signed by the key of its validator.
+func depositSignatureValid(deposit *deposithistory.Deposit, forkVersion phase0.Version) bool {
+	if len(deposit.WithdrawalCredentials) != 32 {
+		return false
+	}
+	message := &phase0.DepositMessage{
+		PublicKey:             deposit.PublicKey,
+		WithdrawalCredentials: deposit.WithdrawalCredentials,
+		Amount:                deposit.Amount,
+	}
+	root, err := message.HashTreeRoot()
+	if err != nil {
+		return false
+	}
+	// Deposits are valid across forks, so are signed with a zero genesis validators root.
+	signingRoot, err := SigningRoot(root, phase0.DomainType(e2types.DomainDeposit), forkVersion, phase0.Root{})
+	if err != nil {
+		return false
+	}
+	pubKey, err := e2types.BLSPublicKeyFromBytes(append([]byte{}, deposit.PublicKey[:]...))
+	if err != nil {
+		return false
+	}
+	sig, err := e2types.BLSSignatureFromBytes(append([]byte{}, deposit.Signature[:]...))
+	if err != nil {
+		return false
+	}
+
+	return sig.Verify(signingRoot[:], pubKey)
+}
@@ -0,0 +1,226 @@
+// Copyright © 2022 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aaron-alderman/ethdo/services/deposithistory"
+	"github.com/aaron-alderman/ethdo/testutil"
+	"github.com/aaron-alderman/ethdo/util"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+	e2types "github.com/wealdtech/go-eth2-types/v2"
+)
+
+func TestDepositHistory(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name            string
+		source          string
+		network         string
+		depositContract []byte
+		none            bool
+		err             string
+	}{
+		{
+			name: "None",
+			none: true,
+		},
+		{
+			name:    "JSONRPCUnknownNetwork",
+			source:  "http://localhost:8545",
+			network: "Unknown",
+			err:     "failed to obtain deposit contract: unknown network Unknown",
+		},
+		{
+			name:            "JSONRPCUnknownNetworkWithContract",
+			source:          "http://localhost:8545",
+			network:         "Unknown",
+			depositContract: make([]byte, 20),
+		},
+		{
+			name:    "JSONRPC",
+			source:  "https://localhost:8545",
+			network: "Mainnet",
+		},
+		{
+			name:   "TheGraphNoNetwork",
+			source: "thegraph",
+			err:    "failed to create graph deposit history: problem with parameters: no network specified",
+		},
+		{
+			name:    "TheGraph",
+			source:  "thegraph",
+			network: "Prater",
+		},
+		{
+			name:   "FileMissing",
+			source: "/nonexistent/deposits.json",
+			err:    "failed to create file deposit history: failed to read deposit log file: open /nonexistent/deposits.json: no such file or directory",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			viper.Reset()
+			viper.Set("timeout", "5s")
+			viper.Set("deposit-history", test.source)
+			service, err := util.DepositHistory(ctx, test.network, test.depositContract)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+				return
+			}
+			require.NoError(t, err)
+			if test.none {
+				require.Nil(t, service)
+			} else {
+				require.NotNil(t, service)
+			}
+		})
+	}
+}
+
+// signedDeposit creates a deposit signed by the given key for the zero genesis fork version.
+func signedDeposit(t *testing.T, key *e2types.BLSPrivateKey, index uint64, withdrawalCredentials []byte, amount phase0.Gwei) *deposithistory.Deposit {
+	t.Helper()
+
+	deposit := &deposithistory.Deposit{
+		Index:                 index,
+		WithdrawalCredentials: withdrawalCredentials,
+		Amount:                amount,
+	}
+	copy(deposit.PublicKey[:], key.PublicKey().Marshal())
+	message := &phase0.DepositMessage{
+		PublicKey:             deposit.PublicKey,
+		WithdrawalCredentials: withdrawalCredentials,
+		Amount:                amount,
+	}
+	root, err := message.HashTreeRoot()
+	require.NoError(t, err)
+	signingRoot, err := util.SigningRoot(root, phase0.DomainType(e2types.DomainDeposit), phase0.Version{}, phase0.Root{})
+	require.NoError(t, err)
+	copy(deposit.Signature[:], key.Sign(signingRoot[:]).Marshal())
+
+	return deposit
+}
+
+func TestDepositHistoryWarnings(t *testing.T) {
+	require.NoError(t, e2types.InitBLS())
+	key, err := e2types.BLSPrivateKeyFromBytes(testutil.HexToBytes("0x25295f0d1d592a90b333e26e85149708208e9f8e8bc18f6c77bd62f8ad7a6866"))
+	require.NoError(t, err)
+
+	credentials1 := make([]byte, 32)
+	credentials2 := make([]byte, 32)
+	credentials2[0] = 0x01
+	unsigned := &deposithistory.Deposit{
+		Index:                 1,
+		WithdrawalCredentials: credentials2,
+		Amount:                32000000000,
+	}
+	copy(unsigned.PublicKey[:], key.PublicKey().Marshal())
+
+	tests := []struct {
+		name                  string
+		deposits              []*deposithistory.Deposit
+		withdrawalCredentials []byte
+		warnings              []string
+	}{
+		{
+			name:     "None",
+			warnings: []string{},
+		},
+		{
+			name: "Single",
+			deposits: []*deposithistory.Deposit{
+				signedDeposit(t, key, 1, credentials1, 32000000000),
+			},
+			warnings: []string{},
+		},
+		{
+			name: "DifferingPrior",
+			deposits: []*deposithistory.Deposit{
+				signedDeposit(t, key, 1, credentials1, 32000000000),
+				signedDeposit(t, key, 2, credentials2, 1000000000),
+			},
+			warnings: []string{
+				"prior deposits have differing withdrawal credentials; the validator uses 0x0000000000000000000000000000000000000000000000000000000000000000 of the first valid deposit",
+			},
+		},
+		{
+			name: "FirstInvalid",
+			deposits: []*deposithistory.Deposit{
+				unsigned,
+				signedDeposit(t, key, 2, credentials1, 32000000000),
+			},
+			warnings: []string{
+				"deposit 1 has an invalid signature so was ignored by the chain",
+			},
+		},
+		{
+			name: "AllInvalid",
+			deposits: []*deposithistory.Deposit{
+				unsigned,
+			},
+			withdrawalCredentials: credentials1,
+			warnings: []string{
+				"deposit 1 has an invalid signature so was ignored by the chain",
+				"validator already has 1 deposit(s) totalling 32 Ether",
+			},
+		},
+		{
+			name: "NewMatching",
+			deposits: []*deposithistory.Deposit{
+				signedDeposit(t, key, 1, credentials1, 32000000000),
+			},
+			withdrawalCredentials: credentials1,
+			warnings: []string{
+				"validator already has 1 deposit(s) totalling 32 Ether",
+			},
+		},
+		{
+			name: "NewDiffering",
+			deposits: []*deposithistory.Deposit{
+				signedDeposit(t, key, 1, credentials1, 16000000000),
+				signedDeposit(t, key, 2, credentials1, 16000000000),
+			},
+			withdrawalCredentials: credentials2,
+			warnings: []string{
+				"validator already has 2 deposit(s) totalling 32 Ether",
+				"withdrawal credentials 0x0100000000000000000000000000000000000000000000000000000000000000 do not match 0x0000000000000000000000000000000000000000000000000000000000000000 of the first valid deposit, which are those used by the validator",
+			},
+		},
+		{
+			name: "NewMatchingAfterInvalid",
+			deposits: []*deposithistory.Deposit{
+				unsigned,
+				signedDeposit(t, key, 2, credentials1, 32000000000),
+			},
+			withdrawalCredentials: credentials1,
+			warnings: []string{
+				"deposit 1 has an invalid signature so was ignored by the chain",
+				"validator already has 2 deposit(s) totalling 64 Ether",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.warnings, util.DepositHistoryWarnings(test.deposits, test.withdrawalCredentials, phase0.Version{}))
+		})
+	}
+}
@@ -0,0 +1,396 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"context"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+)
+
+// callFunc is a request made to a single beacon node.
+type callFunc func(ctx context.Context, client eth2client.Service) (interface{}, error)
+
+// dispatchService is a client that makes read requests to individual beacon nodes,
+// with the choice of beacon nodes made by its dispatch function.  Other requests
+// are passed to the underlying service.
+type dispatchService struct {
+	*wrappedService
+	dispatch func(ctx context.Context, call callFunc) (interface{}, error)
+}
+
+// AttesterDuties implements eth2client.AttesterDutiesProvider.
+func (s *dispatchService) AttesterDuties(ctx context.Context, epoch phase0.Epoch, validatorIndices []phase0.ValidatorIndex) ([]*apiv1.AttesterDuty, error) {
+	res, err := s.dispatch(ctx, func(ctx context.Context, client eth2client.Service) (interface{}, error) {
+		provider, isProvider := client.(eth2client.AttesterDutiesProvider)
+		if !isProvider {
+			return nil, errors.New("client does not support AttesterDuties")
+		}
+		res, err := provider.AttesterDuties(ctx, epoch, validatorIndices)
+		if err != nil || res == nil {
+			return nil, err
+		}
+		return res, nil
+	})
+	if err != nil || res == nil {
+		return nil, err
+	}
+	return res.([]*apiv1.AttesterDuty), nil
+}
+
+// BeaconBlockHeader implements eth2client.BeaconBlockHeadersProvider.
+func (s *dispatchService) BeaconBlockHeader(ctx context.Context, blockID string) (*apiv1.BeaconBlockHeader, error) {
+	res, err := s.dispatch(ctx, func(ctx context.Context, client eth2client.Service) (interface{}, error) {
+		provider, isProvider := client.(eth2client.BeaconBlockHeadersProvider)
+		if !isProvider {
+			return nil, errors.New("client does not support BeaconBlockHeader")
+		}
+		res, err := provider.BeaconBlockHeader(ctx, blockID)
+		if err != nil || res == nil {
+			return nil, err
+		}
+		return res, nil
+	})
+	if err != nil || res == nil {
+		return nil, err
+	}
+	return res.(*apiv1.BeaconBlockHeader), nil
+}
+
+// BeaconCommittees implements eth2client.BeaconCommitteesProvider.
+func (s *dispatchService) BeaconCommittees(ctx context.Context, stateID string) ([]*apiv1.BeaconCommittee, error) {
+	res, err := s.dispatch(ctx, func(ctx context.Context, client eth2client.Service) (interface{}, error) {
+		provider, isProvider := client.(eth2client.BeaconCommitteesProvider)
+		if !isProvider {
+			return nil, errors.New("client does not support BeaconCommittees")
+		}
+		res, err := provider.BeaconCommittees(ctx, stateID)
+		if err != nil || res == nil {
+			return nil, err
+		}
+		return res, nil
+	})
+	if err != nil || res == nil {
+		return nil, err
+	}
+	return res.([]*apiv1.BeaconCommittee), nil
+}
+
+// BeaconCommitteesAtEpoch implements eth2client.BeaconCommitteesProvider.
+func (s *dispatchService) BeaconCommitteesAtEpoch(ctx context.Context, stateID string, epoch phase0.Epoch) ([]*apiv1.BeaconCommittee, error) {
+	res, err := s.dispatch(ctx, func(ctx context.Context, client eth2client.Service) (interface{}, error) {
+		provider, isProvider := client.(eth2client.BeaconCommitteesProvider)
+		if !isProvider {
+			return nil, errors.New("client does not support BeaconCommitteesAtEpoch")
+		}
+		res, err := provider.BeaconCommitteesAtEpoch(ctx, stateID, epoch)
+		if err != nil || res == nil {
+			return nil, err
+		}
+		return res, nil
+	})
+	if err != nil || res == nil {
+		return nil, err
+	}
+	return res.([]*apiv1.BeaconCommittee), nil
+}
+
+// BeaconState implements eth2client.BeaconStateProvider.
+func (s *dispatchService) BeaconState(ctx context.Context, stateID string) (*spec.VersionedBeaconState, error) {
+	res, err := s.dispatch(ctx, func(ctx context.Context, client eth2client.Service) (interface{}, error) {
+		provider, isProvider := client.(eth2client.BeaconStateProvider)
+		if !isProvider {
+			return nil, errors.New("client does not support BeaconState")
+		}
+		res, err := provider.BeaconState(ctx, stateID)
+		if err != nil || res == nil {
+			return nil, err
+		}
+		return res, nil
+	})
+	if err != nil || res == nil {
+		return nil, err
+	}
+	return res.(*spec.VersionedBeaconState), nil
+}
+
+// Finality implements eth2client.FinalityProvider.
+func (s *dispatchService) Finality(ctx context.Context, stateID string) (*apiv1.Finality, error) {
+	res, err := s.dispatch(ctx, func(ctx context.Context, client eth2client.Service) (interface{}, error) {
+		provider, isProvider := client.(eth2client.FinalityProvider)
+		if !isProvider {
+			return nil, errors.New("client does not support Finality")
+		}
+		res, err := provider.Finality(ctx, stateID)
+		if err != nil || res == nil {
+			return nil, err
+		}
+		return res, nil
+	})
+	if err != nil || res == nil {
+		return nil, err
+	}
+	return res.(*apiv1.Finality), nil
+}
+
+// Fork implements eth2client.ForkProvider.
+func (s *dispatchService) Fork(ctx context.Context, stateID string) (*phase0.Fork, error) {
+	res, err := s.dispatch(ctx, func(ctx context.Context, client eth2client.Service) (interface{}, error) {
+		provider, isProvider := client.(eth2client.ForkProvider)
+		if !isProvider {
+			return nil, errors.New("client does not support Fork")
+		}
+		res, err := provider.Fork(ctx, stateID)
+		if err != nil || res == nil {
+			return nil, err
+		}
+		return res, nil
+	})
+	if err != nil || res == nil {
+		return nil, err
+	}
+	return res.(*phase0.Fork), nil
+}
+
+// ForkSchedule implements eth2client.ForkScheduleProvider.
+func (s *dispatchService) ForkSchedule(ctx context.Context) ([]*phase0.Fork, error) {
+	res, err := s.dispatch(ctx, func(ctx context.Context, client eth2client.Service) (interface{}, error) {
+		provider, isProvider := client.(eth2client.ForkScheduleProvider)
+		if !isProvider {
+			return nil, errors.New("client does not support ForkSchedule")
+		}
+		res, err := provider.ForkSchedule(ctx)
+		if err != nil || res == nil {
+			return nil, err
+		}
+		return res, nil
+	})
+	if err != nil || res == nil {
+		return nil, err
+	}
+	return res.([]*phase0.Fork), nil
+}
+
+// Genesis implements eth2client.GenesisProvider.
+func (s *dispatchService) Genesis(ctx context.Context) (*apiv1.Genesis, error) {
+	res, err := s.dispatch(ctx, func(ctx context.Context, client eth2client.Service) (interface{}, error) {
+		provider, isProvider := client.(eth2client.GenesisProvider)
+		if !isProvider {
+			return nil, errors.New("client does not support Genesis")
+		}
+		res, err := provider.Genesis(ctx)
+		if err != nil || res == nil {
+			return nil, err
+		}
+		return res, nil
+	})
+	if err != nil || res == nil {
+		return nil, err
+	}
+	return res.(*apiv1.Genesis), nil
+}
+
+// NodeSyncing implements eth2client.NodeSyncingProvider.
+func (s *dispatchService) NodeSyncing(ctx context.Context) (*apiv1.SyncState, error) {
+	res, err := s.dispatch(ctx, func(ctx context.Context, client eth2client.Service) (interface{}, error) {
+		provider, isProvider := client.(eth2client.NodeSyncingProvider)
+		if !isProvider {
+			return nil, errors.New("client does not support NodeSyncing")
+		}
+		res, err := provider.NodeSyncing(ctx)
+		if err != nil || res == nil {
+			return nil, err
+		}
+		return res, nil
+	})
+	if err != nil || res == nil {
+		return nil, err
+	}
+	return res.(*apiv1.SyncState), nil
+}
+
+// ProposerDuties implements eth2client.ProposerDutiesProvider.
+func (s *dispatchService) ProposerDuties(ctx context.Context, epoch phase0.Epoch, validatorIndices []phase0.ValidatorIndex) ([]*apiv1.ProposerDuty, error) {
+	res, err := s.dispatch(ctx, func(ctx context.Context, client eth2client.Service) (interface{}, error) {
+		provider, isProvider := client.(eth2client.ProposerDutiesProvider)
+		if !isProvider {
+			return nil, errors.New("client does not support ProposerDuties")
+		}
+		res, err := provider.ProposerDuties(ctx, epoch, validatorIndices)
+		if err != nil || res == nil {
+			return nil, err
+		}
+		return res, nil
+	})
+	if err != nil || res == nil {
+		return nil, err
+	}
+	return res.([]*apiv1.ProposerDuty), nil
+}
+
+// SignedBeaconBlock implements eth2client.SignedBeaconBlockProvider.
+func (s *dispatchService) SignedBeaconBlock(ctx context.Context, blockID string) (*spec.VersionedSignedBeaconBlock, error) {
+	res, err := s.dispatch(ctx, func(ctx context.Context, client eth2client.Service) (interface{}, error) {
+		provider, isProvider := client.(eth2client.SignedBeaconBlockProvider)
+		if !isProvider {
+			return nil, errors.New("client does not support SignedBeaconBlock")
+		}
+		res, err := provider.SignedBeaconBlock(ctx, blockID)
+		if err != nil || res == nil {
+			return nil, err
+		}
+		return res, nil
+	})
+	if err != nil || res == nil {
+		return nil, err
+	}
+	return res.(*spec.VersionedSignedBeaconBlock), nil
+}
+
+// Spec implements eth2client.SpecProvider.
+func (s *dispatchService) Spec(ctx context.Context) (map[string]interface{}, error) {
+	res, err := s.dispatch(ctx, func(ctx context.Context, client eth2client.Service) (interface{}, error) {
+		provider, isProvider := client.(eth2client.SpecProvider)
+		if !isProvider {
+			return nil, errors.New("client does not support Spec")
+		}
+		res, err := provider.Spec(ctx)
+		if err != nil || res == nil {
+			return nil, err
+		}
+		return res, nil
+	})
+	if err != nil || res == nil {
+		return nil, err
+	}
+	return res.(map[string]interface{}), nil
+}
+
+// SyncCommittee implements eth2client.SyncCommitteesProvider.
+func (s *dispatchService) SyncCommittee(ctx context.Context, stateID string) (*apiv1.SyncCommittee, error) {
+	res, err := s.dispatch(ctx, func(ctx context.Context, client eth2client.Service) (interface{}, error) {
+		provider, isProvider := client.(eth2client.SyncCommitteesProvider)
+		if !isProvider {
+			return nil, errors.New("client does not support SyncCommittee")
+		}
+		res, err := provider.SyncCommittee(ctx, stateID)
+		if err != nil || res == nil {
+			return nil, err
+		}
+		return res, nil
+	})
+	if err != nil || res == nil {
+		return nil, err
+	}
+	return res.(*apiv1.SyncCommittee), nil
+}
+
+// SyncCommitteeAtEpoch implements eth2client.SyncCommitteesProvider.
+func (s *dispatchService) SyncCommitteeAtEpoch(ctx context.Context, stateID string, epoch phase0.Epoch) (*apiv1.SyncCommittee, error) {
+	res, err := s.dispatch(ctx, func(ctx context.Context, client eth2client.Service) (interface{}, error) {
+		provider, isProvider := client.(eth2client.SyncCommitteesProvider)
+		if !isProvider {
+			return nil, errors.New("client does not support SyncCommitteeAtEpoch")
+		}
+		res, err := provider.SyncCommitteeAtEpoch(ctx, stateID, epoch)
+		if err != nil || res == nil {
+			return nil, err
+		}
+		return res, nil
+	})
+	if err != nil || res == nil {
+		return nil, err
+	}
+	return res.(*apiv1.SyncCommittee), nil
+}
+
+// SyncCommitteeDuties implements eth2client.SyncCommitteeDutiesProvider.
+func (s *dispatchService) SyncCommitteeDuties(ctx context.Context, epoch phase0.Epoch, validatorIndices []phase0.ValidatorIndex) ([]*apiv1.SyncCommitteeDuty, error) {
+	res, err := s.dispatch(ctx, func(ctx context.Context, client eth2client.Service) (interface{}, error) {
+		provider, isProvider := client.(eth2client.SyncCommitteeDutiesProvider)
+		if !isProvider {
+			return nil, errors.New("client does not support SyncCommitteeDuties")
+		}
+		res, err := provider.SyncCommitteeDuties(ctx, epoch, validatorIndices)
+		if err != nil || res == nil {
+			return nil, err
+		}
+		return res, nil
+	})
+	if err != nil || res == nil {
+		return nil, err
+	}
+	return res.([]*apiv1.SyncCommitteeDuty), nil
+}
+
+// ValidatorBalances implements eth2client.ValidatorBalancesProvider.
+func (s *dispatchService) ValidatorBalances(ctx context.Context, stateID string, validatorIndices []phase0.ValidatorIndex) (map[phase0.ValidatorIndex]phase0.Gwei, error) {
+	res, err := s.dispatch(ctx, func(ctx context.Context, client eth2client.Service) (interface{}, error) {
+		provider, isProvider := client.(eth2client.ValidatorBalancesProvider)
+		if !isProvider {
+			return nil, errors.New("client does not support ValidatorBalances")
+		}
+		res, err := provider.ValidatorBalances(ctx, stateID, validatorIndices)
+		if err != nil || res == nil {
+			return nil, err
+		}
+		return res, nil
+	})
+	if err != nil || res == nil {
+		return nil, err
+	}
+	return res.(map[phase0.ValidatorIndex]phase0.Gwei), nil
+}
+
+// Validators implements eth2client.ValidatorsProvider.
+func (s *dispatchService) Validators(ctx context.Context, stateID string, validatorIndices []phase0.ValidatorIndex) (map[phase0.ValidatorIndex]*apiv1.Validator, error) {
+	res, err := s.dispatch(ctx, func(ctx context.Context, client eth2client.Service) (interface{}, error) {
+		provider, isProvider := client.(eth2client.ValidatorsProvider)
+		if !isProvider {
+			return nil, errors.New("client does not support Validators")
+		}
+		res, err := provider.Validators(ctx, stateID, validatorIndices)
+		if err != nil || res == nil {
+			return nil, err
+		}
+		return res, nil
+	})
+	if err != nil || res == nil {
+		return nil, err
+	}
+	return res.(map[phase0.ValidatorIndex]*apiv1.Validator), nil
+}
+
+// ValidatorsByPubKey implements eth2client.ValidatorsProvider.
+func (s *dispatchService) ValidatorsByPubKey(ctx context.Context, stateID string, validatorPubKeys []phase0.BLSPubKey) (map[phase0.ValidatorIndex]*apiv1.Validator, error) {
+	res, err := s.dispatch(ctx, func(ctx context.Context, client eth2client.Service) (interface{}, error) {
+		provider, isProvider := client.(eth2client.ValidatorsProvider)
+		if !isProvider {
+			return nil, errors.New("client does not support ValidatorsByPubKey")
+		}
+		res, err := provider.ValidatorsByPubKey(ctx, stateID, validatorPubKeys)
+		if err != nil || res == nil {
+			return nil, err
+		}
+		return res, nil
+	})
+	if err != nil || res == nil {
+		return nil, err
+	}
+	return res.(map[phase0.ValidatorIndex]*apiv1.Validator), nil
+}
@@ -40,3 +40,16 @@ func ParseEpoch(ctx context.Context, chainTime chaintime.Service, epochStr strin
 		return chainTime.CurrentEpoch() + phase0.Epoch(val), nil
 	}
 }
+
+// ForkVersionAtEpoch returns the fork version in force at the given epoch, given the
+// chain's fork schedule.
+func ForkVersionAtEpoch(forkSchedule []*phase0.Fork, epoch phase0.Epoch) phase0.Version {
+	var version phase0.Version
+	for _, fork := range forkSchedule {
+		if fork.Epoch <= epoch {
+			version = fork.CurrentVersion
+		}
+	}
+
+	return version
+}
@@ -0,0 +1,87 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"github.com/pkg/errors"
+)
+
+// Error codes for structured error output.
+const (
+	// ErrorCodeInvalidInput is for errors in the input supplied to a command.
+	ErrorCodeInvalidInput = "invalid_input"
+	// ErrorCodeConnectionFailed is for failures to connect to a beacon node or other service.
+	ErrorCodeConnectionFailed = "connection_failed"
+	// ErrorCodeVerificationFailed is for data that a command has checked and found to be invalid.
+	ErrorCodeVerificationFailed = "verification_failed"
+	// ErrorCodeFailed is for all other failures.
+	ErrorCodeFailed = "failed"
+)
+
+// codedError is an error with a code for structured error output.
+type codedError struct {
+	code string
+	err  error
+}
+
+// Error implements error.
+func (e *codedError) Error() string {
+	return e.err.Error()
+}
+
+// Unwrap returns the underlying error.
+func (e *codedError) Unwrap() error {
+	return e.err
+}
+
+// NewCodedError returns an error with a code for structured error output.
+func NewCodedError(code string, err error) error {
+	if err == nil {
+		return nil
+	}
+	return &codedError{
+		code: code,
+		err:  err,
+	}
+}
+
+// ErrorCode returns the code of an error, or an empty string if it does not have one.
+// Codes are found through wrapped errors, with the outermost code taking precedence.
+func ErrorCode(err error) string {
+	var coded *codedError
+	if errors.As(err, &coded) {
+		return coded.code
+	}
+	return ""
+}
+
+// errorOutput is the structured output of an error.
+type errorOutput struct {
+	Error *errorOutputDetail `json:"error"`
+}
+
+type errorOutputDetail struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// FormatError returns an error with its code in the given structured format.
+func FormatError(format string, code string, err error) (string, error) {
+	return FormatOutput(format, &errorOutput{
+		Error: &errorOutputDetail{
+			Code:    code,
+			Message: err.Error(),
+		},
+	})
+}
@@ -0,0 +1,80 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/aaron-alderman/ethdo/util"
+	pkgerrors "github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestErrorCode(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		code string
+	}{
+		{
+			name: "Nil",
+		},
+		{
+			name: "Uncoded",
+			err:  errors.New("bad"),
+		},
+		{
+			name: "Coded",
+			err:  util.NewCodedError(util.ErrorCodeVerificationFailed, errors.New("bad")),
+			code: util.ErrorCodeVerificationFailed,
+		},
+		{
+			name: "Wrapped",
+			err:  pkgerrors.Wrap(util.NewCodedError(util.ErrorCodeConnectionFailed, errors.New("bad")), "outer"),
+			code: util.ErrorCodeConnectionFailed,
+		},
+		{
+			name: "Nested",
+			err:  util.NewCodedError(util.ErrorCodeFailed, util.NewCodedError(util.ErrorCodeConnectionFailed, errors.New("bad"))),
+			code: util.ErrorCodeFailed,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.code, util.ErrorCode(test.err))
+		})
+	}
+}
+
+func TestNewCodedError(t *testing.T) {
+	require.NoError(t, util.NewCodedError(util.ErrorCodeFailed, nil))
+	err := util.NewCodedError(util.ErrorCodeFailed, errors.New("bad"))
+	require.EqualError(t, err, "bad")
+}
+
+func TestFormatError(t *testing.T) {
+	err := pkgerrors.Wrap(errors.New("inner"), "outer")
+
+	res, formatErr := util.FormatError(util.FormatJSON, util.ErrorCodeInvalidInput, err)
+	require.NoError(t, formatErr)
+	require.Equal(t, `{"error":{"code":"invalid_input","message":"outer: inner"}}`, res)
+
+	res, formatErr = util.FormatError(util.FormatYAML, util.ErrorCodeInvalidInput, err)
+	require.NoError(t, formatErr)
+	require.Equal(t, `error:
+  code: invalid_input
+  message: 'outer: inner'`, res)
+}
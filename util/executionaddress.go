@@ -0,0 +1,70 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/sha3"
+)
+
+// ParseExecutionAddress parses an execution address.  If the address is
+// mixed-case its EIP-55 checksum is verified.
+func ParseExecutionAddress(input string) ([ExecutionAddressLength]byte, error) {
+	var address [ExecutionAddressLength]byte
+
+	input = strings.TrimPrefix(input, "0x")
+	data, err := hex.DecodeString(input)
+	if err != nil {
+		return address, errors.Wrap(err, "invalid execution address")
+	}
+	if len(data) != ExecutionAddressLength {
+		return address, errors.New("execution address must be 20 bytes")
+	}
+	copy(address[:], data)
+
+	if input != strings.ToLower(input) && input != strings.ToUpper(input) {
+		if input != strings.TrimPrefix(ExecutionAddressChecksum(address), "0x") {
+			return address, errors.New("execution address checksum incorrect")
+		}
+	}
+
+	return address, nil
+}
+
+// ExecutionAddressChecksum provides the EIP-55 checksummed form of an execution address.
+func ExecutionAddressChecksum(address [ExecutionAddressLength]byte) string {
+	lower := hex.EncodeToString(address[:])
+	hash := sha3.NewLegacyKeccak256()
+	hash.Write([]byte(lower))
+	digest := hash.Sum(nil)
+
+	res := make([]byte, len(lower))
+	for i := range lower {
+		nibble := digest[i/2]
+		if i%2 == 0 {
+			nibble >>= 4
+		}
+		if lower[i] >= 'a' && nibble&0x0f >= 8 {
+			res[i] = lower[i] - 'a' + 'A'
+		} else {
+			res[i] = lower[i]
+		}
+	}
+
+	return fmt.Sprintf("0x%s", string(res))
+}
@@ -0,0 +1,73 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util_test
+
+import (
+	"testing"
+
+	"github.com/aaron-alderman/ethdo/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseExecutionAddress(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		checksum string
+		err      string
+	}{
+		{
+			name:  "Invalid",
+			input: "invalid",
+			err:   "invalid execution address: encoding/hex: invalid byte: U+0069 'i'",
+		},
+		{
+			name:  "Short",
+			input: "0x5aaeb6053f3e94c9b9a09f33669435e7ef1bea",
+			err:   "execution address must be 20 bytes",
+		},
+		{
+			name:  "ChecksumIncorrect",
+			input: "0x5AAeb6053F3E94C9b9A09f33669435E7Ef1BeAed",
+			err:   "execution address checksum incorrect",
+		},
+		{
+			name:     "Lower",
+			input:    "0x5aaeb6053f3e94c9b9a09f33669435e7ef1beaed",
+			checksum: "0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed",
+		},
+		{
+			name:     "Checksummed",
+			input:    "0xfB6916095ca1df60bB79Ce92cE3Ea74c37c5d359",
+			checksum: "0xfB6916095ca1df60bB79Ce92cE3Ea74c37c5d359",
+		},
+		{
+			name:     "NoPrefix",
+			input:    "dbF03B407c01E7cD3CBea99509d93f8DDDC8C6FB",
+			checksum: "0xdbF03B407c01E7cD3CBea99509d93f8DDDC8C6FB",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			address, err := util.ParseExecutionAddress(test.input)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, test.checksum, util.ExecutionAddressChecksum(address))
+			}
+		})
+	}
+}
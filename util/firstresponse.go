@@ -0,0 +1,74 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"context"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+)
+
+// firstResponseService is a client that sends read requests to all beacon nodes
+// concurrently and uses the first successful response.  Other requests are passed
+// to the underlying failover service.
+type firstResponseService struct {
+	*dispatchService
+	clients []eth2client.Service
+}
+
+// newFirstResponseService creates a new first response service.
+func newFirstResponseService(service eth2client.Service, clients []eth2client.Service) *firstResponseService {
+	s := &firstResponseService{
+		clients: clients,
+	}
+	s.dispatchService = &dispatchService{
+		wrappedService: &wrappedService{Service: service},
+		dispatch:       s.firstResponse,
+	}
+	return s
+}
+
+type firstResponseResult struct {
+	res interface{}
+	err error
+}
+
+// firstResponse calls all clients concurrently, returning the first non-empty
+// successful response.  If no client returns a response the last error is returned.
+func (s *firstResponseService) firstResponse(ctx context.Context, call callFunc) (interface{}, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	resCh := make(chan *firstResponseResult, len(s.clients))
+	for _, client := range s.clients {
+		go func(client eth2client.Service) {
+			res, err := call(ctx, client)
+			resCh <- &firstResponseResult{res: res, err: err}
+		}(client)
+	}
+
+	var err error
+	for range s.clients {
+		result := <-resCh
+		if result.err != nil {
+			err = result.err
+			continue
+		}
+		if result.res != nil {
+			return result.res, nil
+		}
+	}
+
+	return nil, err
+}
@@ -0,0 +1,352 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	"gopkg.in/yaml.v3"
+)
+
+// Output formats.
+const (
+	FormatText = "text"
+	FormatJSON = "json"
+	FormatYAML = "yaml"
+	FormatCSV  = "csv"
+)
+
+// OutputFormat returns the output format selected with the format option.
+// The json option provided by some commands selects JSON output if no format is supplied.
+func OutputFormat() string {
+	format := strings.ToLower(viper.GetString("format"))
+	if format == "" || format == FormatText {
+		if viper.GetBool("json") {
+			return FormatJSON
+		}
+		return FormatText
+	}
+
+	return format
+}
+
+// CheckOutputFormat checks that an output format is supported.
+func CheckOutputFormat(format string) error {
+	switch format {
+	case FormatText, FormatJSON, FormatYAML, FormatCSV:
+		return nil
+	default:
+		return fmt.Errorf("unsupported format %s; must be one of text, json, yaml or csv", format)
+	}
+}
+
+// StructuredFormat returns true if the format is a structured format.
+func StructuredFormat(format string) bool {
+	return format == FormatJSON || format == FormatYAML || format == FormatCSV
+}
+
+// FormatOutput returns data in the given structured format.
+// The data is encoded to JSON first, so its JSON encoding defines the output schema in all formats.
+func FormatOutput(format string, data interface{}) (string, error) {
+	encoded, err := json.Marshal(data)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to encode output")
+	}
+
+	return FormatJSONData(format, encoded)
+}
+
+// FormatJSONData returns JSON-encoded data in the given structured format.
+// YAML output retains the order of fields.  CSV output has one row for each item of a list, or a
+// single row for anything else, with nested fields flattened to dotted column names and lists
+// encoded as JSON.
+func FormatJSONData(format string, data []byte) (string, error) {
+	switch format {
+	case FormatJSON:
+		return string(bytes.TrimSpace(data)), nil
+	case FormatYAML:
+		value, err := decodeOrderedJSON(data)
+		if err != nil {
+			return "", err
+		}
+		buf := new(bytes.Buffer)
+		encoder := yaml.NewEncoder(buf)
+		encoder.SetIndent(2)
+		if err := encoder.Encode(toYAML(value)); err != nil {
+			return "", errors.Wrap(err, "failed to generate YAML")
+		}
+		if err := encoder.Close(); err != nil {
+			return "", errors.Wrap(err, "failed to generate YAML")
+		}
+		return strings.TrimSuffix(buf.String(), "\n"), nil
+	case FormatCSV:
+		value, err := decodeOrderedJSON(data)
+		if err != nil {
+			return "", err
+		}
+		return toCSV(value)
+	default:
+		return "", fmt.Errorf("format %s is not a structured format", format)
+	}
+}
+
+// orderedEntry is a field of a JSON object.
+type orderedEntry struct {
+	key   string
+	value interface{}
+}
+
+// orderedObject is a JSON object that retains the order of its fields.
+type orderedObject []*orderedEntry
+
+// decodeOrderedJSON decodes JSON, retaining the order of fields in objects.
+func decodeOrderedJSON(data []byte) (interface{}, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	value, err := decodeOrderedValue(decoder)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid JSON")
+	}
+
+	return value, nil
+}
+
+func decodeOrderedValue(decoder *json.Decoder) (interface{}, error) {
+	token, err := decoder.Token()
+	if err != nil {
+		return nil, err
+	}
+	switch token {
+	case json.Delim('{'):
+		object := make(orderedObject, 0)
+		for decoder.More() {
+			keyToken, err := decoder.Token()
+			if err != nil {
+				return nil, err
+			}
+			value, err := decodeOrderedValue(decoder)
+			if err != nil {
+				return nil, err
+			}
+			object = append(object, &orderedEntry{key: fmt.Sprintf("%v", keyToken), value: value})
+		}
+		if _, err := decoder.Token(); err != nil {
+			return nil, err
+		}
+		return object, nil
+	case json.Delim('['):
+		list := make([]interface{}, 0)
+		for decoder.More() {
+			value, err := decodeOrderedValue(decoder)
+			if err != nil {
+				return nil, err
+			}
+			list = append(list, value)
+		}
+		if _, err := decoder.Token(); err != nil {
+			return nil, err
+		}
+		return list, nil
+	default:
+		return token, nil
+	}
+}
+
+// toYAML converts a decoded JSON value to a YAML node, retaining the order of fields.
+// Hex strings are quoted, as some YAML parsers would otherwise read them as numbers.
+func toYAML(value interface{}) *yaml.Node {
+	switch v := value.(type) {
+	case orderedObject:
+		node := &yaml.Node{Kind: yaml.MappingNode}
+		for _, entry := range v {
+			node.Content = append(node.Content,
+				&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: entry.key},
+				toYAML(entry.value),
+			)
+		}
+		return node
+	case []interface{}:
+		node := &yaml.Node{Kind: yaml.SequenceNode}
+		for _, item := range v {
+			node.Content = append(node.Content, toYAML(item))
+		}
+		return node
+	case json.Number:
+		if !strings.ContainsAny(string(v), ".eE") {
+			return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!int", Value: string(v)}
+		}
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!float", Value: string(v)}
+	case string:
+		node := &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: v}
+		if strings.HasPrefix(v, "0x") {
+			node.Style = yaml.DoubleQuotedStyle
+		}
+		return node
+	case bool:
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!bool", Value: strconv.FormatBool(v)}
+	default:
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!null", Value: "null"}
+	}
+}
+
+// toCSV converts a decoded JSON value to CSV.
+func toCSV(value interface{}) (string, error) {
+	items, isList := value.([]interface{})
+	if !isList {
+		items = []interface{}{value}
+	}
+
+	columns := make([]string, 0)
+	known := make(map[string]bool)
+	rows := make([]map[string]string, 0, len(items))
+	for _, item := range items {
+		row := make(map[string]string)
+		if err := flattenCSV("", item, row, &columns, known); err != nil {
+			return "", err
+		}
+		rows = append(rows, row)
+	}
+
+	buf := new(bytes.Buffer)
+	writer := csv.NewWriter(buf)
+	if err := writer.Write(columns); err != nil {
+		return "", errors.Wrap(err, "failed to generate CSV")
+	}
+	for _, row := range rows {
+		record := make([]string, 0, len(columns))
+		for _, column := range columns {
+			record = append(record, row[column])
+		}
+		if err := writer.Write(record); err != nil {
+			return "", errors.Wrap(err, "failed to generate CSV")
+		}
+	}
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return "", errors.Wrap(err, "failed to generate CSV")
+	}
+
+	return strings.TrimSuffix(buf.String(), "\n"), nil
+}
+
+// flattenCSV flattens a decoded JSON value into the cells of a CSV row.
+func flattenCSV(prefix string, value interface{}, row map[string]string, columns *[]string, known map[string]bool) error {
+	if object, isObject := value.(orderedObject); isObject {
+		for _, entry := range object {
+			key := entry.key
+			if prefix != "" {
+				key = fmt.Sprintf("%s.%s", prefix, entry.key)
+			}
+			if err := flattenCSV(key, entry.value, row, columns, known); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	if prefix == "" {
+		prefix = "value"
+	}
+	if !known[prefix] {
+		known[prefix] = true
+		*columns = append(*columns, prefix)
+	}
+	cell, err := csvCell(value)
+	if err != nil {
+		return err
+	}
+	row[prefix] = cell
+
+	return nil
+}
+
+// csvCell returns the contents of a CSV cell for a decoded JSON value.
+func csvCell(value interface{}) (string, error) {
+	switch v := value.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return v, nil
+	case json.Number:
+		return string(v), nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	default:
+		buf := new(bytes.Buffer)
+		if err := writeOrderedJSON(buf, v); err != nil {
+			return "", err
+		}
+		return buf.String(), nil
+	}
+}
+
+// writeOrderedJSON writes a decoded JSON value as JSON, retaining the order of fields.
+func writeOrderedJSON(w io.Writer, value interface{}) error {
+	switch v := value.(type) {
+	case orderedObject:
+		if _, err := io.WriteString(w, "{"); err != nil {
+			return err
+		}
+		for i, entry := range v {
+			if i > 0 {
+				if _, err := io.WriteString(w, ","); err != nil {
+					return err
+				}
+			}
+			key, err := json.Marshal(entry.key)
+			if err != nil {
+				return err
+			}
+			if _, err := w.Write(append(key, ':')); err != nil {
+				return err
+			}
+			if err := writeOrderedJSON(w, entry.value); err != nil {
+				return err
+			}
+		}
+		_, err := io.WriteString(w, "}")
+		return err
+	case []interface{}:
+		if _, err := io.WriteString(w, "["); err != nil {
+			return err
+		}
+		for i, item := range v {
+			if i > 0 {
+				if _, err := io.WriteString(w, ","); err != nil {
+					return err
+				}
+			}
+			if err := writeOrderedJSON(w, item); err != nil {
+				return err
+			}
+		}
+		_, err := io.WriteString(w, "]")
+		return err
+	default:
+		data, err := json.Marshal(v)
+		if err != nil {
+			return err
+		}
+		_, err = w.Write(data)
+		return err
+	}
+}
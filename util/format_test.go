@@ -0,0 +1,205 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util_test
+
+import (
+	"testing"
+
+	"github.com/aaron-alderman/ethdo/util"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+)
+
+type testFormatNested struct {
+	B string `json:"b"`
+	A int    `json:"a"`
+}
+
+type testFormatItem struct {
+	Name   string            `json:"name"`
+	Count  uint64            `json:"count"`
+	Nested *testFormatNested `json:"nested,omitempty"`
+	List   []int             `json:"list,omitempty"`
+}
+
+func TestOutputFormat(t *testing.T) {
+	tests := []struct {
+		name   string
+		vars   map[string]interface{}
+		format string
+	}{
+		{
+			name:   "Default",
+			format: util.FormatText,
+		},
+		{
+			name: "YAML",
+			vars: map[string]interface{}{
+				"format": "yaml",
+			},
+			format: util.FormatYAML,
+		},
+		{
+			name: "UpperCase",
+			vars: map[string]interface{}{
+				"format": "CSV",
+			},
+			format: util.FormatCSV,
+		},
+		{
+			name: "LegacyJSON",
+			vars: map[string]interface{}{
+				"json": true,
+			},
+			format: util.FormatJSON,
+		},
+		{
+			name: "LegacyJSONWithFormat",
+			vars: map[string]interface{}{
+				"format": "yaml",
+				"json":   true,
+			},
+			format: util.FormatYAML,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			viper.Reset()
+			for k, v := range test.vars {
+				viper.Set(k, v)
+			}
+			require.Equal(t, test.format, util.OutputFormat())
+		})
+	}
+}
+
+func TestCheckOutputFormat(t *testing.T) {
+	require.NoError(t, util.CheckOutputFormat(util.FormatText))
+	require.NoError(t, util.CheckOutputFormat(util.FormatJSON))
+	require.NoError(t, util.CheckOutputFormat(util.FormatYAML))
+	require.NoError(t, util.CheckOutputFormat(util.FormatCSV))
+	require.EqualError(t, util.CheckOutputFormat("xml"), "unsupported format xml; must be one of text, json, yaml or csv")
+	require.False(t, util.StructuredFormat(util.FormatText))
+	require.True(t, util.StructuredFormat(util.FormatYAML))
+}
+
+func TestFormatOutput(t *testing.T) {
+	item := &testFormatItem{
+		Name:  "first",
+		Count: 18446744073709551615,
+		Nested: &testFormatNested{
+			B: "b,with comma",
+			A: 1,
+		},
+		List: []int{1, 2},
+	}
+	items := []*testFormatItem{
+		item,
+		{
+			Name:  "second",
+			Count: 2,
+		},
+	}
+
+	tests := []struct {
+		name   string
+		format string
+		data   interface{}
+		res    string
+		err    string
+	}{
+		{
+			name:   "Text",
+			format: util.FormatText,
+			data:   item,
+			err:    "format text is not a structured format",
+		},
+		{
+			name:   "JSON",
+			format: util.FormatJSON,
+			data:   item,
+			res:    `{"name":"first","count":18446744073709551615,"nested":{"b":"b,with comma","a":1},"list":[1,2]}`,
+		},
+		{
+			name:   "YAML",
+			format: util.FormatYAML,
+			data:   item,
+			res: `name: first
+count: 18446744073709551615
+nested:
+  b: b,with comma
+  a: 1
+list:
+  - 1
+  - 2`,
+		},
+		{
+			name:   "YAMLHex",
+			format: util.FormatYAML,
+			data: map[string]interface{}{
+				"root": "0x0102",
+				"none": nil,
+				"ok":   true,
+				"frac": 1.5,
+			},
+			res: `frac: 1.5
+none: null
+ok: true
+root: "0x0102"`,
+		},
+		{
+			name:   "YAMLList",
+			format: util.FormatYAML,
+			data:   []string{"a", "b"},
+			res: `- a
+- b`,
+		},
+		{
+			name:   "CSV",
+			format: util.FormatCSV,
+			data:   item,
+			res: `name,count,nested.b,nested.a,list
+first,18446744073709551615,"b,with comma",1,"[1,2]"`,
+		},
+		{
+			name:   "CSVList",
+			format: util.FormatCSV,
+			data:   items,
+			res: `name,count,nested.b,nested.a,list
+first,18446744073709551615,"b,with comma",1,"[1,2]"
+second,2,,,`,
+		},
+		{
+			name:   "CSVScalar",
+			format: util.FormatCSV,
+			data:   []int{1, 2},
+			res: `value
+1
+2`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			res, err := util.FormatOutput(test.format, test.data)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, test.res, res)
+		})
+	}
+}
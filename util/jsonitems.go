@@ -0,0 +1,95 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// JSONItem is a single JSON item read from a file.
+type JSONItem struct {
+	// Source is the file from which the item was read, with the index of the item if
+	// the file contains an array.
+	Source string
+	Data   json.RawMessage
+	// Err is set if the item could not be read.
+	Err error
+}
+
+// ReadJSONItems reads JSON items from a file, or from all files with a .json suffix in a
+// directory.  Each file can contain either a single item or an array of items.
+// Files that are not valid JSON are returned as items with an error, rather than failing
+// the entire read.
+func ReadJSONItems(path string) ([]*JSONItem, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to access data")
+	}
+
+	files := []string{path}
+	if info.IsDir() {
+		entries, err := ioutil.ReadDir(path)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to read data directory")
+		}
+		files = make([]string, 0, len(entries))
+		for _, entry := range entries {
+			if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".json") {
+				continue
+			}
+			files = append(files, filepath.Join(path, entry.Name()))
+		}
+	}
+
+	items := make([]*JSONItem, 0)
+	for _, file := range files {
+		data, err := ioutil.ReadFile(file)
+		if err != nil {
+			return nil, errors.Wrap(err, fmt.Sprintf("failed to read %s", file))
+		}
+		data = bytes.TrimSpace(data)
+		if !bytes.HasPrefix(data, []byte("[")) {
+			items = append(items, &JSONItem{
+				Source: file,
+				Data:   data,
+			})
+			continue
+		}
+
+		elements := make([]json.RawMessage, 0)
+		if err := json.Unmarshal(data, &elements); err != nil {
+			items = append(items, &JSONItem{
+				Source: file,
+				Err:    errors.Wrap(err, "invalid JSON"),
+			})
+			continue
+		}
+		for i := range elements {
+			items = append(items, &JSONItem{
+				Source: fmt.Sprintf("%s[%d]", file, i),
+				Data:   elements[i],
+			})
+		}
+	}
+
+	return items, nil
+}
@@ -0,0 +1,84 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/aaron-alderman/ethdo/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadJSONItems(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "array.json"), []byte(`[{"a":1},{"b":2}]`), 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "bad.json"), []byte(`[{"a":1`), 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "ignored.txt"), []byte(`{"c":3}`), 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "single.json"), []byte(" {\"d\":4}\n"), 0600))
+	require.NoError(t, os.Mkdir(filepath.Join(dir, "subdir.json"), 0700))
+
+	tests := []struct {
+		name    string
+		path    string
+		sources []string
+		data    []string
+		err     string
+	}{
+		{
+			name: "Missing",
+			path: filepath.Join(dir, "missing"),
+			err:  "failed to access data: stat " + filepath.Join(dir, "missing") + ": no such file or directory",
+		},
+		{
+			name:    "File",
+			path:    filepath.Join(dir, "single.json"),
+			sources: []string{filepath.Join(dir, "single.json")},
+			data:    []string{`{"d":4}`},
+		},
+		{
+			name: "Directory",
+			path: dir,
+			sources: []string{
+				filepath.Join(dir, "array.json") + "[0]",
+				filepath.Join(dir, "array.json") + "[1]",
+				filepath.Join(dir, "bad.json"),
+				filepath.Join(dir, "single.json"),
+			},
+			data: []string{`{"a":1}`, `{"b":2}`, ``, `{"d":4}`},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			items, err := util.ReadJSONItems(test.path)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, items, len(test.sources))
+			for i := range items {
+				require.Equal(t, test.sources[i], items[i].Source)
+				if test.data[i] == "" {
+					require.Error(t, items[i].Err)
+					continue
+				}
+				require.NoError(t, items[i].Err)
+				require.Equal(t, test.data[i], string(items[i].Data))
+			}
+		})
+	}
+}
@@ -0,0 +1,113 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	"github.com/tyler-smith/go-bip39"
+	e2types "github.com/wealdtech/go-eth2-types/v2"
+	ethutil "github.com/wealdtech/go-eth2-util"
+	"golang.org/x/text/unicode/norm"
+)
+
+// withdrawalKeySearchLimit is the number of withdrawal key paths searched in a seed.
+const withdrawalKeySearchLimit = 1024
+
+// WithdrawalKey is a withdrawal key derived from a seed.
+type WithdrawalKey struct {
+	Path string
+	Key  *e2types.BLSPrivateKey
+}
+
+// SeedFromMnemonic creates a seed from a mnemonic.
+// If there are more than 24 words the additional words are treated as the passphrase.
+func SeedFromMnemonic(mnemonic string) ([]byte, error) {
+	mnemonicParts := strings.Split(mnemonic, " ")
+	mnemonicPassphrase := ""
+	if len(mnemonicParts) > 24 {
+		mnemonic = strings.Join(mnemonicParts[:24], " ")
+		mnemonicPassphrase = strings.Join(mnemonicParts[24:], " ")
+	}
+	// Normalise the input.
+	mnemonic = string(norm.NFKD.Bytes([]byte(mnemonic)))
+	mnemonicPassphrase = string(norm.NFKD.Bytes([]byte(mnemonicPassphrase)))
+
+	if !bip39.IsMnemonicValid(mnemonic) {
+		return nil, errors.New("mnemonic is invalid")
+	}
+
+	return bip39.NewSeed(mnemonic, mnemonicPassphrase), nil
+}
+
+// WithdrawalKeysForCredentials searches the withdrawal key paths of a seed for the keys that
+// generate the given BLS withdrawal credentials.  The result is keyed by withdrawal credentials,
+// and contains only those credentials for which a key was found.
+func WithdrawalKeysForCredentials(seed []byte, withdrawalCredentials [][]byte) (map[[32]byte]*WithdrawalKey, error) {
+	required := make(map[[32]byte]bool, len(withdrawalCredentials))
+	for _, credentials := range withdrawalCredentials {
+		var key [32]byte
+		copy(key[:], credentials)
+		required[key] = true
+	}
+
+	res := make(map[[32]byte]*WithdrawalKey)
+	for i := 0; i < withdrawalKeySearchLimit && len(res) < len(required); i++ {
+		path := fmt.Sprintf("m/12381/3600/%d/0", i)
+		if viper.GetBool("debug") {
+			fmt.Fprintf(os.Stderr, "Checking path %s\n", path)
+		}
+		key, err := ethutil.PrivateKeyFromSeedAndPath(seed, path)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to generate key")
+		}
+		var credentials [32]byte
+		copy(credentials[:], BLSWithdrawalCredentials(key.PublicKey().Marshal()))
+		if required[credentials] {
+			res[credentials] = &WithdrawalKey{
+				Path: path,
+				Key:  key,
+			}
+		}
+	}
+
+	return res, nil
+}
+
+// WithdrawalPathsForValidatorKeys derives the validator keys of a seed at the signing key paths
+// m/12381/3600/i/0/0 that correspond to the searched withdrawal key paths m/12381/3600/i/0.  The
+// result is keyed by validator public key, and contains the withdrawal key path for each.
+func WithdrawalPathsForValidatorKeys(seed []byte) (map[phase0.BLSPubKey]string, error) {
+	res := make(map[phase0.BLSPubKey]string, withdrawalKeySearchLimit)
+	for i := 0; i < withdrawalKeySearchLimit; i++ {
+		path := fmt.Sprintf("m/12381/3600/%d/0/0", i)
+		if viper.GetBool("debug") {
+			fmt.Fprintf(os.Stderr, "Deriving validator key at path %s\n", path)
+		}
+		key, err := ethutil.PrivateKeyFromSeedAndPath(seed, path)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to generate key")
+		}
+		var pubKey phase0.BLSPubKey
+		copy(pubKey[:], key.PublicKey().Marshal())
+		res[pubKey] = fmt.Sprintf("m/12381/3600/%d/0", i)
+	}
+
+	return res, nil
+}
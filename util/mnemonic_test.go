@@ -0,0 +1,103 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util_test
+
+import (
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/aaron-alderman/ethdo/util"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+	e2types "github.com/wealdtech/go-eth2-types/v2"
+)
+
+func TestWithdrawalKeysForCredentials(t *testing.T) {
+	require.NoError(t, e2types.InitBLS())
+
+	tests := []struct {
+		name        string
+		mnemonic    string
+		credentials []string
+		paths       map[string]string
+		err         string
+	}{
+		{
+			name:     "MnemonicInvalid",
+			mnemonic: "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon",
+			err:      "mnemonic is invalid",
+		},
+		{
+			name:     "NotFound",
+			mnemonic: "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon art",
+			credentials: []string{
+				"0x00ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff",
+			},
+			paths: map[string]string{},
+		},
+		{
+			name:     "Good",
+			mnemonic: "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon art",
+			credentials: []string{
+				"0x007e28dcf9029e8d92ca4b5d01c66c934e7f3110606f34ae3052cbf67bd3fc02",
+			},
+			paths: map[string]string{
+				"0x007e28dcf9029e8d92ca4b5d01c66c934e7f3110606f34ae3052cbf67bd3fc02": "m/12381/3600/10/0",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			seed, err := util.SeedFromMnemonic(test.mnemonic)
+			if err != nil {
+				require.EqualError(t, err, test.err)
+				return
+			}
+			require.Empty(t, test.err)
+
+			credentials := make([][]byte, 0, len(test.credentials))
+			for _, credential := range test.credentials {
+				data, err := hex.DecodeString(strings.TrimPrefix(credential, "0x"))
+				require.NoError(t, err)
+				credentials = append(credentials, data)
+			}
+			keys, err := util.WithdrawalKeysForCredentials(seed, credentials)
+			require.NoError(t, err)
+			paths := make(map[string]string, len(keys))
+			for credential, key := range keys {
+				paths["0x"+hex.EncodeToString(credential[:])] = key.Path
+			}
+			require.Equal(t, test.paths, paths)
+		})
+	}
+}
+
+func TestWithdrawalPathsForValidatorKeys(t *testing.T) {
+	require.NoError(t, e2types.InitBLS())
+
+	seed, err := util.SeedFromMnemonic("abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon art")
+	require.NoError(t, err)
+
+	paths, err := util.WithdrawalPathsForValidatorKeys(seed)
+	require.NoError(t, err)
+	require.Len(t, paths, 1024)
+
+	var pubKey phase0.BLSPubKey
+	data, err := hex.DecodeString("b557c7af324c68f7c160ba3845b99efed189424b0c74a71050f1ecc11a9ff4617e2df70a9dcdbf69be47e341d53e2c98")
+	require.NoError(t, err)
+	copy(pubKey[:], data)
+	require.Equal(t, "m/12381/3600/10/0", paths[pubKey])
+}
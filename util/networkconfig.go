@@ -0,0 +1,342 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	"gopkg.in/yaml.v3"
+)
+
+// farFutureEpoch is the epoch used in chain specifications for forks that are not scheduled.
+const farFutureEpoch = uint64(0xffffffffffffffff)
+
+// customNetworkConfigs returns the networks defined in the "networks" section of the configuration file.
+// Each network is keyed by its name, and can contain the path of a chain specification in "chain-spec"
+// along with any of "deposit-contract", "deposit-contract-block", "genesis-fork-version",
+// "genesis-validators-root", "forks", "seconds-per-slot" and "slots-per-epoch", which override
+// values in the chain specification.
+func customNetworkConfigs() ([]*NetworkConfig, error) {
+	entries := viper.GetStringMap("networks")
+	names := make([]string, 0, len(entries))
+	for name := range entries {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	res := make([]*NetworkConfig, 0, len(names))
+	for _, name := range names {
+		entry, err := toStringMap(entries[name])
+		if err != nil {
+			return nil, errors.Wrap(err, fmt.Sprintf("invalid configuration for network %s", name))
+		}
+		network, err := customNetworkConfig(name, entry)
+		if err != nil {
+			return nil, errors.Wrap(err, fmt.Sprintf("invalid configuration for network %s", name))
+		}
+		res = append(res, network)
+	}
+
+	return res, nil
+}
+
+// customNetworkConfig creates the configuration for a single custom network.
+func customNetworkConfig(name string, entry map[string]interface{}) (*NetworkConfig, error) {
+	network := &NetworkConfig{}
+	if path, exists := entry["chain-spec"]; exists {
+		var err error
+		network, err = LoadChainSpec(fmt.Sprintf("%v", path))
+		if err != nil {
+			return nil, err
+		}
+	}
+	network.Name = name
+
+	if value, exists := entry["deposit-contract"]; exists {
+		depositContract, err := configBytes(value, 20)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid deposit contract")
+		}
+		network.DepositContract = depositContract
+	}
+
+	if value, exists := entry["deposit-contract-block"]; exists {
+		block, err := configUint64(value)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid deposit contract block")
+		}
+		network.DepositContractBlock = block
+	}
+
+	if value, exists := entry["genesis-fork-version"]; exists {
+		forkVersion, err := configBytes(value, phase0.ForkVersionLength)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid genesis fork version")
+		}
+		copy(network.GenesisForkVersion[:], forkVersion)
+	} else if network.Forks == nil {
+		return nil, errors.New("genesis fork version is required")
+	}
+
+	if value, exists := entry["genesis-validators-root"]; exists {
+		root, err := configBytes(value, phase0.RootLength)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid genesis validators root")
+		}
+		network.GenesisValidatorsRoot = &phase0.Root{}
+		copy(network.GenesisValidatorsRoot[:], root)
+	}
+
+	if value, exists := entry["seconds-per-slot"]; exists {
+		seconds, err := configUint64(value)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid seconds per slot")
+		}
+		network.SlotDuration = time.Duration(seconds) * time.Second
+	}
+
+	if value, exists := entry["slots-per-epoch"]; exists {
+		slotsPerEpoch, err := configUint64(value)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid slots per epoch")
+		}
+		network.SlotsPerEpoch = slotsPerEpoch
+	}
+
+	forks := make([]*phase0.Fork, 0)
+	if value, exists := entry["forks"]; exists {
+		items, isList := value.([]interface{})
+		if !isList {
+			return nil, errors.New("forks must be a list")
+		}
+		for i, item := range items {
+			fork, err := configFork(item)
+			if err != nil {
+				return nil, errors.Wrap(err, fmt.Sprintf("invalid fork %d", i))
+			}
+			forks = append(forks, fork)
+		}
+	} else if network.Forks != nil {
+		forks = network.Forks
+	}
+	network.Forks = sortForks(network.GenesisForkVersion, forks)
+
+	return network, nil
+}
+
+// configFork creates a fork from an item in the configuration file.
+func configFork(item interface{}) (*phase0.Fork, error) {
+	entry, err := toStringMap(item)
+	if err != nil {
+		return nil, err
+	}
+	fork := &phase0.Fork{}
+	value, exists := entry["version"]
+	if !exists {
+		return nil, errors.New("version is required")
+	}
+	version, err := configBytes(value, phase0.ForkVersionLength)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid version")
+	}
+	copy(fork.CurrentVersion[:], version)
+	value, exists = entry["epoch"]
+	if !exists {
+		return nil, errors.New("epoch is required")
+	}
+	epoch, err := configUint64(value)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid epoch")
+	}
+	fork.Epoch = phase0.Epoch(epoch)
+
+	return fork, nil
+}
+
+// LoadChainSpec loads network configuration from a chain specification in the
+// format of the consensus specification config.yaml file.
+// Chain specifications do not contain a genesis validators root, so it is not set.
+func LoadChainSpec(path string) (*NetworkConfig, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read chain specification")
+	}
+	// Values are read as strings to retain the leading zeros of hex values.
+	spec := make(map[string]string)
+	if err := yaml.Unmarshal(data, &spec); err != nil {
+		return nil, errors.Wrap(err, "failed to parse chain specification")
+	}
+
+	network := &NetworkConfig{
+		Name: spec["CONFIG_NAME"],
+	}
+
+	value, exists := spec["DEPOSIT_CONTRACT_ADDRESS"]
+	if !exists {
+		return nil, errors.New("chain specification does not contain DEPOSIT_CONTRACT_ADDRESS")
+	}
+	network.DepositContract, err = configBytes(value, 20)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid DEPOSIT_CONTRACT_ADDRESS")
+	}
+
+	value, exists = spec["GENESIS_FORK_VERSION"]
+	if !exists {
+		return nil, errors.New("chain specification does not contain GENESIS_FORK_VERSION")
+	}
+	forkVersion, err := configBytes(value, phase0.ForkVersionLength)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid GENESIS_FORK_VERSION")
+	}
+	copy(network.GenesisForkVersion[:], forkVersion)
+
+	// Slots per epoch is part of the preset rather than the configuration, so is usually absent.
+	if value, exists := spec["SECONDS_PER_SLOT"]; exists {
+		seconds, err := strconv.ParseUint(value, 10, 64)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid SECONDS_PER_SLOT")
+		}
+		network.SlotDuration = time.Duration(seconds) * time.Second
+	}
+	if value, exists := spec["SLOTS_PER_EPOCH"]; exists {
+		network.SlotsPerEpoch, err = strconv.ParseUint(value, 10, 64)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid SLOTS_PER_EPOCH")
+		}
+	}
+
+	// Forks are defined by pairs of <NAME>_FORK_VERSION and <NAME>_FORK_EPOCH.
+	forks := make([]*phase0.Fork, 0)
+	for key, value := range spec {
+		if !strings.HasSuffix(key, "_FORK_VERSION") || key == "GENESIS_FORK_VERSION" {
+			continue
+		}
+		epochKey := fmt.Sprintf("%s_FORK_EPOCH", strings.TrimSuffix(key, "_FORK_VERSION"))
+		epochValue, exists := spec[epochKey]
+		if !exists {
+			return nil, fmt.Errorf("chain specification does not contain %s", epochKey)
+		}
+		epoch, err := strconv.ParseUint(epochValue, 10, 64)
+		if err != nil {
+			return nil, errors.Wrap(err, fmt.Sprintf("invalid %s", epochKey))
+		}
+		if epoch == farFutureEpoch {
+			continue
+		}
+		version, err := configBytes(value, phase0.ForkVersionLength)
+		if err != nil {
+			return nil, errors.Wrap(err, fmt.Sprintf("invalid %s", key))
+		}
+		fork := &phase0.Fork{
+			Epoch: phase0.Epoch(epoch),
+		}
+		copy(fork.CurrentVersion[:], version)
+		forks = append(forks, fork)
+	}
+	network.Forks = sortForks(network.GenesisForkVersion, forks)
+
+	return network, nil
+}
+
+// toStringMap converts a map from the configuration file to a map with string keys.
+func toStringMap(input interface{}) (map[string]interface{}, error) {
+	switch value := input.(type) {
+	case map[string]interface{}:
+		return value, nil
+	case map[interface{}]interface{}:
+		res := make(map[string]interface{}, len(value))
+		for k, v := range value {
+			res[strings.ToLower(fmt.Sprintf("%v", k))] = v
+		}
+		return res, nil
+	default:
+		return nil, errors.New("not a map")
+	}
+}
+
+// configBytes returns a fixed-length byte value from the configuration.
+// The value can be a hex string, or an integer if the configuration parser has interpreted it as such.
+func configBytes(input interface{}, length int) ([]byte, error) {
+	var value uint64
+	switch v := input.(type) {
+	case string:
+		data, err := hex.DecodeString(strings.TrimPrefix(v, "0x"))
+		if err != nil {
+			return nil, err
+		}
+		if len(data) != length {
+			return nil, fmt.Errorf("must be %d bytes", length)
+		}
+		return data, nil
+	case int:
+		if v < 0 {
+			return nil, errors.New("must not be negative")
+		}
+		value = uint64(v)
+	case int64:
+		if v < 0 {
+			return nil, errors.New("must not be negative")
+		}
+		value = uint64(v)
+	case uint64:
+		value = v
+	default:
+		return nil, fmt.Errorf("unsupported value %v", input)
+	}
+
+	data := make([]byte, 8)
+	binary.BigEndian.PutUint64(data, value)
+	if length >= 8 {
+		return append(make([]byte, length-8), data...), nil
+	}
+	for _, b := range data[:8-length] {
+		if b != 0 {
+			return nil, fmt.Errorf("must be %d bytes", length)
+		}
+	}
+
+	return data[8-length:], nil
+}
+
+// configUint64 returns an unsigned integer value from the configuration.
+func configUint64(input interface{}) (uint64, error) {
+	switch v := input.(type) {
+	case string:
+		return strconv.ParseUint(v, 10, 64)
+	case int:
+		if v < 0 {
+			return 0, errors.New("must not be negative")
+		}
+		return uint64(v), nil
+	case int64:
+		if v < 0 {
+			return 0, errors.New("must not be negative")
+		}
+		return uint64(v), nil
+	case uint64:
+		return v, nil
+	default:
+		return 0, fmt.Errorf("unsupported value %v", input)
+	}
+}
@@ -0,0 +1,273 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/aaron-alderman/ethdo/testutil"
+	"github.com/aaron-alderman/ethdo/util"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+)
+
+const testChainSpec = `PRESET_BASE: 'mainnet'
+CONFIG_NAME: 'testnet'
+GENESIS_FORK_VERSION: 0x10000038
+ALTAIR_FORK_VERSION: 0x20000038
+ALTAIR_FORK_EPOCH: 0
+BELLATRIX_FORK_VERSION: 0x30000038
+BELLATRIX_FORK_EPOCH: 10
+CAPELLA_FORK_VERSION: 0x40000038
+CAPELLA_FORK_EPOCH: 18446744073709551615
+SECONDS_PER_SLOT: 12
+DEPOSIT_CHAIN_ID: 1337
+DEPOSIT_CONTRACT_ADDRESS: 0x4242424242424242424242424242424242424242
+`
+
+func TestLoadChainSpec(t *testing.T) {
+	dir := t.TempDir()
+
+	tests := []struct {
+		name    string
+		spec    string
+		err     string
+		network *util.NetworkConfig
+	}{
+		{
+			name: "DepositContractMissing",
+			spec: "GENESIS_FORK_VERSION: 0x10000038\n",
+			err:  "chain specification does not contain DEPOSIT_CONTRACT_ADDRESS",
+		},
+		{
+			name: "GenesisForkVersionMissing",
+			spec: "DEPOSIT_CONTRACT_ADDRESS: 0x4242424242424242424242424242424242424242\n",
+			err:  "chain specification does not contain GENESIS_FORK_VERSION",
+		},
+		{
+			name: "ForkEpochMissing",
+			spec: "GENESIS_FORK_VERSION: 0x10000038\nALTAIR_FORK_VERSION: 0x20000038\nDEPOSIT_CONTRACT_ADDRESS: 0x4242424242424242424242424242424242424242\n",
+			err:  "chain specification does not contain ALTAIR_FORK_EPOCH",
+		},
+		{
+			name: "ForkVersionInvalid",
+			spec: "GENESIS_FORK_VERSION: 0x1000\nDEPOSIT_CONTRACT_ADDRESS: 0x4242424242424242424242424242424242424242\n",
+			err:  "invalid GENESIS_FORK_VERSION: must be 4 bytes",
+		},
+		{
+			name: "Good",
+			spec: testChainSpec,
+			network: &util.NetworkConfig{
+				Name:               "testnet",
+				DepositContract:    testutil.HexToBytes("0x4242424242424242424242424242424242424242"),
+				GenesisForkVersion: phase0.Version{0x10, 0x00, 0x00, 0x38},
+				Forks: []*phase0.Fork{
+					{
+						PreviousVersion: phase0.Version{0x10, 0x00, 0x00, 0x38},
+						CurrentVersion:  phase0.Version{0x20, 0x00, 0x00, 0x38},
+					},
+					{
+						PreviousVersion: phase0.Version{0x20, 0x00, 0x00, 0x38},
+						CurrentVersion:  phase0.Version{0x30, 0x00, 0x00, 0x38},
+						Epoch:           10,
+					},
+				},
+				SlotDuration: 12 * time.Second,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			path := filepath.Join(dir, test.name)
+			require.NoError(t, os.WriteFile(path, []byte(test.spec), 0600))
+			network, err := util.LoadChainSpec(path)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, test.network, network)
+			}
+		})
+	}
+}
+
+func TestCustomNetworks(t *testing.T) {
+	dir := t.TempDir()
+	chainSpecPath := filepath.Join(dir, "config.yaml")
+	require.NoError(t, os.WriteFile(chainSpecPath, []byte(testChainSpec), 0600))
+
+	tests := []struct {
+		name    string
+		config  string
+		network string
+		err     string
+		res     *util.NetworkConfig
+	}{
+		{
+			name: "GenesisForkVersionMissing",
+			config: `networks:
+  mydevnet:
+    deposit-contract: 0x4242424242424242424242424242424242424242
+`,
+			network: "mydevnet",
+			err:     "invalid configuration for network mydevnet: genesis fork version is required",
+		},
+		{
+			name: "DepositContractInvalid",
+			config: `networks:
+  mydevnet:
+    genesis-fork-version: 0x10000038
+    deposit-contract: "0x4242"
+`,
+			network: "mydevnet",
+			err:     "invalid configuration for network mydevnet: invalid deposit contract: must be 20 bytes",
+		},
+		{
+			name: "ChainSpecMissing",
+			config: `networks:
+  mydevnet:
+    chain-spec: ` + filepath.Join(dir, "missing.yaml") + `
+`,
+			network: "mydevnet",
+			err:     "invalid configuration for network mydevnet: failed to read chain specification: open " + filepath.Join(dir, "missing.yaml") + ": no such file or directory",
+		},
+		{
+			name: "Explicit",
+			config: `networks:
+  myDevnet:
+    deposit-contract: 0x0000000000000000000000000000000000001234
+    deposit-contract-block: 10
+    genesis-fork-version: 0x00000038
+    genesis-validators-root: 0x0102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f20
+    seconds-per-slot: 6
+    slots-per-epoch: 8
+    forks:
+      - version: 0x02000038
+        epoch: 20
+      - version: 0x01000038
+        epoch: 10
+`,
+			network: "MyDevnet",
+			res: &util.NetworkConfig{
+				Name:                  "mydevnet",
+				DepositContract:       testutil.HexToBytes("0x0000000000000000000000000000000000001234"),
+				DepositContractBlock:  10,
+				GenesisForkVersion:    phase0.Version{0x00, 0x00, 0x00, 0x38},
+				GenesisValidatorsRoot: &phase0.Root{0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08, 0x09, 0x0a, 0x0b, 0x0c, 0x0d, 0x0e, 0x0f, 0x10, 0x11, 0x12, 0x13, 0x14, 0x15, 0x16, 0x17, 0x18, 0x19, 0x1a, 0x1b, 0x1c, 0x1d, 0x1e, 0x1f, 0x20},
+				Forks: []*phase0.Fork{
+					{
+						PreviousVersion: phase0.Version{0x00, 0x00, 0x00, 0x38},
+						CurrentVersion:  phase0.Version{0x00, 0x00, 0x00, 0x38},
+					},
+					{
+						PreviousVersion: phase0.Version{0x00, 0x00, 0x00, 0x38},
+						CurrentVersion:  phase0.Version{0x01, 0x00, 0x00, 0x38},
+						Epoch:           10,
+					},
+					{
+						PreviousVersion: phase0.Version{0x01, 0x00, 0x00, 0x38},
+						CurrentVersion:  phase0.Version{0x02, 0x00, 0x00, 0x38},
+						Epoch:           20,
+					},
+				},
+				SlotDuration:  6 * time.Second,
+				SlotsPerEpoch: 8,
+			},
+		},
+		{
+			name: "ChainSpec",
+			config: `networks:
+  mydevnet:
+    chain-spec: ` + chainSpecPath + `
+    genesis-validators-root: "0x0102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f20"
+`,
+			network: "mydevnet",
+			res: &util.NetworkConfig{
+				Name:                  "mydevnet",
+				DepositContract:       testutil.HexToBytes("0x4242424242424242424242424242424242424242"),
+				GenesisForkVersion:    phase0.Version{0x10, 0x00, 0x00, 0x38},
+				GenesisValidatorsRoot: &phase0.Root{0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08, 0x09, 0x0a, 0x0b, 0x0c, 0x0d, 0x0e, 0x0f, 0x10, 0x11, 0x12, 0x13, 0x14, 0x15, 0x16, 0x17, 0x18, 0x19, 0x1a, 0x1b, 0x1c, 0x1d, 0x1e, 0x1f, 0x20},
+				Forks: []*phase0.Fork{
+					{
+						PreviousVersion: phase0.Version{0x10, 0x00, 0x00, 0x38},
+						CurrentVersion:  phase0.Version{0x20, 0x00, 0x00, 0x38},
+					},
+					{
+						PreviousVersion: phase0.Version{0x20, 0x00, 0x00, 0x38},
+						CurrentVersion:  phase0.Version{0x30, 0x00, 0x00, 0x38},
+						Epoch:           10,
+					},
+				},
+				SlotDuration: 12 * time.Second,
+			},
+		},
+		{
+			name: "BuiltinUnaffected",
+			config: `networks:
+  mydevnet:
+    genesis-fork-version: 0x10000038
+`,
+			network: "prater",
+			res:     nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			viper.Reset()
+			viper.SetConfigType("yaml")
+			require.NoError(t, viper.ReadConfig(bytes.NewBufferString(test.config)))
+			network, err := util.NetworkConfigByName(test.network)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+				return
+			}
+			require.NoError(t, err)
+			if test.res == nil {
+				require.Equal(t, "Prater", network.Name)
+				return
+			}
+			require.Equal(t, test.res, network)
+			// Custom networks are also found by genesis fork version.
+			require.Equal(t, test.res.Name, util.NetworkForGenesisForkVersion(test.res.GenesisForkVersion))
+		})
+	}
+}
+
+func TestNetworkDomain(t *testing.T) {
+	viper.Reset()
+	mainnet, err := util.NetworkConfigByName("mainnet")
+	require.NoError(t, err)
+
+	require.Equal(t, phase0.Version{0x00, 0x00, 0x00, 0x00}, mainnet.ForkAtEpoch(0).CurrentVersion)
+	require.Equal(t, phase0.Version{0x00, 0x00, 0x00, 0x00}, mainnet.ForkAtEpoch(74239).CurrentVersion)
+	require.Equal(t, phase0.Version{0x01, 0x00, 0x00, 0x00}, mainnet.ForkAtEpoch(74240).CurrentVersion)
+	require.Equal(t, phase0.Version{0x02, 0x00, 0x00, 0x00}, mainnet.ForkAtEpoch(200000).CurrentVersion)
+
+	// Voluntary exit domain for mainnet Bellatrix.
+	domain, err := mainnet.Domain(phase0.DomainType{0x04, 0x00, 0x00, 0x00}, 200000)
+	require.NoError(t, err)
+	require.Equal(t, testutil.HexToBytes("0x040000004a26c58b08add8089b75caa540848881a8d4f0af0be83417a85c0f45"), domain[:])
+
+	pyrmont, err := util.NetworkConfigByName("pyrmont")
+	require.NoError(t, err)
+	_, err = pyrmont.Domain(phase0.DomainType{0x04, 0x00, 0x00, 0x00}, 0)
+	require.EqualError(t, err, "genesis validators root of network Pyrmont is not known")
+}
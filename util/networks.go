@@ -14,54 +14,389 @@
 package util
 
 import (
+	"bytes"
 	"context"
+	"encoding/hex"
 	"fmt"
+	"sort"
+	"strings"
+	"time"
 
 	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/pkg/errors"
+	e2types "github.com/wealdtech/go-eth2-types/v2"
 )
 
-// networks is a map of deposit contract addresses to networks.
-var networks = map[string]string{
-	"00000000219ab540356cbb839cbe05303d7705fa": "Mainnet",
-	"07b39f4fde4a38bace212b546dac87c58dfe3fdc": "Medalla",
-	"8c5fecdc472e27bc447696f431e425d02dd46a8c": "Pyrmont",
-	"ff50ed3d0ec03ac01d4c79aad74928bff48a7b2b": "Prater",
-	"6f22ffbc56eff051aecf839396dd1ed9ad6bba9d": "Ropsten",
+// NetworkConfig contains the information about a network required to operate without a beacon node.
+type NetworkConfig struct {
+	Name            string
+	DepositContract []byte
+	// DepositContractBlock is the block in which the deposit contract was deployed, if known.
+	DepositContractBlock uint64
+	GenesisForkVersion   phase0.Version
+	// GenesisValidatorsRoot is the genesis validators root, if known.
+	GenesisValidatorsRoot *phase0.Root
+	// Forks is the fork schedule of the network, ordered by epoch and starting with the genesis fork.
+	Forks []*phase0.Fork
+	// SlotDuration is the duration of a slot, if it differs from mainnet.
+	SlotDuration time.Duration
+	// SlotsPerEpoch is the number of slots in an epoch, if it differs from mainnet.
+	SlotsPerEpoch uint64
+}
+
+// builtinNetworks are the networks known to ethdo without configuration.
+var builtinNetworks = []*NetworkConfig{
+	{
+		Name:                  "Mainnet",
+		DepositContract:       mustDecodeHex("00000000219ab540356cbb839cbe05303d7705fa"),
+		DepositContractBlock:  11052984,
+		GenesisForkVersion:    phase0.Version{0x00, 0x00, 0x00, 0x00},
+		GenesisValidatorsRoot: mustDecodeRoot("4b363db94e286120d76eb905340fdd4e54bfe9f06bf33ff6cf5ad27f511bfe95"),
+		Forks: []*phase0.Fork{
+			{
+				PreviousVersion: phase0.Version{0x00, 0x00, 0x00, 0x00},
+				CurrentVersion:  phase0.Version{0x00, 0x00, 0x00, 0x00},
+			},
+			{
+				PreviousVersion: phase0.Version{0x00, 0x00, 0x00, 0x00},
+				CurrentVersion:  phase0.Version{0x01, 0x00, 0x00, 0x00},
+				Epoch:           74240,
+			},
+			{
+				PreviousVersion: phase0.Version{0x01, 0x00, 0x00, 0x00},
+				CurrentVersion:  phase0.Version{0x02, 0x00, 0x00, 0x00},
+				Epoch:           144896,
+			},
+		},
+	},
+	{
+		Name:                  "Medalla",
+		DepositContract:       mustDecodeHex("07b39f4fde4a38bace212b546dac87c58dfe3fdc"),
+		GenesisForkVersion:    phase0.Version{0x00, 0x00, 0x00, 0x01},
+		GenesisValidatorsRoot: mustDecodeRoot("04700007fabc8282644aed6d1c7c9e21d38a03a0c4ba193f3afe428824b3a673"),
+		Forks: []*phase0.Fork{
+			{
+				PreviousVersion: phase0.Version{0x00, 0x00, 0x00, 0x01},
+				CurrentVersion:  phase0.Version{0x00, 0x00, 0x00, 0x01},
+			},
+		},
+	},
+	{
+		Name:               "Pyrmont",
+		DepositContract:    mustDecodeHex("8c5fecdc472e27bc447696f431e425d02dd46a8c"),
+		GenesisForkVersion: phase0.Version{0x00, 0x00, 0x20, 0x09},
+		Forks: []*phase0.Fork{
+			{
+				PreviousVersion: phase0.Version{0x00, 0x00, 0x20, 0x09},
+				CurrentVersion:  phase0.Version{0x00, 0x00, 0x20, 0x09},
+			},
+		},
+	},
+	{
+		Name:                  "Prater",
+		DepositContract:       mustDecodeHex("ff50ed3d0ec03ac01d4c79aad74928bff48a7b2b"),
+		GenesisForkVersion:    phase0.Version{0x00, 0x00, 0x10, 0x20},
+		GenesisValidatorsRoot: mustDecodeRoot("043db0d9a83813551ee2f33450d23797757d430911a9320530ad8a0eabc43efb"),
+		Forks: []*phase0.Fork{
+			{
+				PreviousVersion: phase0.Version{0x00, 0x00, 0x10, 0x20},
+				CurrentVersion:  phase0.Version{0x00, 0x00, 0x10, 0x20},
+			},
+			{
+				PreviousVersion: phase0.Version{0x00, 0x00, 0x10, 0x20},
+				CurrentVersion:  phase0.Version{0x01, 0x00, 0x10, 0x20},
+				Epoch:           36660,
+			},
+			{
+				PreviousVersion: phase0.Version{0x01, 0x00, 0x10, 0x20},
+				CurrentVersion:  phase0.Version{0x02, 0x00, 0x10, 0x20},
+				Epoch:           112260,
+			},
+		},
+	},
+	{
+		Name:                  "Ropsten",
+		DepositContract:       mustDecodeHex("6f22ffbc56eff051aecf839396dd1ed9ad6bba9d"),
+		GenesisForkVersion:    phase0.Version{0x80, 0x00, 0x00, 0x69},
+		GenesisValidatorsRoot: mustDecodeRoot("44f1e56283ca88b35c789f7f449e52339bc1fefe3a45913a43a6d16edcd33cf1"),
+		Forks: []*phase0.Fork{
+			{
+				PreviousVersion: phase0.Version{0x80, 0x00, 0x00, 0x69},
+				CurrentVersion:  phase0.Version{0x80, 0x00, 0x00, 0x69},
+			},
+			{
+				PreviousVersion: phase0.Version{0x80, 0x00, 0x00, 0x69},
+				CurrentVersion:  phase0.Version{0x80, 0x00, 0x00, 0x70},
+				Epoch:           500,
+			},
+			{
+				PreviousVersion: phase0.Version{0x80, 0x00, 0x00, 0x70},
+				CurrentVersion:  phase0.Version{0x80, 0x00, 0x00, 0x71},
+				Epoch:           750,
+			},
+		},
+	},
+	{
+		Name:                  "Sepolia",
+		DepositContract:       mustDecodeHex("7f02c3e3c98b133055b8b348b2ac625669ed295d"),
+		GenesisForkVersion:    phase0.Version{0x90, 0x00, 0x00, 0x69},
+		GenesisValidatorsRoot: mustDecodeRoot("d8ea171f3c94aea21ebc42a1ed61052acf3f9209c00e4efbaaddac09ed9b8078"),
+		Forks: []*phase0.Fork{
+			{
+				PreviousVersion: phase0.Version{0x90, 0x00, 0x00, 0x69},
+				CurrentVersion:  phase0.Version{0x90, 0x00, 0x00, 0x69},
+			},
+			{
+				PreviousVersion: phase0.Version{0x90, 0x00, 0x00, 0x69},
+				CurrentVersion:  phase0.Version{0x90, 0x00, 0x00, 0x70},
+				Epoch:           50,
+			},
+			{
+				PreviousVersion: phase0.Version{0x90, 0x00, 0x00, 0x70},
+				CurrentVersion:  phase0.Version{0x90, 0x00, 0x00, 0x71},
+				Epoch:           100,
+			},
+		},
+	},
+}
+
+// Networks returns the configuration of all networks, with custom networks
+// from the configuration file ahead of the built-in networks.
+func Networks() ([]*NetworkConfig, error) {
+	customNetworks, err := customNetworkConfigs()
+	if err != nil {
+		return nil, err
+	}
+
+	return append(customNetworks, builtinNetworks...), nil
+}
+
+// NetworkConfigByName returns the configuration of a named network.
+// The name is not case-sensitive.
+func NetworkConfigByName(name string) (*NetworkConfig, error) {
+	networks, err := Networks()
+	if err != nil {
+		return nil, err
+	}
+	for _, network := range networks {
+		if strings.EqualFold(network.Name, name) {
+			return network, nil
+		}
+	}
+
+	return nil, fmt.Errorf("unknown network %s", name)
+}
+
+// ForkAtEpoch returns the fork of the network in force at the given epoch.
+func (n *NetworkConfig) ForkAtEpoch(epoch phase0.Epoch) *phase0.Fork {
+	fork := &phase0.Fork{
+		PreviousVersion: n.GenesisForkVersion,
+		CurrentVersion:  n.GenesisForkVersion,
+	}
+	for _, scheduledFork := range n.Forks {
+		if scheduledFork.Epoch > epoch {
+			break
+		}
+		fork = scheduledFork
+	}
+
+	return fork
+}
+
+// ChainInfo returns the chain parameters of the network.
+func (n *NetworkConfig) ChainInfo() *ChainInfo {
+	chainInfo := DefaultChainInfo()
+	if n.SlotDuration != 0 {
+		chainInfo.SlotDuration = n.SlotDuration
+	}
+	if n.SlotsPerEpoch != 0 {
+		chainInfo.SlotsPerEpoch = n.SlotsPerEpoch
+	}
+
+	return chainInfo
+}
+
+// VerifyBeaconState confirms that a beacon state is from the network, by checking its
+// genesis validators root, if known, and that its fork matches the fork schedule.
+func (n *NetworkConfig) VerifyBeaconState(state *spec.VersionedBeaconState) error {
+	if n.GenesisValidatorsRoot != nil {
+		genesisValidatorsRoot, err := BeaconStateGenesisValidatorsRoot(state)
+		if err != nil {
+			return err
+		}
+		if !bytes.Equal(genesisValidatorsRoot, n.GenesisValidatorsRoot[:]) {
+			return fmt.Errorf("state has genesis validators root %#x but %s has %#x", genesisValidatorsRoot, n.Name, *n.GenesisValidatorsRoot)
+		}
+	}
+
+	slot, err := BeaconStateSlot(state)
+	if err != nil {
+		return err
+	}
+	fork, err := BeaconStateFork(state)
+	if err != nil {
+		return err
+	}
+	if fork == nil {
+		return errors.New("state has no fork")
+	}
+	epoch := phase0.Epoch(uint64(slot) / n.ChainInfo().SlotsPerEpoch)
+	expected := n.ForkAtEpoch(epoch)
+	if !bytes.Equal(fork.CurrentVersion[:], expected.CurrentVersion[:]) {
+		return fmt.Errorf("state at epoch %d has fork version %#x but %s has %#x", epoch, fork.CurrentVersion, n.Name, expected.CurrentVersion)
+	}
+
+	return nil
+}
+
+// Domain returns the signing domain of the given type for the network at the given epoch.
+func (n *NetworkConfig) Domain(domainType phase0.DomainType, epoch phase0.Epoch) (phase0.Domain, error) {
+	var domain phase0.Domain
+	if n.GenesisValidatorsRoot == nil {
+		return domain, fmt.Errorf("genesis validators root of network %s is not known", n.Name)
+	}
+	forkVersion := n.ForkAtEpoch(epoch).CurrentVersion
+	copy(domain[:], e2types.Domain(e2types.DomainType(domainType), forkVersion[:], n.GenesisValidatorsRoot[:]))
+
+	return domain, nil
 }
 
 // Network returns the name of the network., calculated from the deposit contract information.
 // If not known, returns "Unknown".
 func Network(ctx context.Context, eth2Client eth2client.Service) (string, error) {
+	address, err := DepositContractAddress(ctx, eth2Client)
+	if err != nil {
+		return "", err
+	}
+
+	networks, err := Networks()
+	if err != nil {
+		return "", err
+	}
+
+	return network(networks, address), nil
+}
+
+// DepositContractAddress returns the address of the deposit contract as supplied by the beacon node.
+func DepositContractAddress(ctx context.Context, eth2Client eth2client.Service) ([]byte, error) {
 	var address []byte
 
 	if eth2Client == nil {
-		return "", errors.New("no Ethereum 2 client supplied")
+		return nil, errors.New("no Ethereum 2 client supplied")
 	}
 
 	provider, isProvider := eth2Client.(eth2client.SpecProvider)
 	if !isProvider {
-		return "", errors.New("client does not provide deposit contract address")
+		return nil, errors.New("client does not provide deposit contract address")
 	}
 	config, err := provider.Spec(ctx)
 	if err != nil {
-		return "", errors.Wrap(err, "failed to obtain chain specification")
+		return nil, errors.Wrap(err, "failed to obtain chain specification")
 	}
 	if config == nil {
-		return "", errors.New("failed to return chain specification")
+		return nil, errors.New("failed to return chain specification")
 	}
 	depositContractAddress, exists := config["DEPOSIT_CONTRACT_ADDRESS"]
 	if exists {
 		address = depositContractAddress.([]byte)
 	}
 
-	return network(address), nil
+	return address, nil
+}
+
+// NetworkDepositContract returns the address of the deposit contract of a named network,
+// along with the block in which it was deployed if known.
+// The name is not case-sensitive.
+func NetworkDepositContract(name string) ([]byte, uint64, error) {
+	network, err := NetworkConfigByName(name)
+	if err != nil {
+		return nil, 0, err
+	}
+	if len(network.DepositContract) == 0 {
+		return nil, 0, fmt.Errorf("deposit contract of network %s is not known", network.Name)
+	}
+
+	return network.DepositContract, network.DepositContractBlock, nil
 }
 
 // network returns a network given an Ethereum 1 contract address.
-func network(address []byte) string {
-	if network, exists := networks[fmt.Sprintf("%x", address)]; exists {
-		return network
+func network(networks []*NetworkConfig, address []byte) string {
+	if len(address) == 0 {
+		return "Unknown"
+	}
+	for _, network := range networks {
+		if bytes.Equal(network.DepositContract, address) {
+			return network.Name
+		}
+	}
+	return "Unknown"
+}
+
+// NetworkGenesisForkVersion returns the genesis fork version of a named network.
+// The name is not case-sensitive.
+func NetworkGenesisForkVersion(name string) (phase0.Version, error) {
+	network, err := NetworkConfigByName(name)
+	if err != nil {
+		return phase0.Version{}, err
 	}
+
+	return network.GenesisForkVersion, nil
+}
+
+// NetworkForGenesisForkVersion returns the name of the network with the given genesis fork version.
+// If not known, returns "Unknown".
+func NetworkForGenesisForkVersion(forkVersion phase0.Version) string {
+	networks, err := Networks()
+	if err != nil {
+		// Fall back to the built-in networks if the custom networks are invalid.
+		networks = builtinNetworks
+	}
+	for _, network := range networks {
+		if network.GenesisForkVersion == forkVersion {
+			return network.Name
+		}
+	}
+
 	return "Unknown"
 }
+
+// sortForks orders forks by epoch and links each fork to its predecessor.
+func sortForks(genesisForkVersion phase0.Version, forks []*phase0.Fork) []*phase0.Fork {
+	sort.SliceStable(forks, func(i int, j int) bool {
+		return forks[i].Epoch < forks[j].Epoch
+	})
+	schedule := []*phase0.Fork{
+		{
+			PreviousVersion: genesisForkVersion,
+			CurrentVersion:  genesisForkVersion,
+		},
+	}
+	for _, fork := range forks {
+		if fork.Epoch == 0 {
+			// A fork at genesis replaces the genesis fork.
+			schedule[0].PreviousVersion = schedule[0].CurrentVersion
+			schedule[0].CurrentVersion = fork.CurrentVersion
+			continue
+		}
+		fork.PreviousVersion = schedule[len(schedule)-1].CurrentVersion
+		schedule = append(schedule, fork)
+	}
+
+	return schedule
+}
+
+func mustDecodeHex(input string) []byte {
+	data, err := hex.DecodeString(input)
+	if err != nil {
+		panic(err)
+	}
+	return data
+}
+
+func mustDecodeRoot(input string) *phase0.Root {
+	root := phase0.Root{}
+	copy(root[:], mustDecodeHex(input))
+	return &root
+}
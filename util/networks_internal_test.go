@@ -49,7 +49,7 @@ func TestNetworksInternal(t *testing.T) {
 
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
-			require.Equal(t, test.expected, network(test.address))
+			require.Equal(t, test.expected, network(builtinNetworks, test.address))
 		})
 	}
 }
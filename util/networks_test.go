@@ -15,11 +15,14 @@ package util_test
 
 import (
 	"context"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/aaron-alderman/ethdo/testutil"
 	"github.com/aaron-alderman/ethdo/util"
 	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/stretchr/testify/require"
 )
 
@@ -84,3 +87,77 @@ func TestNetworks(t *testing.T) {
 		})
 	}
 }
+
+func TestNetworkGenesisForkVersion(t *testing.T) {
+	tests := []struct {
+		name        string
+		network     string
+		forkVersion phase0.Version
+		err         string
+	}{
+		{
+			name:    "Empty",
+			network: "",
+			err:     "unknown network ",
+		},
+		{
+			name:    "Unknown",
+			network: "unknown",
+			err:     "unknown network unknown",
+		},
+		{
+			name:        "Mainnet",
+			network:     "Mainnet",
+			forkVersion: phase0.Version{0x00, 0x00, 0x00, 0x00},
+		},
+		{
+			name:        "PraterLowerCase",
+			network:     "prater",
+			forkVersion: phase0.Version{0x00, 0x00, 0x10, 0x20},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			forkVersion, err := util.NetworkGenesisForkVersion(test.network)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, test.forkVersion, forkVersion)
+				require.True(t, strings.EqualFold(test.network, util.NetworkForGenesisForkVersion(forkVersion)))
+			}
+		})
+	}
+}
+
+func TestNetworkChainInfo(t *testing.T) {
+	tests := []struct {
+		name      string
+		network   *util.NetworkConfig
+		chainInfo *util.ChainInfo
+	}{
+		{
+			name:      "Default",
+			network:   &util.NetworkConfig{},
+			chainInfo: util.DefaultChainInfo(),
+		},
+		{
+			name: "Custom",
+			network: &util.NetworkConfig{
+				SlotDuration:  6 * time.Second,
+				SlotsPerEpoch: 8,
+			},
+			chainInfo: &util.ChainInfo{
+				SlotDuration:  6 * time.Second,
+				SlotsPerEpoch: 8,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.chainInfo, test.network.ChainInfo())
+		})
+	}
+}
@@ -26,23 +26,48 @@ import (
 
 // SignRoot signs the hash tree root of a data structure
 func SignRoot(account e2wtypes.Account, root spec.Root, domain spec.Domain) (e2types.Signature, error) {
-	if _, isProtectingSigner := account.(e2wtypes.AccountProtectingSigner); isProtectingSigner {
-		// Signer builds the signing data.
-		return signGeneric(account, root, domain)
+	signature, err := SignRootUnaudited(account, root, domain)
+	if err != nil {
+		return nil, err
 	}
 
-	// Build the signing data manually.
-	container := &spec.SigningData{
-		ObjectRoot: root,
-		Domain:     domain,
+	if err := AuditSigning(AccountPublicKey(account), root[:], domain[:]); err != nil {
+		return nil, errors.Wrap(err, "failed to record signing in audit log")
+	}
+
+	return signature, nil
+}
+
+// SignRootUnaudited signs the hash tree root of a data structure without
+// recording the signing in the audit log.  Callers that sign in bulk, such
+// as when computing selection proofs, should record a single summary with
+// AuditSigningSummary instead.
+func SignRootUnaudited(account e2wtypes.Account, root spec.Root, domain spec.Domain) (e2types.Signature, error) {
+	var signature e2types.Signature
+	var err error
+	if _, isProtectingSigner := account.(e2wtypes.AccountProtectingSigner); isProtectingSigner {
+		// Signer builds the signing data.
+		signature, err = signGeneric(account, root, domain)
+	} else {
+		// Build the signing data manually.
+		container := &spec.SigningData{
+			ObjectRoot: root,
+			Domain:     domain,
+		}
+		// outputIf(debug, fmt.Sprintf("Signing container:\n root: %#x\n domain: %#x", container.ObjectRoot, container.Domain))
+		var signingRoot [32]byte
+		signingRoot, err = container.HashTreeRoot()
+		if err != nil {
+			return nil, err
+		}
+		// outputIf(debug, fmt.Sprintf("Signing root: %#x", signingRoot))
+		signature, err = sign(account, signingRoot[:])
 	}
-	// outputIf(debug, fmt.Sprintf("Signing container:\n root: %#x\n domain: %#x", container.ObjectRoot, container.Domain))
-	signingRoot, err := container.HashTreeRoot()
 	if err != nil {
 		return nil, err
 	}
-	// outputIf(debug, fmt.Sprintf("Signing root: %#x", signingRoot))
-	return sign(account, signingRoot[:])
+
+	return signature, nil
 }
 
 // VerifyRoot verifies the hash tree root of a data structure.
@@ -65,6 +90,25 @@ func VerifyRoot(account e2wtypes.Account, root spec.Root, domain spec.Domain, si
 	return signature.Verify(signingRoot[:], pubKey), nil
 }
 
+// SigningRoot computes the signing root of an object root for a given domain type, fork version
+// and genesis validators root.
+func SigningRoot(root spec.Root, domainType spec.DomainType, forkVersion spec.Version, genesisValidatorsRoot spec.Root) (spec.Root, error) {
+	domain, err := e2types.ComputeDomain(e2types.DomainType(domainType), forkVersion[:], genesisValidatorsRoot[:])
+	if err != nil {
+		return spec.Root{}, errors.Wrap(err, "failed to calculate domain")
+	}
+	container := &spec.SigningData{
+		ObjectRoot: root,
+	}
+	copy(container.Domain[:], domain)
+	signingRoot, err := container.HashTreeRoot()
+	if err != nil {
+		return spec.Root{}, errors.Wrap(err, "failed to generate signing root")
+	}
+
+	return signingRoot, nil
+}
+
 // signGeneric signs generic data.
 func signGeneric(account e2wtypes.Account, data spec.Root, domain spec.Domain) (e2types.Signature, error) {
 	alreadyUnlocked, err := unlock(account)
@@ -0,0 +1,453 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+)
+
+// SlashingProtectionInterchangeVersion is the version of the EIP-3076 interchange format supported.
+const SlashingProtectionInterchangeVersion = "5"
+
+// SlashingProtection is an EIP-3076 slashing protection interchange.
+type SlashingProtection struct {
+	GenesisValidatorsRoot phase0.Root
+	Validators            []*SlashingProtectionValidator
+}
+
+// SlashingProtectionValidator contains the signing history of a single validator.
+type SlashingProtectionValidator struct {
+	PubKey             phase0.BLSPubKey
+	SignedBlocks       []*SlashingProtectionBlock
+	SignedAttestations []*SlashingProtectionAttestation
+}
+
+// SlashingProtectionBlock is a signed block.  The signing root is optional.
+type SlashingProtectionBlock struct {
+	Slot        phase0.Slot
+	SigningRoot *phase0.Root
+}
+
+// SlashingProtectionAttestation is a signed attestation.  The signing root is optional.
+type SlashingProtectionAttestation struct {
+	SourceEpoch phase0.Epoch
+	TargetEpoch phase0.Epoch
+	SigningRoot *phase0.Root
+}
+
+type slashingProtectionJSON struct {
+	Metadata *slashingProtectionMetadataJSON    `json:"metadata"`
+	Data     []*slashingProtectionValidatorJSON `json:"data"`
+}
+
+type slashingProtectionMetadataJSON struct {
+	InterchangeFormatVersion string `json:"interchange_format_version"`
+	GenesisValidatorsRoot    string `json:"genesis_validators_root"`
+}
+
+type slashingProtectionValidatorJSON struct {
+	PubKey             string                               `json:"pubkey"`
+	SignedBlocks       []*slashingProtectionBlockJSON       `json:"signed_blocks"`
+	SignedAttestations []*slashingProtectionAttestationJSON `json:"signed_attestations"`
+}
+
+type slashingProtectionBlockJSON struct {
+	Slot        string `json:"slot"`
+	SigningRoot string `json:"signing_root,omitempty"`
+}
+
+type slashingProtectionAttestationJSON struct {
+	SourceEpoch string `json:"source_epoch"`
+	TargetEpoch string `json:"target_epoch"`
+	SigningRoot string `json:"signing_root,omitempty"`
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s *SlashingProtection) MarshalJSON() ([]byte, error) {
+	data := &slashingProtectionJSON{
+		Metadata: &slashingProtectionMetadataJSON{
+			InterchangeFormatVersion: SlashingProtectionInterchangeVersion,
+			GenesisValidatorsRoot:    fmt.Sprintf("%#x", s.GenesisValidatorsRoot),
+		},
+		Data: make([]*slashingProtectionValidatorJSON, len(s.Validators)),
+	}
+	for i, validator := range s.Validators {
+		validatorJSON := &slashingProtectionValidatorJSON{
+			PubKey:             fmt.Sprintf("%#x", validator.PubKey),
+			SignedBlocks:       make([]*slashingProtectionBlockJSON, len(validator.SignedBlocks)),
+			SignedAttestations: make([]*slashingProtectionAttestationJSON, len(validator.SignedAttestations)),
+		}
+		for j, block := range validator.SignedBlocks {
+			validatorJSON.SignedBlocks[j] = &slashingProtectionBlockJSON{
+				Slot:        fmt.Sprintf("%d", block.Slot),
+				SigningRoot: rootString(block.SigningRoot),
+			}
+		}
+		for j, attestation := range validator.SignedAttestations {
+			validatorJSON.SignedAttestations[j] = &slashingProtectionAttestationJSON{
+				SourceEpoch: fmt.Sprintf("%d", attestation.SourceEpoch),
+				TargetEpoch: fmt.Sprintf("%d", attestation.TargetEpoch),
+				SigningRoot: rootString(attestation.SigningRoot),
+			}
+		}
+		data.Data[i] = validatorJSON
+	}
+
+	return json.Marshal(data)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *SlashingProtection) UnmarshalJSON(input []byte) error {
+	var data slashingProtectionJSON
+	if err := json.Unmarshal(input, &data); err != nil {
+		return errors.Wrap(err, "invalid JSON")
+	}
+
+	if data.Metadata == nil {
+		return errors.New("metadata missing")
+	}
+	if data.Metadata.InterchangeFormatVersion != SlashingProtectionInterchangeVersion {
+		return fmt.Errorf("unsupported interchange format version %q", data.Metadata.InterchangeFormatVersion)
+	}
+	root, err := parseRoot(data.Metadata.GenesisValidatorsRoot)
+	if err != nil {
+		return errors.Wrap(err, "invalid genesis validators root")
+	}
+	s.GenesisValidatorsRoot = *root
+
+	s.Validators = make([]*SlashingProtectionValidator, len(data.Data))
+	for i, validatorJSON := range data.Data {
+		if validatorJSON == nil {
+			return fmt.Errorf("validator %d missing", i)
+		}
+		validator := &SlashingProtectionValidator{
+			SignedBlocks:       make([]*SlashingProtectionBlock, len(validatorJSON.SignedBlocks)),
+			SignedAttestations: make([]*SlashingProtectionAttestation, len(validatorJSON.SignedAttestations)),
+		}
+		pubKey, err := hex.DecodeString(strings.TrimPrefix(validatorJSON.PubKey, "0x"))
+		if err != nil {
+			return errors.Wrap(err, fmt.Sprintf("invalid public key %s", validatorJSON.PubKey))
+		}
+		if len(pubKey) != phase0.PublicKeyLength {
+			return fmt.Errorf("public key %s incorrect length", validatorJSON.PubKey)
+		}
+		copy(validator.PubKey[:], pubKey)
+
+		for j, blockJSON := range validatorJSON.SignedBlocks {
+			if blockJSON == nil {
+				return fmt.Errorf("validator %s block %d missing", validatorJSON.PubKey, j)
+			}
+			slot, err := strconv.ParseUint(blockJSON.Slot, 10, 64)
+			if err != nil {
+				return errors.Wrap(err, fmt.Sprintf("invalid block slot %s", blockJSON.Slot))
+			}
+			validator.SignedBlocks[j] = &SlashingProtectionBlock{
+				Slot: phase0.Slot(slot),
+			}
+			if blockJSON.SigningRoot != "" {
+				validator.SignedBlocks[j].SigningRoot, err = parseRoot(blockJSON.SigningRoot)
+				if err != nil {
+					return errors.Wrap(err, "invalid block signing root")
+				}
+			}
+		}
+
+		for j, attestationJSON := range validatorJSON.SignedAttestations {
+			if attestationJSON == nil {
+				return fmt.Errorf("validator %s attestation %d missing", validatorJSON.PubKey, j)
+			}
+			sourceEpoch, err := strconv.ParseUint(attestationJSON.SourceEpoch, 10, 64)
+			if err != nil {
+				return errors.Wrap(err, fmt.Sprintf("invalid attestation source epoch %s", attestationJSON.SourceEpoch))
+			}
+			targetEpoch, err := strconv.ParseUint(attestationJSON.TargetEpoch, 10, 64)
+			if err != nil {
+				return errors.Wrap(err, fmt.Sprintf("invalid attestation target epoch %s", attestationJSON.TargetEpoch))
+			}
+			validator.SignedAttestations[j] = &SlashingProtectionAttestation{
+				SourceEpoch: phase0.Epoch(sourceEpoch),
+				TargetEpoch: phase0.Epoch(targetEpoch),
+			}
+			if attestationJSON.SigningRoot != "" {
+				validator.SignedAttestations[j].SigningRoot, err = parseRoot(attestationJSON.SigningRoot)
+				if err != nil {
+					return errors.Wrap(err, "invalid attestation signing root")
+				}
+			}
+		}
+
+		s.Validators[i] = validator
+	}
+
+	return nil
+}
+
+// ReadSlashingProtection reads an interchange from a file.
+func ReadSlashingProtection(path string) (*SlashingProtection, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read interchange file")
+	}
+	res := &SlashingProtection{}
+	if err := json.Unmarshal(data, res); err != nil {
+		return nil, errors.Wrap(err, fmt.Sprintf("failed to parse interchange file %s", path))
+	}
+
+	return res, nil
+}
+
+// Validate checks that the interchange is internally consistent, in that no validator
+// has signed conflicting blocks or attestations.
+func (s *SlashingProtection) Validate() error {
+	for _, validator := range s.merged() {
+		for i, block := range validator.SignedBlocks {
+			for _, other := range validator.SignedBlocks[i+1:] {
+				if err := block.Conflicts(other); err != nil {
+					return errors.Wrap(err, fmt.Sprintf("validator %#x", validator.PubKey))
+				}
+			}
+		}
+		for i, attestation := range validator.SignedAttestations {
+			if attestation.SourceEpoch > attestation.TargetEpoch {
+				return fmt.Errorf("validator %#x: attestation source epoch %d after target epoch %d", validator.PubKey, attestation.SourceEpoch, attestation.TargetEpoch)
+			}
+			for _, other := range validator.SignedAttestations[i+1:] {
+				if err := attestation.Conflicts(other); err != nil {
+					return errors.Wrap(err, fmt.Sprintf("validator %#x", validator.PubKey))
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// Minify returns the minimal interchange that provides the same protection, containing
+// for each validator only its highest block slot and highest source and target epochs.
+func (s *SlashingProtection) Minify() *SlashingProtection {
+	res := &SlashingProtection{
+		GenesisValidatorsRoot: s.GenesisValidatorsRoot,
+	}
+	for _, validator := range s.merged() {
+		minified := &SlashingProtectionValidator{
+			PubKey:             validator.PubKey,
+			SignedBlocks:       make([]*SlashingProtectionBlock, 0, 1),
+			SignedAttestations: make([]*SlashingProtectionAttestation, 0, 1),
+		}
+		if len(validator.SignedBlocks) > 0 {
+			minified.SignedBlocks = append(minified.SignedBlocks, &SlashingProtectionBlock{
+				Slot: validator.SignedBlocks[len(validator.SignedBlocks)-1].Slot,
+			})
+		}
+		if len(validator.SignedAttestations) > 0 {
+			attestation := &SlashingProtectionAttestation{}
+			for _, signedAttestation := range validator.SignedAttestations {
+				if signedAttestation.SourceEpoch > attestation.SourceEpoch {
+					attestation.SourceEpoch = signedAttestation.SourceEpoch
+				}
+				if signedAttestation.TargetEpoch > attestation.TargetEpoch {
+					attestation.TargetEpoch = signedAttestation.TargetEpoch
+				}
+			}
+			minified.SignedAttestations = append(minified.SignedAttestations, attestation)
+		}
+		res.Validators = append(res.Validators, minified)
+	}
+
+	return res
+}
+
+// Prune returns the interchange without blocks before the given slot and attestations
+// targeting epochs before the given epoch.  The latest block of each validator, and
+// its attestations with the highest source and target epochs, are always retained so
+// that an importer continues to refuse to sign anything earlier.
+func (s *SlashingProtection) Prune(slot phase0.Slot, epoch phase0.Epoch) *SlashingProtection {
+	res := &SlashingProtection{
+		GenesisValidatorsRoot: s.GenesisValidatorsRoot,
+	}
+	for _, validator := range s.merged() {
+		pruned := &SlashingProtectionValidator{
+			PubKey:             validator.PubKey,
+			SignedBlocks:       make([]*SlashingProtectionBlock, 0),
+			SignedAttestations: make([]*SlashingProtectionAttestation, 0),
+		}
+		for i, block := range validator.SignedBlocks {
+			if block.Slot >= slot || i == len(validator.SignedBlocks)-1 {
+				pruned.SignedBlocks = append(pruned.SignedBlocks, block)
+			}
+		}
+		// Attestations are sorted by target epoch, so the last has the highest target.
+		highestSource := -1
+		for i, attestation := range validator.SignedAttestations {
+			if highestSource == -1 || attestation.SourceEpoch >= validator.SignedAttestations[highestSource].SourceEpoch {
+				highestSource = i
+			}
+		}
+		for i, attestation := range validator.SignedAttestations {
+			if attestation.TargetEpoch >= epoch || i == len(validator.SignedAttestations)-1 || i == highestSource {
+				pruned.SignedAttestations = append(pruned.SignedAttestations, attestation)
+			}
+		}
+		res.Validators = append(res.Validators, pruned)
+	}
+
+	return res
+}
+
+// MergeSlashingProtection merges multiple interchanges in to a single interchange.
+func MergeSlashingProtection(interchanges []*SlashingProtection) (*SlashingProtection, error) {
+	if len(interchanges) == 0 {
+		return nil, errors.New("no interchanges supplied")
+	}
+
+	res := &SlashingProtection{
+		GenesisValidatorsRoot: interchanges[0].GenesisValidatorsRoot,
+	}
+	for _, interchange := range interchanges {
+		if interchange.GenesisValidatorsRoot != res.GenesisValidatorsRoot {
+			return nil, errors.New("genesis validators roots do not match")
+		}
+		res.Validators = append(res.Validators, interchange.Validators...)
+	}
+	res.Validators = res.merged()
+
+	return res, nil
+}
+
+// merged returns the validators of the interchange with multiple entries for the same
+// validator combined, duplicate blocks and attestations removed, and blocks and
+// attestations sorted.
+func (s *SlashingProtection) merged() []*SlashingProtectionValidator {
+	res := make([]*SlashingProtectionValidator, 0, len(s.Validators))
+	validators := make(map[phase0.BLSPubKey]*SlashingProtectionValidator)
+	for _, validator := range s.Validators {
+		merged, exists := validators[validator.PubKey]
+		if !exists {
+			merged = &SlashingProtectionValidator{
+				PubKey:             validator.PubKey,
+				SignedBlocks:       make([]*SlashingProtectionBlock, 0, len(validator.SignedBlocks)),
+				SignedAttestations: make([]*SlashingProtectionAttestation, 0, len(validator.SignedAttestations)),
+			}
+			validators[validator.PubKey] = merged
+			res = append(res, merged)
+		}
+		for _, block := range validator.SignedBlocks {
+			duplicate := false
+			for _, existing := range merged.SignedBlocks {
+				if existing.Slot == block.Slot && rootsEqual(existing.SigningRoot, block.SigningRoot) {
+					duplicate = true
+					break
+				}
+			}
+			if !duplicate {
+				merged.SignedBlocks = append(merged.SignedBlocks, block)
+			}
+		}
+		for _, attestation := range validator.SignedAttestations {
+			duplicate := false
+			for _, existing := range merged.SignedAttestations {
+				if existing.SourceEpoch == attestation.SourceEpoch &&
+					existing.TargetEpoch == attestation.TargetEpoch &&
+					rootsEqual(existing.SigningRoot, attestation.SigningRoot) {
+					duplicate = true
+					break
+				}
+			}
+			if !duplicate {
+				merged.SignedAttestations = append(merged.SignedAttestations, attestation)
+			}
+		}
+	}
+
+	for _, validator := range res {
+		sort.SliceStable(validator.SignedBlocks, func(i int, j int) bool {
+			return validator.SignedBlocks[i].Slot < validator.SignedBlocks[j].Slot
+		})
+		sort.SliceStable(validator.SignedAttestations, func(i int, j int) bool {
+			if validator.SignedAttestations[i].TargetEpoch != validator.SignedAttestations[j].TargetEpoch {
+				return validator.SignedAttestations[i].TargetEpoch < validator.SignedAttestations[j].TargetEpoch
+			}
+			return validator.SignedAttestations[i].SourceEpoch < validator.SignedAttestations[j].SourceEpoch
+		})
+	}
+
+	return res
+}
+
+// Conflicts returns an error if the two blocks are a double proposal.
+func (b *SlashingProtectionBlock) Conflicts(other *SlashingProtectionBlock) error {
+	if b.Slot == other.Slot && !rootsEqual(b.SigningRoot, other.SigningRoot) {
+		return fmt.Errorf("double proposal at slot %d", b.Slot)
+	}
+
+	return nil
+}
+
+// Conflicts returns an error if the two attestations are a double vote or a surround vote.
+func (a *SlashingProtectionAttestation) Conflicts(other *SlashingProtectionAttestation) error {
+	if a.TargetEpoch == other.TargetEpoch {
+		// Without both signing roots, only an attestation with the same source
+		// and target can be assumed to be the same attestation.
+		if a.SigningRoot != nil && other.SigningRoot != nil {
+			if *a.SigningRoot != *other.SigningRoot {
+				return fmt.Errorf("double vote for target epoch %d", a.TargetEpoch)
+			}
+		} else if a.SourceEpoch != other.SourceEpoch {
+			return fmt.Errorf("double vote for target epoch %d", a.TargetEpoch)
+		}
+	}
+	if (a.SourceEpoch < other.SourceEpoch && other.TargetEpoch < a.TargetEpoch) ||
+		(other.SourceEpoch < a.SourceEpoch && a.TargetEpoch < other.TargetEpoch) {
+		return fmt.Errorf("surround vote between %d->%d and %d->%d", a.SourceEpoch, a.TargetEpoch, other.SourceEpoch, other.TargetEpoch)
+	}
+
+	return nil
+}
+
+// rootsEqual returns true if two optional roots are equal.
+func rootsEqual(a *phase0.Root, b *phase0.Root) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return *a == *b
+}
+
+func rootString(root *phase0.Root) string {
+	if root == nil {
+		return ""
+	}
+	return fmt.Sprintf("%#x", *root)
+}
+
+func parseRoot(input string) (*phase0.Root, error) {
+	data, err := hex.DecodeString(strings.TrimPrefix(input, "0x"))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) != phase0.RootLength {
+		return nil, errors.New("incorrect length")
+	}
+	var root phase0.Root
+	copy(root[:], data)
+	return &root, nil
+}
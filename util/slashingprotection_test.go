@@ -0,0 +1,257 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/aaron-alderman/ethdo/util"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	spGVR     = "0x4b363db94e286120d76eb905340fdd4e54bfe9f06bf33ff6cf5ad27f511bfe95"
+	spPubKey1 = "0xa99a76ed7796f7be22d5b7e85deeb7c5677e88e511e0b337618f8c4eb61349b4bf2d153f649f7b53359fe8b94a38e44c"
+	spPubKey2 = "0xb89bebc699769726a318c8e9971bd3171297c61aea4a6578a7a4f94b547dcba5bac16a89108b6b6a1fe3695d1a874a0b"
+	spRoot1   = "0x0101010101010101010101010101010101010101010101010101010101010101"
+	spRoot2   = "0x0202020202020202020202020202020202020202020202020202020202020202"
+)
+
+func slashingProtection(t *testing.T, input string) *util.SlashingProtection {
+	t.Helper()
+	res := &util.SlashingProtection{}
+	require.NoError(t, json.Unmarshal([]byte(input), res))
+	return res
+}
+
+func TestSlashingProtectionJSON(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		err  string
+	}{
+		{
+			name: "Invalid",
+			in:   `invalid`,
+			err:  "invalid character 'i' looking for beginning of value",
+		},
+		{
+			name: "MetadataMissing",
+			in:   `{"data":[]}`,
+			err:  "metadata missing",
+		},
+		{
+			name: "VersionIncorrect",
+			in:   `{"metadata":{"interchange_format_version":"4","genesis_validators_root":"` + spGVR + `"},"data":[]}`,
+			err:  `unsupported interchange format version "4"`,
+		},
+		{
+			name: "GenesisValidatorsRootInvalid",
+			in:   `{"metadata":{"interchange_format_version":"5","genesis_validators_root":"0x01"},"data":[]}`,
+			err:  "invalid genesis validators root: incorrect length",
+		},
+		{
+			name: "PubKeyInvalid",
+			in:   `{"metadata":{"interchange_format_version":"5","genesis_validators_root":"` + spGVR + `"},"data":[{"pubkey":"0x01","signed_blocks":[],"signed_attestations":[]}]}`,
+			err:  "public key 0x01 incorrect length",
+		},
+		{
+			name: "SlotInvalid",
+			in:   `{"metadata":{"interchange_format_version":"5","genesis_validators_root":"` + spGVR + `"},"data":[{"pubkey":"` + spPubKey1 + `","signed_blocks":[{"slot":"-1"}],"signed_attestations":[]}]}`,
+			err:  `invalid block slot -1: strconv.ParseUint: parsing "-1": invalid syntax`,
+		},
+		{
+			name: "TargetEpochInvalid",
+			in:   `{"metadata":{"interchange_format_version":"5","genesis_validators_root":"` + spGVR + `"},"data":[{"pubkey":"` + spPubKey1 + `","signed_blocks":[],"signed_attestations":[{"source_epoch":"1","target_epoch":"x"}]}]}`,
+			err:  `invalid attestation target epoch x: strconv.ParseUint: parsing "x": invalid syntax`,
+		},
+		{
+			name: "Good",
+			in:   `{"metadata":{"interchange_format_version":"5","genesis_validators_root":"` + spGVR + `"},"data":[{"pubkey":"` + spPubKey1 + `","signed_blocks":[{"slot":"10","signing_root":"` + spRoot1 + `"},{"slot":"12"}],"signed_attestations":[{"source_epoch":"1","target_epoch":"2","signing_root":"` + spRoot2 + `"}]}]}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			res := &util.SlashingProtection{}
+			err := json.Unmarshal([]byte(test.in), res)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+				return
+			}
+			require.NoError(t, err)
+			data, err := json.Marshal(res)
+			require.NoError(t, err)
+			require.Equal(t, test.in, string(data))
+		})
+	}
+}
+
+func TestSlashingProtectionValidate(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		err  string
+	}{
+		{
+			name: "Empty",
+			in:   `{"metadata":{"interchange_format_version":"5","genesis_validators_root":"` + spGVR + `"},"data":[]}`,
+		},
+		{
+			name: "DuplicateEntries",
+			in:   `{"metadata":{"interchange_format_version":"5","genesis_validators_root":"` + spGVR + `"},"data":[{"pubkey":"` + spPubKey1 + `","signed_blocks":[{"slot":"10"}],"signed_attestations":[{"source_epoch":"1","target_epoch":"2"}]},{"pubkey":"` + spPubKey1 + `","signed_blocks":[{"slot":"10"}],"signed_attestations":[{"source_epoch":"1","target_epoch":"2"}]}]}`,
+		},
+		{
+			name: "DoubleProposal",
+			in:   `{"metadata":{"interchange_format_version":"5","genesis_validators_root":"` + spGVR + `"},"data":[{"pubkey":"` + spPubKey1 + `","signed_blocks":[{"slot":"10","signing_root":"` + spRoot1 + `"}],"signed_attestations":[]},{"pubkey":"` + spPubKey1 + `","signed_blocks":[{"slot":"10","signing_root":"` + spRoot2 + `"}],"signed_attestations":[]}]}`,
+			err:  "validator " + spPubKey1 + ": double proposal at slot 10",
+		},
+		{
+			name: "DoubleVote",
+			in:   `{"metadata":{"interchange_format_version":"5","genesis_validators_root":"` + spGVR + `"},"data":[{"pubkey":"` + spPubKey1 + `","signed_blocks":[],"signed_attestations":[{"source_epoch":"1","target_epoch":"2","signing_root":"` + spRoot1 + `"},{"source_epoch":"0","target_epoch":"2","signing_root":"` + spRoot2 + `"}]}]}`,
+			err:  "validator " + spPubKey1 + ": double vote for target epoch 2",
+		},
+		{
+			name: "DoubleVoteNoRoots",
+			in:   `{"metadata":{"interchange_format_version":"5","genesis_validators_root":"` + spGVR + `"},"data":[{"pubkey":"` + spPubKey1 + `","signed_blocks":[],"signed_attestations":[{"source_epoch":"1","target_epoch":"2"},{"source_epoch":"0","target_epoch":"2"}]}]}`,
+			err:  "validator " + spPubKey1 + ": double vote for target epoch 2",
+		},
+		{
+			name: "DoubleVoteOneRoot",
+			in:   `{"metadata":{"interchange_format_version":"5","genesis_validators_root":"` + spGVR + `"},"data":[{"pubkey":"` + spPubKey1 + `","signed_blocks":[],"signed_attestations":[{"source_epoch":"1","target_epoch":"2","signing_root":"` + spRoot1 + `"},{"source_epoch":"0","target_epoch":"2"}]}]}`,
+			err:  "validator " + spPubKey1 + ": double vote for target epoch 2",
+		},
+		{
+			name: "SameVoteOneRoot",
+			in:   `{"metadata":{"interchange_format_version":"5","genesis_validators_root":"` + spGVR + `"},"data":[{"pubkey":"` + spPubKey1 + `","signed_blocks":[],"signed_attestations":[{"source_epoch":"1","target_epoch":"2","signing_root":"` + spRoot1 + `"},{"source_epoch":"1","target_epoch":"2"}]}]}`,
+		},
+		{
+			name: "SurroundVote",
+			in:   `{"metadata":{"interchange_format_version":"5","genesis_validators_root":"` + spGVR + `"},"data":[{"pubkey":"` + spPubKey1 + `","signed_blocks":[],"signed_attestations":[{"source_epoch":"2","target_epoch":"3"},{"source_epoch":"1","target_epoch":"4"}]}]}`,
+			err:  "validator " + spPubKey1 + ": surround vote between 2->3 and 1->4",
+		},
+		{
+			name: "SourceAfterTarget",
+			in:   `{"metadata":{"interchange_format_version":"5","genesis_validators_root":"` + spGVR + `"},"data":[{"pubkey":"` + spPubKey1 + `","signed_blocks":[],"signed_attestations":[{"source_epoch":"3","target_epoch":"2"}]}]}`,
+			err:  "validator " + spPubKey1 + ": attestation source epoch 3 after target epoch 2",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := slashingProtection(t, test.in).Validate()
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestMergeSlashingProtection(t *testing.T) {
+	interchange1 := slashingProtection(t, `{"metadata":{"interchange_format_version":"5","genesis_validators_root":"`+spGVR+`"},"data":[{"pubkey":"`+spPubKey1+`","signed_blocks":[{"slot":"12"}],"signed_attestations":[{"source_epoch":"1","target_epoch":"2"}]}]}`)
+	interchange2 := slashingProtection(t, `{"metadata":{"interchange_format_version":"5","genesis_validators_root":"`+spGVR+`"},"data":[{"pubkey":"`+spPubKey2+`","signed_blocks":[],"signed_attestations":[{"source_epoch":"3","target_epoch":"4"}]},{"pubkey":"`+spPubKey1+`","signed_blocks":[{"slot":"10"},{"slot":"12"}],"signed_attestations":[]}]}`)
+	otherChain := slashingProtection(t, `{"metadata":{"interchange_format_version":"5","genesis_validators_root":"`+spRoot1+`"},"data":[]}`)
+
+	tests := []struct {
+		name         string
+		interchanges []*util.SlashingProtection
+		res          string
+		err          string
+	}{
+		{
+			name: "None",
+			err:  "no interchanges supplied",
+		},
+		{
+			name:         "MismatchedChains",
+			interchanges: []*util.SlashingProtection{interchange1, otherChain},
+			err:          "genesis validators roots do not match",
+		},
+		{
+			name:         "Good",
+			interchanges: []*util.SlashingProtection{interchange1, interchange2},
+			res:          `{"metadata":{"interchange_format_version":"5","genesis_validators_root":"` + spGVR + `"},"data":[{"pubkey":"` + spPubKey1 + `","signed_blocks":[{"slot":"10"},{"slot":"12"}],"signed_attestations":[{"source_epoch":"1","target_epoch":"2"}]},{"pubkey":"` + spPubKey2 + `","signed_blocks":[],"signed_attestations":[{"source_epoch":"3","target_epoch":"4"}]}]}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			res, err := util.MergeSlashingProtection(test.interchanges)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+				return
+			}
+			require.NoError(t, err)
+			data, err := json.Marshal(res)
+			require.NoError(t, err)
+			require.Equal(t, test.res, string(data))
+		})
+	}
+}
+
+func TestSlashingProtectionMinify(t *testing.T) {
+	interchange := slashingProtection(t, `{"metadata":{"interchange_format_version":"5","genesis_validators_root":"`+spGVR+`"},"data":[{"pubkey":"`+spPubKey1+`","signed_blocks":[{"slot":"12","signing_root":"`+spRoot1+`"},{"slot":"10"}],"signed_attestations":[{"source_epoch":"5","target_epoch":"6"},{"source_epoch":"1","target_epoch":"7"}]},{"pubkey":"`+spPubKey2+`","signed_blocks":[],"signed_attestations":[]}]}`)
+	data, err := json.Marshal(interchange.Minify())
+	require.NoError(t, err)
+	require.Equal(t, `{"metadata":{"interchange_format_version":"5","genesis_validators_root":"`+spGVR+`"},"data":[{"pubkey":"`+spPubKey1+`","signed_blocks":[{"slot":"12"}],"signed_attestations":[{"source_epoch":"5","target_epoch":"7"}]},{"pubkey":"`+spPubKey2+`","signed_blocks":[],"signed_attestations":[]}]}`, string(data))
+}
+
+func TestSlashingProtectionPruneHighestSource(t *testing.T) {
+	// In a history containing a surround vote the attestation with the highest source epoch
+	// is not the one with the highest target epoch.
+	interchange := slashingProtection(t, `{"metadata":{"interchange_format_version":"5","genesis_validators_root":"`+spGVR+`"},"data":[{"pubkey":"`+spPubKey1+`","signed_blocks":[],"signed_attestations":[{"source_epoch":"5","target_epoch":"6"},{"source_epoch":"1","target_epoch":"7"}]}]}`)
+	data, err := json.Marshal(interchange.Prune(0, 10))
+	require.NoError(t, err)
+	require.Equal(t, `{"metadata":{"interchange_format_version":"5","genesis_validators_root":"`+spGVR+`"},"data":[{"pubkey":"`+spPubKey1+`","signed_blocks":[],"signed_attestations":[{"source_epoch":"5","target_epoch":"6"},{"source_epoch":"1","target_epoch":"7"}]}]}`, string(data))
+}
+
+func TestSlashingProtectionPrune(t *testing.T) {
+	interchange := slashingProtection(t, `{"metadata":{"interchange_format_version":"5","genesis_validators_root":"`+spGVR+`"},"data":[{"pubkey":"`+spPubKey1+`","signed_blocks":[{"slot":"10"},{"slot":"40"}],"signed_attestations":[{"source_epoch":"0","target_epoch":"1"},{"source_epoch":"1","target_epoch":"2"}]},{"pubkey":"`+spPubKey2+`","signed_blocks":[{"slot":"1"},{"slot":"2"}],"signed_attestations":[{"source_epoch":"1","target_epoch":"2"},{"source_epoch":"2","target_epoch":"3"}]}]}`)
+
+	tests := []struct {
+		name  string
+		slot  phase0.Slot
+		epoch phase0.Epoch
+		res   string
+	}{
+		{
+			name: "None",
+			res:  `{"metadata":{"interchange_format_version":"5","genesis_validators_root":"` + spGVR + `"},"data":[{"pubkey":"` + spPubKey1 + `","signed_blocks":[{"slot":"10"},{"slot":"40"}],"signed_attestations":[{"source_epoch":"0","target_epoch":"1"},{"source_epoch":"1","target_epoch":"2"}]},{"pubkey":"` + spPubKey2 + `","signed_blocks":[{"slot":"1"},{"slot":"2"}],"signed_attestations":[{"source_epoch":"1","target_epoch":"2"},{"source_epoch":"2","target_epoch":"3"}]}]}`,
+		},
+		{
+			name:  "Partial",
+			slot:  32,
+			epoch: 2,
+			res:   `{"metadata":{"interchange_format_version":"5","genesis_validators_root":"` + spGVR + `"},"data":[{"pubkey":"` + spPubKey1 + `","signed_blocks":[{"slot":"40"}],"signed_attestations":[{"source_epoch":"1","target_epoch":"2"}]},{"pubkey":"` + spPubKey2 + `","signed_blocks":[{"slot":"2"}],"signed_attestations":[{"source_epoch":"1","target_epoch":"2"},{"source_epoch":"2","target_epoch":"3"}]}]}`,
+		},
+		{
+			name:  "All",
+			slot:  320,
+			epoch: 10,
+			res:   `{"metadata":{"interchange_format_version":"5","genesis_validators_root":"` + spGVR + `"},"data":[{"pubkey":"` + spPubKey1 + `","signed_blocks":[{"slot":"40"}],"signed_attestations":[{"source_epoch":"1","target_epoch":"2"}]},{"pubkey":"` + spPubKey2 + `","signed_blocks":[{"slot":"2"}],"signed_attestations":[{"source_epoch":"2","target_epoch":"3"}]}]}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			data, err := json.Marshal(interchange.Prune(test.slot, test.epoch))
+			require.NoError(t, err)
+			require.Equal(t, test.res, string(data))
+		})
+	}
+}
@@ -0,0 +1,599 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/altair"
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/go-bitfield"
+)
+
+// beaconStateOffsetPos is the position in an SSZ beacon state of the first
+// offset, which is also the size of the fixed part of the state.
+const beaconStateOffsetPos = 524464
+
+// beaconStateFixedSizes are the sizes of the fixed part of the beacon state
+// for each fork, using the mainnet preset.
+var beaconStateFixedSizes = map[uint32]spec.DataVersion{
+	2687377: spec.DataVersionPhase0,
+	2736629: spec.DataVersionAltair,
+	2736633: spec.DataVersionBellatrix,
+}
+
+// ParseBeaconState parses a beacon state.  JSON states can either be wrapped
+// with their version, as returned by the beacon node API, or bare, in which case
+// the fork is identified by the fields present.  SSZ states can be raw
+// or hex-encoded, with the fork detected from the size of the fixed part of the
+// state; only states using the mainnet preset can be decoded.
+func ParseBeaconState(input []byte) (*spec.VersionedBeaconState, error) {
+	data, isJSON, err := decodeInput(input)
+	if err != nil {
+		return nil, err
+	}
+	if !isJSON {
+		return parseBeaconStateSSZ(data)
+	}
+
+	versioned := &versionedJSON{}
+	if err := json.Unmarshal(data, versioned); err == nil && versioned.Version != nil && len(versioned.Data) > 0 {
+		return parseBeaconStateVersion(*versioned.Version, versioned.Data)
+	}
+
+	// Bare states are identified by the fields added in each fork.
+	fields := make(map[string]json.RawMessage)
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, errors.Wrap(err, "invalid JSON")
+	}
+	version := spec.DataVersionPhase0
+	switch {
+	case fields["latest_execution_payload_header"] != nil:
+		version = spec.DataVersionBellatrix
+	case fields["inactivity_scores"] != nil:
+		version = spec.DataVersionAltair
+	}
+
+	return parseBeaconStateVersion(version, data)
+}
+
+func parseBeaconStateVersion(version spec.DataVersion, input []byte) (*spec.VersionedBeaconState, error) {
+	state := &spec.VersionedBeaconState{
+		Version: version,
+	}
+	switch version {
+	case spec.DataVersionPhase0:
+		state.Phase0 = &phase0.BeaconState{}
+		if err := json.Unmarshal(input, state.Phase0); err != nil {
+			return nil, errors.Wrap(err, "invalid phase0 state")
+		}
+	case spec.DataVersionAltair:
+		state.Altair = &altair.BeaconState{}
+		if err := json.Unmarshal(input, state.Altair); err != nil {
+			return nil, errors.Wrap(err, "invalid altair state")
+		}
+	case spec.DataVersionBellatrix:
+		state.Bellatrix = &bellatrix.BeaconState{}
+		if err := json.Unmarshal(input, state.Bellatrix); err != nil {
+			return nil, errors.Wrap(err, "invalid bellatrix state")
+		}
+	default:
+		return nil, fmt.Errorf("unhandled state version %v", version)
+	}
+
+	return state, nil
+}
+
+// BeaconStateSlot obtains the slot of the state.
+func BeaconStateSlot(state *spec.VersionedBeaconState) (phase0.Slot, error) {
+	switch state.Version {
+	case spec.DataVersionPhase0:
+		return phase0.Slot(state.Phase0.Slot), nil
+	case spec.DataVersionAltair:
+		return phase0.Slot(state.Altair.Slot), nil
+	case spec.DataVersionBellatrix:
+		return phase0.Slot(state.Bellatrix.Slot), nil
+	default:
+		return 0, fmt.Errorf("unhandled state version %v", state.Version)
+	}
+}
+
+// BeaconStateGenesisValidatorsRoot obtains the genesis validators root of the state.
+func BeaconStateGenesisValidatorsRoot(state *spec.VersionedBeaconState) ([]byte, error) {
+	switch state.Version {
+	case spec.DataVersionPhase0:
+		return state.Phase0.GenesisValidatorsRoot, nil
+	case spec.DataVersionAltair:
+		return state.Altair.GenesisValidatorsRoot, nil
+	case spec.DataVersionBellatrix:
+		return state.Bellatrix.GenesisValidatorsRoot, nil
+	default:
+		return nil, fmt.Errorf("unhandled state version %v", state.Version)
+	}
+}
+
+// BeaconStateFork obtains the fork of the state.
+func BeaconStateFork(state *spec.VersionedBeaconState) (*phase0.Fork, error) {
+	switch state.Version {
+	case spec.DataVersionPhase0:
+		return state.Phase0.Fork, nil
+	case spec.DataVersionAltair:
+		return state.Altair.Fork, nil
+	case spec.DataVersionBellatrix:
+		return state.Bellatrix.Fork, nil
+	default:
+		return nil, fmt.Errorf("unhandled state version %v", state.Version)
+	}
+}
+
+// BeaconStateValidators obtains the validators of the state.
+func BeaconStateValidators(state *spec.VersionedBeaconState) ([]*phase0.Validator, error) {
+	switch state.Version {
+	case spec.DataVersionPhase0:
+		return state.Phase0.Validators, nil
+	case spec.DataVersionAltair:
+		return state.Altair.Validators, nil
+	case spec.DataVersionBellatrix:
+		return state.Bellatrix.Validators, nil
+	default:
+		return nil, fmt.Errorf("unhandled state version %v", state.Version)
+	}
+}
+
+// BeaconStateBalances obtains the balances of the validators of the state.
+func BeaconStateBalances(state *spec.VersionedBeaconState) ([]uint64, error) {
+	switch state.Version {
+	case spec.DataVersionPhase0:
+		return state.Phase0.Balances, nil
+	case spec.DataVersionAltair:
+		return state.Altair.Balances, nil
+	case spec.DataVersionBellatrix:
+		return state.Bellatrix.Balances, nil
+	default:
+		return nil, fmt.Errorf("unhandled state version %v", state.Version)
+	}
+}
+
+// BeaconStateJustificationBits obtains the justification bits of the state.
+func BeaconStateJustificationBits(state *spec.VersionedBeaconState) (bitfield.Bitvector4, error) {
+	switch state.Version {
+	case spec.DataVersionPhase0:
+		return state.Phase0.JustificationBits, nil
+	case spec.DataVersionAltair:
+		return state.Altair.JustificationBits, nil
+	case spec.DataVersionBellatrix:
+		return state.Bellatrix.JustificationBits, nil
+	default:
+		return nil, fmt.Errorf("unhandled state version %v", state.Version)
+	}
+}
+
+// BeaconStateSlashings obtains the slashed balances of the state.
+func BeaconStateSlashings(state *spec.VersionedBeaconState) ([]uint64, error) {
+	switch state.Version {
+	case spec.DataVersionPhase0:
+		return state.Phase0.Slashings, nil
+	case spec.DataVersionAltair:
+		return state.Altair.Slashings, nil
+	case spec.DataVersionBellatrix:
+		return state.Bellatrix.Slashings, nil
+	default:
+		return nil, fmt.Errorf("unhandled state version %v", state.Version)
+	}
+}
+
+// BeaconStateRANDAOMixes obtains the RANDAO mixes of the state.
+func BeaconStateRANDAOMixes(state *spec.VersionedBeaconState) ([][]byte, error) {
+	switch state.Version {
+	case spec.DataVersionPhase0:
+		return state.Phase0.RANDAOMixes, nil
+	case spec.DataVersionAltair:
+		return state.Altair.RANDAOMixes, nil
+	case spec.DataVersionBellatrix:
+		return state.Bellatrix.RANDAOMixes, nil
+	default:
+		return nil, fmt.Errorf("unhandled state version %v", state.Version)
+	}
+}
+
+// BeaconStateParticipation obtains the participation flags of the validators for
+// the previous and current epochs of the state.  Phase0 states record pending
+// attestations rather than participation flags, so return nil.
+func BeaconStateParticipation(state *spec.VersionedBeaconState) ([]altair.ParticipationFlags, []altair.ParticipationFlags, error) {
+	switch state.Version {
+	case spec.DataVersionPhase0:
+		return nil, nil, nil
+	case spec.DataVersionAltair:
+		return state.Altair.PreviousEpochParticipation, state.Altair.CurrentEpochParticipation, nil
+	case spec.DataVersionBellatrix:
+		return state.Bellatrix.PreviousEpochParticipation, state.Bellatrix.CurrentEpochParticipation, nil
+	default:
+		return nil, nil, fmt.Errorf("unhandled state version %v", state.Version)
+	}
+}
+
+// ValidatorStateAt calculates the state of a validator at the given epoch.  Unlike
+// apiv1.ValidatorToState this takes the balance of the validator in to account, so
+// can report withdrawn validators.
+func ValidatorStateAt(validator *phase0.Validator, balance uint64, epoch phase0.Epoch) apiv1.ValidatorState {
+	state := apiv1.ValidatorToState(validator, epoch, phase0.Epoch(farFutureEpoch))
+	if state == apiv1.ValidatorStateWithdrawalPossible && balance == 0 {
+		return apiv1.ValidatorStateWithdrawalDone
+	}
+	return state
+}
+
+// ObtainBeaconState obtains a beacon state, either from the named file if it is
+// supplied or otherwise from the beacon node.
+func ObtainBeaconState(ctx context.Context, eth2Client eth2client.Service, stateID string, file string) (*spec.VersionedBeaconState, error) {
+	if file != "" {
+		data, err := ioutil.ReadFile(file)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to read state file")
+		}
+		state, err := ParseBeaconState(data)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to parse beacon state")
+		}
+		return state, nil
+	}
+
+	beaconStateProvider, isProvider := eth2Client.(eth2client.BeaconStateProvider)
+	if !isProvider {
+		return nil, errors.New("connection does not provide beacon state information")
+	}
+	state, err := beaconStateProvider.BeaconState(ctx, stateID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to obtain beacon state")
+	}
+	if state == nil {
+		return nil, errors.New("empty beacon state")
+	}
+
+	return state, nil
+}
+
+func parseBeaconStateSSZ(input []byte) (*spec.VersionedBeaconState, error) {
+	if len(input) < beaconStateOffsetPos+4 {
+		return nil, errors.New("SSZ state too short")
+	}
+	fixedSize := binary.LittleEndian.Uint32(input[beaconStateOffsetPos:])
+	version, exists := beaconStateFixedSizes[fixedSize]
+	if !exists {
+		return nil, errors.New("SSZ state does not match a known fork")
+	}
+	if uint32(len(input)) < fixedSize {
+		return nil, errors.New("SSZ state too short")
+	}
+
+	return unmarshalBeaconStateSSZ(input, version)
+}
+
+// sszReader reads consecutive fields from the fixed part of an SSZ container.
+type sszReader struct {
+	buf []byte
+	pos int
+}
+
+func (r *sszReader) next(size int) []byte {
+	data := r.buf[r.pos : r.pos+size]
+	r.pos += size
+	return data
+}
+
+func (r *sszReader) uint64() uint64 {
+	return binary.LittleEndian.Uint64(r.next(8))
+}
+
+func (r *sszReader) offset() uint32 {
+	return binary.LittleEndian.Uint32(r.next(4))
+}
+
+func (r *sszReader) roots(count int) [][]byte {
+	roots := make([][]byte, count)
+	for i := range roots {
+		roots[i] = r.next(32)
+	}
+	return roots
+}
+
+func (r *sszReader) uint64s(count int) []uint64 {
+	values := make([]uint64, count)
+	for i := range values {
+		values[i] = r.uint64()
+	}
+	return values
+}
+
+// unmarshalBeaconStateSSZ decodes an SSZ state.  The go-eth2-client library
+// does not provide SSZ decoding for altair and bellatrix states, and its phase0
+// state omits the deposit index so does not match the spec encoding.  Fields
+// common to all forks are decoded in to a bellatrix state and then copied to
+// the state for the required fork.
+func unmarshalBeaconStateSSZ(buf []byte, version spec.DataVersion) (*spec.VersionedBeaconState, error) {
+	state, previousEpochAttestations, currentEpochAttestations, err := unmarshalBeaconStateFieldsSSZ(buf, version)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid %s state", strings.ToLower(version.String()))
+	}
+
+	switch version {
+	case spec.DataVersionPhase0:
+		return &spec.VersionedBeaconState{
+			Version: version,
+			Phase0: &phase0.BeaconState{
+				GenesisTime:                 state.GenesisTime,
+				GenesisValidatorsRoot:       state.GenesisValidatorsRoot,
+				Slot:                        state.Slot,
+				Fork:                        state.Fork,
+				LatestBlockHeader:           state.LatestBlockHeader,
+				BlockRoots:                  state.BlockRoots,
+				StateRoots:                  state.StateRoots,
+				HistoricalRoots:             state.HistoricalRoots,
+				ETH1Data:                    state.ETH1Data,
+				ETH1DataVotes:               state.ETH1DataVotes,
+				Validators:                  state.Validators,
+				Balances:                    state.Balances,
+				RANDAOMixes:                 state.RANDAOMixes,
+				Slashings:                   state.Slashings,
+				PreviousEpochAttestations:   previousEpochAttestations,
+				CurrentEpochAttestations:    currentEpochAttestations,
+				JustificationBits:           state.JustificationBits,
+				PreviousJustifiedCheckpoint: state.PreviousJustifiedCheckpoint,
+				CurrentJustifiedCheckpoint:  state.CurrentJustifiedCheckpoint,
+				FinalizedCheckpoint:         state.FinalizedCheckpoint,
+			},
+		}, nil
+	case spec.DataVersionAltair:
+		return &spec.VersionedBeaconState{
+			Version: version,
+			Altair: &altair.BeaconState{
+				GenesisTime:                 state.GenesisTime,
+				GenesisValidatorsRoot:       state.GenesisValidatorsRoot,
+				Slot:                        state.Slot,
+				Fork:                        state.Fork,
+				LatestBlockHeader:           state.LatestBlockHeader,
+				BlockRoots:                  state.BlockRoots,
+				StateRoots:                  state.StateRoots,
+				HistoricalRoots:             state.HistoricalRoots,
+				ETH1Data:                    state.ETH1Data,
+				ETH1DataVotes:               state.ETH1DataVotes,
+				ETH1DepositIndex:            state.ETH1DepositIndex,
+				Validators:                  state.Validators,
+				Balances:                    state.Balances,
+				RANDAOMixes:                 state.RANDAOMixes,
+				Slashings:                   state.Slashings,
+				PreviousEpochParticipation:  state.PreviousEpochParticipation,
+				CurrentEpochParticipation:   state.CurrentEpochParticipation,
+				JustificationBits:           state.JustificationBits,
+				PreviousJustifiedCheckpoint: state.PreviousJustifiedCheckpoint,
+				CurrentJustifiedCheckpoint:  state.CurrentJustifiedCheckpoint,
+				FinalizedCheckpoint:         state.FinalizedCheckpoint,
+				InactivityScores:            state.InactivityScores,
+				CurrentSyncCommittee:        state.CurrentSyncCommittee,
+				NextSyncCommittee:           state.NextSyncCommittee,
+			},
+		}, nil
+	default:
+		return &spec.VersionedBeaconState{
+			Version:   version,
+			Bellatrix: state,
+		}, nil
+	}
+}
+
+// unmarshalBeaconStateFieldsSSZ decodes the fields of an SSZ state.
+func unmarshalBeaconStateFieldsSSZ(buf []byte,
+	version spec.DataVersion,
+) (
+	*bellatrix.BeaconState,
+	[]*phase0.PendingAttestation,
+	[]*phase0.PendingAttestation,
+	error,
+) {
+	var previousEpochAttestations []*phase0.PendingAttestation
+	var currentEpochAttestations []*phase0.PendingAttestation
+
+	state := &bellatrix.BeaconState{
+		Fork:                        &phase0.Fork{},
+		LatestBlockHeader:           &phase0.BeaconBlockHeader{},
+		ETH1Data:                    &phase0.ETH1Data{},
+		PreviousJustifiedCheckpoint: &phase0.Checkpoint{},
+		CurrentJustifiedCheckpoint:  &phase0.Checkpoint{},
+		FinalizedCheckpoint:         &phase0.Checkpoint{},
+		CurrentSyncCommittee:        &altair.SyncCommittee{},
+		NextSyncCommittee:           &altair.SyncCommittee{},
+	}
+	offsets := make([]uint32, 0, 8)
+	r := &sszReader{buf: buf}
+
+	state.GenesisTime = r.uint64()
+	state.GenesisValidatorsRoot = r.next(32)
+	state.Slot = r.uint64()
+	if err := state.Fork.UnmarshalSSZ(r.next(16)); err != nil {
+		return nil, nil, nil, errors.Wrap(err, "invalid fork")
+	}
+	if err := state.LatestBlockHeader.UnmarshalSSZ(r.next(112)); err != nil {
+		return nil, nil, nil, errors.Wrap(err, "invalid latest block header")
+	}
+	state.BlockRoots = r.roots(8192)
+	state.StateRoots = r.roots(8192)
+	offsets = append(offsets, r.offset())
+	if err := state.ETH1Data.UnmarshalSSZ(r.next(72)); err != nil {
+		return nil, nil, nil, errors.Wrap(err, "invalid eth1 data")
+	}
+	offsets = append(offsets, r.offset())
+	state.ETH1DepositIndex = r.uint64()
+	offsets = append(offsets, r.offset(), r.offset())
+	state.RANDAOMixes = r.roots(65536)
+	state.Slashings = r.uint64s(8192)
+	offsets = append(offsets, r.offset(), r.offset())
+	state.JustificationBits = bitfield.Bitvector4(r.next(1))
+	for _, checkpoint := range []*phase0.Checkpoint{state.PreviousJustifiedCheckpoint, state.CurrentJustifiedCheckpoint, state.FinalizedCheckpoint} {
+		if err := checkpoint.UnmarshalSSZ(r.next(40)); err != nil {
+			return nil, nil, nil, errors.Wrap(err, "invalid checkpoint")
+		}
+	}
+	if version != spec.DataVersionPhase0 {
+		offsets = append(offsets, r.offset())
+		if err := state.CurrentSyncCommittee.UnmarshalSSZ(r.next(24624)); err != nil {
+			return nil, nil, nil, errors.Wrap(err, "invalid current sync committee")
+		}
+		if err := state.NextSyncCommittee.UnmarshalSSZ(r.next(24624)); err != nil {
+			return nil, nil, nil, errors.Wrap(err, "invalid next sync committee")
+		}
+	}
+	if version == spec.DataVersionBellatrix {
+		offsets = append(offsets, r.offset())
+	}
+
+	// Split the variable part of the state in to its fields.
+	if offsets[0] != uint32(r.pos) {
+		return nil, nil, nil, errors.New("invalid first offset")
+	}
+	fields := make([][]byte, len(offsets))
+	for i := range offsets {
+		end := uint32(len(buf))
+		if i < len(offsets)-1 {
+			end = offsets[i+1]
+		}
+		if end < offsets[i] || end > uint32(len(buf)) {
+			return nil, nil, nil, fmt.Errorf("invalid offset %d", i)
+		}
+		fields[i] = buf[offsets[i]:end]
+	}
+
+	items, err := sszItems(fields[0], 32)
+	if err != nil {
+		return nil, nil, nil, errors.Wrap(err, "invalid historical roots")
+	}
+	state.HistoricalRoots = items
+	if items, err = sszItems(fields[1], 72); err != nil {
+		return nil, nil, nil, errors.Wrap(err, "invalid eth1 data votes")
+	}
+	state.ETH1DataVotes = make([]*phase0.ETH1Data, len(items))
+	for i := range items {
+		state.ETH1DataVotes[i] = &phase0.ETH1Data{}
+		if err := state.ETH1DataVotes[i].UnmarshalSSZ(items[i]); err != nil {
+			return nil, nil, nil, errors.Wrapf(err, "invalid eth1 data vote %d", i)
+		}
+	}
+	if items, err = sszItems(fields[2], 121); err != nil {
+		return nil, nil, nil, errors.Wrap(err, "invalid validators")
+	}
+	state.Validators = make([]*phase0.Validator, len(items))
+	for i := range items {
+		state.Validators[i] = &phase0.Validator{}
+		if err := state.Validators[i].UnmarshalSSZ(items[i]); err != nil {
+			return nil, nil, nil, errors.Wrapf(err, "invalid validator %d", i)
+		}
+	}
+	if state.Balances, err = sszUint64s(fields[3]); err != nil {
+		return nil, nil, nil, errors.Wrap(err, "invalid balances")
+	}
+	if version == spec.DataVersionPhase0 {
+		if previousEpochAttestations, err = sszPendingAttestations(fields[4]); err != nil {
+			return nil, nil, nil, errors.Wrap(err, "invalid previous epoch attestations")
+		}
+		if currentEpochAttestations, err = sszPendingAttestations(fields[5]); err != nil {
+			return nil, nil, nil, errors.Wrap(err, "invalid current epoch attestations")
+		}
+		return state, previousEpochAttestations, currentEpochAttestations, nil
+	}
+
+	state.PreviousEpochParticipation = make([]altair.ParticipationFlags, len(fields[4]))
+	for i := range fields[4] {
+		state.PreviousEpochParticipation[i] = altair.ParticipationFlags(fields[4][i])
+	}
+	state.CurrentEpochParticipation = make([]altair.ParticipationFlags, len(fields[5]))
+	for i := range fields[5] {
+		state.CurrentEpochParticipation[i] = altair.ParticipationFlags(fields[5][i])
+	}
+	if state.InactivityScores, err = sszUint64s(fields[6]); err != nil {
+		return nil, nil, nil, errors.Wrap(err, "invalid inactivity scores")
+	}
+	if version == spec.DataVersionBellatrix {
+		state.LatestExecutionPayloadHeader = &bellatrix.ExecutionPayloadHeader{}
+		if err := state.LatestExecutionPayloadHeader.UnmarshalSSZ(fields[7]); err != nil {
+			return nil, nil, nil, errors.Wrap(err, "invalid latest execution payload header")
+		}
+	}
+
+	return state, nil, nil, nil
+}
+
+// sszItems splits an SSZ list of fixed-size items in to its items.
+func sszItems(data []byte, size int) ([][]byte, error) {
+	if len(data)%size != 0 {
+		return nil, fmt.Errorf("length %d is not a multiple of %d", len(data), size)
+	}
+	items := make([][]byte, len(data)/size)
+	for i := range items {
+		items[i] = data[i*size : (i+1)*size]
+	}
+	return items, nil
+}
+
+// sszUint64s decodes an SSZ list of uint64s.
+func sszUint64s(data []byte) ([]uint64, error) {
+	items, err := sszItems(data, 8)
+	if err != nil {
+		return nil, err
+	}
+	values := make([]uint64, len(items))
+	for i := range items {
+		values[i] = binary.LittleEndian.Uint64(items[i])
+	}
+	return values, nil
+}
+
+// sszPendingAttestations decodes an SSZ list of pending attestations, which
+// are variable-size so are preceded by a table of offsets.
+func sszPendingAttestations(data []byte) ([]*phase0.PendingAttestation, error) {
+	if len(data) == 0 {
+		return []*phase0.PendingAttestation{}, nil
+	}
+	if len(data) < 4 {
+		return nil, errors.New("data too short")
+	}
+	firstOffset := binary.LittleEndian.Uint32(data)
+	if firstOffset%4 != 0 || firstOffset > uint32(len(data)) {
+		return nil, errors.New("invalid first offset")
+	}
+	attestations := make([]*phase0.PendingAttestation, firstOffset/4)
+	for i := range attestations {
+		start := binary.LittleEndian.Uint32(data[i*4:])
+		end := uint32(len(data))
+		if i < len(attestations)-1 {
+			end = binary.LittleEndian.Uint32(data[(i+1)*4:])
+		}
+		if end < start || end > uint32(len(data)) {
+			return nil, fmt.Errorf("invalid offset %d", i)
+		}
+		attestations[i] = &phase0.PendingAttestation{}
+		if err := attestations[i].UnmarshalSSZ(data[start:end]); err != nil {
+			return nil, errors.Wrapf(err, "invalid attestation %d", i)
+		}
+	}
+	return attestations, nil
+}
@@ -0,0 +1,317 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util_test
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/aaron-alderman/ethdo/util"
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/prysmaticlabs/go-bitfield"
+	"github.com/stretchr/testify/require"
+)
+
+func roots(count int) [][]byte {
+	res := make([][]byte, count)
+	for i := range res {
+		res[i] = make([]byte, 32)
+	}
+	return res
+}
+
+func testPhase0State() *phase0.BeaconState {
+	return &phase0.BeaconState{
+		GenesisTime:           1606824023,
+		GenesisValidatorsRoot: make([]byte, 32),
+		Slot:                  12345,
+		Fork:                  &phase0.Fork{},
+		LatestBlockHeader:     &phase0.BeaconBlockHeader{Slot: 12344},
+		BlockRoots:            roots(8192),
+		StateRoots:            roots(8192),
+		HistoricalRoots:       roots(1),
+		ETH1Data:              &phase0.ETH1Data{BlockHash: make([]byte, 32)},
+		ETH1DataVotes:         []*phase0.ETH1Data{},
+		Validators: []*phase0.Validator{
+			{WithdrawalCredentials: make([]byte, 32), EffectiveBalance: 32000000000},
+			{WithdrawalCredentials: make([]byte, 32), EffectiveBalance: 31000000000},
+		},
+		Balances:                    []uint64{32000000001, 31000000002},
+		RANDAOMixes:                 roots(65536),
+		Slashings:                   make([]uint64, 8192),
+		PreviousEpochAttestations:   []*phase0.PendingAttestation{},
+		CurrentEpochAttestations:    []*phase0.PendingAttestation{},
+		JustificationBits:           []byte{0x03},
+		PreviousJustifiedCheckpoint: &phase0.Checkpoint{},
+		CurrentJustifiedCheckpoint:  &phase0.Checkpoint{},
+		FinalizedCheckpoint:         &phase0.Checkpoint{Epoch: 383},
+	}
+}
+
+// phase0StateSSZ builds a phase0 SSZ state.  The go-eth2-client phase0 state
+// omits the deposit index, so it is inserted to match the spec encoding.
+func phase0StateSSZ(t *testing.T, state *phase0.BeaconState) []byte {
+	data, err := state.MarshalSSZ()
+	require.NoError(t, err)
+
+	res := make([]byte, 0)
+	res = append(res, data[:524544]...)
+	depositIndex := make([]byte, 8)
+	binary.LittleEndian.PutUint64(depositIndex, 2)
+	res = append(res, depositIndex...)
+	res = append(res, data[524544:]...)
+	for _, pos := range []int{524464, 524540, 524552, 524556, 2687248, 2687252} {
+		offset := binary.LittleEndian.Uint32(res[pos:])
+		binary.LittleEndian.PutUint32(res[pos:], offset+8)
+	}
+
+	return res
+}
+
+// laterForkStateSSZ builds an altair or bellatrix SSZ state from a phase0 SSZ state
+// with no pending attestations, which shares the same layout up to the end of the
+// fixed part of the phase0 state.
+func laterForkStateSSZ(t *testing.T, phase0SSZ []byte, withExecutionPayloadHeader bool) []byte {
+	phase0FixedSize := 2687377
+	extraFixedSize := 4 + 2*24624
+	if withExecutionPayloadHeader {
+		extraFixedSize += 4
+	}
+
+	res := make([]byte, 0)
+	res = append(res, phase0SSZ[:phase0FixedSize]...)
+	for _, pos := range []int{524464, 524540, 524552, 524556, 2687248, 2687252} {
+		offset := binary.LittleEndian.Uint32(res[pos:])
+		binary.LittleEndian.PutUint32(res[pos:], offset+uint32(extraFixedSize))
+	}
+	variableSize := len(phase0SSZ) - phase0FixedSize
+	inactivityScores := make([]byte, 16)
+	binary.LittleEndian.PutUint64(inactivityScores[8:], 5)
+	res = binary.LittleEndian.AppendUint32(res, uint32(phase0FixedSize+extraFixedSize+variableSize))
+	res = append(res, make([]byte, 2*24624)...)
+	if withExecutionPayloadHeader {
+		res = binary.LittleEndian.AppendUint32(res, uint32(phase0FixedSize+extraFixedSize+variableSize+len(inactivityScores)))
+	}
+	res = append(res, phase0SSZ[phase0FixedSize:]...)
+	res = append(res, inactivityScores...)
+	if withExecutionPayloadHeader {
+		header, err := (&bellatrix.ExecutionPayloadHeader{BlockNumber: 15537394}).MarshalSSZ()
+		require.NoError(t, err)
+		res = append(res, header...)
+	}
+
+	return res
+}
+
+func TestParseBeaconState(t *testing.T) {
+	phase0State := testPhase0State()
+	phase0JSON, err := json.Marshal(phase0State)
+	require.NoError(t, err)
+	phase0SSZ := phase0StateSSZ(t, phase0State)
+	phase0AttestationsState := testPhase0State()
+	phase0AttestationsState.CurrentEpochAttestations = []*phase0.PendingAttestation{
+		{
+			AggregationBits: bitfield.NewBitlist(8),
+			Data: &phase0.AttestationData{
+				Source: &phase0.Checkpoint{},
+				Target: &phase0.Checkpoint{},
+			},
+			InclusionDelay: 1,
+		},
+		{
+			AggregationBits: bitfield.NewBitlist(16),
+			Data: &phase0.AttestationData{
+				Source: &phase0.Checkpoint{},
+				Target: &phase0.Checkpoint{},
+			},
+			InclusionDelay: 2,
+		},
+	}
+	phase0AttestationsSSZ := phase0StateSSZ(t, phase0AttestationsState)
+	altairSSZ := laterForkStateSSZ(t, phase0SSZ, false)
+	bellatrixSSZ := laterForkStateSSZ(t, phase0SSZ, true)
+
+	tests := []struct {
+		name         string
+		input        []byte
+		version      spec.DataVersion
+		attestations int
+		err          string
+	}{
+		{
+			name:  "Empty",
+			input: []byte(" "),
+			err:   "no data supplied",
+		},
+		{
+			name:  "Invalid",
+			input: []byte(`{}`),
+			err:   "invalid phase0 state: genesis time missing",
+		},
+		{
+			name:    "Phase0",
+			input:   phase0JSON,
+			version: spec.DataVersionPhase0,
+		},
+		{
+			name:    "Versioned",
+			input:   []byte(fmt.Sprintf(`{"version":"phase0","data":%s}`, string(phase0JSON))),
+			version: spec.DataVersionPhase0,
+		},
+		{
+			name:    "Phase0SSZ",
+			input:   phase0SSZ,
+			version: spec.DataVersionPhase0,
+		},
+		{
+			name:    "Phase0SSZHex",
+			input:   []byte(fmt.Sprintf("%x", phase0SSZ)),
+			version: spec.DataVersionPhase0,
+		},
+		{
+			name:         "Phase0SSZAttestations",
+			input:        phase0AttestationsSSZ,
+			version:      spec.DataVersionPhase0,
+			attestations: 2,
+		},
+		{
+			name:    "AltairSSZ",
+			input:   altairSSZ,
+			version: spec.DataVersionAltair,
+		},
+		{
+			name:    "BellatrixSSZ",
+			input:   bellatrixSSZ,
+			version: spec.DataVersionBellatrix,
+		},
+		{
+			name:  "SSZShort",
+			input: phase0SSZ[:1024],
+			err:   "SSZ state too short",
+		},
+		{
+			name:  "SSZUnknownFork",
+			input: make([]byte, 2736633),
+			err:   "SSZ state does not match a known fork",
+		},
+		{
+			name:  "AltairSSZTruncated",
+			input: altairSSZ[:len(altairSSZ)-1],
+			err:   "invalid altair state: invalid inactivity scores: length 15 is not a multiple of 8",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			state, err := util.ParseBeaconState(test.input)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, test.version, state.Version)
+			slot, err := util.BeaconStateSlot(state)
+			require.NoError(t, err)
+			require.Equal(t, phase0.Slot(12345), slot)
+			validators, err := util.BeaconStateValidators(state)
+			require.NoError(t, err)
+			require.Len(t, validators, 2)
+			require.Equal(t, phase0.Gwei(31000000000), validators[1].EffectiveBalance)
+			balances, err := util.BeaconStateBalances(state)
+			require.NoError(t, err)
+			require.Equal(t, []uint64{32000000001, 31000000002}, balances)
+			switch state.Version {
+			case spec.DataVersionPhase0:
+				require.Equal(t, phase0.Epoch(383), state.Phase0.FinalizedCheckpoint.Epoch)
+				require.Len(t, state.Phase0.CurrentEpochAttestations, test.attestations)
+			case spec.DataVersionAltair:
+				require.Equal(t, phase0.Epoch(383), state.Altair.FinalizedCheckpoint.Epoch)
+				require.Equal(t, uint64(2), state.Altair.ETH1DepositIndex)
+				require.Equal(t, []uint64{0, 5}, state.Altair.InactivityScores)
+				require.Len(t, state.Altair.HistoricalRoots, 1)
+			case spec.DataVersionBellatrix:
+				require.Equal(t, []uint64{0, 5}, state.Bellatrix.InactivityScores)
+				require.Equal(t, uint64(15537394), state.Bellatrix.LatestExecutionPayloadHeader.BlockNumber)
+			}
+		})
+	}
+}
+
+func TestValidatorStateAt(t *testing.T) {
+	farFutureEpoch := phase0.Epoch(0xffffffffffffffff)
+	tests := []struct {
+		name      string
+		validator *phase0.Validator
+		balance   uint64
+		epoch     phase0.Epoch
+		state     apiv1.ValidatorState
+	}{
+		{
+			name: "Pending",
+			validator: &phase0.Validator{
+				ActivationEligibilityEpoch: 5,
+				ActivationEpoch:            farFutureEpoch,
+				ExitEpoch:                  farFutureEpoch,
+				WithdrawableEpoch:          farFutureEpoch,
+			},
+			balance: 32000000000,
+			epoch:   10,
+			state:   apiv1.ValidatorStatePendingQueued,
+		},
+		{
+			name: "Active",
+			validator: &phase0.Validator{
+				ActivationEpoch:   5,
+				ExitEpoch:         farFutureEpoch,
+				WithdrawableEpoch: farFutureEpoch,
+			},
+			balance: 32000000000,
+			epoch:   10,
+			state:   apiv1.ValidatorStateActiveOngoing,
+		},
+		{
+			name: "WithdrawalPossible",
+			validator: &phase0.Validator{
+				ActivationEpoch:   5,
+				ExitEpoch:         8,
+				WithdrawableEpoch: 9,
+			},
+			balance: 32000000000,
+			epoch:   10,
+			state:   apiv1.ValidatorStateWithdrawalPossible,
+		},
+		{
+			name: "WithdrawalDone",
+			validator: &phase0.Validator{
+				ActivationEpoch:   5,
+				ExitEpoch:         8,
+				WithdrawableEpoch: 9,
+			},
+			balance: 0,
+			epoch:   10,
+			state:   apiv1.ValidatorStateWithdrawalDone,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.state, util.ValidatorStateAt(test.validator, test.balance, test.epoch))
+		})
+	}
+}
@@ -0,0 +1,100 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+)
+
+// SubmitBLSToExecutionChanges submits BLS to execution changes to the beacon node.
+func SubmitBLSToExecutionChanges(ctx context.Context, client eth2client.Service, timeout time.Duration, changes []*SignedBLSToExecutionChange) error {
+	return postToBeaconNode(ctx, client, timeout, "/eth/v1/beacon/pool/bls_to_execution_changes", changes)
+}
+
+// SubmitAttesterSlashing submits an attester slashing to the beacon node.
+func SubmitAttesterSlashing(ctx context.Context, client eth2client.Service, timeout time.Duration, slashing *phase0.AttesterSlashing) error {
+	return postToBeaconNode(ctx, client, timeout, "/eth/v1/beacon/pool/attester_slashings", slashing)
+}
+
+// SubmitProposerSlashing submits a proposer slashing to the beacon node.
+func SubmitProposerSlashing(ctx context.Context, client eth2client.Service, timeout time.Duration, slashing *phase0.ProposerSlashing) error {
+	return postToBeaconNode(ctx, client, timeout, "/eth/v1/beacon/pool/proposer_slashings", slashing)
+}
+
+// postToBeaconNode posts JSON-encoded data to an endpoint of the beacon node, for
+// operations that the beacon node client does not support.  If the client fronts
+// multiple beacon nodes they are tried in order of health until one accepts the data
+// or rejects it as invalid.  Only beacon nodes that were connected by ConnectToBeaconNode
+// are used, so the data is subject to the same connection security checks.
+func postToBeaconNode(ctx context.Context, client eth2client.Service, timeout time.Duration, endpoint string, data interface{}) error {
+	body, err := json.Marshal(data)
+	if err != nil {
+		return errors.Wrap(err, "failed to encode request")
+	}
+
+	httpClient := &http.Client{
+		Timeout: timeout,
+	}
+	for _, beaconNode := range beaconNodeClients(client) {
+		var retry bool
+		retry, err = postToAddress(ctx, httpClient, beaconNode.Address(), endpoint, body)
+		if err == nil || !retry {
+			return err
+		}
+		debugBeaconNode("Beacon node at %s failed to accept %s: %v", beaconNode.Address(), endpoint, err)
+	}
+
+	return err
+}
+
+// postToAddress posts a request body to an endpoint of a single beacon node.  It returns
+// true if a failure was down to the beacon node rather than the request, in which case
+// the request can be retried with another beacon node.
+func postToAddress(ctx context.Context, httpClient *http.Client, address string, endpoint string, body []byte) (bool, error) {
+	url := fmt.Sprintf("%s%s", strings.TrimSuffix(beaconNodeURL(address), "/"), endpoint)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return false, errors.Wrap(err, "failed to create request")
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return true, errors.Wrap(err, "failed to call beacon node")
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode/100 != 2 {
+		respBody, err := ioutil.ReadAll(resp.Body)
+		if err != nil {
+			return true, errors.Wrap(err, "failed to read response")
+		}
+		// Server errors may be specific to this beacon node; client errors are not.
+		return resp.StatusCode/100 == 5, fmt.Errorf("beacon node returned status %d: %s", resp.StatusCode, string(respBody))
+	}
+
+	return false, nil
+}
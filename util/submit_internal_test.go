@@ -0,0 +1,128 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/stretchr/testify/require"
+)
+
+// addressService is a client that provides only an address.
+type addressService struct {
+	address string
+}
+
+func (s *addressService) Name() string {
+	return "address"
+}
+
+func (s *addressService) Address() string {
+	return s.address
+}
+
+// newStatusServer starts a server that responds to all requests with the given status,
+// counting the requests it receives.
+func newStatusServer(t *testing.T, status int, requests *int32) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(requests, 1)
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestPostToBeaconNode(t *testing.T) {
+	var unavailableRequests, rejectingRequests, acceptingRequests int32
+	unavailable := newStatusServer(t, http.StatusServiceUnavailable, &unavailableRequests)
+	rejecting := newStatusServer(t, http.StatusBadRequest, &rejectingRequests)
+	accepting := newStatusServer(t, http.StatusOK, &acceptingRequests)
+
+	tests := []struct {
+		name      string
+		clients   []eth2client.Service
+		err       string
+		accepted  int32
+		rejected  int32
+		failovers int32
+	}{
+		{
+			name:     "Single",
+			clients:  []eth2client.Service{&addressService{address: accepting.URL}},
+			accepted: 1,
+		},
+		{
+			name: "Failover",
+			clients: []eth2client.Service{
+				&addressService{address: unavailable.URL},
+				&addressService{address: accepting.URL},
+			},
+			accepted:  1,
+			failovers: 1,
+		},
+		{
+			name: "Rejected",
+			clients: []eth2client.Service{
+				&addressService{address: rejecting.URL},
+				&addressService{address: accepting.URL},
+			},
+			err:      "beacon node returned status 400: ",
+			rejected: 1,
+		},
+		{
+			name: "AllUnavailable",
+			clients: []eth2client.Service{
+				&addressService{address: unavailable.URL},
+				&addressService{address: unavailable.URL},
+			},
+			err:       "beacon node returned status 503: ",
+			failovers: 2,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			atomic.StoreInt32(&unavailableRequests, 0)
+			atomic.StoreInt32(&rejectingRequests, 0)
+			atomic.StoreInt32(&acceptingRequests, 0)
+			client := newFailoverService(test.clients[0], test.clients)
+			err := postToBeaconNode(context.Background(), client, time.Second, "/eth/v1/beacon/pool/proposer_slashings", struct{}{})
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+			}
+			require.Equal(t, test.accepted, atomic.LoadInt32(&acceptingRequests))
+			require.Equal(t, test.rejected, atomic.LoadInt32(&rejectingRequests))
+			require.Equal(t, test.failovers, atomic.LoadInt32(&unavailableRequests))
+		})
+	}
+}
+
+func TestPostToBeaconNodeTimeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(200 * time.Millisecond)
+	}))
+	defer server.Close()
+
+	err := postToBeaconNode(context.Background(), &addressService{address: server.URL}, 10*time.Millisecond, "/eth/v1/beacon/pool/proposer_slashings", struct{}{})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "failed to call beacon node")
+}
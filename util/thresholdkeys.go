@@ -0,0 +1,216 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/herumi/bls-eth-go-binary/bls"
+	"github.com/pkg/errors"
+	keystorev4 "github.com/wealdtech/go-eth2-wallet-encryptor-keystorev4"
+)
+
+// ThresholdKeys are the keys for a distributed account generated locally.
+type ThresholdKeys struct {
+	// CompositePublicKey is the public key of the distributed account.
+	CompositePublicKey *bls.PublicKey
+	// VerificationVector holds the public commitments to the coefficients of the secret polynomial.
+	VerificationVector []bls.PublicKey
+	// Shares are the secret key shares, indexed by participant ID.
+	Shares map[uint64]*bls.SecretKey
+}
+
+// GenerateThresholdKeys generates a new key and splits it in to shares for the given number of
+// participants, any signingThreshold of which can recover signatures for the key.
+// The split uses a trusted dealer with Feldman verifiable secret sharing, so that each share
+// can be checked against the verification vector.  Participant IDs start at 1.
+func GenerateThresholdKeys(participants uint32, signingThreshold uint32) (*ThresholdKeys, error) {
+	if participants < 2 {
+		return nil, errors.New("at least two participants are required")
+	}
+	if signingThreshold == 0 {
+		return nil, errors.New("signing threshold must be at least one")
+	}
+	if signingThreshold > participants {
+		return nil, errors.New("signing threshold cannot be higher than the number of participants")
+	}
+
+	// The master secret key is a polynomial of degree signingThreshold-1, with the
+	// constant term being the composite secret key.
+	msk := make([]bls.SecretKey, signingThreshold)
+	for i := range msk {
+		msk[i].SetByCSPRNG()
+	}
+	mpk := bls.GetMasterPublicKey(msk)
+
+	shares := make(map[uint64]*bls.SecretKey, participants)
+	for id := uint64(1); id <= uint64(participants); id++ {
+		var share bls.SecretKey
+		if err := share.Set(msk, BLSID(id)); err != nil {
+			return nil, errors.Wrap(err, fmt.Sprintf("failed to generate share %d", id))
+		}
+		shares[id] = &share
+	}
+
+	return &ThresholdKeys{
+		CompositePublicKey: &mpk[0],
+		VerificationVector: mpk,
+		Shares:             shares,
+	}, nil
+}
+
+// VerifyThresholdShare returns true if the public key of the share with the given
+// participant ID is consistent with the verification vector.
+func VerifyThresholdShare(verificationVector []bls.PublicKey, id uint64, pubKey *bls.PublicKey) bool {
+	if len(verificationVector) == 0 || pubKey == nil {
+		return false
+	}
+	var expected bls.PublicKey
+	if err := expected.Set(verificationVector, BLSID(id)); err != nil {
+		return false
+	}
+
+	return expected.IsEqual(pubKey)
+}
+
+// ThresholdKeystore is an EIP-2335 keystore for a share of a distributed account, with
+// additional fields to identify the share and verify it against the distributed account.
+type ThresholdKeystore struct {
+	Crypto             map[string]interface{} `json:"crypto"`
+	Description        string                 `json:"description"`
+	PubKey             string                 `json:"pubkey"`
+	Path               string                 `json:"path"`
+	UUID               string                 `json:"uuid"`
+	Version            uint                   `json:"version"`
+	ShareID            uint64                 `json:"share_id"`
+	SigningThreshold   uint32                 `json:"signing_threshold"`
+	CompositePubKey    string                 `json:"composite_pubkey"`
+	VerificationVector []string               `json:"verification_vector"`
+}
+
+// NewThresholdKeystore creates a keystore for the share of the given participant, encrypted with the passphrase.
+func NewThresholdKeystore(keys *ThresholdKeys, id uint64, name string, passphrase string) (*ThresholdKeystore, error) {
+	if keys == nil {
+		return nil, errors.New("no keys supplied")
+	}
+	share, exists := keys.Shares[id]
+	if !exists {
+		return nil, fmt.Errorf("no share for participant %d", id)
+	}
+
+	crypto, err := keystorev4.New().Encrypt(share.Serialize(), passphrase)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to encrypt share")
+	}
+
+	verificationVector := make([]string, len(keys.VerificationVector))
+	for i := range keys.VerificationVector {
+		verificationVector[i] = fmt.Sprintf("%x", keys.VerificationVector[i].Serialize())
+	}
+
+	return &ThresholdKeystore{
+		Crypto:             crypto,
+		Description:        fmt.Sprintf("share %d of %s", id, name),
+		PubKey:             fmt.Sprintf("%x", share.GetPublicKey().Serialize()),
+		Path:               "",
+		UUID:               uuid.New().String(),
+		Version:            4,
+		ShareID:            id,
+		SigningThreshold:   uint32(len(keys.VerificationVector)),
+		CompositePubKey:    fmt.Sprintf("%x", keys.CompositePublicKey.Serialize()),
+		VerificationVector: verificationVector,
+	}, nil
+}
+
+// PublicKey returns the public key of the share held in the keystore.
+func (k *ThresholdKeystore) PublicKey() (*bls.PublicKey, error) {
+	return blsPublicKeyFromHex(k.PubKey)
+}
+
+// CompositePublicKey returns the public key of the distributed account.
+func (k *ThresholdKeystore) CompositePublicKey() (*bls.PublicKey, error) {
+	return blsPublicKeyFromHex(k.CompositePubKey)
+}
+
+// Verify confirms that the public key of the share is consistent with the verification vector,
+// that the verification vector is consistent with the composite public key and that the signing
+// threshold matches the length of the verification vector.
+func (k *ThresholdKeystore) Verify() error {
+	if k.ShareID == 0 {
+		return errors.New("share ID missing")
+	}
+	if len(k.VerificationVector) == 0 {
+		return errors.New("verification vector missing")
+	}
+	if k.SigningThreshold == 0 {
+		return errors.New("signing threshold missing")
+	}
+	if int(k.SigningThreshold) != len(k.VerificationVector) {
+		return fmt.Errorf("signing threshold %d does not match verification vector length %d", k.SigningThreshold, len(k.VerificationVector))
+	}
+	verificationVector := make([]bls.PublicKey, len(k.VerificationVector))
+	for i := range k.VerificationVector {
+		pubKey, err := blsPublicKeyFromHex(k.VerificationVector[i])
+		if err != nil {
+			return errors.Wrap(err, "invalid verification vector")
+		}
+		verificationVector[i] = *pubKey
+	}
+	compositePubKey, err := k.CompositePublicKey()
+	if err != nil {
+		return errors.Wrap(err, "invalid composite public key")
+	}
+	if !verificationVector[0].IsEqual(compositePubKey) {
+		return errors.New("verification vector does not match composite public key")
+	}
+	pubKey, err := k.PublicKey()
+	if err != nil {
+		return errors.Wrap(err, "invalid public key")
+	}
+	if !VerifyThresholdShare(verificationVector, k.ShareID, pubKey) {
+		return errors.New("share does not match verification vector")
+	}
+
+	return nil
+}
+
+// SecretKey decrypts the keystore to obtain the secret key share.
+func (k *ThresholdKeystore) SecretKey(passphrase string) (*bls.SecretKey, error) {
+	secret, err := keystorev4.New().Decrypt(k.Crypto, passphrase)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to decrypt share")
+	}
+	var key bls.SecretKey
+	if err := key.Deserialize(secret); err != nil {
+		return nil, errors.Wrap(err, "invalid secret key")
+	}
+
+	return &key, nil
+}
+
+func blsPublicKeyFromHex(input string) (*bls.PublicKey, error) {
+	data, err := hex.DecodeString(strings.TrimPrefix(input, "0x"))
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid hex")
+	}
+	var pubKey bls.PublicKey
+	if err := pubKey.Deserialize(data); err != nil {
+		return nil, errors.Wrap(err, "invalid public key")
+	}
+
+	return &pubKey, nil
+}
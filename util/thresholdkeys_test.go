@@ -0,0 +1,139 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util_test
+
+import (
+	"testing"
+
+	"github.com/aaron-alderman/ethdo/util"
+	"github.com/herumi/bls-eth-go-binary/bls"
+	"github.com/stretchr/testify/require"
+	e2types "github.com/wealdtech/go-eth2-types/v2"
+)
+
+func TestGenerateThresholdKeys(t *testing.T) {
+	require.NoError(t, e2types.InitBLS())
+
+	tests := []struct {
+		name             string
+		participants     uint32
+		signingThreshold uint32
+		err              string
+	}{
+		{
+			name:             "ParticipantsTooFew",
+			participants:     1,
+			signingThreshold: 1,
+			err:              "at least two participants are required",
+		},
+		{
+			name:             "SigningThresholdZero",
+			participants:     3,
+			signingThreshold: 0,
+			err:              "signing threshold must be at least one",
+		},
+		{
+			name:             "SigningThresholdTooHigh",
+			participants:     3,
+			signingThreshold: 4,
+			err:              "signing threshold cannot be higher than the number of participants",
+		},
+		{
+			name:             "Good",
+			participants:     5,
+			signingThreshold: 3,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			keys, err := util.GenerateThresholdKeys(test.participants, test.signingThreshold)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, keys.Shares, int(test.participants))
+			require.Len(t, keys.VerificationVector, int(test.signingThreshold))
+			for id, share := range keys.Shares {
+				require.True(t, util.VerifyThresholdShare(keys.VerificationVector, id, share.GetPublicKey()))
+			}
+
+			// Any signingThreshold shares should recover a signature that verifies against the composite key.
+			msg := []byte("threshold")
+			ids := make([]bls.ID, 0, test.signingThreshold)
+			sigs := make([]bls.Sign, 0, test.signingThreshold)
+			for id := uint64(test.participants); id > uint64(test.participants-test.signingThreshold); id-- {
+				ids = append(ids, *util.BLSID(id))
+				sigs = append(sigs, *keys.Shares[id].SignByte(msg))
+			}
+			var sig bls.Sign
+			require.NoError(t, sig.Recover(sigs, ids))
+			require.True(t, sig.VerifyByte(keys.CompositePublicKey, msg))
+		})
+	}
+}
+
+func TestVerifyThresholdShare(t *testing.T) {
+	require.NoError(t, e2types.InitBLS())
+
+	keys, err := util.GenerateThresholdKeys(3, 2)
+	require.NoError(t, err)
+
+	require.True(t, util.VerifyThresholdShare(keys.VerificationVector, 1, keys.Shares[1].GetPublicKey()))
+	require.False(t, util.VerifyThresholdShare(keys.VerificationVector, 2, keys.Shares[1].GetPublicKey()))
+	require.False(t, util.VerifyThresholdShare(nil, 1, keys.Shares[1].GetPublicKey()))
+	require.False(t, util.VerifyThresholdShare(keys.VerificationVector, 1, nil))
+}
+
+func TestThresholdKeystore(t *testing.T) {
+	require.NoError(t, e2types.InitBLS())
+
+	keys, err := util.GenerateThresholdKeys(3, 2)
+	require.NoError(t, err)
+
+	_, err = util.NewThresholdKeystore(nil, 1, "Test", "secret")
+	require.EqualError(t, err, "no keys supplied")
+	_, err = util.NewThresholdKeystore(keys, 4, "Test", "secret")
+	require.EqualError(t, err, "no share for participant 4")
+
+	keystore, err := util.NewThresholdKeystore(keys, 2, "Test", "secret")
+	require.NoError(t, err)
+	require.Equal(t, uint64(2), keystore.ShareID)
+	require.Equal(t, uint32(2), keystore.SigningThreshold)
+	require.NoError(t, keystore.Verify())
+
+	compositePubKey, err := keystore.CompositePublicKey()
+	require.NoError(t, err)
+	require.True(t, compositePubKey.IsEqual(keys.CompositePublicKey))
+
+	_, err = keystore.SecretKey("wrong")
+	require.Error(t, err)
+	key, err := keystore.SecretKey("secret")
+	require.NoError(t, err)
+	require.True(t, key.IsEqual(keys.Shares[2]))
+
+	// Claim to be a different share.
+	keystore.ShareID = 3
+	require.EqualError(t, keystore.Verify(), "share does not match verification vector")
+	keystore.ShareID = 2
+
+	// Claim an invalid signing threshold.
+	keystore.SigningThreshold = 0
+	require.EqualError(t, keystore.Verify(), "signing threshold missing")
+	keystore.SigningThreshold = 3
+	require.EqualError(t, keystore.Verify(), "signing threshold 3 does not match verification vector length 2")
+	keystore.SigningThreshold = 1
+	require.EqualError(t, keystore.Verify(), "signing threshold 1 does not match verification vector length 2")
+}
@@ -0,0 +1,98 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/herumi/bls-eth-go-binary/bls"
+	"github.com/pkg/errors"
+)
+
+// ThresholdPartialSignature is a signature from a single share of a distributed account.
+type ThresholdPartialSignature struct {
+	// ID is the participant ID of the share.
+	ID uint64
+	// PublicKey is the public key of the share.
+	PublicKey *bls.PublicKey
+	// Signature is the signature generated by the share.
+	Signature *bls.Sign
+}
+
+// ThresholdShareID returns the participant ID of a public key share, found by checking the
+// key against the verification vector for each of the candidate IDs.
+func ThresholdShareID(verificationVector []bls.PublicKey, ids []uint64, pubKey *bls.PublicKey) (uint64, error) {
+	for _, id := range ids {
+		if VerifyThresholdShare(verificationVector, id, pubKey) {
+			return id, nil
+		}
+	}
+
+	return 0, errors.New("public key does not match any participant")
+}
+
+// RecoverThresholdSignature checks each partial signature of the signing root against the public
+// key of its share and, if they are all valid, recovers the composite signature.
+// The composite signature is verified against the composite public key before being returned.
+func RecoverThresholdSignature(signingRoot []byte,
+	partials []*ThresholdPartialSignature,
+	signingThreshold uint32,
+	compositePubKey *bls.PublicKey,
+) (
+	*bls.Sign,
+	error,
+) {
+	if compositePubKey == nil {
+		return nil, errors.New("no composite public key supplied")
+	}
+	if len(partials) < int(signingThreshold) {
+		return nil, fmt.Errorf("%d signatures supplied but %d required", len(partials), signingThreshold)
+	}
+
+	// Order by ID to provide consistent results and errors.
+	sorted := make([]*ThresholdPartialSignature, len(partials))
+	copy(sorted, partials)
+	sort.Slice(sorted, func(i int, j int) bool {
+		return sorted[i].ID < sorted[j].ID
+	})
+
+	ids := make([]bls.ID, 0, len(sorted))
+	sigs := make([]bls.Sign, 0, len(sorted))
+	seen := make(map[uint64]bool, len(sorted))
+	for _, partial := range sorted {
+		if partial.PublicKey == nil || partial.Signature == nil {
+			return nil, fmt.Errorf("share %d: missing public key or signature", partial.ID)
+		}
+		if seen[partial.ID] {
+			return nil, fmt.Errorf("share %d: supplied more than once", partial.ID)
+		}
+		seen[partial.ID] = true
+		if !partial.Signature.VerifyByte(partial.PublicKey, signingRoot) {
+			return nil, fmt.Errorf("share %d: signature does not verify against public key of share", partial.ID)
+		}
+		ids = append(ids, *BLSID(partial.ID))
+		sigs = append(sigs, *partial.Signature)
+	}
+
+	var signature bls.Sign
+	if err := signature.Recover(sigs, ids); err != nil {
+		return nil, errors.Wrap(err, "failed to recover composite signature")
+	}
+	if !signature.VerifyByte(compositePubKey, signingRoot) {
+		return nil, errors.New("composite signature does not verify against composite public key")
+	}
+
+	return &signature, nil
+}
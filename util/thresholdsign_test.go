@@ -0,0 +1,124 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util_test
+
+import (
+	"testing"
+
+	"github.com/aaron-alderman/ethdo/util"
+	"github.com/herumi/bls-eth-go-binary/bls"
+	"github.com/stretchr/testify/require"
+	e2types "github.com/wealdtech/go-eth2-types/v2"
+)
+
+func TestThresholdShareID(t *testing.T) {
+	require.NoError(t, e2types.InitBLS())
+
+	keys, err := util.GenerateThresholdKeys(3, 2)
+	require.NoError(t, err)
+
+	id, err := util.ThresholdShareID(keys.VerificationVector, []uint64{1, 2, 3}, keys.Shares[3].GetPublicKey())
+	require.NoError(t, err)
+	require.Equal(t, uint64(3), id)
+
+	_, err = util.ThresholdShareID(keys.VerificationVector, []uint64{1, 2}, keys.Shares[3].GetPublicKey())
+	require.EqualError(t, err, "public key does not match any participant")
+}
+
+func TestRecoverThresholdSignature(t *testing.T) {
+	require.NoError(t, e2types.InitBLS())
+
+	keys, err := util.GenerateThresholdKeys(3, 2)
+	require.NoError(t, err)
+	otherKeys, err := util.GenerateThresholdKeys(3, 2)
+	require.NoError(t, err)
+
+	signingRoot := []byte{
+		0x00, 0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08, 0x09, 0x0a, 0x0b, 0x0c, 0x0d, 0x0e, 0x0f,
+		0x10, 0x11, 0x12, 0x13, 0x14, 0x15, 0x16, 0x17, 0x18, 0x19, 0x1a, 0x1b, 0x1c, 0x1d, 0x1e, 0x1f,
+	}
+	partial := func(keys *util.ThresholdKeys, id uint64) *util.ThresholdPartialSignature {
+		return &util.ThresholdPartialSignature{
+			ID:        id,
+			PublicKey: keys.Shares[id].GetPublicKey(),
+			Signature: keys.Shares[id].SignByte(signingRoot),
+		}
+	}
+
+	tests := []struct {
+		name            string
+		partials        []*util.ThresholdPartialSignature
+		compositePubKey *bls.PublicKey
+		err             string
+	}{
+		{
+			name:     "CompositePubKeyMissing",
+			partials: []*util.ThresholdPartialSignature{partial(keys, 1), partial(keys, 2)},
+			err:      "no composite public key supplied",
+		},
+		{
+			name:            "TooFew",
+			partials:        []*util.ThresholdPartialSignature{partial(keys, 1)},
+			compositePubKey: keys.CompositePublicKey,
+			err:             "1 signatures supplied but 2 required",
+		},
+		{
+			name:            "Duplicate",
+			partials:        []*util.ThresholdPartialSignature{partial(keys, 1), partial(keys, 1)},
+			compositePubKey: keys.CompositePublicKey,
+			err:             "share 1: supplied more than once",
+		},
+		{
+			name: "PartialInvalid",
+			partials: []*util.ThresholdPartialSignature{
+				partial(keys, 1),
+				{
+					ID:        2,
+					PublicKey: keys.Shares[2].GetPublicKey(),
+					Signature: keys.Shares[3].SignByte(signingRoot),
+				},
+			},
+			compositePubKey: keys.CompositePublicKey,
+			err:             "share 2: signature does not verify against public key of share",
+		},
+		{
+			name:            "MixedAccounts",
+			partials:        []*util.ThresholdPartialSignature{partial(keys, 1), partial(otherKeys, 2)},
+			compositePubKey: keys.CompositePublicKey,
+			err:             "composite signature does not verify against composite public key",
+		},
+		{
+			name:            "Good",
+			partials:        []*util.ThresholdPartialSignature{partial(keys, 3), partial(keys, 1)},
+			compositePubKey: keys.CompositePublicKey,
+		},
+		{
+			name:            "GoodAll",
+			partials:        []*util.ThresholdPartialSignature{partial(keys, 1), partial(keys, 2), partial(keys, 3)},
+			compositePubKey: keys.CompositePublicKey,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			signature, err := util.RecoverThresholdSignature(signingRoot, test.partials, 2, test.compositePubKey)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+				return
+			}
+			require.NoError(t, err)
+			require.True(t, signature.VerifyByte(keys.CompositePublicKey, signingRoot))
+		})
+	}
+}
@@ -22,6 +22,7 @@ import (
 	"time"
 
 	consensusclient "github.com/attestantio/go-eth2-client"
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
 	"github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/pkg/errors"
 	e2wtypes "github.com/wealdtech/go-eth2-wallet-types/v2"
@@ -77,3 +78,85 @@ func accountToIndex(ctx context.Context, account e2wtypes.Account, client consen
 	}
 	return 0, errors.New("validator not found")
 }
+
+// ParseValidators obtains validators from a list of indices or public keys, and an
+// optional wallet or wallet/account path.
+func ParseValidators(ctx context.Context,
+	validatorsProvider consensusclient.ValidatorsProvider,
+	validators []string,
+	accountsPath string,
+) (
+	map[phase0.ValidatorIndex]*apiv1.Validator,
+	error,
+) {
+	indices := make([]phase0.ValidatorIndex, 0)
+	pubKeys := make([]phase0.BLSPubKey, 0)
+	for _, validator := range validators {
+		validator = strings.TrimSpace(validator)
+		if validator == "" {
+			continue
+		}
+		if strings.HasPrefix(validator, "0x") {
+			data, err := hex.DecodeString(strings.TrimPrefix(validator, "0x"))
+			if err != nil {
+				return nil, errors.Wrap(err, fmt.Sprintf("invalid public key %s", validator))
+			}
+			if len(data) != phase0.PublicKeyLength {
+				return nil, fmt.Errorf("public key %s has incorrect length", validator)
+			}
+			var pubKey phase0.BLSPubKey
+			copy(pubKey[:], data)
+			pubKeys = append(pubKeys, pubKey)
+			continue
+		}
+		index, err := strconv.ParseUint(validator, 10, 64)
+		if err != nil {
+			return nil, errors.Wrap(err, fmt.Sprintf("invalid validator index %s", validator))
+		}
+		indices = append(indices, phase0.ValidatorIndex(index))
+	}
+
+	if accountsPath != "" {
+		_, accounts, err := WalletAndAccountsFromPath(ctx, accountsPath)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to obtain accounts")
+		}
+		if len(accounts) == 0 {
+			return nil, errors.New("no accounts found")
+		}
+		for _, account := range accounts {
+			pubKey, err := BestPublicKey(account)
+			if err != nil {
+				return nil, errors.Wrap(err, fmt.Sprintf("failed to obtain public key for account %s", account.Name()))
+			}
+			var blsPubKey phase0.BLSPubKey
+			copy(blsPubKey[:], pubKey.Marshal())
+			pubKeys = append(pubKeys, blsPubKey)
+		}
+	}
+
+	res := make(map[phase0.ValidatorIndex]*apiv1.Validator)
+	if len(indices) > 0 {
+		validators, err := validatorsProvider.Validators(ctx, "head", indices)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to obtain validators by index")
+		}
+		for index, validator := range validators {
+			res[index] = validator
+		}
+	}
+	if len(pubKeys) > 0 {
+		validators, err := validatorsProvider.ValidatorsByPubKey(ctx, "head", pubKeys)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to obtain validators by public key")
+		}
+		for index, validator := range validators {
+			res[index] = validator
+		}
+	}
+	if len(res) == 0 {
+		return nil, errors.New("no validators found")
+	}
+
+	return res, nil
+}
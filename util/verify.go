@@ -0,0 +1,120 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	e2types "github.com/wealdtech/go-eth2-types/v2"
+)
+
+// VerifyAggregateSignature verifies a signature over an object root and domain against
+// the aggregate of the supplied public keys.
+func VerifyAggregateSignature(signature e2types.Signature,
+	pubKeys []phase0.BLSPubKey,
+	root phase0.Root,
+	domain phase0.Domain,
+) (
+	bool,
+	error,
+) {
+	if len(pubKeys) == 0 {
+		return false, errors.New("no public keys supplied")
+	}
+
+	var aggregatePubKey *e2types.BLSPublicKey
+	for i := range pubKeys {
+		pubKey, err := e2types.BLSPublicKeyFromBytes(pubKeys[i][:])
+		if err != nil {
+			return false, errors.Wrap(err, fmt.Sprintf("invalid public key %#x", pubKeys[i]))
+		}
+		if aggregatePubKey == nil {
+			aggregatePubKey = pubKey
+		} else {
+			aggregatePubKey.Aggregate(pubKey)
+		}
+	}
+
+	container := &phase0.SigningData{
+		ObjectRoot: root,
+		Domain:     domain,
+	}
+	signingRoot, err := container.HashTreeRoot()
+	if err != nil {
+		return false, errors.Wrap(err, "failed to generate signing root")
+	}
+
+	return signature.Verify(signingRoot[:], aggregatePubKey), nil
+}
+
+// IsAttestationAggregator returns true if the selection proof selects its signer as an
+// aggregator for a beacon committee of the given size.
+func IsAttestationAggregator(committeeSize uint64, targetAggregatorsPerCommittee uint64, selectionProof phase0.BLSSignature) bool {
+	modulo := uint64(1)
+	if targetAggregatorsPerCommittee > 0 && committeeSize/targetAggregatorsPerCommittee > 1 {
+		modulo = committeeSize / targetAggregatorsPerCommittee
+	}
+	hash := sha256.Sum256(selectionProof[:])
+
+	return binary.LittleEndian.Uint64(hash[:8])%modulo == 0
+}
+
+// IsSyncCommitteeAggregator returns true if the selection proof selects its signer as an
+// aggregator for a sync subcommittee, given the sync committee parameters of the chain.
+func IsSyncCommitteeAggregator(syncCommitteeSize uint64,
+	syncCommitteeSubnetCount uint64,
+	targetAggregatorsPerSyncSubcommittee uint64,
+	selectionProof phase0.BLSSignature,
+) bool {
+	modulo := uint64(1)
+	if syncCommitteeSubnetCount > 0 && targetAggregatorsPerSyncSubcommittee > 0 &&
+		syncCommitteeSize/syncCommitteeSubnetCount/targetAggregatorsPerSyncSubcommittee > 1 {
+		modulo = syncCommitteeSize / syncCommitteeSubnetCount / targetAggregatorsPerSyncSubcommittee
+	}
+	hash := sha256.Sum256(selectionProof[:])
+
+	return binary.LittleEndian.Uint64(hash[:8])%modulo == 0
+}
+
+// SpecUint64 obtains a uint64 value from a beacon node specification.
+func SpecUint64(spec map[string]interface{}, name string) (uint64, error) {
+	tmp, exists := spec[name]
+	if !exists {
+		return 0, fmt.Errorf("spec does not contain %s", name)
+	}
+	val, isUint64 := tmp.(uint64)
+	if !isUint64 {
+		return 0, fmt.Errorf("spec returned non-integer value for %s", name)
+	}
+
+	return val, nil
+}
+
+// SpecDomainType obtains a domain type from a beacon node specification.
+func SpecDomainType(spec map[string]interface{}, name string) (phase0.DomainType, error) {
+	tmp, exists := spec[name]
+	if !exists {
+		return phase0.DomainType{}, fmt.Errorf("spec does not contain %s", name)
+	}
+	val, isDomainType := tmp.(phase0.DomainType)
+	if !isDomainType {
+		return phase0.DomainType{}, fmt.Errorf("spec returned non-domain type value for %s", name)
+	}
+
+	return val, nil
+}
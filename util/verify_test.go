@@ -0,0 +1,214 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util_test
+
+import (
+	"testing"
+
+	"github.com/aaron-alderman/ethdo/util"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/prysmaticlabs/go-bitfield"
+	"github.com/stretchr/testify/require"
+	e2types "github.com/wealdtech/go-eth2-types/v2"
+)
+
+func TestVerifyAggregateSignature(t *testing.T) {
+	require.NoError(t, e2types.InitBLS())
+
+	root := phase0.Root{0x01}
+	domain := phase0.Domain{0x02}
+	container := &phase0.SigningData{
+		ObjectRoot: root,
+		Domain:     domain,
+	}
+	signingRoot, err := container.HashTreeRoot()
+	require.NoError(t, err)
+
+	pubKeys := make([]phase0.BLSPubKey, 3)
+	var signature *e2types.BLSSignature
+	for i := range pubKeys {
+		key, err := e2types.GenerateBLSPrivateKey()
+		require.NoError(t, err)
+		copy(pubKeys[i][:], key.PublicKey().Marshal())
+		sig := key.Sign(signingRoot[:]).(*e2types.BLSSignature)
+		if signature == nil {
+			signature = sig
+		} else {
+			signature = e2types.AggregateSignatures([]e2types.Signature{signature, sig})
+		}
+	}
+
+	tests := []struct {
+		name    string
+		pubKeys []phase0.BLSPubKey
+		root    phase0.Root
+		res     bool
+		err     string
+	}{
+		{
+			name: "PubKeysMissing",
+			root: root,
+			err:  "no public keys supplied",
+		},
+		{
+			name:    "PubKeyInvalid",
+			pubKeys: []phase0.BLSPubKey{{}},
+			root:    root,
+			err:     "invalid public key 0x000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000: failed to deserialize public key: err blsPublicKeyDeserialize 000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000",
+		},
+		{
+			name:    "PubKeysPartial",
+			pubKeys: pubKeys[:2],
+			root:    root,
+			res:     false,
+		},
+		{
+			name:    "RootWrong",
+			pubKeys: pubKeys,
+			root:    phase0.Root{0x03},
+			res:     false,
+		},
+		{
+			name:    "Good",
+			pubKeys: pubKeys,
+			root:    root,
+			res:     true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			res, err := util.VerifyAggregateSignature(signature, test.pubKeys, test.root, domain)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, test.res, res)
+			}
+		})
+	}
+}
+
+func TestIsAttestationAggregator(t *testing.T) {
+	// The hash of this selection proof is not a multiple of 2^20, but every proof selects an aggregator with a modulo of 1.
+	selectionProof := phase0.BLSSignature{0x01}
+
+	tests := []struct {
+		name          string
+		committeeSize uint64
+		target        uint64
+		res           bool
+	}{
+		{
+			name:          "SmallCommittee",
+			committeeSize: 16,
+			target:        16,
+			res:           true,
+		},
+		{
+			name:          "TargetZero",
+			committeeSize: 128,
+			res:           true,
+		},
+		{
+			name:          "LargeCommittee",
+			committeeSize: 1 << 20,
+			target:        1,
+			res:           false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.res, util.IsAttestationAggregator(test.committeeSize, test.target, selectionProof))
+		})
+	}
+}
+
+func TestIsSyncCommitteeAggregator(t *testing.T) {
+	// The hash of this selection proof is not a multiple of 2^20, but every proof selects an aggregator with a modulo of 1.
+	selectionProof := phase0.BLSSignature{0x01}
+
+	tests := []struct {
+		name        string
+		size        uint64
+		subnetCount uint64
+		target      uint64
+		res         bool
+	}{
+		{
+			name:        "SmallSubcommittee",
+			size:        64,
+			subnetCount: 4,
+			target:      16,
+			res:         true,
+		},
+		{
+			name: "ParametersZero",
+			size: 512,
+			res:  true,
+		},
+		{
+			name:        "LargeSubcommittee",
+			size:        1 << 22,
+			subnetCount: 4,
+			target:      1,
+			res:         false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.res, util.IsSyncCommitteeAggregator(test.size, test.subnetCount, test.target, selectionProof))
+		})
+	}
+}
+
+func TestSpecValues(t *testing.T) {
+	spec := map[string]interface{}{
+		"SLOTS_PER_EPOCH":        uint64(32),
+		"DOMAIN_BEACON_ATTESTER": phase0.DomainType{0x01, 0x00, 0x00, 0x00},
+		"CONFIG_NAME":            "mainnet",
+	}
+
+	slotsPerEpoch, err := util.SpecUint64(spec, "SLOTS_PER_EPOCH")
+	require.NoError(t, err)
+	require.Equal(t, uint64(32), slotsPerEpoch)
+	_, err = util.SpecUint64(spec, "MISSING")
+	require.EqualError(t, err, "spec does not contain MISSING")
+	_, err = util.SpecUint64(spec, "CONFIG_NAME")
+	require.EqualError(t, err, "spec returned non-integer value for CONFIG_NAME")
+
+	domainType, err := util.SpecDomainType(spec, "DOMAIN_BEACON_ATTESTER")
+	require.NoError(t, err)
+	require.Equal(t, phase0.DomainType{0x01, 0x00, 0x00, 0x00}, domainType)
+	_, err = util.SpecDomainType(spec, "MISSING")
+	require.EqualError(t, err, "spec does not contain MISSING")
+	_, err = util.SpecDomainType(spec, "SLOTS_PER_EPOCH")
+	require.EqualError(t, err, "spec returned non-domain type value for SLOTS_PER_EPOCH")
+}
+
+func TestAttestingIndices(t *testing.T) {
+	committee := []phase0.ValidatorIndex{10, 11, 12, 13}
+	bits := bitfield.NewBitlist(4)
+	bits.SetBitAt(1, true)
+	bits.SetBitAt(3, true)
+
+	res, err := util.AttestingIndices(bits, committee)
+	require.NoError(t, err)
+	require.Equal(t, []phase0.ValidatorIndex{11, 13}, res)
+
+	_, err = util.AttestingIndices(bitfield.NewBitlist(3), committee)
+	require.EqualError(t, err, "aggregation bits length 3 does not match committee size 4")
+}
@@ -0,0 +1,299 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"context"
+	"time"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/altair"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+)
+
+// wrappedService passes all requests through to an underlying service.  It is
+// embedded by services that alter the behaviour of a subset of requests, and
+// provides the remaining requests unchanged.
+type wrappedService struct {
+	eth2client.Service
+}
+
+// beaconNodeClients returns the individual beacon nodes behind the underlying service.
+func (s *wrappedService) beaconNodeClients() []eth2client.Service {
+	return beaconNodeClients(s.Service)
+}
+
+// AttesterDuties implements eth2client.AttesterDutiesProvider.
+func (s *wrappedService) AttesterDuties(ctx context.Context, epoch phase0.Epoch, validatorIndices []phase0.ValidatorIndex) ([]*apiv1.AttesterDuty, error) {
+	provider, isProvider := s.Service.(eth2client.AttesterDutiesProvider)
+	if !isProvider {
+		return nil, errors.New("client does not support AttesterDuties")
+	}
+	return provider.AttesterDuties(ctx, epoch, validatorIndices)
+}
+
+// BeaconBlockHeader implements eth2client.BeaconBlockHeadersProvider.
+func (s *wrappedService) BeaconBlockHeader(ctx context.Context, blockID string) (*apiv1.BeaconBlockHeader, error) {
+	provider, isProvider := s.Service.(eth2client.BeaconBlockHeadersProvider)
+	if !isProvider {
+		return nil, errors.New("client does not support BeaconBlockHeader")
+	}
+	return provider.BeaconBlockHeader(ctx, blockID)
+}
+
+// BeaconCommittees implements eth2client.BeaconCommitteesProvider.
+func (s *wrappedService) BeaconCommittees(ctx context.Context, stateID string) ([]*apiv1.BeaconCommittee, error) {
+	provider, isProvider := s.Service.(eth2client.BeaconCommitteesProvider)
+	if !isProvider {
+		return nil, errors.New("client does not support BeaconCommittees")
+	}
+	return provider.BeaconCommittees(ctx, stateID)
+}
+
+// BeaconCommitteesAtEpoch implements eth2client.BeaconCommitteesProvider.
+func (s *wrappedService) BeaconCommitteesAtEpoch(ctx context.Context, stateID string, epoch phase0.Epoch) ([]*apiv1.BeaconCommittee, error) {
+	provider, isProvider := s.Service.(eth2client.BeaconCommitteesProvider)
+	if !isProvider {
+		return nil, errors.New("client does not support BeaconCommitteesAtEpoch")
+	}
+	return provider.BeaconCommitteesAtEpoch(ctx, stateID, epoch)
+}
+
+// BeaconState implements eth2client.BeaconStateProvider.
+func (s *wrappedService) BeaconState(ctx context.Context, stateID string) (*spec.VersionedBeaconState, error) {
+	provider, isProvider := s.Service.(eth2client.BeaconStateProvider)
+	if !isProvider {
+		return nil, errors.New("client does not support BeaconState")
+	}
+	return provider.BeaconState(ctx, stateID)
+}
+
+// Finality implements eth2client.FinalityProvider.
+func (s *wrappedService) Finality(ctx context.Context, stateID string) (*apiv1.Finality, error) {
+	provider, isProvider := s.Service.(eth2client.FinalityProvider)
+	if !isProvider {
+		return nil, errors.New("client does not support Finality")
+	}
+	return provider.Finality(ctx, stateID)
+}
+
+// Fork implements eth2client.ForkProvider.
+func (s *wrappedService) Fork(ctx context.Context, stateID string) (*phase0.Fork, error) {
+	provider, isProvider := s.Service.(eth2client.ForkProvider)
+	if !isProvider {
+		return nil, errors.New("client does not support Fork")
+	}
+	return provider.Fork(ctx, stateID)
+}
+
+// ForkSchedule implements eth2client.ForkScheduleProvider.
+func (s *wrappedService) ForkSchedule(ctx context.Context) ([]*phase0.Fork, error) {
+	provider, isProvider := s.Service.(eth2client.ForkScheduleProvider)
+	if !isProvider {
+		return nil, errors.New("client does not support ForkSchedule")
+	}
+	return provider.ForkSchedule(ctx)
+}
+
+// Genesis implements eth2client.GenesisProvider.
+func (s *wrappedService) Genesis(ctx context.Context) (*apiv1.Genesis, error) {
+	provider, isProvider := s.Service.(eth2client.GenesisProvider)
+	if !isProvider {
+		return nil, errors.New("client does not support Genesis")
+	}
+	return provider.Genesis(ctx)
+}
+
+// NodeSyncing implements eth2client.NodeSyncingProvider.
+func (s *wrappedService) NodeSyncing(ctx context.Context) (*apiv1.SyncState, error) {
+	provider, isProvider := s.Service.(eth2client.NodeSyncingProvider)
+	if !isProvider {
+		return nil, errors.New("client does not support NodeSyncing")
+	}
+	return provider.NodeSyncing(ctx)
+}
+
+// ProposerDuties implements eth2client.ProposerDutiesProvider.
+func (s *wrappedService) ProposerDuties(ctx context.Context, epoch phase0.Epoch, validatorIndices []phase0.ValidatorIndex) ([]*apiv1.ProposerDuty, error) {
+	provider, isProvider := s.Service.(eth2client.ProposerDutiesProvider)
+	if !isProvider {
+		return nil, errors.New("client does not support ProposerDuties")
+	}
+	return provider.ProposerDuties(ctx, epoch, validatorIndices)
+}
+
+// SignedBeaconBlock implements eth2client.SignedBeaconBlockProvider.
+func (s *wrappedService) SignedBeaconBlock(ctx context.Context, blockID string) (*spec.VersionedSignedBeaconBlock, error) {
+	provider, isProvider := s.Service.(eth2client.SignedBeaconBlockProvider)
+	if !isProvider {
+		return nil, errors.New("client does not support SignedBeaconBlock")
+	}
+	return provider.SignedBeaconBlock(ctx, blockID)
+}
+
+// Spec implements eth2client.SpecProvider.
+func (s *wrappedService) Spec(ctx context.Context) (map[string]interface{}, error) {
+	provider, isProvider := s.Service.(eth2client.SpecProvider)
+	if !isProvider {
+		return nil, errors.New("client does not support Spec")
+	}
+	return provider.Spec(ctx)
+}
+
+// SyncCommittee implements eth2client.SyncCommitteesProvider.
+func (s *wrappedService) SyncCommittee(ctx context.Context, stateID string) (*apiv1.SyncCommittee, error) {
+	provider, isProvider := s.Service.(eth2client.SyncCommitteesProvider)
+	if !isProvider {
+		return nil, errors.New("client does not support SyncCommittee")
+	}
+	return provider.SyncCommittee(ctx, stateID)
+}
+
+// SyncCommitteeAtEpoch implements eth2client.SyncCommitteesProvider.
+func (s *wrappedService) SyncCommitteeAtEpoch(ctx context.Context, stateID string, epoch phase0.Epoch) (*apiv1.SyncCommittee, error) {
+	provider, isProvider := s.Service.(eth2client.SyncCommitteesProvider)
+	if !isProvider {
+		return nil, errors.New("client does not support SyncCommitteeAtEpoch")
+	}
+	return provider.SyncCommitteeAtEpoch(ctx, stateID, epoch)
+}
+
+// SyncCommitteeDuties implements eth2client.SyncCommitteeDutiesProvider.
+func (s *wrappedService) SyncCommitteeDuties(ctx context.Context, epoch phase0.Epoch, validatorIndices []phase0.ValidatorIndex) ([]*apiv1.SyncCommitteeDuty, error) {
+	provider, isProvider := s.Service.(eth2client.SyncCommitteeDutiesProvider)
+	if !isProvider {
+		return nil, errors.New("client does not support SyncCommitteeDuties")
+	}
+	return provider.SyncCommitteeDuties(ctx, epoch, validatorIndices)
+}
+
+// ValidatorBalances implements eth2client.ValidatorBalancesProvider.
+func (s *wrappedService) ValidatorBalances(ctx context.Context, stateID string, validatorIndices []phase0.ValidatorIndex) (map[phase0.ValidatorIndex]phase0.Gwei, error) {
+	provider, isProvider := s.Service.(eth2client.ValidatorBalancesProvider)
+	if !isProvider {
+		return nil, errors.New("client does not support ValidatorBalances")
+	}
+	return provider.ValidatorBalances(ctx, stateID, validatorIndices)
+}
+
+// Validators implements eth2client.ValidatorsProvider.
+func (s *wrappedService) Validators(ctx context.Context, stateID string, validatorIndices []phase0.ValidatorIndex) (map[phase0.ValidatorIndex]*apiv1.Validator, error) {
+	provider, isProvider := s.Service.(eth2client.ValidatorsProvider)
+	if !isProvider {
+		return nil, errors.New("client does not support Validators")
+	}
+	return provider.Validators(ctx, stateID, validatorIndices)
+}
+
+// ValidatorsByPubKey implements eth2client.ValidatorsProvider.
+func (s *wrappedService) ValidatorsByPubKey(ctx context.Context, stateID string, validatorPubKeys []phase0.BLSPubKey) (map[phase0.ValidatorIndex]*apiv1.Validator, error) {
+	provider, isProvider := s.Service.(eth2client.ValidatorsProvider)
+	if !isProvider {
+		return nil, errors.New("client does not support ValidatorsByPubKey")
+	}
+	return provider.ValidatorsByPubKey(ctx, stateID, validatorPubKeys)
+}
+
+// Domain implements eth2client.DomainProvider.
+func (s *wrappedService) Domain(ctx context.Context, domainType phase0.DomainType, epoch phase0.Epoch) (phase0.Domain, error) {
+	provider, isProvider := s.Service.(eth2client.DomainProvider)
+	if !isProvider {
+		return phase0.Domain{}, errors.New("client does not support Domain")
+	}
+	return provider.Domain(ctx, domainType, epoch)
+}
+
+// GenesisTime implements eth2client.GenesisTimeProvider.
+func (s *wrappedService) GenesisTime(ctx context.Context) (time.Time, error) {
+	provider, isProvider := s.Service.(eth2client.GenesisTimeProvider)
+	if !isProvider {
+		return time.Time{}, errors.New("client does not support GenesisTime")
+	}
+	return provider.GenesisTime(ctx)
+}
+
+// NodeVersion implements eth2client.NodeVersionProvider.
+func (s *wrappedService) NodeVersion(ctx context.Context) (string, error) {
+	provider, isProvider := s.Service.(eth2client.NodeVersionProvider)
+	if !isProvider {
+		return "", errors.New("client does not support NodeVersion")
+	}
+	return provider.NodeVersion(ctx)
+}
+
+// SlotDuration implements eth2client.SlotDurationProvider.
+func (s *wrappedService) SlotDuration(ctx context.Context) (time.Duration, error) {
+	provider, isProvider := s.Service.(eth2client.SlotDurationProvider)
+	if !isProvider {
+		return 0, errors.New("client does not support SlotDuration")
+	}
+	return provider.SlotDuration(ctx)
+}
+
+// SlotsPerEpoch implements eth2client.SlotsPerEpochProvider.
+func (s *wrappedService) SlotsPerEpoch(ctx context.Context) (uint64, error) {
+	provider, isProvider := s.Service.(eth2client.SlotsPerEpochProvider)
+	if !isProvider {
+		return 0, errors.New("client does not support SlotsPerEpoch")
+	}
+	return provider.SlotsPerEpoch(ctx)
+}
+
+// FarFutureEpoch implements eth2client.FarFutureEpochProvider.
+func (s *wrappedService) FarFutureEpoch(ctx context.Context) (phase0.Epoch, error) {
+	provider, isProvider := s.Service.(eth2client.FarFutureEpochProvider)
+	if !isProvider {
+		return 0, errors.New("client does not support FarFutureEpoch")
+	}
+	return provider.FarFutureEpoch(ctx)
+}
+
+// DepositContract implements eth2client.DepositContractProvider.
+func (s *wrappedService) DepositContract(ctx context.Context) (*apiv1.DepositContract, error) {
+	provider, isProvider := s.Service.(eth2client.DepositContractProvider)
+	if !isProvider {
+		return nil, errors.New("client does not support DepositContract")
+	}
+	return provider.DepositContract(ctx)
+}
+
+// Events implements eth2client.EventsProvider.
+func (s *wrappedService) Events(ctx context.Context, topics []string, handler eth2client.EventHandlerFunc) error {
+	provider, isProvider := s.Service.(eth2client.EventsProvider)
+	if !isProvider {
+		return errors.New("client does not support Events")
+	}
+	return provider.Events(ctx, topics, handler)
+}
+
+// SubmitVoluntaryExit implements eth2client.VoluntaryExitSubmitter.
+func (s *wrappedService) SubmitVoluntaryExit(ctx context.Context, voluntaryExit *phase0.SignedVoluntaryExit) error {
+	provider, isProvider := s.Service.(eth2client.VoluntaryExitSubmitter)
+	if !isProvider {
+		return errors.New("client does not support SubmitVoluntaryExit")
+	}
+	return provider.SubmitVoluntaryExit(ctx, voluntaryExit)
+}
+
+// SubmitSyncCommitteeMessages implements eth2client.SyncCommitteeMessagesSubmitter.
+func (s *wrappedService) SubmitSyncCommitteeMessages(ctx context.Context, messages []*altair.SyncCommitteeMessage) error {
+	provider, isProvider := s.Service.(eth2client.SyncCommitteeMessagesSubmitter)
+	if !isProvider {
+		return errors.New("client does not support SubmitSyncCommitteeMessages")
+	}
+	return provider.SubmitSyncCommitteeMessages(ctx, messages)
+}